@@ -3,6 +3,7 @@ package component
 import (
 	"fmt"
 	"html/template"
+	"io"
 	"strings"
 
 	st "github.com/nervatura/component/pkg/static"
@@ -32,7 +33,7 @@ type Application struct {
 	Title string `json:"title"`
 	/*
 		The theme of the application.
-		[Theme] variable constants: [ThemeLight], [ThemeDark]. Default value: [ThemeLight]
+		Any registered [Theme] value, see more [RegisterTheme]. Default value: [ThemeLight]
 	*/
 	Theme string `json:"theme"`
 	/*
@@ -57,6 +58,13 @@ type Application struct {
 	SpinnerNotModal bool `json:"spinner_notmodal"`
 	// Application element synchronization mode. Default value: [SyncQueueAll]
 	ComponentSync string `json:"component_sync"`
+	// Includes the [IconSprite] symbols of all registered icons. It is always included in [IconSpriteMode].
+	IconSprite bool `json:"icon_sprite"`
+	/*
+		The event stream URL of an [SSEHub]. The pushed components are swapped by the htmx sse extension,
+		the Script values must also contain it. Example: "/sse?session=SESSION0123456789"
+	*/
+	SSEConnect string `json:"sse_connect"`
 }
 
 /*
@@ -74,6 +82,8 @@ func (app *Application) Properties() ut.IM {
 			"main":             app.MainComponent,
 			"spinner_notmodal": app.SpinnerNotModal,
 			"component_sync":   app.ComponentSync,
+			"icon_sprite":      app.IconSprite,
+			"sse_connect":      app.SSEConnect,
 		})
 }
 
@@ -184,6 +194,10 @@ func (app *Application) SetProperty(propName string, propValue interface{}) inte
 			app.ComponentSync = app.Validation(propName, propValue).(string)
 			return app.ComponentSync
 		},
+		"sse_connect": func() interface{} {
+			app.SSEConnect = ut.ToString(propValue, "")
+			return app.SSEConnect
+		},
 	}
 	if _, found := pm[propName]; found {
 		return pm[propName]()
@@ -219,45 +233,71 @@ func (app *Application) OnRequest(te TriggerEvent) (re ResponseEvent) {
 	return re
 }
 
-func (app *Application) getComponent() (html template.HTML, err error) {
+func (app *Application) getComponent(w io.Writer) (err error) {
 	if app.MainComponent != nil {
-		return app.MainComponent.Render()
+		return RenderTo(w, app.MainComponent)
 	}
-	return html, err
+	return err
 }
 
 /*
 Based on the values, it will generate the html code of the [Application] or return with an error message.
 */
 func (app *Application) Render() (html template.HTML, err error) {
-	app.InitProps(app)
-	spinner := Spinner{NoModal: app.SpinnerNotModal}
+	return RenderHTML(app)
+}
 
-	funcMap := map[string]any{
-		"styleMap": func() bool {
-			return len(app.Style) > 0
-		},
-		"customClass": func() string {
-			return strings.Join(app.Class, " ")
-		},
-		"spinner": func() (template.HTML, error) {
-			return spinner.Render()
-		},
-		"main": func() (html template.HTML, err error) {
-			return app.getComponent()
-		},
-	}
-	headerKeys := func() string {
+// the render data of the [Application] template
+type applicationRender struct {
+	*Application
+	spinner Spinner
+	hw      *ut.HTMLWriter
+}
+
+// [Application] template functions
+var applicationFuncMap = map[string]any{
+	"styleMap": func(app *applicationRender) bool {
+		return len(app.Style) > 0
+	},
+	"customClass": func(app *applicationRender) string {
+		return strings.Join(app.Class, " ")
+	},
+	"spinner": func(app *applicationRender) (template.HTML, error) {
+		return app.spinner.Render()
+	},
+	"main": func(app *applicationRender) (html template.HTML, err error) {
+		return html, app.getComponent(app.hw)
+	},
+	"headerKeys": func(app *applicationRender) template.HTMLAttr {
 		values := []string{}
 		for key, value := range app.Header {
 			values = append(values, fmt.Sprintf(`"%s":"%s"`, key, value))
 		}
 		if len(values) > 0 {
-			return fmt.Sprintf(`hx-headers='{%s}'`, strings.Join(values, `,`))
+			return template.HTMLAttr(fmt.Sprintf(`hx-headers='{%s}'`, strings.Join(values, `,`)))
 		}
 		return ""
-	}
-	tpl := fmt.Sprintf(`<!DOCTYPE html>
+	},
+	"sseEvent": func() string {
+		return SSEEventComponent
+	},
+	"iconSprite": func(app *applicationRender) template.HTML {
+		if app.IconSprite || IconSpriteMode {
+			return IconSprite()
+		}
+		return ""
+	},
+}
+
+/*
+Based on the values, it will write the html code of the [Application] into the writer or return with an error message.
+*/
+func (app *Application) RenderTo(w io.Writer) (err error) {
+	app.InitProps(app)
+	spinner := Spinner{NoModal: app.SpinnerNotModal}
+	hw := ut.NewHTMLWriter(w)
+
+	tpl := `<!DOCTYPE html>
 	<html lang="en">
 		<head>
 			<meta charset="utf-8">
@@ -272,13 +312,17 @@ func (app *Application) Render() (html template.HTML, err error) {
 		</head>
 		<body>
 		<div id="{{ .Id }}" theme="{{ .Theme }}" 
-		{{ if styleMap }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }} 
-		hx-ext="remove-me" %s {{ if ne .ComponentSync "none" }} hx-sync="{{ .ComponentSync }}"{{ end }} class="{{ customClass }}">
-		<div id="toast-msg"></div><div>{{ spinner }}</div>
-		{{ main }}
+		{{ if styleMap $ }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }} 
+		hx-ext="remove-me{{ if ne .SSEConnect "" }}, sse{{ end }}" {{ if ne .SSEConnect "" }}sse-connect="{{ .SSEConnect }}"{{ end }} {{ headerKeys $ }} {{ if ne .ComponentSync "none" }} hx-sync="{{ .ComponentSync }}"{{ end }} class="{{ customClass $ }}">
+		{{ iconSprite $ }}<div id="toast-msg"></div><div>{{ spinner $ }}</div>
+		{{ if ne .SSEConnect "" }}<div sse-swap="{{ sseEvent }}"></div>{{ end }}
+		{{ main $ }}
 		</div>
 		</body>
-	</html>`, headerKeys())
+	</html>`
 
-	return ut.TemplateBuilder("application", tpl, funcMap, app)
+	if err = ut.CachedTemplateWriter(hw, "application", tpl, applicationFuncMap, &applicationRender{Application: app, spinner: spinner, hw: hw}); err == nil {
+		err = hw.Close()
+	}
+	return err
 }
@@ -1,6 +1,7 @@
 package component
 
 import (
+	"io"
 	"reflect"
 	"testing"
 
@@ -16,12 +17,20 @@ func TestApplication_Render(t *testing.T) {
 		Script        []string
 		HeadLink      []HeadLink
 		MainComponent ClientComponent
+		SSEConnect    string
 	}
 	tests := []struct {
 		name    string
 		fields  fields
 		wantErr bool
 	}{
+		{
+			name: "sse",
+			fields: fields{
+				SSEConnect: "/sse?session=SESSION",
+			},
+			wantErr: false,
+		},
 		{
 			name: "header",
 			fields: fields{
@@ -47,6 +56,7 @@ func TestApplication_Render(t *testing.T) {
 				Script:        tt.fields.Script,
 				HeadLink:      tt.fields.HeadLink,
 				MainComponent: tt.fields.MainComponent,
+				SSEConnect:    tt.fields.SSEConnect,
 			}
 			_, err := app.Render()
 			if (err != nil) != tt.wantErr {
@@ -233,6 +243,14 @@ func TestApplication_SetProperty(t *testing.T) {
 			},
 			want: &Login{},
 		},
+		{
+			name: "sse_connect",
+			args: args{
+				propName:  "sse_connect",
+				propValue: "/sse?session=SESSION",
+			},
+			want: "/sse?session=SESSION",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -342,7 +360,7 @@ func TestApplication_getComponent(t *testing.T) {
 				HeadLink:      tt.fields.HeadLink,
 				MainComponent: tt.fields.MainComponent,
 			}
-			_, err := app.getComponent()
+			err := app.getComponent(io.Discard)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Application.getComponent() error = %v, wantErr %v", err, tt.wantErr)
 				return
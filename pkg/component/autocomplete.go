@@ -0,0 +1,609 @@
+package component
+
+import (
+	"html/template"
+	"io"
+	"slices"
+	"strings"
+
+	ut "github.com/nervatura/component/pkg/util"
+)
+
+// [Autocomplete] constants
+const (
+	ComponentTypeAutocomplete = "autocomplete"
+
+	AutocompleteEventSearch   = "autocomplete_search"
+	AutocompleteEventSelected = "autocomplete_selected"
+	AutocompleteEventChange   = "autocomplete_change"
+
+	AutocompleteDefaultDelay = 300
+	AutocompleteDefaultLimit = 10
+
+	AutocompleteKeyDown   = "ArrowDown"
+	AutocompleteKeyUp     = "ArrowUp"
+	AutocompleteKeyEnter  = "Enter"
+	AutocompleteKeyEscape = "Escape"
+)
+
+/*
+Creates a combobox input control. The suggestions are queried from the server as the user types.
+
+The suggestions are loaded from the registered [LookupProvider] of the Lookup value, or the OnResponse function
+can set the options property on the [AutocompleteEventSearch] event. The keyboard navigation
+(ArrowDown, ArrowUp, Enter, Escape) is handled by the component and does not call the OnResponse function.
+
+For example:
+
+	&Autocomplete{
+	  BaseComponent: BaseComponent{
+	    Id:           "id_autocomplete_default",
+	    EventURL:     "/event",
+	    RequestValue: parent_component.GetProperty("request_value").(map[string]ut.IM),
+	    RequestMap:   parent_component.GetProperty("request_map").(map[string]ClientComponent),
+	  },
+	  Lookup:   "product",
+	  FreeText: true,
+	}
+*/
+type Autocomplete struct {
+	BaseComponent
+	// The selected value. In case of FreeText, the text of the input.
+	Value SelectOption `json:"value"`
+	// The selected values (chips) when the Multiple is true
+	Values []SelectOption `json:"values"`
+	// The current text of the input element
+	Text string `json:"text"`
+	// The current suggestions
+	Options []SelectOption `json:"options"`
+	// The name of a registered [LookupProvider] of the suggestions
+	Lookup string `json:"lookup"`
+	// Any text can be entered, otherwise only a suggestion can be selected
+	FreeText bool `json:"free_text"`
+	// Multiple values can be selected
+	Multiple bool `json:"multiple"`
+	// The index of the highlighted suggestion. -1: no highlighted item
+	ActiveIndex int64 `json:"active_index"`
+	// Show the suggestion list
+	ShowList bool `json:"show_list"`
+	// The minimum length of the text to start the search. Default value: 1
+	MinLength int64 `json:"min_length"`
+	// The maximum number of the suggestions. Default value: [AutocompleteDefaultLimit]
+	Limit int64 `json:"limit"`
+	// The debounce delay of the search in milliseconds. Default value: [AutocompleteDefaultDelay]
+	Delay int64 `json:"delay"`
+	// Specifies a short hint that describes the expected value of the input element
+	Placeholder string `json:"placeholder"`
+	// The HTML aria-label attribute of the component
+	Label string `json:"label"`
+	// Specifies that the input should be disabled
+	Disabled bool `json:"disabled"`
+	// Specifies that the input element should automatically get focus when the page loads
+	AutoFocus bool `json:"auto_focus"`
+	// Full width input (100%)
+	Full bool `json:"full"`
+}
+
+/*
+Returns all properties of the [Autocomplete]
+*/
+func (acp *Autocomplete) Properties() ut.IM {
+	return ut.MergeIM(
+		acp.BaseComponent.Properties(),
+		ut.IM{
+			"value":        acp.Value,
+			"values":       acp.Values,
+			"text":         acp.Text,
+			"options":      acp.Options,
+			"lookup":       acp.Lookup,
+			"free_text":    acp.FreeText,
+			"multiple":     acp.Multiple,
+			"active_index": acp.ActiveIndex,
+			"show_list":    acp.ShowList,
+			"min_length":   acp.MinLength,
+			"limit":        acp.Limit,
+			"delay":        acp.Delay,
+			"placeholder":  acp.Placeholder,
+			"label":        acp.Label,
+			"disabled":     acp.Disabled,
+			"auto_focus":   acp.AutoFocus,
+			"full":         acp.Full,
+		})
+}
+
+/*
+Returns the value of the property of the [Autocomplete] with the specified name.
+*/
+func (acp *Autocomplete) GetProperty(propName string) interface{} {
+	return acp.Properties()[propName]
+}
+
+/*
+It checks the value given to the property of the [Autocomplete] and always returns a valid value
+*/
+func (acp *Autocomplete) Validation(propName string, propValue interface{}) interface{} {
+	pm := map[string]func() interface{}{
+		"value": func() interface{} {
+			if value, valid := propValue.(SelectOption); valid {
+				return value
+			}
+			if valueOptions, found := propValue.(ut.IM); found {
+				return SelectOption{
+					Value: ut.ToString(valueOptions["value"], ""),
+					Text:  ut.ToString(valueOptions["text"], ""),
+				}
+			}
+			return SelectOption{}
+		},
+		"values": func() interface{} {
+			return SelectOptionRangeValidation(propValue, []SelectOption{})
+		},
+		"options": func() interface{} {
+			return SelectOptionRangeValidation(propValue, []SelectOption{})
+		},
+		"active_index": func() interface{} {
+			value := ut.ToInteger(propValue, 0)
+			if value < 0 || value >= int64(len(acp.Options)) {
+				return int64(-1)
+			}
+			return value
+		},
+		"min_length": func() interface{} {
+			return max(ut.ToInteger(propValue, 1), 1)
+		},
+		"limit": func() interface{} {
+			if value := ut.ToInteger(propValue, 0); value > 0 {
+				return value
+			}
+			return int64(AutocompleteDefaultLimit)
+		},
+		"delay": func() interface{} {
+			if value := ut.ToInteger(propValue, 0); value > 0 {
+				return value
+			}
+			return int64(AutocompleteDefaultDelay)
+		},
+		"target": func() interface{} {
+			acp.SetProperty("id", acp.Id)
+			value := ut.ToString(propValue, acp.Id)
+			if value != "this" && !strings.HasPrefix(value, "#") {
+				value = "#" + value
+			}
+			return value
+		},
+	}
+	if _, found := pm[propName]; found {
+		return pm[propName]()
+	}
+	if acp.BaseComponent.GetProperty(propName) != nil {
+		return acp.BaseComponent.Validation(propName, propValue)
+	}
+	return propValue
+}
+
+/*
+Setting a property of the [Autocomplete] value safely. Checks the entered value.
+In case of an invalid value, the default value will be set.
+*/
+func (acp *Autocomplete) SetProperty(propName string, propValue interface{}) interface{} {
+	pm := map[string]func() interface{}{
+		"value": func() interface{} {
+			acp.Value = acp.Validation(propName, propValue).(SelectOption)
+			return acp.Value
+		},
+		"values": func() interface{} {
+			acp.Values = acp.Validation(propName, propValue).([]SelectOption)
+			return acp.Values
+		},
+		"text": func() interface{} {
+			acp.Text = ut.ToString(propValue, "")
+			return acp.Text
+		},
+		"options": func() interface{} {
+			acp.Options = acp.Validation(propName, propValue).([]SelectOption)
+			return acp.Options
+		},
+		"lookup": func() interface{} {
+			acp.Lookup = ut.ToString(propValue, "")
+			return acp.Lookup
+		},
+		"free_text": func() interface{} {
+			acp.FreeText = ut.ToBoolean(propValue, false)
+			return acp.FreeText
+		},
+		"multiple": func() interface{} {
+			acp.Multiple = ut.ToBoolean(propValue, false)
+			return acp.Multiple
+		},
+		"active_index": func() interface{} {
+			acp.ActiveIndex = acp.Validation(propName, propValue).(int64)
+			return acp.ActiveIndex
+		},
+		"show_list": func() interface{} {
+			acp.ShowList = ut.ToBoolean(propValue, false)
+			return acp.ShowList
+		},
+		"min_length": func() interface{} {
+			acp.MinLength = acp.Validation(propName, propValue).(int64)
+			return acp.MinLength
+		},
+		"limit": func() interface{} {
+			acp.Limit = acp.Validation(propName, propValue).(int64)
+			return acp.Limit
+		},
+		"delay": func() interface{} {
+			acp.Delay = acp.Validation(propName, propValue).(int64)
+			return acp.Delay
+		},
+		"placeholder": func() interface{} {
+			acp.Placeholder = ut.ToString(propValue, "")
+			return acp.Placeholder
+		},
+		"label": func() interface{} {
+			acp.Label = ut.ToString(propValue, "")
+			return acp.Label
+		},
+		"disabled": func() interface{} {
+			acp.Disabled = ut.ToBoolean(propValue, false)
+			return acp.Disabled
+		},
+		"auto_focus": func() interface{} {
+			acp.AutoFocus = ut.ToBoolean(propValue, false)
+			return acp.AutoFocus
+		},
+		"full": func() interface{} {
+			acp.Full = ut.ToBoolean(propValue, false)
+			return acp.Full
+		},
+		"target": func() interface{} {
+			acp.Target = acp.Validation(propName, propValue).(string)
+			return acp.Target
+		},
+	}
+	if _, found := pm[propName]; found {
+		return acp.SetRequestValue(propName, pm[propName](), []string{})
+	}
+	if acp.BaseComponent.GetProperty(propName) != nil {
+		return acp.BaseComponent.SetProperty(propName, propValue)
+	}
+	return propValue
+}
+
+func (acp *Autocomplete) search(text string) ResponseEvent {
+	acp.SetProperty("text", text)
+	if acp.FreeText && !acp.Multiple {
+		acp.SetProperty("value", SelectOption{Value: text, Text: text})
+	}
+	options := []SelectOption{}
+	minLength := acp.Validation("min_length", acp.MinLength).(int64)
+	if acp.Lookup != "" && int64(len([]rune(text))) >= minLength {
+		limit := acp.Validation("limit", acp.Limit).(int64)
+		if rows, err := LookupSearch(acp.RequestValue, acp.Lookup, text, 0, limit); err == nil {
+			provider := LookupMap[acp.Lookup]
+			for _, row := range rows {
+				options = append(options, provider.Option(row))
+			}
+		}
+	}
+	acp.SetProperty("options", options)
+	evt := ResponseEvent{Trigger: acp, TriggerName: acp.Name, Name: AutocompleteEventSearch, Value: text}
+	if acp.OnResponse != nil {
+		evt = acp.OnResponse(evt)
+	}
+	acp.SetProperty("show_list", len(acp.Options) > 0)
+	activeIndex := int64(-1)
+	if !acp.FreeText {
+		activeIndex = 0
+	}
+	acp.SetProperty("active_index", activeIndex)
+	return evt
+}
+
+func (acp *Autocomplete) navigate(key string) ResponseEvent {
+	count := int64(len(acp.Options))
+	switch key {
+	case AutocompleteKeyDown:
+		acp.SetProperty("active_index", (acp.ActiveIndex+1)%max(count, 1))
+		acp.SetProperty("show_list", count > 0)
+	case AutocompleteKeyUp:
+		index := acp.ActiveIndex - 1
+		if index < 0 {
+			index = count - 1
+		}
+		acp.SetProperty("active_index", index)
+		acp.SetProperty("show_list", count > 0)
+	default:
+		acp.SetProperty("show_list", false)
+		if !acp.FreeText && !acp.Multiple {
+			acp.SetProperty("text", acp.Value.Text)
+		}
+	}
+	return ResponseEvent{Trigger: acp, TriggerName: acp.Name, Name: AutocompleteEventSearch, Value: acp.Text}
+}
+
+func (acp *Autocomplete) selectOption(option SelectOption, evtName string) ResponseEvent {
+	var value any = option
+	if acp.Multiple {
+		if !slices.ContainsFunc(acp.Values, func(opt SelectOption) bool {
+			return opt.Value == option.Value
+		}) {
+			acp.SetProperty("values", append(slices.Clone(acp.Values), option))
+		}
+		acp.SetProperty("text", "")
+		value = acp.Values
+	} else {
+		acp.SetProperty("value", option)
+		acp.SetProperty("text", option.Text)
+	}
+	acp.SetProperty("show_list", false)
+	acp.SetProperty("options", []SelectOption{})
+	evt := ResponseEvent{Trigger: acp, TriggerName: acp.Name, Name: evtName, Value: value}
+	if acp.OnResponse != nil {
+		return acp.OnResponse(evt)
+	}
+	return evt
+}
+
+func (acp *Autocomplete) response(evt ResponseEvent) (re ResponseEvent) {
+	index := ut.ToInteger(ut.ToIM(evt.Trigger.GetProperty("data"), ut.IM{})["index"], 0)
+	switch evt.TriggerName {
+	case "option":
+		if index < int64(len(acp.Options)) {
+			return acp.selectOption(acp.Options[index], AutocompleteEventSelected)
+		}
+
+	case "chip":
+		if index < int64(len(acp.Values)) {
+			acp.SetProperty("values", slices.Delete(slices.Clone(acp.Values), int(index), int(index+1)))
+			re = ResponseEvent{Trigger: acp, TriggerName: acp.Name, Name: AutocompleteEventChange, Value: acp.Values}
+			if acp.OnResponse != nil {
+				return acp.OnResponse(re)
+			}
+			return re
+		}
+	}
+	return ResponseEvent{Trigger: acp, TriggerName: acp.Name, Name: AutocompleteEventSearch, Value: acp.Text}
+}
+
+/*
+If the OnResponse function of the [Autocomplete] is implemented, the function calls it after the [TriggerEvent]
+is processed, otherwise the function's return [ResponseEvent] is the processed [TriggerEvent].
+*/
+func (acp *Autocomplete) OnRequest(te TriggerEvent) (re ResponseEvent) {
+	key := te.Values.Get("key")
+	switch key {
+	case AutocompleteKeyDown, AutocompleteKeyUp, AutocompleteKeyEscape:
+		return acp.navigate(key)
+
+	case AutocompleteKeyEnter:
+		if acp.ShowList && acp.ActiveIndex >= 0 && acp.ActiveIndex < int64(len(acp.Options)) {
+			return acp.selectOption(acp.Options[acp.ActiveIndex], AutocompleteEventSelected)
+		}
+		if text := te.Values.Get(te.Name); acp.FreeText && text != "" {
+			return acp.selectOption(SelectOption{Value: text, Text: text}, AutocompleteEventChange)
+		}
+		return acp.navigate(key)
+	}
+	return acp.search(te.Values.Get(te.Name))
+}
+
+func (acp *Autocomplete) component(name string, index int) ClientComponent {
+	ccLbl := func() *Label {
+		return &Label{
+			BaseComponent: BaseComponent{
+				Id:           acp.Id + "_" + name + "_" + ut.ToString(index, ""),
+				Name:         name,
+				EventURL:     acp.EventURL,
+				Target:       acp.Target,
+				Data:         ut.IM{"index": index},
+				OnResponse:   acp.response,
+				RequestValue: acp.RequestValue,
+				RequestMap:   acp.RequestMap,
+			},
+		}
+	}
+	ccMap := map[string]func() ClientComponent{
+		"option": func() ClientComponent {
+			return ccLbl()
+		},
+		"chip": func() ClientComponent {
+			return ccLbl()
+		},
+		"chip_icon": func() ClientComponent {
+			return &Icon{Value: IconTimes, Width: 12, Height: 12}
+		},
+	}
+	return ccMap[name]()
+}
+
+/*
+Based on the values, it will generate the html code of the [Autocomplete] or return with an error message.
+*/
+func (acp *Autocomplete) Render() (html template.HTML, err error) {
+	return RenderHTML(acp)
+}
+
+// the render data of the [Autocomplete] template
+type autocompleteRender struct {
+	*Autocomplete
+	hw *ut.HTMLWriter
+}
+
+// [Autocomplete] template functions
+var autocompleteFuncMap = map[string]any{
+	"styleMap": func(acp *autocompleteRender) bool {
+		return len(acp.Style) > 0
+	},
+	"customClass": func(acp *autocompleteRender) string {
+		return strings.Join(acp.Class, " ")
+	},
+	"itemID": func(acp *autocompleteRender, name string, index int) string {
+		if acp.EventURL != "" {
+			_ = RenderTo(io.Discard, acp.component(name, index))
+		}
+		return acp.Id + "_" + name + "_" + ut.ToString(index, "")
+	},
+	"chipIcon": func(acp *autocompleteRender) (template.HTML, error) {
+		return "", RenderTo(acp.hw, acp.component("chip_icon", 0))
+	},
+	"highlight": func(acp *autocompleteRender, text string) template.HTML {
+		start := strings.Index(strings.ToLower(text), strings.ToLower(acp.Text))
+		if acp.Text == "" || start < 0 || len(strings.ToLower(text)) != len(text) ||
+			len(strings.ToLower(acp.Text)) != len(acp.Text) {
+			return template.HTML(template.HTMLEscapeString(text))
+		}
+		end := start + len(acp.Text)
+		return template.HTML(template.HTMLEscapeString(text[:start]) +
+			"<mark>" + template.HTMLEscapeString(text[start:end]) + "</mark>" + template.HTMLEscapeString(text[end:]))
+	},
+}
+
+/*
+Based on the values, it will write the html code of the [Autocomplete] into the writer or return with an error message.
+*/
+func (acp *Autocomplete) RenderTo(w io.Writer) (err error) {
+	acp.InitProps(acp)
+
+	event := `{{ if ne $.EventURL "" }} hx-post="{{ $.EventURL }}" hx-target="{{ $.Target }}" {{ if ne $.Sync "none" }} hx-sync="{{ $.Sync }}"{{ end }} hx-swap="{{ $.Swap }}"{{ end }}
+	{{ if ne $.Indicator "none" }} hx-indicator="#{{ $.Indicator }}"{{ end }}`
+	tpl := `<div id="{{ .Id }}" class="autocomplete {{ customClass $ }}{{ if .Full }} full{{ end }}"
+	{{ if styleMap $ }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}
+	><div class="autocomplete-box{{ if .Disabled }} disabled{{ end }}">
+	{{ if .Multiple }}{{ range $index, $chip := .Values }}<span class="autocomplete-chip" >{{ $chip.Text }}
+	{{ if not $.Disabled }}<span id="{{ itemID $ "chip" $index }}" name="chip" class="autocomplete-chip-remove" ` + event + `
+	>{{ chipIcon $ }}</span>{{ end }}</span>{{ end }}{{ end }}
+	<input id="{{ .Id }}_input" name="{{ .Name }}" type="text" value="{{ .Text }}" autocomplete="off"
+	 role="combobox" aria-autocomplete="list" aria-controls="{{ .Id }}_list" aria-expanded="{{ .ShowList }}"
+	{{ if and .ShowList (ge .ActiveIndex 0) }} aria-activedescendant="{{ .Id }}_option_{{ .ActiveIndex }}"{{ end }}
+	{{ if ne .EventURL "" }} hx-post="{{ .EventURL }}" hx-target="{{ .Target }}" {{ if ne .Sync "none" }} hx-sync="{{ .Sync }}"{{ end }} hx-swap="{{ .Swap }}"
+	 hx-trigger="keyup changed delay:{{ .Delay }}ms, keydown[key=='ArrowDown'||key=='ArrowUp'||key=='Enter'||key=='Escape']"
+	 hx-vals="js:{key: event.key}"{{ end }}
+	{{ if ne .Indicator "none" }} hx-indicator="#{{ .Indicator }}"{{ end }}
+	{{ if ne .Placeholder "" }} placeholder="{{ .Placeholder }}"{{ end }}
+	{{ if ne .Label "" }} aria-label="{{ .Label }}"{{ end }}
+	{{ if .Disabled }} disabled{{ end }}
+	{{ if .AutoFocus }} autofocus{{ end }} class="autocomplete-input" ></input>
+	</div>{{ if and .ShowList (not .Disabled) }}<ul id="{{ .Id }}_list" role="listbox" class="autocomplete-list" >
+	{{ range $index, $option := .Options }}<li id="{{ itemID $ "option" $index }}" name="option" role="option"
+	 class="autocomplete-option{{ if eq $.ActiveIndex $index }} active{{ end }}"
+	 aria-selected="{{ eq $.ActiveIndex $index }}" ` + event + `
+	>{{ highlight $ $option.Text }}</li>{{ end }}
+	</ul>{{ end }}</div>`
+
+	hw := ut.NewHTMLWriter(w)
+	if err = ut.CachedTemplateWriter(hw, "autocomplete", tpl, autocompleteFuncMap, &autocompleteRender{Autocomplete: acp, hw: hw}); err == nil {
+		err = hw.Close()
+	}
+	if err == nil && acp.EventURL != "" {
+		acp.SetProperty("request_map", acp)
+		acp.RequestMap[acp.Id+"_input"] = acp
+	}
+	return err
+}
+
+var testAutocompleteLookup *LookupRows = &LookupRows{
+	Rows: []ut.IM{
+		{"id": "PRD-001", "name": "PRD-001 Big product"},
+		{"id": "PRD-002", "name": "PRD-002 Good work"},
+		{"id": "PRD-003", "name": "PRD-003 Nice product"},
+		{"id": "PRD-004", "name": "PRD-004 Red car"},
+		{"id": "PRD-005", "name": "PRD-005 Blue car"},
+		{"id": "SRV-001", "name": "SRV-001 Hour service"},
+		{"id": "SRV-002", "name": "SRV-002 Day service"},
+	},
+	TextField: "name",
+}
+
+var testAutocompleteResponse func(evt ResponseEvent) (re ResponseEvent) = func(evt ResponseEvent) (re ResponseEvent) {
+	if evt.Name == AutocompleteEventSearch && evt.Trigger.GetProperty("lookup") == "" {
+		options := []SelectOption{}
+		for _, color := range []string{"Red", "Green", "Blue", "Yellow", "Purple", "Orange", "Pink"} {
+			if strings.Contains(strings.ToLower(color), strings.ToLower(ut.ToString(evt.Value, ""))) {
+				options = append(options, SelectOption{Value: strings.ToLower(color), Text: color})
+			}
+		}
+		evt.Trigger.SetProperty("options", options)
+	}
+	return evt
+}
+
+// the demo lookup of the [Autocomplete] and [Field] demos is registered once
+func init() {
+	RegisterLookup("test_product", testAutocompleteLookup)
+}
+
+// [Autocomplete] test and demo data
+func TestAutocomplete(cc ClientComponent) []TestComponent {
+	id := ut.ToString(cc.GetProperty("id"), "")
+	eventURL := ut.ToString(cc.GetProperty("event_url"), "")
+	requestValue := cc.GetProperty("request_value").(map[string]ut.IM)
+	requestMap := cc.GetProperty("request_map").(map[string]ClientComponent)
+	return []TestComponent{
+		{
+			Label:         "Forced selection (lookup)",
+			ComponentType: ComponentTypeAutocomplete,
+			Component: &Autocomplete{
+				BaseComponent: BaseComponent{
+					Id:           id + "_autocomplete_default",
+					EventURL:     eventURL,
+					OnResponse:   testAutocompleteResponse,
+					RequestValue: requestValue,
+					RequestMap:   requestMap,
+				},
+				Lookup:      "test_product",
+				Placeholder: "Product code",
+				Full:        true,
+			}},
+		{
+			Label:         "Free text, custom options",
+			ComponentType: ComponentTypeAutocomplete,
+			Component: &Autocomplete{
+				BaseComponent: BaseComponent{
+					Id:           id + "_autocomplete_free",
+					EventURL:     eventURL,
+					OnResponse:   testAutocompleteResponse,
+					RequestValue: requestValue,
+					RequestMap:   requestMap,
+				},
+				FreeText: true,
+				Text:     "Red",
+				Value:    SelectOption{Value: "Red", Text: "Red"},
+				Full:     true,
+			}},
+		{
+			Label:         "Multiple values",
+			ComponentType: ComponentTypeAutocomplete,
+			Component: &Autocomplete{
+				BaseComponent: BaseComponent{
+					Id:           id + "_autocomplete_multiple",
+					EventURL:     eventURL,
+					OnResponse:   testAutocompleteResponse,
+					RequestValue: requestValue,
+					RequestMap:   requestMap,
+				},
+				Lookup:   "test_product",
+				Multiple: true,
+				Values: []SelectOption{
+					{Value: "PRD-001", Text: "PRD-001 Big product"},
+					{Value: "SRV-002", Text: "SRV-002 Day service"},
+				},
+				Full: true,
+			}},
+		{
+			Label:         "Disabled",
+			ComponentType: ComponentTypeAutocomplete,
+			Component: &Autocomplete{
+				BaseComponent: BaseComponent{
+					Id:           id + "_autocomplete_disabled",
+					EventURL:     eventURL,
+					RequestValue: requestValue,
+					RequestMap:   requestMap,
+				},
+				Multiple: true,
+				Values: []SelectOption{
+					{Value: "PRD-001", Text: "PRD-001 Big product"},
+				},
+				Disabled: true,
+			}},
+	}
+}
@@ -0,0 +1,368 @@
+package component
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	ut "github.com/nervatura/component/pkg/util"
+)
+
+func TestTestAutocomplete(t *testing.T) {
+	for _, tt := range TestAutocomplete(&BaseComponent{EventURL: "/demo"}) {
+		t.Run(tt.Label, func(t *testing.T) {
+			tt.Component.Render()
+		})
+	}
+	testAutocompleteResponse(ResponseEvent{
+		Name: AutocompleteEventSearch, Trigger: &Autocomplete{}, Value: "re",
+	})
+}
+
+func TestAutocomplete_Validation(t *testing.T) {
+	type fields struct {
+		BaseComponent BaseComponent
+		Options       []SelectOption
+	}
+	type args struct {
+		propName  string
+		propValue interface{}
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   interface{}
+	}{
+		{
+			name: "base",
+			args: args{
+				propName:  "id",
+				propValue: "BTNID",
+			},
+			want: "BTNID",
+		},
+		{
+			name: "invalid",
+			args: args{
+				propName:  "invalid",
+				propValue: "",
+			},
+			want: "",
+		},
+		{
+			name: "value",
+			args: args{
+				propName:  "value",
+				propValue: SelectOption{Value: "value", Text: "text"},
+			},
+			want: SelectOption{Value: "value", Text: "text"},
+		},
+		{
+			name: "value_map",
+			args: args{
+				propName:  "value",
+				propValue: ut.IM{"value": "value", "text": "text"},
+			},
+			want: SelectOption{Value: "value", Text: "text"},
+		},
+		{
+			name: "value_invalid",
+			args: args{
+				propName:  "value",
+				propValue: "",
+			},
+			want: SelectOption{},
+		},
+		{
+			name: "values",
+			args: args{
+				propName:  "values",
+				propValue: []interface{}{ut.IM{"value": "value", "text": "text"}},
+			},
+			want: []SelectOption{{Value: "value", Text: "text"}},
+		},
+		{
+			name: "active_index",
+			fields: fields{
+				Options: []SelectOption{{Value: "value", Text: "text"}},
+			},
+			args: args{
+				propName:  "active_index",
+				propValue: 1,
+			},
+			want: int64(-1),
+		},
+		{
+			name: "min_length",
+			args: args{
+				propName:  "min_length",
+				propValue: 0,
+			},
+			want: int64(1),
+		},
+		{
+			name: "limit",
+			args: args{
+				propName:  "limit",
+				propValue: 5,
+			},
+			want: int64(5),
+		},
+		{
+			name: "delay",
+			args: args{
+				propName:  "delay",
+				propValue: 500,
+			},
+			want: int64(500),
+		},
+		{
+			name: "target",
+			args: args{
+				propName:  "target",
+				propValue: "target",
+			},
+			want: "#target",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			acp := &Autocomplete{
+				BaseComponent: tt.fields.BaseComponent,
+				Options:       tt.fields.Options,
+			}
+			if got := acp.Validation(tt.args.propName, tt.args.propValue); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Autocomplete.Validation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAutocomplete_SetProperty(t *testing.T) {
+	type args struct {
+		propName  string
+		propValue interface{}
+	}
+	tests := []struct {
+		name string
+		args args
+		want interface{}
+	}{
+		{
+			name: "base",
+			args: args{
+				propName:  "id",
+				propValue: "BTNID",
+			},
+			want: "BTNID",
+		},
+		{
+			name: "missing",
+			args: args{
+				propName:  "missing",
+				propValue: "",
+			},
+			want: "",
+		},
+		{
+			name: "target",
+			args: args{
+				propName:  "target",
+				propValue: "this",
+			},
+			want: "this",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			acp := &Autocomplete{}
+			if got := acp.SetProperty(tt.args.propName, tt.args.propValue); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Autocomplete.SetProperty() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAutocomplete_OnRequest(t *testing.T) {
+	RegisterLookup("test_color", testLookupRows)
+	request := func(acp *Autocomplete, text, key string) ResponseEvent {
+		return acp.OnRequest(TriggerEvent{
+			Id: acp.Id + "_input", Name: acp.Name,
+			Values: url.Values{acp.Name: {text}, "key": {key}},
+		})
+	}
+	onResponse := func(evt ResponseEvent) (re ResponseEvent) {
+		return evt
+	}
+
+	acp := &Autocomplete{
+		BaseComponent: BaseComponent{
+			Id: "acp", Name: "acp", EventURL: "/event", OnResponse: onResponse,
+			RequestValue: map[string]ut.IM{}, RequestMap: map[string]ClientComponent{},
+		},
+		Lookup: "test_color",
+	}
+	if _, err := acp.Render(); err != nil || acp.RequestMap["acp_input"] != acp {
+		t.Errorf("Autocomplete.Render() error = %v", err)
+	}
+	if re := request(acp, "e", "e"); re.Name != AutocompleteEventSearch || len(acp.Options) != 3 ||
+		acp.ActiveIndex != 0 || !acp.ShowList {
+		t.Errorf("Autocomplete search = %v, %v", re, acp.Options)
+	}
+	if _, err := acp.Render(); err != nil {
+		t.Errorf("Autocomplete.Render() error = %v", err)
+	}
+	request(acp, "e", AutocompleteKeyUp)
+	if acp.ActiveIndex != 2 {
+		t.Errorf("Autocomplete ArrowUp = %v", acp.ActiveIndex)
+	}
+	request(acp, "e", AutocompleteKeyDown)
+	if acp.ActiveIndex != 0 {
+		t.Errorf("Autocomplete ArrowDown = %v", acp.ActiveIndex)
+	}
+	request(acp, "e", AutocompleteKeyUp)
+	request(acp, "e", AutocompleteKeyUp)
+	if re := request(acp, "e", AutocompleteKeyEnter); re.Name != AutocompleteEventSelected || acp.Value.Text != "Green" {
+		t.Errorf("Autocomplete Enter = %v", re)
+	}
+	request(acp, "Gr", "r")
+	request(acp, "Gr", AutocompleteKeyEscape)
+	if acp.Text != "Green" || acp.ShowList {
+		t.Errorf("Autocomplete Escape = %v", acp.Text)
+	}
+	if re := request(acp, "", AutocompleteKeyEnter); re.Name != AutocompleteEventSearch {
+		t.Errorf("Autocomplete Enter = %v", re)
+	}
+
+	// free text, multiple values
+	acp = &Autocomplete{
+		BaseComponent: BaseComponent{
+			Id: "acp", Name: "acp", EventURL: "/event",
+			RequestValue: map[string]ut.IM{}, RequestMap: map[string]ClientComponent{},
+		},
+		Lookup:   "test_color",
+		FreeText: true,
+		Multiple: true,
+	}
+	request(acp, "bl", "l")
+	if acp.ActiveIndex != -1 || len(acp.Options) != 1 {
+		t.Errorf("Autocomplete search = %v", acp.Options)
+	}
+	if _, err := acp.Render(); err != nil {
+		t.Errorf("Autocomplete.Render() error = %v", err)
+	}
+	request(acp, "bl", AutocompleteKeyDown)
+	request(acp, "bl", AutocompleteKeyEnter)
+	request(acp, "bl", "l")
+	request(acp, "bl", AutocompleteKeyDown)
+	request(acp, "bl", AutocompleteKeyEnter)
+	if re := request(acp, "Black", AutocompleteKeyEnter); re.Name != AutocompleteEventChange || len(acp.Values) != 2 {
+		t.Errorf("Autocomplete free text = %v", acp.Values)
+	}
+	request(acp, "", AutocompleteKeyEscape)
+
+	// free text
+	acp = &Autocomplete{FreeText: true}
+	request(acp, "text", "t")
+	if acp.Value.Value != "text" || acp.ShowList {
+		t.Errorf("Autocomplete free text = %v", acp.Value)
+	}
+	request(acp, "text", AutocompleteKeyDown)
+
+	// stale active index after the options shrink
+	acp = &Autocomplete{ShowList: true, ActiveIndex: 2, Options: []SelectOption{{Value: "1", Text: "Red"}}}
+	if re := request(acp, "", AutocompleteKeyEnter); re.Name == AutocompleteEventSelected {
+		t.Errorf("Autocomplete Enter = %v", re)
+	}
+	acp = &Autocomplete{ShowList: true, Options: []SelectOption{}}
+	if re := request(acp, "", AutocompleteKeyEnter); re.Name == AutocompleteEventSelected {
+		t.Errorf("Autocomplete Enter = %v", re)
+	}
+}
+
+func TestAutocomplete_response(t *testing.T) {
+	onResponse := func(evt ResponseEvent) (re ResponseEvent) {
+		return evt
+	}
+	label := func(index int) *Label {
+		return &Label{BaseComponent: BaseComponent{Data: ut.IM{"index": index}}}
+	}
+	tests := []struct {
+		name       string
+		acp        *Autocomplete
+		evt        ResponseEvent
+		wantName   string
+		wantValues int
+	}{
+		{
+			name: "option",
+			acp: &Autocomplete{
+				BaseComponent: BaseComponent{OnResponse: onResponse},
+				Options:       []SelectOption{{Value: "1", Text: "One"}},
+			},
+			evt:      ResponseEvent{TriggerName: "option", Trigger: label(0)},
+			wantName: AutocompleteEventSelected,
+		},
+		{
+			name:     "option_invalid",
+			acp:      &Autocomplete{},
+			evt:      ResponseEvent{TriggerName: "option", Trigger: label(1)},
+			wantName: AutocompleteEventSearch,
+		},
+		{
+			name: "chip",
+			acp: &Autocomplete{
+				BaseComponent: BaseComponent{OnResponse: onResponse},
+				Multiple:      true,
+				Values:        []SelectOption{{Value: "1", Text: "One"}, {Value: "2", Text: "Two"}},
+			},
+			evt:        ResponseEvent{TriggerName: "chip", Trigger: label(0)},
+			wantName:   AutocompleteEventChange,
+			wantValues: 1,
+		},
+		{
+			name: "chip_no_response",
+			acp: &Autocomplete{
+				Multiple: true,
+				Values:   []SelectOption{{Value: "1", Text: "One"}},
+			},
+			evt:      ResponseEvent{TriggerName: "chip", Trigger: label(0)},
+			wantName: AutocompleteEventChange,
+		},
+		{
+			name:     "chip_invalid",
+			acp:      &Autocomplete{},
+			evt:      ResponseEvent{TriggerName: "chip", Trigger: label(1)},
+			wantName: AutocompleteEventSearch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if re := tt.acp.response(tt.evt); re.Name != tt.wantName || len(tt.acp.Values) != tt.wantValues {
+				t.Errorf("Autocomplete.response() = %v, want %v", re.Name, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestAutocomplete_Render(t *testing.T) {
+	acp := &Autocomplete{
+		BaseComponent: BaseComponent{Id: "acp"},
+		Text:          "ab",
+		ShowList:      true,
+		Options: []SelectOption{
+			{Value: "1", Text: "xAbc"}, {Value: "2", Text: "İstanbul ab"}, {Value: "3", Text: "none"},
+		},
+	}
+	html, err := acp.Render()
+	if err != nil || !reflect.DeepEqual(acp.ActiveIndex, int64(0)) {
+		t.Fatalf("Autocomplete.Render() error = %v", err)
+	}
+	for _, want := range []string{"x<mark>Ab</mark>c", "İstanbul ab", ">none<"} {
+		if !strings.Contains(string(html), want) {
+			t.Errorf("Autocomplete.Render() missing %s", want)
+		}
+	}
+}
@@ -8,6 +8,8 @@ package component
 
 import (
 	"html/template"
+	"io"
+	"maps"
 	"net/url"
 	"slices"
 	"strings"
@@ -17,8 +19,9 @@ import (
 
 // Common component constants
 const (
-	ThemeLight = "light"
-	ThemeDark  = "dark"
+	ThemeLight        = "light"
+	ThemeDark         = "dark"
+	ThemeHighContrast = "contrast"
 
 	TextAlignLeft   = "left"
 	TextAlignCenter = "center"
@@ -101,8 +104,8 @@ const (
 	HeaderTriggerAfterSwap = "HX-Trigger-After-Swap"
 )
 
-// Component Theme values
-var Theme []string = []string{ThemeLight, ThemeDark}
+// Component Theme values. The custom themes are added by the [RegisterTheme] function.
+var Theme []string = []string{ThemeLight, ThemeDark, ThemeHighContrast}
 
 // Component TextAlign values
 var TextAlign []string = []string{TextAlignLeft, TextAlignCenter, TextAlignRight}
@@ -160,6 +163,48 @@ type ClientComponent interface {
 	*/
 }
 
+// RenderWriter is implemented by the components that can write their html code directly into an io.Writer.
+type RenderWriter interface {
+	RenderTo(w io.Writer) (err error) /*
+		Based on the values, it will write the component's html code into the writer or return with an error message.
+		The InitProps function is automatically called at the beginning of the function.
+	*/
+}
+
+/*
+StreamComponent is a [ClientComponent] that can write its html code without buffering the whole result.
+All components of the package implement it. A custom component that overrides the Render function of
+an embedded component must also override the RenderTo function.
+*/
+type StreamComponent interface {
+	ClientComponent
+	RenderWriter
+}
+
+/*
+The RenderTo function writes the html code of the component into the writer. A component that only implements
+the Render function of the [ClientComponent] is rendered by its Render function.
+*/
+func RenderTo(w io.Writer, cc ClientComponent) (err error) {
+	if sc, valid := cc.(StreamComponent); valid {
+		return sc.RenderTo(w)
+	}
+	var html template.HTML
+	if html, err = cc.Render(); err == nil {
+		_, err = w.Write([]byte(html))
+	}
+	return err
+}
+
+// The RenderHTML function returns the buffered html result of the RenderTo function of the component.
+func RenderHTML(rw RenderWriter) (html template.HTML, err error) {
+	var sb strings.Builder
+	if err = rw.RenderTo(&sb); err != nil {
+		return "", err
+	}
+	return template.HTML(sb.String()), err
+}
+
 // Test container for component test cases
 type TestComponent struct {
 	// The name of the test data
@@ -182,6 +227,8 @@ type TriggerEvent struct {
 	Values url.Values `json:"values"`
 	// text/plain or application/json data
 	Data []byte
+	// The uploaded files of the request (multipart/form-data). See more [FormFiles]
+	Files []UploadFile `json:"files"`
 }
 
 // Response data for a user event
@@ -197,6 +244,8 @@ type ResponseEvent struct {
 	/* htmx supports some htmx-specific response headers. See more [ResponseEvent] Header map key constants
 	Example: Header: ut.SM{HeaderRetarget: "#toast-msg", HeaderReswap: SwapInnerHTML} */
 	Header ut.SM `json:"header"`
+	// Additional components of the response, which are swapped by the htmx hx-swap-oob attribute. See more [OOBSwap]
+	OOB []OOBComponent `json:"oob"`
 }
 
 // A component whose properties and functions are contained in all other components.
@@ -455,18 +504,30 @@ the component. The Render function calls it automatically.
 */
 func (bcc *BaseComponent) InitProps(cc ClientComponent) {
 	bcc.init = true
-	for key, value := range cc.Properties() {
-		cc.SetProperty(key, value)
+	// the properties are set in a stable order, so the rendered html does not depend on the map iteration
+	props := cc.Properties()
+	for _, key := range slices.Sorted(maps.Keys(props)) {
+		cc.SetProperty(key, props[key])
 	}
 	requestValue := cc.Validation("request_value", bcc.RequestValue).(map[string]ut.IM)
 	if rq, found := requestValue[bcc.Id]; found {
-		for key, value := range rq {
-			cc.SetProperty(key, value)
+		for _, key := range slices.Sorted(maps.Keys(rq)) {
+			cc.SetProperty(key, rq[key])
 		}
 	}
 	bcc.init = false
 }
 
+// [BaseComponent] template functions
+var baseComponentFuncMap = map[string]any{
+	"styleMap": func(bcc *BaseComponent) bool {
+		return len(bcc.Style) > 0
+	},
+	"customClass": func(bcc *BaseComponent) string {
+		return strings.Join(bcc.Class, " ")
+	},
+}
+
 /*
 Based on the values, it will generate the html code of the [BaseComponent] or return with an error message.
 The [BaseComponent.InitProps] function is automatically called at the beginning of the function.
@@ -474,19 +535,11 @@ The [BaseComponent.InitProps] function is automatically called at the beginning
 func (bcc *BaseComponent) Render() (html template.HTML, err error) {
 	bcc.InitProps(bcc)
 
-	funcMap := map[string]any{
-		"styleMap": func() bool {
-			return len(bcc.Style) > 0
-		},
-		"customClass": func() string {
-			return strings.Join(bcc.Class, " ")
-		},
-	}
-	tpl := `<div id="{{ .Id }}" class="{{ customClass }}"
-	{{ if styleMap }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}>
+	tpl := `<div id="{{ .Id }}" class="{{ customClass $ }}"
+	{{ if styleMap $ }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}>
 	</div>`
 
-	return ut.TemplateBuilder("base", tpl, funcMap, bcc)
+	return ut.TemplateBuilder("base", tpl, baseComponentFuncMap, bcc)
 }
 
 /*
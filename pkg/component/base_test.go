@@ -1,7 +1,10 @@
 package component
 
 import (
+	"errors"
+	"io"
 	"reflect"
+	"strings"
 	"testing"
 
 	ut "github.com/nervatura/component/pkg/util"
@@ -333,6 +336,39 @@ func TestBaseComponent_Render(t *testing.T) {
 	}
 }
 
+type testStreamComponent struct {
+	BaseComponent
+}
+
+func (tsc *testStreamComponent) RenderTo(w io.Writer) error {
+	return errors.New("error")
+}
+
+func TestRenderTo(t *testing.T) {
+	tests := []struct {
+		name    string
+		cc      ClientComponent
+		want    string
+		wantErr bool
+	}{
+		{name: "render", cc: &BaseComponent{Id: "id"}, want: ""},
+		{name: "stream", cc: &Label{BaseComponent: BaseComponent{Id: "id"}, Value: "value"}, want: "value"},
+		{name: "stream_error", cc: &testStreamComponent{}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sb strings.Builder
+			err := RenderTo(&sb, tt.cc)
+			if (err != nil) != tt.wantErr || !strings.Contains(sb.String(), tt.want) {
+				t.Errorf("RenderTo() = %v, error = %v, wantErr %v", sb.String(), err, tt.wantErr)
+			}
+		})
+	}
+	if html, err := RenderHTML(&testStreamComponent{}); err == nil || html != "" {
+		t.Errorf("RenderHTML() = %v, error = %v", html, err)
+	}
+}
+
 func TestBaseComponent_OnRequest(t *testing.T) {
 	type fields struct {
 		Id         string
@@ -0,0 +1,67 @@
+package component
+
+import (
+	"testing"
+
+	ut "github.com/nervatura/component/pkg/util"
+)
+
+func benchmarkRender(b *testing.B, component func() ClientComponent) {
+	for _, cache := range []bool{true, false} {
+		name := "cache"
+		if !cache {
+			name = "parse"
+		}
+		b.Run(name, func(b *testing.B) {
+			ut.TemplateCacheEnabled = cache
+			defer func() { ut.TemplateCacheEnabled = true }()
+			cc := component()
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := cc.Render(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func benchmarkDemo() *BaseComponent {
+	return &BaseComponent{
+		Id: "bench", EventURL: "/event",
+		RequestValue: map[string]ut.IM{}, RequestMap: map[string]ClientComponent{},
+	}
+}
+
+func BenchmarkTable(b *testing.B) {
+	rows := []ut.IM{}
+	for index := range 100 {
+		row := ut.MergeIM(ut.IM{}, testTableRows[index%len(testTableRows)])
+		row["id"] = index + 1
+		rows = append(rows, row)
+	}
+	benchmarkRender(b, func() ClientComponent {
+		return &Table{
+			BaseComponent: BaseComponent{
+				Id: "bench_table", EventURL: "/event",
+				RequestValue: map[string]ut.IM{}, RequestMap: map[string]ClientComponent{},
+			},
+			Rows:       rows,
+			Fields:     testTableFields,
+			Pagination: PaginationTypeNone,
+			PageSize:   100,
+		}
+	})
+}
+
+func BenchmarkBrowser(b *testing.B) {
+	benchmarkRender(b, func() ClientComponent {
+		return TestBrowser(benchmarkDemo())[0].Component
+	})
+}
+
+func BenchmarkClient(b *testing.B) {
+	benchmarkRender(b, func() ClientComponent {
+		return TestClient(benchmarkDemo())[0].Component
+	})
+}
@@ -5,9 +5,9 @@ import (
 	"encoding/base64"
 	"encoding/csv"
 	"html/template"
+	"io"
 	"slices"
 	"strings"
-	"time"
 
 	ut "github.com/nervatura/component/pkg/util"
 )
@@ -653,9 +653,9 @@ func (bro *Browser) defaultFilterValue(ftype string) interface{} {
 	defvalue := ut.IM{
 		TableFieldTypeNumber:   0,
 		TableFieldTypeInteger:  0,
-		TableFieldTypeDateTime: time.Now().Format("2006-01-02T15:04"),
-		TableFieldTypeDate:     time.Now().Format("2006-01-02"),
-		TableFieldTypeTime:     time.Now().Format("15:04"),
+		TableFieldTypeDateTime: timeNow().Format("2006-01-02T15:04"),
+		TableFieldTypeDate:     timeNow().Format("2006-01-02"),
+		TableFieldTypeTime:     timeNow().Format("15:04"),
 		TableFieldTypeBool:     "1",
 	}
 	if value, found := defvalue[ftype]; found {
@@ -831,7 +831,7 @@ func (bro *Browser) filterTable() *Table {
 	return tbl
 }
 
-func (bro *Browser) getComponent(name string, data ut.IM) (html template.HTML, err error) {
+func (bro *Browser) component(name string, data ut.IM) ClientComponent {
 	ccBtn := func(icoKey, label, bstyle, index string) *Button {
 		btn := &Button{
 			BaseComponent: BaseComponent{
@@ -1011,9 +1011,11 @@ func (bro *Browser) getComponent(name string, data ut.IM) (html template.HTML, e
 			return bro.getComponentTable()
 		},
 	}
-	cc := ccMap[name]()
-	html, err = cc.Render()
-	return html, err
+	return ccMap[name]()
+}
+
+func (bro *Browser) getComponent(name string, data ut.IM) (html template.HTML, err error) {
+	return bro.component(name, data).Render()
 }
 
 func (bro *Browser) msg(labelID string) string {
@@ -1027,105 +1029,124 @@ func (bro *Browser) msg(labelID string) string {
 Based on the values, it will generate the html code of the [Browser] or return with an error message.
 */
 func (bro *Browser) Render() (html template.HTML, err error) {
+	return RenderHTML(bro)
+}
+
+// the render data of the [Browser] template
+type browserRender struct {
+	*Browser
+	hw *ut.HTMLWriter
+}
+
+// [Browser] template functions
+var browserFuncMap = map[string]any{
+	"msg": func(bro *browserRender, labelID string) string {
+		return bro.msg(labelID)
+	},
+	"styleMap": func(bro *browserRender) bool {
+		return len(bro.Style) > 0
+	},
+	"showViews": func(bro *browserRender) bool {
+		return len(bro.Views) > 0
+	},
+	"showFilters": func(bro *browserRender) bool {
+		return len(bro.Filters) > 0
+	},
+	"customClass": func(bro *browserRender) string {
+		return strings.Join(bro.Class, " ")
+	},
+	"browserComponent": func(bro *browserRender, name string) (template.HTML, error) {
+		return "", RenderTo(bro.hw, bro.component(name, ut.IM{}))
+	},
+	"menuItem": func(bro *browserRender, key, value string) (template.HTML, error) {
+		return "", RenderTo(bro.hw, bro.component("menu_item", ut.IM{"key": key, "value": value}))
+	},
+	"colItem": func(bro *browserRender, key, value string) (template.HTML, error) {
+		return "", RenderTo(bro.hw, bro.component("col_item", ut.IM{"key": key, "value": value}))
+	},
+	"resultCount": func(bro *browserRender) int {
+		return len(bro.Rows)
+	},
+	"totalFields": func(bro *browserRender) []BrowserTotalField {
+		return bro.totalFields
+	},
+	"totalLabel": func(bro *browserRender, label string) (template.HTML, error) {
+		return "", RenderTo(bro.hw, bro.component("total_label", ut.IM{"label": label}))
+	},
+	"totalValue": func(bro *browserRender, total float64) (template.HTML, error) {
+		return "", RenderTo(bro.hw, bro.component("total_value", ut.IM{"total": total}))
+	},
+}
+
+/*
+Based on the values, it will write the html code of the [Browser] into the writer or return with an error message.
+*/
+func (bro *Browser) RenderTo(w io.Writer) (err error) {
 	bro.InitProps(bro)
+	hw := ut.NewHTMLWriter(w)
 	if bro.ShowTotal {
 		bro.totalFields = bro.setTotalValues()
 	}
 
-	funcMap := map[string]any{
-		"msg": func(labelID string) string {
-			return bro.msg(labelID)
-		},
-		"styleMap": func() bool {
-			return len(bro.Style) > 0
-		},
-		"showViews": func() bool {
-			return len(bro.Views) > 0
-		},
-		"showFilters": func() bool {
-			return len(bro.Filters) > 0
-		},
-		"customClass": func() string {
-			return strings.Join(bro.Class, " ")
-		},
-		"browserComponent": func(name string) (template.HTML, error) {
-			return bro.getComponent(name, ut.IM{})
-		},
-		"menuItem": func(key, value string) (template.HTML, error) {
-			return bro.getComponent("menu_item", ut.IM{"key": key, "value": value})
-		},
-		"colItem": func(key, value string) (template.HTML, error) {
-			return bro.getComponent("col_item", ut.IM{"key": key, "value": value})
-		},
-		"resultCount": func() int {
-			return len(bro.Rows)
-		},
-		"totalFields": func() []BrowserTotalField {
-			return bro.totalFields
-		},
-		"totalLabel": func(label string) (template.HTML, error) {
-			return bro.getComponent("total_label", ut.IM{"label": label})
-		},
-		"totalValue": func(total float64) (template.HTML, error) {
-			return bro.getComponent("total_value", ut.IM{"total": total})
-		},
-	}
-	tpl := `<div id="{{ .Id }}" name="{{ .Name }}" class="row full {{ customClass }}"
-	{{ if styleMap }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}
+	tpl := `<div id="{{ .Id }}" name="{{ .Name }}" class="row full {{ customClass $ }}"
+	{{ if styleMap $ }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}
 	><div class="panel">
 	<div class="panel-title"><div class="cell title-cell"><span>{{ .Title }}</span></div></div>
 	<div class="panel-container" >
-	<div class="row full" ><div class="cell" >{{ browserComponent "hide_header" }}</div></div>
+	<div class="row full" ><div class="cell" >{{ browserComponent $ "hide_header" }}</div></div>
 	{{ if ne .HideHeader true }}<div class="filter-panel" >
 	<div class="row full" >
-	<div class="cell" >{{ browserComponent "btn_search" }}</div>
+	<div class="cell" >{{ browserComponent $ "btn_search" }}</div>
 	<div class="cell align-right" >
-	{{ if ne .HideBookmark true }}{{ browserComponent "btn_bookmark" }}{{ end }}
-	{{ if ne .HideExport true }}{{ browserComponent "btn_export" }}{{ end }}
-	{{ if ne .HideHelp true }}{{ browserComponent "btn_help" }}{{ end }}
+	{{ if ne .HideBookmark true }}{{ browserComponent $ "btn_bookmark" }}{{ end }}
+	{{ if ne .HideExport true }}{{ browserComponent $ "btn_export" }}{{ end }}
+	{{ if ne .HideHelp true }}{{ browserComponent $ "btn_help" }}{{ end }}
 	</div></div>
 	<div class="row full section-small-top" >
 	<div class="cell" >
 	<div class="dropdown-box" >
-	{{ if showViews }}{{ browserComponent "btn_views" }}{{ end }}
+	{{ if showViews $ }}{{ browserComponent $ "btn_views" }}{{ end }}
 	{{ if .ShowDropdown }}<div class="dropdown-content" >
-	{{ range $index, $view := .Views }}<div class="drop-label" >{{ menuItem $view.Value $view.Text }}</div>{{ end }}
+	{{ range $index, $view := .Views }}<div class="drop-label" >{{ menuItem $ $view.Value $view.Text }}</div>{{ end }}
 	</div>{{ end }}
 	</div>
-	{{ browserComponent "btn_columns" }}{{ browserComponent "btn_filter" }}{{ browserComponent "btn_total" }}
+	{{ browserComponent $ "btn_columns" }}{{ browserComponent $ "btn_filter" }}{{ browserComponent $ "btn_total" }}
 	</div>
 	</div>
 	{{ if .ShowColumns }}<div class="col-box" >
 	{{ range $index, $field := .Table.Fields }}<div 
-	class="cell col-cell" >{{ colItem $field.Name $field.Label }}</div>{{ end }}
+	class="cell col-cell" >{{ colItem $ $field.Name $field.Label }}</div>{{ end }}
 	</div>{{ end }}
-	{{ if showFilters }}<div class="row section-top">
-	<div class="row full" style="margin-bottom: 1px;"><div class="cell result-title result-border" >{{ msg "browser_filters" }}</div></div>
-	<div class="row full"><div class="cell" >{{ browserComponent "filter_table" }}</div></div>
+	{{ if showFilters $ }}<div class="row section-top">
+	<div class="row full" style="margin-bottom: 1px;"><div class="cell result-title result-border" >{{ msg $ "browser_filters" }}</div></div>
+	<div class="row full"><div class="cell" >{{ browserComponent $ "filter_table" }}</div></div>
 	</div>{{ end }}
 	</div>{{ end }}
 	<div class="row full section-small-top" ><div class="row full result-border" >
-	<div class="cell result-title" >{{ resultCount }} {{ msg "browser_result" }}</div>
+	<div class="cell result-title" >{{ resultCount $ }} {{ msg $ "browser_result" }}</div>
 	</div></div>
-	<div class="row full" >{{ browserComponent "browser_table" }}</div>
+	<div class="row full" >{{ browserComponent $ "browser_table" }}</div>
 	</div></div>
 	{{ if .ShowTotal }}<div class="modal"><div class="dialog"><div class="panel">
 	<div class="panel-title">
-	<div class="cell title-cell" ><span>{{ msg "browser_total" }}</span></div>
+	<div class="cell title-cell" ><span>{{ msg $ "browser_total" }}</span></div>
 	</div>
 	<div class="section" ><div class="row full container" >
-	{{ range $index, $row := totalFields }}<div class="trow full">
-	<div class="cell padding-tiny mobile">{{ totalLabel $row.Label }}</div>
-	<div class="cell padding-tiny mobile">{{ totalValue $row.Total }}</div>
+	{{ range $index, $row := totalFields $ }}<div class="trow full">
+	<div class="cell padding-tiny mobile">{{ totalLabel $ $row.Label }}</div>
+	<div class="cell padding-tiny mobile">{{ totalValue $ $row.Total }}</div>
 	</div>{{ end }}
 	</div></div>
   <div class="section buttons" ><div class="row full container" ><div class="cell padding-small" >
-	{{ browserComponent "btn_ok" }}
+	{{ browserComponent $ "btn_ok" }}
 	</div></div></div>
 	</div></div></div>{{ end }}
 	</div>`
 
-	return ut.TemplateBuilder("browser", tpl, funcMap, bro)
+	if err = ut.CachedTemplateWriter(hw, "browser", tpl, browserFuncMap, &browserRender{Browser: bro, hw: hw}); err == nil {
+		err = hw.Close()
+	}
+	return err
 }
 
 var testBrowserFields map[string]func() []TableField = map[string]func() []TableField{
@@ -2,6 +2,7 @@ package component
 
 import (
 	"html/template"
+	"io"
 	"strings"
 
 	ut "github.com/nervatura/component/pkg/util"
@@ -265,19 +266,28 @@ func (btn *Button) getComponent(name string) (template.HTML, error) {
 Based on the values, it will generate the html code of the [Button] or return with an error message.
 */
 func (btn *Button) Render() (html template.HTML, err error) {
+	return RenderHTML(btn)
+}
+
+// [Button] template functions
+var buttonFuncMap = map[string]any{
+	"styleMap": func(btn *Button) bool {
+		return len(btn.Style) > 0
+	},
+	"customClass": func(btn *Button) string {
+		return strings.Join(btn.Class, " ")
+	},
+	"buttonComponent": func(btn *Button, name string) (template.HTML, error) {
+		return btn.getComponent(name)
+	},
+}
+
+/*
+Based on the values, it will write the html code of the [Button] into the writer or return with an error message.
+*/
+func (btn *Button) RenderTo(w io.Writer) (err error) {
 	btn.InitProps(btn)
 
-	funcMap := map[string]any{
-		"styleMap": func() bool {
-			return len(btn.Style) > 0
-		},
-		"customClass": func() string {
-			return strings.Join(btn.Class, " ")
-		},
-		"buttonComponent": func(name string) (template.HTML, error) {
-			return btn.getComponent(name)
-		},
-	}
 	tpl := `<button id="{{ .Id }}" name="{{ .Name }}" type="{{ .Type }}" value="{{ .Name }}"
 	{{ if and (eq .Type "submit") (ne .FormId "") }} form="{{ .FormId }}"{{ end }}
 	{{ if or (eq .ButtonStyle "primary") (eq .ButtonStyle "border") }} button-type="{{ .ButtonStyle }}"{{ end }}
@@ -287,18 +297,18 @@ func (btn *Button) Render() (html template.HTML, err error) {
 	{{ if .AutoFocus }} autofocus{{ end }}
 	{{ if ne .Label "" }} aria-label="{{ .Label }}" title="{{ .Label }}"{{ end }}
 	{{ if ne .OnClick "" }} hx-on:click="{{ .OnClick }}"{{ end }}
-	 class="{{ .Align }}{{ if .Small }} small-button{{ end }}{{ if .Full }} full{{ end }}{{ if .Selected }} selected{{ end }}{{ if .HideLabel }} hidelabel{{ end }} {{ customClass }}"
-	{{ if styleMap }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}
-	>{{ if and (ne .Icon "") (ne .Align "right") }}{{ buttonComponent "icon" }}{{ end }}
-	{{ if .LabelComponent }}{{ buttonComponent "label" }}{{ else }}<span>{{ .Label }}</span>{{ end }}
-	{{ if and (ne .Icon "") (eq .Align "right") }}{{ buttonComponent "icon" }}{{ end }}
+	 class="{{ .Align }}{{ if .Small }} small-button{{ end }}{{ if .Full }} full{{ end }}{{ if .Selected }} selected{{ end }}{{ if .HideLabel }} hidelabel{{ end }} {{ customClass $ }}"
+	{{ if styleMap $ }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}
+	>{{ if and (ne .Icon "") (ne .Align "right") }}{{ buttonComponent $ "icon" }}{{ end }}
+	{{ if .LabelComponent }}{{ buttonComponent $ "label" }}{{ else }}<span>{{ .Label }}</span>{{ end }}
+	{{ if and (ne .Icon "") (eq .Align "right") }}{{ buttonComponent $ "icon" }}{{ end }}
 	{{ if ne .Badge "" }}<span class="right" ><span class="badge{{ if .Selected }} selected-badge{{ end }}" >{{ .Badge }}</span></span>{{ end }}
 	</button>`
 
-	if html, err = ut.TemplateBuilder("button", tpl, funcMap, btn); err == nil && btn.EventURL != "" {
+	if err = ut.CachedTemplateWriter(w, "button", tpl, buttonFuncMap, btn); err == nil && btn.EventURL != "" {
 		btn.SetProperty("request_map", btn)
 	}
-	return html, err
+	return err
 }
 
 var testBtnResponse func(evt ResponseEvent) (re ResponseEvent) = func(evt ResponseEvent) (re ResponseEvent) {
@@ -307,6 +317,13 @@ var testBtnResponse func(evt ResponseEvent) (re ResponseEvent) = func(evt Respon
 	return evt
 }
 
+var testBtnOOBResponse func(evt ResponseEvent) (re ResponseEvent) = func(evt ResponseEvent) (re ResponseEvent) {
+	re = testBtnResponse(evt)
+	re.OOB = append(re.OOB,
+		OOBToast(ToastTypeInfo, "Badge value: "+ut.ToString(re.Trigger.GetProperty("badge"), ""), 2))
+	return re
+}
+
 // [Button] test and demo data
 func TestButton(cc ClientComponent) []TestComponent {
 	id := ut.ToString(cc.GetProperty("id"), "")
@@ -386,7 +403,7 @@ func TestButton(cc ClientComponent) []TestComponent {
 				BaseComponent: BaseComponent{
 					Id:           id + "_button_full",
 					EventURL:     eventURL,
-					OnResponse:   testBtnResponse,
+					OnResponse:   testBtnOOBResponse,
 					RequestValue: requestValue,
 					RequestMap:   requestMap,
 				},
@@ -12,6 +12,9 @@ func TestTestButton(t *testing.T) {
 		})
 	}
 	testBtnResponse(ResponseEvent{Trigger: &Button{}})
+	if re := testBtnOOBResponse(ResponseEvent{Trigger: &Button{}}); len(re.OOB) != 1 {
+		t.Errorf("testBtnOOBResponse() OOB = %v", re.OOB)
+	}
 }
 
 func TestButton_GetProperty(t *testing.T) {
@@ -0,0 +1,930 @@
+package component
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	ut "github.com/nervatura/component/pkg/util"
+)
+
+// [Calendar] constants
+const (
+	ComponentTypeCalendar = "calendar"
+
+	CalendarEventNavigate = "calendar_navigate"
+	CalendarEventCreate   = "calendar_create"
+	CalendarEventSelected = "calendar_selected"
+	CalendarEventMove     = "calendar_move"
+	CalendarEventResize   = "calendar_resize"
+
+	CalendarViewMonth  = "month"
+	CalendarViewWeek   = "week"
+	CalendarViewDay    = "day"
+	CalendarViewAgenda = "agenda"
+
+	// The date and the datetime formats of the calendar values
+	CalendarDateFormat = "2006-01-02"
+	CalendarTimeFormat = "2006-01-02T15:04"
+)
+
+// [Calendar] View values
+var CalendarView []string = []string{CalendarViewMonth, CalendarViewWeek, CalendarViewDay, CalendarViewAgenda}
+
+// [Calendar] SlotMinutes values
+var CalendarSlotMinutes []int64 = []int64{5, 10, 15, 20, 30, 60}
+
+var calendarDefaultLabel ut.SM = ut.SM{
+	"calendar_today":     "Today",
+	"calendar_previous":  "Previous",
+	"calendar_next":      "Next",
+	"calendar_month":     "Month",
+	"calendar_week":      "Week",
+	"calendar_day":       "Day",
+	"calendar_agenda":    "Agenda",
+	"calendar_all_day":   "All day",
+	"calendar_no_events": "No events",
+}
+
+/*
+Creates a calendar and scheduler control with month, week, day and agenda views. The events are rendered on
+the server side, and the recurring events (see [RecurrenceRule]) are expanded to the displayed period.
+
+If the EventURL is set, the control sends the [CalendarEventNavigate] events of the toolbar buttons, the
+[CalendarEventCreate] event of an empty slot click and the [CalendarEventSelected] event of an event click.
+If the ReadOnly is false, the events can be moved and the timed events can be resized by drag and drop, and
+the new times are posted as [CalendarEventMove] and [CalendarEventResize] events.
+
+For example:
+
+	&Calendar{
+	  BaseComponent: BaseComponent{
+	    Id:       "id_calendar_service",
+	    EventURL: "/event",
+	  },
+	  View:      CalendarViewWeek,
+	  Value:     "2024-05-15",
+	  StartHour: 8,
+	  EndHour:   18,
+	  Events: []ut.IM{
+	    {"id": 1, "title": "Service visit", "start": "2024-05-15T10:00", "end": "2024-05-15T11:30"},
+	    {"id": 2, "title": "Delivery", "start": "2024-05-13T08:00", "end": "2024-05-13T09:00",
+	      "rrule": "FREQ=WEEKLY;BYDAY=MO,TH", "color": "#8e44ad"},
+	  },
+	}
+*/
+type Calendar struct {
+	BaseComponent
+	/* [CalendarView] variable constants: [CalendarViewMonth], [CalendarViewWeek], [CalendarViewDay],
+	[CalendarViewAgenda]. Default value: [CalendarViewMonth] */
+	View string `json:"view"`
+	// The current date of the view (ISO 8601 date). Default value: the current date
+	Value string `json:"value"`
+	/* The calendar event rows. The fields of the rows: id, title, start and end (ISO 8601 date or datetime),
+	all_day (the end date of the all-day events is exclusive), color (CSS color name or hex value),
+	rrule (see [RecurrenceRule]) and exdate (comma separated dates of the excluded occurrences). */
+	Events []ut.IM `json:"events"`
+	// The first displayed hour of the week and day views (0-23). Default value: 0
+	StartHour int64 `json:"start_hour"`
+	// The last displayed hour of the week and day views (1-24). Default value: 24
+	EndHour int64 `json:"end_hour"`
+	// [CalendarSlotMinutes] variable values: 5, 10, 15, 20, 30, 60. Default value: 30
+	SlotMinutes int64 `json:"slot_minutes"`
+	// The number of the days of the agenda view. Default value: 14
+	AgendaDays int64 `json:"agenda_days"`
+	// The first day of the week is Sunday. Default value: Monday
+	SundayFirst bool `json:"sunday_first"`
+	// Disables the event creation and the drag and drop
+	ReadOnly bool `json:"readonly"`
+	// The texts of the labels of the controls. The month and weekday names can be translated by their English names.
+	Labels ut.SM `json:"labels"`
+}
+
+/*
+Returns all properties of the [Calendar]
+*/
+func (cal *Calendar) Properties() ut.IM {
+	return ut.MergeIM(
+		cal.BaseComponent.Properties(),
+		ut.IM{
+			"view":         cal.View,
+			"value":        cal.Value,
+			"events":       cal.Events,
+			"start_hour":   cal.StartHour,
+			"end_hour":     cal.EndHour,
+			"slot_minutes": cal.SlotMinutes,
+			"agenda_days":  cal.AgendaDays,
+			"sunday_first": cal.SundayFirst,
+			"readonly":     cal.ReadOnly,
+			"labels":       cal.Labels,
+		})
+}
+
+/*
+Returns the value of the property of the [Calendar] with the specified name.
+*/
+func (cal *Calendar) GetProperty(propName string) interface{} {
+	return cal.Properties()[propName]
+}
+
+/*
+It checks the value given to the property of the [Calendar] and always returns a valid value
+*/
+func (cal *Calendar) Validation(propName string, propValue interface{}) interface{} {
+	pm := map[string]func() interface{}{
+		"view": func() interface{} {
+			return cal.CheckEnumValue(ut.ToString(propValue, ""), CalendarViewMonth, CalendarView)
+		},
+		"value": func() interface{} {
+			if value, valid := calendarTime(propValue); valid {
+				return value.Format(CalendarDateFormat)
+			}
+			return calendarToday().Format(CalendarDateFormat)
+		},
+		"events": func() interface{} {
+			return ut.ToIMA(propValue, []ut.IM{})
+		},
+		"start_hour": func() interface{} {
+			if value := ut.ToInteger(propValue, 0); value > 0 && value < 24 {
+				return value
+			}
+			return int64(0)
+		},
+		"end_hour": func() interface{} {
+			if value := ut.ToInteger(propValue, 24); value > 0 && value < 24 {
+				return value
+			}
+			return int64(24)
+		},
+		"slot_minutes": func() interface{} {
+			if value := ut.ToInteger(propValue, 30); slices.Contains(CalendarSlotMinutes, value) {
+				return value
+			}
+			return int64(30)
+		},
+		"agenda_days": func() interface{} {
+			if value := ut.ToInteger(propValue, 14); value > 0 && value <= 366 {
+				return value
+			}
+			return int64(14)
+		},
+		"labels": func() interface{} {
+			value := ut.ToSM(cal.Labels, ut.SM{})
+			switch v := propValue.(type) {
+			case ut.SM:
+				value = ut.MergeSM(value, v)
+			case ut.IM:
+				value = ut.MergeSM(value, ut.IMToSM(v))
+			}
+			if len(value) == 0 {
+				value = calendarDefaultLabel
+			}
+			return value
+		},
+		"target": func() interface{} {
+			cal.SetProperty("id", cal.Id)
+			value := ut.ToString(propValue, cal.Id)
+			if value != "this" && !strings.HasPrefix(value, "#") {
+				value = "#" + value
+			}
+			return value
+		},
+	}
+	if _, found := pm[propName]; found {
+		return pm[propName]()
+	}
+	if cal.BaseComponent.GetProperty(propName) != nil {
+		return cal.BaseComponent.Validation(propName, propValue)
+	}
+	return propValue
+}
+
+/*
+Setting a property of the [Calendar] value safely. Checks the entered value.
+In case of an invalid value, the default value will be set.
+*/
+func (cal *Calendar) SetProperty(propName string, propValue interface{}) interface{} {
+	pm := map[string]func() interface{}{
+		"view": func() interface{} {
+			cal.View = cal.Validation(propName, propValue).(string)
+			return cal.View
+		},
+		"value": func() interface{} {
+			cal.Value = cal.Validation(propName, propValue).(string)
+			return cal.Value
+		},
+		"events": func() interface{} {
+			cal.Events = cal.Validation(propName, propValue).([]ut.IM)
+			return cal.Events
+		},
+		"start_hour": func() interface{} {
+			cal.StartHour = cal.Validation(propName, propValue).(int64)
+			return cal.StartHour
+		},
+		"end_hour": func() interface{} {
+			cal.EndHour = cal.Validation(propName, propValue).(int64)
+			return cal.EndHour
+		},
+		"slot_minutes": func() interface{} {
+			cal.SlotMinutes = cal.Validation(propName, propValue).(int64)
+			return cal.SlotMinutes
+		},
+		"agenda_days": func() interface{} {
+			cal.AgendaDays = cal.Validation(propName, propValue).(int64)
+			return cal.AgendaDays
+		},
+		"sunday_first": func() interface{} {
+			cal.SundayFirst = ut.ToBoolean(propValue, false)
+			return cal.SundayFirst
+		},
+		"readonly": func() interface{} {
+			cal.ReadOnly = ut.ToBoolean(propValue, false)
+			return cal.ReadOnly
+		},
+		"labels": func() interface{} {
+			cal.Labels = cal.Validation(propName, propValue).(ut.SM)
+			return cal.Labels
+		},
+		"target": func() interface{} {
+			cal.Target = cal.Validation(propName, propValue).(string)
+			return cal.Target
+		},
+	}
+	if _, found := pm[propName]; found {
+		return cal.SetRequestValue(propName, pm[propName](), []string{})
+	}
+	if cal.BaseComponent.GetProperty(propName) != nil {
+		return cal.BaseComponent.SetProperty(propName, propValue)
+	}
+	return propValue
+}
+
+// Returns the wall clock time of a date or datetime value
+func calendarTime(value interface{}) (tm time.Time, valid bool) {
+	tm, valid = value.(time.Time)
+	if !valid {
+		var err error
+		tm, err = ut.StringToDateTime(ut.ToString(value, ""))
+		valid = (err == nil)
+	}
+	return time.Date(tm.Year(), tm.Month(), tm.Day(), tm.Hour(), tm.Minute(), 0, 0, time.UTC), valid
+}
+
+func calendarDate(tm time.Time) time.Time {
+	return time.Date(tm.Year(), tm.Month(), tm.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func calendarToday() time.Time {
+	return calendarDate(time.Now())
+}
+
+func calendarFormat(tm time.Time, allDay bool) string {
+	if allDay {
+		return tm.Format(CalendarDateFormat)
+	}
+	return tm.Format(CalendarTimeFormat)
+}
+
+func (cal *Calendar) msg(labelID string) string {
+	if label, found := cal.Labels[labelID]; found {
+		return label
+	}
+	return labelID
+}
+
+func (cal *Calendar) date() time.Time {
+	tm, _ := calendarTime(cal.Value)
+	return tm
+}
+
+func (cal *Calendar) slot() time.Duration {
+	return time.Duration(cal.SlotMinutes) * time.Minute
+}
+
+func (cal *Calendar) weekStart(date time.Time) time.Time {
+	first := time.Monday
+	if cal.SundayFirst {
+		first = time.Sunday
+	}
+	return date.AddDate(0, 0, -((int(date.Weekday()) - int(first) + 7) % 7))
+}
+
+// Returns the first day and the day after the last day of the displayed period
+func (cal *Calendar) period() (start, end time.Time) {
+	date := cal.date()
+	switch cal.View {
+	case CalendarViewWeek:
+		start = cal.weekStart(date)
+		return start, start.AddDate(0, 0, 7)
+	case CalendarViewDay:
+		return date, date.AddDate(0, 0, 1)
+	case CalendarViewAgenda:
+		return date, date.AddDate(0, 0, int(cal.AgendaDays))
+	}
+	first := date.AddDate(0, 0, 1-date.Day())
+	return cal.weekStart(first), cal.weekStart(first.AddDate(0, 1, -1)).AddDate(0, 0, 7)
+}
+
+// Returns the current date of the previous or the next period
+func (cal *Calendar) step(direction int) time.Time {
+	date := cal.date()
+	switch cal.View {
+	case CalendarViewWeek:
+		return date.AddDate(0, 0, 7*direction)
+	case CalendarViewDay:
+		return date.AddDate(0, 0, direction)
+	case CalendarViewAgenda:
+		return date.AddDate(0, 0, int(cal.AgendaDays)*direction)
+	}
+	return date.AddDate(0, direction, 1-date.Day())
+}
+
+func (cal *Calendar) title() string {
+	start, end := cal.period()
+	monthDay := func(tm time.Time) string {
+		return fmt.Sprintf("%s %d", cal.msg(tm.Month().String()), tm.Day())
+	}
+	date := cal.date()
+	switch cal.View {
+	case CalendarViewWeek, CalendarViewAgenda:
+		return fmt.Sprintf("%s – %s, %d", monthDay(start), monthDay(end.AddDate(0, 0, -1)), end.AddDate(0, 0, -1).Year())
+	case CalendarViewDay:
+		return fmt.Sprintf("%s, %s, %d", cal.msg(date.Weekday().String()), monthDay(date), date.Year())
+	}
+	return fmt.Sprintf("%s %d", cal.msg(date.Month().String()), date.Year())
+}
+
+// Returns the start and the end time of the event row
+func (cal *Calendar) eventTimes(event ut.IM) (start, end time.Time, allDay, valid bool) {
+	if start, valid = calendarTime(event["start"]); !valid {
+		return start, end, allDay, valid
+	}
+	value, isString := event["start"].(string)
+	allDay = ut.ToBoolean(event["all_day"], false) || (isString && len(value) == len(CalendarDateFormat))
+	duration := time.Hour
+	if allDay {
+		start, duration = calendarDate(start), 24*time.Hour
+	}
+	if end, _ = calendarTime(event["end"]); !end.After(start) {
+		end = start.Add(duration)
+	}
+	return start, end, allDay, valid
+}
+
+// A displayed occurrence of a calendar event
+type calendarItem struct {
+	Index      int
+	Title      string
+	Color      string
+	Time       string
+	Start, End time.Time
+	AllDay     bool
+	// The position of the timed item in the day column (percent values)
+	Top, Height, Left, Width float64
+	Resize                   bool
+	// The htmx trigger id and the hx-vals value of the item
+	Id, Vals string
+}
+
+// An empty time slot or day cell of the calendar
+type calendarSlot struct {
+	Label      string
+	Start, End time.Time
+	AllDay     bool
+	Hour       bool
+	// The htmx trigger id and the hx-vals value of the slot
+	Id, Vals string
+}
+
+type calendarDay struct {
+	calendarSlot
+	Outside bool
+	Today   bool
+	// The all-day and the month view items
+	Items []calendarItem
+	// The timed items of the week and day views
+	Timed []calendarItem
+	Slots []calendarSlot
+}
+
+// Returns the event occurrences of the [from, to) interval in chronological order
+func (cal *Calendar) occurrences(from, to time.Time) (items []calendarItem) {
+	for index, event := range cal.Events {
+		start, end, allDay, valid := cal.eventTimes(event)
+		if !valid {
+			continue
+		}
+		duration := end.Sub(start)
+		starts := []time.Time{start}
+		if rule, err := ParseRecurrenceRule(ut.ToString(event["rrule"], "")); err == nil {
+			starts = rule.Occurrences(start, from.Add(-duration), to)
+		}
+		exdate := strings.Split(ut.ToString(event["exdate"], ""), ",")
+		for _, occ := range starts {
+			if slices.ContainsFunc(exdate, func(value string) bool {
+				tm, valid := calendarTime(strings.TrimSpace(value))
+				return valid && calendarDate(tm).Equal(calendarDate(occ))
+			}) {
+				continue
+			}
+			if occ.Add(duration).After(from) && occ.Before(to) {
+				items = append(items, calendarItem{
+					Index: index, Title: ut.ToString(event["title"], ""), Color: ut.ToString(event["color"], ""),
+					Start: occ, End: occ.Add(duration), AllDay: allDay,
+				})
+			}
+		}
+	}
+	slices.SortStableFunc(items, func(a, b calendarItem) int {
+		return a.Start.Compare(b.Start)
+	})
+	return items
+}
+
+// Sets the horizontal positions of the overlapping timed items
+func calendarLanes(items []calendarItem) {
+	first, lanes := 0, []time.Time{}
+	var clusterEnd time.Time
+	flush := func(last int) {
+		for index := first; index < last; index++ {
+			items[index].Width = 100 / float64(len(lanes))
+			items[index].Left *= items[index].Width
+		}
+	}
+	for index := range items {
+		if index > 0 && !items[index].Start.Before(clusterEnd) {
+			flush(index)
+			first, lanes = index, []time.Time{}
+		}
+		lane := slices.IndexFunc(lanes, func(end time.Time) bool {
+			return !end.After(items[index].Start)
+		})
+		if lane < 0 {
+			lane, lanes = len(lanes), append(lanes, items[index].End)
+		}
+		lanes[lane] = items[index].End
+		items[index].Left = float64(lane)
+		if items[index].End.After(clusterEnd) {
+			clusterEnd = items[index].End
+		}
+	}
+	flush(len(items))
+}
+
+// Calculates the displayed days of the current view and the htmx trigger ids of the slots and the items
+func (cal *Calendar) days() (days []calendarDay, ids []string) {
+	start, end := cal.period()
+	items := cal.occurrences(start, end)
+	today, date := calendarToday(), cal.date()
+	count := map[string]int{}
+	event := func(kind string, values ut.IM) (id, vals string) {
+		if cal.EventURL == "" {
+			return "", ""
+		}
+		count[kind]++
+		data, _ := json.Marshal(values)
+		id = fmt.Sprintf("%s_%s_%d", cal.Id, kind, count[kind])
+		ids = append(ids, id)
+		return id, string(data)
+	}
+	slotEvent := func(slot *calendarSlot) {
+		if !cal.ReadOnly {
+			slot.Id, slot.Vals = event("slot", ut.IM{
+				"start": slot.Start.Format(CalendarTimeFormat), "end": slot.End.Format(CalendarTimeFormat), "all_day": slot.AllDay,
+			})
+		}
+	}
+	itemEvent := func(item calendarItem) calendarItem {
+		item.Id, item.Vals = event("item", ut.IM{"index": item.Index, "from": item.Start.Format(CalendarTimeFormat)})
+		item.Resize = (item.Id != "") && !cal.ReadOnly && !item.AllDay
+		return item
+	}
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		cd := calendarDay{
+			calendarSlot: calendarSlot{Label: fmt.Sprintf("%s %d", cal.msg(day.Weekday().String()[:3]), day.Day()),
+				Start: day, End: day.AddDate(0, 0, 1), AllDay: true},
+			Outside: (cal.View == CalendarViewMonth && day.Month() != date.Month()),
+			Today:   day.Equal(today),
+		}
+		if cal.View == CalendarViewMonth {
+			cd.Label = fmt.Sprint(day.Day())
+		}
+		if cal.View != CalendarViewAgenda {
+			slotEvent(&cd.calendarSlot)
+		}
+		winStart := day.Add(time.Duration(cal.StartHour) * time.Hour)
+		winEnd := day.Add(time.Duration(max(cal.EndHour, cal.StartHour+1)) * time.Hour)
+		for _, item := range items {
+			if !item.End.After(day) || !item.Start.Before(cd.End) {
+				continue
+			}
+			if item.AllDay || cal.View == CalendarViewMonth || cal.View == CalendarViewAgenda {
+				switch {
+				case item.AllDay:
+				case cal.View == CalendarViewAgenda:
+					item.Time = item.Start.Format("15:04") + " - " + item.End.Format("15:04")
+				case !item.Start.Before(day):
+					item.Time = item.Start.Format("15:04")
+				}
+				cd.Items = append(cd.Items, itemEvent(item))
+				continue
+			}
+			if item.End.After(winStart) && item.Start.Before(winEnd) {
+				top, bottom := item.Start, item.End
+				if top.Before(winStart) {
+					top = winStart
+				}
+				if bottom.After(winEnd) {
+					bottom = winEnd
+				}
+				item.Time = item.Start.Format("15:04") + " - " + item.End.Format("15:04")
+				item.Top = float64(top.Sub(winStart)) / float64(winEnd.Sub(winStart)) * 100
+				item.Height = float64(bottom.Sub(top)) / float64(winEnd.Sub(winStart)) * 100
+				cd.Timed = append(cd.Timed, itemEvent(item))
+			}
+		}
+		calendarLanes(cd.Timed)
+		if cal.View == CalendarViewWeek || cal.View == CalendarViewDay {
+			for tm := winStart; tm.Before(winEnd); tm = tm.Add(cal.slot()) {
+				slot := calendarSlot{Label: tm.Format("15:04"), Start: tm, End: tm.Add(cal.slot()), Hour: tm.Minute() == 0}
+				slotEvent(&slot)
+				cd.Slots = append(cd.Slots, slot)
+			}
+		}
+		days = append(days, cd)
+	}
+	return days, ids
+}
+
+/*
+If the OnResponse function of the [Calendar] is implemented, the function calls it after the [TriggerEvent]
+is processed, otherwise the function's return [ResponseEvent] is the processed [TriggerEvent].
+*/
+func (cal *Calendar) OnRequest(te TriggerEvent) (re ResponseEvent) {
+	evt := ResponseEvent{Trigger: cal, TriggerName: cal.Name}
+	start, _ := calendarTime(te.Values.Get("start"))
+	end, _ := calendarTime(te.Values.Get("end"))
+	allDay := ut.ToBoolean(te.Values.Get("all_day"), false)
+	index := int(ut.ToInteger(te.Values.Get("index"), -1))
+	switch action := te.Values.Get("action"); {
+	case !te.Values.Has("index"):
+		evt.Name = CalendarEventCreate
+		evt.Value = ut.IM{"start": calendarFormat(start, allDay), "end": calendarFormat(end, allDay), "all_day": allDay}
+	case index < 0 || index >= len(cal.Events):
+		evt.Name = CalendarEventSelected
+		evt.Value = ut.IM{"index": index}
+	case action == "move" || action == "resize":
+		from, _ := calendarTime(te.Values.Get("from"))
+		evt.Name, evt.Value = cal.moveEvent(index, action, from, start, end, allDay)
+	default:
+		from, _ := calendarTime(te.Values.Get("from"))
+		evt.Name = CalendarEventSelected
+		evt.Value = ut.IM{"index": index, "event": cal.Events[index], "start": from.Format(CalendarTimeFormat)}
+	}
+	if cal.OnResponse != nil {
+		return cal.OnResponse(evt)
+	}
+	return evt
+}
+
+/*
+Moves or resizes the occurrence of the event (the from value) to the start or the end time of the dropped slot,
+and updates the start and the end values of the event row. The recurring events are shifted as a whole.
+*/
+func (cal *Calendar) moveEvent(index int, action string, from, start, end time.Time, allDay bool) (name string, value ut.IM) {
+	event := cal.Events[index]
+	evStart, evEnd, evAllDay, _ := cal.eventTimes(event)
+	occEnd := from.Add(evEnd.Sub(evStart))
+	name = CalendarEventMove
+	if action == "resize" {
+		name = CalendarEventResize
+		if !end.After(from) {
+			end = from.Add(cal.slot())
+		}
+		occEnd, evEnd = end, evStart.Add(end.Sub(from))
+	} else {
+		delta := start.Sub(from)
+		if allDay || evAllDay {
+			// the time of the day is kept
+			delta = calendarDate(start).Sub(calendarDate(from))
+		}
+		evStart, evEnd, from, occEnd = evStart.Add(delta), evEnd.Add(delta), from.Add(delta), occEnd.Add(delta)
+	}
+	event["start"], event["end"] = calendarFormat(evStart, evAllDay), calendarFormat(evEnd, evAllDay)
+	return name, ut.IM{
+		"index": index, "event": event,
+		"start": calendarFormat(from, evAllDay), "end": calendarFormat(occEnd, evAllDay),
+	}
+}
+
+func (cal *Calendar) response(evt ResponseEvent) (re ResponseEvent) {
+	data := ut.ToIM(evt.Trigger.GetProperty("data"), ut.IM{})
+	if view, found := data["view"]; found {
+		cal.SetProperty("view", view)
+	}
+	if value, found := data["value"]; found {
+		cal.SetProperty("value", value)
+	}
+	start, end := cal.period()
+	calEvt := ResponseEvent{
+		Trigger: cal, TriggerName: cal.Name, Name: CalendarEventNavigate,
+		Value: ut.IM{
+			"view": cal.View, "value": cal.Value,
+			"start": start.Format(CalendarDateFormat), "end": end.Format(CalendarDateFormat),
+		},
+	}
+	if cal.OnResponse != nil {
+		return cal.OnResponse(calEvt)
+	}
+	return calEvt
+}
+
+func (cal *Calendar) getComponent(name string) (html template.HTML, err error) {
+	ccBtn := func(label string, data ut.IM, selected bool) *Button {
+		btn := &Button{
+			BaseComponent: BaseComponent{
+				Id: cal.Id + "_" + name, Name: name,
+				Data:         data,
+				EventURL:     cal.EventURL,
+				Target:       cal.Target,
+				OnResponse:   cal.response,
+				RequestValue: cal.RequestValue,
+				RequestMap:   cal.RequestMap,
+			},
+			ButtonStyle: ButtonStyleBorder,
+			Label:       label,
+			Selected:    selected,
+		}
+		return btn
+	}
+	ccMap := map[string]func() ClientComponent{
+		"calendar_previous": func() ClientComponent {
+			return ccBtn("❮", ut.IM{"value": cal.step(-1).Format(CalendarDateFormat)}, false)
+		},
+		"calendar_next": func() ClientComponent {
+			return ccBtn("❯", ut.IM{"value": cal.step(1).Format(CalendarDateFormat)}, false)
+		},
+		"calendar_today": func() ClientComponent {
+			return ccBtn(cal.msg("calendar_today"), ut.IM{"value": calendarToday().Format(CalendarDateFormat)}, false)
+		},
+	}
+	for _, view := range CalendarView {
+		ccMap["calendar_"+view] = func() ClientComponent {
+			return ccBtn(cal.msg("calendar_"+view), ut.IM{"view": view}, cal.View == view)
+		}
+	}
+	return ccMap[name]().Render()
+}
+
+/*
+Based on the values, it will generate the html code of the [Calendar] or return with an error message.
+*/
+func (cal *Calendar) Render() (html template.HTML, err error) {
+	return RenderHTML(cal)
+}
+
+// the render data of the [Calendar] template
+type calendarRender struct {
+	*Calendar
+	days []calendarDay
+}
+
+// [Calendar] template functions
+var calendarFuncMap = map[string]any{
+	"styleMap": func(cal *calendarRender) bool {
+		return len(cal.Style) > 0
+	},
+	"customClass": func(cal *calendarRender) string {
+		return strings.Join(cal.Class, " ")
+	},
+	"calendarComponent": func(cal *calendarRender, name string) (template.HTML, error) {
+		return cal.getComponent(name)
+	},
+	"msg": (*calendarRender).msg,
+	"num": func(value float64) string {
+		return chartNumber(value)
+	},
+	"title": (*calendarRender).title,
+	"views": func() []string {
+		return CalendarView
+	},
+	"days": func(cal *calendarRender) []calendarDay {
+		return cal.days
+	},
+	"weeks": func(cal *calendarRender) (weeks [][]calendarDay) {
+		for index := 0; index < len(cal.days); index += 7 {
+			weeks = append(weeks, cal.days[index:index+7])
+		}
+		return weeks
+	},
+	"agendaDays": func(cal *calendarRender) (result []calendarDay) {
+		return slices.DeleteFunc(slices.Clone(cal.days), func(day calendarDay) bool {
+			return len(day.Items) == 0
+		})
+	},
+	"dragDrop": func(cal *calendarRender) bool {
+		return cal.EventURL != "" && !cal.ReadOnly && cal.View != CalendarViewAgenda
+	},
+}
+
+/*
+Based on the values, it will write the html code of the [Calendar] into the writer or return with an error message.
+*/
+func (cal *Calendar) RenderTo(w io.Writer) (err error) {
+	cal.InitProps(cal)
+	days, ids := cal.days()
+
+	event := `{{ if ne .Id "" }} id="{{ .Id }}" hx-post="{{ $.EventURL }}" hx-target="{{ $.Target }}" {{ if ne $.Sync "none" }} hx-sync="{{ $.Sync }}"{{ end }} hx-swap="{{ $.Swap }}" hx-vals="{{ .Vals }}"
+	{{ if ne $.Indicator "none" }} hx-indicator="#{{ $.Indicator }}"{{ end }}{{ end }}`
+	slot := event + `{{ if ne .Id "" }} data-calendar-slot="true"{{ end }}`
+	item := event + `{{ if ne .Id "" }} hx-trigger="click consume"{{ if dragDrop $ }} draggable="true"{{ end }}{{ end }}
+	 title="{{ .Title }}{{ if ne .Time "" }} ({{ .Time }}){{ end }}"`
+	itemColor := `{{ if ne .Color "" }}background-color:{{ .Color }};{{ end }}`
+	tpl := `<div id="{{ .Id }}" name="{{ .Name }}" class="calendar {{ customClass $ }}"
+	{{ if styleMap $ }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}
+	><div class="calendar-toolbar">
+	<div class="calendar-nav">{{ calendarComponent $ "calendar_previous" }}{{ calendarComponent $ "calendar_today" }}{{ calendarComponent $ "calendar_next" }}</div>
+	<div class="calendar-title">{{ title $ }}</div>
+	<div class="calendar-views">{{ range views }}{{ calendarComponent $ (print "calendar_" .) }}{{ end }}</div>
+	</div>
+	{{ if eq .View "month" }}<div class="calendar-month">
+	<div class="calendar-week calendar-header">{{ range (index (weeks $) 0) }}<div class="calendar-weekday">{{ msg $ (print .Start.Weekday | printf "%.3s") }}</div>{{ end }}</div>
+	{{ range weeks $ }}<div class="calendar-week">{{ range . }}<div ` + slot + `
+	 class="calendar-cell{{ if .Outside }} calendar-outside{{ end }}{{ if .Today }} calendar-today{{ end }}"
+	><div class="calendar-date">{{ .Label }}</div>
+	{{ range .Items }}<div ` + item + ` class="calendar-event{{ if .AllDay }} calendar-all-day{{ end }}"
+	{{ if ne .Color "" }} style="` + itemColor + `"{{ end }}>{{ if ne .Time "" }}<span class="calendar-event-time">{{ .Time }}</span> {{ end }}{{ .Title }}</div>{{ end }}
+	</div>{{ end }}</div>{{ end }}
+	</div>{{ end }}
+	{{ if or (eq .View "week") (eq .View "day") }}<div class="calendar-grid">
+	<div class="calendar-row calendar-header"><div class="calendar-gutter"></div>
+	{{ range days $ }}<div class="calendar-day-header{{ if .Today }} calendar-today{{ end }}">{{ .Label }}</div>{{ end }}</div>
+	<div class="calendar-row"><div class="calendar-gutter calendar-slot-label">{{ msg $ "calendar_all_day" }}</div>
+	{{ range days $ }}<div ` + slot + ` class="calendar-all-day-cell">
+	{{ range .Items }}<div ` + item + ` class="calendar-event calendar-all-day"
+	{{ if ne .Color "" }} style="` + itemColor + `"{{ end }}>{{ .Title }}</div>{{ end }}
+	</div>{{ end }}</div>
+	<div class="calendar-row calendar-body"><div class="calendar-gutter">
+	{{ range (index (days $) 0).Slots }}<div class="calendar-slot-label">{{ if .Hour }}{{ .Label }}{{ end }}</div>{{ end }}</div>
+	{{ range days $ }}<div class="calendar-column">
+	{{ range .Slots }}<div ` + slot + ` class="calendar-slot{{ if .Hour }} calendar-hour{{ end }}"></div>{{ end }}
+	{{ range .Timed }}<div ` + item + ` class="calendar-event calendar-timed"
+	 style="top:{{ num .Top }}%;height:{{ num .Height }}%;left:{{ num .Left }}%;width:{{ num .Width }}%;` + itemColor + `"
+	><span class="calendar-event-time">{{ .Time }}</span> {{ .Title }}
+	{{ if .Resize }}<div class="calendar-resize" draggable="true"></div>{{ end }}</div>{{ end }}
+	</div>{{ end }}</div>
+	</div>{{ end }}
+	{{ if eq .View "agenda" }}<div class="calendar-agenda">
+	{{ range agendaDays $ }}<div class="calendar-agenda-day{{ if .Today }} calendar-today{{ end }}">
+	<div class="calendar-agenda-date">{{ .Label }}</div><div class="calendar-agenda-items">
+	{{ range .Items }}<div ` + item + ` class="calendar-agenda-item">
+	<span class="calendar-event-dot"{{ if ne .Color "" }} style="` + itemColor + `"{{ end }}></span>
+	<span class="calendar-event-time">{{ if ne .Time "" }}{{ .Time }}{{ else }}{{ msg $ "calendar_all_day" }}{{ end }}</span>
+	<span>{{ .Title }}</span></div>{{ end }}
+	</div></div>{{ else }}<div class="calendar-empty">{{ msg $ "calendar_no_events" }}</div>{{ end }}
+	</div>{{ end }}
+	{{ if dragDrop $ }}<script>
+	(function() {
+		var calendar = htmx.find('#{{ .Id }}');
+		var drag = null;
+		calendar.addEventListener('dragstart', function(evt) {
+			var item = evt.target.closest('.calendar-event');
+			if (!item) { return; }
+			drag = JSON.parse(item.getAttribute('hx-vals'));
+			drag.action = evt.target.classList.contains('calendar-resize') ? 'resize' : 'move';
+			evt.dataTransfer.effectAllowed = 'move';
+			evt.dataTransfer.setData('text/plain', item.id);
+		});
+		calendar.addEventListener('dragover', function(evt) {
+			if (drag && evt.target.closest('[data-calendar-slot]')) { evt.preventDefault(); }
+		});
+		calendar.addEventListener('drop', function(evt) {
+			var slot = evt.target.closest('[data-calendar-slot]');
+			if (!drag || !slot) { return; }
+			evt.preventDefault();
+			htmx.ajax('POST', {{ .EventURL }}, {
+				source: slot, target: {{ .Target }}, swap: {{ .Swap }},
+				values: {action: drag.action, index: drag.index, from: drag.from}
+			});
+			drag = null;
+		});
+	})();
+	</script>{{ end }}
+	</div>`
+
+	if err = ut.CachedTemplateWriter(w, "calendar", tpl, calendarFuncMap, &calendarRender{Calendar: cal, days: days}); err == nil && cal.EventURL != "" {
+		cal.SetProperty("request_map", cal)
+		// the htmx trigger ids of the slots and the items
+		for _, id := range ids {
+			cal.RequestMap[id] = cal
+		}
+	}
+	return err
+}
+
+var testCalendarResponse func(evt ResponseEvent) (re ResponseEvent) = func(evt ResponseEvent) (re ResponseEvent) {
+	value := ut.ToIM(evt.Value, ut.IM{})
+	switch evt.Name {
+	case CalendarEventCreate:
+		cal := evt.Trigger.(*Calendar)
+		cal.SetProperty("events", append(cal.Events, ut.IM{
+			"id": len(cal.Events) + 1, "title": "New event",
+			"start": value["start"], "end": value["end"], "all_day": value["all_day"],
+		}))
+	case CalendarEventSelected:
+		event := ut.ToIM(value["event"], ut.IM{})
+		return ResponseEvent{
+			Trigger: &Toast{
+				Type:    ToastTypeInfo,
+				Value:   fmt.Sprintf("%s: %s", ut.ToString(event["title"], ""), ut.ToString(value["start"], "")),
+				Timeout: 4,
+			},
+			TriggerName: evt.TriggerName,
+			Name:        evt.Name,
+			Header: ut.SM{
+				HeaderRetarget: "#toast-msg",
+				HeaderReswap:   SwapInnerHTML,
+			},
+		}
+	}
+	return evt
+}
+
+func testCalendarEvents() []ut.IM {
+	return []ut.IM{
+		{"id": 1, "title": "Service visit - Kovacs Ltd.", "start": "2024-05-15T10:00", "end": "2024-05-15T11:30"},
+		{"id": 2, "title": "Delivery route", "start": "2024-05-06T08:00", "end": "2024-05-06T10:00",
+			"rrule": "FREQ=WEEKLY;BYDAY=MO,TH", "exdate": "2024-05-09", "color": "#8e44ad"},
+		{"id": 3, "title": "Inventory", "start": "2024-05-31T13:00", "end": "2024-05-31T16:00",
+			"rrule": "FREQ=MONTHLY;BYDAY=-1FR", "color": "#d2697d"},
+		{"id": 4, "title": "Trade fair", "start": "2024-05-21", "end": "2024-05-24", "color": "green"},
+		{"id": 5, "title": "Maintenance", "start": "2024-05-15T10:30", "end": "2024-05-15T12:00", "color": "orange"},
+		{"id": 6, "title": "Team meeting", "start": "2024-05-13T09:00", "end": "2024-05-13T10:00",
+			"rrule": "FREQ=DAILY;BYDAY=MO,WE,FR;COUNT=12"},
+	}
+}
+
+// [Calendar] test and demo data
+func TestCalendar(cc ClientComponent) []TestComponent {
+	id := ut.ToString(cc.GetProperty("id"), "")
+	eventURL := ut.ToString(cc.GetProperty("event_url"), "")
+	requestValue := cc.GetProperty("request_value").(map[string]ut.IM)
+	requestMap := cc.GetProperty("request_map").(map[string]ClientComponent)
+	base := func(name string) BaseComponent {
+		return BaseComponent{
+			Id:           id + "_calendar_" + name,
+			EventURL:     eventURL,
+			OnResponse:   testCalendarResponse,
+			RequestValue: requestValue,
+			RequestMap:   requestMap,
+		}
+	}
+	return []TestComponent{
+		{
+			Label:         "Month view",
+			ComponentType: ComponentTypeCalendar,
+			Component: &Calendar{
+				BaseComponent: base("month"),
+				Value:         "2024-05-15",
+				Events:        testCalendarEvents(),
+			}},
+		{
+			Label:         "Week view",
+			ComponentType: ComponentTypeCalendar,
+			Component: &Calendar{
+				BaseComponent: base("week"),
+				View:          CalendarViewWeek,
+				Value:         "2024-05-15",
+				Events:        testCalendarEvents(),
+				StartHour:     8,
+				EndHour:       18,
+			}},
+		{
+			Label:         "Read-only day view",
+			ComponentType: ComponentTypeCalendar,
+			Component: &Calendar{
+				BaseComponent: base("day"),
+				View:          CalendarViewDay,
+				Value:         "2024-05-15",
+				Events:        testCalendarEvents(),
+				StartHour:     8,
+				EndHour:       16,
+				SlotMinutes:   60,
+				ReadOnly:      true,
+			}},
+		{
+			Label:         "Agenda view",
+			ComponentType: ComponentTypeCalendar,
+			Component: &Calendar{
+				BaseComponent: base("agenda"),
+				View:          CalendarViewAgenda,
+				Value:         "2024-05-13",
+				Events:        testCalendarEvents(),
+				SundayFirst:   true,
+			}},
+	}
+}
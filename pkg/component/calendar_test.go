@@ -0,0 +1,672 @@
+package component
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	ut "github.com/nervatura/component/pkg/util"
+)
+
+func TestTestCalendar(t *testing.T) {
+	for _, tt := range TestCalendar(&BaseComponent{EventURL: "/demo"}) {
+		t.Run(tt.Label, func(t *testing.T) {
+			tt.Component.Render()
+		})
+	}
+	cal := &Calendar{}
+	testCalendarResponse(ResponseEvent{Trigger: cal, Name: CalendarEventCreate, Value: ut.IM{"start": "2024-05-15"}})
+	if len(cal.Events) != 1 {
+		t.Errorf("testCalendarResponse() events = %v", cal.Events)
+	}
+	testCalendarResponse(ResponseEvent{Trigger: cal, Name: CalendarEventSelected, Value: ut.IM{"event": cal.Events[0]}})
+	testCalendarResponse(ResponseEvent{Trigger: cal, Name: CalendarEventNavigate})
+}
+
+func TestCalendar_GetProperty(t *testing.T) {
+	tests := []struct {
+		name     string
+		propName string
+		want     interface{}
+	}{
+		{
+			name:     "view",
+			propName: "view",
+			want:     CalendarViewWeek,
+		},
+		{
+			name:     "start_hour",
+			propName: "start_hour",
+			want:     int64(8),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cal := &Calendar{View: CalendarViewWeek, StartHour: 8}
+			if got := cal.GetProperty(tt.propName); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Calendar.GetProperty() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalendar_Validation(t *testing.T) {
+	type args struct {
+		propName  string
+		propValue interface{}
+	}
+	tests := []struct {
+		name   string
+		labels ut.SM
+		args   args
+		want   interface{}
+	}{
+		{
+			name: "base",
+			args: args{
+				propName:  "id",
+				propValue: "CALID",
+			},
+			want: "CALID",
+		},
+		{
+			name: "invalid",
+			args: args{
+				propName:  "invalid",
+				propValue: "",
+			},
+			want: "",
+		},
+		{
+			name: "view",
+			args: args{
+				propName:  "view",
+				propValue: "year",
+			},
+			want: CalendarViewMonth,
+		},
+		{
+			name: "value",
+			args: args{
+				propName:  "value",
+				propValue: time.Date(2024, 5, 15, 10, 30, 0, 0, time.Local),
+			},
+			want: "2024-05-15",
+		},
+		{
+			name: "value_invalid",
+			args: args{
+				propName:  "value",
+				propValue: "today",
+			},
+			want: time.Now().Format(CalendarDateFormat),
+		},
+		{
+			name: "events",
+			args: args{
+				propName:  "events",
+				propValue: []interface{}{ut.IM{"title": "Meeting"}},
+			},
+			want: []ut.IM{{"title": "Meeting"}},
+		},
+		{
+			name: "start_hour",
+			args: args{
+				propName:  "start_hour",
+				propValue: 8,
+			},
+			want: int64(8),
+		},
+		{
+			name: "start_hour_invalid",
+			args: args{
+				propName:  "start_hour",
+				propValue: 24,
+			},
+			want: int64(0),
+		},
+		{
+			name: "end_hour",
+			args: args{
+				propName:  "end_hour",
+				propValue: 18,
+			},
+			want: int64(18),
+		},
+		{
+			name: "end_hour_invalid",
+			args: args{
+				propName:  "end_hour",
+				propValue: 0,
+			},
+			want: int64(24),
+		},
+		{
+			name: "slot_minutes",
+			args: args{
+				propName:  "slot_minutes",
+				propValue: 15,
+			},
+			want: int64(15),
+		},
+		{
+			name: "slot_minutes_invalid",
+			args: args{
+				propName:  "slot_minutes",
+				propValue: 7,
+			},
+			want: int64(30),
+		},
+		{
+			name: "agenda_days",
+			args: args{
+				propName:  "agenda_days",
+				propValue: 7,
+			},
+			want: int64(7),
+		},
+		{
+			name: "agenda_days_invalid",
+			args: args{
+				propName:  "agenda_days",
+				propValue: 0,
+			},
+			want: int64(14),
+		},
+		{
+			name: "labels_default",
+			args: args{
+				propName:  "labels",
+				propValue: nil,
+			},
+			want: calendarDefaultLabel,
+		},
+		{
+			name:   "labels_sm",
+			labels: ut.SM{"calendar_today": "Today"},
+			args: args{
+				propName:  "labels",
+				propValue: ut.SM{"May": "Mai"},
+			},
+			want: ut.SM{"calendar_today": "Today", "May": "Mai"},
+		},
+		{
+			name: "labels_im",
+			args: args{
+				propName:  "labels",
+				propValue: ut.IM{"calendar_today": "Heute"},
+			},
+			want: ut.SM{"calendar_today": "Heute"},
+		},
+		{
+			name: "target",
+			args: args{
+				propName:  "target",
+				propValue: "",
+			},
+			want: "#CALID",
+		},
+		{
+			name: "target_this",
+			args: args{
+				propName:  "target",
+				propValue: "this",
+			},
+			want: "this",
+		},
+		{
+			name: "target_id",
+			args: args{
+				propName:  "target",
+				propValue: "#target",
+			},
+			want: "#target",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cal := &Calendar{BaseComponent: BaseComponent{Id: "CALID"}, Labels: tt.labels}
+			if got := cal.Validation(tt.args.propName, tt.args.propValue); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Calendar.Validation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalendar_SetProperty(t *testing.T) {
+	type args struct {
+		propName  string
+		propValue interface{}
+	}
+	tests := []struct {
+		name string
+		args args
+		want interface{}
+	}{
+		{
+			name: "base",
+			args: args{
+				propName:  "id",
+				propValue: "CALID",
+			},
+			want: "CALID",
+		},
+		{
+			name: "invalid",
+			args: args{
+				propName:  "invalid",
+				propValue: "",
+			},
+			want: "",
+		},
+		{
+			name: "view",
+			args: args{
+				propName:  "view",
+				propValue: CalendarViewDay,
+			},
+			want: CalendarViewDay,
+		},
+		{
+			name: "value",
+			args: args{
+				propName:  "value",
+				propValue: "2024-05-15T10:00",
+			},
+			want: "2024-05-15",
+		},
+		{
+			name: "events",
+			args: args{
+				propName:  "events",
+				propValue: []ut.IM{{"title": "Meeting"}},
+			},
+			want: []ut.IM{{"title": "Meeting"}},
+		},
+		{
+			name: "start_hour",
+			args: args{
+				propName:  "start_hour",
+				propValue: 8,
+			},
+			want: int64(8),
+		},
+		{
+			name: "end_hour",
+			args: args{
+				propName:  "end_hour",
+				propValue: 18,
+			},
+			want: int64(18),
+		},
+		{
+			name: "slot_minutes",
+			args: args{
+				propName:  "slot_minutes",
+				propValue: 60,
+			},
+			want: int64(60),
+		},
+		{
+			name: "agenda_days",
+			args: args{
+				propName:  "agenda_days",
+				propValue: 30,
+			},
+			want: int64(30),
+		},
+		{
+			name: "sunday_first",
+			args: args{
+				propName:  "sunday_first",
+				propValue: true,
+			},
+			want: true,
+		},
+		{
+			name: "readonly",
+			args: args{
+				propName:  "readonly",
+				propValue: true,
+			},
+			want: true,
+		},
+		{
+			name: "labels",
+			args: args{
+				propName:  "labels",
+				propValue: ut.SM{"calendar_today": "Heute"},
+			},
+			want: ut.SM{"calendar_today": "Heute"},
+		},
+		{
+			name: "target",
+			args: args{
+				propName:  "target",
+				propValue: "target",
+			},
+			want: "#target",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cal := &Calendar{}
+			if got := cal.SetProperty(tt.args.propName, tt.args.propValue); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Calendar.SetProperty() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalendar_OnRequest(t *testing.T) {
+	tests := []struct {
+		name       string
+		event      ut.IM
+		onResponse func(evt ResponseEvent) (re ResponseEvent)
+		values     url.Values
+		wantName   string
+		want       interface{}
+	}{
+		{
+			name:     "create",
+			values:   url.Values{"start": []string{"2024-05-15T10:00"}, "end": []string{"2024-05-15T10:30"}},
+			wantName: CalendarEventCreate,
+			want:     ut.IM{"start": "2024-05-15T10:00", "end": "2024-05-15T10:30", "all_day": false},
+		},
+		{
+			name: "create_all_day",
+			values: url.Values{
+				"start": []string{"2024-05-15T00:00"}, "end": []string{"2024-05-16T00:00"}, "all_day": []string{"true"},
+			},
+			wantName: CalendarEventCreate,
+			want:     ut.IM{"start": "2024-05-15", "end": "2024-05-16", "all_day": true},
+		},
+		{
+			name:     "missing_event",
+			values:   url.Values{"index": []string{"2"}},
+			wantName: CalendarEventSelected,
+			want:     ut.IM{"index": 2},
+		},
+		{
+			name:     "selected",
+			event:    ut.IM{"title": "Delivery", "start": "2024-05-13T08:00", "rrule": "FREQ=DAILY"},
+			values:   url.Values{"index": []string{"0"}, "from": []string{"2024-05-15T08:00"}},
+			wantName: CalendarEventSelected,
+			want: ut.IM{
+				"index": 0, "start": "2024-05-15T08:00",
+				"event": ut.IM{"title": "Delivery", "start": "2024-05-13T08:00", "rrule": "FREQ=DAILY"},
+			},
+		},
+		{
+			name:  "move",
+			event: ut.IM{"start": "2024-05-13T08:00", "end": "2024-05-13T09:30", "rrule": "FREQ=DAILY"},
+			values: url.Values{
+				"index": []string{"0"}, "action": []string{"move"}, "from": []string{"2024-05-15T08:00"},
+				"start": []string{"2024-05-16T10:00"}, "end": []string{"2024-05-16T10:30"},
+			},
+			wantName: CalendarEventMove,
+			want: ut.IM{
+				"index": 0, "start": "2024-05-16T10:00", "end": "2024-05-16T11:30",
+				"event": ut.IM{"start": "2024-05-14T10:00", "end": "2024-05-14T11:30", "rrule": "FREQ=DAILY"},
+			},
+		},
+		{
+			name:  "move_all_day",
+			event: ut.IM{"start": "2024-05-13T08:00", "end": "2024-05-13T09:00"},
+			values: url.Values{
+				"index": []string{"0"}, "action": []string{"move"}, "from": []string{"2024-05-13T08:00"},
+				"start": []string{"2024-05-17T00:00"}, "all_day": []string{"true"},
+			},
+			wantName: CalendarEventMove,
+			want: ut.IM{
+				"index": 0, "start": "2024-05-17T08:00", "end": "2024-05-17T09:00",
+				"event": ut.IM{"start": "2024-05-17T08:00", "end": "2024-05-17T09:00"},
+			},
+		},
+		{
+			name:  "move_all_day_event",
+			event: ut.IM{"start": "2024-05-21", "end": "2024-05-24"},
+			values: url.Values{
+				"index": []string{"0"}, "action": []string{"move"}, "from": []string{"2024-05-21T00:00"},
+				"start": []string{"2024-05-22T10:00"},
+			},
+			wantName: CalendarEventMove,
+			want: ut.IM{
+				"index": 0, "start": "2024-05-22", "end": "2024-05-25",
+				"event": ut.IM{"start": "2024-05-22", "end": "2024-05-25"},
+			},
+		},
+		{
+			name:  "resize",
+			event: ut.IM{"start": "2024-05-13T08:00", "end": "2024-05-13T09:00", "rrule": "FREQ=DAILY"},
+			values: url.Values{
+				"index": []string{"0"}, "action": []string{"resize"}, "from": []string{"2024-05-15T08:00"},
+				"start": []string{"2024-05-15T10:00"}, "end": []string{"2024-05-15T10:30"},
+			},
+			wantName: CalendarEventResize,
+			want: ut.IM{
+				"index": 0, "start": "2024-05-15T08:00", "end": "2024-05-15T10:30",
+				"event": ut.IM{"start": "2024-05-13T08:00", "end": "2024-05-13T10:30", "rrule": "FREQ=DAILY"},
+			},
+		},
+		{
+			name:  "resize_min",
+			event: ut.IM{"start": "2024-05-13T08:00", "end": "2024-05-13T09:00"},
+			values: url.Values{
+				"index": []string{"0"}, "action": []string{"resize"}, "from": []string{"2024-05-13T08:00"},
+				"start": []string{"2024-05-13T07:00"}, "end": []string{"2024-05-13T07:30"},
+			},
+			wantName: CalendarEventResize,
+			want: ut.IM{
+				"index": 0, "start": "2024-05-13T08:00", "end": "2024-05-13T08:30",
+				"event": ut.IM{"start": "2024-05-13T08:00", "end": "2024-05-13T08:30"},
+			},
+		},
+		{
+			name: "on_response",
+			onResponse: func(evt ResponseEvent) (re ResponseEvent) {
+				evt.Value = "response"
+				return evt
+			},
+			wantName: CalendarEventCreate,
+			want:     "response",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cal := &Calendar{
+				BaseComponent: BaseComponent{OnResponse: tt.onResponse},
+				SlotMinutes:   30,
+			}
+			if tt.event != nil {
+				cal.Events = []ut.IM{tt.event}
+			}
+			re := cal.OnRequest(TriggerEvent{Values: tt.values})
+			if re.Name != tt.wantName || !reflect.DeepEqual(re.Value, tt.want) {
+				t.Errorf("Calendar.OnRequest() = %v %v, want %v", re.Name, re.Value, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalendar_response(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       ut.IM
+		onResponse func(evt ResponseEvent) (re ResponseEvent)
+		want       interface{}
+	}{
+		{
+			name: "view",
+			data: ut.IM{"view": CalendarViewWeek},
+			want: ut.IM{"view": CalendarViewWeek, "value": "2024-05-15", "start": "2024-05-13", "end": "2024-05-20"},
+		},
+		{
+			name: "value",
+			data: ut.IM{"value": "2024-06-01"},
+			want: ut.IM{"view": CalendarViewMonth, "value": "2024-06-01", "start": "2024-05-27", "end": "2024-07-01"},
+		},
+		{
+			name: "on_response",
+			onResponse: func(evt ResponseEvent) (re ResponseEvent) {
+				evt.Value = "response"
+				return evt
+			},
+			want: "response",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cal := &Calendar{
+				BaseComponent: BaseComponent{OnResponse: tt.onResponse},
+				View:          CalendarViewMonth,
+				Value:         "2024-05-15",
+			}
+			re := cal.response(ResponseEvent{Trigger: &Button{BaseComponent: BaseComponent{Data: tt.data}}})
+			if re.Name != CalendarEventNavigate || !reflect.DeepEqual(re.Value, tt.want) {
+				t.Errorf("Calendar.response() = %v, want %v", re.Value, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalendar_step(t *testing.T) {
+	tests := []struct {
+		name  string
+		view  string
+		title string
+		prev  string
+		next  string
+	}{
+		{name: "month", view: CalendarViewMonth, title: "May 2024", prev: "2024-04-01", next: "2024-06-01"},
+		{name: "week", view: CalendarViewWeek, title: "May 12 – May 18, 2024", prev: "2024-05-08", next: "2024-05-22"},
+		{name: "day", view: CalendarViewDay, title: "Wednesday, May 15, 2024", prev: "2024-05-14", next: "2024-05-16"},
+		{name: "agenda", view: CalendarViewAgenda, title: "May 15 – May 28, 2024", prev: "2024-05-01", next: "2024-05-29"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cal := &Calendar{View: tt.view, Value: "2024-05-15", AgendaDays: 14, SundayFirst: true}
+			if got := cal.title(); got != tt.title {
+				t.Errorf("Calendar.title() = %v, want %v", got, tt.title)
+			}
+			if got := cal.step(-1).Format(CalendarDateFormat); got != tt.prev {
+				t.Errorf("Calendar.step(-1) = %v, want %v", got, tt.prev)
+			}
+			if got := cal.step(1).Format(CalendarDateFormat); got != tt.next {
+				t.Errorf("Calendar.step(1) = %v, want %v", got, tt.next)
+			}
+		})
+	}
+}
+
+func Test_calendarLanes(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2024, 5, 15, hour, minute, 0, 0, time.UTC)
+	}
+	items := []calendarItem{
+		{Start: at(9, 0), End: at(10, 0)},
+		{Start: at(9, 30), End: at(11, 0)},
+		{Start: at(10, 0), End: at(10, 30)},
+		{Start: at(11, 0), End: at(12, 0)},
+	}
+	calendarLanes(items)
+	want := [][2]float64{{0, 50}, {50, 50}, {0, 50}, {0, 100}}
+	for index, item := range items {
+		if item.Left != want[index][0] || item.Width != want[index][1] {
+			t.Errorf("calendarLanes() %d = %v %v, want %v", index, item.Left, item.Width, want[index])
+		}
+	}
+}
+
+func TestCalendar_Render(t *testing.T) {
+	events := []ut.IM{
+		{"title": "Invalid"},
+		{"title": "Night shift", "start": "2024-05-14T22:00", "end": "2024-05-15T06:00"},
+		{"title": "Visit", "start": "2024-05-15T10:00", "color": "red"},
+		{"title": "Holiday", "start": "2024-05-16T00:00", "all_day": true},
+		{"title": "Standup", "start": "2024-05-13T09:00", "rrule": "FREQ=DAILY", "exdate": "2024-05-15, invalid"},
+		{"title": "Hourly", "start": "2024-05-13T09:00", "rrule": "FREQ=HOURLY"},
+		{"title": "Workshop", "start": "2024-05-17T11:00", "end": "2024-05-17T14:00"},
+	}
+	tests := []struct {
+		name     string
+		calendar Calendar
+		want     []string
+		skip     []string
+	}{
+		{
+			name: "month",
+			calendar: Calendar{
+				BaseComponent: BaseComponent{Style: ut.SM{"max-width": "800px"}, Class: []string{"planner"}},
+				Value:         "2024-05-15", Events: events,
+			},
+			want: []string{
+				`class="calendar planner"`, `style="max-width:800px;"`, "May 2024", "calendar-outside",
+				`style="background-color:red;"`, `<span class="calendar-event-time">10:00</span> Visit`,
+			},
+			skip: []string{"hx-post"},
+		},
+		{
+			name: "week",
+			calendar: Calendar{
+				BaseComponent: BaseComponent{Id: "cal", EventURL: "/event"},
+				View:          CalendarViewWeek, Value: "2024-05-15", Events: events, StartHour: 8, EndHour: 12,
+			},
+			want: []string{
+				`id="cal_slot_1"`, `id="cal_item_`, `draggable="true"`, "calendar-resize", "htmx.ajax",
+				`top:50%;height:25%;left:0%;width:100%;background-color:red;`, "Holiday",
+				`top:75%;height:25%;left:0%;width:100%;`,
+			},
+			skip: []string{"Night shift"},
+		},
+		{
+			name: "day_readonly",
+			calendar: Calendar{
+				BaseComponent: BaseComponent{Id: "cal", EventURL: "/event"},
+				View:          CalendarViewDay, Value: "2024-05-15", Events: events, ReadOnly: true,
+				Labels: ut.SM{"Wednesday": "Mittwoch"},
+			},
+			want: []string{"Mittwoch, May 15, 2024", "Night shift", `top:0%;height:25%`, `id="cal_item_1"`},
+			skip: []string{"data-calendar-slot", "draggable", "htmx.ajax", "Standup"},
+		},
+		{
+			name: "agenda",
+			calendar: Calendar{
+				BaseComponent: BaseComponent{Id: "cal", EventURL: "/event"},
+				View:          CalendarViewAgenda, Value: "2024-05-15", Events: events, AgendaDays: 2,
+			},
+			want: []string{"10:00 - 11:00", "22:00 - 06:00", "All day"},
+			skip: []string{"draggable", "htmx.ajax"},
+		},
+		{
+			name: "agenda_empty",
+			calendar: Calendar{
+				View: CalendarViewAgenda, Value: "2024-01-01",
+			},
+			want: []string{"No events"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			html, err := tt.calendar.Render()
+			if err != nil {
+				t.Fatalf("Calendar.Render() error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(string(html), want) {
+					t.Errorf("Calendar.Render() = %v, want %v", html, want)
+				}
+			}
+			for _, skip := range tt.skip {
+				if strings.Contains(string(html), skip) {
+					t.Errorf("Calendar.Render() = %v, skip %v", html, skip)
+				}
+			}
+			if tt.calendar.EventURL != "" && tt.calendar.RequestMap["cal_item_1"] != &tt.calendar {
+				t.Errorf("Calendar.Render() request_map = %v", tt.calendar.RequestMap)
+			}
+		})
+	}
+}
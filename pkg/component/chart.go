@@ -0,0 +1,834 @@
+package component
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	ut "github.com/nervatura/component/pkg/util"
+)
+
+// [Chart] constants
+const (
+	ComponentTypeChart = "chart"
+
+	ChartEventPointSelected = "chart_point_selected"
+
+	ChartTypeBar        = "bar"
+	ChartTypeStackedBar = "stacked_bar"
+	ChartTypeLine       = "line"
+	ChartTypeArea       = "area"
+	ChartTypePie        = "pie"
+	ChartTypeDonut      = "donut"
+	ChartTypeScatter    = "scatter"
+
+	// The number of the theme-aware series colors of the chart.css (chart-color-0 ... chart-color-7)
+	chartColorCount = 8
+	// The estimated width of a label character
+	chartCharWidth = 7
+)
+
+// [Chart] Type values
+var ChartType []string = []string{ChartTypeBar, ChartTypeStackedBar, ChartTypeLine, ChartTypeArea,
+	ChartTypePie, ChartTypeDonut, ChartTypeScatter}
+
+// Value field of the [Chart] rows
+type ChartSeries struct {
+	// The field name of the series values in the Rows
+	Field string `json:"field"`
+	// The legend and tooltip label of the series. Default value: the Field value
+	Label string `json:"label"`
+	// Any valid SVG color value. Default value: the theme-aware color of the series index
+	Color string `json:"color"`
+}
+
+/*
+Creates a server-side rendered SVG chart control. The bar, stacked bar, line, area and scatter charts display
+all Series, the pie and donut charts display the first Series of the Rows. The colors of the series are the
+CSS variables of the current theme, and the tooltips of the data points are SVG title elements. If the
+EventURL is set, the data points are clickable and send a [ChartEventPointSelected] event.
+
+For example:
+
+	&Chart{
+	  BaseComponent: BaseComponent{
+	    Id:       "id_chart_sales",
+	    EventURL: "/event",
+	  },
+	  Type:       ChartTypeBar,
+	  Title:      "Sales",
+	  LabelField: "month",
+	  Series: []ChartSeries{
+	    {Field: "income", Label: "Income"},
+	    {Field: "cost", Label: "Cost", Color: "#d2697d"},
+	  },
+	  Rows: []ut.IM{
+	    {"month": "Jan", "income": 120, "cost": 80},
+	    {"month": "Feb", "income": 150, "cost": 90},
+	  },
+	}
+*/
+type Chart struct {
+	BaseComponent
+	/* [ChartType] variable constants: [ChartTypeBar], [ChartTypeStackedBar], [ChartTypeLine], [ChartTypeArea],
+	[ChartTypePie], [ChartTypeDonut], [ChartTypeScatter].
+	Default value: [ChartTypeBar] */
+	Type  string `json:"type"`
+	Title string `json:"title"`
+	// The data rows of the chart
+	Rows []ut.IM `json:"rows"`
+	// The field name of the category labels. The numeric x values of the [ChartTypeScatter] chart.
+	LabelField string `json:"label_field"`
+	// The value fields of the Rows
+	Series []ChartSeries `json:"series"`
+	// The width of the SVG viewBox. Default value: 600
+	Width float64 `json:"width"`
+	// The height of the SVG viewBox. Default value: 300
+	Height     float64 `json:"height"`
+	HideLegend bool    `json:"hide_legend"`
+	// Hides the axis labels and the grid lines
+	HideAxis bool `json:"hide_axis"`
+}
+
+/*
+Returns all properties of the [Chart]
+*/
+func (cht *Chart) Properties() ut.IM {
+	return ut.MergeIM(
+		cht.BaseComponent.Properties(),
+		ut.IM{
+			"type":        cht.Type,
+			"title":       cht.Title,
+			"rows":        cht.Rows,
+			"label_field": cht.LabelField,
+			"series":      cht.Series,
+			"width":       cht.Width,
+			"height":      cht.Height,
+			"hide_legend": cht.HideLegend,
+			"hide_axis":   cht.HideAxis,
+		})
+}
+
+/*
+Returns the value of the property of the [Chart] with the specified name.
+*/
+func (cht *Chart) GetProperty(propName string) interface{} {
+	return cht.Properties()[propName]
+}
+
+func (cht *Chart) seriesValidation(propValue interface{}) []ChartSeries {
+	series := []ChartSeries{}
+	if cs, valid := propValue.([]ChartSeries); valid && (cs != nil) {
+		series = cs
+	}
+	if values, valid := propValue.([]interface{}); valid {
+		for _, value := range values {
+			if item, valid := value.(ut.IM); valid {
+				series = append(series, ChartSeries{
+					Field: ut.ToString(item["field"], ""),
+					Label: ut.ToString(item["label"], ""),
+					Color: ut.ToString(item["color"], ""),
+				})
+			}
+		}
+	}
+	return series
+}
+
+/*
+It checks the value given to the property of the [Chart] and always returns a valid value
+*/
+func (cht *Chart) Validation(propName string, propValue interface{}) interface{} {
+	pm := map[string]func() interface{}{
+		"type": func() interface{} {
+			return cht.CheckEnumValue(ut.ToString(propValue, ""), ChartTypeBar, ChartType)
+		},
+		"rows": func() interface{} {
+			return ut.ToIMA(propValue, []ut.IM{})
+		},
+		"series": func() interface{} {
+			return cht.seriesValidation(propValue)
+		},
+		"width": func() interface{} {
+			if value := ut.ToFloat(propValue, 0); value > 0 {
+				return value
+			}
+			return float64(600)
+		},
+		"height": func() interface{} {
+			if value := ut.ToFloat(propValue, 0); value > 0 {
+				return value
+			}
+			return float64(300)
+		},
+		"target": func() interface{} {
+			cht.SetProperty("id", cht.Id)
+			value := ut.ToString(propValue, cht.Id)
+			if value != "this" && !strings.HasPrefix(value, "#") {
+				value = "#" + value
+			}
+			return value
+		},
+	}
+	if _, found := pm[propName]; found {
+		return pm[propName]()
+	}
+	if cht.BaseComponent.GetProperty(propName) != nil {
+		return cht.BaseComponent.Validation(propName, propValue)
+	}
+	return propValue
+}
+
+/*
+Setting a property of the [Chart] value safely. Checks the entered value.
+In case of an invalid value, the default value will be set.
+*/
+func (cht *Chart) SetProperty(propName string, propValue interface{}) interface{} {
+	pm := map[string]func() interface{}{
+		"type": func() interface{} {
+			cht.Type = cht.Validation(propName, propValue).(string)
+			return cht.Type
+		},
+		"title": func() interface{} {
+			cht.Title = ut.ToString(propValue, "")
+			return cht.Title
+		},
+		"rows": func() interface{} {
+			cht.Rows = cht.Validation(propName, propValue).([]ut.IM)
+			return cht.Rows
+		},
+		"label_field": func() interface{} {
+			cht.LabelField = ut.ToString(propValue, "")
+			return cht.LabelField
+		},
+		"series": func() interface{} {
+			cht.Series = cht.Validation(propName, propValue).([]ChartSeries)
+			return cht.Series
+		},
+		"width": func() interface{} {
+			cht.Width = cht.Validation(propName, propValue).(float64)
+			return cht.Width
+		},
+		"height": func() interface{} {
+			cht.Height = cht.Validation(propName, propValue).(float64)
+			return cht.Height
+		},
+		"hide_legend": func() interface{} {
+			cht.HideLegend = ut.ToBoolean(propValue, false)
+			return cht.HideLegend
+		},
+		"hide_axis": func() interface{} {
+			cht.HideAxis = ut.ToBoolean(propValue, false)
+			return cht.HideAxis
+		},
+		"target": func() interface{} {
+			cht.Target = cht.Validation(propName, propValue).(string)
+			return cht.Target
+		},
+	}
+	if _, found := pm[propName]; found {
+		return cht.SetRequestValue(propName, pm[propName](), []string{})
+	}
+	if cht.BaseComponent.GetProperty(propName) != nil {
+		return cht.BaseComponent.SetProperty(propName, propValue)
+	}
+	return propValue
+}
+
+/*
+If the OnResponse function of the [Chart] is implemented, the function calls it after the [TriggerEvent]
+is processed, otherwise the function's return [ResponseEvent] is the processed [TriggerEvent].
+The event value contains the series field name, the row index, the label and the value of the selected point.
+*/
+func (cht *Chart) OnRequest(te TriggerEvent) (re ResponseEvent) {
+	series := ut.ToInteger(te.Values.Get("series"), 0)
+	index := ut.ToInteger(te.Values.Get("index"), 0)
+	value := ut.IM{"series": series, "index": index}
+	if series >= 0 && series < int64(len(cht.Series)) && index >= 0 && index < int64(len(cht.Rows)) {
+		row := cht.Rows[index]
+		field := cht.Series[series].Field
+		value = ut.IM{
+			"series": field, "index": index, "label": row[cht.LabelField], "value": row[field], "row": row,
+		}
+	}
+	evt := ResponseEvent{
+		Trigger:     cht,
+		TriggerName: cht.Name,
+		Name:        ChartEventPointSelected,
+		Value:       value,
+	}
+	if cht.OnResponse != nil {
+		return cht.OnResponse(evt)
+	}
+	return evt
+}
+
+// SVG path of a data point, a legend marker or a pie slice
+type chartShape struct {
+	Path string
+	// chart-bar, chart-line, chart-area, chart-point, chart-slice or chart-legend
+	Class string
+	// The custom color of the series
+	Color string
+	// The index of the theme color, if the Color is not set
+	ColorIndex int
+	// The tooltip of the shape
+	Title string
+	// The id and the hx-vals value of the clickable data points
+	Id   string
+	Vals string
+}
+
+type chartText struct {
+	X, Y   float64
+	Anchor string
+	Class  string
+	Value  string
+}
+
+type chartLine struct {
+	X1, Y1, X2, Y2 float64
+	Class          string
+}
+
+// The calculated SVG elements and the plot area of the chart
+type chartLayout struct {
+	shapes                   []chartShape
+	texts                    []chartText
+	lines                    []chartLine
+	left, top, right, bottom float64
+}
+
+// Formats a number without the floating point noise
+func chartNumber(value float64) string {
+	return strconv.FormatFloat(math.Round(value*1e6)/1e6, 'f', -1, 64)
+}
+
+// Returns the rounded tick values of the value range
+func chartTicks(minValue, maxValue float64, count int) (ticks []float64) {
+	if maxValue <= minValue {
+		// the range of the constant values, 1 is below the float precision of the very large values
+		maxValue = minValue + max(1, math.Abs(minValue)/10)
+	}
+	raw := (maxValue - minValue) / float64(count)
+	exp := math.Pow(10, math.Floor(math.Log10(raw)))
+	step := 10 * exp
+	for _, nice := range []float64{1, 2, 5} {
+		if raw <= nice*exp {
+			step = nice * exp
+			break
+		}
+	}
+	for value := math.Floor(minValue/step) * step; step > 0 && value < maxValue+step*0.999 && len(ticks) <= count*2; value += step {
+		ticks = append(ticks, math.Round(math.Round(value/step)*step*1e9)/1e9)
+		if value+step <= value {
+			// the step is below the float precision of the value
+			break
+		}
+	}
+	if len(ticks) < 2 {
+		return []float64{minValue, maxValue}
+	}
+	return ticks
+}
+
+func chartRect(x, y, width, height float64) string {
+	return fmt.Sprintf("M%s %sh%sv%sh%sZ", chartNumber(x), chartNumber(y),
+		chartNumber(width), chartNumber(height), chartNumber(-width))
+}
+
+func chartCircle(x, y, r float64) string {
+	return fmt.Sprintf("M%s %sa%s %s 0 1 0 %s 0a%s %s 0 1 0 %s 0Z", chartNumber(x-r), chartNumber(y),
+		chartNumber(r), chartNumber(r), chartNumber(2*r), chartNumber(r), chartNumber(r), chartNumber(-2*r))
+}
+
+// Returns the path of a pie slice or a donut ring segment
+func chartArc(cx, cy, r, inner, a0, a1 float64) string {
+	if a1-a0 > 2*math.Pi-1e-4 {
+		// the start and end points of a full circle arc are the same
+		a1 = a0 + 2*math.Pi - 1e-4
+	}
+	large := 0
+	if a1-a0 > math.Pi {
+		large = 1
+	}
+	point := func(radius, angle float64) string {
+		return chartNumber(cx+radius*math.Cos(angle)) + " " + chartNumber(cy+radius*math.Sin(angle))
+	}
+	rs := chartNumber(r)
+	if inner == 0 {
+		return fmt.Sprintf("M%s %sL%sA%s %s 0 %d 1 %sZ", chartNumber(cx), chartNumber(cy),
+			point(r, a0), rs, rs, large, point(r, a1))
+	}
+	is := chartNumber(inner)
+	return fmt.Sprintf("M%sA%s %s 0 %d 1 %sL%sA%s %s 0 %d 0 %sZ",
+		point(r, a0), rs, rs, large, point(r, a1), point(inner, a1), is, is, large, point(inner, a0))
+}
+
+func (cht *Chart) seriesLabel(series ChartSeries) string {
+	if series.Label != "" {
+		return series.Label
+	}
+	return series.Field
+}
+
+func (cht *Chart) rowLabel(index int) string {
+	return ut.ToString(cht.Rows[index][cht.LabelField], "")
+}
+
+// Returns the number of the value, the non-finite values (NaN, ±Inf) are displayed as zero
+func chartValue(value any) float64 {
+	if number := ut.ToFloat(value, 0); !math.IsNaN(number) && !math.IsInf(number, 0) {
+		return number
+	}
+	return 0
+}
+
+func (cht *Chart) value(index int, series ChartSeries) float64 {
+	return chartValue(cht.Rows[index][series.Field])
+}
+
+// Returns the id and the hx-vals value of a clickable data point
+func (cht *Chart) pointEvent(series, index int) (id, vals string) {
+	if cht.EventURL == "" {
+		return "", ""
+	}
+	values, _ := json.Marshal(map[string]int{"series": series, "index": index})
+	return fmt.Sprintf("%s_point_%d_%d", cht.Id, series, index), string(values)
+}
+
+// Places the legend items at the bottom of the chart and reduces the plot area
+func (cht *Chart) legendLayout(lo *chartLayout, labels []string, colors []string) {
+	type legendItem struct {
+		x   float64
+		row int
+	}
+	items := []legendItem{}
+	x, row := lo.left, 0
+	for _, label := range labels {
+		width := float64(24 + chartCharWidth*len([]rune(label)))
+		if x+width > lo.right && x > lo.left {
+			x, row = lo.left, row+1
+		}
+		items = append(items, legendItem{x: x, row: row})
+		x += width
+	}
+	top := cht.Height - float64(row+1)*20 - 4
+	for index, item := range items {
+		y := top + float64(item.row)*20
+		lo.shapes = append(lo.shapes, chartShape{
+			Path: chartRect(item.x, y+4, 10, 10), Class: "chart-legend",
+			Color: colors[index], ColorIndex: index % chartColorCount, Title: labels[index],
+		})
+		lo.texts = append(lo.texts, chartText{
+			X: item.x + 14, Y: y + 13, Anchor: "start", Class: "chart-legend-label", Value: labels[index]})
+	}
+	lo.bottom = top - 8
+}
+
+func (cht *Chart) pieLayout(lo *chartLayout) {
+	if len(cht.Series) == 0 {
+		return
+	}
+	series := cht.Series[0]
+	total := 0.0
+	for index := range cht.Rows {
+		total += max(cht.value(index, series), 0)
+	}
+	cx, cy := (lo.left+lo.right)/2, (lo.top+lo.bottom)/2
+	r := max(min(lo.right-lo.left, lo.bottom-lo.top)/2-4, 1)
+	inner := 0.0
+	if cht.Type == ChartTypeDonut {
+		inner = r * 0.55
+	}
+	angle := -math.Pi / 2
+	for index := range cht.Rows {
+		value := cht.value(index, series)
+		if value <= 0 {
+			continue
+		}
+		next := angle + value/total*2*math.Pi
+		shape := chartShape{
+			Path: chartArc(cx, cy, r, inner, angle, next), Class: "chart-slice", ColorIndex: index % chartColorCount,
+			Title: fmt.Sprintf("%s: %s (%s%%)", cht.rowLabel(index), chartNumber(value), chartNumber(math.Round(value/total*1000)/10)),
+		}
+		shape.Id, shape.Vals = cht.pointEvent(0, index)
+		lo.shapes = append(lo.shapes, shape)
+		angle = next
+	}
+}
+
+// Returns the value range of the axis charts
+func (cht *Chart) valueRange() (minValue, maxValue float64) {
+	minValue, maxValue = math.Inf(1), math.Inf(-1)
+	for index := range cht.Rows {
+		pos, neg := 0.0, 0.0
+		for _, series := range cht.Series {
+			value := cht.value(index, series)
+			if cht.Type == ChartTypeStackedBar {
+				if value >= 0 {
+					pos += value
+				} else {
+					neg += value
+				}
+				minValue, maxValue = min(minValue, neg), max(maxValue, pos)
+				continue
+			}
+			minValue, maxValue = min(minValue, value), max(maxValue, value)
+		}
+	}
+	if math.IsInf(minValue, 1) {
+		return 0, 1
+	}
+	if cht.Type == ChartTypeBar || cht.Type == ChartTypeStackedBar || cht.Type == ChartTypeArea {
+		// the bars and the areas start from the zero baseline
+		minValue, maxValue = min(minValue, 0), max(maxValue, 0)
+	}
+	return minValue, maxValue
+}
+
+// Returns the x value range of the scatter chart
+func (cht *Chart) labelRange() (minValue, maxValue float64) {
+	minValue, maxValue = math.Inf(1), math.Inf(-1)
+	for index := range cht.Rows {
+		value := chartValue(cht.Rows[index][cht.LabelField])
+		minValue, maxValue = min(minValue, value), max(maxValue, value)
+	}
+	if math.IsInf(minValue, 1) {
+		return 0, 1
+	}
+	return minValue, maxValue
+}
+
+func (cht *Chart) axisLayout(lo *chartLayout) {
+	minY, maxY := cht.valueRange()
+	yTicks := chartTicks(minY, maxY, 5)
+	var xTicks []float64
+	if cht.Type == ChartTypeScatter {
+		minX, maxX := cht.labelRange()
+		xTicks = chartTicks(minX, maxX, 5)
+	}
+	if !cht.HideAxis {
+		labelWidth := 0
+		for _, tick := range yTicks {
+			labelWidth = max(labelWidth, len(chartNumber(tick)))
+		}
+		lo.left += float64(labelWidth*chartCharWidth + 8)
+		lo.bottom -= 20
+	}
+	yMin, yMax := yTicks[0], yTicks[len(yTicks)-1]
+	yScale := func(value float64) float64 {
+		return lo.bottom - (value-yMin)/(yMax-yMin)*(lo.bottom-lo.top)
+	}
+	baseline := yScale(min(max(0, yMin), yMax))
+	band := (lo.right - lo.left) / float64(max(len(cht.Rows), 1))
+	xScale := func(index int) float64 {
+		if cht.Type == ChartTypeScatter {
+			value := chartValue(cht.Rows[index][cht.LabelField])
+			return lo.left + (value-xTicks[0])/(xTicks[len(xTicks)-1]-xTicks[0])*(lo.right-lo.left)
+		}
+		return lo.left + band*(float64(index)+0.5)
+	}
+
+	if !cht.HideAxis {
+		for _, tick := range yTicks {
+			lo.lines = append(lo.lines, chartLine{X1: lo.left, Y1: yScale(tick), X2: lo.right, Y2: yScale(tick), Class: "chart-grid"})
+			lo.texts = append(lo.texts, chartText{
+				X: lo.left - 6, Y: yScale(tick) + 4, Anchor: "end", Class: "chart-axis-label", Value: chartNumber(tick)})
+		}
+		if cht.Type == ChartTypeScatter {
+			for _, tick := range xTicks {
+				x := lo.left + (tick-xTicks[0])/(xTicks[len(xTicks)-1]-xTicks[0])*(lo.right-lo.left)
+				lo.texts = append(lo.texts, chartText{
+					X: x, Y: lo.bottom + 16, Anchor: "middle", Class: "chart-axis-label", Value: chartNumber(tick)})
+			}
+		} else {
+			// every step-th category label is displayed, if the labels do not fit
+			step := int(math.Ceil(float64(len(cht.Rows)) / math.Max(math.Floor((lo.right-lo.left)/60), 1)))
+			for index := 0; index < len(cht.Rows); index += step {
+				lo.texts = append(lo.texts, chartText{
+					X: xScale(index), Y: lo.bottom + 16, Anchor: "middle", Class: "chart-axis-label", Value: cht.rowLabel(index)})
+			}
+		}
+		lo.lines = append(lo.lines,
+			chartLine{X1: lo.left, Y1: lo.top, X2: lo.left, Y2: lo.bottom, Class: "chart-axis"},
+			chartLine{X1: lo.left, Y1: baseline, X2: lo.right, Y2: baseline, Class: "chart-axis"})
+	}
+
+	pos, neg := make([]float64, len(cht.Rows)), make([]float64, len(cht.Rows))
+	for si, series := range cht.Series {
+		shape := chartShape{Color: series.Color, ColorIndex: si % chartColorCount}
+		label := cht.seriesLabel(series)
+		var line []string
+		for index := range cht.Rows {
+			value := cht.value(index, series)
+			shape.Title = fmt.Sprintf("%s, %s: %s", cht.rowLabel(index), label, chartNumber(value))
+			shape.Id, shape.Vals = cht.pointEvent(si, index)
+			switch cht.Type {
+			case ChartTypeBar:
+				width := band * 0.8 / float64(len(cht.Series))
+				x := lo.left + band*float64(index) + band*0.1 + float64(si)*width
+				shape.Class, shape.Path = "chart-bar", chartRect(x, min(yScale(value), baseline), width, math.Abs(yScale(value)-baseline))
+			case ChartTypeStackedBar:
+				from := &pos[index]
+				if value < 0 {
+					from = &neg[index]
+				}
+				y0, y1 := yScale(*from), yScale(*from+value)
+				*from += value
+				shape.Class, shape.Path = "chart-bar", chartRect(lo.left+band*float64(index)+band*0.2, min(y0, y1), band*0.6, math.Abs(y1-y0))
+			case ChartTypeScatter:
+				shape.Title = fmt.Sprintf("%s: %s, %s", label, cht.rowLabel(index), chartNumber(value))
+				shape.Class, shape.Path = "chart-point", chartCircle(xScale(index), yScale(value), 4)
+			default:
+				line = append(line, chartNumber(xScale(index))+" "+chartNumber(yScale(value)))
+				shape.Class, shape.Path = "chart-point", chartCircle(xScale(index), yScale(value), 4)
+			}
+			lo.shapes = append(lo.shapes, shape)
+		}
+		if len(line) > 0 {
+			// the line and the area are drawn under the points
+			path := "M" + strings.Join(line, "L")
+			shapes := []chartShape{{Path: path, Class: "chart-line", Color: series.Color, ColorIndex: shape.ColorIndex}}
+			if cht.Type == ChartTypeArea {
+				area := path + fmt.Sprintf("L%s %sL%s %sZ", chartNumber(xScale(len(line)-1)), chartNumber(baseline),
+					chartNumber(xScale(0)), chartNumber(baseline))
+				shapes = append([]chartShape{{Path: area, Class: "chart-area", Color: series.Color, ColorIndex: shape.ColorIndex}}, shapes...)
+			}
+			points := len(lo.shapes) - len(line)
+			lo.shapes = append(lo.shapes[:points], append(shapes, lo.shapes[points:]...)...)
+		}
+	}
+}
+
+// Calculates the SVG elements of the chart
+func (cht *Chart) layout() (lo chartLayout) {
+	lo.left, lo.top, lo.right, lo.bottom = 8, 8, cht.Width-8, cht.Height-8
+	if cht.Title != "" {
+		lo.texts = append(lo.texts, chartText{X: cht.Width / 2, Y: 20, Anchor: "middle", Class: "chart-title", Value: cht.Title})
+		lo.top = 32
+	}
+	pie := cht.Type == ChartTypePie || cht.Type == ChartTypeDonut
+	if !cht.HideLegend {
+		labels, colors := []string{}, []string{}
+		if pie {
+			for index := range cht.Rows {
+				labels, colors = append(labels, cht.rowLabel(index)), append(colors, "")
+			}
+		} else {
+			for _, series := range cht.Series {
+				labels, colors = append(labels, cht.seriesLabel(series)), append(colors, series.Color)
+			}
+		}
+		if len(labels) > 0 {
+			cht.legendLayout(&lo, labels, colors)
+		}
+	}
+	if pie {
+		cht.pieLayout(&lo)
+		return lo
+	}
+	cht.axisLayout(&lo)
+	return lo
+}
+
+/*
+Based on the values, it will generate the html code of the [Chart] or return with an error message.
+*/
+func (cht *Chart) Render() (html template.HTML, err error) {
+	return RenderHTML(cht)
+}
+
+// the render data of the [Chart] template
+type chartRender struct {
+	*Chart
+	lo chartLayout
+}
+
+// [Chart] template functions
+var chartFuncMap = map[string]any{
+	"styleMap": func(cht *chartRender) bool {
+		return len(cht.Style) > 0
+	},
+	"customClass": func(cht *chartRender) string {
+		return strings.Join(cht.Class, " ")
+	},
+	"num": chartNumber,
+	"shapes": func(cht *chartRender) []chartShape {
+		return cht.lo.shapes
+	},
+	"texts": func(cht *chartRender) []chartText {
+		return cht.lo.texts
+	},
+	"lines": func(cht *chartRender) []chartLine {
+		return cht.lo.lines
+	},
+}
+
+/*
+Based on the values, it will write the html code of the [Chart] into the writer or return with an error message.
+*/
+func (cht *Chart) RenderTo(w io.Writer) (err error) {
+	cht.InitProps(cht)
+	lo := cht.layout()
+
+	tpl := `<svg xmlns="http://www.w3.org/2000/svg" id="{{ .Id }}" name="{{ .Name }}"
+	 viewBox="0 0 {{ num .Width }} {{ num .Height }}" role="img" class="chart {{ customClass $ }}"
+	{{ if styleMap $ }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}
+	>{{ if ne .Title "" }}<title>{{ .Title }}</title>{{ end }}
+	{{ range lines $ }}<line x1="{{ num .X1 }}" y1="{{ num .Y1 }}" x2="{{ num .X2 }}" y2="{{ num .Y2 }}" class="{{ .Class }}"></line>{{ end }}
+	{{ range shapes $ }}<path d="{{ .Path }}"
+	 class="{{ .Class }}{{ if eq .Color "" }} chart-color-{{ .ColorIndex }}{{ end }}{{ if ne .Vals "" }} chart-link{{ end }}"
+	{{ if ne .Color "" }} fill="{{ .Color }}" stroke="{{ .Color }}"{{ end }}
+	{{ if ne .Vals "" }} id="{{ .Id }}" hx-post="{{ $.EventURL }}" hx-target="{{ $.Target }}" {{ if ne $.Sync "none" }} hx-sync="{{ $.Sync }}"{{ end }} hx-swap="{{ $.Swap }}" hx-vals="{{ .Vals }}"
+	{{ if ne $.Indicator "none" }} hx-indicator="#{{ $.Indicator }}"{{ end }}{{ end }}
+	>{{ if ne .Title "" }}<title>{{ .Title }}</title>{{ end }}</path>{{ end }}
+	{{ range texts $ }}<text x="{{ num .X }}" y="{{ num .Y }}" text-anchor="{{ .Anchor }}" class="{{ .Class }}">{{ .Value }}</text>{{ end }}
+	</svg>`
+
+	if err = ut.CachedTemplateWriter(w, "chart", tpl, chartFuncMap, &chartRender{Chart: cht, lo: lo}); err == nil && cht.EventURL != "" {
+		cht.SetProperty("request_map", cht)
+		// the htmx trigger ids of the data points
+		for _, shape := range lo.shapes {
+			if shape.Id != "" {
+				cht.RequestMap[shape.Id] = cht
+			}
+		}
+	}
+	return err
+}
+
+var testChartResponse func(evt ResponseEvent) (re ResponseEvent) = func(evt ResponseEvent) (re ResponseEvent) {
+	value := ut.ToIM(evt.Value, ut.IM{})
+	re = ResponseEvent{
+		Trigger: &Toast{
+			Type:    ToastTypeInfo,
+			Value:   fmt.Sprintf("%s %s: %s", ut.ToString(value["label"], ""), ut.ToString(value["series"], ""), ut.ToString(value["value"], "")),
+			Timeout: 4,
+		},
+		TriggerName: evt.TriggerName,
+		Name:        evt.Name,
+		Header: ut.SM{
+			HeaderRetarget: "#toast-msg",
+			HeaderReswap:   SwapInnerHTML,
+		},
+	}
+	return re
+}
+
+var testChartRows []ut.IM = []ut.IM{
+	{"month": "Jan", "income": 120, "cost": 80, "tax": 12.5},
+	{"month": "Feb", "income": 150, "cost": 95, "tax": 15},
+	{"month": "Mar", "income": 90, "cost": 110, "tax": -4.5},
+	{"month": "Apr", "income": 180, "cost": 100, "tax": 20},
+	{"month": "May", "income": 210, "cost": 120, "tax": 24.5},
+	{"month": "Jun", "income": 170, "cost": 130, "tax": 10},
+}
+
+var testChartSeries []ChartSeries = []ChartSeries{
+	{Field: "income", Label: "Income"},
+	{Field: "cost", Label: "Cost"},
+	{Field: "tax", Label: "Tax"},
+}
+
+// [Chart] test and demo data
+func TestChart(cc ClientComponent) []TestComponent {
+	id := ut.ToString(cc.GetProperty("id"), "")
+	eventURL := ut.ToString(cc.GetProperty("event_url"), "")
+	requestValue := cc.GetProperty("request_value").(map[string]ut.IM)
+	requestMap := cc.GetProperty("request_map").(map[string]ClientComponent)
+	base := func(name string) BaseComponent {
+		return BaseComponent{
+			Id:           id + "_chart_" + name,
+			EventURL:     eventURL,
+			OnResponse:   testChartResponse,
+			RequestValue: requestValue,
+			RequestMap:   requestMap,
+		}
+	}
+	return []TestComponent{
+		{
+			Label:         "Bar chart",
+			ComponentType: ComponentTypeChart,
+			Component: &Chart{
+				BaseComponent: base("bar"),
+				Type:          ChartTypeBar,
+				Title:         "Monthly result",
+				LabelField:    "month",
+				Series:        testChartSeries,
+				Rows:          testChartRows,
+			}},
+		{
+			Label:         "Stacked bar and custom colors",
+			ComponentType: ComponentTypeChart,
+			Component: &Chart{
+				BaseComponent: base("stacked"),
+				Type:          ChartTypeStackedBar,
+				LabelField:    "month",
+				Series: []ChartSeries{
+					{Field: "cost", Label: "Cost", Color: "#d2697d"},
+					{Field: "tax", Label: "Tax", Color: "orange"},
+				},
+				Rows: testChartRows,
+			}},
+		{
+			Label:         "Line chart",
+			ComponentType: ComponentTypeChart,
+			Component: &Chart{
+				BaseComponent: base("line"),
+				Type:          ChartTypeLine,
+				LabelField:    "month",
+				Series:        testChartSeries[:2],
+				Rows:          testChartRows,
+				Height:        240,
+			}},
+		{
+			Label:         "Area chart without legend",
+			ComponentType: ComponentTypeChart,
+			Component: &Chart{
+				BaseComponent: base("area"),
+				Type:          ChartTypeArea,
+				LabelField:    "month",
+				Series:        testChartSeries[:1],
+				Rows:          testChartRows,
+				HideLegend:    true,
+			}},
+		{
+			Label:         "Pie chart",
+			ComponentType: ComponentTypeChart,
+			Component: &Chart{
+				BaseComponent: base("pie"),
+				Type:          ChartTypePie,
+				Title:         "Income",
+				LabelField:    "month",
+				Series:        testChartSeries[:1],
+				Rows:          testChartRows,
+				Width:         400,
+			}},
+		{
+			Label:         "Donut chart",
+			ComponentType: ComponentTypeChart,
+			Component: &Chart{
+				BaseComponent: BaseComponent{Id: id + "_chart_donut"},
+				Type:          ChartTypeDonut,
+				LabelField:    "month",
+				Series:        testChartSeries[1:2],
+				Rows:          testChartRows,
+				Width:         400,
+			}},
+		{
+			Label:         "Scatter chart",
+			ComponentType: ComponentTypeChart,
+			Component: &Chart{
+				BaseComponent: base("scatter"),
+				Type:          ChartTypeScatter,
+				LabelField:    "income",
+				Series:        testChartSeries[1:],
+				Rows:          testChartRows,
+			}},
+	}
+}
@@ -0,0 +1,496 @@
+package component
+
+import (
+	"math"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	ut "github.com/nervatura/component/pkg/util"
+)
+
+func TestTestChart(t *testing.T) {
+	for _, tt := range TestChart(&BaseComponent{EventURL: "/demo"}) {
+		t.Run(tt.Label, func(t *testing.T) {
+			tt.Component.Render()
+		})
+	}
+	testChartResponse(ResponseEvent{Trigger: &Chart{}, Value: ut.IM{"label": "Jan"}})
+}
+
+func TestChart_GetProperty(t *testing.T) {
+	tests := []struct {
+		name     string
+		propName string
+		want     interface{}
+	}{
+		{
+			name:     "type",
+			propName: "type",
+			want:     ChartTypePie,
+		},
+		{
+			name:     "width",
+			propName: "width",
+			want:     float64(400),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cht := &Chart{Type: ChartTypePie, Width: 400}
+			if got := cht.GetProperty(tt.propName); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Chart.GetProperty() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChart_Validation(t *testing.T) {
+	type args struct {
+		propName  string
+		propValue interface{}
+	}
+	tests := []struct {
+		name string
+		args args
+		want interface{}
+	}{
+		{
+			name: "base",
+			args: args{
+				propName:  "id",
+				propValue: "CHTID",
+			},
+			want: "CHTID",
+		},
+		{
+			name: "invalid",
+			args: args{
+				propName:  "invalid",
+				propValue: "",
+			},
+			want: "",
+		},
+		{
+			name: "type",
+			args: args{
+				propName:  "type",
+				propValue: "radar",
+			},
+			want: ChartTypeBar,
+		},
+		{
+			name: "rows",
+			args: args{
+				propName:  "rows",
+				propValue: []interface{}{ut.IM{"month": "Jan"}},
+			},
+			want: []ut.IM{{"month": "Jan"}},
+		},
+		{
+			name: "series",
+			args: args{
+				propName:  "series",
+				propValue: []ChartSeries{{Field: "income"}},
+			},
+			want: []ChartSeries{{Field: "income"}},
+		},
+		{
+			name: "series_im",
+			args: args{
+				propName:  "series",
+				propValue: []interface{}{ut.IM{"field": "income", "label": "Income", "color": "red"}, "cost"},
+			},
+			want: []ChartSeries{{Field: "income", Label: "Income", Color: "red"}},
+		},
+		{
+			name: "series_invalid",
+			args: args{
+				propName:  "series",
+				propValue: nil,
+			},
+			want: []ChartSeries{},
+		},
+		{
+			name: "width",
+			args: args{
+				propName:  "width",
+				propValue: 400,
+			},
+			want: float64(400),
+		},
+		{
+			name: "width_invalid",
+			args: args{
+				propName:  "width",
+				propValue: -1,
+			},
+			want: float64(600),
+		},
+		{
+			name: "height",
+			args: args{
+				propName:  "height",
+				propValue: 200,
+			},
+			want: float64(200),
+		},
+		{
+			name: "target",
+			args: args{
+				propName:  "target",
+				propValue: "",
+			},
+			want: "#CHTID",
+		},
+		{
+			name: "height_invalid",
+			args: args{
+				propName:  "height",
+				propValue: "",
+			},
+			want: float64(300),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cht := &Chart{BaseComponent: BaseComponent{Id: "CHTID"}}
+			if got := cht.Validation(tt.args.propName, tt.args.propValue); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Chart.Validation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChart_SetProperty(t *testing.T) {
+	type args struct {
+		propName  string
+		propValue interface{}
+	}
+	tests := []struct {
+		name string
+		args args
+		want interface{}
+	}{
+		{
+			name: "base",
+			args: args{
+				propName:  "id",
+				propValue: "CHTID",
+			},
+			want: "CHTID",
+		},
+		{
+			name: "invalid",
+			args: args{
+				propName:  "invalid",
+				propValue: "",
+			},
+			want: "",
+		},
+		{
+			name: "type",
+			args: args{
+				propName:  "type",
+				propValue: ChartTypeLine,
+			},
+			want: ChartTypeLine,
+		},
+		{
+			name: "title",
+			args: args{
+				propName:  "title",
+				propValue: "Sales",
+			},
+			want: "Sales",
+		},
+		{
+			name: "rows",
+			args: args{
+				propName:  "rows",
+				propValue: []ut.IM{{"month": "Jan"}},
+			},
+			want: []ut.IM{{"month": "Jan"}},
+		},
+		{
+			name: "label_field",
+			args: args{
+				propName:  "label_field",
+				propValue: "month",
+			},
+			want: "month",
+		},
+		{
+			name: "series",
+			args: args{
+				propName:  "series",
+				propValue: []ChartSeries{{Field: "income"}},
+			},
+			want: []ChartSeries{{Field: "income"}},
+		},
+		{
+			name: "width",
+			args: args{
+				propName:  "width",
+				propValue: 400,
+			},
+			want: float64(400),
+		},
+		{
+			name: "height",
+			args: args{
+				propName:  "height",
+				propValue: 200,
+			},
+			want: float64(200),
+		},
+		{
+			name: "hide_legend",
+			args: args{
+				propName:  "hide_legend",
+				propValue: true,
+			},
+			want: true,
+		},
+		{
+			name: "target",
+			args: args{
+				propName:  "target",
+				propValue: "this",
+			},
+			want: "this",
+		},
+		{
+			name: "hide_axis",
+			args: args{
+				propName:  "hide_axis",
+				propValue: true,
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cht := &Chart{}
+			if got := cht.SetProperty(tt.args.propName, tt.args.propValue); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Chart.SetProperty() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChart_OnRequest(t *testing.T) {
+	rows := []ut.IM{{"month": "Jan", "income": 120}}
+	tests := []struct {
+		name       string
+		onResponse func(evt ResponseEvent) (re ResponseEvent)
+		values     url.Values
+		want       interface{}
+	}{
+		{
+			name:   "point_selected",
+			values: url.Values{"series": []string{"0"}, "index": []string{"0"}},
+			want: ut.IM{
+				"series": "income", "index": int64(0), "label": "Jan", "value": 120, "row": rows[0],
+			},
+		},
+		{
+			name:   "missing_point",
+			values: url.Values{"series": []string{"1"}, "index": []string{"0"}},
+			want:   ut.IM{"series": int64(1), "index": int64(0)},
+		},
+		{
+			name: "on_response",
+			onResponse: func(evt ResponseEvent) (re ResponseEvent) {
+				evt.Value = "response"
+				return evt
+			},
+			want: "response",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cht := &Chart{
+				BaseComponent: BaseComponent{OnResponse: tt.onResponse},
+				LabelField:    "month",
+				Series:        []ChartSeries{{Field: "income"}},
+				Rows:          rows,
+			}
+			re := cht.OnRequest(TriggerEvent{Values: tt.values})
+			if re.Name != ChartEventPointSelected || !reflect.DeepEqual(re.Value, tt.want) {
+				t.Errorf("Chart.OnRequest() = %v, want %v", re.Value, tt.want)
+			}
+		})
+	}
+}
+
+func TestChart_Render(t *testing.T) {
+	rows := []ut.IM{
+		{"label": "A", "value": 0.3}, {"label": "B", "value": -0.2}, {"label": "C", "value": 0.45},
+	}
+	tests := []struct {
+		name  string
+		chart Chart
+		want  []string
+	}{
+		{
+			name: "empty",
+			chart: Chart{
+				BaseComponent: BaseComponent{Style: ut.SM{"max-width": "400px"}, Class: []string{"sales"}},
+			},
+			want: []string{`class="chart sales"`, `style="max-width:400px;"`, ">1</text>"},
+		},
+		{
+			name:  "empty_scatter",
+			chart: Chart{Type: ChartTypeScatter, Series: []ChartSeries{{Field: "value"}}},
+			want:  []string{`class="chart-legend chart-color-0"`},
+		},
+		{
+			name: "hide_axis",
+			chart: Chart{
+				Type: ChartTypeLine, Rows: rows, LabelField: "label", HideAxis: true, HideLegend: true,
+				Series: []ChartSeries{{Field: "value", Color: "#ff0000"}},
+			},
+			want: []string{`class="chart-line" fill="#ff0000" stroke="#ff0000"`, "<title>B, value: -0.2</title>"},
+		},
+		{
+			name: "stacked_negative",
+			chart: Chart{
+				Type: ChartTypeStackedBar, Rows: rows, LabelField: "label",
+				Series: []ChartSeries{{Field: "value"}, {Field: "value"}},
+			},
+			want: []string{">-0.5</text>", ">1</text>", `d="M263.2 200h109.6v32h-109.6Z"`},
+		},
+		{
+			name: "pie_full_circle",
+			chart: Chart{
+				Type: ChartTypePie, Rows: []ut.IM{{"label": "A", "value": 1}}, LabelField: "label",
+				Series: []ChartSeries{{Field: "value"}},
+			},
+			want: []string{"<title>A: 1 (100%)</title>", " 0 1 1 "},
+		},
+		{
+			name: "pie_negative",
+			chart: Chart{
+				Type: ChartTypeDonut, Rows: rows, LabelField: "label", Series: []ChartSeries{{Field: "value"}},
+			},
+			want: []string{"<title>C: 0.45 (60%)</title>"},
+		},
+		{
+			name: "point_event",
+			chart: Chart{
+				BaseComponent: BaseComponent{Id: "cht", EventURL: "/event"},
+				Type:          ChartTypeScatter, Rows: rows, LabelField: "value", Series: []ChartSeries{{Field: "value"}},
+			},
+			want: []string{`id="cht_point_0_2"`, `hx-post="/event"`},
+		},
+		{
+			name:  "pie_missing_series",
+			chart: Chart{Type: ChartTypePie, Rows: rows, HideLegend: true},
+			want:  []string{`viewBox="0 0 600 300"`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			html, err := tt.chart.Render()
+			if err != nil {
+				t.Fatalf("Chart.Render() error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(string(html), want) {
+					t.Errorf("Chart.Render() = %v, want %v", html, want)
+				}
+			}
+			if tt.chart.EventURL != "" && tt.chart.RequestMap["cht_point_0_2"] != &tt.chart {
+				t.Errorf("Chart.Render() request_map = %v", tt.chart.RequestMap)
+			}
+		})
+	}
+}
+
+func TestChart_layout(t *testing.T) {
+	rows := []ut.IM{}
+	labels := []string{}
+	for index := 0; index < 24; index++ {
+		rows = append(rows, ut.IM{"label": "Hour " + ut.ToString(index, ""), "value": index})
+		labels = append(labels, "Hour "+ut.ToString(index, ""))
+	}
+	cht := &Chart{
+		Type: ChartTypePie, Rows: rows, LabelField: "label", Series: []ChartSeries{{Field: "value"}},
+		Width: 600, Height: 300,
+	}
+	if lo := cht.layout(); lo.bottom != 228 {
+		t.Errorf("Chart.layout() legend rows = %v", lo.bottom)
+	}
+	cht.Type = ChartTypeBar
+	labelCount := 0
+	for _, text := range cht.layout().texts {
+		if text.Class == "chart-axis-label" && strings.HasPrefix(text.Value, "Hour") {
+			labelCount++
+		}
+	}
+	if labelCount >= len(labels) {
+		t.Errorf("Chart.layout() category labels = %v", labelCount)
+	}
+}
+
+func TestChart_nonFinite(t *testing.T) {
+	rows := []ut.IM{
+		{"label": "A", "value": math.NaN(), "x": 1}, {"label": "B", "value": math.Inf(1), "x": math.Inf(1)},
+		{"label": "C", "value": math.Inf(-1), "x": math.NaN()}, {"label": "D", "value": 2, "x": 2}, {"label": "E", "value": "NaN", "x": 3},
+	}
+	// the positions of the svg elements and the values of the titles
+	invalid := regexp.MustCompile(`\s(d|x|y|x1|y1|x2|y2)="[^"]*(NaN|Inf)|(NaN|Inf)(<| \(|%)`)
+	for _, chartType := range ChartType {
+		t.Run(chartType, func(t *testing.T) {
+			cht := &Chart{Type: chartType, Rows: rows, LabelField: "label", Series: []ChartSeries{{Field: "value"}}}
+			if chartType == ChartTypeScatter {
+				cht.LabelField = "x"
+			}
+			html, err := cht.Render()
+			if err != nil {
+				t.Fatalf("Chart.Render() error = %v", err)
+			}
+			if invalid.MatchString(string(html)) {
+				t.Errorf("Chart.Render() = %v", html)
+			}
+		})
+	}
+}
+
+func TestChart_largeValues(t *testing.T) {
+	for _, values := range [][]float64{{2e16, 2e16}, {1e17, 1e17 + 16}} {
+		cht := &Chart{
+			Type: ChartTypeLine, LabelField: "label", Series: []ChartSeries{{Field: "value"}},
+			Rows: []ut.IM{{"label": "A", "value": values[0]}, {"label": "B", "value": values[1]}},
+		}
+		if html, err := cht.Render(); err != nil || strings.Contains(string(html), "NaN") {
+			t.Errorf("Chart.Render() = %v, %v", html, err)
+		}
+	}
+}
+
+func Test_chartTicks(t *testing.T) {
+	tests := []struct {
+		name     string
+		minValue float64
+		maxValue float64
+		want     []float64
+	}{
+		{name: "equal", minValue: 2, maxValue: 2, want: []float64{2, 2.2, 2.4, 2.6, 2.8, 3}},
+		{name: "nice_5", minValue: 0, maxValue: 22, want: []float64{0, 5, 10, 15, 20, 25}},
+		{name: "nice_10", minValue: 0, maxValue: 4.5, want: []float64{0, 1, 2, 3, 4, 5}},
+		{name: "negative", minValue: -30, maxValue: 10, want: []float64{-30, -20, -10, 0, 10}},
+		{name: "large_equal", minValue: 2e16, maxValue: 2e16, want: []float64{2e16, 2.05e16, 2.1e16, 2.15e16, 2.2e16}},
+		{name: "below_precision", minValue: 1e17, maxValue: 1e17 + 16, want: []float64{1e17, 1e17 + 16}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := chartTicks(tt.minValue, tt.maxValue, 5); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("chartTicks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,6 +3,8 @@ package component
 import (
 	"fmt"
 	"html/template"
+	"io"
+	"io/fs"
 	"slices"
 	"strings"
 	"time"
@@ -13,14 +15,33 @@ import (
 const (
 	ComponentTypeClient = "client"
 
-	ClientEventModule   = "client_module"
-	ClientEventTheme    = "client_theme"
-	ClientEventLogOut   = "client_logout"
-	ClientEventSide     = "client_side"
-	ClientEventSideMenu = "client_side_menu"
-	ClientEventForm     = "client_form"
+	ClientEventModule    = "client_module"
+	ClientEventTheme     = "client_theme"
+	ClientEventLogOut    = "client_logout"
+	ClientEventSide      = "client_side"
+	ClientEventSideMenu  = "client_side_menu"
+	ClientEventForm      = "client_form"
+	ClientEventUndo      = "client_undo"
+	ClientEventRedo      = "client_redo"
+	ClientEventHelp      = "client_help"
+	ClientEventHelpClose = "client_help_close"
+
+	ClientEventPalette      = "client_palette"
+	ClientEventPaletteClose = "client_palette_close"
+
+	ClientEventGuard        = "client_guard"
+	ClientEventGuardSave    = "client_guard_save"
+	ClientEventGuardDiscard = "client_guard_discard"
+	ClientEventGuardCancel  = "client_guard_cancel"
+
+	ClientGuardModalKey = "unsaved_guard"
+
+	ClientDefaultHistoryDepth = 20
+	// The maximum number of the recent commands of the command palette
+	ClientDefaultRecentCommands = 10
 )
 
+// Deprecated: the theme switch uses the registered themes. See more [ThemeNext] and [ThemeIcon].
 var ClientIcoMap map[string][]string = map[string][]string{
 	ThemeDark: {ThemeLight, "Sun"}, ThemeLight: {ThemeDark, "Moon"},
 }
@@ -65,6 +86,17 @@ type ClientInterface interface {
 	Labels(lang string) ut.SM
 }
 
+/*
+ClientDashboard is an optional interface of the [Client] CustomFunctions. If it is implemented, the [Client.SetDashboard]
+function displays the [Dashboard] in the main area of the client, for example as the landing page after login.
+*/
+type ClientDashboard interface {
+	/* Custom dashboard. The function is called before each display of the Client component if it also affects the
+	display of the [Dashboard] component. The User of the dashboard is the username of the login [Ticket] if it is not set.
+	*/
+	Dashboard(dashboardKey string, labels ut.SM, dashboardData ut.IM) Dashboard
+}
+
 /*
 The [Client] Login Ticket struct represents a user authentication ticket with various properties.
 */
@@ -83,6 +115,15 @@ type Ticket struct {
 	Expiry time.Time `json:"expiry,omitempty"`
 }
 
+/*
+The [Client] editor state history. The Undo and Redo stacks contain the snapshots of the editor data
+(the "editor" key of the Client Data map).
+*/
+type ClientHistory struct {
+	Undo []ut.IM `json:"undo"`
+	Redo []ut.IM `json:"redo"`
+}
+
 // expired reports whether the ticket is expired.
 func (t *Ticket) expired() bool {
 	if t.Expiry.IsZero() {
@@ -103,7 +144,7 @@ func (t *Ticket) Valid() bool {
 /*
 The Client component is a main application component that can be used to implement all the main functions of a
 typical client application. It allows you to use the following components: [Login], [MenuBar], [SideBar], [Search],
-[Browser], [Editor], modal and simple [Form].
+[Browser], [Editor], [Dashboard], [CommandPalette], modal and simple [Form].
 */
 type Client struct {
 	BaseComponent
@@ -111,7 +152,7 @@ type Client struct {
 	Version string `json:"version"`
 	/*
 		The theme of the control.
-		[Theme] variable constants: [ThemeLight], [ThemeDark]. Default value: [ThemeLight]
+		Any registered [Theme] value, see more [RegisterTheme]. Default value: [ThemeLight]
 	*/
 	Theme string `json:"theme"`
 	// Current ui language
@@ -141,6 +182,39 @@ type Client struct {
 	SideBarVisibility string `json:"sidebar_visibility"`
 	// Specifies whether the main menu is hidden. Default value: false
 	HideMenu bool `json:"hide_menu"`
+	// The maximum number of the stored undo steps per editor. Default value: [ClientDefaultHistoryDepth]
+	HistoryDepth int64 `json:"history_depth"`
+	/*
+		The undo/redo history of the editors. The map key is the editor key and the record id ("id" value of the
+		editor data). The [Client.SetEditor] and [Client.SetForm] functions automatically save the previous state
+		of the current editor record. Only the history of the current record is kept, the history of the other
+		records is dropped on the next save.
+	*/
+	History map[string]ClientHistory `json:"history"`
+	/*
+		Specifies whether leaving a dirty editor shows the unsaved changes confirmation modal (Save/Discard/Cancel).
+		It guards the main menu modules and logout, the editor view tabs, the GuardSideMenu items and the browser unload.
+		Default value: false
+	*/
+	UnsavedGuard bool `json:"unsaved_guard"`
+	// The side menu item values that leave the editor. Example: []string{"editor_cancel", "editor_new"}
+	GuardSideMenu []string `json:"guard_side_menu"`
+	/*
+		The [Markdown] help pages of the application, for example an [embed.FS]. The help buttons of the [Login],
+		[Search] and [Browser] and the "help" main menu item open the help drawer with the page of the current
+		module and view. See more [Client.HelpPage]. Optional.
+	*/
+	Help fs.FS `json:"-"`
+	/*
+		Specifies whether the Ctrl+K shortcut opens the [CommandPalette]. The commands of the palette are the recent
+		commands, the main menu items and the side bar actions of the current module. See more [Client.PaletteCommands].
+		Default value: false
+	*/
+	CommandPalette bool `json:"command_palette"`
+	// The names of the registered [PaletteProvider] values of the command palette. Optional.
+	PaletteProviders []string `json:"palette_providers"`
+	// The recently opened records of the command palette. See more [Client.AddRecentCommand].
+	RecentCommands []PaletteCommand `json:"recent_commands"`
 	// Custom UI and any message text functions for the Client component.
 	CustomFunctions ClientInterface `json:"-"`
 }
@@ -162,6 +236,13 @@ func (cli *Client) Properties() ut.IM {
 			"login_buttons":      cli.LoginButtons,
 			"hide_side_bar":      cli.HideSideBar,
 			"hide_menu":          cli.HideMenu,
+			"history_depth":      cli.HistoryDepth,
+			"unsaved_guard":      cli.UnsavedGuard,
+			"guard_side_menu":    cli.GuardSideMenu,
+			"help":               cli.Help,
+			"command_palette":    cli.CommandPalette,
+			"palette_providers":  cli.PaletteProviders,
+			"recent_commands":    cli.RecentCommands,
 			"custom_functions":   cli.CustomFunctions,
 		})
 }
@@ -190,6 +271,22 @@ func (cli *Client) Validation(propName string, propValue any) any {
 		"sidebar_visibility": func() any {
 			return cli.CheckEnumValue(ut.ToString(propValue, ""), SideBarVisibilityAuto, SideBarVisibility)
 		},
+		"guard_side_menu": func() any {
+			return ut.ILtoSL(propValue)
+		},
+		"palette_providers": func() any {
+			return ut.ILtoSL(propValue)
+		},
+		"recent_commands": func() any {
+			if commands, valid := propValue.([]PaletteCommand); valid && commands != nil {
+				return commands
+			}
+			commands := []PaletteCommand{}
+			if err := ut.ConvertToType(propValue, &commands); err != nil || commands == nil {
+				return []PaletteCommand{}
+			}
+			return commands
+		},
 		"login_buttons": func() any {
 			value := []LoginAuthButton{}
 			if buttons, valid := propValue.([]LoginAuthButton); valid {
@@ -261,6 +358,30 @@ func (cli *Client) SetProperty(propName string, propValue any) any {
 			cli.HideMenu = ut.ToBoolean(propValue, false)
 			return cli.HideMenu
 		},
+		"history_depth": func() any {
+			cli.HistoryDepth = ut.ToInteger(propValue, ClientDefaultHistoryDepth)
+			return cli.HistoryDepth
+		},
+		"unsaved_guard": func() any {
+			cli.UnsavedGuard = ut.ToBoolean(propValue, false)
+			return cli.UnsavedGuard
+		},
+		"guard_side_menu": func() any {
+			cli.GuardSideMenu = cli.Validation(propName, propValue).([]string)
+			return cli.GuardSideMenu
+		},
+		"command_palette": func() any {
+			cli.CommandPalette = ut.ToBoolean(propValue, false)
+			return cli.CommandPalette
+		},
+		"palette_providers": func() any {
+			cli.PaletteProviders = cli.Validation(propName, propValue).([]string)
+			return cli.PaletteProviders
+		},
+		"recent_commands": func() any {
+			cli.RecentCommands = cli.Validation(propName, propValue).([]PaletteCommand)
+			return cli.RecentCommands
+		},
 		"target": func() any {
 			cli.Target = cli.Validation(propName, propValue).(string)
 			return cli.Target
@@ -283,6 +404,13 @@ func (cli *Client) OnRequest(te TriggerEvent) (re ResponseEvent) {
 	if cc, found := cli.RequestMap[te.Id]; found {
 		return cc.OnRequest(te)
 	}
+	if evtName, found := map[string]string{
+		cli.Id + "_undo": ClientEventUndo, cli.Id + "_redo": ClientEventRedo}[te.Id]; found {
+		return cli.responseHistory(evtName)
+	}
+	if te.Id == cli.Id+"_palette_open" {
+		return cli.responsePaletteOpen()
+	}
 	re = ResponseEvent{
 		Trigger:     &BaseComponent{},
 		TriggerName: te.Name,
@@ -295,6 +423,65 @@ func (cli *Client) OnRequest(te TriggerEvent) (re ResponseEvent) {
 	return re
 }
 
+func (cli *Client) responseHistory(evtName string) (re ResponseEvent) {
+	re = ResponseEvent{
+		Trigger: cli, TriggerName: "history", Name: evtName,
+		Header: ut.SM{
+			HeaderRetarget: "#" + cli.Id,
+		},
+	}
+	if (evtName == ClientEventUndo && !cli.Undo()) || (evtName == ClientEventRedo && !cli.Redo()) {
+		re.Header = ut.SM{HeaderReswap: SwapNone}
+	}
+	re.Value = cli.HistoryState()
+	if cli.OnResponse != nil {
+		return cli.OnResponse(re)
+	}
+	return re
+}
+
+func (cli *Client) responsePaletteOpen() (re ResponseEvent) {
+	cli.ShowPalette()
+	re = ResponseEvent{
+		Trigger: cli, TriggerName: "palette", Name: ClientEventPalette,
+		Header: ut.SM{
+			HeaderRetarget: "#" + cli.Id,
+		},
+	}
+	if cli.OnResponse != nil {
+		return cli.OnResponse(re)
+	}
+	return re
+}
+
+func (cli *Client) responsePalette(evt ResponseEvent) (re ResponseEvent) {
+	switch evt.Name {
+	case PaletteEventExecute:
+		cli.ClosePalette()
+		command, _ := evt.Value.(PaletteCommand)
+		// the same event as the menu or side bar item, but the palette is the target of the request
+		re = cli.response(command.Event())
+		if _, found := re.Header[HeaderRetarget]; !found {
+			re.Header = ut.MergeSM(re.Header, ut.SM{HeaderRetarget: "#" + cli.Id})
+		}
+		return re
+
+	case PaletteEventClose:
+		cli.ClosePalette()
+		re = ResponseEvent{
+			Trigger: cli, TriggerName: cli.Name, Name: ClientEventPaletteClose, Value: evt.Value,
+			Header: ut.SM{
+				HeaderRetarget: "#" + cli.Id,
+			},
+		}
+		if cli.OnResponse != nil {
+			return cli.OnResponse(re)
+		}
+		return re
+	}
+	return evt
+}
+
 func (cli *Client) responseBrowser(evt ResponseEvent) (re ResponseEvent) {
 	re = ResponseEvent{
 		Trigger: cli, TriggerName: cli.Name, Value: evt.Value,
@@ -341,7 +528,7 @@ func (cli *Client) responseLogin(evt ResponseEvent) (re ResponseEvent) {
 	}
 	if evt.Name == LoginEventTheme {
 		re.Name = ClientEventTheme
-		cli.SetProperty("theme", ClientIcoMap[cli.Theme][0])
+		cli.SetProperty("theme", ThemeNext(cli.Theme))
 	}
 	if evt.Name == LoginEventAuth {
 		re.Value = evt.Value
@@ -370,7 +557,7 @@ func (cli *Client) responseMainMenu(evt ResponseEvent) (re ResponseEvent) {
 		switch evt.Value {
 		case "theme":
 			re.Name = ClientEventTheme
-			cli.SetProperty("theme", ClientIcoMap[cli.Theme][0])
+			cli.SetProperty("theme", ThemeNext(cli.Theme))
 		case "logout":
 			re.Name = ClientEventLogOut
 			cli.SetProperty("token", "")
@@ -381,6 +568,95 @@ func (cli *Client) responseMainMenu(evt ResponseEvent) (re ResponseEvent) {
 	return re
 }
 
+func (cli *Client) checkGuard(evt ResponseEvent) (re ResponseEvent, guarded bool) {
+	if !cli.UnsavedGuard || !cli.EditorDirty() {
+		return evt, false
+	}
+	value := ut.ToString(evt.Value, "")
+	guarded = (evt.TriggerName == "main_menu" && evt.Name == MenuBarEventValue && value != "theme") ||
+		(evt.TriggerName == "side_menu" && slices.Contains(cli.GuardSideMenu, value)) ||
+		(evt.TriggerName == "editor" && evt.Name == EditorEventView)
+	if !guarded {
+		return evt, false
+	}
+	pending := ut.IM{"trigger_name": evt.TriggerName, "name": evt.Name, "value": evt.Value}
+	cli.Data["guard"] = pending
+	cli.SetForm(ClientGuardModalKey, ut.IM{}, 0, true)
+	re = ResponseEvent{
+		Trigger: cli, TriggerName: cli.Name, Name: ClientEventGuard, Value: pending,
+		Header: ut.SM{
+			HeaderRetarget: "#" + cli.Id,
+		},
+	}
+	if cli.OnResponse != nil {
+		return cli.OnResponse(re), true
+	}
+	return re, true
+}
+
+func (cli *Client) responseGuard(evt ResponseEvent) (re ResponseEvent) {
+	re = ResponseEvent{
+		Trigger: cli, TriggerName: cli.Name, Name: ClientEventGuardCancel, Value: cli.Data["guard"],
+		Header: ut.SM{
+			HeaderRetarget: "#" + cli.Id,
+		},
+	}
+	values := ut.ToIM(ut.ToIM(evt.Value, ut.IM{})["value"], ut.IM{})
+	if _, found := values[ClientEventGuardSave]; found {
+		re.Name = ClientEventGuardSave
+	}
+	if _, found := values[ClientEventGuardDiscard]; found {
+		re.Name = ClientEventGuardDiscard
+		cli.SetDirty(false)
+	}
+	if re.Name == ClientEventGuardCancel {
+		delete(cli.Data, "guard")
+	}
+	cli.CloseModal()
+	if cli.OnResponse != nil {
+		return cli.OnResponse(re)
+	}
+	return re
+}
+
+/*
+The ResumeGuard function continues the navigation event that was interrupted by the unsaved changes
+confirmation modal. It can be called after the [ClientEventGuardSave] or [ClientEventGuardDiscard] event
+has been processed.
+*/
+func (cli *Client) ResumeGuard() (re ResponseEvent) {
+	pending := ut.ToIM(cli.Data["guard"], ut.IM{})
+	delete(cli.Data, "guard")
+	cli.SetDirty(false)
+	return cli.response(ResponseEvent{
+		Trigger:     &BaseComponent{},
+		TriggerName: ut.ToString(pending["trigger_name"], ""),
+		Name:        ut.ToString(pending["name"], ""),
+		Value:       pending["value"],
+	})
+}
+
+/*
+The EditorDirty function returns true if the current editor data has been changed but has not been saved yet.
+*/
+func (cli *Client) EditorDirty() bool {
+	if editor, found := cli.Data["editor"].(ut.IM); found {
+		return ut.ToBoolean(editor["dirty"], false)
+	}
+	return false
+}
+
+/*
+The SetDirty function sets the dirty state of the current editor. As a rule, it has to be reset after the
+editor data has been saved.
+*/
+func (cli *Client) SetDirty(dirty bool) {
+	if editor, found := cli.Data["editor"].(ut.IM); found {
+		editor["dirty"] = dirty
+		cli.SetProperty("data", cli.Data)
+	}
+}
+
 func (cli *Client) responseModal(evt ResponseEvent) (re ResponseEvent) {
 	admEvt := ResponseEvent{
 		Trigger: cli, TriggerName: cli.Name, Name: evt.Name, Value: evt.Value,
@@ -400,13 +676,52 @@ func (cli *Client) responseModal(evt ResponseEvent) (re ResponseEvent) {
 	return admEvt
 }
 
+func (cli *Client) responseHelp(evt ResponseEvent) (re ResponseEvent, found bool) {
+	re = ResponseEvent{
+		Trigger: cli, TriggerName: cli.Name, Name: ClientEventHelp,
+		Header: ut.SM{
+			HeaderRetarget: "#" + cli.Id,
+		},
+	}
+	switch {
+	case cli.Help == nil:
+		return evt, false
+	case slices.Contains([]string{LoginEventHelp, SearchEventHelp, BrowserEventHelp}, evt.Name) ||
+		(evt.TriggerName == "main_menu" && evt.Name == MenuBarEventValue && evt.Value == "help"):
+		re.Value = cli.HelpPage()
+		cli.ShowHelp(cli.HelpPage())
+	case evt.TriggerName == "help":
+		re.Value = evt.Value
+		cli.ShowHelp(ut.ToString(evt.Value, ""))
+	case evt.TriggerName == "help_close":
+		re.Name = ClientEventHelpClose
+		cli.CloseHelp()
+	default:
+		return evt, false
+	}
+	if cli.OnResponse != nil {
+		return cli.OnResponse(re), true
+	}
+	return re, true
+}
+
 func (cli *Client) response(evt ResponseEvent) (re ResponseEvent) {
 	admEvt := ResponseEvent{
 		Trigger: cli, TriggerName: cli.Name, Value: evt.Value,
 	}
+	if helpEvt, found := cli.responseHelp(evt); found {
+		return helpEvt
+	}
+	if guardEvt, guarded := cli.checkGuard(evt); guarded {
+		return guardEvt
+	}
 	switch evt.TriggerName {
 
 	case "modal":
+		modalData := ut.ToIM(cli.Data["modal"], ut.IM{})
+		if ut.ToString(modalData["key"], "") == ClientGuardModalKey && evt.Name != FormEventChange {
+			return cli.responseGuard(evt)
+		}
 		admEvt = cli.responseModal(evt)
 
 	case "browser_table", "filter_table", "view_table":
@@ -430,7 +745,22 @@ func (cli *Client) response(evt ResponseEvent) (re ResponseEvent) {
 	case "browser":
 		return cli.responseBrowser(evt)
 
+	case "palette":
+		return cli.responsePalette(evt)
+
+	case "dashboard":
+		admEvt.Name = evt.Name
+		// the refreshed widget is selected from the client by its htmx poller
+		if evt.Name != DashboardEventRefresh {
+			admEvt.Header = ut.SM{
+				HeaderRetarget: "#" + cli.Id,
+			}
+		}
+
 	case "editor":
+		if ut.ToBoolean(evt.Trigger.GetProperty("dirty"), false) {
+			cli.SetDirty(true)
+		}
 		if evt.Name == EditorEventView {
 			editorData := ut.ToIM(cli.Data["editor"], ut.IM{})
 			editorData["view"] = evt.Value
@@ -450,6 +780,9 @@ func (cli *Client) response(evt ResponseEvent) (re ResponseEvent) {
 		admEvt.Header = ut.SM{
 			HeaderRetarget: "#" + cli.Id,
 		}
+		if evt.Name == FormEventChange && ut.ToBoolean(evt.Trigger.GetProperty("dirty"), false) {
+			cli.SetDirty(true)
+		}
 		if evt.Name != FormEventChange {
 			editorData := ut.ToIM(cli.Data["editor"], ut.IM{})
 			delete(editorData, "form")
@@ -548,7 +881,7 @@ func (cli *Client) GetSearchFilters(value string, cfFilters any) (filters []Brow
 	return filters
 }
 
-func (cli *Client) getComponent(name string) (html template.HTML, err error) {
+func (cli *Client) component(name string) ClientComponent {
 	labels := cli.Labels()
 	state, stateKey, stateData := cli.GetStateData()
 	config := ut.MergeIM(ut.ToIM(cli.Data["config"], ut.IM{}),
@@ -639,6 +972,17 @@ func (cli *Client) getComponent(name string) (html template.HTML, err error) {
 			bro.SetProperty("visible_columns", cli.GetSearchVisibleColumns(bro.VisibleColumns))
 			return &bro
 		},
+		"dashboard": func() ClientComponent {
+			dsh := Dashboard{}
+			if cdb, valid := cli.CustomFunctions.(ClientDashboard); valid {
+				dsh = cdb.Dashboard(stateKey, labels, ut.MergeIM(stateData, ut.IM{"config": config}))
+			}
+			dsh.BaseComponent = ccBase(dsh.Data)
+			if dsh.User == "" {
+				dsh.SetProperty("user", cli.Ticket.User["username"])
+			}
+			return &dsh
+		},
 		"editor": func() ClientComponent {
 			edi := Editor{
 				Views:  []EditorView{},
@@ -650,12 +994,15 @@ func (cli *Client) getComponent(name string) (html template.HTML, err error) {
 					ut.MergeIM(stateData, ut.IM{"config": config}))
 			}
 			edi.BaseComponent = ccBase(edi.Data)
+			edi.SetProperty("dirty", cli.EditorDirty())
 			return &edi
 		},
 		"modal": func() ClientComponent {
 			frm := Form{}
 			modalData := ut.ToIM(cli.Data["modal"], ut.IM{})
-			if cli.CustomFunctions != nil {
+			if ut.ToString(modalData["key"], "") == ClientGuardModalKey {
+				frm = cli.guardForm(labels)
+			} else if cli.CustomFunctions != nil {
 				frm = cli.CustomFunctions.Modal(ut.ToString(modalData["key"], ""), labels,
 					ut.MergeIM(ut.ToIM(modalData["data"], ut.IM{}), ut.IM{"config": config}))
 			}
@@ -674,12 +1021,85 @@ func (cli *Client) getComponent(name string) (html template.HTML, err error) {
 			}
 			frm.BaseComponent = ccBase(frm.Data)
 			frm.SetProperty("data", stateData)
+			frm.SetProperty("dirty", cli.EditorDirty())
 			return &frm
 		},
+		"help": func() ClientComponent {
+			helpData := ut.ToIM(cli.Data["help"], ut.IM{})
+			return &Markdown{
+				BaseComponent: ccBase(ut.IM{}),
+				FS:            cli.Help,
+				Path:          ut.ToString(helpData["page"], "index.md"),
+				Labels:        labels,
+			}
+		},
+		"palette": func() ClientComponent {
+			pal := &CommandPalette{
+				BaseComponent: ccBase(ut.IM{}),
+				Commands:      cli.PaletteCommands(),
+				Providers:     cli.PaletteProviders,
+				Labels:        labels,
+			}
+			pal.SetProperty("target", pal.Id)
+			return pal
+		},
+		"help_close": func() ClientComponent {
+			ico := &Icon{BaseComponent: ccBase(ut.IM{}), Value: IconTimes, Width: 24, Height: 24}
+			ico.Class = []string{"close-icon"}
+			return ico
+		},
+	}
+	return ccMap[name]()
+}
+
+func (cli *Client) guardForm(labels ut.SM) Form {
+	label := func(key, defValue string) string {
+		if value, found := labels[key]; found {
+			return value
+		}
+		return defValue
+	}
+	guardButton := func(name, buttonStyle, icon, labelKey, defLabel string) RowColumn {
+		return RowColumn{Value: Field{
+			Type: FieldTypeButton,
+			Value: ut.IM{
+				"name":         name,
+				"type":         ButtonTypeSubmit,
+				"button_style": buttonStyle,
+				"icon":         icon,
+				"label":        label(labelKey, defLabel),
+				"auto_focus":   (name == ClientEventGuardSave),
+			},
+		}}
+	}
+	return Form{
+		Title: label("guard_title", "Unsaved changes"),
+		Icon:  IconExclamationTriangle,
+		BodyRows: []Row{
+			{
+				Columns: []RowColumn{
+					{Label: label("guard_message", "The data has changed, but has not been saved!"),
+						Value: Field{
+							Type: FieldTypeLabel,
+							Value: ut.IM{
+								"value": label("guard_question", "Do you want to save changes?"),
+								"style": ut.SM{"font-weight": "normal", "font-style": "italic"},
+							},
+						}},
+				},
+			},
+		},
+		FooterRows: []Row{
+			{
+				Columns: []RowColumn{
+					guardButton(ClientEventGuardSave, ButtonStylePrimary, IconCheck, "guard_save", "Save"),
+					guardButton(ClientEventGuardDiscard, ButtonStyleDefault, IconTimes, "guard_discard", "Discard"),
+					guardButton(FormEventCancel, ButtonStyleDefault, IconReply, "guard_cancel", "Cancel"),
+				},
+				Full: true,
+			},
+		},
 	}
-	cc := ccMap[name]()
-	html, err = cc.Render()
-	return html, err
 }
 
 /*
@@ -715,6 +1135,9 @@ func (cli *Client) GetStateData() (state, value string, data ut.IM) {
 		}
 		return "editor", ut.ToString(editor["key"], ""), editor
 	}
+	if dashboard, found := cliData["dashboard"].(ut.IM); found {
+		return "dashboard", ut.ToString(dashboard["key"], ""), dashboard
+	}
 	search := ut.ToIM(cliData["search"], ut.IM{})
 	if ut.ToBoolean(search["simple"], false) {
 		return "search", ut.ToString(search["view"], ""), search
@@ -730,12 +1153,121 @@ func (cli *Client) SetConfigValue(key string, value any) {
 	cli.SetProperty("data", cli.Data)
 }
 
+func (cli *Client) editorKey() string {
+	if editor, found := cli.Data["editor"].(ut.IM); found {
+		return ut.ToString(editor["key"], "")
+	}
+	return ""
+}
+
+// the history key of the editor record: the editor key and the record id
+func (cli *Client) historyKey() string {
+	if editorKey := cli.editorKey(); editorKey != "" {
+		return editorKey + "/" + ut.ToString(cli.Data["editor"].(ut.IM)["id"], "")
+	}
+	return ""
+}
+
+/*
+The SaveHistory function saves the current editor state in the undo stack and clears the redo stack.
+The oldest steps are dropped above the HistoryDepth limit and the history of the other editor records is removed.
+The [Client.SetEditor] and [Client.SetForm] functions call it automatically, but it can also be called before
+any direct change of the editor data.
+*/
+func (cli *Client) SaveHistory() {
+	historyKey := cli.historyKey()
+	if historyKey == "" {
+		return
+	}
+	var snapshot ut.IM
+	ut.ConvertToType(cli.Data["editor"], &snapshot)
+	if cli.History == nil {
+		cli.History = map[string]ClientHistory{}
+	}
+	for key := range cli.History {
+		if key != historyKey {
+			// only the history of the current editor record is kept in the session
+			delete(cli.History, key)
+		}
+	}
+	history := cli.History[historyKey]
+	history.Undo = append(history.Undo, snapshot)
+	if depth := max(int(ut.ToInteger(cli.HistoryDepth, ClientDefaultHistoryDepth)), 0); len(history.Undo) > depth {
+		history.Undo = history.Undo[len(history.Undo)-depth:]
+	}
+	history.Redo = []ut.IM{}
+	cli.History[historyKey] = history
+}
+
+func (cli *Client) restoreHistory(undo bool) bool {
+	historyKey := cli.historyKey()
+	history, found := cli.History[historyKey]
+	source, target := &history.Undo, &history.Redo
+	if !undo {
+		source, target = &history.Redo, &history.Undo
+	}
+	if !found || len(*source) == 0 {
+		return false
+	}
+	var current ut.IM
+	ut.ConvertToType(cli.Data["editor"], &current)
+	*target = append(*target, current)
+	cli.Data["editor"] = (*source)[len(*source)-1]
+	*source = (*source)[:len(*source)-1]
+	cli.History[historyKey] = history
+	cli.SetProperty("data", cli.Data)
+	cli.CleanComponent("editor")
+	cli.CleanComponent("form")
+	return true
+}
+
+/*
+The Undo function restores the previous state of the current editor. It returns false if there is no saved state.
+*/
+func (cli *Client) Undo() bool {
+	return cli.restoreHistory(true)
+}
+
+/*
+The Redo function restores the last undone state of the current editor. It returns false if there is no undone state.
+*/
+func (cli *Client) Redo() bool {
+	return cli.restoreHistory(false)
+}
+
+/*
+The HistoryState function returns the history state of the current editor record: the editor key, the number
+of undo and redo steps and the dirty state ([Client.EditorDirty]).
+*/
+func (cli *Client) HistoryState() ut.IM {
+	history := cli.History[cli.historyKey()]
+	return ut.IM{
+		"key": cli.editorKey(), "undo": len(history.Undo), "redo": len(history.Redo), "dirty": cli.EditorDirty(),
+	}
+}
+
+/*
+The ResetHistory function clears the undo/redo history of the current editor record, for example after the
+record has been (re)loaded.
+*/
+func (cli *Client) ResetHistory() {
+	delete(cli.History, cli.historyKey())
+}
+
 /*
-The SetEditor function sets the editor data and view state.
+The SetEditor function sets the editor data and view state. The record id is the "id" value of the data.
+If the editor key and the record id are the same as the current ones, the previous state is saved in the
+undo history, otherwise a new history is started.
 */
 func (cli *Client) SetEditor(editorKey, viewName string, data ut.IM) {
 	var values ut.IM
 	ut.ConvertToType(data, &values)
+	historyKey := editorKey + "/" + ut.ToString(values["id"], "")
+	if cli.historyKey() == historyKey {
+		cli.SaveHistory()
+	} else {
+		delete(cli.History, historyKey)
+	}
 	editorData := ut.MergeIM(values, ut.IM{"key": editorKey, "view": viewName})
 	cli.Data["editor"] = editorData
 	cli.SetProperty("data", ut.MergeIM(cli.Data, ut.IM{"editor": editorData}))
@@ -746,6 +1278,7 @@ func (cli *Client) SetEditor(editorKey, viewName string, data ut.IM) {
 The ResetEditor function clean the editor data and restores the last search state.
 */
 func (cli *Client) ResetEditor() {
+	cli.ResetHistory()
 	delete(cli.Data, "editor")
 	cli.SetProperty("data", cli.Data)
 	cli.SetProperty("sidebar_visibility", SideBarVisibilityAuto)
@@ -761,6 +1294,7 @@ func (cli *Client) SetSearch(viewName string, data ut.IM, simple bool) {
 	ut.ConvertToType(data, &values)
 	cli.Data["search"] = ut.MergeIM(values, ut.IM{"view": viewName, "simple": simple})
 	delete(cli.Data, "editor")
+	delete(cli.Data, "dashboard")
 	cli.SetProperty("data", cli.Data)
 	cli.SetProperty("sidebar_visibility", SideBarVisibilityAuto)
 	cli.CleanComponent("editor")
@@ -768,7 +1302,28 @@ func (cli *Client) SetSearch(viewName string, data ut.IM, simple bool) {
 }
 
 /*
-The SetForm function sets the form or modal data and view state.
+The SetDashboard function displays the dashboard with the key, for example the landing page after login.
+The [Dashboard] is created by the [ClientDashboard] function of the CustomFunctions. The editor is closed
+and the last search state is restored by the [Client.SetSearch] function.
+*/
+func (cli *Client) SetDashboard(dashboardKey string, data ut.IM) {
+	var values ut.IM
+	ut.ConvertToType(data, &values)
+	if ut.ToString(ut.ToIM(cli.Data["dashboard"], ut.IM{})["key"], "") != dashboardKey {
+		cli.CleanComponent("dashboard")
+	}
+	cli.Data["dashboard"] = ut.MergeIM(values, ut.IM{"key": dashboardKey})
+	cli.ResetHistory()
+	delete(cli.Data, "editor")
+	cli.SetProperty("data", cli.Data)
+	cli.SetProperty("sidebar_visibility", SideBarVisibilityAuto)
+	cli.CleanComponent("editor")
+	cli.CleanComponent("login")
+}
+
+/*
+The SetForm function sets the form or modal data and view state. The previous state of the editor is
+saved in the undo history if the form is not modal.
 */
 func (cli *Client) SetForm(formKey string, data ut.IM, index int64, modal bool) {
 	var values ut.IM
@@ -776,6 +1331,7 @@ func (cli *Client) SetForm(formKey string, data ut.IM, index int64, modal bool)
 	if modal {
 		cli.Data["modal"] = ut.IM{"key": formKey, "data": values}
 	} else {
+		cli.SaveHistory()
 		editorData := ut.ToIM(cli.Data["editor"], ut.IM{})
 		delete(editorData, "form")
 		cli.Data["editor"] = ut.MergeIM(editorData, ut.IM{"form": ut.IM{"key": formKey, "data": values, "index": index}})
@@ -791,56 +1347,233 @@ func (cli *Client) CloseModal() {
 	cli.SetProperty("data", cli.Data)
 }
 
+/*
+The HelpPage function returns the path of the help page of the current module and view. It is the first existing
+file of the Help FS from the "{module}_{view}.md", "{module}.md" and "{state}.md" names, where the state is the
+login, search, browser, editor or form state of the client. The default value is "index.md".
+*/
+func (cli *Client) HelpPage() string {
+	if cli.Help == nil {
+		return "index.md"
+	}
+	state, key, data := cli.GetStateData()
+	pages := []string{}
+	if !cli.LoginDisabled && !cli.Ticket.Valid() {
+		state = "login"
+	} else {
+		if view := ut.ToString(data["view"], ""); view != "" && view != key {
+			pages = append(pages, key+"_"+view+".md")
+		}
+		pages = append(pages, key+".md")
+	}
+	pages = append(pages, state+".md")
+	for _, page := range pages {
+		if _, err := fs.Stat(cli.Help, page); err == nil {
+			return page
+		}
+	}
+	return "index.md"
+}
+
+/*
+The ShowHelp function opens the help drawer with the page of the Help FS.
+*/
+func (cli *Client) ShowHelp(page string) {
+	cli.Data["help"] = ut.IM{"page": page}
+	cli.SetProperty("data", cli.Data)
+	cli.CleanComponent("help")
+}
+
+/*
+The CloseHelp function closes the help drawer.
+*/
+func (cli *Client) CloseHelp() {
+	delete(cli.Data, "help")
+	cli.SetProperty("data", cli.Data)
+	cli.CleanComponent("help")
+}
+
+/*
+The ShowPalette function opens the command palette.
+*/
+func (cli *Client) ShowPalette() {
+	cli.Data["palette"] = ut.IM{}
+	cli.SetProperty("data", cli.Data)
+	cli.CleanComponent("palette")
+}
+
+/*
+The ClosePalette function closes the command palette.
+*/
+func (cli *Client) ClosePalette() {
+	delete(cli.Data, "palette")
+	cli.SetProperty("data", cli.Data)
+	cli.CleanComponent("palette")
+}
+
+/*
+The AddRecentCommand function adds a recently opened record to the beginning of the recent commands of the
+command palette, for example after the selection of a search result. The previous command of the same event
+is removed, and the list is limited to [ClientDefaultRecentCommands] commands.
+*/
+func (cli *Client) AddRecentCommand(command PaletteCommand) {
+	if command.Group == "" {
+		command.Group = PaletteGroupRecent
+	}
+	recent := slices.DeleteFunc(slices.Clone(cli.RecentCommands), func(cmd PaletteCommand) bool {
+		return cmd.key() == command.key()
+	})
+	recent = append([]PaletteCommand{command}, recent...)
+	cli.SetProperty("recent_commands", recent[:min(len(recent), ClientDefaultRecentCommands)])
+}
+
+/*
+The PaletteCommands function returns the commands of the command palette: the recent commands, the main menu
+items and the enabled side bar elements of the current module. The commands dispatch the same events as the
+menu and side bar items. The menu items with url and the hidden main menu and side bar are skipped.
+*/
+func (cli *Client) PaletteCommands() (commands []PaletteCommand) {
+	commands = slices.Clone(cli.RecentCommands)
+	if !cli.HideMenu {
+		for _, item := range cli.component("main_menu").(*MenuBar).Items {
+			if item.ItemURL == "" {
+				commands = append(commands, PaletteCommand{
+					Label: ut.ToString(item.Label, item.Value), Icon: item.Icon, Group: PaletteGroupMenu,
+					TriggerName: "main_menu", Name: MenuBarEventValue, Value: item.Value,
+				})
+			}
+		}
+	}
+	sideBarCommand := func(el SideBarElement, description string) {
+		if !el.Disabled && el.Value != "" {
+			commands = append(commands, PaletteCommand{
+				Label: ut.ToString(el.Label, el.Value), Description: description, Icon: el.Icon,
+				Group: PaletteGroupSideBar, TriggerName: "side_menu", Name: SideBarEventItem, Value: el.Value,
+			})
+		}
+	}
+	if !cli.HideSideBar {
+		for _, item := range cli.component("side_menu").(*SideBar).Items {
+			switch it := item.(type) {
+			case *SideBarElement:
+				sideBarCommand(*it, "")
+			case *SideBarGroup:
+				for _, el := range it.Items {
+					sideBarCommand(el, it.Label)
+				}
+			}
+		}
+	}
+	return commands
+}
+
 /*
 Based on the values, it will generate the html code of the [Client] or return with an error message.
 */
 func (cli *Client) Render() (html template.HTML, err error) {
+	return RenderHTML(cli)
+}
+
+// the render data of the [Client] template
+type clientRender struct {
+	*Client
+	hw *ut.HTMLWriter
+}
+
+// [Client] template functions
+var clientFuncMap = map[string]any{
+	"styleMap": func(cli *clientRender) bool {
+		return len(cli.Style) > 0
+	},
+	"customClass": func(cli *clientRender) string {
+		return strings.Join(cli.Class, " ")
+	},
+	"clientComponent": func(cli *clientRender, name string) (template.HTML, error) {
+		return "", RenderTo(cli.hw, cli.component(name))
+	},
+	"validTicket": func(cli *clientRender) bool {
+		return cli.Ticket.Valid()
+	},
+	"clientState": func(cli *clientRender) string {
+		state, _, _ := cli.GetStateData()
+		return state
+	},
+	"modalForm": func(cli *clientRender) bool {
+		_, found := cli.Data["modal"].(ut.IM)
+		return found
+	},
+	"editorHistory": func(cli *clientRender) bool {
+		return cli.EventURL != "" && cli.editorKey() != ""
+	},
+	"unloadGuard": func(cli *clientRender) bool {
+		return cli.UnsavedGuard && cli.EditorDirty()
+	},
+	"helpDrawer": func(cli *clientRender) bool {
+		_, found := cli.Data["help"].(ut.IM)
+		return found && cli.Help != nil
+	},
+	"paletteEnabled": func(cli *clientRender) bool {
+		return cli.CommandPalette && cli.EventURL != "" && (cli.LoginDisabled || cli.Ticket.Valid())
+	},
+	"paletteOpen": func(cli *clientRender) bool {
+		_, found := cli.Data["palette"].(ut.IM)
+		return found
+	},
+	"helpTitle": func(cli *clientRender) string {
+		if label, found := cli.Labels()["help_title"]; found {
+			return label
+		}
+		return "Help"
+	},
+}
+
+/*
+Based on the values, it will write the html code of the [Client] into the writer or return with an error message.
+*/
+func (cli *Client) RenderTo(w io.Writer) (err error) {
 	cli.InitProps(cli)
+	hw := ut.NewHTMLWriter(w)
 
-	funcMap := map[string]any{
-		"styleMap": func() bool {
-			return len(cli.Style) > 0
-		},
-		"customClass": func() string {
-			return strings.Join(cli.Class, " ")
-		},
-		"clientComponent": func(name string) (template.HTML, error) {
-			return cli.getComponent(name)
-		},
-		"validTicket": func() bool {
-			return cli.Ticket.Valid()
-		},
-		"clientState": func() string {
-			state, _, _ := cli.GetStateData()
-			return state
-		},
-		"modalForm": func() bool {
-			_, found := cli.Data["modal"].(ut.IM)
-			return found
-		},
-	}
-	tpl := `<div id="{{ .Id }}" theme="{{ .Theme }}" class="client {{ customClass }}"
-	{{ if styleMap }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}>
-	{{ if and (eq validTicket false) (eq .LoginDisabled false) }}
-	{{ clientComponent "login" }}
+	tpl := `<div id="{{ .Id }}" theme="{{ .Theme }}" class="client {{ customClass $ }}"
+	{{ if styleMap $ }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}>
+	{{ if and (eq (validTicket $) false) (eq .LoginDisabled false) }}
+	{{ clientComponent $ "login" }}
 	{{ end }}
-	{{ if or (validTicket) (.LoginDisabled) }}
-	{{ if eq .HideMenu false }}<div class="client-menubar">{{ clientComponent "main_menu" }}</div>{{ end }}
+	{{ if or (validTicket $) (.LoginDisabled) }}
+	{{ if eq .HideMenu false }}<div class="client-menubar">{{ clientComponent $ "main_menu" }}</div>{{ end }}
 	<div theme="{{ .Theme }}" class="main" {{ if eq .HideMenu true }}style="top: 0;"{{ end }}>
-  {{ if eq .HideSideBar false }}{{ clientComponent "side_menu" }}{{ end }}
-	<div class="page" {{ if eq .HideSideBar true }}style="margin-left: 0;"{{ end }}>{{ clientComponent clientState }}</div>
+  {{ if eq .HideSideBar false }}{{ clientComponent $ "side_menu" }}{{ end }}
+	<div class="page" {{ if eq .HideSideBar true }}style="margin-left: 0;"{{ end }}>{{ clientComponent $ (clientState $) }}</div>
 	</div>
 	{{ end }}
-	{{ if modalForm }}{{ clientComponent "modal" }}{{ end }}
+	{{ if modalForm $ }}{{ clientComponent $ "modal" }}{{ end }}
+	{{ if helpDrawer $ }}<div class="client-help" role="dialog" aria-label="{{ helpTitle $ }}">
+	<div class="client-help-header"><span class="client-help-title">{{ helpTitle $ }}</span>{{ clientComponent $ "help_close" }}</div>
+	<div class="client-help-content">{{ clientComponent $ "help" }}</div>
+	</div>{{ end }}
+	{{ if editorHistory $ }}
+	<div id="{{ .Id }}_undo" name="history" class="hide" hx-post="{{ .EventURL }}" hx-target="{{ .Target }}" hx-swap="{{ .Swap }}"
+	hx-trigger="keydown[ctrlKey&&key=='z'&&target.tagName!='INPUT'&&target.tagName!='TEXTAREA'] from:body" ></div>
+	<div id="{{ .Id }}_redo" name="history" class="hide" hx-post="{{ .EventURL }}" hx-target="{{ .Target }}" hx-swap="{{ .Swap }}"
+	hx-trigger="keydown[ctrlKey&&key=='y'&&target.tagName!='INPUT'&&target.tagName!='TEXTAREA'] from:body" ></div>
+	{{ end }}
+	{{ if paletteEnabled $ }}{{ if paletteOpen $ }}<div class="modal client-palette">{{ clientComponent $ "palette" }}</div>{{ end }}
+	<div id="{{ .Id }}_palette_open" name="palette" class="hide" hx-post="{{ .EventURL }}" hx-target="{{ .Target }}" hx-swap="{{ .Swap }}"
+	hx-trigger="keydown[(ctrlKey||metaKey)&&key=='k'] from:body" hx-on::config-request="event.detail.triggeringEvent.preventDefault()" ></div>
+	{{ end }}
+	{{ if .UnsavedGuard }}<script>window.onbeforeunload = {{ if unloadGuard $ }}function(evt) { evt.preventDefault(); return ""; }{{ else }}null{{ end }};</script>{{ end }}
 	</div>`
 
-	return ut.TemplateBuilder("client", tpl, funcMap, cli)
+	if err = ut.CachedTemplateWriter(hw, "client", tpl, clientFuncMap, &clientRender{Client: cli, hw: hw}); err == nil {
+		err = hw.Close()
+	}
+	return err
 }
 
 var testClientLabels func(lang string) ut.SM = func(lang string) ut.SM {
 	labelMap := map[string]ut.SM{
 		"en": {
-			"theme_dark": "Dark", "theme_light": "Light",
+			"theme_dark": "Dark", "theme_light": "Light", "theme_contrast": "High contrast",
 			"mnu_menu": "Menu", "mnu_hide": "Hide", "mnu_search": "Search",
 			"mnu_setting": "Setting", "mnu_help": "Help", "mnu_logout": "Logout",
 			"title_login":          "Demo Client",
@@ -857,6 +1590,7 @@ var testClientLabels func(lang string) ut.SM = func(lang string) ut.SM {
 			"editor_save":          "Save",
 			"editor_delete":        "Delete",
 			"editor_cancel":        "Cancel",
+			"guard_title":          "Unsaved changes",
 			"customer_new":         "New Customer",
 			"browser_title":        "Data browser",
 			"browser_view":         "Data view",
@@ -881,9 +1615,12 @@ var testClientLabels func(lang string) ut.SM = func(lang string) ut.SM {
 			"editor_title":         "Demo Editor",
 			"settings_title":       "Settings",
 			"info_title":           "Info",
+			"mnu_home":             "Home",
+			"palette_customer":     "Customer",
+			"help_title":           "Help",
 		},
 		"zh": {
-			"theme_dark": "暗黑", "theme_light": "明亮",
+			"theme_dark": "暗黑", "theme_light": "明亮", "theme_contrast": "高对比度",
 			"mnu_menu": "菜单", "mnu_hide": "隐藏", "mnu_search": "搜索",
 			"mnu_setting": "设置", "mnu_help": "帮助", "mnu_logout": "退出",
 			"title_login":    "Demo Client",
@@ -913,7 +1650,7 @@ func testClientLogin(labels ut.SM, config ut.IM) Login {
 		Lang:         lang,
 		HideDatabase: false,
 		HidePassword: false,
-		ShowHelp:     false,
+		ShowHelp:     true,
 		HelpURL:      "",
 	}
 	login.SetProperty("data", ut.IM{
@@ -927,10 +1664,12 @@ func testClientMenu(labels ut.SM, config ut.IM) MenuBar {
 	hideExit := ut.ToBoolean(config["login_disabled"], false)
 	mnu := MenuBar{
 		Items: []MenuBarItem{
-			{Value: "theme", Label: labels["theme_"+ClientIcoMap[theme][0]], Icon: ClientIcoMap[theme][1]},
+			{Value: "theme", Label: ThemeLabel(labels, ThemeNext(theme)), Icon: ThemeIcon(ThemeNext(theme))},
+			{Value: "home", Label: labels["mnu_home"], Icon: IconHome},
 			{Value: "search", Label: labels["mnu_search"], Icon: IconSearch},
 			{Value: "setting", Label: labels["mnu_setting"], Icon: IconCog},
 			{Value: "info", Label: labels["mnu_info"], Icon: IconInfoCircle},
+			{Value: "help", Label: labels["mnu_help"], Icon: IconQuestionCircle},
 		},
 		LabelMenu: labels["mnu_menu"],
 		LabelHide: labels["mnu_hide"],
@@ -1023,6 +1762,7 @@ func testClientSearch(_ string, labels ut.SM, _ ut.IM) Search {
 		FilterPlaceholder: "Customer Name, Number, City, Steet...",
 		AutoFocus:         true,
 		Full:              true,
+		ShowHelp:          true,
 		Rows:              testSearchRows,
 	}
 	return search
@@ -1041,7 +1781,7 @@ func testClientBrowser(viewName string, labels ut.SM, _ ut.IM) Browser {
 		Title:        labels["mnu_search_browser"],
 		View:         viewName,
 		HideBookmark: true,
-		HideHelp:     true,
+		HideHelp:     false,
 		ExportLimit:  65000,
 		Labels:       labels,
 		Download:     fmt.Sprintf("%s.csv", viewName),
@@ -1083,6 +1823,14 @@ func testClientModalForm(_ string, labels ut.SM, _ ut.IM) (form Form) {
 	}
 }
 
+func testClientDashboard(dashboardKey string, labels ut.SM, _ ut.IM) Dashboard {
+	return Dashboard{
+		Widgets: testDashboardWidgets("client_"+dashboardKey, "", nil, nil),
+		Store:   testDashboardStore,
+		Labels:  labels,
+	}
+}
+
 var testClientResponse func(evt ResponseEvent) (re ResponseEvent) = func(evt ResponseEvent) (re ResponseEvent) {
 	toast := func(value string) ResponseEvent {
 		return ResponseEvent{
@@ -1103,8 +1851,13 @@ var testClientResponse func(evt ResponseEvent) (re ResponseEvent) = func(evt Res
 	switch evt.Name {
 	case ClientEventTheme, ClientEventSide, LoginEventLang,
 		BrowserEventChangeFilter, BrowserEventAddFilter, BrowserEventSetColumn,
-		EditorEventView, FormEventOK, FormEventCancel, EditorEventField:
+		EditorEventView, FormEventOK, FormEventCancel, EditorEventField, ClientEventUndo, ClientEventRedo,
+		ClientEventGuard, ClientEventGuardCancel, ClientEventHelp, ClientEventHelpClose,
+		DashboardEventAdd, DashboardEventRemove, DashboardEventMove, DashboardEventResize, DashboardEventRefresh,
+		ClientEventPalette, ClientEventPaletteClose:
 		return evt
+	case ClientEventGuardSave, ClientEventGuardDiscard:
+		return client.ResumeGuard()
 	case LoginEventLogin:
 		values := ut.ToIM(evt.Value, ut.IM{})
 		client.Ticket = Ticket{
@@ -1114,7 +1867,7 @@ var testClientResponse func(evt ResponseEvent) (re ResponseEvent) = func(evt Res
 			User:       ut.IM{"username": ut.ToString(values["username"], "")},
 			Expiry:     time.Now().Add(time.Hour * 24),
 		}
-		client.SetSearch("customer_simple", ut.IM{}, true)
+		client.SetDashboard("home", ut.IM{})
 		return evt
 	case ClientEventLogOut:
 		client.Ticket = Ticket{}
@@ -1130,13 +1883,23 @@ var testClientResponse func(evt ResponseEvent) (re ResponseEvent) = func(evt Res
 			return evt
 		}
 	case SearchEventSelected:
-		client.SetEditor("customer", "main", ut.IM{})
+		row := ut.ToIM(evt.Value, ut.IM{})
+		client.AddRecentCommand(PaletteCommand{
+			Label: ut.ToString(row["custname"], ""), Description: ut.ToString(row["custnumber"], ""), Icon: IconUser,
+			TriggerName: "search", Name: SearchEventSelected, Value: row,
+		})
+		client.SetEditor("customer", "main", ut.IM{"id": row["id"]})
 		return evt
 	case BrowserEventEditRow:
-		client.SetEditor("customer", "main", ut.IM{})
+		client.SetEditor("customer", "main", ut.IM{"id": ut.ToIM(evt.Value, ut.IM{})["id"]})
 		return evt
 	case ClientEventModule:
 		value := ut.ToString(evt.Value, "")
+		if value == "home" {
+			client.SetDashboard("home", ut.IM{})
+			client.HideSideBar = false
+			return evt
+		}
 		if value == "search" {
 			client.ResetEditor()
 			client.HideSideBar = false
@@ -1189,6 +1952,10 @@ func (c *testCustomFunctions) Modal(formKey string, labels ut.SM, data ut.IM) (f
 	return testClientModalForm(formKey, labels, data)
 }
 
+func (c *testCustomFunctions) Dashboard(dashboardKey string, labels ut.SM, data ut.IM) Dashboard {
+	return testClientDashboard(dashboardKey, labels, data)
+}
+
 func (c *testCustomFunctions) Labels(lang string) ut.SM {
 	return testClientLabels(lang)
 }
@@ -1200,6 +1967,7 @@ func TestClient(cc ClientComponent) []TestComponent {
 	requestValue := cc.GetProperty("request_value").(map[string]ut.IM)
 	requestMap := cc.GetProperty("request_map").(map[string]ClientComponent)
 	ztm, _ := time.Parse(time.DateTime, "0000-00-01 00:00:00")
+	help, _ := fs.Sub(helpFS, "help")
 	return []TestComponent{
 		{
 			Label:         "Login",
@@ -1220,6 +1988,7 @@ func TestClient(cc ClientComponent) []TestComponent {
 					SessionID: "SES012345",
 					Database:  "demo",
 				},
+				Help:            help,
 				CustomFunctions: &testCustomFunctions{},
 			},
 		},
@@ -1247,6 +2016,7 @@ func TestClient(cc ClientComponent) []TestComponent {
 					User:       ut.IM{"username": "admin"},
 					Expiry:     ztm,
 				},
+				Help:            help,
 				CustomFunctions: &testCustomFunctions{},
 			},
 		},
@@ -1268,6 +2038,7 @@ func TestClient(cc ClientComponent) []TestComponent {
 					},
 				},
 				LoginDisabled:   true,
+				Help:            help,
 				CustomFunctions: &testCustomFunctions{},
 			},
 		},
@@ -1299,6 +2070,44 @@ func TestClient(cc ClientComponent) []TestComponent {
 					User:       ut.IM{"username": "admin"},
 					Expiry:     time.Now().Add(time.Hour * 24),
 				},
+				UnsavedGuard:    true,
+				GuardSideMenu:   []string{"editor_cancel"},
+				Help:            help,
+				CustomFunctions: &testCustomFunctions{},
+			},
+		},
+		{
+			Label:         "Help drawer",
+			ComponentType: ComponentTypeClient,
+			Component: &Client{
+				BaseComponent: BaseComponent{
+					Id:           id + "help",
+					EventURL:     eventURL,
+					OnResponse:   testClientResponse,
+					RequestValue: requestValue,
+					RequestMap:   requestMap,
+					Data: ut.IM{
+						"search": ut.IM{
+							"view":   "customer_simple",
+							"simple": true,
+						},
+						"editor": ut.IM{
+							"key":  "customer",
+							"view": "main",
+						},
+						"help": ut.IM{
+							"page": "editor.md",
+						},
+					},
+				},
+				Ticket: Ticket{
+					SessionID:  "1234567890",
+					AuthMethod: "password",
+					Database:   "demo",
+					User:       ut.IM{"username": "admin"},
+					Expiry:     time.Now().Add(time.Hour * 24),
+				},
+				Help:            help,
 				CustomFunctions: &testCustomFunctions{},
 			},
 		},
@@ -1333,6 +2142,7 @@ func TestClient(cc ClientComponent) []TestComponent {
 					Expiry:     time.Now().Add(time.Hour * 24),
 				},
 				HideSideBar:     true,
+				Help:            help,
 				CustomFunctions: &testCustomFunctions{},
 			},
 		},
@@ -1369,6 +2179,7 @@ func TestClient(cc ClientComponent) []TestComponent {
 					User:       ut.IM{"username": "admin"},
 					Expiry:     time.Now().Add(time.Hour * 24),
 				},
+				Help:            help,
 				CustomFunctions: &testCustomFunctions{},
 			},
 		},
@@ -1400,6 +2211,69 @@ func TestClient(cc ClientComponent) []TestComponent {
 					User:       ut.IM{"username": "admin"},
 					Expiry:     time.Now().Add(time.Hour * 24),
 				},
+				Help:            help,
+				CustomFunctions: &testCustomFunctions{},
+			},
+		},
+		{
+			Label:         "Dashboard landing page",
+			ComponentType: ComponentTypeClient,
+			Component: &Client{
+				BaseComponent: BaseComponent{
+					Id:           id + "dashboard",
+					EventURL:     eventURL,
+					OnResponse:   testClientResponse,
+					RequestValue: requestValue,
+					RequestMap:   requestMap,
+					Data: ut.IM{
+						"dashboard": ut.IM{
+							"key": "home",
+						},
+					},
+				},
+				Ticket: Ticket{
+					SessionID:  "1234567890",
+					AuthMethod: "password",
+					Database:   "demo",
+					User:       ut.IM{"username": "admin"},
+					Expiry:     time.Now().Add(time.Hour * 24),
+				},
+				Help:            help,
+				CustomFunctions: &testCustomFunctions{},
+			},
+		},
+		{
+			Label:         "Command palette",
+			ComponentType: ComponentTypeClient,
+			Component: &Client{
+				BaseComponent: BaseComponent{
+					Id:           id + "palette",
+					EventURL:     eventURL,
+					OnResponse:   testClientResponse,
+					RequestValue: requestValue,
+					RequestMap:   requestMap,
+					Data: ut.IM{
+						"search": ut.IM{
+							"view":   "customer_browser",
+							"simple": false,
+						},
+						"palette": ut.IM{},
+					},
+				},
+				Ticket: Ticket{
+					SessionID:  "1234567890",
+					AuthMethod: "password",
+					Database:   "demo",
+					User:       ut.IM{"username": "admin"},
+					Expiry:     time.Now().Add(time.Hour * 24),
+				},
+				CommandPalette:   true,
+				PaletteProviders: []string{"palette_customer"},
+				RecentCommands: []PaletteCommand{
+					{Label: "Second Customer Name", Description: "DMCUST/00002", Icon: IconUser, Group: PaletteGroupRecent,
+						TriggerName: "search", Name: SearchEventSelected, Value: testSearchRows[1]},
+				},
+				Help:            help,
 				CustomFunctions: &testCustomFunctions{},
 			},
 		},
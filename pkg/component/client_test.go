@@ -1,8 +1,14 @@
 package component
 
 import (
+	"fmt"
+	"io/fs"
+	"net/url"
 	"reflect"
+	"slices"
+	"strings"
 	"testing"
+	"testing/fstest"
 
 	ut "github.com/nervatura/component/pkg/util"
 )
@@ -63,6 +69,12 @@ func TestTestClient(t *testing.T) {
 		},
 	},
 		Name: ClientEventModule, Value: "info"})
+	testClientResponse(ResponseEvent{Trigger: &Client{
+		BaseComponent: BaseComponent{
+			Data: ut.IM{},
+		},
+	},
+		Name: ClientEventModule, Value: "home"})
 	testClientResponse(ResponseEvent{Trigger: &Client{}, Name: FormEventChange})
 }
 
@@ -99,6 +111,38 @@ func TestClient_Validation(t *testing.T) {
 			},
 			want: Ticket{},
 		},
+		{
+			name:   "palette_providers",
+			fields: fields{},
+			args: args{
+				propName: "palette_providers", propValue: []interface{}{"customer"},
+			},
+			want: []string{"customer"},
+		},
+		{
+			name:   "recent_commands",
+			fields: fields{},
+			args: args{
+				propName: "recent_commands", propValue: []PaletteCommand{{Label: "Customer"}},
+			},
+			want: []PaletteCommand{{Label: "Customer"}},
+		},
+		{
+			name:   "recent_commands_map",
+			fields: fields{},
+			args: args{
+				propName: "recent_commands", propValue: []interface{}{ut.IM{"label": "Customer", "value": "customer-3"}},
+			},
+			want: []PaletteCommand{{Label: "Customer", Value: "customer-3"}},
+		},
+		{
+			name:   "recent_commands_invalid",
+			fields: fields{},
+			args: args{
+				propName: "recent_commands", propValue: "customer",
+			},
+			want: []PaletteCommand{},
+		},
 		{
 			name:   "invalid",
 			fields: fields{},
@@ -1110,3 +1154,642 @@ func TestClient_CloseModal(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_History(t *testing.T) {
+	type fields struct {
+		BaseComponent BaseComponent
+		HistoryDepth  int64
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		steps     []string
+		wantState ut.IM
+	}{
+		{
+			name: "undo",
+			fields: fields{
+				BaseComponent: BaseComponent{
+					Id: "client", Data: ut.IM{},
+				},
+			},
+			steps:     []string{"edit", "edit", "edit", "undo"},
+			wantState: ut.IM{"key": "customer", "undo": 1, "redo": 1, "dirty": false},
+		},
+		{
+			name: "redo",
+			fields: fields{
+				BaseComponent: BaseComponent{
+					Id: "client", Data: ut.IM{},
+					OnResponse: func(evt ResponseEvent) (re ResponseEvent) {
+						return evt
+					},
+				},
+			},
+			steps:     []string{"edit", "edit", "edit", "undo", "undo", "redo"},
+			wantState: ut.IM{"key": "customer", "undo": 1, "redo": 1, "dirty": false},
+		},
+		{
+			name: "form",
+			fields: fields{
+				BaseComponent: BaseComponent{
+					Id: "client", Data: ut.IM{},
+				},
+			},
+			steps:     []string{"edit", "form", "form", "undo"},
+			wantState: ut.IM{"key": "customer", "undo": 1, "redo": 1, "dirty": false},
+		},
+		{
+			name: "depth",
+			fields: fields{
+				BaseComponent: BaseComponent{
+					Id: "client", Data: ut.IM{},
+				},
+				HistoryDepth: 2,
+			},
+			steps:     []string{"edit", "edit", "edit", "edit", "edit"},
+			wantState: ut.IM{"key": "customer", "undo": 2, "redo": 0, "dirty": false},
+		},
+		{
+			name: "negative_depth",
+			fields: fields{
+				BaseComponent: BaseComponent{
+					Id: "client", Data: ut.IM{},
+				},
+				HistoryDepth: -1,
+			},
+			steps:     []string{"edit", "edit", "edit"},
+			wantState: ut.IM{"key": "customer", "undo": 0, "redo": 0, "dirty": false},
+		},
+		{
+			name: "dirty",
+			fields: fields{
+				BaseComponent: BaseComponent{
+					Id: "client", Data: ut.IM{},
+				},
+			},
+			steps:     []string{"edit", "edit", "dirty"},
+			wantState: ut.IM{"key": "customer", "undo": 1, "redo": 0, "dirty": true},
+		},
+		{
+			name: "record",
+			fields: fields{
+				BaseComponent: BaseComponent{
+					Id: "client", Data: ut.IM{},
+				},
+			},
+			steps:     []string{"edit", "edit", "record", "record"},
+			wantState: ut.IM{"key": "customer", "undo": 1, "redo": 0, "dirty": false},
+		},
+		{
+			name: "record_changed",
+			fields: fields{
+				BaseComponent: BaseComponent{
+					Id: "client", Data: ut.IM{},
+				},
+			},
+			steps:     []string{"edit", "edit", "record", "edit", "undo"},
+			wantState: ut.IM{"key": "customer", "undo": 0, "redo": 0, "dirty": false},
+		},
+		{
+			name: "missing",
+			fields: fields{
+				BaseComponent: BaseComponent{
+					Id: "client", Data: ut.IM{},
+				},
+			},
+			steps:     []string{"undo", "redo", "edit", "undo", "redo"},
+			wantState: ut.IM{"key": "customer", "undo": 0, "redo": 0, "dirty": false},
+		},
+		{
+			name: "reset",
+			fields: fields{
+				BaseComponent: BaseComponent{
+					Id: "client", Data: ut.IM{},
+				},
+			},
+			steps:     []string{"edit", "edit", "reset"},
+			wantState: ut.IM{"key": "", "undo": 0, "redo": 0, "dirty": false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cli := &Client{
+				BaseComponent: tt.fields.BaseComponent,
+				HistoryDepth:  tt.fields.HistoryDepth,
+			}
+			for index, step := range tt.steps {
+				switch step {
+				case "edit":
+					cli.SetEditor("customer", "main", ut.IM{"index": index})
+				case "record":
+					cli.SetEditor("customer", "main", ut.IM{"id": "customer-2", "index": index})
+				case "dirty":
+					cli.SetDirty(true)
+				case "form":
+					cli.SetForm("setting", ut.IM{"index": index}, 0, false)
+				case "undo":
+					cli.OnRequest(TriggerEvent{Id: "client_undo"})
+				case "redo":
+					cli.OnRequest(TriggerEvent{Id: "client_redo"})
+				case "reset":
+					cli.ResetEditor()
+				}
+			}
+			cli.Render()
+			if got := cli.HistoryState(); !reflect.DeepEqual(got, tt.wantState) {
+				t.Errorf("Client.HistoryState() = %v, want %v", got, tt.wantState)
+			}
+		})
+	}
+}
+
+func TestClient_SaveHistory(t *testing.T) {
+	cli := &Client{BaseComponent: BaseComponent{Id: "client", Data: ut.IM{}}}
+	cli.SetEditor("customer", "main", ut.IM{"id": "customer-1"})
+	cli.SetEditor("customer", "main", ut.IM{"id": "customer-1", "name": "name"})
+	cli.SetEditor("customer", "main", ut.IM{"id": "customer-2"})
+	cli.SetEditor("customer", "main", ut.IM{"id": "customer-2", "name": "name"})
+	if _, found := cli.History["customer/customer-2"]; !found || len(cli.History) != 1 {
+		t.Errorf("Client.SaveHistory() = %v", cli.History)
+	}
+}
+
+func TestClient_Guard(t *testing.T) {
+	editorData := func(dirty bool) ut.IM {
+		return ut.IM{"editor": ut.IM{"key": "customer", "view": "main", "dirty": dirty}}
+	}
+	guardResponse := func(evt ResponseEvent) (re ResponseEvent) {
+		if slices.Contains([]string{ClientEventGuardSave, ClientEventGuardDiscard}, evt.Name) {
+			return evt.Trigger.(*Client).ResumeGuard()
+		}
+		return evt
+	}
+	type fields struct {
+		BaseComponent   BaseComponent
+		UnsavedGuard    bool
+		GuardSideMenu   []string
+		CustomFunctions ClientInterface
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		evt       ResponseEvent
+		modal     ResponseEvent
+		wantName  string
+		wantDirty bool
+	}{
+		{
+			name: "main_menu_cancel",
+			fields: fields{
+				BaseComponent: BaseComponent{Id: "client", Data: editorData(true), OnResponse: guardResponse,
+					RequestValue: map[string]ut.IM{}},
+				UnsavedGuard:    true,
+				CustomFunctions: &testCustomFunctions{},
+			},
+			evt: ResponseEvent{Trigger: &MenuBar{}, TriggerName: "main_menu", Name: MenuBarEventValue, Value: "search"},
+			modal: ResponseEvent{Trigger: &Form{}, TriggerName: "modal", Name: FormEventCancel,
+				Value: ut.IM{"value": ut.IM{}}},
+			wantName:  ClientEventGuardCancel,
+			wantDirty: true,
+		},
+		{
+			name: "side_menu_save",
+			fields: fields{
+				BaseComponent: BaseComponent{Id: "client", Data: editorData(true), OnResponse: guardResponse},
+				UnsavedGuard:  true,
+				GuardSideMenu: []string{"editor_cancel"},
+			},
+			evt: ResponseEvent{Trigger: &SideBar{}, TriggerName: "side_menu", Name: SideBarEventItem, Value: "editor_cancel"},
+			modal: ResponseEvent{Trigger: &Form{}, TriggerName: "modal", Name: FormEventCancel,
+				Value: ut.IM{"value": ut.IM{ClientEventGuardSave: ClientEventGuardSave}}},
+			wantName:  ClientEventSideMenu,
+			wantDirty: false,
+		},
+		{
+			name: "editor_view_discard",
+			fields: fields{
+				BaseComponent: BaseComponent{Id: "client", Data: editorData(true)},
+				UnsavedGuard:  true,
+			},
+			evt: ResponseEvent{Trigger: &Editor{}, TriggerName: "editor", Name: EditorEventView, Value: "item"},
+			modal: ResponseEvent{Trigger: &Form{}, TriggerName: "modal", Name: FormEventCancel,
+				Value: ut.IM{"value": ut.IM{ClientEventGuardDiscard: ClientEventGuardDiscard}}},
+			wantName:  ClientEventGuardDiscard,
+			wantDirty: false,
+		},
+		{
+			name: "not_dirty",
+			fields: fields{
+				BaseComponent: BaseComponent{Id: "client", Data: editorData(false)},
+				UnsavedGuard:  true,
+			},
+			evt: ResponseEvent{Trigger: &Editor{Dirty: true}, TriggerName: "editor", Name: EditorEventField},
+			modal: ResponseEvent{Trigger: &Form{}, TriggerName: "modal", Name: FormEventChange,
+				Value: ut.IM{"value": ut.IM{}}},
+			wantName:  FormEventChange,
+			wantDirty: true,
+		},
+		{
+			name: "not_guarded",
+			fields: fields{
+				BaseComponent: BaseComponent{Id: "client", Data: editorData(true)},
+				UnsavedGuard:  true,
+			},
+			evt: ResponseEvent{Trigger: &Form{Dirty: true}, TriggerName: "form", Name: FormEventChange},
+			modal: ResponseEvent{Trigger: &Form{}, TriggerName: "modal", Name: FormEventOK,
+				Value: ut.IM{"value": ut.IM{}}},
+			wantName:  FormEventOK,
+			wantDirty: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cli := &Client{
+				BaseComponent:   tt.fields.BaseComponent,
+				UnsavedGuard:    tt.fields.UnsavedGuard,
+				GuardSideMenu:   tt.fields.GuardSideMenu,
+				CustomFunctions: tt.fields.CustomFunctions,
+			}
+			cli.response(tt.evt)
+			cli.Render()
+			if got := cli.response(tt.modal); got.Name != tt.wantName {
+				t.Errorf("Client.response() = %v, want %v", got.Name, tt.wantName)
+			}
+			if got := cli.EditorDirty(); got != tt.wantDirty {
+				t.Errorf("Client.EditorDirty() = %v, want %v", got, tt.wantDirty)
+			}
+		})
+	}
+	cli := &Client{BaseComponent: BaseComponent{Data: ut.IM{}}}
+	cli.SetDirty(true)
+	if cli.EditorDirty() {
+		t.Errorf("Client.EditorDirty() = %v, want %v", true, false)
+	}
+}
+
+func TestClient_HelpPage(t *testing.T) {
+	help := fstest.MapFS{
+		"index.md": {}, "login.md": {}, "search.md": {}, "customer.md": {}, "customer_item.md": {},
+	}
+	validTicket := Ticket{SessionID: "SES012345", User: ut.IM{"username": "admin"}}
+	tests := []struct {
+		name          string
+		help          fs.FS
+		data          ut.IM
+		ticket        Ticket
+		loginDisabled bool
+		want          string
+	}{
+		{
+			name: "missing_fs",
+			data: ut.IM{},
+			want: "index.md",
+		},
+		{
+			name: "login",
+			help: help,
+			data: ut.IM{"editor": ut.IM{"key": "customer", "view": "item"}},
+			want: "login.md",
+		},
+		{
+			name:   "editor_view",
+			help:   help,
+			data:   ut.IM{"editor": ut.IM{"key": "customer", "view": "item"}},
+			ticket: validTicket,
+			want:   "customer_item.md",
+		},
+		{
+			name:   "editor",
+			help:   help,
+			data:   ut.IM{"editor": ut.IM{"key": "customer", "view": "main"}},
+			ticket: validTicket,
+			want:   "customer.md",
+		},
+		{
+			name:          "search",
+			help:          help,
+			data:          ut.IM{"search": ut.IM{"view": "customer_simple", "simple": true}},
+			loginDisabled: true,
+			want:          "search.md",
+		},
+		{
+			name:   "index",
+			help:   help,
+			data:   ut.IM{"editor": ut.IM{"key": "setting", "form": ut.IM{"key": "setting"}}},
+			ticket: validTicket,
+			want:   "index.md",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cli := &Client{
+				BaseComponent: BaseComponent{Data: tt.data},
+				Ticket:        tt.ticket,
+				LoginDisabled: tt.loginDisabled,
+				Help:          tt.help,
+			}
+			if got := cli.HelpPage(); got != tt.want {
+				t.Errorf("Client.HelpPage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_Help(t *testing.T) {
+	help := fstest.MapFS{
+		"index.md": {Data: []byte("# Help\n\n[Search](search.md)")}, "search.md": {Data: []byte("# Search")},
+		"browser.md": {Data: []byte("# Browser")},
+	}
+	tests := []struct {
+		name       string
+		help       fs.FS
+		onResponse func(evt ResponseEvent) (re ResponseEvent)
+		evt        ResponseEvent
+		wantName   string
+		wantPage   string
+	}{
+		{
+			name:     "missing_fs",
+			evt:      ResponseEvent{Trigger: &Search{}, TriggerName: "search", Name: SearchEventHelp},
+			wantName: SearchEventHelp,
+		},
+		{
+			name:     "search",
+			help:     help,
+			evt:      ResponseEvent{Trigger: &Search{}, TriggerName: "search", Name: SearchEventHelp},
+			wantName: ClientEventHelp,
+			wantPage: "search.md",
+		},
+		{
+			name: "main_menu",
+			help: help,
+			onResponse: func(evt ResponseEvent) (re ResponseEvent) {
+				return evt
+			},
+			evt:      ResponseEvent{Trigger: &MenuBar{}, TriggerName: "main_menu", Name: MenuBarEventValue, Value: "help"},
+			wantName: ClientEventHelp,
+			wantPage: "search.md",
+		},
+		{
+			name:     "link",
+			help:     help,
+			evt:      ResponseEvent{Trigger: &Markdown{}, TriggerName: "help", Name: MarkdownEventLink, Value: "browser.md"},
+			wantName: ClientEventHelp,
+			wantPage: "browser.md",
+		},
+		{
+			name:     "close",
+			help:     help,
+			evt:      ResponseEvent{Trigger: &Icon{}, TriggerName: "help_close", Name: IconEventClick},
+			wantName: ClientEventHelpClose,
+		},
+		{
+			name:     "other",
+			help:     help,
+			evt:      ResponseEvent{Trigger: &SideBar{}, TriggerName: "side_menu", Name: SideBarEventItem},
+			wantName: ClientEventSideMenu,
+			wantPage: "index.md",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cli := &Client{
+				BaseComponent: BaseComponent{
+					Id: "client", EventURL: "/event", OnResponse: tt.onResponse,
+					RequestValue: map[string]ut.IM{}, RequestMap: map[string]ClientComponent{},
+					Data: ut.IM{"search": ut.IM{"view": "customer_simple", "simple": true}, "help": ut.IM{"page": "index.md"}},
+				},
+				LoginDisabled: true,
+				Help:          tt.help,
+			}
+			if got := cli.response(tt.evt); got.Name != tt.wantName {
+				t.Errorf("Client.response() = %v, want %v", got.Name, tt.wantName)
+			}
+			page := ut.ToString(ut.ToIM(cli.Data["help"], ut.IM{})["page"], "")
+			if tt.help != nil && page != tt.wantPage {
+				t.Errorf("Client.Data[help] = %v, want %v", page, tt.wantPage)
+			}
+		})
+	}
+
+	cli := &Client{
+		BaseComponent: BaseComponent{
+			Id: "client", EventURL: "/event",
+			RequestValue: map[string]ut.IM{}, RequestMap: map[string]ClientComponent{},
+			Data: ut.IM{"help": ut.IM{"page": "index.md"}},
+		},
+		LoginDisabled: true,
+		Help:          help,
+	}
+	res, err := cli.Render()
+	if err != nil || !strings.Contains(string(res), `class="client-help"`) || !strings.Contains(string(res), `<span class="client-help-title">Help</span>`) {
+		t.Errorf("Client.Render() = %v, %v", res, err)
+	}
+	evt := cli.OnRequest(TriggerEvent{Id: "client_help_link_1", Values: url.Values{"link": []string{"search.md"}}})
+	if evt.Name != ClientEventHelp || evt.Value != "search.md" {
+		t.Errorf("Client.OnRequest() = %v, %v", evt.Name, evt.Value)
+	}
+	if res, _ = cli.Render(); !strings.Contains(string(res), `<h1>Search</h1>`) {
+		t.Errorf("Client.Render() = %v", res)
+	}
+	cli.CloseHelp()
+	if res, _ = cli.Render(); strings.Contains(string(res), `class="client-help"`) {
+		t.Errorf("Client.Render() = %v", res)
+	}
+}
+
+// The Dashboard method of the embedded testCustomFunctions is shadowed, so it does not implement the ClientDashboard
+type testClientNoDashboard struct {
+	testCustomFunctions
+}
+
+func (c *testClientNoDashboard) Dashboard() {}
+
+func TestClient_Dashboard(t *testing.T) {
+	cli := &Client{
+		BaseComponent: BaseComponent{
+			Id: "client", EventURL: "/event",
+			RequestValue: map[string]ut.IM{}, RequestMap: map[string]ClientComponent{},
+			Data: ut.IM{"search": ut.IM{"view": "customer_simple", "simple": true},
+				"editor": ut.IM{"key": "customer", "view": "main"}},
+		},
+		Ticket:          Ticket{SessionID: "1234567890", User: ut.IM{"username": "dashboard_user"}},
+		CustomFunctions: &testCustomFunctions{},
+	}
+	cli.SetDashboard("home", ut.IM{"period": "month"})
+	if state, key, data := cli.GetStateData(); state != "dashboard" || key != "home" || data["period"] != "month" {
+		t.Errorf("Client.GetStateData() = %v, %v, %v", state, key, data)
+	}
+	res, err := cli.Render()
+	if err != nil || !strings.Contains(string(res), `id="client_dashboard" name="dashboard" class="dashboard `) {
+		t.Errorf("Client.Render() = %v, %v", res, err)
+	}
+	dsh := cli.RequestMap["client_dashboard"].(*Dashboard)
+	if dsh.User != "dashboard_user" || len(dsh.Widgets) == 0 {
+		t.Errorf("Client dashboard = %v, %v", dsh.User, len(dsh.Widgets))
+	}
+
+	// the layout changes render the client, the refreshed widget is selected from the client
+	evt := cli.OnRequest(TriggerEvent{Id: "client_dashboard", Values: url.Values{"action": {"resize"}, "widget": {"sales"}}})
+	if evt.Name != DashboardEventResize || evt.Trigger != cli || evt.Header[HeaderRetarget] != "#client" {
+		t.Errorf("Client.OnRequest() = %v, %v", evt.Name, evt.Header)
+	}
+	evt = cli.OnRequest(TriggerEvent{Id: "client_dashboard_orders_refresh", Values: url.Values{"action": {"refresh"}, "widget": {"orders"}}})
+	if evt.Name != DashboardEventRefresh || evt.Trigger != cli || evt.Header != nil {
+		t.Errorf("Client.OnRequest() = %v, %v", evt.Name, evt.Header)
+	}
+
+	// the same dashboard keeps the request values
+	cli.RequestValue["client_dashboard_add"] = ut.IM{}
+	cli.SetDashboard("home", ut.IM{})
+	if _, found := cli.RequestValue["client_dashboard_add"]; !found {
+		t.Error("Client.SetDashboard() cleaned the dashboard")
+	}
+	cli.SetDashboard("sales", ut.IM{})
+	if _, found := cli.RequestValue["client_dashboard_add"]; found {
+		t.Error("Client.SetDashboard() missing clean")
+	}
+
+	cli.SetSearch("customer_simple", ut.IM{}, true)
+	if state, _, _ := cli.GetStateData(); state != "search" {
+		t.Errorf("Client.SetSearch() state = %v", state)
+	}
+
+	// the CustomFunctions without dashboard function
+	cli.CustomFunctions = &testClientNoDashboard{}
+	cli.Ticket.User = ut.IM{}
+	cli.SetDashboard("home", ut.IM{})
+	if res, err = cli.Render(); err != nil || !strings.Contains(string(res), `class="dashboard-empty"`) {
+		t.Errorf("Client.Render() = %v, %v", res, err)
+	}
+}
+
+// The menu and the side bar of the command palette commands
+type testClientPaletteFunctions struct {
+	testCustomFunctions
+}
+
+func (c *testClientPaletteFunctions) Menu(labels ut.SM, config ut.IM) MenuBar {
+	return MenuBar{Items: []MenuBarItem{
+		{Value: "search", Label: "Search", Icon: IconSearch},
+		{Value: "docs", Label: "Docs", ItemURL: "https://example.com"},
+	}}
+}
+
+func (c *testClientPaletteFunctions) SideBar(moduleKey string, labels ut.SM, data ut.IM) SideBar {
+	return SideBar{Items: []SideBarItem{
+		&SideBarElement{Value: "editor_save", Label: "Save"},
+		&SideBarElement{Value: "editor_new", Label: "New", Disabled: true},
+		&SideBarSeparator{},
+		&SideBarGroup{Label: "Report", Items: []SideBarElement{
+			{Value: "report_pdf", Label: "PDF"}, {Label: "Empty"},
+		}},
+	}}
+}
+
+func TestClient_Palette(t *testing.T) {
+	labels := func(commands []PaletteCommand) (result []string) {
+		for _, command := range commands {
+			result = append(result, command.Label+"|"+command.Description+"|"+command.Group)
+		}
+		return result
+	}
+	cli := &Client{
+		BaseComponent: BaseComponent{
+			Id: "client", EventURL: "/event",
+			RequestValue: map[string]ut.IM{}, RequestMap: map[string]ClientComponent{},
+			Data: ut.IM{"search": ut.IM{"view": "customer_simple", "simple": true}},
+		},
+		LoginDisabled:    true,
+		CommandPalette:   true,
+		PaletteProviders: []string{"palette_customer"},
+		CustomFunctions:  &testClientPaletteFunctions{},
+	}
+
+	// the recent commands are deduplicated and limited
+	for i := 0; i < ClientDefaultRecentCommands+2; i++ {
+		cli.AddRecentCommand(PaletteCommand{
+			Label: fmt.Sprintf("Customer %d", i), TriggerName: "search", Name: SearchEventSelected, Value: i})
+	}
+	cli.AddRecentCommand(PaletteCommand{Label: "Customer 5", TriggerName: "search", Name: SearchEventSelected, Value: 5})
+	if len(cli.RecentCommands) != ClientDefaultRecentCommands || cli.RecentCommands[0].Label != "Customer 5" ||
+		cli.RecentCommands[0].Group != PaletteGroupRecent || cli.RecentCommands[1].Label != "Customer 11" {
+		t.Errorf("Client.AddRecentCommand() = %v", labels(cli.RecentCommands))
+	}
+	cli.SetProperty("recent_commands", cli.RecentCommands[:1])
+	if got := labels(cli.PaletteCommands()); !reflect.DeepEqual(got, []string{
+		"Customer 5||recent", "Search||menu", "Save||sidebar", "PDF|Report|sidebar"}) {
+		t.Errorf("Client.PaletteCommands() = %v", got)
+	}
+	cli.HideMenu, cli.HideSideBar = true, true
+	if got := labels(cli.PaletteCommands()); !reflect.DeepEqual(got, []string{"Customer 5||recent"}) {
+		t.Errorf("Client.PaletteCommands() = %v", got)
+	}
+	cli.HideMenu, cli.HideSideBar = false, false
+
+	// Ctrl+K opens the palette
+	res, err := cli.Render()
+	if err != nil || !strings.Contains(string(res), `id="client_palette_open"`) ||
+		strings.Contains(string(res), `class="modal client-palette"`) {
+		t.Errorf("Client.Render() = %v, %v", res, err)
+	}
+	evt := cli.OnRequest(TriggerEvent{Id: "client_palette_open"})
+	if evt.Name != ClientEventPalette || evt.Header[HeaderRetarget] != "#client" {
+		t.Errorf("Client.OnRequest() = %v, %v", evt.Name, evt.Header)
+	}
+	if res, err = cli.Render(); err != nil || !strings.Contains(string(res), `class="modal client-palette"`) {
+		t.Errorf("Client.Render() = %v, %v", res, err)
+	}
+
+	// the palette search is returned to the palette
+	evt = cli.OnRequest(TriggerEvent{Id: "client_palette_input", Name: "palette", Values: url.Values{"palette": {"sea"}}})
+	if evt.Name != PaletteEventSearch || evt.TriggerName != "palette" {
+		t.Errorf("Client.OnRequest() = %v, %v", evt.TriggerName, evt.Name)
+	}
+
+	// the execution closes the palette and dispatches the event of the menu item
+	evt = cli.OnRequest(TriggerEvent{Id: "client_palette_input", Name: "palette",
+		Values: url.Values{"palette": {"sea"}, "key": {AutocompleteKeyEnter}}})
+	if _, open := cli.Data["palette"]; open || evt.Name != ClientEventModule || evt.Value != "search" ||
+		evt.Header[HeaderRetarget] != "#client" {
+		t.Errorf("Client.OnRequest() = %v, %v, %v", evt.Name, evt.Value, evt.Header)
+	}
+
+	// the retarget of the OnResponse is kept
+	cli.OnResponse = func(evt ResponseEvent) (re ResponseEvent) {
+		if evt.Name == ClientEventSideMenu {
+			evt.Header = ut.SM{HeaderRetarget: "#toast-msg"}
+		}
+		return evt
+	}
+	evt = cli.responsePalette(ResponseEvent{Name: PaletteEventExecute, Value: PaletteCommand{
+		TriggerName: "side_menu", Name: SideBarEventItem, Value: "editor_save"}})
+	if evt.Name != ClientEventSideMenu || evt.Header[HeaderRetarget] != "#toast-msg" {
+		t.Errorf("Client.responsePalette() = %v, %v", evt.Name, evt.Header)
+	}
+
+	// Escape closes the palette
+	if evt = cli.responsePaletteOpen(); evt.Name != ClientEventPalette {
+		t.Errorf("Client.responsePaletteOpen() = %v", evt.Name)
+	}
+	cli.Render()
+	evt = cli.OnRequest(TriggerEvent{Id: "client_palette_input", Name: "palette",
+		Values: url.Values{"palette": {"sea"}, "key": {AutocompleteKeyEscape}}})
+	if _, open := cli.Data["palette"]; open || evt.Name != ClientEventPaletteClose || evt.Value != "sea" {
+		t.Errorf("Client.OnRequest() = %v, %v", evt.Name, evt.Value)
+	}
+	cli.OnResponse = nil
+	if evt = cli.responsePalette(ResponseEvent{Name: PaletteEventClose}); evt.Name != ClientEventPaletteClose {
+		t.Errorf("Client.responsePalette() = %v", evt.Name)
+	}
+	if evt = cli.responsePaletteOpen(); evt.Name != ClientEventPalette || evt.Trigger != cli {
+		t.Errorf("Client.responsePaletteOpen() = %v", evt.Name)
+	}
+
+	// the shortcut is disabled without a valid ticket
+	cli.LoginDisabled = false
+	if res, err = cli.Render(); err != nil || strings.Contains(string(res), `id="client_palette_open"`) {
+		t.Errorf("Client.Render() = %v, %v", res, err)
+	}
+}
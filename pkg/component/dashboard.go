@@ -0,0 +1,723 @@
+package component
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"slices"
+	"strings"
+
+	ut "github.com/nervatura/component/pkg/util"
+)
+
+// [Dashboard] constants
+const (
+	ComponentTypeDashboard = "dashboard"
+
+	DashboardEventAdd     = "dashboard_add"
+	DashboardEventRemove  = "dashboard_remove"
+	DashboardEventMove    = "dashboard_move"
+	DashboardEventResize  = "dashboard_resize"
+	DashboardEventRefresh = "dashboard_refresh"
+
+	// The default number of the grid columns
+	DashboardDefaultColumns = 4
+	// The default height of a grid row in pixels
+	DashboardDefaultRowHeight = 160
+)
+
+var dashboardDefaultLabel ut.SM = ut.SM{
+	"dashboard_add":    "Add widget",
+	"dashboard_remove": "Remove",
+	"dashboard_resize": "Resize",
+	"dashboard_empty":  "There are no widgets on the dashboard",
+}
+
+var errDashboardWidget = errors.New("unknown dashboard widget")
+
+// A widget of the [Dashboard]
+type DashboardWidget struct {
+	// The unique name of the widget
+	Name  string `json:"name"`
+	Title string `json:"title"`
+	// Valid [Icon] component value. See more [IconValues] variable values.
+	Icon string `json:"icon"`
+	// The content of the widget. For example a [Label] KPI value, a [Chart] or a small [Table]
+	Component ClientComponent `json:"component"`
+	// The default number of the spanned grid columns. Default value: 1
+	Width int64 `json:"width"`
+	// The default number of the spanned grid rows. Default value: 1
+	Height int64 `json:"height"`
+	// The htmx polling interval of the widget in seconds. No polling if the value is 0
+	Refresh int64 `json:"refresh"`
+}
+
+/*
+Creates a dashboard of the Widgets in a responsive grid. The Layout contains the visible widgets in display
+order with their sizes, and the widgets can be added, removed, moved by drag and drop and resized by the resize
+handle of the widgets. The default layout contains all Widgets with their default sizes.
+
+The layout of the User is loaded from and saved into the Store if it is set. The widgets with Refresh value
+are reloaded by htmx polling, and a widget can be refreshed by server push with the [Dashboard.PublishWidget]
+function.
+
+For example:
+
+	&Dashboard{
+	  BaseComponent: BaseComponent{
+	    Id:       "id_dashboard_home",
+	    EventURL: "/event",
+	  },
+	  Widgets: []DashboardWidget{
+	    {Name: "orders", Title: "Orders", Icon: IconShoppingCart, Refresh: 30,
+	      Component: &Label{BaseComponent: BaseComponent{Id: "id_kpi_orders"}, Value: "128"}},
+	    {Name: "sales", Title: "Monthly sales", Icon: IconChartBar, Width: 2, Height: 2,
+	      Component: &Chart{BaseComponent: BaseComponent{Id: "id_chart_sales"}, ...}},
+	  },
+	  User:  "admin",
+	  Store: &MemoryDashboardStore{},
+	}
+*/
+type Dashboard struct {
+	BaseComponent
+	// All available widgets of the dashboard
+	Widgets []DashboardWidget `json:"widgets"`
+	// The visible widgets in display order. The default layout is used if the value is nil.
+	Layout []DashboardItem `json:"layout"`
+	// The number of the grid columns on wide screens. Default value: [DashboardDefaultColumns]
+	Columns int64 `json:"columns"`
+	// The height of a grid row in pixels. Default value: [DashboardDefaultRowHeight]
+	RowHeight int64 `json:"row_height"`
+	// The owner of the stored layout
+	User string `json:"user"`
+	// The storage of the user layouts. See [LocalDashboardStore] and [MemoryDashboardStore]
+	Store DashboardStore `json:"-"`
+	// Disables the adding, removing, moving and resizing of the widgets
+	ReadOnly bool `json:"readonly"`
+	// The texts of the labels of the component
+	Labels ut.SM `json:"labels"`
+}
+
+/*
+Returns all properties of the [Dashboard]
+*/
+func (dsh *Dashboard) Properties() ut.IM {
+	return ut.MergeIM(
+		dsh.BaseComponent.Properties(),
+		ut.IM{
+			"widgets":    dsh.Widgets,
+			"layout":     dsh.Layout,
+			"columns":    dsh.Columns,
+			"row_height": dsh.RowHeight,
+			"user":       dsh.User,
+			"store":      dsh.Store,
+			"readonly":   dsh.ReadOnly,
+			"labels":     dsh.Labels,
+		})
+}
+
+/*
+Returns the value of the property of the [Dashboard] with the specified name.
+*/
+func (dsh *Dashboard) GetProperty(propName string) interface{} {
+	return dsh.Properties()[propName]
+}
+
+/*
+It checks the value given to the property of the [Dashboard] and always returns a valid value
+*/
+func (dsh *Dashboard) Validation(propName string, propValue interface{}) interface{} {
+	pm := map[string]func() interface{}{
+		"widgets": func() interface{} {
+			if widgets, valid := propValue.([]DashboardWidget); valid && widgets != nil {
+				return widgets
+			}
+			return []DashboardWidget{}
+		},
+		"layout": func() interface{} {
+			// the nil value is the default layout
+			if layout, valid := propValue.([]DashboardItem); valid {
+				return layout
+			}
+			var layout []DashboardItem
+			if err := ut.ConvertToType(propValue, &layout); err != nil {
+				return []DashboardItem(nil)
+			}
+			return layout
+		},
+		"columns": func() interface{} {
+			if value := ut.ToInteger(propValue, 0); value > 0 {
+				return value
+			}
+			return int64(DashboardDefaultColumns)
+		},
+		"row_height": func() interface{} {
+			if value := ut.ToInteger(propValue, 0); value > 0 {
+				return value
+			}
+			return int64(DashboardDefaultRowHeight)
+		},
+		"store": func() interface{} {
+			if value, valid := propValue.(DashboardStore); valid {
+				return value
+			}
+			return nil
+		},
+		"labels": func() interface{} {
+			value := ut.ToSM(dsh.Labels, ut.SM{})
+			switch v := propValue.(type) {
+			case ut.SM:
+				value = ut.MergeSM(value, v)
+			case ut.IM:
+				value = ut.MergeSM(value, ut.IMToSM(v))
+			}
+			if len(value) == 0 {
+				value = dashboardDefaultLabel
+			}
+			return value
+		},
+		"target": func() interface{} {
+			dsh.SetProperty("id", dsh.Id)
+			value := ut.ToString(propValue, dsh.Id)
+			if value != "this" && !strings.HasPrefix(value, "#") {
+				value = "#" + value
+			}
+			return value
+		},
+	}
+	if _, found := pm[propName]; found {
+		return pm[propName]()
+	}
+	if dsh.BaseComponent.GetProperty(propName) != nil {
+		return dsh.BaseComponent.Validation(propName, propValue)
+	}
+	return propValue
+}
+
+/*
+Setting a property of the [Dashboard] value safely. Checks the entered value.
+In case of an invalid value, the default value will be set.
+*/
+func (dsh *Dashboard) SetProperty(propName string, propValue interface{}) interface{} {
+	pm := map[string]func() interface{}{
+		"widgets": func() interface{} {
+			dsh.Widgets = dsh.Validation(propName, propValue).([]DashboardWidget)
+			return dsh.Widgets
+		},
+		"layout": func() interface{} {
+			dsh.Layout = dsh.Validation(propName, propValue).([]DashboardItem)
+			return dsh.Layout
+		},
+		"columns": func() interface{} {
+			dsh.Columns = dsh.Validation(propName, propValue).(int64)
+			return dsh.Columns
+		},
+		"row_height": func() interface{} {
+			dsh.RowHeight = dsh.Validation(propName, propValue).(int64)
+			return dsh.RowHeight
+		},
+		"user": func() interface{} {
+			dsh.User = ut.ToString(propValue, "")
+			return dsh.User
+		},
+		"store": func() interface{} {
+			dsh.Store, _ = dsh.Validation(propName, propValue).(DashboardStore)
+			return dsh.Store
+		},
+		"readonly": func() interface{} {
+			dsh.ReadOnly = ut.ToBoolean(propValue, false)
+			return dsh.ReadOnly
+		},
+		"labels": func() interface{} {
+			dsh.Labels = dsh.Validation(propName, propValue).(ut.SM)
+			return dsh.Labels
+		},
+		"target": func() interface{} {
+			dsh.Target = dsh.Validation(propName, propValue).(string)
+			return dsh.Target
+		},
+	}
+	if _, found := pm[propName]; found {
+		return dsh.SetRequestValue(propName, pm[propName](), []string{})
+	}
+	if dsh.BaseComponent.GetProperty(propName) != nil {
+		return dsh.BaseComponent.SetProperty(propName, propValue)
+	}
+	return propValue
+}
+
+func (dsh *Dashboard) msg(labelID string) string {
+	if label, found := dsh.Labels[labelID]; found {
+		return label
+	}
+	return dashboardDefaultLabel[labelID]
+}
+
+func (dsh *Dashboard) widgetIndex(name string) int {
+	return slices.IndexFunc(dsh.Widgets, func(widget DashboardWidget) bool { return widget.Name == name })
+}
+
+// Returns a valid widget size: the width is between 1 and the Columns value, the height is at least 1
+func (dsh *Dashboard) itemSize(width, height int64) (int64, int64) {
+	return min(max(width, 1), dsh.Columns), max(height, 1)
+}
+
+/*
+Returns the visible widgets of the Layout. The unknown and duplicated widget names are skipped, and the default
+layout contains all Widgets with their default sizes.
+*/
+func (dsh *Dashboard) Items() (items []DashboardItem) {
+	items = []DashboardItem{}
+	layout := dsh.Layout
+	if layout == nil {
+		for _, widget := range dsh.Widgets {
+			layout = append(layout, DashboardItem{Name: widget.Name, Width: widget.Width, Height: widget.Height})
+		}
+	}
+	for _, item := range layout {
+		if dsh.widgetIndex(item.Name) > -1 && dsh.itemIndex(items, item.Name) < 0 {
+			item.Width, item.Height = dsh.itemSize(item.Width, item.Height)
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func (dsh *Dashboard) itemIndex(items []DashboardItem, name string) int {
+	return slices.IndexFunc(items, func(item DashboardItem) bool { return item.Name == name })
+}
+
+// Loads the layout of the User from the Store if the Layout is not set
+func (dsh *Dashboard) loadLayout() {
+	if dsh.Layout == nil && dsh.Store != nil && dsh.User != "" {
+		if layout, err := dsh.Store.Load(dsh.User); err == nil && layout != nil {
+			dsh.SetProperty("layout", layout)
+		}
+	}
+}
+
+// Sets and stores the new layout and returns the response event of the layout change
+func (dsh *Dashboard) update(name string, layout []DashboardItem, value ut.IM) (re ResponseEvent) {
+	dsh.SetProperty("layout", layout)
+	if dsh.Store != nil && dsh.User != "" {
+		if err := dsh.Store.Save(dsh.User, dsh.Layout); err != nil {
+			value["error"] = err.Error()
+		}
+	}
+	return dsh.response(name, value)
+}
+
+func (dsh *Dashboard) response(name string, value ut.IM) (re ResponseEvent) {
+	evt := ResponseEvent{
+		Trigger: dsh, TriggerName: dsh.Name, Name: name,
+		Value: ut.MergeIM(value, ut.IM{"layout": dsh.Items()}),
+	}
+	if dsh.OnResponse != nil {
+		return dsh.OnResponse(evt)
+	}
+	return evt
+}
+
+/*
+The AddWidget function appends the widget to the end of the layout with its default size.
+*/
+func (dsh *Dashboard) AddWidget(name string) (re ResponseEvent) {
+	items := dsh.Items()
+	if index := dsh.widgetIndex(name); index > -1 && dsh.itemIndex(items, name) < 0 {
+		width, height := dsh.itemSize(dsh.Widgets[index].Width, dsh.Widgets[index].Height)
+		items = append(items, DashboardItem{Name: name, Width: width, Height: height})
+	}
+	return dsh.update(DashboardEventAdd, items, ut.IM{"widget": name})
+}
+
+/*
+The RemoveWidget function removes the widget from the layout.
+*/
+func (dsh *Dashboard) RemoveWidget(name string) (re ResponseEvent) {
+	items := slices.DeleteFunc(dsh.Items(), func(item DashboardItem) bool { return item.Name == name })
+	return dsh.update(DashboardEventRemove, items, ut.IM{"widget": name})
+}
+
+/*
+If the OnResponse function of the [Dashboard] is implemented, the function calls it after the [TriggerEvent]
+is processed, otherwise the function's return [ResponseEvent] is the processed [TriggerEvent].
+*/
+func (dsh *Dashboard) OnRequest(te TriggerEvent) (re ResponseEvent) {
+	name := te.Values.Get("widget")
+	items := dsh.Items()
+	index := dsh.itemIndex(items, name)
+	switch te.Values.Get("action") {
+	case "move":
+		position := ut.ToInteger(te.Values.Get("position"), 0)
+		if index > -1 {
+			item := items[index]
+			items = slices.Delete(items, index, index+1)
+			position = min(max(position, 0), int64(len(items)))
+			items = slices.Insert(items, int(position), item)
+		}
+		return dsh.update(DashboardEventMove, items, ut.IM{"widget": name, "position": position})
+
+	case "resize":
+		width, height := dsh.itemSize(
+			ut.ToInteger(te.Values.Get("width"), 1), ut.ToInteger(te.Values.Get("height"), 1))
+		if index > -1 {
+			items[index].Width, items[index].Height = width, height
+		}
+		return dsh.update(DashboardEventResize, items, ut.IM{"widget": name, "width": width, "height": height})
+	}
+	return dsh.response(DashboardEventRefresh, ut.IM{"widget": name})
+}
+
+/*
+The PublishWidget function pushes the current content of the widget to the page of the session by the [SSEHub].
+The Component of the widget must have an Id.
+*/
+func (dsh *Dashboard) PublishWidget(hub *SSEHub, session, name string) error {
+	index := dsh.widgetIndex(name)
+	if index < 0 || dsh.Widgets[index].Component == nil {
+		return errDashboardWidget
+	}
+	return hub.PublishComponent(session, dsh.Widgets[index].Component)
+}
+
+// A visible widget of the dashboard
+type dashboardCell struct {
+	DashboardItem
+	Widget DashboardWidget
+	// The element id of the widget
+	Id string
+}
+
+func (dsh *Dashboard) cells() (cells []dashboardCell) {
+	for _, item := range dsh.Items() {
+		cells = append(cells, dashboardCell{
+			DashboardItem: item,
+			Widget:        dsh.Widgets[dsh.widgetIndex(item.Name)],
+			Id:            dsh.Id + "_" + item.Name,
+		})
+	}
+	return cells
+}
+
+func (dsh *Dashboard) component(name, widget string) ClientComponent {
+	ccBase := func(id string, onResponse func(evt ResponseEvent) (re ResponseEvent)) BaseComponent {
+		return BaseComponent{
+			Id:           id,
+			Name:         name,
+			EventURL:     dsh.EventURL,
+			Target:       dsh.Target,
+			Swap:         dsh.Swap,
+			Indicator:    dsh.Indicator,
+			RequestValue: dsh.RequestValue,
+			RequestMap:   dsh.RequestMap,
+			OnResponse:   onResponse,
+		}
+	}
+	ccMap := map[string]func() ClientComponent{
+		"add": func() ClientComponent {
+			options := []SelectOption{}
+			items := dsh.Items()
+			for _, widget := range dsh.Widgets {
+				if dsh.itemIndex(items, widget.Name) < 0 {
+					options = append(options, SelectOption{Value: widget.Name, Text: widget.Title})
+				}
+			}
+			return &Select{
+				BaseComponent: ccBase(dsh.Id+"_add", func(evt ResponseEvent) (re ResponseEvent) {
+					// the next render shows the empty value
+					delete(dsh.RequestValue, dsh.Id+"_add")
+					return dsh.AddWidget(ut.ToString(evt.Value, ""))
+				}),
+				Options:  options,
+				IsNull:   true,
+				Label:    dsh.msg("dashboard_add"),
+				Disabled: (len(options) == 0),
+			}
+		},
+		"remove": func() ClientComponent {
+			ico := &Icon{
+				BaseComponent: ccBase(dsh.Id+"_"+widget+"_remove", func(evt ResponseEvent) (re ResponseEvent) {
+					return dsh.RemoveWidget(widget)
+				}),
+				Value: IconTimes, Width: 16, Height: 16,
+			}
+			ico.Class = []string{"dashboard-remove"}
+			return ico
+		},
+		"title": func() ClientComponent {
+			return &Icon{Value: dsh.Widgets[dsh.widgetIndex(widget)].Icon, Width: 16, Height: 16}
+		},
+	}
+	return ccMap[name]()
+}
+
+/*
+Based on the values, it will generate the html code of the [Dashboard] or return with an error message.
+*/
+func (dsh *Dashboard) Render() (html template.HTML, err error) {
+	return RenderHTML(dsh)
+}
+
+// the render data of the [Dashboard] template
+type dashboardRender struct {
+	*Dashboard
+	cells []dashboardCell
+	hw    *ut.HTMLWriter
+}
+
+// [Dashboard] template functions
+var dashboardFuncMap = map[string]any{
+	"customClass": func(dsh *dashboardRender) string {
+		return strings.Join(dsh.Class, " ")
+	},
+	"msg": func(dsh *dashboardRender, labelID string) string {
+		return dsh.msg(labelID)
+	},
+	"cells": func(dsh *dashboardRender) []dashboardCell {
+		return dsh.cells
+	},
+	"editable": func(dsh *dashboardRender) bool {
+		return dsh.EventURL != "" && !dsh.ReadOnly
+	},
+	"dashboardComponent": func(dsh *dashboardRender, name, widget string) (template.HTML, error) {
+		return "", RenderTo(dsh.hw, dsh.component(name, widget))
+	},
+	"widgetComponent": func(dsh *dashboardRender, cc ClientComponent) (template.HTML, error) {
+		if cc == nil {
+			return "", nil
+		}
+		return "", RenderTo(dsh.hw, cc)
+	},
+	"vals": func(name string) string {
+		data, _ := json.Marshal(ut.SM{"action": "refresh", "widget": name})
+		return string(data)
+	},
+}
+
+/*
+Based on the values, it will write the html code of the [Dashboard] into the writer or return with an error message.
+*/
+func (dsh *Dashboard) RenderTo(w io.Writer) (err error) {
+	dsh.InitProps(dsh)
+	dsh.loadLayout()
+	cells := dsh.cells()
+
+	tpl := `<div id="{{ .Id }}" name="{{ .Name }}" class="dashboard {{ customClass $ }}"
+	 style="--dashboard-columns:{{ .Columns }};--dashboard-row-height:{{ .RowHeight }}px;{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"
+	>{{ if editable $ }}<div class="dashboard-toolbar"><label class="dashboard-add"><span>{{ msg $ "dashboard_add" }}</span>
+	{{ dashboardComponent $ "add" "" }}</label></div>{{ end }}
+	{{ if cells $ }}<div class="dashboard-grid">{{ range cells $ }}<section id="{{ .Id }}" class="dashboard-widget" data-widget="{{ .Name }}"
+	 style="--dashboard-width:{{ .Width }};--dashboard-height:{{ .Height }};"{{ if editable $ }} draggable="true"{{ end }}
+	><div class="dashboard-widget-header">{{ if .Widget.Icon }}{{ dashboardComponent $ "title" .Name }}{{ end }}
+	<span class="dashboard-widget-title">{{ .Widget.Title }}</span>
+	{{ if editable $ }}<span title="{{ msg $ "dashboard_remove" }}">{{ dashboardComponent $ "remove" .Name }}</span>{{ end }}</div>
+	<div class="dashboard-widget-content">{{ widgetComponent $ .Widget.Component }}</div>
+	{{ if editable $ }}<span class="dashboard-resize" title="{{ msg $ "dashboard_resize" }}"></span>{{ end }}
+	{{ if and (ne $.EventURL "") (gt .Widget.Refresh 0) }}<div id="{{ .Id }}_refresh" class="hide"
+	 hx-post="{{ $.EventURL }}" hx-trigger="every {{ .Widget.Refresh }}s" hx-vals="{{ vals .Name }}"
+	 hx-target="#{{ .Id }}" hx-swap="outerHTML" hx-select="#{{ .Id }}"></div>{{ end }}
+	</section>{{ end }}</div>{{ else }}<p class="dashboard-empty">{{ msg $ "dashboard_empty" }}</p>{{ end }}
+	{{ if editable $ }}<script>
+	(function() {
+		var dashboard = htmx.find('#{{ .Id }}');
+		var grid = dashboard.querySelector('.dashboard-grid');
+		if (!grid) { return; }
+		var drag = null;
+		var send = function(values) {
+			htmx.ajax('POST', {{ .EventURL }}, { source: dashboard, target: {{ .Target }}, swap: {{ .Swap }}, values: values });
+		};
+		var widgets = function() {
+			return Array.prototype.slice.call(grid.querySelectorAll('.dashboard-widget'));
+		};
+		grid.addEventListener('dragstart', function(evt) {
+			var widget = evt.target.closest && evt.target.closest('.dashboard-widget');
+			if (!widget) { return; }
+			drag = widget;
+			widget.classList.add('dragging');
+			evt.dataTransfer.effectAllowed = 'move';
+			evt.dataTransfer.setData('text/plain', widget.id);
+		});
+		grid.addEventListener('dragend', function() {
+			if (drag) { drag.classList.remove('dragging'); }
+			drag = null;
+		});
+		grid.addEventListener('dragover', function(evt) {
+			if (drag) { evt.preventDefault(); }
+		});
+		grid.addEventListener('drop', function(evt) {
+			if (!drag) { return; }
+			evt.preventDefault();
+			var target = evt.target.closest('.dashboard-widget');
+			var position = widgets().filter(function(widget) { return widget !== drag; }).indexOf(target);
+			send({ action: 'move', widget: drag.getAttribute('data-widget'), position: (position < 0) ? widgets().length : position });
+		});
+		grid.querySelectorAll('.dashboard-resize').forEach(function(handle) {
+			handle.addEventListener('pointerdown', function(evt) {
+				var widget = handle.closest('.dashboard-widget');
+				var style = getComputedStyle(grid);
+				var columns = style.gridTemplateColumns.split(' ').length;
+				var gap = parseFloat(style.columnGap) || 0;
+				var cellWidth = (grid.clientWidth - gap * (columns - 1)) / columns;
+				var cellHeight = parseFloat(style.gridAutoRows) || {{ .RowHeight }};
+				var rect = widget.getBoundingClientRect();
+				evt.preventDefault();
+				handle.setPointerCapture(evt.pointerId);
+				var move = function(e) {
+					widget.style.width = Math.max(e.clientX - rect.left, cellWidth / 2) + 'px';
+					widget.style.height = Math.max(e.clientY - rect.top, cellHeight / 2) + 'px';
+				};
+				var up = function(e) {
+					handle.removeEventListener('pointermove', move);
+					handle.removeEventListener('pointerup', up);
+					send({
+						action: 'resize', widget: widget.getAttribute('data-widget'),
+						width: Math.max(1, Math.round((e.clientX - rect.left + gap) / (cellWidth + gap))),
+						height: Math.max(1, Math.round((e.clientY - rect.top + gap) / (cellHeight + gap)))
+					});
+				};
+				handle.addEventListener('pointermove', move);
+				handle.addEventListener('pointerup', up);
+			});
+		});
+	})();
+	</script>{{ end }}
+	</div>`
+
+	hw := ut.NewHTMLWriter(w)
+	if err = ut.CachedTemplateWriter(hw, "dashboard", tpl, dashboardFuncMap, &dashboardRender{Dashboard: dsh, cells: cells, hw: hw}); err == nil {
+		err = hw.Close()
+	}
+	if err == nil && dsh.EventURL != "" {
+		dsh.SetProperty("request_map", dsh)
+		// the htmx trigger ids of the polling widgets
+		for _, cell := range cells {
+			if cell.Widget.Refresh > 0 {
+				dsh.RequestMap[cell.Id+"_refresh"] = dsh
+			}
+		}
+	}
+	return err
+}
+
+func testDashboardWidgets(id, eventURL string, requestValue map[string]ut.IM, requestMap map[string]ClientComponent) []DashboardWidget {
+	base := func(name string) BaseComponent {
+		return BaseComponent{
+			Id:           id + "_" + name,
+			EventURL:     eventURL,
+			RequestValue: requestValue,
+			RequestMap:   requestMap,
+		}
+	}
+	kpi := func(name, value string) *Label {
+		return &Label{
+			BaseComponent: BaseComponent{Id: id + "_" + name, Class: []string{"dashboard-kpi"}},
+			Value:         value, Static: true,
+		}
+	}
+	return []DashboardWidget{
+		{Name: "orders", Title: "Orders", Icon: IconShoppingCart, Refresh: 10,
+			Component: kpi("kpi_orders", "128")},
+		{Name: "revenue", Title: "Revenue", Icon: IconMoney,
+			Component: kpi("kpi_revenue", "$ 48 250")},
+		{Name: "customers", Title: "New customers", Icon: IconUser,
+			Component: kpi("kpi_customers", "36")},
+		{Name: "sales", Title: "Monthly sales", Icon: IconChartBar, Width: 2, Height: 2,
+			Component: &Chart{
+				BaseComponent: base("chart_sales"),
+				Type:          ChartTypeBar,
+				LabelField:    "month",
+				Series:        testChartSeries,
+				Rows:          testChartRows,
+			}},
+		{Name: "invoices", Title: "Latest invoices", Icon: IconFileText, Width: 2, Height: 2,
+			Component: &Table{
+				BaseComponent: base("table_invoices"),
+				Fields: []TableField{
+					{Name: "number", FieldType: TableFieldTypeString, Label: "Number"},
+					{Name: "customer", FieldType: TableFieldTypeString, Label: "Customer"},
+					{Name: "amount", FieldType: TableFieldTypeNumber, Label: "Amount"},
+				},
+				Rows: []ut.IM{
+					{"number": "INV/0012", "customer": "First Customer", "amount": 1250},
+					{"number": "INV/0013", "customer": "Second Customer", "amount": 320.5},
+					{"number": "INV/0014", "customer": "Third Customer", "amount": 4980},
+				},
+				Pagination: PaginationTypeNone,
+			}},
+	}
+}
+
+var testDashboardStore DashboardStore = &MemoryDashboardStore{}
+
+var testDashboardResponse func(evt ResponseEvent) (re ResponseEvent) = func(evt ResponseEvent) (re ResponseEvent) {
+	value := ut.ToIM(evt.Value, ut.IM{})
+	dsh, _ := evt.Trigger.(*Dashboard)
+	if evt.Name == DashboardEventRefresh && value["widget"] == "orders" && dsh != nil {
+		// a new order arrived
+		if index := dsh.widgetIndex("orders"); index > -1 {
+			kpi := dsh.Widgets[index].Component
+			kpi.SetProperty("value", fmt.Sprintf("%d", ut.ToInteger(kpi.GetProperty("value"), 0)+1))
+		}
+	}
+	return evt
+}
+
+// [Dashboard] test and demo data
+func TestDashboard(cc ClientComponent) []TestComponent {
+	id := ut.ToString(cc.GetProperty("id"), "")
+	eventURL := ut.ToString(cc.GetProperty("event_url"), "")
+	requestValue := cc.GetProperty("request_value").(map[string]ut.IM)
+	requestMap := cc.GetProperty("request_map").(map[string]ClientComponent)
+	return []TestComponent{
+		{
+			Label:         "Default layout and stored user layout",
+			ComponentType: ComponentTypeDashboard,
+			Component: &Dashboard{
+				BaseComponent: BaseComponent{
+					Id:           id + "_dashboard_default",
+					EventURL:     eventURL,
+					OnResponse:   testDashboardResponse,
+					RequestValue: requestValue,
+					RequestMap:   requestMap,
+				},
+				Widgets: testDashboardWidgets(id+"_dashboard_default", eventURL, requestValue, requestMap),
+				User:    "admin",
+				Store:   testDashboardStore,
+			}},
+		{
+			Label:         "Custom layout",
+			ComponentType: ComponentTypeDashboard,
+			Component: &Dashboard{
+				BaseComponent: BaseComponent{
+					Id:           id + "_dashboard_custom",
+					EventURL:     eventURL,
+					OnResponse:   testDashboardResponse,
+					RequestValue: requestValue,
+					RequestMap:   requestMap,
+				},
+				Widgets: testDashboardWidgets(id+"_dashboard_custom", eventURL, requestValue, requestMap),
+				Layout: []DashboardItem{
+					{Name: "sales", Width: 3, Height: 2},
+					{Name: "revenue", Width: 1, Height: 1},
+					{Name: "orders", Width: 1, Height: 1},
+				},
+				Columns:   3,
+				RowHeight: 120,
+			}},
+		{
+			Label:         "Read only",
+			ComponentType: ComponentTypeDashboard,
+			Component: &Dashboard{
+				BaseComponent: BaseComponent{
+					Id:           id + "_dashboard_readonly",
+					EventURL:     eventURL,
+					RequestValue: requestValue,
+					RequestMap:   requestMap,
+				},
+				Widgets:  testDashboardWidgets(id+"_dashboard_readonly", eventURL, requestValue, requestMap)[:3],
+				ReadOnly: true,
+			}},
+	}
+}
@@ -0,0 +1,591 @@
+package component
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	ut "github.com/nervatura/component/pkg/util"
+)
+
+type testFailDashboardStore struct{}
+
+func (fds *testFailDashboardStore) Load(user string) ([]DashboardItem, error) {
+	return nil, errors.New("load error")
+}
+
+func (fds *testFailDashboardStore) Save(user string, layout []DashboardItem) error {
+	return errors.New("save error")
+}
+
+func testDashboard(store DashboardStore) *Dashboard {
+	return &Dashboard{
+		BaseComponent: BaseComponent{
+			Id: "dsh", EventURL: "/event", RequestValue: map[string]ut.IM{}, RequestMap: map[string]ClientComponent{},
+		},
+		Widgets: []DashboardWidget{
+			{Name: "orders", Title: "Orders", Icon: IconShoppingCart, Refresh: 5,
+				Component: &Label{BaseComponent: BaseComponent{Id: "kpi_orders"}, Value: "1"}},
+			{Name: "sales", Title: "Sales", Width: 2, Height: 2,
+				Component: &Label{BaseComponent: BaseComponent{Id: "kpi_sales"}, Value: "2"}},
+			{Name: "empty", Title: "Empty", Width: 9},
+		},
+		User:  "admin",
+		Store: store,
+	}
+}
+
+func TestTestDashboard(t *testing.T) {
+	for _, tt := range TestDashboard(&BaseComponent{EventURL: "/demo"}) {
+		t.Run(tt.Label, func(t *testing.T) {
+			tt.Component.Render()
+		})
+	}
+	dsh := &Dashboard{Widgets: testDashboardWidgets("dsh", "", nil, nil)}
+	testDashboardResponse(ResponseEvent{Trigger: dsh, Name: DashboardEventRefresh, Value: ut.IM{"widget": "orders"}})
+	if value := dsh.Widgets[0].Component.GetProperty("value"); value != "129" {
+		t.Errorf("testDashboardResponse() = %v", value)
+	}
+	dsh.Widgets = dsh.Widgets[1:]
+	testDashboardResponse(ResponseEvent{Trigger: dsh, Name: DashboardEventRefresh, Value: ut.IM{"widget": "orders"}})
+}
+
+func TestDashboard_GetProperty(t *testing.T) {
+	tests := []struct {
+		name     string
+		propName string
+		want     interface{}
+	}{
+		{
+			name:     "columns",
+			propName: "columns",
+			want:     int64(3),
+		},
+		{
+			name:     "user",
+			propName: "user",
+			want:     "admin",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dsh := &Dashboard{Columns: 3, User: "admin"}
+			if got := dsh.GetProperty(tt.propName); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Dashboard.GetProperty() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDashboard_Validation(t *testing.T) {
+	type args struct {
+		propName  string
+		propValue interface{}
+	}
+	tests := []struct {
+		name   string
+		labels ut.SM
+		args   args
+		want   interface{}
+	}{
+		{
+			name: "base",
+			args: args{
+				propName:  "id",
+				propValue: "DASHBOARDID",
+			},
+			want: "DASHBOARDID",
+		},
+		{
+			name: "invalid",
+			args: args{
+				propName:  "invalid",
+				propValue: "",
+			},
+			want: "",
+		},
+		{
+			name: "widgets",
+			args: args{
+				propName:  "widgets",
+				propValue: []DashboardWidget{{Name: "orders"}},
+			},
+			want: []DashboardWidget{{Name: "orders"}},
+		},
+		{
+			name: "widgets_invalid",
+			args: args{
+				propName:  "widgets",
+				propValue: "widgets",
+			},
+			want: []DashboardWidget{},
+		},
+		{
+			name: "layout",
+			args: args{
+				propName:  "layout",
+				propValue: []DashboardItem{{Name: "orders", Width: 1, Height: 1}},
+			},
+			want: []DashboardItem{{Name: "orders", Width: 1, Height: 1}},
+		},
+		{
+			name: "layout_map",
+			args: args{
+				propName:  "layout",
+				propValue: []interface{}{ut.IM{"name": "orders", "width": 2, "height": 1}},
+			},
+			want: []DashboardItem{{Name: "orders", Width: 2, Height: 1}},
+		},
+		{
+			name: "layout_nil",
+			args: args{
+				propName:  "layout",
+				propValue: nil,
+			},
+			want: []DashboardItem(nil),
+		},
+		{
+			name: "layout_invalid",
+			args: args{
+				propName:  "layout",
+				propValue: "layout",
+			},
+			want: []DashboardItem(nil),
+		},
+		{
+			name: "columns",
+			args: args{
+				propName:  "columns",
+				propValue: 3,
+			},
+			want: int64(3),
+		},
+		{
+			name: "columns_default",
+			args: args{
+				propName:  "columns",
+				propValue: 0,
+			},
+			want: int64(DashboardDefaultColumns),
+		},
+		{
+			name: "row_height",
+			args: args{
+				propName:  "row_height",
+				propValue: 100,
+			},
+			want: int64(100),
+		},
+		{
+			name: "row_height_default",
+			args: args{
+				propName:  "row_height",
+				propValue: -1,
+			},
+			want: int64(DashboardDefaultRowHeight),
+		},
+		{
+			name: "store",
+			args: args{
+				propName:  "store",
+				propValue: &MemoryDashboardStore{},
+			},
+			want: &MemoryDashboardStore{},
+		},
+		{
+			name: "store_invalid",
+			args: args{
+				propName:  "store",
+				propValue: "store",
+			},
+			want: nil,
+		},
+		{
+			name: "labels_sm",
+			args: args{
+				propName:  "labels",
+				propValue: ut.SM{"dashboard_add": "Hozzáadás"},
+			},
+			want: ut.SM{"dashboard_add": "Hozzáadás"},
+		},
+		{
+			name:   "labels_im",
+			labels: ut.SM{"dashboard_remove": "Törlés"},
+			args: args{
+				propName:  "labels",
+				propValue: ut.IM{"dashboard_add": "Hozzáadás"},
+			},
+			want: ut.SM{"dashboard_remove": "Törlés", "dashboard_add": "Hozzáadás"},
+		},
+		{
+			name: "labels_default",
+			args: args{
+				propName:  "labels",
+				propValue: nil,
+			},
+			want: dashboardDefaultLabel,
+		},
+		{
+			name: "target",
+			args: args{
+				propName:  "target",
+				propValue: "target",
+			},
+			want: "#target",
+		},
+		{
+			name: "target_this",
+			args: args{
+				propName:  "target",
+				propValue: "this",
+			},
+			want: "this",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dsh := &Dashboard{Labels: tt.labels}
+			if got := dsh.Validation(tt.args.propName, tt.args.propValue); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Dashboard.Validation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDashboard_SetProperty(t *testing.T) {
+	type args struct {
+		propName  string
+		propValue interface{}
+	}
+	tests := []struct {
+		name string
+		args args
+		want interface{}
+	}{
+		{
+			name: "base",
+			args: args{
+				propName:  "id",
+				propValue: "DASHBOARDID",
+			},
+			want: "DASHBOARDID",
+		},
+		{
+			name: "missing",
+			args: args{
+				propName:  "missing",
+				propValue: "value",
+			},
+			want: "value",
+		},
+		{
+			name: "widgets",
+			args: args{
+				propName:  "widgets",
+				propValue: []DashboardWidget{{Name: "orders"}},
+			},
+			want: []DashboardWidget{{Name: "orders"}},
+		},
+		{
+			name: "layout",
+			args: args{
+				propName:  "layout",
+				propValue: []DashboardItem{{Name: "orders"}},
+			},
+			want: []DashboardItem{{Name: "orders"}},
+		},
+		{
+			name: "columns",
+			args: args{
+				propName:  "columns",
+				propValue: 2,
+			},
+			want: int64(2),
+		},
+		{
+			name: "row_height",
+			args: args{
+				propName:  "row_height",
+				propValue: 200,
+			},
+			want: int64(200),
+		},
+		{
+			name: "user",
+			args: args{
+				propName:  "user",
+				propValue: "admin",
+			},
+			want: "admin",
+		},
+		{
+			name: "store",
+			args: args{
+				propName:  "store",
+				propValue: &MemoryDashboardStore{},
+			},
+			want: &MemoryDashboardStore{},
+		},
+		{
+			name: "readonly",
+			args: args{
+				propName:  "readonly",
+				propValue: true,
+			},
+			want: true,
+		},
+		{
+			name: "labels",
+			args: args{
+				propName:  "labels",
+				propValue: ut.SM{"dashboard_add": "Hozzáadás"},
+			},
+			want: ut.SM{"dashboard_add": "Hozzáadás"},
+		},
+		{
+			name: "target",
+			args: args{
+				propName:  "target",
+				propValue: "#target",
+			},
+			want: "#target",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dsh := &Dashboard{}
+			if got := dsh.SetProperty(tt.args.propName, tt.args.propValue); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Dashboard.SetProperty() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDashboard_Items(t *testing.T) {
+	dsh := testDashboard(nil)
+	dsh.SetProperty("columns", 4)
+	want := []DashboardItem{{Name: "orders", Width: 1, Height: 1}, {Name: "sales", Width: 2, Height: 2},
+		{Name: "empty", Width: 4, Height: 1}}
+	if got := dsh.Items(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Dashboard.Items() = %v, want %v", got, want)
+	}
+	dsh.SetProperty("layout", []DashboardItem{{Name: "sales", Width: 0, Height: 3}, {Name: "missing"}, {Name: "sales"}})
+	want = []DashboardItem{{Name: "sales", Width: 1, Height: 3}}
+	if got := dsh.Items(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Dashboard.Items() = %v, want %v", got, want)
+	}
+	dsh.SetProperty("layout", []DashboardItem{})
+	if got := dsh.Items(); len(got) != 0 {
+		t.Errorf("Dashboard.Items() = %v, want empty", got)
+	}
+}
+
+func TestDashboard_OnRequest(t *testing.T) {
+	tests := []struct {
+		name       string
+		store      DashboardStore
+		values     url.Values
+		onResponse func(evt ResponseEvent) (re ResponseEvent)
+		evtName    string
+		value      ut.IM
+		layout     []string
+	}{
+		{
+			name:    "move",
+			store:   &MemoryDashboardStore{},
+			values:  url.Values{"action": {"move"}, "widget": {"empty"}, "position": {"0"}},
+			evtName: DashboardEventMove,
+			value:   ut.IM{"widget": "empty", "position": int64(0)},
+			layout:  []string{"empty", "orders", "sales"},
+		},
+		{
+			name:    "move end",
+			values:  url.Values{"action": {"move"}, "widget": {"orders"}, "position": {"9"}},
+			evtName: DashboardEventMove,
+			value:   ut.IM{"widget": "orders", "position": int64(2)},
+			layout:  []string{"sales", "empty", "orders"},
+		},
+		{
+			name:    "move unknown",
+			values:  url.Values{"action": {"move"}, "widget": {"missing"}, "position": {"1"}},
+			evtName: DashboardEventMove,
+			value:   ut.IM{"widget": "missing", "position": int64(1)},
+			layout:  []string{"orders", "sales", "empty"},
+		},
+		{
+			name:    "resize",
+			store:   &testFailDashboardStore{},
+			values:  url.Values{"action": {"resize"}, "widget": {"orders"}, "width": {"6"}, "height": {"2"}},
+			evtName: DashboardEventResize,
+			value:   ut.IM{"widget": "orders", "width": int64(4), "height": int64(2), "error": "save error"},
+			layout:  []string{"orders", "sales", "empty"},
+		},
+		{
+			name:    "resize unknown",
+			values:  url.Values{"action": {"resize"}, "widget": {"missing"}},
+			evtName: DashboardEventResize,
+			value:   ut.IM{"widget": "missing", "width": int64(1), "height": int64(1)},
+			layout:  []string{"orders", "sales", "empty"},
+		},
+		{
+			name:    "refresh",
+			values:  url.Values{"action": {"refresh"}, "widget": {"orders"}},
+			evtName: DashboardEventRefresh,
+			value:   ut.IM{"widget": "orders"},
+			layout:  []string{"orders", "sales", "empty"},
+		},
+		{
+			name:   "response",
+			values: url.Values{"action": {"refresh"}, "widget": {"orders"}},
+			onResponse: func(evt ResponseEvent) (re ResponseEvent) {
+				evt.Name = "response"
+				return evt
+			},
+			evtName: "response",
+			value:   ut.IM{"widget": "orders"},
+			layout:  []string{"orders", "sales", "empty"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dsh := testDashboard(tt.store)
+			dsh.SetProperty("columns", 4)
+			dsh.OnResponse = tt.onResponse
+			evt := dsh.OnRequest(TriggerEvent{Values: tt.values})
+			value := ut.ToIM(evt.Value, ut.IM{})
+			layout := []string{}
+			for _, item := range value["layout"].([]DashboardItem) {
+				layout = append(layout, item.Name)
+			}
+			delete(value, "layout")
+			if evt.Name != tt.evtName || !reflect.DeepEqual(value, tt.value) || !reflect.DeepEqual(layout, tt.layout) {
+				t.Errorf("Dashboard.OnRequest() = %v, %v, %v", evt.Name, value, layout)
+			}
+			if store, valid := tt.store.(*MemoryDashboardStore); valid {
+				if stored, _ := store.Load("admin"); !reflect.DeepEqual(stored, dsh.Layout) {
+					t.Errorf("Dashboard.OnRequest() stored = %v, want %v", stored, dsh.Layout)
+				}
+			}
+		})
+	}
+}
+
+func TestDashboard_AddRemove(t *testing.T) {
+	store := &MemoryDashboardStore{}
+	store.Save("admin", []DashboardItem{{Name: "sales", Width: 2, Height: 1}})
+	dsh := testDashboard(store)
+	if _, err := dsh.Render(); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(dsh.Layout, []DashboardItem{{Name: "sales", Width: 2, Height: 1}}) {
+		t.Errorf("Dashboard.Render() layout = %v", dsh.Layout)
+	}
+
+	evt := dsh.RequestMap["dsh_add"].OnRequest(TriggerEvent{Name: "add", Values: url.Values{"add": {"orders"}}})
+	want := []DashboardItem{{Name: "sales", Width: 2, Height: 1}, {Name: "orders", Width: 1, Height: 1}}
+	if evt.Name != DashboardEventAdd || evt.Trigger != dsh || !reflect.DeepEqual(dsh.Layout, want) {
+		t.Errorf("Dashboard add = %v, %v", evt.Name, dsh.Layout)
+	}
+	if _, found := dsh.RequestValue["dsh_add"]; found {
+		t.Error("Dashboard add request value is not cleared")
+	}
+	dsh.AddWidget("orders")
+	dsh.AddWidget("missing")
+	if !reflect.DeepEqual(dsh.Layout, want) {
+		t.Errorf("Dashboard.AddWidget() = %v", dsh.Layout)
+	}
+
+	if _, err := dsh.Render(); err != nil {
+		t.Fatal(err)
+	}
+	evt = dsh.RequestMap["dsh_sales_remove"].OnRequest(TriggerEvent{})
+	want = []DashboardItem{{Name: "orders", Width: 1, Height: 1}}
+	if evt.Name != DashboardEventRemove || !reflect.DeepEqual(dsh.Layout, want) {
+		t.Errorf("Dashboard remove = %v, %v", evt.Name, dsh.Layout)
+	}
+	if stored, _ := store.Load("admin"); !reflect.DeepEqual(stored, want) {
+		t.Errorf("Dashboard remove stored = %v", stored)
+	}
+}
+
+func TestDashboard_PublishWidget(t *testing.T) {
+	hub := &SSEHub{}
+	dsh := testDashboard(nil)
+	if err := dsh.PublishWidget(hub, "session", "orders"); err != nil {
+		t.Error(err)
+	}
+	for _, name := range []string{"empty", "missing"} {
+		if err := dsh.PublishWidget(hub, "session", name); err != errDashboardWidget {
+			t.Errorf("Dashboard.PublishWidget() error = %v", err)
+		}
+	}
+}
+
+func TestDashboard_Render(t *testing.T) {
+	tests := []struct {
+		name    string
+		dsh     *Dashboard
+		want    []string
+		notWant []string
+		ids     []string
+	}{
+		{
+			name: "default",
+			dsh:  testDashboard(&testFailDashboardStore{}),
+			want: []string{`style="--dashboard-columns:4;--dashboard-row-height:160px;"`, `id="dsh_add"`,
+				` disabled aria-label="Add widget"`, `id="dsh_orders" class="dashboard-widget"`,
+				`style="--dashboard-width:4;--dashboard-height:1;"`, `id="dsh_sales_remove"`, `hx-trigger="every 5s"`,
+				`hx-target="#dsh_orders" hx-swap="outerHTML" hx-select="#dsh_orders"`, `class="dashboard-resize"`, `<script>`},
+			ids: []string{"dsh", "dsh_add", "dsh_orders_remove", "dsh_orders_refresh"},
+		},
+		{
+			name: "readonly",
+			dsh: &Dashboard{
+				BaseComponent: BaseComponent{Id: "dsh", Style: ut.SM{"padding": "8px"}},
+				Widgets:       []DashboardWidget{{Name: "orders", Title: "Orders", Refresh: 5}},
+				Columns:       2,
+				RowHeight:     100,
+				ReadOnly:      true,
+			},
+			want:    []string{`--dashboard-row-height:100px;padding:8px;`, `<span class="dashboard-widget-title">Orders</span>`},
+			notWant: []string{`dashboard-toolbar`, `draggable`, `hx-trigger`, `<script>`, `<svg`},
+		},
+		{
+			name: "empty",
+			dsh: &Dashboard{
+				BaseComponent: BaseComponent{Id: "dsh", EventURL: "/event"},
+				Widgets:       []DashboardWidget{{Name: "orders", Title: "Orders"}},
+				Layout:        []DashboardItem{},
+				Labels:        ut.SM{"dashboard_add": "Hozzáadás"},
+			},
+			want: []string{`<p class="dashboard-empty">There are no widgets on the dashboard</p>`, `Hozzáadás`,
+				`<option  key="0" value="orders" >Orders</option>`},
+			notWant: []string{`disabled`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := tt.dsh.Render()
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(string(res), want) {
+					t.Errorf("Dashboard.Render() missing %s", want)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(string(res), notWant) {
+					t.Errorf("Dashboard.Render() unexpected %s", notWant)
+				}
+			}
+			for _, id := range tt.ids {
+				if _, found := tt.dsh.RequestMap[id]; !found {
+					t.Errorf("Dashboard.Render() missing request map %s", id)
+				}
+			}
+		})
+	}
+}
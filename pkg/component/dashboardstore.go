@@ -0,0 +1,110 @@
+package component
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+)
+
+// The position and size of a visible widget of the [Dashboard] layout
+type DashboardItem struct {
+	// The name of the [DashboardWidget]
+	Name string `json:"name"`
+	// The number of the spanned grid columns
+	Width int64 `json:"width"`
+	// The number of the spanned grid rows
+	Height int64 `json:"height"`
+}
+
+// The storage of the user layouts of the [Dashboard]. See [LocalDashboardStore] and [MemoryDashboardStore]
+type DashboardStore interface {
+	// Returns the stored layout of the user. The layout is nil if the user has no stored layout.
+	Load(user string) ([]DashboardItem, error)
+	// Stores the layout of the user
+	Save(user string, layout []DashboardItem) error
+}
+
+var errDashboardUser = errors.New("missing dashboard user")
+
+// Stores the user layouts in JSON files of a local directory
+type LocalDashboardStore struct {
+	// The directory of the layout files. It will be created if it does not exist.
+	Dir string `json:"dir"`
+}
+
+func (lds *LocalDashboardStore) path(user string) string {
+	return filepath.Join(lds.Dir, url.PathEscape(user)+".json")
+}
+
+/*
+Reads the layout of the user from the JSON file of the user
+*/
+func (lds *LocalDashboardStore) Load(user string) (layout []DashboardItem, err error) {
+	if user == "" {
+		return nil, errDashboardUser
+	}
+	var data []byte
+	if data, err = os.ReadFile(lds.path(user)); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	err = json.Unmarshal(data, &layout)
+	return layout, err
+}
+
+/*
+Writes the layout of the user into the JSON file of the user
+*/
+func (lds *LocalDashboardStore) Save(user string, layout []DashboardItem) (err error) {
+	if user == "" {
+		return errDashboardUser
+	}
+	if err = os.MkdirAll(lds.Dir, 0o755); err != nil {
+		return err
+	}
+	data, _ := json.Marshal(layout)
+	return os.WriteFile(lds.path(user), data, 0o644)
+}
+
+// Stores the user layouts in memory
+type MemoryDashboardStore struct {
+	layouts map[string][]DashboardItem
+	mu      sync.RWMutex
+}
+
+/*
+Returns a copy of the stored layout of the user
+*/
+func (mds *MemoryDashboardStore) Load(user string) ([]DashboardItem, error) {
+	if user == "" {
+		return nil, errDashboardUser
+	}
+	mds.mu.RLock()
+	defer mds.mu.RUnlock()
+	return slices.Clone(mds.layouts[user]), nil
+}
+
+/*
+Stores a copy of the layout of the user
+*/
+func (mds *MemoryDashboardStore) Save(user string, layout []DashboardItem) error {
+	if user == "" {
+		return errDashboardUser
+	}
+	mds.mu.Lock()
+	defer mds.mu.Unlock()
+	if mds.layouts == nil {
+		mds.layouts = make(map[string][]DashboardItem)
+	}
+	mds.layouts[user] = slices.Clone(layout)
+	if mds.layouts[user] == nil {
+		mds.layouts[user] = []DashboardItem{}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package component
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLocalDashboardStore(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "layouts")
+	lds := &LocalDashboardStore{Dir: dir}
+	layout := []DashboardItem{{Name: "orders", Width: 1, Height: 1}, {Name: "sales", Width: 2, Height: 2}}
+	if stored, err := lds.Load("admin"); err != nil || stored != nil {
+		t.Errorf("LocalDashboardStore.Load() = %v, %v", stored, err)
+	}
+	if err := lds.Save("../admin", layout); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "..%2Fadmin.json")); err != nil {
+		t.Error(err)
+	}
+	if stored, err := lds.Load("../admin"); err != nil || !reflect.DeepEqual(stored, layout) {
+		t.Errorf("LocalDashboardStore.Load() = %v, %v", stored, err)
+	}
+	if err := lds.Save("empty", []DashboardItem{}); err != nil {
+		t.Fatal(err)
+	}
+	if stored, err := lds.Load("empty"); err != nil || stored == nil || len(stored) != 0 {
+		t.Errorf("LocalDashboardStore.Load() = %v, %v", stored, err)
+	}
+
+	if _, err := lds.Load(""); err != errDashboardUser {
+		t.Errorf("LocalDashboardStore.Load() error = %v", err)
+	}
+	if err := lds.Save("", layout); err != errDashboardUser {
+		t.Errorf("LocalDashboardStore.Save() error = %v", err)
+	}
+	os.WriteFile(filepath.Join(dir, "invalid.json"), []byte("{"), 0o644)
+	if _, err := lds.Load("invalid"); err == nil {
+		t.Error("LocalDashboardStore.Load() missing error")
+	}
+	os.Mkdir(filepath.Join(dir, "dir.json"), 0o755)
+	if _, err := lds.Load("dir"); err == nil {
+		t.Error("LocalDashboardStore.Load() missing error")
+	}
+
+	// the directory cannot be created
+	file := filepath.Join(t.TempDir(), "file")
+	os.WriteFile(file, []byte("file"), 0o644)
+	if err := (&LocalDashboardStore{Dir: file}).Save("admin", layout); err == nil {
+		t.Error("LocalDashboardStore.Save() missing error")
+	}
+}
+
+func TestMemoryDashboardStore(t *testing.T) {
+	mds := &MemoryDashboardStore{}
+	layout := []DashboardItem{{Name: "orders", Width: 1, Height: 1}}
+	if stored, err := mds.Load("admin"); err != nil || stored != nil {
+		t.Errorf("MemoryDashboardStore.Load() = %v, %v", stored, err)
+	}
+	if err := mds.Save("admin", layout); err != nil {
+		t.Fatal(err)
+	}
+	layout[0].Width = 2
+	if stored, _ := mds.Load("admin"); stored[0].Width != 1 {
+		t.Errorf("MemoryDashboardStore.Load() = %v", stored)
+	}
+	mds.Save("empty", nil)
+	if stored, _ := mds.Load("empty"); stored == nil {
+		t.Error("MemoryDashboardStore.Load() = nil")
+	}
+	if _, err := mds.Load(""); err != errDashboardUser {
+		t.Errorf("MemoryDashboardStore.Load() error = %v", err)
+	}
+	if err := mds.Save("", layout); err != errDashboardUser {
+		t.Errorf("MemoryDashboardStore.Save() error = %v", err)
+	}
+}
@@ -2,6 +2,7 @@ package component
 
 import (
 	"html/template"
+	"io"
 	"strings"
 	"time"
 
@@ -22,6 +23,9 @@ const (
 // [DateTime] Type values
 var DateTimeType []string = []string{DateTimeTypeDate, DateTimeTypeTime, DateTimeTypeDateTime}
 
+// The current time of the default date and time values. The snapshot tests replace it with a fixed time.
+var timeNow func() time.Time = time.Now
+
 /*
 Creates an HTML date, datetime or time input control
 
@@ -90,11 +94,11 @@ func (dti *DateTime) defaultValue(dtype string) (value string) {
 	}
 	switch dtype {
 	case DateTimeTypeTime:
-		value = time.Now().Format("15:04")
+		value = timeNow().Format("15:04")
 	case DateTimeTypeDate:
-		value = time.Now().Format("2006-01-02")
+		value = timeNow().Format("2006-01-02")
 	default:
-		value = time.Now().Format("2006-01-02T15:04")
+		value = timeNow().Format("2006-01-02T15:04")
 	}
 	return value
 }
@@ -212,16 +216,25 @@ func (dti *DateTime) OnRequest(te TriggerEvent) (re ResponseEvent) {
 Based on the values, it will generate the html code of the [DateTime] or return with an error message.
 */
 func (dti *DateTime) Render() (html template.HTML, err error) {
+	return RenderHTML(dti)
+}
+
+// [DateTime] template functions
+var dateTimeFuncMap = map[string]any{
+	"styleMap": func(dti *DateTime) bool {
+		return len(dti.Style) > 0
+	},
+	"customClass": func(dti *DateTime) string {
+		return strings.Join(dti.Class, " ")
+	},
+}
+
+/*
+Based on the values, it will write the html code of the [DateTime] into the writer or return with an error message.
+*/
+func (dti *DateTime) RenderTo(w io.Writer) (err error) {
 	dti.InitProps(dti)
 
-	funcMap := map[string]any{
-		"styleMap": func() bool {
-			return len(dti.Style) > 0
-		},
-		"customClass": func() string {
-			return strings.Join(dti.Class, " ")
-		},
-	}
 	tpl := `<input id="{{ .Id }}" name="{{ .Name }}" type="{{ .Type }}" value="{{ .Value }}"
 	max={{ if eq .Type "date" }}"9999-12-31"{{ else }}"9999-12-31 23:59"{{ end }}
 	{{ if ne .EventURL "" }} hx-post="{{ .EventURL }}" hx-trigger="blur, keyup[keyCode==13]" hx-target="{{ .Target }}" {{ if ne .Sync "none" }} hx-sync="{{ .Sync }}"{{ end }} hx-swap="{{ .Swap }}"{{ end }}
@@ -231,14 +244,14 @@ func (dti *DateTime) Render() (html template.HTML, err error) {
 	{{ if .Disabled }} disabled{{ end }}
 	{{ if .AutoFocus }} autofocus{{ end }}
 	{{ if ne .Label "" }} aria-label="{{ .Label }}"{{ end }}
-	 class="{{ customClass }}{{ if .Full }} full{{ end }}"
-	{{ if styleMap }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}
+	 class="{{ customClass $ }}{{ if .Full }} full{{ end }}"
+	{{ if styleMap $ }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}
 	></input>`
 
-	if html, err = ut.TemplateBuilder("datetime", tpl, funcMap, dti); err == nil && dti.EventURL != "" {
+	if err = ut.CachedTemplateWriter(w, "datetime", tpl, dateTimeFuncMap, dti); err == nil && dti.EventURL != "" {
 		dti.SetProperty("request_map", dti)
 	}
-	return html, nil
+	return nil
 }
 
 // [DateTime] test and demo data
@@ -0,0 +1,342 @@
+package component
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+
+	ut "github.com/nervatura/component/pkg/util"
+)
+
+// [ParseDefinition] constants
+const (
+	// The key of the response handler name of a component definition. See more [RegisterHandler].
+	DefinitionHandlerKey = "on_response"
+
+	// An always invalid property value for the enum checking of the [ClientComponent] Validation functions
+	definitionInvalidValue = "\x00"
+)
+
+// The registered response handlers of the component definitions
+var definitionHandlers = map[string]func(evt ResponseEvent) (re ResponseEvent){}
+
+/*
+The RegisterHandler function adds a response handler function to the registry of the component definitions.
+The on_response key of a definition sets the handler by its name as the OnResponse function of the component.
+*/
+func RegisterHandler(name string, handler func(evt ResponseEvent) (re ResponseEvent)) {
+	definitionHandlers[name] = handler
+}
+
+// The DefinitionError is returned by the [ParseDefinition] function for an invalid component definition value.
+type DefinitionError struct {
+	// The path of the invalid value. Example: $.body_rows[0].columns[1].value.type
+	Path    string
+	Message string
+}
+
+func (err *DefinitionError) Error() string {
+	return err.Path + ": " + err.Message
+}
+
+/*
+The ParseDefinition function creates a component tree from a JSON or YAML definition. The definition values
+are the json properties of the components, and the component_type key selects the registered type of the
+component and the values of the interface fields (for example MainComponent, LabelComponent or the
+SideBar Items). See more [RegisterComponent] and [RegisterType] functions. The on_response key binds a
+registered response handler (see more [RegisterHandler]) to the component.
+
+For example:
+
+	component_type: form
+	id: customer
+	title: Customer
+	on_response: customerResponse
+	body_rows:
+	  - columns:
+	      - label: Name
+	        value:
+	          type: text
+	          value: { name: name }
+
+The unknown properties, component types and handlers, the values with invalid type and the invalid enum
+values are returned as a [DefinitionError] with the path of the value.
+*/
+func ParseDefinition(data []byte) (cc ClientComponent, err error) {
+	var values any
+	if trimmed := bytes.TrimSpace(data); bytes.HasPrefix(trimmed, []byte("{")) {
+		err = json.Unmarshal(trimmed, &values)
+	} else {
+		values, err = ut.ParseYAML(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+	dec := &definitionDecoder{}
+	var value reflect.Value
+	if value, err = dec.decodeAny("$", values); err != nil {
+		return nil, err
+	}
+	if cc, valid := value.Interface().(ClientComponent); valid {
+		dec.share(cc)
+		return cc, nil
+	}
+	return nil, &DefinitionError{Path: "$", Message: "component definition expected"}
+}
+
+// The LoadDefinition function creates a component tree from the JSON or YAML definition file of the file system.
+func LoadDefinition(fsys fs.FS, name string) (cc ClientComponent, err error) {
+	var data []byte
+	if data, err = fs.ReadFile(fsys, name); err == nil {
+		if cc, err = ParseDefinition(data); err != nil {
+			err = fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return cc, err
+}
+
+type definitionDecoder struct {
+	// The BaseComponent values of the component tree
+	bases []*BaseComponent
+}
+
+func (dec *definitionDecoder) errorf(path, format string, args ...any) error {
+	return &DefinitionError{Path: path, Message: fmt.Sprintf(format, args...)}
+}
+
+// The nested components share the RequestValue and the RequestMap of the root component
+func (dec *definitionDecoder) share(cc ClientComponent) {
+	if root := jsonBaseComponent(reflect.ValueOf(cc)); root != nil {
+		if root.RequestValue == nil {
+			root.RequestValue = map[string]ut.IM{}
+		}
+		if root.RequestMap == nil {
+			root.RequestMap = map[string]ClientComponent{}
+		}
+		for _, base := range dec.bases {
+			if base.RequestValue == nil {
+				base.RequestValue = root.RequestValue
+			}
+			base.RequestMap = root.RequestMap
+		}
+	}
+}
+
+// Creates a registered type value from a definition object with a component_type key
+func (dec *definitionDecoder) component(path string, values map[string]any) (value reflect.Value, err error) {
+	name, valid := values[JSONTypeKey].(string)
+	if !valid {
+		return value, dec.errorf(path, "missing %s", JSONTypeKey)
+	}
+	rt, found := typeMap[name]
+	if !found {
+		return value, dec.errorf(path+"."+JSONTypeKey, "unknown component type: %s", name)
+	}
+	properties := maps.Clone(values)
+	delete(properties, JSONTypeKey)
+	value = reflect.New(rt).Elem()
+	return value, dec.decode(path, properties, value)
+}
+
+// Decodes an untyped value. The objects with a component_type key are created as components.
+func (dec *definitionDecoder) decodeAny(path string, raw any) (value reflect.Value, err error) {
+	switch raw := raw.(type) {
+	case map[string]any:
+		if _, found := raw[JSONTypeKey]; found {
+			return dec.component(path, raw)
+		}
+		items := map[string]any{}
+		for _, key := range slices.Sorted(maps.Keys(raw)) {
+			var item reflect.Value
+			if item, err = dec.decodeAny(path+"."+key, raw[key]); err != nil {
+				return value, err
+			}
+			items[key] = item.Interface()
+		}
+		return reflect.ValueOf(items), nil
+
+	case []any:
+		items := make([]any, len(raw))
+		for index, item := range raw {
+			var iv reflect.Value
+			if iv, err = dec.decodeAny(path+"["+strconv.Itoa(index)+"]", item); err != nil {
+				return value, err
+			}
+			items[index] = iv.Interface()
+		}
+		return reflect.ValueOf(items), nil
+	}
+	return reflect.ValueOf(&raw).Elem(), nil
+}
+
+func (dec *definitionDecoder) decode(path string, raw any, value reflect.Value) (err error) {
+	rt := value.Type()
+	if raw == nil {
+		value.SetZero()
+		return nil
+	}
+	if tu, valid := value.Addr().Interface().(encoding.TextUnmarshaler); valid {
+		if text, valid := raw.(string); valid {
+			if err = tu.UnmarshalText([]byte(text)); err != nil {
+				return dec.errorf(path, "invalid %s value: %s", rt, text)
+			}
+			return nil
+		}
+		return dec.errorf(path, "invalid %s value", rt)
+	}
+	switch value.Kind() {
+	case reflect.Interface:
+		var result reflect.Value
+		if result, err = dec.decodeAny(path, raw); err != nil || result.IsZero() {
+			return err
+		}
+		if !result.Type().AssignableTo(rt) {
+			return dec.errorf(path, "invalid %s value", rt)
+		}
+		value.Set(result)
+		return nil
+
+	case reflect.Pointer:
+		if value.IsNil() {
+			value.Set(reflect.New(rt.Elem()))
+		}
+		return dec.decode(path, raw, value.Elem())
+
+	case reflect.Struct:
+		if values, valid := raw.(map[string]any); valid {
+			return dec.decodeStruct(path, values, value)
+		}
+
+	case reflect.Map:
+		if values, valid := raw.(map[string]any); valid && rt.Key().Kind() == reflect.String {
+			items := reflect.MakeMapWithSize(rt, len(values))
+			for _, key := range slices.Sorted(maps.Keys(values)) {
+				item := reflect.New(rt.Elem()).Elem()
+				if err = dec.decode(path+"."+key, values[key], item); err != nil {
+					return err
+				}
+				items.SetMapIndex(reflect.ValueOf(key).Convert(rt.Key()), item)
+			}
+			value.Set(items)
+			return nil
+		}
+
+	case reflect.Slice:
+		if list, valid := raw.([]any); valid {
+			value.Set(reflect.MakeSlice(rt, len(list), len(list)))
+			for index, item := range list {
+				if err = dec.decode(path+"["+strconv.Itoa(index)+"]", item, value.Index(index)); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+	default:
+		return dec.decodeScalar(path, raw, value)
+	}
+	return dec.errorf(path, "invalid %s value", rt)
+}
+
+func (dec *definitionDecoder) decodeScalar(path string, raw any, value reflect.Value) error {
+	switch raw := raw.(type) {
+	case string:
+		if value.Kind() == reflect.String {
+			value.SetString(raw)
+			return nil
+		}
+	case bool:
+		if value.Kind() == reflect.Bool {
+			value.SetBool(raw)
+			return nil
+		}
+	case float64:
+		switch value.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if raw == float64(int64(raw)) && !value.OverflowInt(int64(raw)) {
+				value.SetInt(int64(raw))
+				return nil
+			}
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if raw >= 0 && raw == float64(uint64(raw)) && !value.OverflowUint(uint64(raw)) {
+				value.SetUint(uint64(raw))
+				return nil
+			}
+		case reflect.Float32, reflect.Float64:
+			value.SetFloat(raw)
+			return nil
+		}
+	}
+	return dec.errorf(path, "invalid %s value: %v", value.Type(), raw)
+}
+
+func (dec *definitionDecoder) decodeStruct(path string, values map[string]any, value reflect.Value) (err error) {
+	fields := map[string][]int{}
+	definitionFields(value.Type(), nil, fields)
+	base := jsonBaseComponent(value.Addr())
+	if base != nil {
+		dec.bases = append(dec.bases, base)
+	}
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		keyPath := path + "." + key
+		if key == DefinitionHandlerKey && base != nil {
+			name := ut.ToString(values[key], "")
+			handler, found := definitionHandlers[name]
+			if !found {
+				return dec.errorf(keyPath, "unknown response handler: %s", name)
+			}
+			base.OnResponse = handler
+			continue
+		}
+		index, found := fields[key]
+		if !found {
+			return dec.errorf(keyPath, "unknown property")
+		}
+		if err = dec.decode(keyPath, values[key], value.FieldByIndex(index)); err != nil {
+			return err
+		}
+	}
+	if cc, valid := value.Addr().Interface().(ClientComponent); valid && base != nil {
+		return dec.validate(path, values, cc, base)
+	}
+	return nil
+}
+
+// Checks the enum values of the component. The Validation function returns the default value for an invalid value.
+func (dec *definitionDecoder) validate(path string, values map[string]any, cc ClientComponent, base *BaseComponent) (err error) {
+	// the Validation functions do not change the RequestValue of the component
+	base.init = true
+	defer func() { base.init = false }()
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		if text, valid := values[key].(string); valid && key != DefinitionHandlerKey {
+			if result, valid := cc.Validation(key, text).(string); valid && result != text &&
+				result == cc.Validation(key, definitionInvalidValue) {
+				return dec.errorf(path+"."+key, "invalid value: %s", text)
+			}
+		}
+	}
+	return nil
+}
+
+// Returns the field indexes of the json property names. The fields of the outer struct hide the fields of the embedded structs.
+func definitionFields(rt reflect.Type, index []int, fields map[string][]int) {
+	embedded := []jsonField{}
+	for _, field := range jsonFields(rt) {
+		if field.embedded {
+			embedded = append(embedded, field)
+			continue
+		}
+		if _, found := fields[field.name]; !found {
+			fields[field.name] = append(slices.Clone(index), field.index)
+		}
+	}
+	for _, field := range embedded {
+		definitionFields(rt.Field(field.index).Type, append(slices.Clone(index), field.index), fields)
+	}
+}
@@ -0,0 +1,173 @@
+package component
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"testing/fstest"
+
+	ut "github.com/nervatura/component/pkg/util"
+)
+
+func testDefinitionResponse(evt ResponseEvent) (re ResponseEvent) {
+	return ResponseEvent{Trigger: &Toast{Value: "customer"}, Name: evt.Name}
+}
+
+func TestParseDefinition(t *testing.T) {
+	RegisterHandler("customer", testDefinitionResponse)
+	t.Cleanup(func() {
+		delete(definitionHandlers, "customer")
+	})
+	yamlForm := `
+# customer form
+component_type: form
+id: customer
+title: Customer
+icon: User
+on_response: customer
+data: { label: { component_type: label, value: Label }, list: [1, { component_type: icon, value: Check }] }
+body_rows:
+  - columns:
+      - label: Name
+        value:
+          type: text
+          value: { name: name }
+      - label: Group
+        value:
+          type: select
+          value: { name: group, options: [{ value: a, text: A }] }
+footer_rows:
+  - columns:
+      - value: { type: button, value: { name: ok, label: OK, button_style: primary } }
+`
+	jsonClient := `{
+		"component_type": "application", "id": "app", "title": "App",
+		"main_component": {
+			"component_type": "sidebar", "id": "sidebar",
+			"items": [
+				{ "component_type": "sidebar_element", "name": "customer", "value": "customer", "label": "Customer" },
+				{ "component_type": "sidebar_separator" }
+			]
+		},
+		"head_link": [{ "rel": "stylesheet", "href": "/style.css" }]
+	}`
+	yamlClient := `
+component_type: client
+id: client
+ticket: { database: demo, expiry: 2024-01-01T00:00:00Z }
+style: { color: red }
+request_value: { client: { theme: dark } }
+`
+
+	frm, err := ParseDefinition([]byte(yamlForm))
+	if err != nil {
+		t.Fatal(err)
+	}
+	form := frm.(*Form)
+	if form.Title != "Customer" || form.BodyRows[0].Columns[1].Value.Type != FieldTypeSelect ||
+		form.FooterRows[0].Columns[0].Value.Value["label"] != "OK" || form.RequestMap == nil {
+		t.Errorf("ParseDefinition() = %v", form)
+	}
+	if _, valid := form.Data["label"].(*Label); !valid {
+		t.Errorf("ParseDefinition() data = %v", form.Data)
+	}
+	if evt := form.OnResponse(ResponseEvent{Name: FormEventOK}); evt.Trigger.(*Toast).Value != "customer" {
+		t.Errorf("ParseDefinition() on_response = %v", evt)
+	}
+	if _, err = form.Render(); err != nil {
+		t.Errorf("ParseDefinition() Render error = %v", err)
+	}
+
+	app, err := ParseDefinition([]byte(jsonClient))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sb := app.(*Application).MainComponent.(*SideBar)
+	if item, valid := sb.Items[0].(*SideBarElement); !valid || item.Label != "Customer" {
+		t.Errorf("ParseDefinition() items = %v", sb.Items)
+	}
+	if sb.RequestMap == nil || ut.ToString(app.(*Application).HeadLink[0].Href, "") != "/style.css" {
+		t.Errorf("ParseDefinition() = %v", app)
+	}
+	if _, err = app.Render(); err != nil {
+		t.Errorf("ParseDefinition() Render error = %v", err)
+	}
+
+	client, err := LoadDefinition(fstest.MapFS{"client.yaml": {Data: []byte(yamlClient)}}, "client.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cli := client.(*Client); cli.Ticket.Expiry.Year() != 2024 || cli.Style["color"] != "red" ||
+		cli.RequestValue["client"]["theme"] != "dark" {
+		t.Errorf("LoadDefinition() = %v", cli)
+	}
+}
+
+func TestParseDefinition_error(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		path string
+	}{
+		{name: "json", data: `{"component_type": }`},
+		{name: "yaml", data: "key: [1"},
+		{name: "component", data: "title: Form", path: "$"},
+		{name: "missing", data: "component_type: 1", path: "$"},
+		{name: "unknown", data: "component_type: unknown", path: "$.component_type"},
+		{name: "property", data: "component_type: form\ntitle_text: Form", path: "$.title_text"},
+		{name: "handler", data: "component_type: form\non_response: unknown", path: "$.on_response"},
+		{name: "enum", data: "component_type: form\nicon: Unknown", path: "$.icon"},
+		{name: "field_enum", data: "component_type: form\nbody_rows:\n- columns:\n  - value: { type: string }",
+			path: "$.body_rows[0].columns[0].value.type"},
+		{name: "bool", data: "component_type: form\nmodal: 1", path: "$.modal"},
+		{name: "string", data: "component_type: form\ntitle: 1", path: "$.title"},
+		{name: "int", data: "component_type: table\ncurrent_page: 1.5", path: "$.current_page"},
+		{name: "struct", data: "component_type: form\nbody_rows: [1]", path: "$.body_rows[0]"},
+		{name: "slice", data: "component_type: form\nbody_rows: {}", path: "$.body_rows"},
+		{name: "map", data: "component_type: form\nstyle: []", path: "$.style"},
+		{name: "map_value", data: "component_type: form\nstyle: { color: 1 }", path: "$.style.color"},
+		{name: "interface", data: "component_type: button\nlabel_component: Label", path: "$.label_component"},
+		{name: "interface_type", data: "component_type: button\nlabel_component: { component_type: sidebar_separator }",
+			path: "$.label_component"},
+		{name: "interface_value", data: "component_type: button\nlabel_component: { component_type: label, value: [] }",
+			path: "$.label_component.value"},
+		{name: "any_map", data: "component_type: form\ndata: { key: { value: { component_type: unknown } } }",
+			path: "$.data.key.value.component_type"},
+		{name: "any_list", data: "component_type: form\ndata: { key: [{ component_type: unknown }] }",
+			path: "$.data.key[0].component_type"},
+		{name: "text", data: "component_type: client\nticket: { expiry: today }", path: "$.ticket.expiry"},
+		{name: "text_type", data: "component_type: client\nticket: { expiry: 1 }", path: "$.ticket.expiry"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseDefinition([]byte(tt.data))
+			var defErr *DefinitionError
+			if err == nil || (tt.path != "" && (!errors.As(err, &defErr) || defErr.Path != tt.path)) {
+				t.Errorf("ParseDefinition() error = %v, want path %s", err, tt.path)
+			}
+		})
+	}
+	if _, err := LoadDefinition(fstest.MapFS{"form.yaml": {Data: []byte("title: Form")}}, "form.yaml"); err == nil {
+		t.Error("LoadDefinition() error = nil")
+	}
+}
+
+func TestDefinitionDecoder_decodeScalar(t *testing.T) {
+	dec := &definitionDecoder{}
+	var values struct {
+		Bool  bool
+		Int   int
+		Uint  uint8
+		Float float32
+	}
+	if err := dec.decode("$", map[string]any{"Bool": true, "Int": float64(-1), "Uint": float64(8), "Float": 1.5},
+		reflect.ValueOf(&values).Elem()); err != nil || !values.Bool || values.Int != -1 || values.Uint != 8 || values.Float != 1.5 {
+		t.Errorf("definitionDecoder.decode() = %v, %v", values, err)
+	}
+	if err := dec.decode("$", map[string]any{"Uint": float64(-1)}, reflect.ValueOf(&values).Elem()); err == nil {
+		t.Error("definitionDecoder.decode() error = nil")
+	}
+	if err := dec.decode("$", map[string]any{"Uint": nil}, reflect.ValueOf(&values).Elem()); err != nil || values.Uint != 0 {
+		t.Errorf("definitionDecoder.decode() = %v, %v", values, err)
+	}
+}
@@ -2,6 +2,7 @@ package component
 
 import (
 	"html/template"
+	"io"
 	"slices"
 	"strings"
 
@@ -16,6 +17,14 @@ const (
 	EditorEventField = "editor_field"
 )
 
+// The field and table events that change the data of the [Editor] or [Form] and set its Dirty state
+var EditorChangeEvents []string = []string{
+	InputEventChange, NumberEventChange, DateTimeEventChange, SelectEventChange, ToggleEventChange,
+	SelectorEventSelected, SelectorEventDelete, ListEventDelete, UploadEventUpload,
+	TableEventFormUpdate, TableEventFormDelete, CalendarEventMove, CalendarEventResize,
+	KanbanEventMove, AutocompleteEventChange, AutocompleteEventSelected, RichTextEventChange,
+}
+
 type EditorView struct {
 	Key string `json:"key"`
 	// The label of the view
@@ -40,6 +49,8 @@ type Editor struct {
 	Rows []Row `json:"rows"`
 	// The contents of the view table
 	Tables []Table `json:"tables"`
+	// The editor data has been changed but has not been saved yet
+	Dirty bool `json:"dirty"`
 }
 
 /*
@@ -55,6 +66,7 @@ func (edi *Editor) Properties() ut.IM {
 			"views":  edi.Views,
 			"rows":   edi.Rows,
 			"tables": edi.Tables,
+			"dirty":  edi.Dirty,
 		})
 }
 
@@ -139,6 +151,10 @@ func (edi *Editor) SetProperty(propName string, propValue interface{}) interface
 			edi.Tables = edi.Validation(propName, propValue).([]Table)
 			return edi.Tables
 		},
+		"dirty": func() interface{} {
+			edi.Dirty = ut.ToBoolean(propValue, false)
+			return edi.Dirty
+		},
 		"target": func() interface{} {
 			edi.Target = edi.Validation(propName, propValue).(string)
 			return edi.Target
@@ -158,6 +174,9 @@ func (edi *Editor) response(evt ResponseEvent) (re ResponseEvent) {
 		Trigger: edi, TriggerName: edi.Name, Value: evt.Value,
 		Header: ut.SM{HeaderRetarget: "#" + edi.Id},
 	}
+	if slices.Contains(EditorChangeEvents, evt.Name) {
+		edi.SetProperty("dirty", true)
+	}
 	switch evt.TriggerName {
 	case "tab_btn":
 		data := evt.Trigger.GetProperty("data").(ut.IM)
@@ -187,7 +206,7 @@ func (edi *Editor) response(evt ResponseEvent) (re ResponseEvent) {
 	return admEvt
 }
 
-func (edi *Editor) getComponent(name string, view EditorView, index int) (html template.HTML, err error) {
+func (edi *Editor) component(name string, view EditorView, index int) ClientComponent {
 	ccMap := map[string]func() ClientComponent{
 		"title": func() ClientComponent {
 			return &Label{
@@ -243,47 +262,64 @@ func (edi *Editor) getComponent(name string, view EditorView, index int) (html t
 			return tbl
 		},
 	}
-	cc := ccMap[name]()
-	html, err = cc.Render()
-	return html, err
+	return ccMap[name]()
 }
 
 /*
 Based on the values, it will generate the html code of the [Editor] or return with an error message.
 */
 func (edi *Editor) Render() (html template.HTML, err error) {
+	return RenderHTML(edi)
+}
+
+// the render data of the [Editor] template
+type editorRender struct {
+	*Editor
+	hw *ut.HTMLWriter
+}
+
+// [Editor] template functions
+var editorFuncMap = map[string]any{
+	"styleMap": func(edi *editorRender) bool {
+		return len(edi.Style) > 0
+	},
+	"customClass": func(edi *editorRender) string {
+		return strings.Join(edi.Class, " ")
+	},
+	"editorComponent": func(edi *editorRender, name string) (template.HTML, error) {
+		return "", RenderTo(edi.hw, edi.component(name, EditorView{}, 0))
+	},
+	"viewComponent": func(edi *editorRender, name string, view EditorView, index int) (template.HTML, error) {
+		return "", RenderTo(edi.hw, edi.component(name, view, index))
+	},
+}
+
+/*
+Based on the values, it will write the html code of the [Editor] into the writer or return with an error message.
+*/
+func (edi *Editor) RenderTo(w io.Writer) (err error) {
 	edi.InitProps(edi)
+	hw := ut.NewHTMLWriter(w)
 
-	funcMap := map[string]any{
-		"styleMap": func() bool {
-			return len(edi.Style) > 0
-		},
-		"customClass": func() string {
-			return strings.Join(edi.Class, " ")
-		},
-		"editorComponent": func(name string) (template.HTML, error) {
-			return edi.getComponent(name, EditorView{}, 0)
-		},
-		"viewComponent": func(name string, view EditorView, index int) (template.HTML, error) {
-			return edi.getComponent(name, view, index)
-		},
-	}
 	tpl := `<div id="{{ .Id }}"
-	class="{{ customClass }}"
-	{{ if styleMap }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}>
+	class="{{ customClass $ }}"
+	{{ if styleMap $ }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}>
 	<div class="editor">
-	<div class="editor-title"><div class="cell">{{ editorComponent "title" }}</div></div>
+	<div class="editor-title{{ if .Dirty }} editor-dirty{{ end }}"><div class="cell">{{ editorComponent $ "title" }}</div></div>
 	<div class="section-container" >
 	{{ range $index, $view := .Views }}
-	{{ viewComponent "tab_btn" $view 0 }}
+	{{ viewComponent $ "tab_btn" $view 0 }}
 	{{ if eq $view.Key $.View }}<div class="row-panel" >
-	{{ range $row_index, $row := $.Rows }}{{ viewComponent "view_row" $view $row_index }}{{ end }}
-	{{ range $tbl_index, $tbl := $.Tables }}{{ viewComponent "view_table" $view $tbl_index }}{{ end }}
+	{{ range $row_index, $row := $.Rows }}{{ viewComponent $ "view_row" $view $row_index }}{{ end }}
+	{{ range $tbl_index, $tbl := $.Tables }}{{ viewComponent $ "view_table" $view $tbl_index }}{{ end }}
 	</div>{{ end }}
 	{{ end }}
 	</div></div></div>`
 
-	return ut.TemplateBuilder("editor", tpl, funcMap, edi)
+	if err = ut.CachedTemplateWriter(hw, "editor", tpl, editorFuncMap, &editorRender{Editor: edi, hw: hw}); err == nil {
+		err = hw.Close()
+	}
+	return err
 }
 
 var testEditorResponse func(evt ResponseEvent) (re ResponseEvent) = func(evt ResponseEvent) (re ResponseEvent) {
@@ -396,7 +432,18 @@ func testEditorRows(key string) []Row {
 						Type: FieldTypeText,
 						Value: ut.IM{
 							"name":  "text",
-							"value": `Long text&#13;&#10;Next row...`,
+							"value": "Long text\nNext row...",
+						},
+					}},
+					{Label: "Description", Value: Field{
+						Type: FieldTypeRichText,
+						Value: ut.IM{
+							"name":     "description",
+							"value":    "The **best** product of the *year*",
+							"markdown": true,
+							"toolbar": []string{
+								RichTextToolBold, RichTextToolItalic, RichTextToolUnorderedList, RichTextToolLink,
+							},
 						},
 					}},
 				},
@@ -2,6 +2,7 @@ package component
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 
 	ut "github.com/nervatura/component/pkg/util"
@@ -16,6 +17,47 @@ func TestTestEditor(t *testing.T) {
 	testEditorResponse(ResponseEvent{Trigger: &Editor{}})
 }
 
+func TestEditor_changeEvents(t *testing.T) {
+	for _, name := range []string{AutocompleteEventChange, AutocompleteEventSelected, RichTextEventChange} {
+		t.Run(name, func(t *testing.T) {
+			edi := &Editor{}
+			edi.response(ResponseEvent{TriggerName: "view_row", Name: name, Trigger: &BaseComponent{}})
+			if !edi.Dirty {
+				t.Errorf("Editor.response() %s Dirty = false", name)
+			}
+			frm := &Form{}
+			frm.triggerEvent(ResponseEvent{TriggerName: "field", Name: name, Trigger: &BaseComponent{}})
+			if !frm.Dirty {
+				t.Errorf("Form.triggerEvent() %s Dirty = false", name)
+			}
+		})
+	}
+}
+
+func TestEditor_dirty(t *testing.T) {
+	cli := &Client{BaseComponent: BaseComponent{Id: "client", EventURL: "/event", Data: ut.IM{}}, UnsavedGuard: true}
+	cli.SetEditor("customer", "main", ut.IM{})
+	edi := cli.component("editor").(*Editor)
+
+	edi.response(ResponseEvent{TriggerName: "view_row", Name: AutocompleteEventSearch, Trigger: &BaseComponent{}})
+	if edi.Dirty || cli.EditorDirty() {
+		t.Error("Editor.response() Dirty = true")
+	}
+	edi.response(ResponseEvent{TriggerName: "view_row", Name: InputEventChange, Trigger: &BaseComponent{}})
+	if !edi.Dirty || !cli.EditorDirty() {
+		t.Error("Editor.response() Dirty = false")
+	}
+	if html, _ := edi.Render(); !strings.Contains(string(html), "editor-dirty") {
+		t.Errorf("Editor.Render() = %v", html)
+	}
+	// the view change of the dirty editor is guarded
+	evt := edi.response(ResponseEvent{
+		TriggerName: "tab_btn", Trigger: &BaseComponent{Data: ut.IM{"key": "maps"}}})
+	if evt.Name != ClientEventGuard || cli.Data["guard"] == nil {
+		t.Errorf("Editor.response() guard = %v", evt.Name)
+	}
+}
+
 func TestEditor_GetProperty(t *testing.T) {
 	type fields struct {
 		BaseComponent BaseComponent
@@ -2,6 +2,9 @@ package component
 
 import (
 	"html/template"
+	"io"
+	"maps"
+	"slices"
 
 	ut "github.com/nervatura/component/pkg/util"
 )
@@ -10,24 +13,28 @@ import (
 const (
 	ComponentTypeField = "field"
 
-	FieldTypeButton   = "button"
-	FieldTypeUrlLink  = "url"
-	FieldTypeString   = InputTypeString
-	FieldTypeText     = InputTypeText
-	FieldTypeColor    = InputTypeColor
-	FieldTypePassword = InputTypePassword
-	FieldTypeInteger  = "integer"
-	FieldTypeNumber   = "float"
-	FieldTypeDate     = DateTimeTypeDate
-	FieldTypeTime     = DateTimeTypeTime
-	FieldTypeDateTime = DateTimeTypeDateTime
-	FieldTypeBool     = "bool"
-	FieldTypeSelect   = "select"
-	FieldTypeLink     = "link"
-	FieldTypeUpload   = "upload"
-	FieldTypeSelector = "selector"
-	FieldTypeList     = "list"
-	FieldTypeLabel    = "label"
+	FieldTypeButton       = "button"
+	FieldTypeUrlLink      = "url"
+	FieldTypeString       = InputTypeString
+	FieldTypeText         = InputTypeText
+	FieldTypeColor        = InputTypeColor
+	FieldTypePassword     = InputTypePassword
+	FieldTypeInteger      = "integer"
+	FieldTypeNumber       = "float"
+	FieldTypeDate         = DateTimeTypeDate
+	FieldTypeTime         = DateTimeTypeTime
+	FieldTypeDateTime     = DateTimeTypeDateTime
+	FieldTypeBool         = "bool"
+	FieldTypeSelect       = "select"
+	FieldTypeLink         = "link"
+	FieldTypeUpload       = "upload"
+	FieldTypeSelector     = "selector"
+	FieldTypeList         = "list"
+	FieldTypeLabel        = "label"
+	FieldTypeAutocomplete = "autocomplete"
+	FieldTypeCalendar     = "calendar"
+	FieldTypeKanban       = "kanban"
+	FieldTypeRichText     = "richtext"
 )
 
 // [Field] Type values
@@ -35,7 +42,8 @@ var FieldType []string = []string{
 	FieldTypeButton, FieldTypeUrlLink, FieldTypeString, FieldTypeText, FieldTypeColor, FieldTypePassword,
 	FieldTypeInteger, FieldTypeNumber, FieldTypeDate, FieldTypeTime, FieldTypeDateTime,
 	FieldTypeBool, FieldTypeSelect, FieldTypeLink, FieldTypeUpload, FieldTypeSelector,
-	FieldTypeList, FieldTypeLabel,
+	FieldTypeList, FieldTypeLabel, FieldTypeAutocomplete, FieldTypeCalendar, FieldTypeKanban,
+	FieldTypeRichText,
 }
 
 // Multi-type input component
@@ -122,7 +130,7 @@ func (fld *Field) SetProperty(propName string, propValue interface{}) interface{
 	return propValue
 }
 
-func (fld *Field) getComponent() (html template.HTML, err error) {
+func (fld *Field) getComponent() ClientComponent {
 	ccBase := func() BaseComponent {
 		if fld.EventURL != "" {
 			return BaseComponent{
@@ -161,8 +169,8 @@ func (fld *Field) getComponent() (html template.HTML, err error) {
 		return dti
 	}
 	setProperty := func(cc ClientComponent) {
-		for propName, propValue := range fld.Value {
-			cc.SetProperty(propName, propValue)
+		for _, propName := range slices.Sorted(maps.Keys(fld.Value)) {
+			cc.SetProperty(propName, fld.Value[propName])
 		}
 	}
 	ccMap := map[string]func() ClientComponent{
@@ -197,6 +205,14 @@ func (fld *Field) getComponent() (html template.HTML, err error) {
 			setProperty(inp)
 			return inp
 		},
+		FieldTypeAutocomplete: func() ClientComponent {
+			inp := &Autocomplete{
+				BaseComponent: ccBase(),
+				Full:          true,
+			}
+			setProperty(inp)
+			return inp
+		},
 		FieldTypeList: func() ClientComponent {
 			inp := &List{
 				BaseComponent: ccBase(),
@@ -204,6 +220,28 @@ func (fld *Field) getComponent() (html template.HTML, err error) {
 			setProperty(inp)
 			return inp
 		},
+		FieldTypeCalendar: func() ClientComponent {
+			cal := &Calendar{
+				BaseComponent: ccBase(),
+			}
+			setProperty(cal)
+			return cal
+		},
+		FieldTypeKanban: func() ClientComponent {
+			kan := &Kanban{
+				BaseComponent: ccBase(),
+			}
+			setProperty(kan)
+			return kan
+		},
+		FieldTypeRichText: func() ClientComponent {
+			rte := &RichText{
+				BaseComponent: ccBase(),
+				Full:          true,
+			}
+			setProperty(rte)
+			return rte
+		},
 		FieldTypeColor: func() ClientComponent {
 			inp := ccInp()
 			setProperty(inp)
@@ -307,17 +345,22 @@ func (fld *Field) getComponent() (html template.HTML, err error) {
 			return lbl
 		},
 	}
-	cc := ccMap[fld.Type]()
-	html, err = cc.Render()
-	return html, err
+	return ccMap[fld.Type]()
 }
 
 /*
 Based on the values, it will generate the html code of the [Field] or return with an error message.
 */
 func (fld *Field) Render() (html template.HTML, err error) {
+	return RenderHTML(fld)
+}
+
+/*
+Based on the values, it will write the html code of the [Field] into the writer or return with an error message.
+*/
+func (fld *Field) RenderTo(w io.Writer) (err error) {
 	fld.InitProps(fld)
-	return fld.getComponent()
+	return RenderTo(w, fld.getComponent())
 }
 
 var testFieldResponse func(evt ResponseEvent) (re ResponseEvent) = func(evt ResponseEvent) (re ResponseEvent) {
@@ -343,6 +386,12 @@ var testFieldResponse func(evt ResponseEvent) (re ResponseEvent) = func(evt Resp
 		evt.Trigger.SetProperty("show_badge", true)
 	case "link":
 		return toast(ut.ToString(evt.Value, ""))
+	case "calendar":
+		return testCalendarResponse(evt)
+	case "kanban":
+		return testKanbanResponse(evt)
+	case "richtext":
+		return testRichTextResponse(evt)
 	case "list":
 		row := evt.Value.(ut.IM)["row"].(ut.IM)
 		return toast(ut.ToString(row["lsvalue"], ""))
@@ -404,7 +453,7 @@ func TestField(cc ClientComponent) []TestComponent {
 				Type: FieldTypeText,
 				Value: ut.IM{
 					"name":  "text",
-					"value": `Long text&#13;&#10;Next row...`,
+					"value": "Long text\nNext row...",
 				},
 			}},
 		{
@@ -667,6 +716,24 @@ func TestField(cc ClientComponent) []TestComponent {
 					"is_null": true,
 				},
 			}},
+		{
+			Label:         "Autocomplete",
+			ComponentType: ComponentTypeField,
+			Component: &Field{
+				BaseComponent: BaseComponent{
+					Id:           id + "_autocomplete",
+					EventURL:     eventURL,
+					OnResponse:   testFieldResponse,
+					RequestValue: requestValue,
+					RequestMap:   requestMap,
+				},
+				Type: FieldTypeAutocomplete,
+				Value: ut.IM{
+					"name":        "autocomplete",
+					"lookup":      "test_product",
+					"placeholder": "Product code",
+				},
+			}},
 		{
 			Label:         "List",
 			ComponentType: ComponentTypeField,
@@ -697,6 +764,68 @@ func TestField(cc ClientComponent) []TestComponent {
 					"edit_icon":   "Plus",
 				},
 			}},
+		{
+			Label:         "Calendar",
+			ComponentType: ComponentTypeField,
+			Component: &Field{
+				BaseComponent: BaseComponent{
+					Id:           id + "_calendar",
+					EventURL:     eventURL,
+					OnResponse:   testFieldResponse,
+					RequestValue: requestValue,
+					RequestMap:   requestMap,
+				},
+				Type: FieldTypeCalendar,
+				Value: ut.IM{
+					"name":        "calendar",
+					"view":        CalendarViewAgenda,
+					"value":       "2024-05-13",
+					"agenda_days": 7,
+					"events":      testCalendarEvents(),
+				},
+			}},
+		{
+			Label:         "Kanban",
+			ComponentType: ComponentTypeField,
+			Component: &Field{
+				BaseComponent: BaseComponent{
+					Id:           id + "_kanban",
+					EventURL:     eventURL,
+					OnResponse:   testFieldResponse,
+					RequestValue: requestValue,
+					RequestMap:   requestMap,
+				},
+				Type: FieldTypeKanban,
+				Value: ut.IM{
+					"name": "kanban",
+					"columns": []interface{}{
+						ut.IM{"value": "todo", "label": "To do"},
+						ut.IM{"value": "doing", "label": "In progress", "limit": 2},
+						ut.IM{"value": "done", "label": "Done"},
+					},
+					"badges": []interface{}{ut.IM{"field": "due_date", "icon": IconCalendar}},
+					"rows":   testKanbanTasks(),
+				},
+			}},
+		{
+			Label:         "Rich text",
+			ComponentType: ComponentTypeField,
+			Component: &Field{
+				BaseComponent: BaseComponent{
+					Id:           id + "_richtext",
+					EventURL:     eventURL,
+					OnResponse:   testFieldResponse,
+					RequestValue: requestValue,
+					RequestMap:   requestMap,
+				},
+				Type: FieldTypeRichText,
+				Value: ut.IM{
+					"name":     "richtext",
+					"value":    "Rich text with **Markdown** storage",
+					"markdown": true,
+					"toolbar":  []string{RichTextToolBold, RichTextToolItalic, RichTextToolLink},
+				},
+			}},
 		{
 			Label:         "Label",
 			ComponentType: ComponentTypeField,
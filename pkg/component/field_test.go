@@ -23,6 +23,7 @@ func TestTestField(t *testing.T) {
 		Trigger: &Selector{}, TriggerName: "selector", Name: SelectorEventSelected,
 		Value: ut.IM{"row": ut.IM{}}})
 	testFieldResponse(ResponseEvent{Trigger: &List{}, TriggerName: "list", Value: ut.IM{"row": ut.IM{}}})
+	testFieldResponse(ResponseEvent{Trigger: &Kanban{}, TriggerName: "kanban", Name: KanbanEventMove})
 }
 
 func TestField_GetProperty(t *testing.T) {
@@ -0,0 +1,183 @@
+package component
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	ut "github.com/nervatura/component/pkg/util"
+)
+
+// An uploaded file of the [TriggerEvent]
+type UploadFile struct {
+	// The original file name
+	Name string `json:"name"`
+	// The file size in bytes
+	Size int64 `json:"size"`
+	// The MIME type of the file
+	Type string `json:"type"`
+	// The content of the file. It is only readable during the processing of the request.
+	Reader io.ReadCloser `json:"-"`
+}
+
+// A stored file reference of the [FileStore]
+type StoredFile struct {
+	// The unique identifier of the stored file
+	Id string `json:"id"`
+	// The original file name
+	Name string `json:"name"`
+	// The stored file size in bytes
+	Size int64 `json:"size"`
+	// The MIME type of the file
+	Type string `json:"type"`
+	// The location of the stored file. Empty for in-memory files.
+	Path string `json:"path"`
+}
+
+// The storage of the uploaded files. See [LocalFileStore] and [MemoryFileStore]
+type FileStore interface {
+	// Stores the content of the file and returns the stored file reference
+	Save(file UploadFile) (StoredFile, error)
+	// Returns the content of the stored file
+	Open(id string) (io.ReadCloser, error)
+	// Removes the stored file
+	Delete(id string) error
+}
+
+var errFileNotFound = errors.New("file not found")
+
+/*
+Opens the uploaded files of the multipart form input with the specified name. The MIME type of the file
+is the Content-Type of the file part, or it is based on the file extension if it is missing.
+The caller must close the files, see [CloseFiles].
+*/
+func FormFiles(form *multipart.Form, name string) (files []UploadFile, err error) {
+	for _, header := range form.File[name] {
+		var file multipart.File
+		if file, err = header.Open(); err != nil {
+			CloseFiles(files)
+			return nil, err
+		}
+		fileType := header.Header.Get("Content-Type")
+		if extType := mime.TypeByExtension(filepath.Ext(header.Filename)); extType != "" &&
+			(fileType == "" || fileType == "application/octet-stream") {
+			fileType = extType
+		}
+		fileType, _, _ = strings.Cut(fileType, ";")
+		files = append(files, UploadFile{
+			Name: header.Filename, Size: header.Size, Type: strings.TrimSpace(fileType), Reader: file,
+		})
+	}
+	return files, nil
+}
+
+/*
+Closes the readers of the uploaded files
+*/
+func CloseFiles(files []UploadFile) {
+	for _, file := range files {
+		file.Reader.Close()
+	}
+}
+
+// Returns a new unique file id with the extension of the file name
+func storeFileId(name string) string {
+	return ut.RandString(24) + strings.ToLower(filepath.Ext(filepath.Base(name)))
+}
+
+// Stores the uploaded files in a local directory
+type LocalFileStore struct {
+	// The directory of the stored files. It will be created if it does not exist.
+	Dir string `json:"dir"`
+}
+
+func (lfs *LocalFileStore) path(id string) string {
+	return filepath.Join(lfs.Dir, filepath.Base(id))
+}
+
+/*
+Saves the file into the directory with a unique name and returns the stored file reference
+*/
+func (lfs *LocalFileStore) Save(file UploadFile) (ref StoredFile, err error) {
+	if err = os.MkdirAll(lfs.Dir, 0o755); err != nil {
+		return ref, err
+	}
+	ref = StoredFile{Id: storeFileId(file.Name), Name: file.Name, Type: file.Type}
+	ref.Path = lfs.path(ref.Id)
+	var dst *os.File
+	if dst, err = os.Create(ref.Path); err != nil {
+		return ref, err
+	}
+	defer dst.Close()
+	ref.Size, err = io.Copy(dst, file.Reader)
+	return ref, err
+}
+
+/*
+Opens the stored file
+*/
+func (lfs *LocalFileStore) Open(id string) (io.ReadCloser, error) {
+	return os.Open(lfs.path(id))
+}
+
+/*
+Removes the stored file from the directory
+*/
+func (lfs *LocalFileStore) Delete(id string) error {
+	return os.Remove(lfs.path(id))
+}
+
+// Stores the uploaded files in memory
+type MemoryFileStore struct {
+	files map[string][]byte
+	mu    sync.RWMutex
+}
+
+/*
+Reads the content of the file into the memory and returns the stored file reference
+*/
+func (mfs *MemoryFileStore) Save(file UploadFile) (ref StoredFile, err error) {
+	var data []byte
+	if data, err = io.ReadAll(file.Reader); err != nil {
+		return ref, err
+	}
+	ref = StoredFile{Id: storeFileId(file.Name), Name: file.Name, Size: int64(len(data)), Type: file.Type}
+	mfs.mu.Lock()
+	defer mfs.mu.Unlock()
+	if mfs.files == nil {
+		mfs.files = make(map[string][]byte)
+	}
+	mfs.files[ref.Id] = data
+	return ref, nil
+}
+
+/*
+Returns a reader of the stored file content
+*/
+func (mfs *MemoryFileStore) Open(id string) (io.ReadCloser, error) {
+	mfs.mu.RLock()
+	defer mfs.mu.RUnlock()
+	if data, found := mfs.files[id]; found {
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+	return nil, errFileNotFound
+}
+
+/*
+Removes the stored file from the memory
+*/
+func (mfs *MemoryFileStore) Delete(id string) error {
+	mfs.mu.Lock()
+	defer mfs.mu.Unlock()
+	if _, found := mfs.files[id]; !found {
+		return errFileNotFound
+	}
+	delete(mfs.files, id)
+	return nil
+}
@@ -0,0 +1,143 @@
+package component
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func testMultipartForm(t *testing.T, maxMemory int64) *multipart.Form {
+	body := new(bytes.Buffer)
+	mw := multipart.NewWriter(body)
+	for _, file := range []struct{ name, fileType, content string }{
+		{"image.png", "image/png", "png"},
+		{"data.json", "", "a;b"},
+		{"notes", "application/octet-stream", "notes"},
+		{"report.pdf", "application/octet-stream", "pdf"},
+	} {
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Disposition", `form-data; name="file"; filename="`+file.name+`"`)
+		if file.fileType != "" {
+			header.Set("Content-Type", file.fileType)
+		}
+		part, _ := mw.CreatePart(header)
+		part.Write([]byte(file.content))
+	}
+	mw.Close()
+	form, err := multipart.NewReader(body, mw.Boundary()).ReadForm(maxMemory)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return form
+}
+
+func TestFormFiles(t *testing.T) {
+	form := testMultipartForm(t, 1024)
+	files, err := FormFiles(form, "file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer CloseFiles(files)
+	types := []string{}
+	for _, file := range files {
+		types = append(types, file.Type)
+	}
+	if want := []string{"image/png", "application/json", "application/octet-stream", "application/pdf"}; !reflect.DeepEqual(types, want) {
+		t.Errorf("FormFiles() = %v, want %v", types, want)
+	}
+	if content, _ := io.ReadAll(files[1].Reader); string(content) != "a;b" || files[1].Size != 3 {
+		t.Errorf("FormFiles() content = %s", content)
+	}
+
+	// the temporary files of the form are removed
+	form = testMultipartForm(t, 0)
+	form.RemoveAll()
+	if _, err := FormFiles(form, "file"); err == nil {
+		t.Error("FormFiles() missing error")
+	}
+}
+
+func TestLocalFileStore(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "files")
+	lfs := &LocalFileStore{Dir: dir}
+	ref, err := lfs.Save(testUploadFile("Image.PNG", "image/png", "png"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(ref.Id, ".png") || ref.Path != filepath.Join(dir, ref.Id) || ref.Size != 3 || ref.Name != "Image.PNG" {
+		t.Errorf("LocalFileStore.Save() = %v", ref)
+	}
+	reader, err := lfs.Open("../" + ref.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, _ := io.ReadAll(reader)
+	reader.Close()
+	if string(content) != "png" {
+		t.Errorf("LocalFileStore.Open() = %s", content)
+	}
+	if err := lfs.Delete(ref.Id); err != nil {
+		t.Error(err)
+	}
+	if _, err := lfs.Open(ref.Id); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("LocalFileStore.Open() error = %v", err)
+	}
+
+	blocked := filepath.Join(t.TempDir(), "blocked")
+	os.WriteFile(blocked, []byte("file"), 0o600)
+	tests := []struct {
+		name  string
+		store *LocalFileStore
+		file  UploadFile
+	}{
+		{name: "mkdir", store: &LocalFileStore{Dir: filepath.Join(blocked, "files")},
+			file: testUploadFile("image.png", "image/png", "png")},
+		{name: "create", store: lfs, file: testUploadFile("image."+strings.Repeat("x", 300), "", "png")},
+		{name: "copy", store: lfs, file: UploadFile{Name: "image.png", Reader: io.NopCloser(iotest.ErrReader(errors.New("read")))}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.store.Save(tt.file); err == nil {
+				t.Error("LocalFileStore.Save() missing error")
+			}
+		})
+	}
+}
+
+func TestMemoryFileStore(t *testing.T) {
+	mfs := &MemoryFileStore{}
+	ref, err := mfs.Save(testUploadFile("data.csv", "text/csv", "a;b"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(ref.Id, ".csv") || ref.Size != 3 || ref.Path != "" || ref.Type != "text/csv" {
+		t.Errorf("MemoryFileStore.Save() = %v", ref)
+	}
+	reader, err := mfs.Open(ref.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if content, _ := io.ReadAll(reader); string(content) != "a;b" {
+		t.Errorf("MemoryFileStore.Open() = %s", content)
+	}
+	if err := mfs.Delete(ref.Id); err != nil {
+		t.Error(err)
+	}
+	if _, err := mfs.Open(ref.Id); err != errFileNotFound {
+		t.Errorf("MemoryFileStore.Open() error = %v", err)
+	}
+	if err := mfs.Delete(ref.Id); err != errFileNotFound {
+		t.Errorf("MemoryFileStore.Delete() error = %v", err)
+	}
+	if _, err := mfs.Save(UploadFile{Reader: io.NopCloser(iotest.ErrReader(errors.New("read")))}); err == nil {
+		t.Error("MemoryFileStore.Save() missing error")
+	}
+}
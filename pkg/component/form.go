@@ -2,6 +2,7 @@ package component
 
 import (
 	"html/template"
+	"io"
 	"slices"
 	"strings"
 
@@ -30,6 +31,8 @@ type Form struct {
 	FooterRows []Row `json:"footer_rows"`
 	// The modal mode
 	Modal bool `json:"modal"`
+	// The form data has been changed but has not been saved yet
+	Dirty bool `json:"dirty"`
 }
 
 /*
@@ -44,6 +47,7 @@ func (frm *Form) Properties() ut.IM {
 			"body_rows":   frm.BodyRows,
 			"footer_rows": frm.FooterRows,
 			"modal":       frm.Modal,
+			"dirty":       frm.Dirty,
 		})
 }
 
@@ -118,6 +122,10 @@ func (frm *Form) SetProperty(propName string, propValue interface{}) interface{}
 			frm.Modal = frm.Validation(propName, propValue).(bool)
 			return frm.Modal
 		},
+		"dirty": func() interface{} {
+			frm.Dirty = ut.ToBoolean(propValue, false)
+			return frm.Dirty
+		},
 		"target": func() interface{} {
 			frm.Target = frm.Validation(propName, propValue).(string)
 			return frm.Target
@@ -169,6 +177,9 @@ func (frm *Form) triggerEvent(evt ResponseEvent) (re ResponseEvent) {
 			"form": frm, "data": frm.Data,
 		},
 	}
+	if slices.Contains(EditorChangeEvents, evt.Name) {
+		frm.SetProperty("dirty", true)
+	}
 	if evt.TriggerName == "btn_close" {
 		evt.Trigger = frm
 		frmEvt.Name = FormEventCancel
@@ -183,7 +194,7 @@ func (frm *Form) triggerEvent(evt ResponseEvent) (re ResponseEvent) {
 	return frmEvt
 }
 
-func (frm *Form) getComponent(name string, index int) (html template.HTML, err error) {
+func (frm *Form) component(name string, index int) ClientComponent {
 	checkFieldTrigger := func(row *Row) {
 		for index, column := range row.Columns {
 			if column.Value.FormTrigger {
@@ -229,54 +240,71 @@ func (frm *Form) getComponent(name string, index int) (html template.HTML, err e
 			}
 		},
 	}
-	cc := ccMap[name]()
-	html, err = cc.Render()
-	return html, err
+	return ccMap[name]()
 }
 
 /*
 Based on the values, it will generate the html code of the [Form] or return with an error message.
 */
 func (frm *Form) Render() (html template.HTML, err error) {
+	return RenderHTML(frm)
+}
+
+// the render data of the [Form] template
+type formRender struct {
+	*Form
+	hw *ut.HTMLWriter
+}
+
+// [Form] template functions
+var formFuncMap = map[string]any{
+	"styleMap": func(frm *formRender) bool {
+		return len(frm.Style) > 0
+	},
+	"customClass": func(frm *formRender) string {
+		return strings.Join(frm.Class, " ")
+	},
+	"inputComponent": func(frm *formRender, name string, index int) (template.HTML, error) {
+		return "", RenderTo(frm.hw, frm.component(name, index))
+	},
+	"footerRows": func(frm *formRender) bool {
+		return len(frm.FooterRows) > 0
+	},
+}
+
+/*
+Based on the values, it will write the html code of the [Form] into the writer or return with an error message.
+*/
+func (frm *Form) RenderTo(w io.Writer) (err error) {
 	frm.InitProps(frm)
 
-	funcMap := map[string]any{
-		"styleMap": func() bool {
-			return len(frm.Style) > 0
-		},
-		"customClass": func() string {
-			return strings.Join(frm.Class, " ")
-		},
-		"inputComponent": func(name string, index int) (template.HTML, error) {
-			return frm.getComponent(name, index)
-		},
-		"footerRows": func() bool {
-			return len(frm.FooterRows) > 0
-		},
-	}
-	tpl := `<div id="{{ .Id }}" name="{{ .Name }}" class="row full {{ customClass }}">
+	tpl := `<div id="{{ .Id }}" name="{{ .Name }}" class="row full {{ customClass $ }}">
 	<form id="{{ .Id }}" name="inputbox_form"
 	{{ if ne .EventURL "" }} hx-post="{{ .EventURL }}" hx-target="{{ .Target }}" {{ if ne .Sync "none" }} hx-sync="{{ .Sync }}"{{ end }} hx-swap="{{ .Swap }}"{{ end }}
 	{{ if ne .Indicator "none" }} hx-indicator="#{{ .Indicator }}"{{ end }}
 	>{{ if .Modal }}<div class="modal"><div class="dialog" 
-	{{ if styleMap }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}>{{ end }}
-	<div class="editor" {{ if and (eq .Modal false) (styleMap) }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}>
-	<div class="editor-title">
-	<div class="cell">{{ inputComponent "title" 0 }}</div>
-	{{ if .Modal }}<div class="cell align-right">{{ inputComponent "btn_close" 0 }}</div>{{ end }}</div>
+	{{ if styleMap $ }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}>{{ end }}
+	<div class="editor" {{ if and (eq .Modal false) (styleMap $) }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}>
+	<div class="editor-title{{ if .Dirty }} editor-dirty{{ end }}">
+	<div class="cell">{{ inputComponent $ "title" 0 }}</div>
+	{{ if .Modal }}<div class="cell align-right">{{ inputComponent $ "btn_close" 0 }}</div>{{ end }}</div>
 	<div class="section-small container-small" >
-	{{ range $row_index, $row := $.BodyRows }}{{ inputComponent "body_row" $row_index }}{{ end }}
+	{{ range $row_index, $row := $.BodyRows }}{{ inputComponent $ "body_row" $row_index }}{{ end }}
 	</div>
-	{{ if footerRows }}<div class="section-small container-small buttons full" >
-	{{ range $row_index, $row := $.FooterRows }}{{ inputComponent "footer_row" $row_index }}{{ end }}
+	{{ if footerRows $ }}<div class="section-small container-small buttons full" >
+	{{ range $row_index, $row := $.FooterRows }}{{ inputComponent $ "footer_row" $row_index }}{{ end }}
 	</div>{{ end }}
 	</div>{{ if .Modal }}</div></div>{{ end }}
 	</form></div>`
 
-	if html, err = ut.TemplateBuilder("inputform", tpl, funcMap, frm); err == nil && frm.EventURL != "" {
+	hw := ut.NewHTMLWriter(w)
+	if err = ut.CachedTemplateWriter(hw, "inputform", tpl, formFuncMap, &formRender{Form: frm, hw: hw}); err == nil {
+		err = hw.Close()
+	}
+	if err == nil && frm.EventURL != "" {
 		frm.SetProperty("request_map", frm)
 	}
-	return html, err
+	return err
 }
 
 var testFormResponse func(evt ResponseEvent) (re ResponseEvent) = func(evt ResponseEvent) (re ResponseEvent) {
@@ -1,6 +1,7 @@
 package component
 
 import (
+	"io"
 	"net/url"
 	"reflect"
 	"testing"
@@ -302,7 +303,7 @@ func TestForm_triggerEvent(t *testing.T) {
 	}
 }
 
-func TestForm_getComponent(t *testing.T) {
+func TestForm_component(t *testing.T) {
 	type fields struct {
 		BaseComponent BaseComponent
 		Title         string
@@ -346,9 +347,9 @@ func TestForm_getComponent(t *testing.T) {
 				FooterRows:    tt.fields.FooterRows,
 				Modal:         tt.fields.Modal,
 			}
-			_, err := frm.getComponent(tt.args.name, tt.args.index)
+			err := RenderTo(io.Discard, frm.component(tt.args.name, tt.args.index))
 			if (err != nil) != tt.wantErr {
-				t.Errorf("Form.getComponent() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("Form.component() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 		})
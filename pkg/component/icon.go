@@ -2,6 +2,7 @@ package component
 
 import (
 	"html/template"
+	"io"
 	"strings"
 
 	ut "github.com/nervatura/component/pkg/util"
@@ -107,7 +108,7 @@ const (
 	IconWrench              = "Wrench"
 )
 
-// [Icon] Value values
+// [Icon] Value values. The [RegisterIcon] function appends the names of the custom icons.
 var IconValues []string = []string{
 	IconArrowDown, IconArrowLeft, IconArrowRight, IconArrowUp, IconBarcode, IconBars, IconBold, IconBolt, IconBook, IconBriefcase,
 	IconCalendar, IconCaretRight, IconChartBar, IconCheck, IconCheckCircle, IconCheckSquare, IconCheckSquareEmpty, IconClock,
@@ -147,13 +148,8 @@ type Icon struct {
 	Height float64 `json:"height"`
 	// The HTML fill attribute of the component
 	Color string `json:"color"`
-}
-
-type iconData struct {
-	ViewBox string  `json:"view_box"`
-	Width   float64 `json:"width"`
-	Height  float64 `json:"height"`
-	Path    string  `json:"path"`
+	// Emits a <use href> reference to the [IconSprite] symbol. Default value: [IconSpriteMode]
+	Sprite bool `json:"sprite"`
 }
 
 /*
@@ -167,6 +163,7 @@ func (ico *Icon) Properties() ut.IM {
 			"width":  ico.Width,
 			"height": ico.Height,
 			"color":  ico.Color,
+			"sprite": ico.Sprite,
 		})
 }
 
@@ -227,6 +224,10 @@ func (ico *Icon) SetProperty(propName string, propValue interface{}) interface{}
 			ico.Color = ut.ToString(propValue, "")
 			return ico.Color
 		},
+		"sprite": func() interface{} {
+			ico.Sprite = ut.ToBoolean(propValue, false)
+			return ico.Sprite
+		},
 	}
 	if _, found := pm[propName]; found {
 		return ico.SetRequestValue(propName, pm[propName](), []string{})
@@ -258,35 +259,62 @@ func (ico *Icon) OnRequest(te TriggerEvent) (re ResponseEvent) {
 Based on the values, it will generate the html code of the [Icon] or return with an error message.
 */
 func (ico *Icon) Render() (html template.HTML, err error) {
+	return RenderHTML(ico)
+}
+
+// the render data of the [Icon] template
+type iconRender struct {
+	*Icon
+	idata IconData
+}
+
+// [Icon] template functions
+var iconFuncMap = map[string]any{
+	"styleMap": func(ico *iconRender) bool {
+		return len(ico.Style) > 0
+	},
+	"customClass": func(ico *iconRender) string {
+		return strings.Join(ico.Class, " ")
+	},
+	"viewBox": func(ico *iconRender) string {
+		return ico.idata.ViewBox
+	},
+	"iPath": func(ico *iconRender) string {
+		return ico.idata.Path
+	},
+	"singlePath": func(ico *iconRender) bool {
+		return len(ico.idata.Paths) == 0 && !ico.idata.Stroke
+	},
+	"iconContent": func(ico *iconRender) template.HTML {
+		return ico.idata.content()
+	},
+	"sprite": func(ico *iconRender) bool {
+		return ico.Sprite || IconSpriteMode
+	},
+	"symbolID": func(ico *iconRender) string {
+		return IconSymbolID(ico.Value)
+	},
+}
+
+/*
+Based on the values, it will write the html code of the [Icon] into the writer or return with an error message.
+*/
+func (ico *Icon) RenderTo(w io.Writer) (err error) {
 	ico.InitProps(ico)
 	idata := iconMap[ico.Value]
 
-	funcMap := map[string]any{
-		"styleMap": func() bool {
-			return len(ico.Style) > 0
-		},
-		"customClass": func() string {
-			return strings.Join(ico.Class, " ")
-		},
-		"viewBox": func() string {
-			return idata.ViewBox
-		},
-		"iPath": func() string {
-			return idata.Path
-		},
-	}
 	tpl := `<svg xmlns="http://www.w3.org/2000/svg" 
-	id="{{ .Id }}" name="{{ .Name }}" viewBox="{{ viewBox }}" width={{ .Width }} height={{ .Height }}
-	{{ if ne .EventURL "" }} class="link {{ customClass }}" hx-post="{{ .EventURL }}" hx-target="{{ .Target }}" {{ if ne .Sync "none" }} hx-sync="{{ .Sync }}"{{ end }} hx-swap="{{ .Swap }}"{{ end }}
+	id="{{ .Id }}" name="{{ .Name }}" viewBox="{{ viewBox $ }}" width={{ .Width }} height={{ .Height }}
+	{{ if ne .EventURL "" }} class="link {{ customClass $ }}" hx-post="{{ .EventURL }}" hx-target="{{ .Target }}" {{ if ne .Sync "none" }} hx-sync="{{ .Sync }}"{{ end }} hx-swap="{{ .Swap }}"{{ end }}
 	{{ if ne .Indicator "none" }} hx-indicator="#{{ .Indicator }}"{{ end }}
-	{{ if styleMap }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}
-	><g{{ if ne .Color "" }} fill="{{ .Color }}"{{ end }}><path d="{{ iPath }}"></path></g>
+	{{ if styleMap $ }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}
+	><g{{ if ne .Color "" }} fill="{{ .Color }}" color="{{ .Color }}"{{ end }}>{{ if sprite $ }}<use href="#{{ symbolID $ }}"></use>{{ else if singlePath $ }}<path d="{{ iPath $ }}"></path>{{ else }}{{ iconContent $ }}{{ end }}</g>
 	</svg>`
 
-	if html, err = ut.TemplateBuilder("icon", tpl, funcMap, ico); err == nil && ico.EventURL != "" {
+	if err = ut.CachedTemplateWriter(w, "icon", tpl, iconFuncMap, &iconRender{Icon: ico, idata: idata}); err == nil && ico.EventURL != "" {
 		ico.SetProperty("request_map", ico)
 	}
-	return html, nil
+	return nil
 }
 
 var testIcoResponse func(evt ResponseEvent) (re ResponseEvent) = func(evt ResponseEvent) (re ResponseEvent) {
@@ -306,6 +334,20 @@ var testIcoResponse func(evt ResponseEvent) (re ResponseEvent) = func(evt Respon
 	return re
 }
 
+var testIconSet map[string]string = map[string]string{
+	"brand": `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 24 24">
+	<circle cx="12" cy="12" r="11" fill="#1478dc"/><rect x="7" y="7" width="10" height="10" fill="#fff"/></svg>`,
+	"activity": `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 24 24" fill="none" stroke="currentColor"
+	stroke-width="2"><polyline points="22 12 18 12 15 21 9 3 6 12 2 12"></polyline></svg>`,
+}
+
+// the demo icons are registered once, the registry is not safe for concurrent writes
+func init() {
+	for name, svg := range testIconSet {
+		_ = RegisterIconSVG(IconName("test", name), []byte(svg))
+	}
+}
+
 // [Icon] test and demo data
 func TestIcon(cc ClientComponent) []TestComponent {
 	id := ut.ToString(cc.GetProperty("id"), "")
@@ -362,10 +404,43 @@ func TestIcon(cc ClientComponent) []TestComponent {
 				},
 				Value: IconGlobe,
 			}},
+		{
+			Label:         "Registered SVG icon (multi-path)",
+			ComponentType: ComponentTypeIcon,
+			Component: &Icon{
+				BaseComponent: BaseComponent{
+					Id: id + "_icon_brand",
+				},
+				Value: "test:brand",
+				Width: 32, Height: 32,
+			}},
+		{
+			Label:         "Stroke icon",
+			ComponentType: ComponentTypeIcon,
+			Component: &Icon{
+				BaseComponent: BaseComponent{
+					Id: id + "_icon_stroke",
+				},
+				Value: "test:activity",
+				Width: 32, Height: 32,
+				Color: "green",
+			}},
+		{
+			Label:         "Sprite reference",
+			ComponentType: ComponentTypeIcon,
+			Component: &Icon{
+				BaseComponent: BaseComponent{
+					Id: id + "_icon_sprite",
+				},
+				Value:  IconStar,
+				Width:  32,
+				Height: 32,
+				Sprite: true,
+			}},
 	}
 }
 
-var iconMap map[string]iconData = map[string]iconData{
+var iconMap map[string]IconData = map[string]IconData{
 	"UserLock": {
 		ViewBox: "0 0 640 512",
 		Width:   20, Height: 16,
@@ -0,0 +1,337 @@
+package component
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"io/fs"
+	"math"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	ut "github.com/nervatura/component/pkg/util"
+)
+
+// The default height of the registered icons. The width is calculated from the viewBox.
+const IconDefaultHeight = 16
+
+/*
+All [Icon] components are rendered as <use href> references of the [IconSprite] symbols
+instead of inlining the full path on every render. The [Application] includes the sprite automatically.
+*/
+var IconSpriteMode bool = false
+
+// IconPath is a path element of a multi-path or stroke-based [IconData]
+type IconPath struct {
+	// The path data (d attribute)
+	D string `json:"d"`
+	// Optional fill attribute. Example: "none", "currentColor"
+	Fill string `json:"fill"`
+	// Optional stroke attribute
+	Stroke string `json:"stroke"`
+	// Optional stroke-width attribute
+	StrokeWidth string `json:"stroke_width"`
+	// Optional fill-rule attribute. Example: "evenodd"
+	FillRule string `json:"fill_rule"`
+	// Optional opacity attribute
+	Opacity string `json:"opacity"`
+}
+
+// IconData is the SVG graphic of an [Icon]
+type IconData struct {
+	ViewBox string  `json:"view_box"`
+	Width   float64 `json:"width"`
+	Height  float64 `json:"height"`
+	// Single path data
+	Path string `json:"path"`
+	// Multi-path icon. If it is set, the Path value is not used.
+	Paths []IconPath `json:"paths"`
+	// Stroke-based icon. The paths are drawn with the current color, without fill.
+	Stroke bool `json:"stroke"`
+	// The stroke-width of a stroke-based icon. Default value: 2
+	StrokeWidth float64 `json:"stroke_width"`
+}
+
+func (icon *IconData) content() template.HTML {
+	var sb strings.Builder
+	attr := func(name, value string) {
+		if value != "" {
+			sb.WriteString(fmt.Sprintf(` %s="%s"`, name, template.HTMLEscapeString(value)))
+		}
+	}
+	if icon.Stroke {
+		sb.WriteString(fmt.Sprintf(
+			`<g fill="none" stroke="currentColor" stroke-width="%s" stroke-linecap="round" stroke-linejoin="round">`,
+			ut.ToString(ut.ToFloat(icon.StrokeWidth, 2), "")))
+	}
+	paths := icon.Paths
+	if len(paths) == 0 {
+		paths = []IconPath{{D: icon.Path}}
+	}
+	for _, ipath := range paths {
+		sb.WriteString("<path")
+		attr("d", ipath.D)
+		attr("fill", ipath.Fill)
+		attr("stroke", ipath.Stroke)
+		attr("stroke-width", ipath.StrokeWidth)
+		attr("fill-rule", ipath.FillRule)
+		attr("opacity", ipath.Opacity)
+		sb.WriteString("></path>")
+	}
+	if icon.Stroke {
+		sb.WriteString("</g>")
+	}
+	return template.HTML(sb.String())
+}
+
+func iconSize(viewBox string) (width, height float64) {
+	values := strings.Fields(strings.ReplaceAll(viewBox, ",", " "))
+	if len(values) != 4 || ut.ToFloat(values[3], 0) <= 0 {
+		return IconDefaultHeight, IconDefaultHeight
+	}
+	width = IconDefaultHeight * ut.ToFloat(values[2], 0) / ut.ToFloat(values[3], 0)
+	return math.Round(width*100) / 100, IconDefaultHeight
+}
+
+/*
+The IconName function returns the registered name of a namespaced icon. Example: IconName("mdi", "account")
+returns "mdi:account".
+*/
+func IconName(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + ":" + name
+}
+
+/*
+The RegisterIcon function adds a custom icon to the valid [Icon] values. An existing icon with the same
+name is replaced. If the Width or Height is not set, the size is calculated from the ViewBox.
+The icons should be registered before the first request is served.
+*/
+func RegisterIcon(name string, icon IconData) {
+	if icon.Width == 0 || icon.Height == 0 {
+		icon.Width, icon.Height = iconSize(icon.ViewBox)
+	}
+	iconMap[name] = icon
+	if !slices.Contains(IconValues, name) {
+		IconValues = append(IconValues, name)
+	}
+}
+
+// The RegisterIconSet function registers all icons of the set with the namespace prefix.
+func RegisterIconSet(namespace string, icons map[string]IconData) {
+	for name, icon := range icons {
+		RegisterIcon(IconName(namespace, name), icon)
+	}
+}
+
+// The RegisterIconSVG function parses and registers an SVG file content. See more [ParseIconSVG].
+func RegisterIconSVG(name string, data []byte) (err error) {
+	var icon IconData
+	if icon, err = ParseIconSVG(data); err == nil {
+		RegisterIcon(name, icon)
+	}
+	return err
+}
+
+/*
+The RegisterIconFS function registers all *.svg files of the directory with the namespace prefix.
+The icon name is the file name without the extension. It can be used with an embed.FS directory.
+*/
+func RegisterIconFS(namespace string, fsys fs.FS, dir string) (err error) {
+	var files []string
+	if files, err = fs.Glob(fsys, path.Join(dir, "*.svg")); err != nil {
+		return err
+	}
+	for _, file := range files {
+		var data []byte
+		if data, err = fs.ReadFile(fsys, file); err != nil {
+			return err
+		}
+		if err = RegisterIconSVG(IconName(namespace, strings.TrimSuffix(path.Base(file), ".svg")), data); err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+	}
+	return nil
+}
+
+type iconParser struct {
+	icon   IconData
+	styles []map[string]string
+	skip   int
+}
+
+var iconStyleAttrs []string = []string{"fill", "stroke", "stroke-width", "fill-rule", "opacity"}
+
+func (ip *iconParser) attrs(elem xml.StartElement) map[string]string {
+	values := map[string]string{}
+	for _, attr := range elem.Attr {
+		values[attr.Name.Local] = attr.Value
+	}
+	return values
+}
+
+func (ip *iconParser) style(values map[string]string) map[string]string {
+	style := map[string]string{}
+	if len(ip.styles) > 0 {
+		style = ip.styles[len(ip.styles)-1]
+	}
+	current := map[string]string{}
+	for _, key := range iconStyleAttrs {
+		current[key] = style[key]
+		if value, found := values[key]; found {
+			current[key] = value
+		}
+	}
+	return current
+}
+
+func iconShapePath(tag string, attrs map[string]string) string {
+	num := func(key string) float64 {
+		return ut.ToFloat(attrs[key], 0)
+	}
+	points := func() string {
+		return strings.Join(strings.Fields(strings.ReplaceAll(attrs["points"], ",", " ")), " ")
+	}
+	switch tag {
+	case "path":
+		return attrs["d"]
+	case "circle":
+		return fmt.Sprintf("M%g %gm-%g 0a%g %g 0 1 0 %g 0a%g %g 0 1 0 -%g 0",
+			num("cx"), num("cy"), num("r"), num("r"), num("r"), num("r")*2, num("r"), num("r"), num("r")*2)
+	case "ellipse":
+		return fmt.Sprintf("M%g %gm-%g 0a%g %g 0 1 0 %g 0a%g %g 0 1 0 -%g 0",
+			num("cx"), num("cy"), num("rx"), num("rx"), num("ry"), num("rx")*2, num("rx"), num("ry"), num("rx")*2)
+	case "rect":
+		return fmt.Sprintf("M%g %gh%gv%gh-%gZ", num("x"), num("y"), num("width"), num("height"), num("width"))
+	case "line":
+		return fmt.Sprintf("M%g %gL%g %g", num("x1"), num("y1"), num("x2"), num("y2"))
+	case "polyline":
+		return "M" + points()
+	case "polygon":
+		return "M" + points() + "Z"
+	}
+	return ""
+}
+
+func (ip *iconParser) root(values map[string]string) {
+	ip.icon.ViewBox = values["viewBox"]
+	if ip.icon.ViewBox == "" && values["width"] != "" && values["height"] != "" {
+		ip.icon.ViewBox = fmt.Sprintf("0 0 %s %s",
+			strings.TrimSuffix(values["width"], "px"), strings.TrimSuffix(values["height"], "px"))
+	}
+	if values["fill"] == "none" && values["stroke"] != "" && values["stroke"] != "none" {
+		ip.icon.Stroke = true
+		ip.icon.StrokeWidth = ut.ToFloat(values["stroke-width"], 2)
+	}
+	ip.styles = append(ip.styles, map[string]string{})
+}
+
+func (ip *iconParser) element(elem xml.StartElement) {
+	tag := elem.Name.Local
+	if ip.skip > 0 || slices.Contains([]string{"defs", "clipPath", "mask", "title", "desc", "style", "metadata"}, tag) {
+		ip.skip++
+		return
+	}
+	values := ip.attrs(elem)
+	style := ip.style(values)
+	switch tag {
+	case "svg":
+		if ip.icon.ViewBox == "" && len(ip.styles) == 0 {
+			ip.root(values)
+			return
+		}
+	case "g":
+	default:
+		if d := iconShapePath(tag, values); d != "" {
+			ip.icon.Paths = append(ip.icon.Paths, IconPath{
+				D: d, Fill: style["fill"], Stroke: style["stroke"], StrokeWidth: style["stroke-width"],
+				FillRule: style["fill-rule"], Opacity: style["opacity"],
+			})
+		}
+	}
+	ip.styles = append(ip.styles, style)
+}
+
+func (ip *iconParser) end() {
+	if ip.skip > 0 {
+		ip.skip--
+		return
+	}
+	if len(ip.styles) > 0 {
+		ip.styles = ip.styles[:len(ip.styles)-1]
+	}
+}
+
+/*
+The ParseIconSVG function converts an SVG file content to [IconData]. The path, circle, ellipse, rect, line,
+polyline and polygon elements are converted to paths, the fill, stroke, stroke-width, fill-rule and opacity
+attributes of the groups are inherited. An SVG with fill="none" and a stroke attribute on the root element
+is a stroke-based icon. The transform attributes are not supported.
+*/
+func ParseIconSVG(data []byte) (icon IconData, err error) {
+	ip := &iconParser{}
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		var token xml.Token
+		if token, err = decoder.Token(); err != nil {
+			break
+		}
+		switch elem := token.(type) {
+		case xml.StartElement:
+			ip.element(elem)
+		case xml.EndElement:
+			ip.end()
+		}
+	}
+	if !errors.Is(err, io.EOF) {
+		return icon, err
+	}
+	if ip.icon.ViewBox == "" {
+		return icon, errors.New("missing svg viewBox")
+	}
+	if len(ip.icon.Paths) == 0 {
+		return icon, errors.New("missing svg shape elements")
+	}
+	if len(ip.icon.Paths) == 1 && ip.icon.Paths[0] == (IconPath{D: ip.icon.Paths[0].D}) {
+		ip.icon.Path, ip.icon.Paths = ip.icon.Paths[0].D, nil
+	}
+	ip.icon.Width, ip.icon.Height = iconSize(ip.icon.ViewBox)
+	return ip.icon, nil
+}
+
+var iconSymbolInvalid = regexp.MustCompile(`[^A-Za-z0-9_-]`)
+
+// The IconSymbolID function returns the id of the [IconSprite] symbol of the icon
+func IconSymbolID(name string) string {
+	return "icon-" + iconSymbolInvalid.ReplaceAllString(name, "_")
+}
+
+/*
+The IconSprite function returns a hidden SVG element with the symbols of the icons. If the names
+are not set, all registered icons are included.
+*/
+func IconSprite(names ...string) template.HTML {
+	if len(names) == 0 {
+		names = slices.Clone(IconValues)
+		slices.Sort(names)
+	}
+	var sb strings.Builder
+	sb.WriteString(`<svg xmlns="http://www.w3.org/2000/svg" style="display:none" aria-hidden="true">`)
+	for _, name := range names {
+		if icon, found := iconMap[name]; found {
+			sb.WriteString(fmt.Sprintf(`<symbol id="%s" viewBox="%s">`,
+				IconSymbolID(name), template.HTMLEscapeString(icon.ViewBox)))
+			sb.WriteString(string(icon.content()))
+			sb.WriteString(`</symbol>`)
+		}
+	}
+	sb.WriteString(`</svg>`)
+	return template.HTML(sb.String())
+}
@@ -0,0 +1,165 @@
+package component
+
+import (
+	"io/fs"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestParseIconSVG(t *testing.T) {
+	tests := []struct {
+		name    string
+		svg     string
+		want    IconData
+		wantErr bool
+	}{
+		{
+			name: "single_path",
+			svg:  `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 32 16"><title>Icon</title><path d="M0 0h32v16z"/></svg>`,
+			want: IconData{ViewBox: "0 0 32 16", Width: 32, Height: 16, Path: "M0 0h32v16z"},
+		},
+		{
+			name: "multi_path",
+			svg: `<svg width="24px" height="24px"><defs><clipPath id="c"><rect width="24" height="24"/></clipPath></defs>
+			<g fill="red" opacity="0.5"><circle cx="12" cy="12" r="10"/><ellipse cx="12" cy="12" rx="4" ry="2" fill="blue"/></g>
+			<rect x="1" y="2" width="3" height="4" fill-rule="evenodd"/><line x1="0" y1="0" x2="24" y2="24" stroke="#000" stroke-width="1"/>
+			<polygon points="1,1 2,2 3,1"/><unknown/><svg><path d="M1 1"/></svg></svg>`,
+			want: IconData{ViewBox: "0 0 24 24", Width: 16, Height: 16, Paths: []IconPath{
+				{D: "M12 12m-10 0a10 10 0 1 0 20 0a10 10 0 1 0 -20 0", Fill: "red", Opacity: "0.5"},
+				{D: "M12 12m-4 0a4 2 0 1 0 8 0a4 2 0 1 0 -8 0", Fill: "blue", Opacity: "0.5"},
+				{D: "M1 2h3v4h-3Z", FillRule: "evenodd"},
+				{D: "M0 0L24 24", Stroke: "#000", StrokeWidth: "1"},
+				{D: "M1 1 2 2 3 1Z"},
+				{D: "M1 1"},
+			}},
+		},
+		{
+			name: "stroke",
+			svg: `<svg viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="1.5">
+			<polyline points="22 12 18 12"/><path d="M1 1"/></svg>`,
+			want: IconData{ViewBox: "0 0 24 24", Width: 16, Height: 16, Stroke: true, StrokeWidth: 1.5,
+				Paths: []IconPath{{D: "M22 12 18 12"}, {D: "M1 1"}}},
+		},
+		{
+			name:    "invalid_xml",
+			svg:     `<svg viewBox="0 0 24 24"><path d="M1 1"></svg>`,
+			wantErr: true,
+		},
+		{
+			name:    "missing_viewbox",
+			svg:     `<svg><path d="M1 1"/></svg>`,
+			wantErr: true,
+		},
+		{
+			name:    "missing_shape",
+			svg:     `<svg viewBox="0 0 24 24"><g></g></svg>`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseIconSVG([]byte(tt.svg))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseIconSVG() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseIconSVG() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegisterIcon(t *testing.T) {
+	RegisterIconSet("unit", map[string]IconData{
+		"box":  {ViewBox: "0 0 20 10", Path: "M0 0h20v10z"},
+		"size": {ViewBox: "invalid", Path: "M0 0", Width: 10, Height: 10},
+	})
+	RegisterIcon("unit:box", IconData{ViewBox: "0 0 20 0", Path: "M0 0h20v10z"})
+	if icon := iconMap["unit:box"]; icon.Width != 16 || icon.Height != 16 {
+		t.Errorf("RegisterIcon() = %v", icon)
+	}
+	if icon := iconMap["unit:size"]; icon.Width != 10 {
+		t.Errorf("RegisterIconSet() = %v", icon)
+	}
+	if name := IconName("", "box"); name != "box" {
+		t.Errorf("IconName() = %v", name)
+	}
+	count := 0
+	for _, name := range IconValues {
+		if name == "unit:box" {
+			count++
+		}
+	}
+	if count != 1 {
+		t.Errorf("RegisterIcon() IconValues count = %d", count)
+	}
+	btn := &Button{Icon: "unit:box"}
+	if btn.Validation("icon", "unit:box") != "unit:box" {
+		t.Error("Button.Validation() registered icon")
+	}
+
+	fsys := fstest.MapFS{
+		"icons/home.svg":   {Data: []byte(`<svg viewBox="0 0 24 24"><path d="M1 1"/></svg>`)},
+		"icons/readme.txt": {Data: []byte(`readme`)},
+		"invalid/bad.svg":  {Data: []byte(`<svg><g/></svg>`)},
+	}
+	if err := RegisterIconFS("fs", fsys, "icons"); err != nil || iconMap["fs:home"].Path != "M1 1" {
+		t.Errorf("RegisterIconFS() error = %v", err)
+	}
+	if err := RegisterIconFS("fs", fsys, "invalid"); err == nil {
+		t.Error("RegisterIconFS() error = nil")
+	}
+	if err := RegisterIconFS("fs", fsys, "["); err == nil {
+		t.Error("RegisterIconFS() pattern error = nil")
+	}
+	if err := RegisterIconFS("fs", errorFS{}, "icons"); err == nil {
+		t.Error("RegisterIconFS() read error = nil")
+	}
+}
+
+type errorFS struct{}
+
+func (errorFS) Open(name string) (fs.File, error) {
+	if name == "." || name == "icons" {
+		return fstest.MapFS{"icons/error.svg": {}}.Open(name)
+	}
+	return nil, fs.ErrPermission
+}
+
+func TestIconSprite(t *testing.T) {
+	RegisterIcon("sprite:stroke", IconData{ViewBox: "0 0 24 24", Stroke: true,
+		Paths: []IconPath{{D: "M1 1", Fill: "none"}}})
+	sprite := string(IconSprite())
+	for _, want := range []string{
+		`<symbol id="icon-sprite_stroke" viewBox="0 0 24 24">`,
+		`<g fill="none" stroke="currentColor" stroke-width="2"`,
+		`<path d="M1 1" fill="none"></path>`,
+		`<symbol id="icon-Star"`,
+	} {
+		if !strings.Contains(sprite, want) {
+			t.Errorf("IconSprite() missing %s", want)
+		}
+	}
+	if sprite = string(IconSprite(IconStar, "missing")); strings.Count(sprite, "<symbol") != 1 {
+		t.Errorf("IconSprite() = %s", sprite)
+	}
+
+	ico := &Icon{Value: "sprite:stroke", Color: "red"}
+	html, _ := ico.Render()
+	if !strings.Contains(string(html), `color="red"`) || !strings.Contains(string(html), `stroke="currentColor"`) {
+		t.Errorf("Icon.Render() = %s", html)
+	}
+	IconSpriteMode = true
+	defer func() { IconSpriteMode = false }()
+	html, _ = ico.Render()
+	if !strings.Contains(string(html), `<use href="#icon-sprite_stroke"></use>`) {
+		t.Errorf("Icon.Render() = %s", html)
+	}
+	app := &Application{}
+	if html, _ = app.Render(); !strings.Contains(string(html), `<symbol id="icon-sprite_stroke"`) {
+		t.Error("Application.Render() missing icon sprite")
+	}
+}
@@ -3,6 +3,7 @@ package component
 import (
 	"fmt"
 	"html/template"
+	"io"
 	"strings"
 
 	ut "github.com/nervatura/component/pkg/util"
@@ -67,6 +68,9 @@ type Input struct {
 	Rows int64 `json:"rows"`
 	// Full width input (100%)
 	Full bool `json:"full"`
+	/* The debounce delay of the change event in milliseconds. If it is greater than zero,
+	the change event is triggered while typing (type-ahead). Default value: 0 */
+	TriggerDelay int64 `json:"trigger_delay"`
 }
 
 /*
@@ -76,19 +80,20 @@ func (inp *Input) Properties() ut.IM {
 	return ut.MergeIM(
 		inp.BaseComponent.Properties(),
 		ut.IM{
-			"type":        inp.Type,
-			"value":       inp.Value,
-			"placeholder": inp.Placeholder,
-			"label":       inp.Label,
-			"disabled":    inp.Disabled,
-			"readonly":    inp.ReadOnly,
-			"auto_focus":  inp.AutoFocus,
-			"invalid":     inp.Invalid,
-			"required":    inp.Required,
-			"max_length":  inp.MaxLength,
-			"size":        inp.Size,
-			"rows":        inp.Rows,
-			"full":        inp.Full,
+			"type":          inp.Type,
+			"value":         inp.Value,
+			"placeholder":   inp.Placeholder,
+			"label":         inp.Label,
+			"disabled":      inp.Disabled,
+			"readonly":      inp.ReadOnly,
+			"auto_focus":    inp.AutoFocus,
+			"invalid":       inp.Invalid,
+			"required":      inp.Required,
+			"max_length":    inp.MaxLength,
+			"size":          inp.Size,
+			"rows":          inp.Rows,
+			"full":          inp.Full,
+			"trigger_delay": inp.TriggerDelay,
 		})
 }
 
@@ -175,6 +180,10 @@ func (inp *Input) SetProperty(propName string, propValue interface{}) interface{
 			inp.Label = ut.ToString(propValue, "")
 			return inp.Label
 		},
+		"trigger_delay": func() interface{} {
+			inp.TriggerDelay = ut.ToInteger(propValue, 0)
+			return inp.TriggerDelay
+		},
 	}
 	if _, found := pm[propName]; found {
 		return inp.SetRequestValue(propName, pm[propName](), []string{})
@@ -206,28 +215,36 @@ func (inp *Input) OnRequest(te TriggerEvent) (re ResponseEvent) {
 Based on the values, it will generate the html code of the [Input] or return with an error message.
 */
 func (inp *Input) Render() (html template.HTML, err error) {
+	return RenderHTML(inp)
+}
+
+// [Input] template functions
+var inputFuncMap = map[string]any{
+	"styleMap": func(inp *Input) bool {
+		return len(inp.Style) > 0
+	},
+	"customClass": func(inp *Input) string {
+		return strings.Join(inp.Class, " ")
+	},
+	"inputEl": func(inp *Input) bool {
+		return (inp.Type != InputTypeText)
+	},
+}
+
+/*
+Based on the values, it will write the html code of the [Input] into the writer or return with an error message.
+*/
+func (inp *Input) RenderTo(w io.Writer) (err error) {
 	inp.InitProps(inp)
 
-	funcMap := map[string]any{
-		"styleMap": func() bool {
-			return len(inp.Style) > 0
-		},
-		"customClass": func() string {
-			return strings.Join(inp.Class, " ")
-		},
-		"inputEl": func() bool {
-			return (inp.Type != InputTypeText)
-		},
-	}
 	tagEl := "input"
-	value := ""
 	if inp.Type == InputTypeText {
 		tagEl = "textarea"
-		value = inp.Value
 	}
 	tpl := fmt.Sprintf(`<%s id="{{ .Id }}" name="{{ .Name }}" 
-	{{ if inputEl }} type="{{ .Type }}" value="{{ .Value }}"{{ end }}
+	{{ if inputEl $ }} type="{{ .Type }}" value="{{ .Value }}"{{ end }}
 	{{ if ne .EventURL "" }} hx-post="{{ .EventURL }}" hx-target="{{ .Target }}" {{ if ne .Sync "none" }} hx-sync="{{ .Sync }}"{{ end }} hx-swap="{{ .Swap }}"{{ end }}
+	{{ if and (ne .EventURL "") (gt .TriggerDelay 0) }} hx-trigger="input changed delay:{{ .TriggerDelay }}ms"{{ end }}
 	{{ if ne .Indicator "none" }} hx-indicator="#{{ .Indicator }}"{{ end }}
 	{{ if ne .Placeholder "" }} placeholder="{{ .Placeholder }}"{{ end }}
 	{{ if .ReadOnly }} readonly{{ end }}
@@ -237,15 +254,15 @@ func (inp *Input) Render() (html template.HTML, err error) {
 	{{ if ne .Label "" }} aria-label="{{ .Label }}"{{ end }}
 	{{ if gt .MaxLength 0 }} maxlength="{{ .MaxLength }}"{{ end }}
 	{{ if gt .Size 0 }} size="{{ .Size }}"{{ end }}
-	{{ if and (gt .Rows 0) (ne inputEl true) }} rows="{{ .Rows }}"{{ end }}
-	 class="{{ if .Full }} full{{ end }}{{ if .Invalid }} invalid{{ end }} {{ customClass }}"
-	{{ if styleMap }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}
-	>%s</%s>`, tagEl, value, tagEl)
+	{{ if and (gt .Rows 0) (ne (inputEl $) true) }} rows="{{ .Rows }}"{{ end }}
+	 class="{{ if .Full }} full{{ end }}{{ if .Invalid }} invalid{{ end }} {{ customClass $ }}"
+	{{ if styleMap $ }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}
+	>{{ if not (inputEl $) }}{{ .Value }}{{ end }}</%s>`, tagEl, tagEl)
 
-	if html, err = ut.TemplateBuilder("input", tpl, funcMap, inp); err == nil && inp.EventURL != "" {
+	if err = ut.CachedTemplateWriter(w, "input", tpl, inputFuncMap, inp); err == nil && inp.EventURL != "" {
 		inp.SetProperty("request_map", inp)
 	}
-	return html, nil
+	return err
 }
 
 var testInputResponse func(evt ResponseEvent) (re ResponseEvent) = func(evt ResponseEvent) (re ResponseEvent) {
@@ -339,7 +356,7 @@ func TestInput(cc ClientComponent) []TestComponent {
 					Id: id + "_input_text",
 				},
 				Type:  InputTypeText,
-				Value: `Long text&#13;&#10;Next row...`,
+				Value: "Long text\nNext row...",
 				Rows:  4,
 				Full:  true,
 			}},
@@ -1,7 +1,9 @@
 package component
 
 import (
+	"html/template"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -284,3 +286,14 @@ func TestInput_OnRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestInput_Render(t *testing.T) {
+	for _, value := range []string{`{{ .Id }}<b>`, `second {{ .Name }}`} {
+		inp := &Input{BaseComponent: BaseComponent{Id: "area"}, Type: InputTypeText, Value: value}
+		html, err := inp.Render()
+		want := template.HTMLEscapeString(value) + "</textarea>"
+		if err != nil || !strings.HasSuffix(string(html), want) {
+			t.Errorf("Input.Render() = %v, %v", html, err)
+		}
+	}
+}
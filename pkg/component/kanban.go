@@ -0,0 +1,692 @@
+package component
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"slices"
+	"strings"
+
+	ut "github.com/nervatura/component/pkg/util"
+)
+
+// [Kanban] constants
+const (
+	ComponentTypeKanban = "kanban"
+
+	KanbanEventSelected = "kanban_selected"
+	KanbanEventMove     = "kanban_move"
+	KanbanEventLimit    = "kanban_limit"
+	KanbanEventExpand   = "kanban_expand"
+	KanbanEventCollapse = "kanban_collapse"
+)
+
+// [Kanban] column
+type KanbanColumn struct {
+	// The status field value of the cards of the column
+	Value string `json:"value"`
+	Label string `json:"label"`
+	// The work in progress limit of the column. No limit if the value is 0
+	Limit int64 `json:"limit"`
+	// Optional CSS color of the column header
+	Color string `json:"color"`
+}
+
+// [Kanban] card badge
+type KanbanBadge struct {
+	// The field name of the data source. The badge is hidden if the field value is empty
+	Field string `json:"field"`
+	// Valid [Icon] component value. See more [IconValues] variable values.
+	Icon string `json:"icon"`
+	// Optional CSS color of the badge
+	Color string `json:"color"`
+}
+
+/*
+Creates a kanban board. The cards of the Rows data source are placed in the columns by the value of the
+StatusField and the cards can be moved between and within the columns by drag and drop. The order of the
+cards in a column is the order of the rows.
+
+If the LaneField is set, the cards are grouped into collapsible swimlanes. The moved cards and the collapsed
+swimlanes are stored in the RequestValue, so the state of the board is preserved between the requests.
+
+For example:
+
+	&Kanban{
+	  BaseComponent: BaseComponent{
+	    Id:       "id_kanban_tasks",
+	    EventURL: "/event",
+	  },
+	  Columns: []KanbanColumn{
+	    {Value: "todo", Label: "To do"},
+	    {Value: "doing", Label: "In progress", Limit: 3},
+	    {Value: "done", Label: "Done"},
+	  },
+	  Badges: []KanbanBadge{
+	    {Field: "due_date", Icon: IconCalendar},
+	  },
+	  Rows: []ut.IM{
+	    {"id": 1, "title": "Invoice template", "status": "todo", "assignee": "Alice", "due_date": "2024-06-01"},
+	    {"id": 2, "title": "Price list import", "status": "doing", "assignee": "Bob"},
+	  },
+	}
+*/
+type Kanban struct {
+	BaseComponent
+	// Data source of the cards
+	Rows    []ut.IM        `json:"rows"`
+	Columns []KanbanColumn `json:"columns"`
+	// The badges of the cards
+	Badges []KanbanBadge `json:"badges"`
+	// The swimlanes. If the LaneField is set and the value is empty, the lanes are the values of the LaneField
+	Lanes []SelectOption `json:"lanes"`
+	// The field name containing the unique card key of the data source. Default: id
+	KeyField string `json:"key_field"`
+	// The field name containing the column value of the data source. Default: status
+	StatusField string `json:"status_field"`
+	// The field name containing the card title of the data source. Default: title
+	TitleField string `json:"title_field"`
+	// The field name containing the card assignee of the data source. Default: assignee
+	AssigneeField string `json:"assignee_field"`
+	// The field name containing the swimlane value of the data source. No swimlanes if the value is empty
+	LaneField string `json:"lane_field"`
+	// The values of the collapsed swimlanes
+	Collapsed []string `json:"collapsed"`
+	// Disables the drag and drop moving of the cards
+	ReadOnly bool `json:"readonly"`
+}
+
+/*
+Returns all properties of the [Kanban]
+*/
+func (kan *Kanban) Properties() ut.IM {
+	return ut.MergeIM(
+		kan.BaseComponent.Properties(),
+		ut.IM{
+			"rows":           kan.Rows,
+			"columns":        kan.Columns,
+			"badges":         kan.Badges,
+			"lanes":          kan.Lanes,
+			"key_field":      kan.KeyField,
+			"status_field":   kan.StatusField,
+			"title_field":    kan.TitleField,
+			"assignee_field": kan.AssigneeField,
+			"lane_field":     kan.LaneField,
+			"collapsed":      kan.Collapsed,
+			"readonly":       kan.ReadOnly,
+		})
+}
+
+/*
+Returns the value of the property of the [Kanban] with the specified name.
+*/
+func (kan *Kanban) GetProperty(propName string) interface{} {
+	return kan.Properties()[propName]
+}
+
+/*
+It checks the value given to the property of the [Kanban] and always returns a valid value
+*/
+func (kan *Kanban) Validation(propName string, propValue interface{}) interface{} {
+	pm := map[string]func() interface{}{
+		"rows": func() interface{} {
+			return ut.ToIMA(propValue, []ut.IM{})
+		},
+		"columns": func() interface{} {
+			if columns, valid := propValue.([]KanbanColumn); valid && columns != nil {
+				return columns
+			}
+			columns := []KanbanColumn{}
+			if err := ut.ConvertToType(propValue, &columns); err != nil || columns == nil {
+				return []KanbanColumn{}
+			}
+			return columns
+		},
+		"badges": func() interface{} {
+			if badges, valid := propValue.([]KanbanBadge); valid && badges != nil {
+				return badges
+			}
+			badges := []KanbanBadge{}
+			if err := ut.ConvertToType(propValue, &badges); err != nil || badges == nil {
+				return []KanbanBadge{}
+			}
+			return badges
+		},
+		"lanes": func() interface{} {
+			if lanes, valid := propValue.([]SelectOption); valid && lanes != nil {
+				return lanes
+			}
+			lanes := []SelectOption{}
+			if err := ut.ConvertToType(propValue, &lanes); err != nil || lanes == nil {
+				return []SelectOption{}
+			}
+			return lanes
+		},
+		"key_field": func() interface{} {
+			return ut.ToString(propValue, "id")
+		},
+		"status_field": func() interface{} {
+			return ut.ToString(propValue, "status")
+		},
+		"title_field": func() interface{} {
+			return ut.ToString(propValue, "title")
+		},
+		"assignee_field": func() interface{} {
+			return ut.ToString(propValue, "assignee")
+		},
+		"collapsed": func() interface{} {
+			return ut.ILtoSL(propValue)
+		},
+		"target": func() interface{} {
+			kan.SetProperty("id", kan.Id)
+			value := ut.ToString(propValue, kan.Id)
+			if value != "this" && !strings.HasPrefix(value, "#") {
+				value = "#" + value
+			}
+			return value
+		},
+	}
+	if _, found := pm[propName]; found {
+		return pm[propName]()
+	}
+	if kan.BaseComponent.GetProperty(propName) != nil {
+		return kan.BaseComponent.Validation(propName, propValue)
+	}
+	return propValue
+}
+
+/*
+Setting a property of the [Kanban] value safely. Checks the entered value.
+In case of an invalid value, the default value will be set.
+*/
+func (kan *Kanban) SetProperty(propName string, propValue interface{}) interface{} {
+	pm := map[string]func() interface{}{
+		"rows": func() interface{} {
+			kan.Rows = kan.Validation(propName, propValue).([]ut.IM)
+			return kan.Rows
+		},
+		"columns": func() interface{} {
+			kan.Columns = kan.Validation(propName, propValue).([]KanbanColumn)
+			return kan.Columns
+		},
+		"badges": func() interface{} {
+			kan.Badges = kan.Validation(propName, propValue).([]KanbanBadge)
+			return kan.Badges
+		},
+		"lanes": func() interface{} {
+			kan.Lanes = kan.Validation(propName, propValue).([]SelectOption)
+			return kan.Lanes
+		},
+		"key_field": func() interface{} {
+			kan.KeyField = kan.Validation(propName, propValue).(string)
+			return kan.KeyField
+		},
+		"status_field": func() interface{} {
+			kan.StatusField = kan.Validation(propName, propValue).(string)
+			return kan.StatusField
+		},
+		"title_field": func() interface{} {
+			kan.TitleField = kan.Validation(propName, propValue).(string)
+			return kan.TitleField
+		},
+		"assignee_field": func() interface{} {
+			kan.AssigneeField = kan.Validation(propName, propValue).(string)
+			return kan.AssigneeField
+		},
+		"lane_field": func() interface{} {
+			kan.LaneField = ut.ToString(propValue, "")
+			return kan.LaneField
+		},
+		"collapsed": func() interface{} {
+			kan.Collapsed = kan.Validation(propName, propValue).([]string)
+			return kan.Collapsed
+		},
+		"readonly": func() interface{} {
+			kan.ReadOnly = ut.ToBoolean(propValue, false)
+			return kan.ReadOnly
+		},
+		"target": func() interface{} {
+			kan.Target = kan.Validation(propName, propValue).(string)
+			return kan.Target
+		},
+	}
+	if _, found := pm[propName]; found {
+		return kan.SetRequestValue(propName, pm[propName](), []string{})
+	}
+	if kan.BaseComponent.GetProperty(propName) != nil {
+		return kan.BaseComponent.SetProperty(propName, propValue)
+	}
+	return propValue
+}
+
+func (kan *Kanban) rowValue(row ut.IM, fieldName string) string {
+	return ut.ToString(row[fieldName], "")
+}
+
+// Returns the index of the row with the card key or -1
+func (kan *Kanban) cardIndex(key string) int {
+	return slices.IndexFunc(kan.Rows, func(row ut.IM) bool {
+		return kan.rowValue(row, kan.KeyField) == key
+	})
+}
+
+// Returns true if the card is in the column and in the swimlane
+func (kan *Kanban) inCell(row ut.IM, column, lane string) bool {
+	return kan.rowValue(row, kan.StatusField) == column &&
+		(kan.LaneField == "" || kan.rowValue(row, kan.LaneField) == lane)
+}
+
+// Returns the number of the cards in the column (in all swimlanes)
+func (kan *Kanban) columnCount(column string) (count int64) {
+	for _, row := range kan.Rows {
+		if kan.rowValue(row, kan.StatusField) == column {
+			count++
+		}
+	}
+	return count
+}
+
+// Returns the swimlanes of the board
+func (kan *Kanban) lanes() []SelectOption {
+	if kan.LaneField == "" {
+		return []SelectOption{{}}
+	}
+	if len(kan.Lanes) > 0 {
+		return kan.Lanes
+	}
+	lanes := []SelectOption{}
+	for _, row := range kan.Rows {
+		value := kan.rowValue(row, kan.LaneField)
+		if !slices.ContainsFunc(lanes, func(lane SelectOption) bool { return lane.Value == value }) {
+			lanes = append(lanes, SelectOption{Value: value, Text: value})
+		}
+	}
+	slices.SortFunc(lanes, func(a, b SelectOption) int { return strings.Compare(a.Value, b.Value) })
+	return lanes
+}
+
+/*
+Moves the card of the row index to the position of the column and swimlane cards and
+returns the final position of the card
+*/
+func (kan *Kanban) moveCard(index int, column, lane string, position int64) int64 {
+	row := ut.MergeIM(ut.IM{}, kan.Rows[index])
+	row[kan.StatusField] = column
+	if kan.LaneField != "" {
+		row[kan.LaneField] = lane
+	}
+	rows := slices.Delete(slices.Clone(kan.Rows), index, index+1)
+	insert, count := len(rows), int64(0)
+	for idx, item := range rows {
+		if kan.inCell(item, column, lane) {
+			if count == position {
+				insert = idx
+				break
+			}
+			count++
+			insert = idx + 1
+		}
+	}
+	kan.SetProperty("rows", slices.Insert(rows, insert, row))
+	return count
+}
+
+/*
+If the OnResponse function of the [Kanban] is implemented, the function calls it after the [TriggerEvent]
+is processed, otherwise the function's return [ResponseEvent] is the processed [TriggerEvent].
+*/
+func (kan *Kanban) OnRequest(te TriggerEvent) (re ResponseEvent) {
+	key := te.Values.Get("card")
+	index := kan.cardIndex(key)
+	evt := ResponseEvent{Trigger: kan, TriggerName: kan.Name, Name: KanbanEventSelected, Value: ut.IM{"key": key}}
+	switch {
+	case te.Values.Has("column"):
+		evt.Name = KanbanEventMove
+		if index < 0 {
+			break
+		}
+		row := kan.Rows[index]
+		from, to := kan.rowValue(row, kan.StatusField), te.Values.Get("column")
+		value := ut.IM{"key": key, "from": from, "to": to, "row": row}
+		if kan.LaneField != "" {
+			value["lane"] = te.Values.Get("lane")
+		}
+		idx := slices.IndexFunc(kan.Columns, func(col KanbanColumn) bool { return col.Value == to })
+		if idx > -1 && from != to && kan.Columns[idx].Limit > 0 && kan.columnCount(to) >= kan.Columns[idx].Limit {
+			evt.Name = KanbanEventLimit
+			value["limit"] = kan.Columns[idx].Limit
+			evt.Value = value
+			break
+		}
+		value["position"] = kan.moveCard(index, to, te.Values.Get("lane"), ut.ToInteger(te.Values.Get("position"), 0))
+		value["row"] = kan.Rows[kan.cardIndex(key)]
+		evt.Value = value
+
+	case index > -1:
+		evt.Value = ut.IM{"key": key, "row": kan.Rows[index]}
+
+	case te.Values.Has("lane"):
+		lane := te.Values.Get("lane")
+		evt.Value = ut.IM{"lane": lane}
+		evt.Name = KanbanEventCollapse
+		if slices.Contains(kan.Collapsed, lane) {
+			evt.Name = KanbanEventExpand
+			kan.SetProperty("collapsed", slices.DeleteFunc(slices.Clone(kan.Collapsed), func(value string) bool {
+				return value == lane
+			}))
+			break
+		}
+		kan.SetProperty("collapsed", append(kan.Collapsed, lane))
+	}
+	if kan.OnResponse != nil {
+		return kan.OnResponse(evt)
+	}
+	return evt
+}
+
+// A swimlane of the board
+type kanbanLane struct {
+	SelectOption
+	Collapsed bool
+	Count     int
+	Cells     []kanbanCell
+	// The htmx trigger id of the swimlane header
+	Id string
+}
+
+// The cards of a column in a swimlane
+type kanbanCell struct {
+	Column KanbanColumn
+	Cards  []kanbanCard
+	// The htmx trigger id of the drop zone
+	Id string
+}
+
+type kanbanCard struct {
+	Row ut.IM
+	Key string
+	// The htmx trigger id of the card
+	Id string
+}
+
+// Returns the swimlanes with the cards and the htmx trigger ids
+func (kan *Kanban) board() (lanes []kanbanLane, ids []string) {
+	newID := func(kind string) string {
+		id := fmt.Sprintf("%s_%s_%d", kan.Id, kind, len(ids)+1)
+		ids = append(ids, id)
+		return id
+	}
+	for _, option := range kan.lanes() {
+		lane := kanbanLane{SelectOption: option}
+		if kan.LaneField != "" {
+			lane.Id = newID("lane")
+			lane.Collapsed = slices.Contains(kan.Collapsed, option.Value)
+		}
+		for _, column := range kan.Columns {
+			cell := kanbanCell{Column: column, Id: newID("zone")}
+			for _, row := range kan.Rows {
+				if kan.inCell(row, column.Value, option.Value) {
+					cell.Cards = append(cell.Cards, kanbanCard{Row: row, Key: kan.rowValue(row, kan.KeyField), Id: newID("card")})
+				}
+			}
+			lane.Count += len(cell.Cards)
+			lane.Cells = append(lane.Cells, cell)
+		}
+		lanes = append(lanes, lane)
+	}
+	return lanes, ids
+}
+
+/*
+Based on the values, it will generate the html code of the [Kanban] or return with an error message.
+*/
+func (kan *Kanban) Render() (html template.HTML, err error) {
+	return RenderHTML(kan)
+}
+
+// the render data of the [Kanban] template
+type kanbanRender struct {
+	*Kanban
+	lanes []kanbanLane
+}
+
+// [Kanban] template functions
+var kanbanFuncMap = map[string]any{
+	"styleMap": func(kan *kanbanRender) bool {
+		return len(kan.Style) > 0
+	},
+	"customClass": func(kan *kanbanRender) string {
+		return strings.Join(kan.Class, " ")
+	},
+	"lanes": func(kan *kanbanRender) []kanbanLane {
+		return kan.lanes
+	},
+	"columnCount": func(kan *kanbanRender, column string) int64 {
+		return kan.columnCount(column)
+	},
+	"limitClass": func(kan *kanbanRender, column KanbanColumn) string {
+		count := kan.columnCount(column.Value)
+		switch {
+		case column.Limit == 0 || count < column.Limit:
+			return ""
+		case count == column.Limit:
+			return " kanban-limit-full"
+		default:
+			return " kanban-limit-over"
+		}
+	},
+	"dragDrop": func(kan *kanbanRender) bool {
+		return kan.EventURL != "" && !kan.ReadOnly
+	},
+	"fieldValue": func(kan *kanbanRender, row ut.IM, fieldName string) string {
+		return kan.rowValue(row, fieldName)
+	},
+	"vals": func(key, value string) string {
+		data, _ := json.Marshal(ut.SM{key: value})
+		return string(data)
+	},
+	"kanbanIcon": func(value string) (template.HTML, error) {
+		return (&Icon{Value: value, Width: 14, Height: 14}).Render()
+	},
+}
+
+/*
+Based on the values, it will write the html code of the [Kanban] into the writer or return with an error message.
+*/
+func (kan *Kanban) RenderTo(w io.Writer) (err error) {
+	kan.InitProps(kan)
+	lanes, ids := kan.board()
+
+	event := ` hx-post="{{ $.EventURL }}" hx-target="{{ $.Target }}" {{ if ne $.Sync "none" }} hx-sync="{{ $.Sync }}"{{ end }} hx-swap="{{ $.Swap }}"
+	{{ if ne $.Indicator "none" }} hx-indicator="#{{ $.Indicator }}"{{ end }}`
+	tpl := `<div id="{{ .Id }}" name="{{ .Name }}" class="kanban {{ customClass $ }}"
+	{{ if styleMap $ }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}
+	><div class="kanban-board" style="grid-template-columns:repeat({{ len .Columns }}, minmax(200px, 1fr));">
+	{{ range .Columns }}<div class="kanban-column-header{{ limitClass $ . }}"{{ if .Color }} style="border-top-color:{{ .Color }};"{{ end }}
+	><span class="kanban-column-label">{{ .Label }}</span>
+	<span class="kanban-count">{{ columnCount $ .Value }}{{ if gt .Limit 0 }} / {{ .Limit }}{{ end }}</span></div>{{ end }}
+	{{ range lanes $ }}{{ if ne .Id "" }}<div id="{{ .Id }}" class="kanban-lane{{ if .Collapsed }} collapsed{{ end }}"
+	 aria-expanded="{{ not .Collapsed }}"
+	{{ if ne $.EventURL "" }} hx-vals="{{ vals "lane" .Value }}"` + event + `{{ end }}
+	>{{ kanbanIcon "CaretRight" }}<span class="kanban-lane-label">{{ .Text }}</span>
+	<span class="kanban-count">{{ .Count }}</span></div>{{ end }}
+	{{ if not .Collapsed }}{{ $lane := .Value }}{{ range .Cells }}<div id="{{ .Id }}" class="kanban-zone"
+	 data-column="{{ .Column.Value }}" data-lane="{{ $lane }}"
+	>{{ range .Cards }}<div id="{{ .Id }}" class="kanban-card"{{ if dragDrop $ }} draggable="true"{{ end }} data-key="{{ .Key }}"
+	{{ if ne $.EventURL "" }} hx-vals="{{ vals "card" .Key }}"` + event + `{{ end }}
+	><div class="kanban-card-title">{{ fieldValue $ .Row $.TitleField }}</div>
+	{{ $row := .Row }}<div class="kanban-card-footer">{{ range $.Badges }}{{ if ne (fieldValue $ $row .Field) "" }}<span class="kanban-badge"
+	{{ if .Color }} style="color:{{ .Color }};fill:{{ .Color }};"{{ end }}
+	>{{ if .Icon }}{{ kanbanIcon .Icon }}{{ end }}<span>{{ fieldValue $ $row .Field }}</span></span>{{ end }}{{ end }}
+	{{ if ne (fieldValue $ .Row $.AssigneeField) "" }}<span class="kanban-assignee">{{ kanbanIcon "User" }}<span>{{ fieldValue $ .Row $.AssigneeField }}</span></span>{{ end }}
+	</div></div>{{ end }}</div>{{ end }}{{ end }}{{ end }}
+	</div>
+	{{ if dragDrop $ }}<script>
+	(function() {
+		var board = htmx.find('#{{ .Id }}');
+		var drag = null;
+		board.addEventListener('dragstart', function(evt) {
+			var card = evt.target.closest('.kanban-card');
+			if (!card) { return; }
+			drag = card;
+			card.classList.add('dragging');
+			evt.dataTransfer.effectAllowed = 'move';
+			evt.dataTransfer.setData('text/plain', card.id);
+		});
+		board.addEventListener('dragend', function() {
+			if (drag) { drag.classList.remove('dragging'); }
+			drag = null;
+		});
+		board.addEventListener('dragover', function(evt) {
+			if (drag && evt.target.closest('.kanban-zone')) { evt.preventDefault(); }
+		});
+		board.addEventListener('drop', function(evt) {
+			var zone = evt.target.closest('.kanban-zone');
+			if (!drag || !zone) { return; }
+			evt.preventDefault();
+			var cards = Array.prototype.slice.call(zone.querySelectorAll('.kanban-card')).filter(function(card) {
+				return card !== drag;
+			});
+			var position = cards.findIndex(function(card) {
+				var rect = card.getBoundingClientRect();
+				return evt.clientY < rect.top + rect.height / 2;
+			});
+			htmx.ajax('POST', {{ .EventURL }}, {
+				source: zone, target: {{ .Target }}, swap: {{ .Swap }},
+				values: {
+					card: drag.getAttribute('data-key'), column: zone.getAttribute('data-column'),
+					lane: zone.getAttribute('data-lane'), position: (position < 0) ? cards.length : position
+				}
+			});
+		});
+	})();
+	</script>{{ end }}
+	</div>`
+
+	if err = ut.CachedTemplateWriter(w, "kanban", tpl, kanbanFuncMap, &kanbanRender{Kanban: kan, lanes: lanes}); err == nil && kan.EventURL != "" {
+		kan.SetProperty("request_map", kan)
+		// the htmx trigger ids of the swimlanes, the drop zones and the cards
+		for _, id := range ids {
+			kan.RequestMap[id] = kan
+		}
+	}
+	return err
+}
+
+var testKanbanResponse func(evt ResponseEvent) (re ResponseEvent) = func(evt ResponseEvent) (re ResponseEvent) {
+	value := ut.ToIM(evt.Value, ut.IM{})
+	toast := func(toastType, message string) ResponseEvent {
+		return ResponseEvent{
+			Trigger: &Toast{
+				Type:    toastType,
+				Value:   message,
+				Timeout: 4,
+			},
+			TriggerName: evt.TriggerName,
+			Name:        evt.Name,
+			Header: ut.SM{
+				HeaderRetarget: "#toast-msg",
+				HeaderReswap:   SwapInnerHTML,
+			},
+		}
+	}
+	switch evt.Name {
+	case KanbanEventSelected:
+		row := ut.ToIM(value["row"], ut.IM{})
+		return toast(ToastTypeInfo, fmt.Sprintf("%s: %s", evt.Name, ut.ToString(row["title"], "")))
+	case KanbanEventLimit:
+		return toast(ToastTypeError, fmt.Sprintf("WIP limit: %d", ut.ToInteger(value["limit"], 0)))
+	}
+	return evt
+}
+
+func testKanbanTasks() []ut.IM {
+	return []ut.IM{
+		{"id": 1, "title": "Invoice template redesign", "status": "todo", "assignee": "Alice", "due_date": "2024-06-03",
+			"comments": 2, "priority": "high"},
+		{"id": 2, "title": "Price list import", "status": "todo", "assignee": "Bob", "priority": "normal"},
+		{"id": 3, "title": "Customer portal login", "status": "doing", "assignee": "Carol", "due_date": "2024-05-31",
+			"priority": "high"},
+		{"id": 4, "title": "Stock report filters", "status": "doing", "assignee": "Alice", "comments": 5,
+			"priority": "normal"},
+		{"id": 5, "title": "Payment reminder e-mails", "status": "review", "assignee": "Bob", "due_date": "2024-05-28",
+			"priority": "normal"},
+		{"id": 6, "title": "Currency rates service", "status": "done", "assignee": "Carol", "comments": 1,
+			"priority": "high"},
+		{"id": 7, "title": "Tax code update", "status": "done", "priority": "normal"},
+	}
+}
+
+// [Kanban] test and demo data
+func TestKanban(cc ClientComponent) []TestComponent {
+	id := ut.ToString(cc.GetProperty("id"), "")
+	eventURL := ut.ToString(cc.GetProperty("event_url"), "")
+	requestValue := cc.GetProperty("request_value").(map[string]ut.IM)
+	requestMap := cc.GetProperty("request_map").(map[string]ClientComponent)
+	base := func(name string) BaseComponent {
+		return BaseComponent{
+			Id:           id + "_kanban_" + name,
+			EventURL:     eventURL,
+			OnResponse:   testKanbanResponse,
+			RequestValue: requestValue,
+			RequestMap:   requestMap,
+		}
+	}
+	columns := []KanbanColumn{
+		{Value: "todo", Label: "To do"},
+		{Value: "doing", Label: "In progress", Limit: 2, Color: "#FFA500"},
+		{Value: "review", Label: "Review", Limit: 2},
+		{Value: "done", Label: "Done", Color: "#3CB371"},
+	}
+	badges := []KanbanBadge{
+		{Field: "due_date", Icon: IconCalendar},
+		{Field: "comments", Icon: IconComment},
+	}
+	return []TestComponent{
+		{
+			Label:         "Task board (WIP limits)",
+			ComponentType: ComponentTypeKanban,
+			Component: &Kanban{
+				BaseComponent: base("tasks"),
+				Columns:       columns,
+				Badges:        badges,
+				Rows:          testKanbanTasks(),
+			}},
+		{
+			Label:         "Swimlanes",
+			ComponentType: ComponentTypeKanban,
+			Component: &Kanban{
+				BaseComponent: base("lanes"),
+				Columns:       columns,
+				Badges:        badges,
+				Rows:          testKanbanTasks(),
+				LaneField:     "priority",
+				Lanes:         []SelectOption{{Value: "high", Text: "High priority"}, {Value: "normal", Text: "Normal priority"}},
+				Collapsed:     []string{"normal"},
+			}},
+		{
+			Label:         "Order pipeline (read only)",
+			ComponentType: ComponentTypeKanban,
+			Component: &Kanban{
+				BaseComponent: base("orders"),
+				Columns: []KanbanColumn{
+					{Value: "new", Label: "New"}, {Value: "picking", Label: "Picking"}, {Value: "shipped", Label: "Shipped"},
+				},
+				Badges:        []KanbanBadge{{Field: "amount", Icon: IconDollar, Color: "#3CB371"}},
+				KeyField:      "order_no",
+				StatusField:   "state",
+				TitleField:    "customer",
+				AssigneeField: "agent",
+				LaneField:     "region",
+				ReadOnly:      true,
+				Rows: []ut.IM{
+					{"order_no": "ORD-1001", "customer": "First Customer Co.", "state": "new", "amount": "1 250", "region": "North"},
+					{"order_no": "ORD-1002", "customer": "Second Customer Ltd.", "state": "picking", "amount": "380",
+						"agent": "Dave", "region": "South"},
+					{"order_no": "ORD-1003", "customer": "Third Customer Inc.", "state": "shipped", "amount": "4 900",
+						"agent": "Dave", "region": "North"},
+				},
+			}},
+	}
+}
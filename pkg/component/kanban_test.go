@@ -0,0 +1,594 @@
+package component
+
+import (
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	ut "github.com/nervatura/component/pkg/util"
+)
+
+func TestTestKanban(t *testing.T) {
+	for _, tt := range TestKanban(&BaseComponent{EventURL: "/demo"}) {
+		t.Run(tt.Label, func(t *testing.T) {
+			tt.Component.Render()
+		})
+	}
+	kan := &Kanban{}
+	testKanbanResponse(ResponseEvent{Trigger: kan, Name: KanbanEventSelected, Value: ut.IM{"row": ut.IM{"title": "Task"}}})
+	testKanbanResponse(ResponseEvent{Trigger: kan, Name: KanbanEventLimit, Value: ut.IM{"limit": 2}})
+	testKanbanResponse(ResponseEvent{Trigger: kan, Name: KanbanEventMove})
+}
+
+func TestKanban_GetProperty(t *testing.T) {
+	tests := []struct {
+		name     string
+		propName string
+		want     interface{}
+	}{
+		{
+			name:     "lane_field",
+			propName: "lane_field",
+			want:     "priority",
+		},
+		{
+			name:     "collapsed",
+			propName: "collapsed",
+			want:     []string{"normal"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kan := &Kanban{LaneField: "priority", Collapsed: []string{"normal"}}
+			if got := kan.GetProperty(tt.propName); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Kanban.GetProperty() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKanban_Validation(t *testing.T) {
+	type args struct {
+		propName  string
+		propValue interface{}
+	}
+	tests := []struct {
+		name string
+		args args
+		want interface{}
+	}{
+		{
+			name: "base",
+			args: args{
+				propName:  "id",
+				propValue: "KANBANID",
+			},
+			want: "KANBANID",
+		},
+		{
+			name: "invalid",
+			args: args{
+				propName:  "invalid",
+				propValue: "",
+			},
+			want: "",
+		},
+		{
+			name: "rows",
+			args: args{
+				propName:  "rows",
+				propValue: []interface{}{ut.IM{"id": 1}},
+			},
+			want: []ut.IM{{"id": 1}},
+		},
+		{
+			name: "columns",
+			args: args{
+				propName:  "columns",
+				propValue: []KanbanColumn{{Value: "todo"}},
+			},
+			want: []KanbanColumn{{Value: "todo"}},
+		},
+		{
+			name: "columns_map",
+			args: args{
+				propName:  "columns",
+				propValue: []interface{}{ut.IM{"value": "doing", "label": "In progress", "limit": 3}},
+			},
+			want: []KanbanColumn{{Value: "doing", Label: "In progress", Limit: 3}},
+		},
+		{
+			name: "columns_invalid",
+			args: args{
+				propName:  "columns",
+				propValue: "columns",
+			},
+			want: []KanbanColumn{},
+		},
+		{
+			name: "badges",
+			args: args{
+				propName:  "badges",
+				propValue: []KanbanBadge{{Field: "due_date"}},
+			},
+			want: []KanbanBadge{{Field: "due_date"}},
+		},
+		{
+			name: "badges_map",
+			args: args{
+				propName:  "badges",
+				propValue: []interface{}{ut.IM{"field": "due_date", "icon": IconCalendar}},
+			},
+			want: []KanbanBadge{{Field: "due_date", Icon: IconCalendar}},
+		},
+		{
+			name: "badges_invalid",
+			args: args{
+				propName:  "badges",
+				propValue: nil,
+			},
+			want: []KanbanBadge{},
+		},
+		{
+			name: "lanes",
+			args: args{
+				propName:  "lanes",
+				propValue: []SelectOption{{Value: "high"}},
+			},
+			want: []SelectOption{{Value: "high"}},
+		},
+		{
+			name: "lanes_map",
+			args: args{
+				propName:  "lanes",
+				propValue: []interface{}{ut.IM{"value": "high", "text": "High"}},
+			},
+			want: []SelectOption{{Value: "high", Text: "High"}},
+		},
+		{
+			name: "lanes_invalid",
+			args: args{
+				propName:  "lanes",
+				propValue: 12,
+			},
+			want: []SelectOption{},
+		},
+		{
+			name: "key_field",
+			args: args{
+				propName:  "key_field",
+				propValue: "",
+			},
+			want: "id",
+		},
+		{
+			name: "status_field",
+			args: args{
+				propName:  "status_field",
+				propValue: nil,
+			},
+			want: "status",
+		},
+		{
+			name: "title_field",
+			args: args{
+				propName:  "title_field",
+				propValue: "customer",
+			},
+			want: "customer",
+		},
+		{
+			name: "assignee_field",
+			args: args{
+				propName:  "assignee_field",
+				propValue: "",
+			},
+			want: "assignee",
+		},
+		{
+			name: "collapsed",
+			args: args{
+				propName:  "collapsed",
+				propValue: []interface{}{"normal"},
+			},
+			want: []string{"normal"},
+		},
+		{
+			name: "target_this",
+			args: args{
+				propName:  "target",
+				propValue: "this",
+			},
+			want: "this",
+		},
+		{
+			name: "target_id",
+			args: args{
+				propName:  "target",
+				propValue: "#target",
+			},
+			want: "#target",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kan := &Kanban{}
+			if got := kan.Validation(tt.args.propName, tt.args.propValue); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Kanban.Validation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKanban_SetProperty(t *testing.T) {
+	type args struct {
+		propName  string
+		propValue interface{}
+	}
+	tests := []struct {
+		name string
+		args args
+		want interface{}
+	}{
+		{
+			name: "base",
+			args: args{
+				propName:  "id",
+				propValue: "KANBANID",
+			},
+			want: "KANBANID",
+		},
+		{
+			name: "invalid",
+			args: args{
+				propName:  "invalid",
+				propValue: "",
+			},
+			want: "",
+		},
+		{
+			name: "rows",
+			args: args{
+				propName:  "rows",
+				propValue: []ut.IM{{"id": 1}},
+			},
+			want: []ut.IM{{"id": 1}},
+		},
+		{
+			name: "columns",
+			args: args{
+				propName:  "columns",
+				propValue: []KanbanColumn{{Value: "todo"}},
+			},
+			want: []KanbanColumn{{Value: "todo"}},
+		},
+		{
+			name: "badges",
+			args: args{
+				propName:  "badges",
+				propValue: []KanbanBadge{{Field: "due_date"}},
+			},
+			want: []KanbanBadge{{Field: "due_date"}},
+		},
+		{
+			name: "lanes",
+			args: args{
+				propName:  "lanes",
+				propValue: []SelectOption{{Value: "high"}},
+			},
+			want: []SelectOption{{Value: "high"}},
+		},
+		{
+			name: "key_field",
+			args: args{
+				propName:  "key_field",
+				propValue: "order_no",
+			},
+			want: "order_no",
+		},
+		{
+			name: "status_field",
+			args: args{
+				propName:  "status_field",
+				propValue: "state",
+			},
+			want: "state",
+		},
+		{
+			name: "title_field",
+			args: args{
+				propName:  "title_field",
+				propValue: "customer",
+			},
+			want: "customer",
+		},
+		{
+			name: "assignee_field",
+			args: args{
+				propName:  "assignee_field",
+				propValue: "agent",
+			},
+			want: "agent",
+		},
+		{
+			name: "lane_field",
+			args: args{
+				propName:  "lane_field",
+				propValue: "region",
+			},
+			want: "region",
+		},
+		{
+			name: "collapsed",
+			args: args{
+				propName:  "collapsed",
+				propValue: []string{"North"},
+			},
+			want: []string{"North"},
+		},
+		{
+			name: "readonly",
+			args: args{
+				propName:  "readonly",
+				propValue: true,
+			},
+			want: true,
+		},
+		{
+			name: "target",
+			args: args{
+				propName:  "target",
+				propValue: "target",
+			},
+			want: "#target",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kan := &Kanban{}
+			if got := kan.SetProperty(tt.args.propName, tt.args.propValue); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Kanban.SetProperty() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKanban_lanes(t *testing.T) {
+	tests := []struct {
+		name      string
+		laneField string
+		lanes     []SelectOption
+		want      []SelectOption
+	}{
+		{
+			name: "no_lanes",
+			want: []SelectOption{{}},
+		},
+		{
+			name:      "lanes",
+			laneField: "priority",
+			lanes:     []SelectOption{{Value: "normal", Text: "Normal"}},
+			want:      []SelectOption{{Value: "normal", Text: "Normal"}},
+		},
+		{
+			name:      "values",
+			laneField: "priority",
+			want:      []SelectOption{{Value: "high", Text: "high"}, {Value: "normal", Text: "normal"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kan := &Kanban{Rows: testKanbanTasks(), LaneField: tt.laneField, Lanes: tt.lanes}
+			if got := kan.lanes(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Kanban.lanes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKanban_OnRequest(t *testing.T) {
+	keys := func(kan *Kanban) (result []string) {
+		for _, row := range kan.Rows {
+			result = append(result, ut.ToString(row["id"], "")+":"+ut.ToString(row["status"], ""))
+		}
+		return result
+	}
+	tests := []struct {
+		name       string
+		laneField  string
+		collapsed  []string
+		onResponse func(evt ResponseEvent) (re ResponseEvent)
+		values     url.Values
+		wantName   string
+		want       ut.IM
+		wantRows   []string
+	}{
+		{
+			name:     "move_missing",
+			values:   url.Values{"card": []string{"9"}, "column": []string{"done"}},
+			wantName: KanbanEventMove,
+			want:     ut.IM{"key": "9"},
+			wantRows: []string{"1:todo", "2:todo", "3:doing", "4:doing", "5:review", "6:done", "7:done"},
+		},
+		{
+			name:     "move_column",
+			values:   url.Values{"card": []string{"1"}, "column": []string{"done"}, "position": []string{"1"}},
+			wantName: KanbanEventMove,
+			want:     ut.IM{"key": "1", "from": "todo", "to": "done", "position": int64(1)},
+			wantRows: []string{"2:todo", "3:doing", "4:doing", "5:review", "6:done", "1:done", "7:done"},
+		},
+		{
+			name:     "move_empty_column",
+			values:   url.Values{"card": []string{"7"}, "column": []string{"new"}, "position": []string{"5"}},
+			wantName: KanbanEventMove,
+			want:     ut.IM{"key": "7", "from": "done", "to": "new", "position": int64(0)},
+			wantRows: []string{"1:todo", "2:todo", "3:doing", "4:doing", "5:review", "6:done", "7:new"},
+		},
+		{
+			name:     "move_within",
+			values:   url.Values{"card": []string{"4"}, "column": []string{"doing"}, "position": []string{"0"}},
+			wantName: KanbanEventMove,
+			want:     ut.IM{"key": "4", "from": "doing", "to": "doing", "position": int64(0)},
+			wantRows: []string{"1:todo", "2:todo", "4:doing", "3:doing", "5:review", "6:done", "7:done"},
+		},
+		{
+			name:     "move_last",
+			values:   url.Values{"card": []string{"1"}, "column": []string{"todo"}, "position": []string{"9"}},
+			wantName: KanbanEventMove,
+			want:     ut.IM{"key": "1", "from": "todo", "to": "todo", "position": int64(1)},
+			wantRows: []string{"2:todo", "1:todo", "3:doing", "4:doing", "5:review", "6:done", "7:done"},
+		},
+		{
+			name:     "limit",
+			values:   url.Values{"card": []string{"1"}, "column": []string{"doing"}},
+			wantName: KanbanEventLimit,
+			want:     ut.IM{"key": "1", "from": "todo", "to": "doing", "limit": int64(2)},
+			wantRows: []string{"1:todo", "2:todo", "3:doing", "4:doing", "5:review", "6:done", "7:done"},
+		},
+		{
+			name:      "move_lane",
+			laneField: "priority",
+			values: url.Values{
+				"card": []string{"2"}, "column": []string{"review"}, "lane": []string{"high"}, "position": []string{"0"}},
+			wantName: KanbanEventMove,
+			want:     ut.IM{"key": "2", "from": "todo", "to": "review", "lane": "high", "position": int64(0)},
+			wantRows: []string{"1:todo", "3:doing", "4:doing", "5:review", "6:done", "7:done", "2:review"},
+		},
+		{
+			name:     "selected",
+			values:   url.Values{"card": []string{"3"}},
+			wantName: KanbanEventSelected,
+			want:     ut.IM{"key": "3"},
+			wantRows: []string{"1:todo", "2:todo", "3:doing", "4:doing", "5:review", "6:done", "7:done"},
+		},
+		{
+			name:     "selected_missing",
+			values:   url.Values{"card": []string{"9"}},
+			wantName: KanbanEventSelected,
+			want:     ut.IM{"key": "9"},
+			wantRows: []string{"1:todo", "2:todo", "3:doing", "4:doing", "5:review", "6:done", "7:done"},
+		},
+		{
+			name:      "collapse",
+			laneField: "priority",
+			values:    url.Values{"lane": []string{"high"}},
+			wantName:  KanbanEventCollapse,
+			want:      ut.IM{"lane": "high"},
+			wantRows:  []string{"1:todo", "2:todo", "3:doing", "4:doing", "5:review", "6:done", "7:done"},
+		},
+		{
+			name:      "expand",
+			laneField: "priority",
+			collapsed: []string{"high"},
+			values:    url.Values{"lane": []string{"high"}},
+			wantName:  KanbanEventExpand,
+			want:      ut.IM{"lane": "high"},
+			wantRows:  []string{"1:todo", "2:todo", "3:doing", "4:doing", "5:review", "6:done", "7:done"},
+		},
+		{
+			name: "on_response",
+			onResponse: func(evt ResponseEvent) (re ResponseEvent) {
+				evt.Value = ut.IM{"response": true}
+				return evt
+			},
+			values:   url.Values{"card": []string{"3"}},
+			wantName: KanbanEventSelected,
+			want:     ut.IM{"response": true},
+			wantRows: []string{"1:todo", "2:todo", "3:doing", "4:doing", "5:review", "6:done", "7:done"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kan := &Kanban{
+				BaseComponent: BaseComponent{OnResponse: tt.onResponse},
+				Rows:          testKanbanTasks(),
+				Columns:       []KanbanColumn{{Value: "todo"}, {Value: "doing", Limit: 2}, {Value: "review", Limit: 2}},
+				KeyField:      "id",
+				StatusField:   "status",
+				LaneField:     tt.laneField,
+				Collapsed:     tt.collapsed,
+			}
+			re := kan.OnRequest(TriggerEvent{Values: tt.values})
+			value := ut.ToIM(re.Value, ut.IM{})
+			delete(value, "row")
+			if re.Name != tt.wantName || !reflect.DeepEqual(value, tt.want) {
+				t.Errorf("Kanban.OnRequest() = %v %v, want %v", re.Name, value, tt.want)
+			}
+			if got := keys(kan); !reflect.DeepEqual(got, tt.wantRows) {
+				t.Errorf("Kanban.OnRequest() rows = %v, want %v", got, tt.wantRows)
+			}
+			if (tt.wantName == KanbanEventCollapse) != slices.Contains(kan.Collapsed, "high") {
+				t.Errorf("Kanban.OnRequest() collapsed = %v", kan.Collapsed)
+			}
+		})
+	}
+}
+
+func TestKanban_Render(t *testing.T) {
+	columns := []KanbanColumn{
+		{Value: "todo", Label: "To do", Limit: 3}, {Value: "doing", Label: "In progress", Limit: 1, Color: "orange"},
+		{Value: "done", Label: "Done"},
+	}
+	badges := []KanbanBadge{{Field: "due_date", Icon: IconCalendar, Color: "red"}, {Field: "comments"}}
+	tests := []struct {
+		name   string
+		kanban Kanban
+		want   []string
+		skip   []string
+	}{
+		{
+			name: "board",
+			kanban: Kanban{
+				BaseComponent: BaseComponent{Id: "kan", EventURL: "/event", Style: ut.SM{"height": "600px"}, Class: []string{"tasks"}},
+				Columns:       columns, Badges: badges, Rows: testKanbanTasks(),
+			},
+			want: []string{
+				`class="kanban tasks"`, `style="height:600px;"`, `class="kanban-column-header kanban-limit-over"`,
+				`style="border-top-color:orange;"`, "2 / 3", `id="kan_card_2"`, `draggable="true"`, "htmx.ajax",
+				`style="color:red;fill:red;"`, "<span>Alice</span>",
+			},
+			skip: []string{"kanban-lane", "kanban-limit-full"},
+		},
+		{
+			name: "lanes_readonly",
+			kanban: Kanban{
+				BaseComponent: BaseComponent{Id: "kan", EventURL: "/event"},
+				Columns:       columns, Rows: testKanbanTasks(), LaneField: "priority", Collapsed: []string{"normal"},
+				ReadOnly: true,
+			},
+			want: []string{`id="kan_lane_1"`, `class="kanban-lane collapsed"`, `data-lane="high"`},
+			skip: []string{`data-lane="normal"`, "draggable", "htmx.ajax"},
+		},
+		{
+			name: "static",
+			kanban: Kanban{
+				Columns: []KanbanColumn{{Value: "todo", Limit: 2}}, Rows: testKanbanTasks(),
+			},
+			want: []string{`class="kanban-column-header kanban-limit-full"`, "Price list import"},
+			skip: []string{"hx-post", "<script>"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			html, err := tt.kanban.Render()
+			if err != nil {
+				t.Fatalf("Kanban.Render() error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(string(html), want) {
+					t.Errorf("Kanban.Render() = %v, want %v", html, want)
+				}
+			}
+			for _, skip := range tt.skip {
+				if strings.Contains(string(html), skip) {
+					t.Errorf("Kanban.Render() = %v, skip %v", html, skip)
+				}
+			}
+			if tt.kanban.EventURL != "" && tt.kanban.RequestMap["kan_card_3"] != &tt.kanban {
+				t.Errorf("Kanban.Render() request_map = %v", tt.kanban.RequestMap)
+			}
+		})
+	}
+}
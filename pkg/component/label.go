@@ -2,6 +2,7 @@ package component
 
 import (
 	"html/template"
+	"io"
 	"strings"
 
 	ut "github.com/nervatura/component/pkg/util"
@@ -196,47 +197,56 @@ func (lbl *Label) getComponent(name string) (template.HTML, error) {
 Based on the values, it will generate the html code of the [Label] or return with an error message.
 */
 func (lbl *Label) Render() (html template.HTML, err error) {
+	return RenderHTML(lbl)
+}
+
+// [Label] template functions
+var labelFuncMap = map[string]any{
+	"styleMap": func(lbl *Label) bool {
+		return len(lbl.Style) > 0
+	},
+	"customClass": func(lbl *Label) string {
+		return strings.Join(lbl.Class, " ")
+	},
+	"labelComponent": func(lbl *Label, name string) (template.HTML, error) {
+		return lbl.getComponent(name)
+	},
+}
+
+/*
+Based on the values, it will write the html code of the [Label] into the writer or return with an error message.
+*/
+func (lbl *Label) RenderTo(w io.Writer) (err error) {
 	lbl.InitProps(lbl)
 
-	funcMap := map[string]any{
-		"styleMap": func() bool {
-			return len(lbl.Style) > 0
-		},
-		"customClass": func() string {
-			return strings.Join(lbl.Class, " ")
-		},
-		"labelComponent": func(name string) (template.HTML, error) {
-			return lbl.getComponent(name)
-		},
-	}
 	head := `id="{{ .Id }}" name="{{ .Name }}"
 	{{ if and (ne .EventURL "") (ne .Static true) }} hx-post="{{ .EventURL }}" hx-target="{{ .Target }}" {{ if ne .Sync "none" }} hx-sync="{{ .Sync }}"{{ end }} hx-swap="{{ .Swap }}"{{ end }}
 	{{ if ne .Indicator "none" }} hx-indicator="#{{ .Indicator }}"{{ end }}`
 	tpl := `{{ if or (ne .LeftIcon "") (ne .RightIcon "") }}<div ` + head + `
 	 class="label row{{ if .Border }} label-border{{ end }}{{ if .Full }} full{{ end }}
-	 {{ if and (ne .EventURL "") (ne .Static true) }} label-link{{ else }} label-text{{ end }}{{ if and (ne .LeftIcon "") (.Centered) }} centered{{ end }} {{ customClass }}"
+	 {{ if and (ne .EventURL "") (ne .Static true) }} label-link{{ else }} label-text{{ end }}{{ if and (ne .LeftIcon "") (.Centered) }} centered{{ end }} {{ customClass $ }}"
 	>{{ if ne .LeftIcon "" }}
-	<div class="cell label-icon-left">{{ labelComponent "left_icon" }}</div>
+	<div class="cell label-icon-left">{{ labelComponent $ "left_icon" }}</div>
 	<div class="cell label-info-left bold"
-	{{ if styleMap }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}
+	{{ if styleMap $ }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}
 	>{{ .Value }}</div>
 	{{ else }}
 	<div class="cell label-info-right bold"
-	{{ if styleMap }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}
+	{{ if styleMap $ }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}
 	>{{ .Value }}</div>
-	<div class="cell label-icon-right">{{ labelComponent "right_icon" }}</div>
+	<div class="cell label-icon-right">{{ labelComponent $ "right_icon" }}</div>
 	{{ end }}</div>
 	{{ else }}
 	{{ if .Border }}<div ` + head + ` class="label-border{{ if .Full }} full{{ end }}"><span {{ else }}<span ` + head + `{{ end }}
-	 class="label bold{{ if and (ne .EventURL "") (ne .Static true) }} label-link{{ else }} label-text{{ end }} {{ customClass }}"
-	 {{ if styleMap }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}
+	 class="label bold{{ if and (ne .EventURL "") (ne .Static true) }} label-link{{ else }} label-text{{ end }} {{ customClass $ }}"
+	 {{ if styleMap $ }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}
 	>{{ .Value }}</span>{{ if .Border }}</div>{{ end }}
 	{{ end }}`
 
-	if html, err = ut.TemplateBuilder("label", tpl, funcMap, lbl); err == nil && (lbl.EventURL != "" && !lbl.Static) {
+	if err = ut.CachedTemplateWriter(w, "label", tpl, labelFuncMap, lbl); err == nil && (lbl.EventURL != "" && !lbl.Static) {
 		lbl.SetProperty("request_map", lbl)
 	}
-	return html, nil
+	return nil
 }
 
 var testLblResponse func(evt ResponseEvent) (re ResponseEvent) = func(evt ResponseEvent) (re ResponseEvent) {
@@ -2,6 +2,7 @@ package component
 
 import (
 	"html/template"
+	"io"
 	"strings"
 
 	ut "github.com/nervatura/component/pkg/util"
@@ -264,19 +265,28 @@ func (lnk *Link) getComponent(name string) (template.HTML, error) {
 Based on the values, it will generate the html code of the [Link] or return with an error message.
 */
 func (lnk *Link) Render() (html template.HTML, err error) {
+	return RenderHTML(lnk)
+}
+
+// [Link] template functions
+var linkFuncMap = map[string]any{
+	"styleMap": func(lnk *Link) bool {
+		return len(lnk.Style) > 0
+	},
+	"customClass": func(lnk *Link) string {
+		return strings.Join(lnk.Class, " ")
+	},
+	"linkComponent": func(lnk *Link, name string) (template.HTML, error) {
+		return lnk.getComponent(name)
+	},
+}
+
+/*
+Based on the values, it will write the html code of the [Link] into the writer or return with an error message.
+*/
+func (lnk *Link) RenderTo(w io.Writer) (err error) {
 	lnk.InitProps(lnk)
 
-	funcMap := map[string]any{
-		"styleMap": func() bool {
-			return len(lnk.Style) > 0
-		},
-		"customClass": func() string {
-			return strings.Join(lnk.Class, " ")
-		},
-		"linkComponent": func(name string) (template.HTML, error) {
-			return lnk.getComponent(name)
-		},
-	}
 	tpl := `<a id="{{ .Id }}" name="{{ .Name }}" 
 	{{ if ne .Href "" }} href="{{ .Href }}" target="{{ .LinkTarget }}" referrerpolicy="{{ .ReferrerPolicy }}"{{ end }}
 	{{ if ne .Download "" }} download="{{ .Download }}" type="{{ .MediaType }}"{{ end }}
@@ -288,15 +298,15 @@ func (lnk *Link) Render() (html template.HTML, err error) {
 	{{ if .Disabled }} disabled{{ end }}
 	{{ if .AutoFocus }} autofocus{{ end }}
 	{{ if ne .Label "" }} aria-label="{{ .Label }}" title="{{ .Label }}"{{ end }}
-	 class="{{ .Align }}{{ if .Small }} small-button{{ end }}{{ if .Full }} full{{ end }}{{ if .Selected }} selected{{ end }}{{ if .HideLabel }} hidelabel{{ end }} {{ customClass }}"
-	{{ if styleMap }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}
-	>{{ if and (ne .Icon "") (ne .Align "right") }}{{ linkComponent "icon" }}{{ end }}
-	{{ if .LabelComponent }}{{ linkComponent "label" }}{{ else }}<span>{{ .Label }}</span>{{ end }}
-	{{ if and (ne .Icon "") (eq .Align "right") }}{{ linkComponent "icon" }}{{ end }}
+	 class="{{ .Align }}{{ if .Small }} small-button{{ end }}{{ if .Full }} full{{ end }}{{ if .Selected }} selected{{ end }}{{ if .HideLabel }} hidelabel{{ end }} {{ customClass $ }}"
+	{{ if styleMap $ }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}
+	>{{ if and (ne .Icon "") (ne .Align "right") }}{{ linkComponent $ "icon" }}{{ end }}
+	{{ if .LabelComponent }}{{ linkComponent $ "label" }}{{ else }}<span>{{ .Label }}</span>{{ end }}
+	{{ if and (ne .Icon "") (eq .Align "right") }}{{ linkComponent $ "icon" }}{{ end }}
 	{{ if and (ne .LinkStyle "") (.ShowBadge) }}<span class="right" ><span class="badge{{ if .Selected }} selected-badge{{ end }}" >{{ .Badge }}</span></span>{{ end }}
 	</a>`
 
-	return ut.TemplateBuilder("link", tpl, funcMap, lnk)
+	return ut.CachedTemplateWriter(w, "link", tpl, linkFuncMap, lnk)
 }
 
 // [Link] test and demo data
@@ -2,6 +2,7 @@ package component
 
 import (
 	"html/template"
+	"io"
 	"math"
 	"slices"
 	"strings"
@@ -296,7 +297,7 @@ func (lst *List) response(evt ResponseEvent) (re ResponseEvent) {
 	return lstEvt
 }
 
-func (lst *List) getComponent(name string, pageCount int64) (html template.HTML, err error) {
+func (lst *List) component(name string, pageCount int64) ClientComponent {
 	ccPgn := func() *Pagination {
 		return &Pagination{
 			BaseComponent: BaseComponent{
@@ -367,9 +368,7 @@ func (lst *List) getComponent(name string, pageCount int64) (html template.HTML,
 			}
 		},
 	}
-	cc := ccMap[name]()
-	html, err = cc.Render()
-	return html, err
+	return ccMap[name]()
 }
 
 func (lst *List) filterRows() (rows []ut.IM) {
@@ -414,95 +413,116 @@ func (lst *List) filterRows() (rows []ut.IM) {
 Based on the values, it will generate the html code of the [List] or return with an error message.
 */
 func (lst *List) Render() (html template.HTML, err error) {
+	return RenderHTML(lst)
+}
+
+// the render data of the [List] template
+type listRender struct {
+	*List
+	pageCount int64
+	rows      []ut.IM
+	hw        *ut.HTMLWriter
+}
+
+// [List] template functions
+var listFuncMap = map[string]any{
+	"styleMap": func(lst *listRender) bool {
+		return len(lst.Style) > 0
+	},
+	"customClass": func(lst *listRender) string {
+		return strings.Join(lst.Class, " ")
+	},
+	"topPagination": func(lst *listRender) bool {
+		return ((lst.pageCount > 1) && ((lst.Pagination == PaginationTypeTop) || lst.Pagination == PaginationTypeAll))
+	},
+	"bottomPagination": func(lst *listRender) bool {
+		return ((lst.pageCount > 1) && ((lst.Pagination == PaginationTypeBottom) || lst.Pagination == PaginationTypeAll))
+	},
+	"listComponent": func(lst *listRender, name string) (template.HTML, error) {
+		return "", RenderTo(lst.hw, lst.component(name, lst.pageCount))
+	},
+	"listRows": func(lst *listRender) []ut.IM {
+		if lst.Pagination != PaginationTypeNone {
+			currentPage := lst.Validation("current_page", lst.CurrentPage).(int64)
+			start := (currentPage - 1) * lst.PageSize
+			end := currentPage * lst.PageSize
+			if end > int64(len(lst.rows)) {
+				end = int64(len(lst.rows))
+			}
+			return lst.rows[start:end]
+		}
+		return lst.rows
+	},
+	"rowID": func(lst *listRender, row ut.IM, index int, event string) string {
+		rowID := lst.Id + "_row_" + event + "_" + ut.ToString(index, "")
+		lbl := &Label{BaseComponent: BaseComponent{
+			Id: rowID, Name: event, Data: ut.IM{
+				"row": row, "index": index,
+			},
+			OnResponse:   lst.response,
+			RequestValue: lst.RequestValue,
+			RequestMap:   lst.RequestMap,
+		}}
+		lbl.SetProperty("request_map", lbl)
+		return rowID
+	},
+	"isValue": func(row ut.IM, fieldName string) bool {
+		return (ut.ToString(row[fieldName], "") != "")
+	},
+	"rowValue": func(row ut.IM, fieldName string) string {
+		return ut.ToString(row[fieldName], "")
+	},
+	"showRows": func(lst *listRender) bool {
+		return len(lst.rows) > 0
+	},
+}
+
+/*
+Based on the values, it will write the html code of the [List] into the writer or return with an error message.
+*/
+func (lst *List) RenderTo(w io.Writer) (err error) {
 	lst.InitProps(lst)
 
 	rows := lst.filterRows()
 	pageCount := int64(math.Ceil(float64(len(rows)) / float64(lst.PageSize)))
 
-	funcMap := map[string]any{
-		"styleMap": func() bool {
-			return len(lst.Style) > 0
-		},
-		"customClass": func() string {
-			return strings.Join(lst.Class, " ")
-		},
-		"topPagination": func() bool {
-			return ((pageCount > 1) && ((lst.Pagination == PaginationTypeTop) || lst.Pagination == PaginationTypeAll))
-		},
-		"bottomPagination": func() bool {
-			return ((pageCount > 1) && ((lst.Pagination == PaginationTypeBottom) || lst.Pagination == PaginationTypeAll))
-		},
-		"listComponent": func(name string) (template.HTML, error) {
-			return lst.getComponent(name, pageCount)
-		},
-		"listRows": func() []ut.IM {
-			if lst.Pagination != PaginationTypeNone {
-				currentPage := lst.Validation("current_page", lst.CurrentPage).(int64)
-				start := (currentPage - 1) * lst.PageSize
-				end := currentPage * lst.PageSize
-				if end > int64(len(rows)) {
-					end = int64(len(rows))
-				}
-				return rows[start:end]
-			}
-			return rows
-		},
-		"rowID": func(row ut.IM, index int, event string) string {
-			rowID := lst.Id + "_row_" + event + "_" + ut.ToString(index, "")
-			lbl := &Label{BaseComponent: BaseComponent{
-				Id: rowID, Name: event, Data: ut.IM{
-					"row": row, "index": index,
-				},
-				OnResponse:   lst.response,
-				RequestValue: lst.RequestValue,
-				RequestMap:   lst.RequestMap,
-			}}
-			lbl.SetProperty("request_map", lbl)
-			return rowID
-		},
-		"isValue": func(row ut.IM, fieldName string) bool {
-			return (ut.ToString(row[fieldName], "") != "")
-		},
-		"rowValue": func(row ut.IM, fieldName string) string {
-			return ut.ToString(row[fieldName], "")
-		},
-		"showRows": func() bool {
-			return len(rows) > 0
-		},
-	}
-	tpl := `<div id="{{ .Id }}" name="{{ .Name }}" class="responsive {{ customClass }}">
-	{{ if or .ListFilter topPagination }}<div>
-	{{ if topPagination }}<div>{{ listComponent "top_pagination" }}</div>{{ end }}
+	tpl := `<div id="{{ .Id }}" name="{{ .Name }}" class="responsive {{ customClass $ }}">
+	{{ if or .ListFilter (topPagination $) }}<div>
+	{{ if topPagination $ }}<div>{{ listComponent $ "top_pagination" }}</div>{{ end }}
 	{{ if .ListFilter }}<div class="row full">
-	<div class="cell" >{{ listComponent "filter" }}</div>
-	{{ if .AddItem }}<div class="cell" style="width: 20px;" >{{ listComponent "btn_add" }}</div>{{ end }}
+	<div class="cell" >{{ listComponent $ "filter" }}</div>
+	{{ if .AddItem }}<div class="cell" style="width: 20px;" >{{ listComponent $ "btn_add" }}</div>{{ end }}
 	</div>{{ end }}</div>{{ end }}
-	{{ if showRows }}<ul class="list"
-	{{ if styleMap }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}>
-	{{ range $index, $row := listRows }}
+	{{ if showRows $ }}<ul class="list"
+	{{ if styleMap $ }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}>
+	{{ range $index, $row := listRows $ }}
 	<li class="list-row border-bottom">
-	{{ if $.EditItem }}<div id="{{ rowID $row $index "edit_item" }}" class="list-edit-cell" 
+	{{ if $.EditItem }}<div id="{{ rowID $ $row $index "edit_item" }}" class="list-edit-cell" 
 	{{ if ne $.EventURL "" }} hx-post="{{ $.EventURL }}" hx-target="{{ $.Target }}" {{ if ne $.Sync "none" }} hx-sync="{{ $.Sync }}"{{ end }} hx-swap="{{ $.Swap }}"{{ end }}
 	{{ if ne $.Indicator "none" }} hx-indicator="#{{ $.Indicator }}"{{ end }}
-	>{{ listComponent "edit_icon" }}</div>{{ end }}
-	<div id="{{ rowID $row $index "edit_item" }}" class="list-value-cell {{ if $.EditItem }} cursor-pointer{{ end }}" 
+	>{{ listComponent $ "edit_icon" }}</div>{{ end }}
+	<div id="{{ rowID $ $row $index "edit_item" }}" class="list-value-cell {{ if $.EditItem }} cursor-pointer{{ end }}" 
 	{{ if $.EditItem }}{{ if ne $.EventURL "" }} hx-post="{{ $.EventURL }}" hx-target="{{ $.Target }}" {{ if ne $.Sync "none" }} hx-sync="{{ $.Sync }}"{{ end }} hx-swap="{{ $.Swap }}"{{ end }}
 	{{ if ne $.Indicator "none" }} hx-indicator="#{{ $.Indicator }}"{{ end }}{{ end }}
 	>
 	{{ if isValue $row $.LabelField }}<div class="border-bottom list-label" ><span>{{ rowValue $row $.LabelField }}</span></div>{{ end }}
   {{ if isValue $row $.LabelValue }}<div class="list-value" ><span>{{ rowValue $row $.LabelValue }}</span></div>{{ end }}
 	</div>
-	{{ if $.DeleteItem }}<div id="{{ rowID $row $index "delete_item" }}" class="list-delete-cell" 
+	{{ if $.DeleteItem }}<div id="{{ rowID $ $row $index "delete_item" }}" class="list-delete-cell" 
 	{{ if ne $.EventURL "" }} hx-post="{{ $.EventURL }}" hx-target="{{ $.Target }}" {{ if ne $.Sync "none" }} hx-sync="{{ $.Sync }}"{{ end }} hx-swap="{{ $.Swap }}"{{ end }}
 	{{ if ne $.Indicator "none" }} hx-indicator="#{{ $.Indicator }}"{{ end }}
-	>{{ listComponent "delete_icon" }}</div>{{ end }}
+	>{{ listComponent $ "delete_icon" }}</div>{{ end }}
 	</li>
 	{{ end }}
 	</ul>{{ end }}
-	{{ if bottomPagination }}<div>{{ listComponent "bottom_pagination" }}</div>{{ end }}
+	{{ if bottomPagination $ }}<div>{{ listComponent $ "bottom_pagination" }}</div>{{ end }}
 	</div>`
 
-	return ut.TemplateBuilder("list", tpl, funcMap, lst)
+	hw := ut.NewHTMLWriter(w)
+	if err = ut.CachedTemplateWriter(hw, "list", tpl, listFuncMap, &listRender{List: lst, pageCount: pageCount, rows: rows, hw: hw}); err == nil {
+		err = hw.Close()
+	}
+	return err
 }
 
 var testListRows []ut.IM = []ut.IM{
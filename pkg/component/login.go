@@ -3,6 +3,7 @@ package component
 import (
 	"fmt"
 	"html/template"
+	"io"
 	"strings"
 
 	ut "github.com/nervatura/component/pkg/util"
@@ -30,10 +31,6 @@ var loginDefaultLabel ut.SM = ut.SM{
 	"login_help":     "Help",
 }
 
-var loginThemeMap map[string][]string = map[string][]string{
-	ThemeDark: {ThemeLight, "Sun"}, ThemeLight: {ThemeDark, "Moon"},
-}
-
 /*
 Creates an application login control
 
@@ -76,7 +73,7 @@ type Login struct {
 	HidePassword bool `json:"hide_password"`
 	/*
 		The theme of the control.
-		[Theme] variable constants: [ThemeLight], [ThemeDark]. Default value: [ThemeLight]
+		Any registered [Theme] value, see more [RegisterTheme]. Default value: [ThemeLight]
 	*/
 	Theme string `json:"theme"`
 	// The texts of the labels of the controls
@@ -266,7 +263,7 @@ func (lgn *Login) response(evt ResponseEvent) (re ResponseEvent) {
 
 	case "theme":
 		lgnEvt.Name = LoginEventTheme
-		lgn.SetProperty("theme", loginThemeMap[lgn.Theme][0])
+		lgn.SetProperty("theme", ThemeNext(lgn.Theme))
 
 	case "auth":
 		value := ut.ToString(evt.Trigger.GetProperty("data").(ut.IM)["id"], "")
@@ -288,7 +285,7 @@ func (lgn *Login) response(evt ResponseEvent) (re ResponseEvent) {
 	return lgnEvt
 }
 
-func (lgn *Login) getComponent(name string, authIdx int) (html template.HTML, err error) {
+func (lgn *Login) component(name string, authIdx int) ClientComponent {
 	ccInp := func(itype string, required, focus bool) *Input {
 		inp := &Input{
 			BaseComponent: BaseComponent{
@@ -375,7 +372,7 @@ func (lgn *Login) getComponent(name string, authIdx int) (html template.HTML, er
 				},
 				ButtonStyle:    ButtonStyleBorder,
 				Label:          lgn.Labels["login_"+name],
-				LabelComponent: &Icon{Value: loginThemeMap[lgn.Theme][1], Width: 18, Height: 18},
+				LabelComponent: &Icon{Value: ThemeIcon(ThemeNext(lgn.Theme)), Width: 18, Height: 18},
 			}
 		},
 		"help": func() ClientComponent {
@@ -423,9 +420,7 @@ func (lgn *Login) getComponent(name string, authIdx int) (html template.HTML, er
 			}
 		},
 	}
-	cc := ccMap[name]()
-	html, err = cc.Render()
-	return html, err
+	return ccMap[name]()
 }
 
 func (lgn *Login) msg(labelID string) string {
@@ -439,92 +434,111 @@ func (lgn *Login) msg(labelID string) string {
 Based on the values, it will generate the html code of the [Login] or return with an error message.
 */
 func (lgn *Login) Render() (html template.HTML, err error) {
+	return RenderHTML(lgn)
+}
+
+// the render data of the [Login] template
+type loginRender struct {
+	*Login
+	hw *ut.HTMLWriter
+}
+
+// [Login] template functions
+var loginFuncMap = map[string]any{
+	"msg": func(lgn *loginRender, labelID string) string {
+		return lgn.msg(labelID)
+	},
+	"styleMap": func(lgn *loginRender) bool {
+		return len(lgn.Style) > 0
+	},
+	"customClass": func(lgn *loginRender) string {
+		return strings.Join(lgn.Class, " ")
+	},
+	"loginComponent": func(lgn *loginRender, name string) (template.HTML, error) {
+		return "", RenderTo(lgn.hw, lgn.component(name, 0))
+	},
+	"authBtn": func(lgn *loginRender, idx int) (template.HTML, error) {
+		return "", RenderTo(lgn.hw, lgn.component("auth", idx))
+	},
+	"even": func(idx int) bool {
+		return (idx%2 == 0)
+	},
+	"odd": func(lgn *loginRender, idx int) bool {
+		return !(idx%2 == 0) || (len(lgn.AuthButtons)-1 == idx)
+	},
+	"buttons": func(lgn *loginRender) bool {
+		return len(lgn.AuthButtons) > 0
+	},
+}
+
+/*
+Based on the values, it will write the html code of the [Login] into the writer or return with an error message.
+*/
+func (lgn *Login) RenderTo(w io.Writer) (err error) {
 	lgn.InitProps(lgn)
 
-	funcMap := map[string]any{
-		"msg": func(labelID string) string {
-			return lgn.msg(labelID)
-		},
-		"styleMap": func() bool {
-			return len(lgn.Style) > 0
-		},
-		"customClass": func() string {
-			return strings.Join(lgn.Class, " ")
-		},
-		"loginComponent": func(name string) (template.HTML, error) {
-			return lgn.getComponent(name, 0)
-		},
-		"authBtn": func(idx int) (template.HTML, error) {
-			return lgn.getComponent("auth", idx)
-		},
-		"even": func(idx int) bool {
-			return (idx%2 == 0)
-		},
-		"odd": func(idx int) bool {
-			return !(idx%2 == 0) || (len(lgn.AuthButtons)-1 == idx)
-		},
-		"buttons": func() bool {
-			return len(lgn.AuthButtons) > 0
-		},
-	}
-	tpl := `<div id="{{ .Id }}" class="login-modal {{ customClass }}" theme="{{ .Theme }}" 
-	{{ if styleMap }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}>
+	tpl := `<div id="{{ .Id }}" class="login-modal {{ customClass $ }}" theme="{{ .Theme }}" 
+	{{ if styleMap $ }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}>
 	<div class="middle"><div class="dialog"><form id="{{ .Id }}" name="login_form"
 	{{ if ne .EventURL "" }} hx-post="{{ .EventURL }}" hx-target="{{ .Target }}" {{ if ne .Sync "none" }} hx-sync="{{ .Sync }}"{{ end }} hx-swap="{{ .Swap }}"{{ end }}
 	{{ if ne .Indicator "none" }} hx-indicator="#{{ .Indicator }}"{{ end }}>
 	<div class="row title">
-	<div class="cell title-cell login-title-cell" ><span>{{ msg "title_login" }}</span></div>
+	<div class="cell title-cell login-title-cell" ><span>{{ msg $ "title_login" }}</span></div>
 	<div class="cell version-cell" ><span>{{ .Version }}</span></div>
 	</div>
 	{{ if ne .HidePassword true }}
 	<div class="row full section-small" >
 	<div class="row full section-small" >
-	<div class="cell label-cell padding-normal mobile" >{{ loginComponent "login_username" }}</div>
-	<div class="cell container mobile" >{{ loginComponent "username" }}</div>
+	<div class="cell label-cell padding-normal mobile" >{{ loginComponent $ "login_username" }}</div>
+	<div class="cell container mobile" >{{ loginComponent $ "username" }}</div>
 	</div>
 	<div class="row full {{ if .HideDatabase }}section-small-bottom{{ end }}" >
-	<div class="cell label-cell padding-normal mobile" >{{ loginComponent "login_password" }}</div>
-	<div class="cell container mobile" >{{ loginComponent "password" }}</div>
+	<div class="cell label-cell padding-normal mobile" >{{ loginComponent $ "login_password" }}</div>
+	<div class="cell container mobile" >{{ loginComponent $ "password" }}</div>
 	</div>
 	{{ if ne .HideDatabase true }}
 	<div class="row full section-small" >
-	<div class="cell label-cell padding-normal mobile" >{{ loginComponent "login_database" }}</div>
-	<div class="cell container mobile" >{{ loginComponent "database" }}</div>
+	<div class="cell label-cell padding-normal mobile" >{{ loginComponent $ "login_database" }}</div>
+	<div class="cell container mobile" >{{ loginComponent $ "database" }}</div>
 	</div>
 	{{ end }}
 	</div>
 	{{ end }}
-	{{ if buttons }}<div class="row full section border-top" >
+	{{ if buttons $ }}<div class="row full section border-top" >
 	{{ range $index, $auth := .AuthButtons }}
 	{{ if even $index }}<div class="row full container-small section-small" >{{ end }}
-	<div class="cell container-small mobile" >{{ authBtn $index }}</div>
-	{{ if odd $index }}</div>{{ end }}
+	<div class="cell container-small mobile" >{{ authBtn $ $index }}</div>
+	{{ if odd $ $index }}</div>{{ end }}
 	{{ end }}
 	</div>{{ end }}
   <div class="row full section buttons" >
 	{{ if ne .HidePassword true }}<div class="cell section-small mobile" >{{ end }}
 	<div class="cell container-left align-right" >
-	{{ loginComponent "theme" }}
+	{{ loginComponent $ "theme" }}
 	</div>
 	<div class="cell container-left" >
-	{{ loginComponent "lang" }}
+	{{ loginComponent $ "lang" }}
 	</div>
 	{{ if .ShowHelp }} <div class="cell container-left" >
-	{{ loginComponent "help" }}
+	{{ loginComponent $ "help" }}
 	</div>{{ end }}
 	{{ if ne .HidePassword true }}</div>{{ end }}
 	{{ if ne .HidePassword true }}
 	<div class="cell container section-small align-right mobile" >
-	{{ loginComponent "login" }}
+	{{ loginComponent $ "login" }}
 	</div>
 	{{ end }}
 	</div>
 	</form></div></div></div>`
 
-	if html, err = ut.TemplateBuilder("login", tpl, funcMap, lgn); err == nil && lgn.EventURL != "" {
+	hw := ut.NewHTMLWriter(w)
+	if err = ut.CachedTemplateWriter(hw, "login", tpl, loginFuncMap, &loginRender{Login: lgn, hw: hw}); err == nil {
+		err = hw.Close()
+	}
+	if err == nil && lgn.EventURL != "" {
 		lgn.SetProperty("request_map", lgn)
 	}
-	return html, err
+	return err
 }
 
 var testLoginLabels map[string]ut.SM = map[string]ut.SM{
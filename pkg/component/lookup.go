@@ -0,0 +1,380 @@
+package component
+
+import (
+	"cmp"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	ut "github.com/nervatura/component/pkg/util"
+)
+
+// [LookupProvider] constants
+const (
+	// The RequestValue key of the session lookup cache
+	LookupCacheKey = "lookup_cache"
+	// The maximum number of the loaded search rows and options
+	LookupDefaultLimit = 100
+	// The debounce delay of the type-ahead search in milliseconds
+	LookupDefaultDelay = 300
+	// The maximum number of the cached lookup results in a session
+	LookupCacheSize = 100
+)
+
+// The validity of the cached lookup results. Zero value disables the caching.
+var LookupCacheTimeout time.Duration = 5 * time.Minute
+
+/*
+LookupProvider is an interface that defines the reference data methods of a lookup. The registered providers
+can be bound by name to the [Selector], the [Select] options and the [TableField] options.
+*/
+type LookupProvider interface {
+	// The displayed columns of the [Selector] search result table.
+	Fields() []TableField
+	// Returns the rows that match the filter text. The offset and limit values are the paging parameters.
+	Search(text string, offset, limit int64) (rows []ut.IM, err error)
+	// Returns the option of the key value.
+	Resolve(key string) (option SelectOption, err error)
+	// Converts a search result row to an option.
+	Option(row ut.IM) SelectOption
+}
+
+// The registered lookup providers. See more [RegisterLookup] function.
+var LookupMap map[string]LookupProvider = map[string]LookupProvider{}
+
+/*
+The RegisterLookup function adds a lookup provider to the [LookupMap]. The providers should be registered
+before the first request is served.
+*/
+func RegisterLookup(name string, provider LookupProvider) {
+	LookupMap[name] = provider
+}
+
+func lookupProvider(name string) (provider LookupProvider, err error) {
+	if provider, found := LookupMap[name]; found {
+		return provider, nil
+	}
+	return provider, fmt.Errorf("unknown lookup: %s", name)
+}
+
+func lookupCache(requestValue map[string]ut.IM, cacheKey string, result any, load func() (any, error)) (err error) {
+	if requestValue == nil || LookupCacheTimeout == 0 {
+		var value any
+		if value, err = load(); err == nil {
+			err = ut.ConvertToType(value, result)
+		}
+		return err
+	}
+	if _, found := requestValue[LookupCacheKey]; !found {
+		requestValue[LookupCacheKey] = ut.IM{}
+	}
+	cache := requestValue[LookupCacheKey]
+	if entry, found := cache[cacheKey].(ut.IM); found &&
+		time.Now().Unix()-ut.ToInteger(entry["stamp"], 0) < int64(LookupCacheTimeout.Seconds()) {
+		return ut.ConvertToType(entry["value"], result)
+	}
+	var value any
+	if value, err = load(); err == nil {
+		lookupCachePrune(cache)
+		cache[cacheKey] = ut.IM{"stamp": time.Now().Unix(), "value": value}
+		err = ut.ConvertToType(value, result)
+	}
+	return err
+}
+
+// lookupCachePrune removes the expired entries and the oldest entries above the LookupCacheSize limit
+func lookupCachePrune(cache ut.IM) {
+	stamp := func(key string) int64 {
+		return ut.ToInteger(ut.ToIM(cache[key], ut.IM{})["stamp"], 0)
+	}
+	expired := time.Now().Unix() - int64(LookupCacheTimeout.Seconds())
+	keys := []string{}
+	for key := range cache {
+		if stamp(key) <= expired {
+			delete(cache, key)
+		} else {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) >= LookupCacheSize {
+		slices.SortFunc(keys, func(a, b string) int {
+			return cmp.Compare(stamp(a), stamp(b))
+		})
+		for _, key := range keys[:len(keys)-LookupCacheSize+1] {
+			delete(cache, key)
+		}
+	}
+}
+
+/*
+The LookupSearch function returns the search result rows of the named lookup provider. The results are
+cached in the session RequestValue map for [LookupCacheTimeout].
+*/
+func LookupSearch(requestValue map[string]ut.IM, name, text string, offset, limit int64) (rows []ut.IM, err error) {
+	var provider LookupProvider
+	if provider, err = lookupProvider(name); err != nil {
+		return rows, err
+	}
+	rows = []ut.IM{}
+	cacheKey := fmt.Sprintf("%s|search|%s|%d|%d", name, strings.ToLower(text), offset, limit)
+	err = lookupCache(requestValue, cacheKey, &rows, func() (any, error) {
+		return provider.Search(text, offset, limit)
+	})
+	return rows, err
+}
+
+/*
+The LookupResolve function returns the option of the key value by the named lookup provider. The results are
+cached in the session RequestValue map for [LookupCacheTimeout].
+*/
+func LookupResolve(requestValue map[string]ut.IM, name, key string) (option SelectOption, err error) {
+	var provider LookupProvider
+	if provider, err = lookupProvider(name); err != nil {
+		return option, err
+	}
+	err = lookupCache(requestValue, name+"|key|"+key, &option, func() (any, error) {
+		return provider.Resolve(key)
+	})
+	return option, err
+}
+
+/*
+The LookupOptions function returns the first [LookupDefaultLimit] rows of the named lookup provider as
+select options. In case of an error, the result is an empty list.
+*/
+func LookupOptions(requestValue map[string]ut.IM, name string) (options []SelectOption) {
+	options = []SelectOption{}
+	if rows, err := LookupSearch(requestValue, name, "", 0, LookupDefaultLimit); err == nil {
+		provider := LookupMap[name]
+		for _, row := range rows {
+			options = append(options, provider.Option(row))
+		}
+	}
+	return options
+}
+
+/*
+The LookupClearCache function removes the cached results of the named lookup provider from the session.
+If the name is empty, the whole lookup cache is cleared.
+*/
+func LookupClearCache(requestValue map[string]ut.IM, name string) {
+	cache, found := requestValue[LookupCacheKey]
+	if !found {
+		return
+	}
+	for key := range cache {
+		if name == "" || strings.HasPrefix(key, name+"|") {
+			delete(cache, key)
+		}
+	}
+}
+
+/*
+LookupRows is an in-memory [LookupProvider]. It can be used for small, static code lists.
+*/
+type LookupRows struct {
+	// The reference data rows
+	Rows []ut.IM `json:"rows"`
+	// The field name of the key value. Default value: "id"
+	KeyField string `json:"key_field"`
+	// The field name of the displayed text
+	TextField string `json:"text_field"`
+	// The displayed columns. Default value: the text field
+	Columns []TableField `json:"columns"`
+}
+
+func (lr *LookupRows) Fields() []TableField {
+	if len(lr.Columns) > 0 {
+		return lr.Columns
+	}
+	return []TableField{{Name: lr.TextField, Label: lr.TextField}}
+}
+
+/*
+The Search function returns the rows where any displayed column contains the filter text (case-insensitive).
+*/
+func (lr *LookupRows) Search(text string, offset, limit int64) (rows []ut.IM, err error) {
+	rows = []ut.IM{}
+	text = strings.ToLower(text)
+	for _, row := range lr.Rows {
+		if slices.ContainsFunc(lr.Fields(), func(field TableField) bool {
+			return strings.Contains(strings.ToLower(ut.ToString(row[field.Name], "")), text)
+		}) {
+			rows = append(rows, row)
+		}
+	}
+	if offset >= int64(len(rows)) {
+		return []ut.IM{}, nil
+	}
+	return rows[offset:min(offset+limit, int64(len(rows)))], nil
+}
+
+func (lr *LookupRows) Resolve(key string) (option SelectOption, err error) {
+	for _, row := range lr.Rows {
+		if option = lr.Option(row); option.Value == key {
+			return option, nil
+		}
+	}
+	return SelectOption{}, fmt.Errorf("not found: %s", key)
+}
+
+func (lr *LookupRows) Option(row ut.IM) SelectOption {
+	return SelectOption{
+		Value: ut.ToString(row[ut.ToString(lr.KeyField, "id")], ""),
+		Text:  ut.ToString(row[lr.TextField], ""),
+	}
+}
+
+/*
+LookupSQL is a database/sql based [LookupProvider]. The table and field names are part of the
+SQL statements, the filter values are passed as query parameters. The result values are returned as strings.
+*/
+type LookupSQL struct {
+	// Database connection
+	DB *sql.DB `json:"-"`
+	// The database driver name. It sets the query parameter style: postgres ($1), mssql (@p1), others (?)
+	DriverName string `json:"driver_name"`
+	// The table or view name
+	Table string `json:"table"`
+	// The field name of the key value. Default value: "id"
+	KeyField string `json:"key_field"`
+	// The field name of the displayed text
+	TextField string `json:"text_field"`
+	// The filtered field names of the search. Default value: the text field
+	SearchFields []string `json:"search_fields"`
+	// The displayed columns. Default value: the text field
+	Columns []TableField `json:"columns"`
+	// Optional static filter condition. Example: "deleted = 0"
+	Where string `json:"where"`
+}
+
+func (ls *LookupSQL) keyField() string {
+	return ut.ToString(ls.KeyField, "id")
+}
+
+func (ls *LookupSQL) param(index int) string {
+	switch ls.DriverName {
+	case "postgres":
+		return fmt.Sprintf("$%d", index)
+	case "mssql":
+		return fmt.Sprintf("@p%d", index)
+	default:
+		return "?"
+	}
+}
+
+// escapeLike escapes the wildcard characters of the LIKE pattern with the '!' escape character
+func (ls *LookupSQL) escapeLike(text string) string {
+	chars := []string{"!", "!!", "%", "!%", "_", "!_"}
+	if ls.DriverName == "mssql" {
+		chars = append(chars, "[", "![")
+	}
+	return strings.NewReplacer(chars...).Replace(text)
+}
+
+func (ls *LookupSQL) query(sqlString string, params ...any) (rows []ut.IM, err error) {
+	rows = []ut.IM{}
+	var result *sql.Rows
+	if result, err = ls.DB.Query(sqlString, params...); err != nil {
+		return rows, err
+	}
+	defer result.Close()
+	cols, _ := result.Columns()
+	for result.Next() {
+		values := make([]sql.NullString, len(cols))
+		pointers := make([]any, len(cols))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err = result.Scan(pointers...); err != nil {
+			return []ut.IM{}, err
+		}
+		row := ut.IM{}
+		for i, col := range cols {
+			row[col] = values[i].String
+		}
+		rows = append(rows, row)
+	}
+	if err = result.Err(); err != nil {
+		return []ut.IM{}, err
+	}
+	return rows, nil
+}
+
+func (ls *LookupSQL) Fields() []TableField {
+	if len(ls.Columns) > 0 {
+		return ls.Columns
+	}
+	return []TableField{{Name: ls.TextField, Label: ls.TextField}}
+}
+
+/*
+The SQL function returns the search statement and its parameters. The LIKE wildcard characters of the filter text are escaped.
+*/
+func (ls *LookupSQL) SQL(text string, offset, limit int64) (sqlString string, params []any) {
+	cols := []string{ls.keyField(), ls.TextField}
+	for _, field := range ls.Fields() {
+		if !slices.Contains(cols, field.Name) {
+			cols = append(cols, field.Name)
+		}
+	}
+	where := []string{}
+	if ls.Where != "" {
+		where = append(where, "("+ls.Where+")")
+	}
+	if text != "" {
+		searchFields := ls.SearchFields
+		if len(searchFields) == 0 {
+			searchFields = []string{ls.TextField}
+		}
+		filters := []string{}
+		for _, field := range searchFields {
+			params = append(params, "%"+ls.escapeLike(strings.ToLower(text))+"%")
+			filters = append(filters, fmt.Sprintf("LOWER(%s) LIKE %s ESCAPE '!'", field, ls.param(len(params))))
+		}
+		where = append(where, "("+strings.Join(filters, " OR ")+")")
+	}
+	sqlString = fmt.Sprintf("SELECT %s FROM %s", strings.Join(cols, ", "), ls.Table)
+	if len(where) > 0 {
+		sqlString += " WHERE " + strings.Join(where, " AND ")
+	}
+	sqlString += " ORDER BY " + ls.TextField
+	if ls.DriverName == "mssql" {
+		return sqlString + fmt.Sprintf(" OFFSET %d ROWS FETCH NEXT %d ROWS ONLY", offset, limit), params
+	}
+	return sqlString + fmt.Sprintf(" LIMIT %d OFFSET %d", limit, offset), params
+}
+
+func (ls *LookupSQL) Search(text string, offset, limit int64) (rows []ut.IM, err error) {
+	sqlString, params := ls.SQL(text, offset, limit)
+	return ls.query(sqlString, params...)
+}
+
+func (ls *LookupSQL) resolveSQL() (sqlString string) {
+	sqlString = fmt.Sprintf("SELECT %s, %s FROM %s WHERE %s = %s",
+		ls.keyField(), ls.TextField, ls.Table, ls.keyField(), ls.param(1))
+	if ls.Where != "" {
+		sqlString += " AND (" + ls.Where + ")"
+	}
+	return sqlString
+}
+
+func (ls *LookupSQL) Resolve(key string) (option SelectOption, err error) {
+	var rows []ut.IM
+	if rows, err = ls.query(ls.resolveSQL(), key); err == nil && len(rows) == 0 {
+		err = fmt.Errorf("not found: %s", key)
+	}
+	if err != nil {
+		return option, err
+	}
+	return ls.Option(rows[0]), err
+}
+
+func (ls *LookupSQL) Option(row ut.IM) SelectOption {
+	return SelectOption{
+		Value: ut.ToString(row[ls.keyField()], ""),
+		Text:  ut.ToString(row[ls.TextField], ""),
+	}
+}
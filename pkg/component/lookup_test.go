@@ -0,0 +1,212 @@
+package component
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	ut "github.com/nervatura/component/pkg/util"
+	_ "github.com/nervatura/component/test/sqltest"
+)
+
+var testLookupRows *LookupRows = &LookupRows{
+	Rows: []ut.IM{
+		{"id": "1", "name": "Red", "code": "R"},
+		{"id": "2", "name": "Green", "code": "G"},
+		{"id": "3", "name": "Blue", "code": "B"},
+	},
+	TextField: "name",
+}
+
+func TestLookupRows(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		offset int64
+		limit  int64
+		want   int
+	}{
+		{name: "all", limit: 10, want: 3},
+		{name: "filter", text: "gR", limit: 10, want: 1},
+		{name: "limit", limit: 2, want: 2},
+		{name: "offset", offset: 2, limit: 10, want: 1},
+		{name: "out_of_range", offset: 5, limit: 10, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := testLookupRows.Search(tt.text, tt.offset, tt.limit)
+			if err != nil || len(rows) != tt.want {
+				t.Errorf("LookupRows.Search() = %v, %v, want %v", rows, err, tt.want)
+			}
+		})
+	}
+	if option, err := testLookupRows.Resolve("2"); err != nil || option.Text != "Green" {
+		t.Errorf("LookupRows.Resolve() = %v, %v", option, err)
+	}
+	if _, err := testLookupRows.Resolve("9"); err == nil {
+		t.Error("LookupRows.Resolve() error = nil")
+	}
+	columns := &LookupRows{Columns: []TableField{{Name: "code"}, {Name: "name"}}}
+	if fields := columns.Fields(); len(fields) != 2 {
+		t.Errorf("LookupRows.Fields() = %v", fields)
+	}
+}
+
+func TestLookupSQL_SQL(t *testing.T) {
+	tests := []struct {
+		name       string
+		provider   LookupSQL
+		text       string
+		wantSQL    string
+		wantParams []any
+	}{
+		{
+			name:     "default",
+			provider: LookupSQL{Table: "color", TextField: "name"},
+			wantSQL:  "SELECT id, name FROM color ORDER BY name LIMIT 10 OFFSET 0",
+		},
+		{
+			name: "postgres",
+			provider: LookupSQL{DriverName: "postgres", Table: "color", KeyField: "code", TextField: "name",
+				SearchFields: []string{"name", "code"}, Where: "deleted = 0",
+				Columns: []TableField{{Name: "code"}, {Name: "name"}, {Name: "hex"}}},
+			text: "Red",
+			wantSQL: "SELECT code, name, hex FROM color WHERE (deleted = 0) AND " +
+				"(LOWER(name) LIKE $1 ESCAPE '!' OR LOWER(code) LIKE $2 ESCAPE '!') ORDER BY name LIMIT 10 OFFSET 0",
+			wantParams: []any{"%red%", "%red%"},
+		},
+		{
+			name:       "mssql",
+			provider:   LookupSQL{DriverName: "mssql", Table: "color", TextField: "name"},
+			text:       "[red]",
+			wantSQL:    "SELECT id, name FROM color WHERE (LOWER(name) LIKE @p1 ESCAPE '!') ORDER BY name OFFSET 0 ROWS FETCH NEXT 10 ROWS ONLY",
+			wantParams: []any{"%![red]%"},
+		},
+		{
+			name:       "sqlite",
+			provider:   LookupSQL{DriverName: "sqlite", Table: "color", TextField: "name"},
+			text:       "10%_red!",
+			wantSQL:    "SELECT id, name FROM color WHERE (LOWER(name) LIKE ? ESCAPE '!') ORDER BY name LIMIT 10 OFFSET 0",
+			wantParams: []any{"%10!%!_red!!%"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlString, params := tt.provider.SQL(tt.text, 0, 10)
+			if sqlString != tt.wantSQL {
+				t.Errorf("LookupSQL.SQL() sqlString = %v, want %v", sqlString, tt.wantSQL)
+			}
+			if !reflect.DeepEqual(params, tt.wantParams) {
+				t.Errorf("LookupSQL.SQL() params = %v, want %v", params, tt.wantParams)
+			}
+		})
+	}
+}
+
+func TestLookupSQL_resolveSQL(t *testing.T) {
+	provider := LookupSQL{DriverName: "postgres", Table: "color", TextField: "name"}
+	if sqlString := provider.resolveSQL(); sqlString != "SELECT id, name FROM color WHERE id = $1" {
+		t.Errorf("LookupSQL.resolveSQL() = %v", sqlString)
+	}
+	provider.Where = "deleted = 0"
+	if sqlString := provider.resolveSQL(); sqlString != "SELECT id, name FROM color WHERE id = $1 AND (deleted = 0)" {
+		t.Errorf("LookupSQL.resolveSQL() = %v", sqlString)
+	}
+}
+
+func TestLookupSQL(t *testing.T) {
+	lookupDB := func(dsn string) *LookupSQL {
+		db, _ := sql.Open("sqltest", dsn)
+		return &LookupSQL{DB: db, Table: "color", KeyField: "value", TextField: "value"}
+	}
+	if rows, err := lookupDB("").Search("red", 0, 10); err != nil || len(rows) != 1 {
+		t.Errorf("LookupSQL.Search() = %v, %v", rows, err)
+	}
+	for _, dsn := range []string{"query_error", "scan_error", "next_error"} {
+		if rows, err := lookupDB(dsn).Search("", 0, 10); err == nil || len(rows) != 0 {
+			t.Errorf("LookupSQL.Search() %s = %v, %v", dsn, rows, err)
+		}
+	}
+	if option, err := lookupDB("").Resolve("{}"); err != nil || option.Text != "{}" {
+		t.Errorf("LookupSQL.Resolve() = %v, %v", option, err)
+	}
+	if _, err := lookupDB("not_found").Resolve("1"); err == nil {
+		t.Error("LookupSQL.Resolve() error = nil")
+	}
+	if _, err := lookupDB("query_error").Resolve("1"); err == nil {
+		t.Error("LookupSQL.Resolve() error = nil")
+	}
+}
+
+func TestLookupCache(t *testing.T) {
+	RegisterLookup("test_color", testLookupRows)
+	requestValue := map[string]ut.IM{}
+
+	if rows, err := LookupSearch(requestValue, "test_color", "", 0, 10); err != nil || len(rows) != 3 {
+		t.Errorf("LookupSearch() = %v, %v", rows, err)
+	}
+	if _, found := requestValue[LookupCacheKey]["test_color|search||0|10"]; !found {
+		t.Error("LookupSearch() result is not cached")
+	}
+	// cached result
+	requestValue[LookupCacheKey]["test_color|search||0|100"] = ut.IM{
+		"stamp": time.Now().Unix(), "value": []ut.IM{{"id": "9", "name": "Cached"}}}
+	if options := LookupOptions(requestValue, "test_color"); len(options) != 1 || options[0].Text != "Cached" {
+		t.Errorf("LookupOptions() = %v", options)
+	}
+	// expired result
+	requestValue[LookupCacheKey]["test_color|search||0|100"] = ut.IM{"stamp": 0, "value": []ut.IM{}}
+	if options := LookupOptions(requestValue, "test_color"); len(options) != 3 {
+		t.Errorf("LookupOptions() = %v", options)
+	}
+	if option, err := LookupResolve(requestValue, "test_color", "3"); err != nil || option.Text != "Blue" {
+		t.Errorf("LookupResolve() = %v, %v", option, err)
+	}
+
+	// the expired entries are removed on write
+	requestValue[LookupCacheKey]["test_color|search|expired|0|10"] = ut.IM{"stamp": 0, "value": []ut.IM{}}
+	if _, err := LookupSearch(requestValue, "test_color", "r", 0, 10); err != nil {
+		t.Errorf("LookupSearch() error = %v", err)
+	}
+	if _, found := requestValue[LookupCacheKey]["test_color|search|expired|0|10"]; found {
+		t.Error("LookupSearch() expired entry is not removed")
+	}
+	// the oldest entries are removed above the size limit
+	for index := range LookupCacheSize {
+		requestValue[LookupCacheKey][fmt.Sprintf("test_color|search|%d|0|10", index)] = ut.IM{
+			"stamp": time.Now().Unix() - 60 + int64(index%2), "value": []ut.IM{}}
+	}
+	if _, err := LookupSearch(requestValue, "test_color", "b", 0, 10); err != nil || len(requestValue[LookupCacheKey]) != LookupCacheSize {
+		t.Errorf("LookupSearch() cache size = %v, %v", len(requestValue[LookupCacheKey]), err)
+	}
+	if _, found := requestValue[LookupCacheKey]["test_color|search|b|0|10"]; !found {
+		t.Error("LookupSearch() result is not cached")
+	}
+
+	LookupClearCache(requestValue, "test_color")
+	if len(requestValue[LookupCacheKey]) != 0 {
+		t.Errorf("LookupClearCache() = %v", requestValue[LookupCacheKey])
+	}
+	LookupClearCache(map[string]ut.IM{}, "")
+
+	// without session
+	if option, err := LookupResolve(nil, "test_color", "1"); err != nil || option.Text != "Red" {
+		t.Errorf("LookupResolve() = %v, %v", option, err)
+	}
+	if _, err := LookupResolve(nil, "test_color", "9"); err == nil {
+		t.Error("LookupResolve() error = nil")
+	}
+
+	// unknown lookup
+	if _, err := LookupSearch(requestValue, "unknown", "", 0, 10); err == nil {
+		t.Error("LookupSearch() error = nil")
+	}
+	if _, err := LookupResolve(requestValue, "unknown", ""); err == nil {
+		t.Error("LookupResolve() error = nil")
+	}
+	if options := LookupOptions(requestValue, "unknown"); len(options) != 0 {
+		t.Errorf("LookupOptions() = %v", options)
+	}
+}
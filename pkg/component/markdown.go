@@ -0,0 +1,368 @@
+package component
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"io/fs"
+	"maps"
+	"net/url"
+	"path"
+	"strings"
+
+	ut "github.com/nervatura/component/pkg/util"
+)
+
+// [Markdown] constants
+const (
+	ComponentTypeMarkdown = "markdown"
+
+	MarkdownEventLink = "markdown_link"
+)
+
+var markdownDefaultLabel ut.SM = ut.SM{
+	"markdown_not_found": "The page is not found",
+}
+
+// The allowed elements and attributes of the Markdown content: the rich text elements, the task list checkboxes
+// and the language classes of the code blocks
+var markdownPolicy ut.HTMLPolicy = func() ut.HTMLPolicy {
+	policy := maps.Clone(ut.DefaultHTMLPolicy)
+	policy["input"] = []string{"type", "checked", "disabled"}
+	policy["code"] = []string{"class"}
+	return policy
+}()
+
+//go:embed help
+var helpFS embed.FS
+
+/*
+Creates a CommonMark (GitHub Flavored Markdown tables, code blocks and task lists) content viewer. The
+converted HTML text is always sanitized, the raw HTML of the Markdown text is escaped.
+
+The content can be loaded from the Path file of an [fs.FS] (for example an [embed.FS] of the help pages).
+The relative links to Markdown files of the FS are opened in-app with htmx requests, and the external links
+are opened in a new browser tab.
+
+For example:
+
+	//go:embed help
+	var helpFS embed.FS
+
+	help, _ := fs.Sub(helpFS, "help")
+	&Markdown{
+	  BaseComponent: BaseComponent{
+	    Id:       "id_markdown_help",
+	    EventURL: "/event",
+	  },
+	  FS:   help,
+	  Path: "index.md",
+	}
+*/
+type Markdown struct {
+	BaseComponent
+	// The Markdown text. It is used if the FS or the Path is not set.
+	Value string `json:"value"`
+	// The path of the Markdown file in the FS. The relative links are resolved from the directory of the file.
+	Path string `json:"path"`
+	// The file system of the Markdown files
+	FS fs.FS `json:"-"`
+	// The texts of the labels of the component
+	Labels ut.SM `json:"labels"`
+}
+
+/*
+Returns all properties of the [Markdown]
+*/
+func (mkd *Markdown) Properties() ut.IM {
+	return ut.MergeIM(
+		mkd.BaseComponent.Properties(),
+		ut.IM{
+			"value":  mkd.Value,
+			"path":   mkd.Path,
+			"fs":     mkd.FS,
+			"labels": mkd.Labels,
+		})
+}
+
+/*
+Returns the value of the property of the [Markdown] with the specified name.
+*/
+func (mkd *Markdown) GetProperty(propName string) interface{} {
+	return mkd.Properties()[propName]
+}
+
+/*
+It checks the value given to the property of the [Markdown] and always returns a valid value
+*/
+func (mkd *Markdown) Validation(propName string, propValue interface{}) interface{} {
+	pm := map[string]func() interface{}{
+		"fs": func() interface{} {
+			if value, valid := propValue.(fs.FS); valid {
+				return value
+			}
+			return nil
+		},
+		"labels": func() interface{} {
+			value := ut.ToSM(mkd.Labels, ut.SM{})
+			switch v := propValue.(type) {
+			case ut.SM:
+				value = ut.MergeSM(value, v)
+			case ut.IM:
+				value = ut.MergeSM(value, ut.IMToSM(v))
+			}
+			if len(value) == 0 {
+				value = markdownDefaultLabel
+			}
+			return value
+		},
+		"target": func() interface{} {
+			mkd.SetProperty("id", mkd.Id)
+			value := ut.ToString(propValue, mkd.Id)
+			if value != "this" && !strings.HasPrefix(value, "#") {
+				value = "#" + value
+			}
+			return value
+		},
+	}
+	if _, found := pm[propName]; found {
+		return pm[propName]()
+	}
+	if mkd.BaseComponent.GetProperty(propName) != nil {
+		return mkd.BaseComponent.Validation(propName, propValue)
+	}
+	return propValue
+}
+
+/*
+Setting a property of the [Markdown] value safely. Checks the entered value.
+In case of an invalid value, the default value will be set.
+*/
+func (mkd *Markdown) SetProperty(propName string, propValue interface{}) interface{} {
+	pm := map[string]func() interface{}{
+		"value": func() interface{} {
+			mkd.Value = ut.ToString(propValue, "")
+			return mkd.Value
+		},
+		"path": func() interface{} {
+			mkd.Path = ut.ToString(propValue, "")
+			return mkd.Path
+		},
+		"fs": func() interface{} {
+			mkd.FS, _ = mkd.Validation(propName, propValue).(fs.FS)
+			return mkd.FS
+		},
+		"labels": func() interface{} {
+			mkd.Labels = mkd.Validation(propName, propValue).(ut.SM)
+			return mkd.Labels
+		},
+		"target": func() interface{} {
+			mkd.Target = mkd.Validation(propName, propValue).(string)
+			return mkd.Target
+		},
+	}
+	if _, found := pm[propName]; found {
+		return mkd.SetRequestValue(propName, pm[propName](), []string{})
+	}
+	if mkd.BaseComponent.GetProperty(propName) != nil {
+		return mkd.BaseComponent.SetProperty(propName, propValue)
+	}
+	return propValue
+}
+
+func (mkd *Markdown) msg(labelID string) string {
+	if label, found := mkd.Labels[labelID]; found {
+		return label
+	}
+	return markdownDefaultLabel[labelID]
+}
+
+// Returns the Markdown text of the Path file or the Value. The found is false if the file cannot be read.
+func (mkd *Markdown) source() (text string, found bool) {
+	if mkd.FS != nil && mkd.Path != "" {
+		data, err := fs.ReadFile(mkd.FS, mkd.Path)
+		return string(data), (err == nil)
+	}
+	return mkd.Value, true
+}
+
+// Returns the FS path of an in-app link or an empty string if the link is not a relative Markdown file link
+func (mkd *Markdown) linkPath(href string) string {
+	link, err := url.Parse(href)
+	if err != nil || link.Scheme != "" || link.Host != "" || !strings.EqualFold(path.Ext(link.Path), ".md") {
+		return ""
+	}
+	name := strings.TrimPrefix(link.Path, "/")
+	if !strings.HasPrefix(link.Path, "/") {
+		name = path.Join(path.Dir(mkd.Path), link.Path)
+	}
+	if !fs.ValidPath(name) {
+		return ""
+	}
+	return name
+}
+
+// Returns the sanitized HTML text of the Markdown text and the htmx trigger ids of the in-app links
+func (mkd *Markdown) content(text string) (content string, ids []string) {
+	content = ut.SanitizeHTML(ut.MarkdownToHTML(text), markdownPolicy)
+	content = ut.RewriteHTML(content, func(tag string, attrs [][2]string) [][2]string {
+		// the links of the Markdown text have only a href attribute
+		if tag != "a" {
+			return attrs
+		}
+		href := attrs[0][1]
+		if link, err := url.Parse(href); err == nil && (link.Scheme != "" || link.Host != "") {
+			return append(attrs, [2]string{"target", "_blank"}, [2]string{"rel", "noopener"})
+		}
+		name := mkd.linkPath(href)
+		if name == "" || mkd.FS == nil || mkd.EventURL == "" {
+			return attrs
+		}
+		id := fmt.Sprintf("%s_link_%d", mkd.Id, len(ids)+1)
+		ids = append(ids, id)
+		vals, _ := json.Marshal(ut.IM{"link": name})
+		attrs = [][2]string{{"href", "#"}, {"id", id}, {"hx-post", mkd.EventURL}, {"hx-target", mkd.Target},
+			{"hx-swap", mkd.Swap}, {"hx-vals", string(vals)}}
+		if mkd.Indicator != "none" {
+			attrs = append(attrs, [2]string{"hx-indicator", "#" + mkd.Indicator})
+		}
+		return attrs
+	})
+	return content, ids
+}
+
+/*
+If the OnResponse function of the [Markdown] is implemented, the function calls it after the [TriggerEvent]
+is processed, otherwise the function's return [ResponseEvent] is the processed [TriggerEvent].
+The Path of the component is set to the FS path of the clicked in-app link.
+*/
+func (mkd *Markdown) OnRequest(te TriggerEvent) (re ResponseEvent) {
+	evt := ResponseEvent{
+		Trigger: mkd, TriggerName: mkd.Name,
+		Name:  MarkdownEventLink,
+		Value: mkd.SetProperty("path", te.Values.Get("link")),
+	}
+	if mkd.OnResponse != nil {
+		return mkd.OnResponse(evt)
+	}
+	return evt
+}
+
+/*
+Based on the values, it will generate the html code of the [Markdown] or return with an error message.
+*/
+func (mkd *Markdown) Render() (html template.HTML, err error) {
+	return RenderHTML(mkd)
+}
+
+// the render data of the [Markdown] template
+type markdownRender struct {
+	*Markdown
+	found bool
+	body  string
+}
+
+// [Markdown] template functions
+var markdownFuncMap = map[string]any{
+	"styleMap": func(mkd *markdownRender) bool {
+		return len(mkd.Style) > 0
+	},
+	"customClass": func(mkd *markdownRender) string {
+		return strings.Join(mkd.Class, " ")
+	},
+	"msg": func(mkd *markdownRender, labelID string) string {
+		return mkd.msg(labelID)
+	},
+	"found": func(mkd *markdownRender) bool {
+		return mkd.found
+	},
+	"content": func(mkd *markdownRender) template.HTML {
+		return template.HTML(mkd.body)
+	},
+}
+
+/*
+Based on the values, it will write the html code of the [Markdown] into the writer or return with an error message.
+*/
+func (mkd *Markdown) RenderTo(w io.Writer) (err error) {
+	mkd.InitProps(mkd)
+
+	text, found := mkd.source()
+	content, ids := mkd.content(text)
+	tpl := `<div id="{{ .Id }}" name="{{ .Name }}" class="markdown {{ customClass $ }}"
+	{{ if ne .Path "" }} data-path="{{ .Path }}"{{ end }}
+	{{ if styleMap $ }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}
+	>{{ if found $ }}{{ content $ }}{{ else }}<p class="markdown-not-found">{{ msg $ "markdown_not_found" }}</p>{{ end }}</div>`
+
+	if err = ut.CachedTemplateWriter(w, "markdown", tpl, markdownFuncMap, &markdownRender{Markdown: mkd, found: found, body: content}); err == nil && mkd.EventURL != "" {
+		mkd.SetProperty("request_map", mkd)
+		// the htmx trigger ids of the in-app links
+		for _, id := range ids {
+			mkd.RequestMap[id] = mkd
+		}
+	}
+	return err
+}
+
+var testMarkdownResponse func(evt ResponseEvent) (re ResponseEvent) = func(evt ResponseEvent) (re ResponseEvent) {
+	evt.OOB = append(evt.OOB, OOBToast(ToastTypeInfo, ut.ToString(evt.Value, ""), 4))
+	return evt
+}
+
+// [Markdown] test and demo data
+func TestMarkdown(cc ClientComponent) []TestComponent {
+	id := ut.ToString(cc.GetProperty("id"), "")
+	eventURL := ut.ToString(cc.GetProperty("event_url"), "")
+	requestValue := cc.GetProperty("request_value").(map[string]ut.IM)
+	requestMap := cc.GetProperty("request_map").(map[string]ClientComponent)
+	help, _ := fs.Sub(helpFS, "help")
+	return []TestComponent{
+		{
+			Label:         "Help pages of an embed.FS",
+			ComponentType: ComponentTypeMarkdown,
+			Component: &Markdown{
+				BaseComponent: BaseComponent{
+					Id:           id + "_markdown_help",
+					EventURL:     eventURL,
+					OnResponse:   testMarkdownResponse,
+					RequestValue: requestValue,
+					RequestMap:   requestMap,
+				},
+				FS:   help,
+				Path: "index.md",
+			}},
+		{
+			Label:         "Markdown text",
+			ComponentType: ComponentTypeMarkdown,
+			Component: &Markdown{
+				BaseComponent: BaseComponent{
+					Id:           id + "_markdown_text",
+					EventURL:     eventURL,
+					RequestValue: requestValue,
+					RequestMap:   requestMap,
+				},
+				Value: "## Release notes\n\n- [x] **Markdown** component\n- [ ] Dashboard\n\n" +
+					"```go\nmkd := &Markdown{Value: \"# Title\"}\n```\n\n" +
+					"| Version | Date |\n| --- | ---: |\n| 1.0 | 2024-01-01 |\n\n" +
+					"<script>alert(1)</script> [Source](https://github.com/nervatura/component)",
+			}},
+		{
+			Label:         "Missing page",
+			ComponentType: ComponentTypeMarkdown,
+			Component: &Markdown{
+				BaseComponent: BaseComponent{
+					Id:           id + "_markdown_missing",
+					EventURL:     eventURL,
+					RequestValue: requestValue,
+					RequestMap:   requestMap,
+				},
+				FS:   help,
+				Path: "missing.md",
+				Labels: ut.SM{
+					"markdown_not_found": "The help page is not available",
+				},
+			}},
+	}
+}
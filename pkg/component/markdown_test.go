@@ -0,0 +1,354 @@
+package component
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	ut "github.com/nervatura/component/pkg/util"
+)
+
+func TestTestMarkdown(t *testing.T) {
+	for _, tt := range TestMarkdown(&BaseComponent{EventURL: "/demo"}) {
+		t.Run(tt.Label, func(t *testing.T) {
+			tt.Component.Render()
+		})
+	}
+	re := testMarkdownResponse(ResponseEvent{Trigger: &Markdown{}, Value: "index.md"})
+	if len(re.OOB) != 1 || re.OOB[0].Component.GetProperty("value") != "index.md" {
+		t.Errorf("testMarkdownResponse() = %v", re.OOB)
+	}
+}
+
+func TestMarkdown_GetProperty(t *testing.T) {
+	tests := []struct {
+		name     string
+		propName string
+		want     interface{}
+	}{
+		{
+			name:     "value",
+			propName: "value",
+			want:     "# Title",
+		},
+		{
+			name:     "path",
+			propName: "path",
+			want:     "index.md",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mkd := &Markdown{Value: "# Title", Path: "index.md"}
+			if got := mkd.GetProperty(tt.propName); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Markdown.GetProperty() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarkdown_Validation(t *testing.T) {
+	type args struct {
+		propName  string
+		propValue interface{}
+	}
+	tests := []struct {
+		name   string
+		labels ut.SM
+		args   args
+		want   interface{}
+	}{
+		{
+			name: "base",
+			args: args{
+				propName:  "id",
+				propValue: "MARKDOWNID",
+			},
+			want: "MARKDOWNID",
+		},
+		{
+			name: "invalid",
+			args: args{
+				propName:  "invalid",
+				propValue: "",
+			},
+			want: "",
+		},
+		{
+			name: "fs",
+			args: args{
+				propName:  "fs",
+				propValue: fstest.MapFS{},
+			},
+			want: fstest.MapFS{},
+		},
+		{
+			name: "fs_invalid",
+			args: args{
+				propName:  "fs",
+				propValue: "fs",
+			},
+			want: nil,
+		},
+		{
+			name: "labels_sm",
+			args: args{
+				propName:  "labels",
+				propValue: ut.SM{"markdown_not_found": "Nem található"},
+			},
+			want: ut.SM{"markdown_not_found": "Nem található"},
+		},
+		{
+			name:   "labels_im",
+			labels: ut.SM{"markdown_title": "Súgó"},
+			args: args{
+				propName:  "labels",
+				propValue: ut.IM{"markdown_not_found": "Nem található"},
+			},
+			want: ut.SM{"markdown_title": "Súgó", "markdown_not_found": "Nem található"},
+		},
+		{
+			name: "labels_default",
+			args: args{
+				propName:  "labels",
+				propValue: nil,
+			},
+			want: markdownDefaultLabel,
+		},
+		{
+			name: "target",
+			args: args{
+				propName:  "target",
+				propValue: "target",
+			},
+			want: "#target",
+		},
+		{
+			name: "target_this",
+			args: args{
+				propName:  "target",
+				propValue: "this",
+			},
+			want: "this",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mkd := &Markdown{Labels: tt.labels}
+			if got := mkd.Validation(tt.args.propName, tt.args.propValue); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Markdown.Validation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarkdown_SetProperty(t *testing.T) {
+	type args struct {
+		propName  string
+		propValue interface{}
+	}
+	tests := []struct {
+		name string
+		args args
+		want interface{}
+	}{
+		{
+			name: "base",
+			args: args{
+				propName:  "id",
+				propValue: "MARKDOWNID",
+			},
+			want: "MARKDOWNID",
+		},
+		{
+			name: "missing",
+			args: args{
+				propName:  "missing",
+				propValue: "value",
+			},
+			want: "value",
+		},
+		{
+			name: "value",
+			args: args{
+				propName:  "value",
+				propValue: "# Title",
+			},
+			want: "# Title",
+		},
+		{
+			name: "path",
+			args: args{
+				propName:  "path",
+				propValue: "index.md",
+			},
+			want: "index.md",
+		},
+		{
+			name: "fs",
+			args: args{
+				propName:  "fs",
+				propValue: fstest.MapFS{},
+			},
+			want: fstest.MapFS{},
+		},
+		{
+			name: "labels",
+			args: args{
+				propName:  "labels",
+				propValue: ut.SM{"markdown_not_found": "Nem található"},
+			},
+			want: ut.SM{"markdown_not_found": "Nem található"},
+		},
+		{
+			name: "target",
+			args: args{
+				propName:  "target",
+				propValue: "#target",
+			},
+			want: "#target",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mkd := &Markdown{}
+			if got := mkd.SetProperty(tt.args.propName, tt.args.propValue); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Markdown.SetProperty() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarkdown_OnRequest(t *testing.T) {
+	tests := []struct {
+		name       string
+		onResponse func(evt ResponseEvent) (re ResponseEvent)
+		want       string
+	}{
+		{
+			name: "link",
+			want: "guide/page.md",
+		},
+		{
+			name: "response",
+			onResponse: func(evt ResponseEvent) (re ResponseEvent) {
+				evt.Value = "response"
+				return evt
+			},
+			want: "response",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mkd := &Markdown{Path: "index.md"}
+			mkd.OnResponse = tt.onResponse
+			evt := mkd.OnRequest(TriggerEvent{Values: url.Values{"link": []string{"guide/page.md"}}})
+			if evt.Name != MarkdownEventLink || evt.Value != tt.want || mkd.Path != "guide/page.md" {
+				t.Errorf("Markdown.OnRequest() = %v, %v, want %v", evt.Name, evt.Value, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarkdown_Render(t *testing.T) {
+	help := fstest.MapFS{
+		"index.md": {Data: []byte("# Help\n\n[Page](guide/page.md#top) [Image](logo.png)")},
+		"guide/page.md": {Data: []byte("[Index](../index.md) [Root](/index.md) [Outside](../../x.md) " +
+			"[Anchor](#top) [External](https://example.com/help.md) [Bad](%zz.md)")},
+	}
+	tests := []struct {
+		name    string
+		mkd     *Markdown
+		want    []string
+		notWant []string
+		links   int
+	}{
+		{
+			name: "fs",
+			mkd: &Markdown{
+				BaseComponent: BaseComponent{Id: "help", EventURL: "/event", RequestMap: map[string]ClientComponent{},
+					Indicator: IndicatorSpinner},
+				FS:   help,
+				Path: "index.md",
+			},
+			want: []string{`<h1>Help</h1>`, `id="help_link_1"`, `hx-post="/event"`, `hx-target="#help"`,
+				`hx-vals="{&#34;link&#34;:&#34;guide/page.md&#34;}"`, `hx-indicator="#spinner"`,
+				`<a href="logo.png">`},
+			links: 1,
+		},
+		{
+			name: "relative links",
+			mkd: &Markdown{
+				BaseComponent: BaseComponent{Id: "help", EventURL: "/event", RequestMap: map[string]ClientComponent{}},
+				FS:            help,
+				Path:          "guide/page.md",
+			},
+			want: []string{`&#34;link&#34;:&#34;index.md&#34;}">Index</a>`, `&#34;link&#34;:&#34;index.md&#34;}">Root</a>`,
+				`<a href="../../x.md">Outside</a>`, `<a href="#top">Anchor</a>`, `<a href="%zz.md">Bad</a>`,
+				`<a href="https://example.com/help.md" target="_blank" rel="noopener">External</a>`},
+			notWant: []string{`hx-indicator`},
+			links:   2,
+		},
+		{
+			name: "value",
+			mkd: &Markdown{
+				BaseComponent: BaseComponent{Id: "text"},
+				Value:         "- [x] done\n\n```go\ncode\n```\n\n<b>raw</b> [Page](page.md) [Bad](javascript:alert(1))",
+			},
+			want: []string{`<input type="checkbox" disabled="" checked="">`, `<code class="language-go">`,
+				`&lt;b&gt;raw&lt;/b&gt;`, `<a href="page.md">Page</a>`},
+			notWant: []string{`hx-post`, `javascript`},
+		},
+		{
+			name: "not found",
+			mkd: &Markdown{
+				FS:   help,
+				Path: "missing.md",
+			},
+			want: []string{`<p class="markdown-not-found">The page is not found</p>`},
+		},
+		{
+			name: "not found label",
+			mkd: &Markdown{
+				FS:     help,
+				Path:   "missing.md",
+				Labels: ut.SM{"markdown_not_found": "Nem található"},
+			},
+			want: []string{`<p class="markdown-not-found">Nem található</p>`},
+		},
+		{
+			name: "not found default label",
+			mkd: &Markdown{
+				FS:     help,
+				Path:   "missing.md",
+				Labels: ut.SM{"markdown_title": "Súgó"},
+			},
+			want: []string{`<p class="markdown-not-found">The page is not found</p>`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := tt.mkd.Render()
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(string(res), want) {
+					t.Errorf("Markdown.Render() missing %s", want)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(string(res), notWant) {
+					t.Errorf("Markdown.Render() unexpected %s", notWant)
+				}
+			}
+			for i := 1; i <= tt.links; i++ {
+				if tt.mkd.RequestMap[tt.mkd.Id+"_link_"+ut.ToString(i, "")] != tt.mkd {
+					t.Error("Markdown.Render() missing request map")
+				}
+			}
+		})
+	}
+}
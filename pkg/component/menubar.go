@@ -2,6 +2,7 @@ package component
 
 import (
 	"html/template"
+	"io"
 	"strings"
 
 	ut "github.com/nervatura/component/pkg/util"
@@ -199,7 +200,7 @@ func (mnb *MenuBar) response(evt ResponseEvent) (re ResponseEvent) {
 	return mnbEvt
 }
 
-func (mnb *MenuBar) getComponent(name string, item MenuBarItem) (html template.HTML, err error) {
+func (mnb *MenuBar) component(name string, item MenuBarItem) ClientComponent {
 	ccLnk := func(label, linkStyle string) *Link {
 		return &Link{
 			BaseComponent: BaseComponent{
@@ -296,57 +297,74 @@ func (mnb *MenuBar) getComponent(name string, item MenuBarItem) (html template.H
 			}
 		},
 	}
-	cc := ccMap[name]()
-	html, err = cc.Render()
-	return html, err
+	return ccMap[name]()
 }
 
 /*
 Based on the values, it will generate the html code of the [MenuBar] or return with an error message.
 */
 func (mnb *MenuBar) Render() (html template.HTML, err error) {
+	return RenderHTML(mnb)
+}
+
+// the render data of the [MenuBar] template
+type menuBarRender struct {
+	*MenuBar
+	hw *ut.HTMLWriter
+}
+
+// [MenuBar] template functions
+var menuBarFuncMap = map[string]any{
+	"styleMap": func(mnb *menuBarRender) bool {
+		return len(mnb.Style) > 0
+	},
+	"customClass": func(mnb *menuBarRender) string {
+		return strings.Join(mnb.Class, " ")
+	},
+	"sideBar": func(mnb *menuBarRender) (template.HTML, error) {
+		return "", RenderTo(mnb.hw, mnb.component("sidebar", MenuBarItem{}))
+	},
+	"menuItem": func(mnb *menuBarRender, item MenuBarItem) (template.HTML, error) {
+		return "", RenderTo(mnb.hw, mnb.component("item", item))
+	},
+	"menuIcon": func(mnb *menuBarRender, item MenuBarItem) (template.HTML, error) {
+		return "", RenderTo(mnb.hw, mnb.component("icon", item))
+	},
+	"reverse": func(mnb *menuBarRender, idx int) MenuBarItem {
+		reverseIndex := len(mnb.Items) - 1 - idx
+		return mnb.Items[reverseIndex]
+	},
+}
+
+/*
+Based on the values, it will write the html code of the [MenuBar] into the writer or return with an error message.
+*/
+func (mnb *MenuBar) RenderTo(w io.Writer) (err error) {
 	mnb.InitProps(mnb)
 
-	funcMap := map[string]any{
-		"styleMap": func() bool {
-			return len(mnb.Style) > 0
-		},
-		"customClass": func() string {
-			return strings.Join(mnb.Class, " ")
-		},
-		"sideBar": func() (template.HTML, error) {
-			return mnb.getComponent("sidebar", MenuBarItem{})
-		},
-		"menuItem": func(item MenuBarItem) (template.HTML, error) {
-			return mnb.getComponent("item", item)
-		},
-		"menuIcon": func(item MenuBarItem) (template.HTML, error) {
-			return mnb.getComponent("icon", item)
-		},
-		"reverse": func(idx int) MenuBarItem {
-			reverseIndex := len(mnb.Items) - 1 - idx
-			return mnb.Items[reverseIndex]
-		},
-	}
-	tpl := `<div id="{{ .Id }}" name="{{ .Name }}" class="menubar {{ customClass }}"
-	{{ if styleMap }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}
+	tpl := `<div id="{{ .Id }}" name="{{ .Name }}" class="menubar {{ customClass $ }}"
+	{{ if styleMap $ }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}
 	><div class="cell">
-	{{ if .SideBar }}<div id="mnu_sidebar" class="menuitem menu-sidebar">{{ sideBar }}</div>{{ end }}
+	{{ if .SideBar }}<div id="mnu_sidebar" class="menuitem menu-sidebar">{{ sideBar $ }}</div>{{ end }}
 	{{ range $index, $item := .Items }}
-	<div id="mnu_{{ $item.Value }}_large" class="hide-small hide-medium menuitem">{{ menuItem $item }}</div>
+	<div id="mnu_{{ $item.Value }}_large" class="hide-small hide-medium menuitem">{{ menuItem $ $item }}</div>
 	{{ end }}
 	</div>
 	<div class="cell container">
-	{{ range $index, $item := .Items }}{{ $reverseItem := reverse $index }}
+	{{ range $index, $item := .Items }}{{ $reverseItem := reverse $ $index }}
 	<div id="mnu_{{ $item.Value }}_medium" class="right hide-large menuitem">
-	<span class="hide-small menu-text">{{ menuItem $reverseItem }}</span>
-	<span class="menu-label hide-medium">{{ menuIcon $reverseItem }}</span>
+	<span class="hide-small menu-text">{{ menuItem $ $reverseItem }}</span>
+	<span class="menu-label hide-medium">{{ menuIcon $ $reverseItem }}</span>
 	</div>
 	{{ end }}
 	</div>
 	</div>`
 
-	return ut.TemplateBuilder("menubar", tpl, funcMap, mnb)
+	hw := ut.NewHTMLWriter(w)
+	if err = ut.CachedTemplateWriter(hw, "menubar", tpl, menuBarFuncMap, &menuBarRender{MenuBar: mnb, hw: hw}); err == nil {
+		err = hw.Close()
+	}
+	return err
 }
 
 // [MenuBar] test and demo data
@@ -2,6 +2,7 @@ package component
 
 import (
 	"html/template"
+	"io"
 	"math"
 	"strings"
 
@@ -199,20 +200,29 @@ func (inp *NumberInput) OnRequest(te TriggerEvent) (re ResponseEvent) {
 Based on the values, it will generate the html code of the [NumberInput] or return with an error message.
 */
 func (inp *NumberInput) Render() (html template.HTML, err error) {
+	return RenderHTML(inp)
+}
+
+// [NumberInput] template functions
+var numberInputFuncMap = map[string]any{
+	"styleMap": func(inp *NumberInput) bool {
+		return len(inp.Style) > 0
+	},
+	"customClass": func(inp *NumberInput) string {
+		return strings.Join(inp.Class, " ")
+	},
+	"value": func(inp *NumberInput) string {
+		return ut.ToString(inp.Value, "0")
+	},
+}
+
+/*
+Based on the values, it will write the html code of the [NumberInput] into the writer or return with an error message.
+*/
+func (inp *NumberInput) RenderTo(w io.Writer) (err error) {
 	inp.InitProps(inp)
 
-	funcMap := map[string]any{
-		"styleMap": func() bool {
-			return len(inp.Style) > 0
-		},
-		"customClass": func() string {
-			return strings.Join(inp.Class, " ")
-		},
-		"value": func() string {
-			return ut.ToString(inp.Value, "0")
-		},
-	}
-	tpl := `<input id="{{ .Id }}" name="{{ .Name }}" type="number" onfocus="this.select();" value="{{ value }}"
+	tpl := `<input id="{{ .Id }}" name="{{ .Name }}" type="number" onfocus="this.select();" value="{{ value $ }}"
 	{{ if .Integer }} step="1"{{ else }} step="any"{{ end }}
 	{{ if ne .EventURL "" }} hx-post="{{ .EventURL }}" hx-target="{{ .Target }}" {{ if ne .Sync "none" }} hx-sync="{{ .Sync }}"{{ end }} hx-swap="{{ .Swap }}"{{ end }}
 	{{ if ne .Indicator "none" }} hx-indicator="#{{ .Indicator }}"{{ end }}
@@ -220,14 +230,14 @@ func (inp *NumberInput) Render() (html template.HTML, err error) {
 	{{ if .Disabled }} disabled{{ end }}
 	{{ if .AutoFocus }} autofocus{{ end }}
 	{{ if ne .Label "" }} aria-label="{{ .Label }}"{{ end }}
-	 class="{{ customClass }}{{ if .Full }} full{{ end }}"
-	{{ if styleMap }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}
+	 class="{{ customClass $ }}{{ if .Full }} full{{ end }}"
+	{{ if styleMap $ }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}
 	></input>`
 
-	if html, err = ut.TemplateBuilder("number", tpl, funcMap, inp); err == nil && inp.EventURL != "" {
+	if err = ut.CachedTemplateWriter(w, "number", tpl, numberInputFuncMap, inp); err == nil && inp.EventURL != "" {
 		inp.SetProperty("request_map", inp)
 	}
-	return html, nil
+	return nil
 }
 
 // [NumberInput] test and demo data
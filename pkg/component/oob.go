@@ -0,0 +1,110 @@
+package component
+
+import (
+	"html/template"
+	"io"
+	"regexp"
+	"strings"
+
+	ut "github.com/nervatura/component/pkg/util"
+)
+
+// [OOBComponent] constants
+const (
+	// The target of the [Toast] messages of the [Application]
+	OOBTargetToast = "#toast-msg"
+)
+
+// The first start tag of the rendered html
+var oobStartTag = regexp.MustCompile(`<[a-zA-Z][a-zA-Z0-9-]*`)
+
+/*
+OOBComponent is an additional component of a [ResponseEvent] which is swapped into the page by the htmx
+hx-swap-oob attribute, independently of the Trigger component.
+
+For example:
+
+	re.OOB = append(re.OOB,
+	  OOBSwap(badgeButton, SwapOuterHTML, ""),
+	  OOBToast(ToastTypeInfo, "Saved", 4),
+	)
+*/
+type OOBComponent struct {
+	// The swapped component
+	Component ClientComponent `json:"component"`
+	// [Swap] variable constants. Default value: [SwapOuterHTML]
+	Swap string `json:"swap"`
+	/* The CSS selector of the swapped element. Default value: the id of the component.
+	Example: "#toast-msg" */
+	Target string `json:"target"`
+}
+
+/*
+The OOBSwap function marks the component for an out-of-band swap with the swap strategy. The [SwapOuterHTML]
+replaces the target element by the component, other [Swap] values insert the component relative to the
+target element. The empty target value is the id of the component.
+*/
+func OOBSwap(cc ClientComponent, swap, target string) OOBComponent {
+	bcc := BaseComponent{}
+	return OOBComponent{
+		Component: cc,
+		Swap:      bcc.CheckEnumValue(swap, SwapOuterHTML, Swap),
+		Target:    target,
+	}
+}
+
+// The OOBToast function returns a [Toast] message out-of-band swap into the [OOBTargetToast] element.
+func OOBToast(toastType, value string, timeout int64) OOBComponent {
+	return OOBSwap(&Toast{Type: toastType, Value: value, Timeout: timeout}, SwapInnerHTML, OOBTargetToast)
+}
+
+func (oob *OOBComponent) swapValue() string {
+	target := oob.Target
+	if target == "" {
+		target = "#" + ut.ToString(oob.Component.GetProperty("id"), "")
+	}
+	swap := oob.Swap
+	if swap == "" {
+		swap = SwapOuterHTML
+	}
+	return swap + ":" + target
+}
+
+/*
+Based on the values, it will write the html code of the [OOBComponent] into the writer or return with an error
+message. The [SwapOuterHTML] value is set on the root element of the component, other swap values wrap the
+component in a new element.
+*/
+func (oob *OOBComponent) RenderTo(w io.Writer) (err error) {
+	var sb strings.Builder
+	if err = RenderTo(&sb, oob.Component); err != nil {
+		return err
+	}
+	html, swap := sb.String(), oob.swapValue()
+	attr := ` hx-swap-oob="` + template.HTMLEscapeString(swap) + `"`
+	if loc := oobStartTag.FindStringIndex(html); loc != nil && strings.HasPrefix(swap, SwapOuterHTML+":") {
+		html = html[:loc[1]] + attr + html[loc[1]:]
+	} else {
+		html = "<div" + attr + ">" + html + "</div>"
+	}
+	_, err = io.WriteString(w, html)
+	return err
+}
+
+/*
+The RenderTo function writes the html code of the Trigger component and the [OOBComponent] values of the
+[ResponseEvent] into the writer. If the Trigger is nil, the [HeaderReswap] of the response should be [SwapNone].
+*/
+func (re *ResponseEvent) RenderTo(w io.Writer) (err error) {
+	if re.Trigger != nil {
+		if err = RenderTo(w, re.Trigger); err != nil {
+			return err
+		}
+	}
+	for _, oob := range re.OOB {
+		if err = oob.RenderTo(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package component
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+type testEmptyComponent struct {
+	BaseComponent
+}
+
+func (tec *testEmptyComponent) RenderTo(w io.Writer) error {
+	return nil
+}
+
+func TestOOBSwap(t *testing.T) {
+	if oob := OOBSwap(&Label{}, "invalid", ""); oob.Swap != SwapOuterHTML {
+		t.Errorf("OOBSwap() Swap = %v", oob.Swap)
+	}
+	if oob := OOBToast(ToastTypeError, "error", 0); oob.Swap != SwapInnerHTML || oob.Target != OOBTargetToast {
+		t.Errorf("OOBToast() = %v", oob)
+	}
+}
+
+func TestOOBComponent_RenderTo(t *testing.T) {
+	tests := []struct {
+		name    string
+		oob     OOBComponent
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "outer",
+			oob:  OOBSwap(&Button{BaseComponent: BaseComponent{Id: "btn"}, Badge: "1"}, SwapOuterHTML, ""),
+			want: `<button hx-swap-oob="outerHTML:#btn" id="btn"`,
+		},
+		{
+			name: "outer_target",
+			oob:  OOBComponent{Component: &Label{BaseComponent: BaseComponent{Id: "lbl"}, Value: "value"}, Target: `[name="lbl"]`},
+			want: `<span hx-swap-oob="outerHTML:[name=&#34;lbl&#34;]"`,
+		},
+		{
+			name: "inner",
+			oob:  OOBToast(ToastTypeInfo, "message", 0),
+			want: `<div hx-swap-oob="innerHTML:#toast-msg"><div id=`,
+		},
+		{
+			name: "empty",
+			oob:  OOBSwap(&testEmptyComponent{BaseComponent{Id: "id"}}, SwapOuterHTML, ""),
+			want: `<div hx-swap-oob="outerHTML:#id"></div>`,
+		},
+		{
+			name:    "error",
+			oob:     OOBSwap(&testStreamComponent{}, SwapDelete, ""),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sb strings.Builder
+			err := tt.oob.RenderTo(&sb)
+			if (err != nil) != tt.wantErr || !strings.HasPrefix(sb.String(), tt.want) {
+				t.Errorf("OOBComponent.RenderTo() = %v, error = %v, wantErr %v", sb.String(), err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestResponseEvent_RenderTo(t *testing.T) {
+	tests := []struct {
+		name    string
+		re      ResponseEvent
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "trigger_oob",
+			re: ResponseEvent{
+				Trigger: &Label{BaseComponent: BaseComponent{Id: "lbl"}, Value: "label"},
+				OOB: []OOBComponent{
+					OOBSwap(&Label{BaseComponent: BaseComponent{Id: "badge"}, Value: "2"}, "", ""),
+					OOBToast(ToastTypeSuccess, "saved", 4),
+				},
+			},
+			want: []string{`<span id="lbl"`, `hx-swap-oob="outerHTML:#badge"`, `hx-swap-oob="innerHTML:#toast-msg"`},
+		},
+		{
+			name: "oob",
+			re: ResponseEvent{
+				OOB: []OOBComponent{OOBToast(ToastTypeInfo, "message", 0)},
+			},
+			want: []string{`hx-swap-oob="innerHTML:#toast-msg"`},
+		},
+		{
+			name:    "trigger_error",
+			re:      ResponseEvent{Trigger: &testStreamComponent{}},
+			wantErr: true,
+		},
+		{
+			name: "oob_error",
+			re: ResponseEvent{
+				OOB: []OOBComponent{OOBSwap(&testStreamComponent{}, SwapInnerHTML, "#id")},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			html, err := RenderHTML(&tt.re)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ResponseEvent.RenderTo() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(string(html), want) {
+					t.Errorf("ResponseEvent.RenderTo() = %v, missing %v", html, want)
+				}
+			}
+		})
+	}
+}
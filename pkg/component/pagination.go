@@ -2,6 +2,7 @@ package component
 
 import (
 	"html/template"
+	"io"
 	"slices"
 	"strings"
 
@@ -174,7 +175,7 @@ func (pgn *Pagination) response(evt ResponseEvent) (re ResponseEvent) {
 	return pgnEvt
 }
 
-func (pgn *Pagination) getComponent(name string) (html template.HTML, err error) {
+func (pgn *Pagination) component(name string) ClientComponent {
 	ccBtn := func(label, value string, disabled bool, style ut.SM) *Button {
 		return &Button{
 			BaseComponent: BaseComponent{
@@ -264,37 +265,54 @@ func (pgn *Pagination) getComponent(name string) (html template.HTML, err error)
 			return sel
 		},
 	}
-	cc := ccMap[name]()
-	html, err = cc.Render()
-	return html, err
+	return ccMap[name]()
 }
 
 /*
 Based on the values, it will generate the html code of the [Pagination] or return with an error message.
 */
 func (pgn *Pagination) Render() (html template.HTML, err error) {
+	return RenderHTML(pgn)
+}
+
+// the render data of the [Pagination] template
+type paginationRender struct {
+	*Pagination
+	hw *ut.HTMLWriter
+}
+
+// [Pagination] template functions
+var paginationFuncMap = map[string]any{
+	"styleMap": func(pgn *paginationRender) bool {
+		return len(pgn.Style) > 0
+	},
+	"customClass": func(pgn *paginationRender) string {
+		return strings.Join(pgn.Class, " ")
+	},
+	"paginationComponent": func(pgn *paginationRender, name string) (template.HTML, error) {
+		return "", RenderTo(pgn.hw, pgn.component(name))
+	},
+}
+
+/*
+Based on the values, it will write the html code of the [Pagination] into the writer or return with an error message.
+*/
+func (pgn *Pagination) RenderTo(w io.Writer) (err error) {
 	pgn.InitProps(pgn)
 
-	funcMap := map[string]any{
-		"styleMap": func() bool {
-			return len(pgn.Style) > 0
-		},
-		"customClass": func() string {
-			return strings.Join(pgn.Class, " ")
-		},
-		"paginationComponent": func(name string) (template.HTML, error) {
-			return pgn.getComponent(name)
-		},
-	}
-	tpl := `<div id="{{ .Id }}" name="{{ .Name }}" class="row {{ customClass }}"
-	{{ if styleMap }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}
-	><div class="cell padding-small" >{{ paginationComponent "pagination_btn_first" }}{{ paginationComponent "pagination_btn_previous" }}</div>
-	<div class="cell" >{{ paginationComponent "pagination_input_value" }}</div>
-	<div class="cell padding-small" >{{ paginationComponent "pagination_btn_next" }}{{ paginationComponent "pagination_btn_last" }}</div>
-	{{ if ne .HidePageSize true }}<div class="cell padding-small" >{{ paginationComponent "pagination_page_size" }}</div>{{ end }}
+	tpl := `<div id="{{ .Id }}" name="{{ .Name }}" class="row {{ customClass $ }}"
+	{{ if styleMap $ }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}
+	><div class="cell padding-small" >{{ paginationComponent $ "pagination_btn_first" }}{{ paginationComponent $ "pagination_btn_previous" }}</div>
+	<div class="cell" >{{ paginationComponent $ "pagination_input_value" }}</div>
+	<div class="cell padding-small" >{{ paginationComponent $ "pagination_btn_next" }}{{ paginationComponent $ "pagination_btn_last" }}</div>
+	{{ if ne .HidePageSize true }}<div class="cell padding-small" >{{ paginationComponent $ "pagination_page_size" }}</div>{{ end }}
 	</div>`
 
-	return ut.TemplateBuilder("pagination", tpl, funcMap, pgn)
+	hw := ut.NewHTMLWriter(w)
+	if err = ut.CachedTemplateWriter(hw, "pagination", tpl, paginationFuncMap, &paginationRender{Pagination: pgn, hw: hw}); err == nil {
+		err = hw.Close()
+	}
+	return err
 }
 
 // [Pagination] test and demo data
@@ -0,0 +1,696 @@
+package component
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"slices"
+	"strings"
+	"unicode"
+
+	ut "github.com/nervatura/component/pkg/util"
+)
+
+// [CommandPalette] constants
+const (
+	ComponentTypeCommandPalette = "palette"
+
+	PaletteEventSearch  = "palette_search"
+	PaletteEventExecute = "palette_execute"
+	PaletteEventClose   = "palette_close"
+
+	PaletteGroupMenu    = "menu"
+	PaletteGroupSideBar = "sidebar"
+	PaletteGroupRecent  = "recent"
+
+	// The maximum number of the matching commands and the provider results
+	PaletteDefaultLimit = 20
+	// The debounce delay of the search in milliseconds
+	PaletteDefaultDelay = 200
+)
+
+var paletteDefaultLabel ut.SM = ut.SM{
+	"palette_title":       "Command palette",
+	"palette_placeholder": "Type a command or search...",
+	"palette_empty":       "No matching commands",
+	"palette_hint":        "↑↓ to navigate, Enter to run, Esc to close",
+	"palette_menu":        "Menu",
+	"palette_sidebar":     "Action",
+	"palette_recent":      "Recent",
+}
+
+/*
+A command of the [CommandPalette]. The execution of the command dispatches the [ResponseEvent] of the
+TriggerName, Name and Value, the same event as the original menu or side bar item.
+*/
+type PaletteCommand struct {
+	// The displayed and searched text of the command
+	Label string `json:"label"`
+	// Optional secondary text, for example the group name of a side bar item
+	Description string `json:"description"`
+	// Valid [Icon] component value. See more [IconValues] variable values.
+	Icon string `json:"icon"`
+	// [PaletteGroupMenu], [PaletteGroupSideBar], [PaletteGroupRecent] or any custom group name
+	Group string `json:"group"`
+	// The TriggerName of the dispatched event. Example: "main_menu", "side_menu"
+	TriggerName string `json:"trigger_name"`
+	// The Name of the dispatched event. Example: [MenuBarEventValue], [SideBarEventItem]
+	Name string `json:"name"`
+	// The Value of the dispatched event
+	Value any `json:"value"`
+}
+
+/*
+The Event function returns the [ResponseEvent] of the command.
+*/
+func (cmd PaletteCommand) Event() ResponseEvent {
+	return ResponseEvent{
+		Trigger:     &BaseComponent{},
+		TriggerName: cmd.TriggerName,
+		Name:        cmd.Name,
+		Value:       cmd.Value,
+	}
+}
+
+// The commands with the same event are duplicates
+func (cmd PaletteCommand) key() string {
+	return fmt.Sprintf("%s|%s|%v", cmd.TriggerName, cmd.Name, cmd.Value)
+}
+
+/*
+PaletteProvider is an interface of the server-side data search of the [CommandPalette], for example the
+customers or the invoices of a database. The registered providers can be bound by name to the palette.
+*/
+type PaletteProvider interface {
+	// Returns the commands of the records that match the search text. The limit is the maximum number of the commands.
+	Search(text string, limit int64) (commands []PaletteCommand, err error)
+}
+
+// The registered palette providers. See more [RegisterPaletteProvider] function.
+var PaletteProviderMap map[string]PaletteProvider = map[string]PaletteProvider{}
+
+/*
+The RegisterPaletteProvider function adds a palette provider to the [PaletteProviderMap]. The providers should be
+registered before the first request is served.
+*/
+func RegisterPaletteProvider(name string, provider PaletteProvider) {
+	PaletteProviderMap[name] = provider
+}
+
+/*
+PaletteLookup is a [PaletteProvider] of a registered [LookupProvider]. The command of a found row dispatches the
+event of the TriggerName and Name with the row value, for example the [SearchEventSelected] event of the [Client].
+*/
+type PaletteLookup struct {
+	// The name of a registered [LookupProvider]
+	Lookup string `json:"lookup"`
+	// The group of the commands
+	Group string `json:"group"`
+	// Valid [Icon] component value of the commands
+	Icon string `json:"icon"`
+	// The TriggerName of the dispatched event
+	TriggerName string `json:"trigger_name"`
+	// The Name of the dispatched event
+	Name string `json:"name"`
+}
+
+/*
+The Search function returns the commands of the lookup rows that match the search text.
+*/
+func (pl *PaletteLookup) Search(text string, limit int64) (commands []PaletteCommand, err error) {
+	var rows []ut.IM
+	if rows, err = LookupSearch(nil, pl.Lookup, text, 0, limit); err != nil {
+		return commands, err
+	}
+	commands = []PaletteCommand{}
+	for _, row := range rows {
+		commands = append(commands, PaletteCommand{
+			Label: LookupMap[pl.Lookup].Option(row).Text, Icon: pl.Icon, Group: pl.Group,
+			TriggerName: pl.TriggerName, Name: pl.Name, Value: row,
+		})
+	}
+	return commands, nil
+}
+
+/*
+The PaletteMatch function is the fuzzy search of the [CommandPalette]. The text matches if it contains all characters
+of the query in the same order (case-insensitive, the spaces of the query are ignored). The consecutive and the word
+start characters increase the score. The positions are the rune indexes of the matched characters of the text.
+*/
+func PaletteMatch(text, query string) (score int, positions []int, found bool) {
+	runes := []rune(text)
+	start := 0
+	for _, qc := range strings.Join(strings.Fields(query), "") {
+		index := slices.IndexFunc(runes[start:], func(tc rune) bool {
+			return unicode.ToLower(tc) == unicode.ToLower(qc)
+		})
+		if index < 0 {
+			return 0, nil, false
+		}
+		pos := start + index
+		score++
+		if len(positions) > 0 && positions[len(positions)-1] == pos-1 {
+			score += 5
+		}
+		if pos == 0 || !(unicode.IsLetter(runes[pos-1]) || unicode.IsDigit(runes[pos-1])) {
+			score += 3
+		}
+		positions = append(positions, pos)
+		start = pos + 1
+	}
+	return score, positions, true
+}
+
+/*
+Creates a keyboard-driven command palette. The Commands are filtered by fuzzy search ([PaletteMatch]) and
+the registered [PaletteProvider] values of the Providers add the results of the server-side data search.
+
+The keyboard navigation (ArrowDown, ArrowUp, Enter, Escape) is handled by the component. The execution of a
+command sends the [PaletteEventExecute] event with the [PaletteCommand] value, and the [Client] dispatches
+the event of the command. The [Client] displays the palette as a modal with the Ctrl+K shortcut.
+
+For example:
+
+	&CommandPalette{
+	  BaseComponent: BaseComponent{
+	    Id:           "id_palette_default",
+	    EventURL:     "/event",
+	    RequestValue: parent_component.GetProperty("request_value").(map[string]ut.IM),
+	    RequestMap:   parent_component.GetProperty("request_map").(map[string]ClientComponent),
+	  },
+	  Commands: []PaletteCommand{
+	    {Label: "Search", Icon: IconSearch, Group: PaletteGroupMenu,
+	      TriggerName: "main_menu", Name: MenuBarEventValue, Value: "search"},
+	  },
+	  Providers: []string{"customer"},
+	}
+*/
+type CommandPalette struct {
+	BaseComponent
+	// The searchable commands. The duplicated commands are skipped.
+	Commands []PaletteCommand `json:"commands"`
+	// The names of the registered [PaletteProvider] values of the server-side search
+	Providers []string `json:"providers"`
+	// The current search text
+	Text string `json:"text"`
+	// The index of the highlighted result
+	ActiveIndex int64 `json:"active_index"`
+	// The maximum number of the matching commands and the provider results. Default value: [PaletteDefaultLimit]
+	Limit int64 `json:"limit"`
+	// The debounce delay of the search in milliseconds. Default value: [PaletteDefaultDelay]
+	Delay int64 `json:"delay"`
+	// The texts of the labels of the component
+	Labels ut.SM `json:"labels"`
+}
+
+/*
+Returns all properties of the [CommandPalette]
+*/
+func (pal *CommandPalette) Properties() ut.IM {
+	return ut.MergeIM(
+		pal.BaseComponent.Properties(),
+		ut.IM{
+			"commands":     pal.Commands,
+			"providers":    pal.Providers,
+			"text":         pal.Text,
+			"active_index": pal.ActiveIndex,
+			"limit":        pal.Limit,
+			"delay":        pal.Delay,
+			"labels":       pal.Labels,
+		})
+}
+
+/*
+Returns the value of the property of the [CommandPalette] with the specified name.
+*/
+func (pal *CommandPalette) GetProperty(propName string) interface{} {
+	return pal.Properties()[propName]
+}
+
+/*
+It checks the value given to the property of the [CommandPalette] and always returns a valid value
+*/
+func (pal *CommandPalette) Validation(propName string, propValue interface{}) interface{} {
+	pm := map[string]func() interface{}{
+		"commands": func() interface{} {
+			if commands, valid := propValue.([]PaletteCommand); valid && commands != nil {
+				return commands
+			}
+			commands := []PaletteCommand{}
+			if err := ut.ConvertToType(propValue, &commands); err != nil || commands == nil {
+				return []PaletteCommand{}
+			}
+			return commands
+		},
+		"providers": func() interface{} {
+			return ut.ILtoSL(propValue)
+		},
+		"active_index": func() interface{} {
+			return max(ut.ToInteger(propValue, 0), 0)
+		},
+		"limit": func() interface{} {
+			if value := ut.ToInteger(propValue, 0); value > 0 {
+				return value
+			}
+			return int64(PaletteDefaultLimit)
+		},
+		"delay": func() interface{} {
+			if value := ut.ToInteger(propValue, 0); value > 0 {
+				return value
+			}
+			return int64(PaletteDefaultDelay)
+		},
+		"labels": func() interface{} {
+			value := ut.ToSM(pal.Labels, ut.SM{})
+			switch v := propValue.(type) {
+			case ut.SM:
+				value = ut.MergeSM(value, v)
+			case ut.IM:
+				value = ut.MergeSM(value, ut.IMToSM(v))
+			}
+			if len(value) == 0 {
+				value = paletteDefaultLabel
+			}
+			return value
+		},
+		"target": func() interface{} {
+			pal.SetProperty("id", pal.Id)
+			value := ut.ToString(propValue, pal.Id)
+			if value != "this" && !strings.HasPrefix(value, "#") {
+				value = "#" + value
+			}
+			return value
+		},
+	}
+	if _, found := pm[propName]; found {
+		return pm[propName]()
+	}
+	if pal.BaseComponent.GetProperty(propName) != nil {
+		return pal.BaseComponent.Validation(propName, propValue)
+	}
+	return propValue
+}
+
+/*
+Setting a property of the [CommandPalette] value safely. Checks the entered value.
+In case of an invalid value, the default value will be set.
+*/
+func (pal *CommandPalette) SetProperty(propName string, propValue interface{}) interface{} {
+	pm := map[string]func() interface{}{
+		"commands": func() interface{} {
+			pal.Commands = pal.Validation(propName, propValue).([]PaletteCommand)
+			return pal.Commands
+		},
+		"providers": func() interface{} {
+			pal.Providers = pal.Validation(propName, propValue).([]string)
+			return pal.Providers
+		},
+		"text": func() interface{} {
+			pal.Text = ut.ToString(propValue, "")
+			return pal.Text
+		},
+		"active_index": func() interface{} {
+			pal.ActiveIndex = pal.Validation(propName, propValue).(int64)
+			return pal.ActiveIndex
+		},
+		"limit": func() interface{} {
+			pal.Limit = pal.Validation(propName, propValue).(int64)
+			return pal.Limit
+		},
+		"delay": func() interface{} {
+			pal.Delay = pal.Validation(propName, propValue).(int64)
+			return pal.Delay
+		},
+		"labels": func() interface{} {
+			pal.Labels = pal.Validation(propName, propValue).(ut.SM)
+			return pal.Labels
+		},
+		"target": func() interface{} {
+			pal.Target = pal.Validation(propName, propValue).(string)
+			return pal.Target
+		},
+	}
+	if _, found := pm[propName]; found {
+		return pal.SetRequestValue(propName, pm[propName](), []string{})
+	}
+	if pal.BaseComponent.GetProperty(propName) != nil {
+		return pal.BaseComponent.SetProperty(propName, propValue)
+	}
+	return propValue
+}
+
+func (pal *CommandPalette) msg(labelID string) string {
+	if label, found := pal.Labels[labelID]; found {
+		return label
+	}
+	return paletteDefaultLabel[labelID]
+}
+
+// Returns the label of the group. The label key is the group name with "palette_" prefix.
+func (pal *CommandPalette) groupLabel(group string) string {
+	if label := pal.msg("palette_" + group); label != "" {
+		return label
+	}
+	return group
+}
+
+/*
+The Search function returns the commands that match the text in score order, followed by the results of the
+Providers. The providers are not called with an empty text.
+*/
+func (pal *CommandPalette) Search(text string) (results []PaletteCommand) {
+	type paletteResult struct {
+		command PaletteCommand
+		score   int
+	}
+	limit := pal.Validation("limit", pal.Limit).(int64)
+	matches := []paletteResult{}
+	keys := map[string]bool{}
+	for _, command := range pal.Commands {
+		if score, _, found := PaletteMatch(command.Label, text); found && !keys[command.key()] {
+			keys[command.key()] = true
+			matches = append(matches, paletteResult{command: command, score: score})
+		}
+	}
+	slices.SortStableFunc(matches, func(a, b paletteResult) int {
+		return b.score - a.score
+	})
+	results = []PaletteCommand{}
+	for _, match := range matches[:min(int64(len(matches)), limit)] {
+		results = append(results, match.command)
+	}
+	if strings.TrimSpace(text) == "" {
+		return results
+	}
+	for _, name := range pal.Providers {
+		if provider, found := PaletteProviderMap[name]; found {
+			if commands, err := provider.Search(text, limit); err == nil {
+				results = append(results, commands...)
+			}
+		}
+	}
+	return results
+}
+
+func (pal *CommandPalette) response(name string, value any) (re ResponseEvent) {
+	evt := ResponseEvent{Trigger: pal, TriggerName: pal.Name, Name: name, Value: value}
+	if pal.OnResponse != nil {
+		return pal.OnResponse(evt)
+	}
+	return evt
+}
+
+/*
+The Execute function sends the [PaletteEventExecute] event of the command.
+*/
+func (pal *CommandPalette) Execute(command PaletteCommand) (re ResponseEvent) {
+	return pal.response(PaletteEventExecute, command)
+}
+
+/*
+If the OnResponse function of the [CommandPalette] is implemented, the function calls it after the [TriggerEvent]
+is processed, otherwise the function's return [ResponseEvent] is the processed [TriggerEvent].
+*/
+func (pal *CommandPalette) OnRequest(te TriggerEvent) (re ResponseEvent) {
+	key := te.Values.Get("key")
+	if text := te.Values.Get(te.Name); text != pal.Text || key == "" {
+		pal.SetProperty("text", text)
+		pal.SetProperty("active_index", 0)
+	}
+	results := pal.Search(pal.Text)
+	count := int64(len(results))
+	index := min(pal.ActiveIndex, count-1)
+	switch key {
+	case AutocompleteKeyDown:
+		pal.SetProperty("active_index", (index+1)%max(count, 1))
+
+	case AutocompleteKeyUp:
+		if index--; index < 0 {
+			index = count - 1
+		}
+		pal.SetProperty("active_index", index)
+
+	case AutocompleteKeyEnter:
+		if index >= 0 {
+			return pal.Execute(results[index])
+		}
+
+	case AutocompleteKeyEscape:
+		return pal.response(PaletteEventClose, pal.Text)
+	}
+	return pal.response(PaletteEventSearch, pal.Text)
+}
+
+func (pal *CommandPalette) component(name string, command PaletteCommand, index int) ClientComponent {
+	ccBase := func(id string, onResponse func(evt ResponseEvent) (re ResponseEvent)) BaseComponent {
+		return BaseComponent{
+			Id:           id,
+			Name:         name,
+			EventURL:     pal.EventURL,
+			Target:       pal.Target,
+			Swap:         pal.Swap,
+			Indicator:    pal.Indicator,
+			RequestValue: pal.RequestValue,
+			RequestMap:   pal.RequestMap,
+			OnResponse:   onResponse,
+		}
+	}
+	ccMap := map[string]func() ClientComponent{
+		"result": func() ClientComponent {
+			return &Label{
+				BaseComponent: ccBase(pal.Id+"_result_"+ut.ToString(index, ""), func(evt ResponseEvent) (re ResponseEvent) {
+					return pal.Execute(command)
+				}),
+			}
+		},
+		"close": func() ClientComponent {
+			ico := &Icon{
+				BaseComponent: ccBase(pal.Id+"_close", func(evt ResponseEvent) (re ResponseEvent) {
+					return pal.response(PaletteEventClose, pal.Text)
+				}),
+				Value: IconTimes, Width: 18, Height: 18,
+			}
+			ico.Class = []string{"palette-close"}
+			return ico
+		},
+		"search": func() ClientComponent {
+			return &Icon{Value: IconSearch, Width: 18, Height: 18}
+		},
+		"icon": func() ClientComponent {
+			return &Icon{Value: command.Icon, Width: 16, Height: 16}
+		},
+	}
+	return ccMap[name]()
+}
+
+/*
+Based on the values, it will generate the html code of the [CommandPalette] or return with an error message.
+*/
+func (pal *CommandPalette) Render() (html template.HTML, err error) {
+	return RenderHTML(pal)
+}
+
+// the render data of the [CommandPalette] template
+type commandPaletteRender struct {
+	*CommandPalette
+	results     []PaletteCommand
+	activeIndex int64
+	hw          *ut.HTMLWriter
+}
+
+// [CommandPalette] template functions
+var commandPaletteFuncMap = map[string]any{
+	"styleMap": func(pal *commandPaletteRender) bool {
+		return len(pal.Style) > 0
+	},
+	"customClass": func(pal *commandPaletteRender) string {
+		return strings.Join(pal.Class, " ")
+	},
+	"msg": func(pal *commandPaletteRender, labelID string) string {
+		return pal.msg(labelID)
+	},
+	"groupLabel": func(pal *commandPaletteRender, group string) string {
+		return pal.groupLabel(group)
+	},
+	"results": func(pal *commandPaletteRender) []PaletteCommand {
+		return pal.results
+	},
+	"activeIndex": func(pal *commandPaletteRender) int64 {
+		return pal.activeIndex
+	},
+	"resultID": func(pal *commandPaletteRender, index int) string {
+		if pal.EventURL != "" {
+			_ = RenderTo(io.Discard, pal.component("result", pal.results[index], index))
+		}
+		return pal.Id + "_result_" + ut.ToString(index, "")
+	},
+	"paletteComponent": func(pal *commandPaletteRender, name string) (template.HTML, error) {
+		return "", RenderTo(pal.hw, pal.component(name, PaletteCommand{}, 0))
+	},
+	"commandIcon": func(pal *commandPaletteRender, command PaletteCommand) (template.HTML, error) {
+		return "", RenderTo(pal.hw, pal.component("icon", command, 0))
+	},
+	"highlight": func(pal *commandPaletteRender, label string) template.HTML {
+		_, positions, _ := PaletteMatch(label, pal.Text)
+		var sb strings.Builder
+		for index, char := range []rune(label) {
+			matched := slices.Contains(positions, index)
+			if matched && !slices.Contains(positions, index-1) {
+				sb.WriteString("<mark>")
+			}
+			sb.WriteString(template.HTMLEscapeString(string(char)))
+			if matched && !slices.Contains(positions, index+1) {
+				sb.WriteString("</mark>")
+			}
+		}
+		return template.HTML(sb.String())
+	},
+}
+
+/*
+Based on the values, it will write the html code of the [CommandPalette] into the writer or return with an error message.
+*/
+func (pal *CommandPalette) RenderTo(w io.Writer) (err error) {
+	pal.InitProps(pal)
+	results := pal.Search(pal.Text)
+	activeIndex := min(pal.ActiveIndex, int64(len(results)-1))
+
+	event := `{{ if ne $.EventURL "" }} hx-post="{{ $.EventURL }}" hx-target="{{ $.Target }}" {{ if ne $.Sync "none" }} hx-sync="{{ $.Sync }}"{{ end }} hx-swap="{{ $.Swap }}"{{ end }}
+	{{ if ne $.Indicator "none" }} hx-indicator="#{{ $.Indicator }}"{{ end }}`
+	tpl := `<div id="{{ .Id }}" name="{{ .Name }}" class="palette {{ customClass $ }}" role="dialog" aria-label="{{ msg $ "palette_title" }}"
+	{{ if styleMap $ }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}
+	><div class="palette-header">{{ paletteComponent $ "search" }}
+	<input id="{{ .Id }}_input" name="{{ .Name }}" type="text" value="{{ .Text }}" autocomplete="off" autofocus
+	 role="combobox" aria-autocomplete="list" aria-controls="{{ .Id }}_list" aria-expanded="{{ if results $ }}true{{ else }}false{{ end }}"
+	{{ if results $ }} aria-activedescendant="{{ .Id }}_result_{{ activeIndex $ }}"{{ end }}
+	 placeholder="{{ msg $ "palette_placeholder" }}" aria-label="{{ msg $ "palette_title" }}"
+	{{ if ne .EventURL "" }} hx-post="{{ .EventURL }}" hx-target="{{ .Target }}" {{ if ne .Sync "none" }} hx-sync="{{ .Sync }}"{{ end }} hx-swap="{{ .Swap }}"
+	 hx-trigger="keyup changed delay:{{ .Delay }}ms, keydown[key=='ArrowDown'||key=='ArrowUp'||key=='Enter'||key=='Escape']"
+	 hx-vals="js:{key: event.key}"{{ end }}
+	{{ if ne .Indicator "none" }} hx-indicator="#{{ .Indicator }}"{{ end }} class="palette-input" ></input>
+	{{ if ne .EventURL "" }}{{ paletteComponent $ "close" }}{{ end }}</div>
+	{{ if results $ }}<ul id="{{ .Id }}_list" role="listbox" class="palette-list" >
+	{{ range $index, $command := results $ }}<li id="{{ resultID $ $index }}" name="result" role="option"
+	 class="palette-item{{ if eq (activeIndex $) $index }} active{{ end }}"
+	 aria-selected="{{ eq (activeIndex $) $index }}" ` + event + `
+	><span class="palette-icon">{{ if $command.Icon }}{{ commandIcon $ $command }}{{ end }}</span>
+	<span class="palette-label">{{ highlight $ $command.Label }}
+	{{ if $command.Description }}<span class="palette-description">{{ $command.Description }}</span>{{ end }}</span>
+	{{ if $command.Group }}<span class="palette-group">{{ groupLabel $ $command.Group }}</span>{{ end }}
+	</li>{{ end }}
+	</ul>{{ else }}<p class="palette-empty">{{ msg $ "palette_empty" }}</p>{{ end }}
+	<div class="palette-hint">{{ msg $ "palette_hint" }}</div></div>`
+
+	hw := ut.NewHTMLWriter(w)
+	if err = ut.CachedTemplateWriter(hw, "palette", tpl, commandPaletteFuncMap, &commandPaletteRender{CommandPalette: pal, results: results, activeIndex: activeIndex, hw: hw}); err == nil {
+		err = hw.Close()
+	}
+	if err == nil && pal.EventURL != "" {
+		pal.SetProperty("request_map", pal)
+		pal.RequestMap[pal.Id+"_input"] = pal
+	}
+	return err
+}
+
+var testPaletteCommands []PaletteCommand = []PaletteCommand{
+	{Label: "Recent customer", Description: "DMCUST/00002", Icon: IconUser, Group: PaletteGroupRecent,
+		TriggerName: "search", Name: SearchEventSelected, Value: "customer-3"},
+	{Label: "Search", Icon: IconSearch, Group: PaletteGroupMenu,
+		TriggerName: "main_menu", Name: MenuBarEventValue, Value: "search"},
+	{Label: "Setting", Icon: IconCog, Group: PaletteGroupMenu,
+		TriggerName: "main_menu", Name: MenuBarEventValue, Value: "setting"},
+	{Label: "Help", Icon: IconQuestionCircle, Group: PaletteGroupMenu,
+		TriggerName: "main_menu", Name: MenuBarEventValue, Value: "help"},
+	{Label: "Quick search", Icon: IconBolt, Group: PaletteGroupSideBar,
+		TriggerName: "side_menu", Name: SideBarEventItem, Value: "customer_simple"},
+	{Label: "New customer", Icon: IconUser, Group: PaletteGroupSideBar,
+		TriggerName: "side_menu", Name: SideBarEventItem, Value: "editor_new"},
+}
+
+var testPaletteCustomers *LookupRows = &LookupRows{
+	Rows: testSearchRows,
+	Columns: []TableField{
+		{Name: "custname", Label: "Name"},
+		{Name: "custnumber", Label: "Number"},
+	},
+	TextField: "custname",
+}
+
+// the demo lookup and palette provider of the [CommandPalette] and [Client] demos are registered once
+func init() {
+	RegisterLookup("palette_customer", testPaletteCustomers)
+	RegisterPaletteProvider("palette_customer", &PaletteLookup{
+		Lookup: "palette_customer", Group: "customer", Icon: IconUser,
+		TriggerName: "search", Name: SearchEventSelected,
+	})
+}
+
+var testPaletteResponse func(evt ResponseEvent) (re ResponseEvent) = func(evt ResponseEvent) (re ResponseEvent) {
+	if evt.Name == PaletteEventExecute {
+		command, _ := evt.Value.(PaletteCommand)
+		return ResponseEvent{
+			Trigger: &Toast{
+				Type:    ToastTypeInfo,
+				Value:   fmt.Sprintf("%s: %s %v", command.TriggerName, command.Name, command.Value),
+				Timeout: 4,
+			},
+			TriggerName: evt.TriggerName,
+			Name:        evt.Name,
+			Header: ut.SM{
+				HeaderRetarget: "#toast-msg",
+				HeaderReswap:   SwapInnerHTML,
+			},
+		}
+	}
+	return evt
+}
+
+// [CommandPalette] test and demo data
+func TestCommandPalette(cc ClientComponent) []TestComponent {
+	id := ut.ToString(cc.GetProperty("id"), "")
+	eventURL := ut.ToString(cc.GetProperty("event_url"), "")
+	requestValue := cc.GetProperty("request_value").(map[string]ut.IM)
+	requestMap := cc.GetProperty("request_map").(map[string]ClientComponent)
+	return []TestComponent{
+		{
+			Label:         "Commands and server-side search",
+			ComponentType: ComponentTypeCommandPalette,
+			Component: &CommandPalette{
+				BaseComponent: BaseComponent{
+					Id:           id + "_palette_default",
+					EventURL:     eventURL,
+					OnResponse:   testPaletteResponse,
+					RequestValue: requestValue,
+					RequestMap:   requestMap,
+				},
+				Commands:  testPaletteCommands,
+				Providers: []string{"palette_customer"},
+			}},
+		{
+			Label:         "Fuzzy search result",
+			ComponentType: ComponentTypeCommandPalette,
+			Component: &CommandPalette{
+				BaseComponent: BaseComponent{
+					Id:           id + "_palette_search",
+					EventURL:     eventURL,
+					OnResponse:   testPaletteResponse,
+					RequestValue: requestValue,
+					RequestMap:   requestMap,
+				},
+				Commands:    testPaletteCommands,
+				Providers:   []string{"palette_customer"},
+				Text:        "sea",
+				ActiveIndex: 1,
+			}},
+		{
+			Label:         "No matching commands",
+			ComponentType: ComponentTypeCommandPalette,
+			Component: &CommandPalette{
+				BaseComponent: BaseComponent{
+					Id: id + "_palette_empty",
+				},
+				Commands: testPaletteCommands,
+				Text:     "xyz",
+			}},
+	}
+}
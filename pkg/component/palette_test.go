@@ -0,0 +1,551 @@
+package component
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	ut "github.com/nervatura/component/pkg/util"
+)
+
+type testFailPaletteProvider struct{}
+
+func (fpp *testFailPaletteProvider) Search(text string, limit int64) ([]PaletteCommand, error) {
+	return nil, errors.New("search error")
+}
+
+func testPalette() *CommandPalette {
+	RegisterPaletteProvider("test_fail", &testFailPaletteProvider{})
+	pal := &CommandPalette{
+		BaseComponent: BaseComponent{
+			Id: "pal", Name: "palette", EventURL: "/event",
+			RequestValue: map[string]ut.IM{}, RequestMap: map[string]ClientComponent{},
+		},
+		Commands:  testPaletteCommands,
+		Providers: []string{"palette_customer", "test_fail", "missing"},
+	}
+	pal.InitProps(pal)
+	return pal
+}
+
+func TestTestCommandPalette(t *testing.T) {
+	for _, tt := range TestCommandPalette(&BaseComponent{EventURL: "/demo"}) {
+		t.Run(tt.Label, func(t *testing.T) {
+			tt.Component.Render()
+		})
+	}
+	evt := testPaletteResponse(ResponseEvent{Name: PaletteEventExecute, Value: testPaletteCommands[1]})
+	if evt.Header[HeaderRetarget] != "#toast-msg" {
+		t.Errorf("testPaletteResponse() = %v", evt)
+	}
+	if evt = testPaletteResponse(ResponseEvent{Name: PaletteEventSearch}); evt.Name != PaletteEventSearch {
+		t.Errorf("testPaletteResponse() = %v", evt)
+	}
+}
+
+func TestCommandPalette_GetProperty(t *testing.T) {
+	tests := []struct {
+		name     string
+		propName string
+		want     interface{}
+	}{
+		{
+			name:     "text",
+			propName: "text",
+			want:     "search",
+		},
+		{
+			name:     "providers",
+			propName: "providers",
+			want:     []string{"customer"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pal := &CommandPalette{Text: "search", Providers: []string{"customer"}}
+			if got := pal.GetProperty(tt.propName); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CommandPalette.GetProperty() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommandPalette_Validation(t *testing.T) {
+	type args struct {
+		propName  string
+		propValue interface{}
+	}
+	tests := []struct {
+		name   string
+		labels ut.SM
+		args   args
+		want   interface{}
+	}{
+		{
+			name: "base",
+			args: args{
+				propName:  "id",
+				propValue: "PALETTEID",
+			},
+			want: "PALETTEID",
+		},
+		{
+			name: "invalid",
+			args: args{
+				propName:  "invalid",
+				propValue: "",
+			},
+			want: "",
+		},
+		{
+			name: "commands",
+			args: args{
+				propName:  "commands",
+				propValue: []PaletteCommand{{Label: "Search"}},
+			},
+			want: []PaletteCommand{{Label: "Search"}},
+		},
+		{
+			name: "commands_map",
+			args: args{
+				propName: "commands",
+				propValue: []interface{}{
+					ut.IM{"label": "Search", "trigger_name": "main_menu", "name": MenuBarEventValue, "value": "search"}},
+			},
+			want: []PaletteCommand{{Label: "Search", TriggerName: "main_menu", Name: MenuBarEventValue, Value: "search"}},
+		},
+		{
+			name: "commands_nil",
+			args: args{
+				propName:  "commands",
+				propValue: nil,
+			},
+			want: []PaletteCommand{},
+		},
+		{
+			name: "commands_invalid",
+			args: args{
+				propName:  "commands",
+				propValue: "commands",
+			},
+			want: []PaletteCommand{},
+		},
+		{
+			name: "providers",
+			args: args{
+				propName:  "providers",
+				propValue: []interface{}{"customer"},
+			},
+			want: []string{"customer"},
+		},
+		{
+			name: "active_index",
+			args: args{
+				propName:  "active_index",
+				propValue: -1,
+			},
+			want: int64(0),
+		},
+		{
+			name: "limit",
+			args: args{
+				propName:  "limit",
+				propValue: 5,
+			},
+			want: int64(5),
+		},
+		{
+			name: "limit_default",
+			args: args{
+				propName:  "limit",
+				propValue: 0,
+			},
+			want: int64(PaletteDefaultLimit),
+		},
+		{
+			name: "delay",
+			args: args{
+				propName:  "delay",
+				propValue: 500,
+			},
+			want: int64(500),
+		},
+		{
+			name: "delay_default",
+			args: args{
+				propName:  "delay",
+				propValue: nil,
+			},
+			want: int64(PaletteDefaultDelay),
+		},
+		{
+			name: "labels_sm",
+			args: args{
+				propName:  "labels",
+				propValue: ut.SM{"palette_empty": "Nincs találat"},
+			},
+			want: ut.SM{"palette_empty": "Nincs találat"},
+		},
+		{
+			name:   "labels_im",
+			labels: ut.SM{"palette_title": "Parancsok"},
+			args: args{
+				propName:  "labels",
+				propValue: ut.IM{"palette_empty": "Nincs találat"},
+			},
+			want: ut.SM{"palette_title": "Parancsok", "palette_empty": "Nincs találat"},
+		},
+		{
+			name: "labels_default",
+			args: args{
+				propName:  "labels",
+				propValue: nil,
+			},
+			want: paletteDefaultLabel,
+		},
+		{
+			name: "target",
+			args: args{
+				propName:  "target",
+				propValue: "target",
+			},
+			want: "#target",
+		},
+		{
+			name: "target_this",
+			args: args{
+				propName:  "target",
+				propValue: "this",
+			},
+			want: "this",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pal := &CommandPalette{Labels: tt.labels}
+			if got := pal.Validation(tt.args.propName, tt.args.propValue); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CommandPalette.Validation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommandPalette_SetProperty(t *testing.T) {
+	type args struct {
+		propName  string
+		propValue interface{}
+	}
+	tests := []struct {
+		name string
+		args args
+		want interface{}
+	}{
+		{
+			name: "base",
+			args: args{
+				propName:  "id",
+				propValue: "PALETTEID",
+			},
+			want: "PALETTEID",
+		},
+		{
+			name: "missing",
+			args: args{
+				propName:  "missing",
+				propValue: "value",
+			},
+			want: "value",
+		},
+		{
+			name: "commands",
+			args: args{
+				propName:  "commands",
+				propValue: []PaletteCommand{{Label: "Search"}},
+			},
+			want: []PaletteCommand{{Label: "Search"}},
+		},
+		{
+			name: "providers",
+			args: args{
+				propName:  "providers",
+				propValue: []string{"customer"},
+			},
+			want: []string{"customer"},
+		},
+		{
+			name: "text",
+			args: args{
+				propName:  "text",
+				propValue: "search",
+			},
+			want: "search",
+		},
+		{
+			name: "active_index",
+			args: args{
+				propName:  "active_index",
+				propValue: 2,
+			},
+			want: int64(2),
+		},
+		{
+			name: "limit",
+			args: args{
+				propName:  "limit",
+				propValue: 10,
+			},
+			want: int64(10),
+		},
+		{
+			name: "delay",
+			args: args{
+				propName:  "delay",
+				propValue: 100,
+			},
+			want: int64(100),
+		},
+		{
+			name: "labels",
+			args: args{
+				propName:  "labels",
+				propValue: ut.SM{"palette_empty": "Nincs találat"},
+			},
+			want: ut.SM{"palette_empty": "Nincs találat"},
+		},
+		{
+			name: "target",
+			args: args{
+				propName:  "target",
+				propValue: "#target",
+			},
+			want: "#target",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pal := &CommandPalette{}
+			if got := pal.SetProperty(tt.args.propName, tt.args.propValue); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CommandPalette.SetProperty() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPaletteMatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		query     string
+		score     int
+		positions []int
+		found     bool
+	}{
+		{name: "empty", text: "Search", query: "", score: 0, positions: nil, found: true},
+		{name: "prefix", text: "Search", query: "sea", score: 16, positions: []int{0, 1, 2}, found: true},
+		{name: "word start", text: "Quick search", query: "qs", score: 8, positions: []int{0, 6}, found: true},
+		{name: "inner", text: "Setting", query: "tn", score: 2, positions: []int{2, 5}, found: true},
+		{name: "spaces", text: "New customer", query: "n c", score: 8, positions: []int{0, 4}, found: true},
+		{name: "unicode", text: "Új ügyfél", query: "üf", score: 5, positions: []int{3, 6}, found: true},
+		{name: "order", text: "Search", query: "hs", found: false},
+		{name: "missing", text: "Search", query: "x", found: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, positions, found := PaletteMatch(tt.text, tt.query)
+			if score != tt.score || !reflect.DeepEqual(positions, tt.positions) || found != tt.found {
+				t.Errorf("PaletteMatch() = %v, %v, %v", score, positions, found)
+			}
+		})
+	}
+}
+
+func TestCommandPalette_Search(t *testing.T) {
+	labels := func(commands []PaletteCommand) (result []string) {
+		for _, command := range commands {
+			result = append(result, command.Label)
+		}
+		return result
+	}
+	pal := testPalette()
+	pal.SetProperty("commands", append(slices.Clone(testPaletteCommands), testPaletteCommands[1]))
+	if got := labels(pal.Search("")); !reflect.DeepEqual(got, labels(testPaletteCommands)) {
+		t.Errorf("CommandPalette.Search() = %v", got)
+	}
+	if got := labels(pal.Search("se")); !reflect.DeepEqual(got, []string{"Search", "Setting", "Quick search", "Recent customer", "New customer",
+		"Second Customer Name", "Second Customer Name"}) {
+		t.Errorf("CommandPalette.Search() = %v", got)
+	}
+	if got := labels(pal.Search("third")); !reflect.DeepEqual(got,
+		[]string{"Third Customer Foundation", "Third Customer Foundation"}) {
+		t.Errorf("CommandPalette.Search() = %v", got)
+	}
+	pal.SetProperty("limit", 2)
+	if got := labels(pal.Search("e")); len(got) != 4 {
+		t.Errorf("CommandPalette.Search() = %v", got)
+	}
+}
+
+func TestPaletteLookup_Search(t *testing.T) {
+	commands, err := PaletteProviderMap["palette_customer"].Search("00003", 5)
+	if err != nil || len(commands) != 1 || commands[0].Label != "Third Customer Foundation" ||
+		commands[0].Name != SearchEventSelected || commands[0].Group != "customer" {
+		t.Errorf("PaletteLookup.Search() = %v, %v", commands, err)
+	}
+	if _, err = (&PaletteLookup{Lookup: "missing"}).Search("", 5); err == nil {
+		t.Error("PaletteLookup.Search() missing error")
+	}
+}
+
+func TestPaletteCommand_Event(t *testing.T) {
+	evt := testPaletteCommands[1].Event()
+	if evt.TriggerName != "main_menu" || evt.Name != MenuBarEventValue || evt.Value != "search" || evt.Trigger == nil {
+		t.Errorf("PaletteCommand.Event() = %v", evt)
+	}
+}
+
+func TestCommandPalette_OnRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		text        string
+		activeIndex int64
+		values      url.Values
+		onResponse  func(evt ResponseEvent) (re ResponseEvent)
+		evtName     string
+		value       any
+		activeWant  int64
+	}{
+		{
+			name:       "search",
+			values:     url.Values{"palette": {"set"}},
+			evtName:    PaletteEventSearch,
+			value:      "set",
+			activeWant: 0,
+		},
+		{
+			name:        "down",
+			activeIndex: 4,
+			values:      url.Values{"key": {AutocompleteKeyDown}},
+			evtName:     PaletteEventSearch,
+			value:       "",
+			activeWant:  5,
+		},
+		{
+			name:        "down wrap",
+			activeIndex: 5,
+			values:      url.Values{"key": {AutocompleteKeyDown}},
+			evtName:     PaletteEventSearch,
+			value:       "",
+			activeWant:  0,
+		},
+		{
+			name:       "down changed text",
+			text:       "s",
+			values:     url.Values{"key": {AutocompleteKeyDown}, "palette": {"help"}},
+			evtName:    PaletteEventSearch,
+			value:      "help",
+			activeWant: 0,
+		},
+		{
+			name:       "up wrap",
+			values:     url.Values{"key": {AutocompleteKeyUp}},
+			evtName:    PaletteEventSearch,
+			value:      "",
+			activeWant: 5,
+		},
+		{
+			name:        "up",
+			activeIndex: 3,
+			values:      url.Values{"key": {AutocompleteKeyUp}},
+			evtName:     PaletteEventSearch,
+			value:       "",
+			activeWant:  2,
+		},
+		{
+			name:        "enter",
+			text:        "se",
+			activeIndex: 2,
+			values:      url.Values{"key": {AutocompleteKeyEnter}, "palette": {"se"}},
+			evtName:     PaletteEventExecute,
+			value:       testPaletteCommands[4],
+			activeWant:  2,
+		},
+		{
+			name:       "enter empty",
+			text:       "xyz",
+			values:     url.Values{"key": {AutocompleteKeyEnter}, "palette": {"xyz"}},
+			evtName:    PaletteEventSearch,
+			value:      "xyz",
+			activeWant: 0,
+		},
+		{
+			name:       "escape",
+			text:       "se",
+			values:     url.Values{"key": {AutocompleteKeyEscape}, "palette": {"se"}},
+			evtName:    PaletteEventClose,
+			value:      "se",
+			activeWant: 0,
+		},
+		{
+			name:   "response",
+			values: url.Values{"palette": {"help"}},
+			onResponse: func(evt ResponseEvent) (re ResponseEvent) {
+				evt.Name = "response"
+				return evt
+			},
+			evtName:    "response",
+			value:      "help",
+			activeWant: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pal := testPalette()
+			pal.SetProperty("text", tt.text)
+			pal.SetProperty("active_index", tt.activeIndex)
+			pal.OnResponse = tt.onResponse
+			evt := pal.OnRequest(TriggerEvent{Name: "palette", Values: tt.values})
+			if evt.Name != tt.evtName || !reflect.DeepEqual(evt.Value, tt.value) || pal.ActiveIndex != tt.activeWant {
+				t.Errorf("CommandPalette.OnRequest() = %v, %v, %v", evt.Name, evt.Value, pal.ActiveIndex)
+			}
+		})
+	}
+}
+
+func TestCommandPalette_Render(t *testing.T) {
+	pal := testPalette()
+	pal.SetProperty("text", "qs")
+	pal.SetProperty("active_index", 5)
+	html, err := pal.Render()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(html), "<mark>Q</mark>uick <mark>s</mark>earch") ||
+		!strings.Contains(string(html), `aria-activedescendant="pal_result_0"`) {
+		t.Errorf("CommandPalette.Render() = %s", html)
+	}
+	if _, found := pal.RequestMap["pal_input"]; !found {
+		t.Error("CommandPalette.Render() input is not registered")
+	}
+	evt := pal.RequestMap["pal_result_0"].OnRequest(TriggerEvent{})
+	if evt.Name != PaletteEventExecute || !reflect.DeepEqual(evt.Value, testPaletteCommands[4]) {
+		t.Errorf("CommandPalette result = %v, %v", evt.Name, evt.Value)
+	}
+	evt = pal.RequestMap["pal_close"].OnRequest(TriggerEvent{})
+	if evt.Name != PaletteEventClose {
+		t.Errorf("CommandPalette close = %v", evt.Name)
+	}
+
+	pal = &CommandPalette{Text: "<b>", Labels: ut.SM{"palette_empty": "Nincs találat"}}
+	if html, _ = pal.Render(); !strings.Contains(string(html), "Nincs találat") ||
+		!strings.Contains(string(html), `aria-expanded="false"`) {
+		t.Errorf("CommandPalette.Render() = %s", html)
+	}
+	pal = &CommandPalette{Commands: []PaletteCommand{{Label: "<b>", Group: "custom"}}, Text: "b"}
+	if html, _ = pal.Render(); !strings.Contains(string(html), "&lt;<mark>b</mark>&gt;") ||
+		!strings.Contains(string(html), `<span class="palette-group">custom</span>`) {
+		t.Errorf("CommandPalette.Render() = %s", html)
+	}
+}
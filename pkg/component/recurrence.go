@@ -0,0 +1,262 @@
+package component
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// [RecurrenceRule] constants
+const (
+	RecurrenceDaily   = "DAILY"
+	RecurrenceWeekly  = "WEEKLY"
+	RecurrenceMonthly = "MONTHLY"
+	RecurrenceYearly  = "YEARLY"
+
+	// The maximum number of the expanded recurrence periods
+	RecurrenceLimit = 10000
+)
+
+// [RecurrenceRule] Freq values
+var RecurrenceFreq []string = []string{RecurrenceDaily, RecurrenceWeekly, RecurrenceMonthly, RecurrenceYearly}
+
+var recurrenceWeekday map[string]time.Weekday = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// BYDAY value of the [RecurrenceRule]
+type RecurrenceDay struct {
+	Weekday time.Weekday `json:"weekday"`
+	// The n-th weekday of the month (negative value: from the end of the month). Zero value: every weekday
+	N int `json:"n"`
+}
+
+/*
+RecurrenceRule is the supported subset of the RFC 5545 RRULE value: the FREQ, INTERVAL, COUNT, UNTIL, BYDAY,
+BYMONTHDAY and WKST parts.
+
+For example:
+
+	FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE;COUNT=10
+	FREQ=MONTHLY;BYDAY=-1FR;UNTIL=20241231
+*/
+type RecurrenceRule struct {
+	// [RecurrenceFreq] variable constants: [RecurrenceDaily], [RecurrenceWeekly], [RecurrenceMonthly], [RecurrenceYearly]
+	Freq     string `json:"freq"`
+	Interval int    `json:"interval"`
+	// The maximum number of the occurrences. Zero value: unlimited
+	Count int `json:"count"`
+	// The last possible occurrence. Zero value: unlimited
+	Until      time.Time       `json:"until"`
+	ByDay      []RecurrenceDay `json:"by_day"`
+	ByMonthDay []int           `json:"by_month_day"`
+	// The first day of the weekly periods. Default value: Monday
+	WeekStart time.Weekday `json:"week_start"`
+}
+
+func parseRecurrenceTime(value string) (tm time.Time, err error) {
+	if tm, err = time.Parse("20060102", value); err == nil {
+		// the date value includes the whole day
+		return tm.Add(24*time.Hour - time.Second), nil
+	}
+	if tm, err = time.Parse("20060102T150405Z", value); err != nil {
+		tm, err = time.Parse("20060102T150405", value)
+	}
+	return tm, err
+}
+
+func parseRecurrenceDay(value string) (day RecurrenceDay, err error) {
+	if len(value) < 2 {
+		return day, fmt.Errorf("invalid rrule weekday: %s", value)
+	}
+	weekday, found := recurrenceWeekday[value[len(value)-2:]]
+	if !found {
+		return day, fmt.Errorf("invalid rrule weekday: %s", value)
+	}
+	day.Weekday = weekday
+	if n := value[:len(value)-2]; n != "" {
+		if day.N, err = strconv.Atoi(n); err != nil || day.N == 0 || day.N < -5 || day.N > 5 {
+			return day, fmt.Errorf("invalid rrule weekday: %s", value)
+		}
+	}
+	return day, nil
+}
+
+/*
+ParseRecurrenceRule parses an RRULE value (the RRULE: prefix is optional). It returns an error if the value
+contains an invalid or unsupported part.
+*/
+func ParseRecurrenceRule(value string) (rule RecurrenceRule, err error) {
+	rule = RecurrenceRule{Interval: 1, WeekStart: time.Monday}
+	value = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(value)), "RRULE:")
+	for _, part := range strings.Split(value, ";") {
+		if part == "" {
+			continue
+		}
+		key, val, _ := strings.Cut(part, "=")
+		switch key {
+		case "FREQ":
+			rule.Freq = val
+			if !slices.Contains(RecurrenceFreq, val) {
+				err = fmt.Errorf("unsupported rrule frequency: %s", val)
+			}
+		case "INTERVAL", "COUNT":
+			var n int
+			if n, err = strconv.Atoi(val); err != nil || n < 1 {
+				return rule, fmt.Errorf("invalid rrule %s: %s", key, val)
+			}
+			if key == "INTERVAL" {
+				rule.Interval = n
+			} else {
+				rule.Count = n
+			}
+		case "UNTIL":
+			if rule.Until, err = parseRecurrenceTime(val); err != nil {
+				err = fmt.Errorf("invalid rrule UNTIL: %s", val)
+			}
+		case "BYDAY":
+			for _, item := range strings.Split(val, ",") {
+				var day RecurrenceDay
+				if day, err = parseRecurrenceDay(item); err != nil {
+					return rule, err
+				}
+				rule.ByDay = append(rule.ByDay, day)
+			}
+		case "BYMONTHDAY":
+			for _, item := range strings.Split(val, ",") {
+				day, err := strconv.Atoi(item)
+				if err != nil || day == 0 || day < -31 || day > 31 {
+					return rule, fmt.Errorf("invalid rrule BYMONTHDAY: %s", item)
+				}
+				rule.ByMonthDay = append(rule.ByMonthDay, day)
+			}
+		case "WKST":
+			var found bool
+			if rule.WeekStart, found = recurrenceWeekday[val]; !found {
+				err = fmt.Errorf("invalid rrule WKST: %s", val)
+			}
+		default:
+			err = fmt.Errorf("unsupported rrule part: %s", part)
+		}
+		if err != nil {
+			return rule, err
+		}
+	}
+	if rule.Freq == "" {
+		return rule, errors.New("missing rrule frequency")
+	}
+	return rule, nil
+}
+
+func (rule RecurrenceRule) hasWeekday(weekday time.Weekday) bool {
+	return len(rule.ByDay) == 0 || slices.ContainsFunc(rule.ByDay, func(day RecurrenceDay) bool {
+		return day.Weekday == weekday
+	})
+}
+
+// Returns the days of the month that match the BYMONTHDAY and BYDAY values
+func (rule RecurrenceRule) monthDays(year int, month time.Month, defaultDay int) (days []int) {
+	last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	weekday := func(day int) time.Weekday {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Weekday()
+	}
+	switch {
+	case len(rule.ByMonthDay) > 0:
+		for _, day := range rule.ByMonthDay {
+			if day < 0 {
+				day = last + day + 1
+			}
+			if day >= 1 && day <= last && rule.hasWeekday(weekday(day)) {
+				days = append(days, day)
+			}
+		}
+	case len(rule.ByDay) > 0:
+		for _, bd := range rule.ByDay {
+			first := (int(bd.Weekday)-int(weekday(1))+7)%7 + 1
+			switch {
+			case bd.N > 0:
+				days = append(days, first+7*(bd.N-1))
+			case bd.N < 0:
+				days = append(days, last-(int(weekday(last))-int(bd.Weekday)+7)%7+7*(bd.N+1))
+			default:
+				for day := first; day <= last; day += 7 {
+					days = append(days, day)
+				}
+			}
+		}
+	default:
+		days = append(days, defaultDay)
+	}
+	days = slices.DeleteFunc(days, func(day int) bool {
+		return day < 1 || day > last
+	})
+	slices.Sort(days)
+	return slices.Compact(days)
+}
+
+// Returns the candidate occurrences of the n-th period in chronological order
+func (rule RecurrenceRule) period(start time.Time, period int) (result []time.Time) {
+	n := period * rule.Interval
+	year, month, day := start.Date()
+	hour, minute, sec := start.Clock()
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, hour, minute, sec, 0, start.Location())
+	}
+	switch rule.Freq {
+	case RecurrenceDaily:
+		if tm := at(year, month, day+n); rule.hasWeekday(tm.Weekday()) {
+			result = append(result, tm)
+		}
+
+	case RecurrenceWeekly:
+		if len(rule.ByDay) == 0 {
+			return []time.Time{at(year, month, day+7*n)}
+		}
+		first := day + 7*n - (int(start.Weekday())-int(rule.WeekStart)+7)%7
+		for index := 0; index < 7; index++ {
+			if tm := at(year, month, first+index); rule.hasWeekday(tm.Weekday()) {
+				result = append(result, tm)
+			}
+		}
+
+	case RecurrenceMonthly, RecurrenceYearly:
+		first := at(year, month+time.Month(n), 1)
+		if rule.Freq == RecurrenceYearly {
+			first = at(year+n, month, 1)
+		}
+		for _, md := range rule.monthDays(first.Year(), first.Month(), day) {
+			result = append(result, at(first.Year(), first.Month(), md))
+		}
+	}
+	return result
+}
+
+/*
+Occurrences returns the recurrences of the start time in the [from, to) interval. The start time is the
+first occurrence, if it matches the rule (RFC 5545 DTSTART), and the COUNT value also includes the
+occurrences before the from value.
+*/
+func (rule RecurrenceRule) Occurrences(start, from, to time.Time) (result []time.Time) {
+	count := 0
+	for period := 0; period < RecurrenceLimit; period++ {
+		for _, tm := range rule.period(start, period) {
+			if tm.Before(start) {
+				continue
+			}
+			if !tm.Before(to) || (!rule.Until.IsZero() && tm.After(rule.Until)) {
+				return result
+			}
+			if count++; rule.Count > 0 && count > rule.Count {
+				return result
+			}
+			if !tm.Before(from) {
+				result = append(result, tm)
+			}
+		}
+	}
+	return result
+}
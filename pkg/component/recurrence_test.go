@@ -0,0 +1,164 @@
+package component
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseRecurrenceRule(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    RecurrenceRule
+		wantErr bool
+	}{
+		{
+			name:  "weekly",
+			value: "RRULE:FREQ=WEEKLY;INTERVAL=2;COUNT=10;BYDAY=MO,-1FR;WKST=SU;",
+			want: RecurrenceRule{
+				Freq: RecurrenceWeekly, Interval: 2, Count: 10, WeekStart: time.Sunday,
+				ByDay: []RecurrenceDay{{Weekday: time.Monday}, {Weekday: time.Friday, N: -1}},
+			},
+		},
+		{
+			name:  "until_date",
+			value: "freq=monthly;bymonthday=1,-1;until=20240131",
+			want: RecurrenceRule{
+				Freq: RecurrenceMonthly, Interval: 1, WeekStart: time.Monday, ByMonthDay: []int{1, -1},
+				Until: time.Date(2024, 1, 31, 23, 59, 59, 0, time.UTC),
+			},
+		},
+		{
+			name:  "until_time",
+			value: "FREQ=DAILY;UNTIL=20240131T100000Z",
+			want: RecurrenceRule{
+				Freq: RecurrenceDaily, Interval: 1, WeekStart: time.Monday,
+				Until: time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:  "until_local_time",
+			value: "FREQ=YEARLY;UNTIL=20240131T100000",
+			want: RecurrenceRule{
+				Freq: RecurrenceYearly, Interval: 1, WeekStart: time.Monday,
+				Until: time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC),
+			},
+		},
+		{name: "missing_freq", value: "COUNT=2", wantErr: true},
+		{name: "unsupported_freq", value: "FREQ=HOURLY", wantErr: true},
+		{name: "invalid_interval", value: "FREQ=DAILY;INTERVAL=0", wantErr: true},
+		{name: "invalid_count", value: "FREQ=DAILY;COUNT=x", wantErr: true},
+		{name: "invalid_until", value: "FREQ=DAILY;UNTIL=2024", wantErr: true},
+		{name: "invalid_weekday", value: "FREQ=WEEKLY;BYDAY=XY", wantErr: true},
+		{name: "short_weekday", value: "FREQ=WEEKLY;BYDAY=M", wantErr: true},
+		{name: "invalid_weekday_n", value: "FREQ=MONTHLY;BYDAY=6MO", wantErr: true},
+		{name: "invalid_month_day", value: "FREQ=MONTHLY;BYMONTHDAY=32", wantErr: true},
+		{name: "invalid_week_start", value: "FREQ=WEEKLY;WKST=XX", wantErr: true},
+		{name: "unsupported_part", value: "FREQ=WEEKLY;BYHOUR=10", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRecurrenceRule(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRecurrenceRule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseRecurrenceRule() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecurrenceRule_Occurrences(t *testing.T) {
+	date := func(value string) time.Time {
+		tm, _ := time.Parse("2006-01-02T15:04", value)
+		return tm
+	}
+	dates := func(values ...string) (result []time.Time) {
+		for _, value := range values {
+			result = append(result, date(value))
+		}
+		return result
+	}
+	tests := []struct {
+		name  string
+		rule  string
+		start string
+		from  string
+		to    string
+		want  []time.Time
+	}{
+		{
+			name: "daily_count", rule: "FREQ=DAILY;COUNT=3",
+			start: "2024-01-30T10:00", from: "2024-01-31T00:00", to: "2024-03-01T00:00",
+			want: dates("2024-01-31T10:00", "2024-02-01T10:00"),
+		},
+		{
+			name: "daily_weekdays", rule: "FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR",
+			start: "2024-05-03T08:00", from: "2024-05-01T00:00", to: "2024-05-08T00:00",
+			want: dates("2024-05-03T08:00", "2024-05-06T08:00", "2024-05-07T08:00"),
+		},
+		{
+			name: "weekly", rule: "FREQ=WEEKLY;INTERVAL=2",
+			start: "2024-05-01T09:00", from: "2024-05-01T00:00", to: "2024-06-01T00:00",
+			want: dates("2024-05-01T09:00", "2024-05-15T09:00", "2024-05-29T09:00"),
+		},
+		{
+			name: "weekly_by_day", rule: "FREQ=WEEKLY;BYDAY=MO,WE;UNTIL=20240515",
+			start: "2024-05-07T09:00", from: "2024-05-01T00:00", to: "2024-06-01T00:00",
+			want: dates("2024-05-08T09:00", "2024-05-13T09:00", "2024-05-15T09:00"),
+		},
+		{
+			name: "monthly_last_day", rule: "FREQ=MONTHLY;BYMONTHDAY=-1",
+			start: "2024-01-31T12:00", from: "2024-02-01T00:00", to: "2024-05-01T00:00",
+			want: dates("2024-02-29T12:00", "2024-03-31T12:00", "2024-04-30T12:00"),
+		},
+		{
+			name: "monthly_day", rule: "FREQ=MONTHLY",
+			start: "2024-01-31T12:00", from: "2024-01-01T00:00", to: "2024-06-01T00:00",
+			want: dates("2024-01-31T12:00", "2024-03-31T12:00", "2024-05-31T12:00"),
+		},
+		{
+			name: "monthly_nth_weekday", rule: "FREQ=MONTHLY;BYDAY=1MO,-1FR",
+			start: "2024-05-01T10:00", from: "2024-05-01T00:00", to: "2024-07-01T00:00",
+			want: dates("2024-05-06T10:00", "2024-05-31T10:00", "2024-06-03T10:00", "2024-06-28T10:00"),
+		},
+		{
+			name: "monthly_weekdays", rule: "FREQ=MONTHLY;BYDAY=TU;COUNT=3",
+			start: "2024-04-23T10:00", from: "2024-04-01T00:00", to: "2024-07-01T00:00",
+			want: dates("2024-04-23T10:00", "2024-04-30T10:00", "2024-05-07T10:00"),
+		},
+		{
+			name: "monthly_day_weekday", rule: "FREQ=MONTHLY;BYMONTHDAY=13;BYDAY=FR",
+			start: "2024-01-01T00:00", from: "2024-01-01T00:00", to: "2025-01-01T00:00",
+			want: dates("2024-09-13T00:00", "2024-12-13T00:00"),
+		},
+		{
+			name: "yearly_leap_day", rule: "FREQ=YEARLY",
+			start: "2024-02-29T00:00", from: "2024-01-01T00:00", to: "2029-01-01T00:00",
+			want: dates("2024-02-29T00:00", "2028-02-29T00:00"),
+		},
+		{
+			name: "count_sparse", rule: "FREQ=MONTHLY;BYMONTHDAY=30;BYDAY=SU;COUNT=1",
+			start: "2024-01-01T00:00", from: "2024-01-01T00:00", to: "4000-01-01T00:00",
+			want: dates("2024-06-30T00:00"),
+		},
+		{
+			name: "limit", rule: "FREQ=MONTHLY;INTERVAL=12;BYMONTHDAY=31",
+			start: "2024-02-01T00:00", from: "2024-01-01T00:00", to: "9000-01-01T00:00",
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := ParseRecurrenceRule(tt.rule)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := rule.Occurrences(date(tt.start), date(tt.from), date(tt.to)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RecurrenceRule.Occurrences() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,507 @@
+package component
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// [Marshal] constants
+const (
+	ComponentTypeBase = "base"
+
+	// The type discriminator key of the polymorphic JSON values
+	JSONTypeKey = "component_type"
+	// The value key of the polymorphic JSON values
+	JSONValueKey = "component"
+	// The type name prefix of the [SideBarItem] values
+	sideBarItemPrefix = "sidebar_"
+)
+
+/*
+The registered types of the polymorphic JSON values. The keys are the ComponentType constants and the
+[SideBarItem] types with the sidebar_ prefix. See more [RegisterComponent] and [RegisterType] functions.
+*/
+var typeMap map[string]reflect.Type = map[string]reflect.Type{
+	ComponentTypeBase:           reflect.TypeFor[*BaseComponent](),
+	ComponentTypeApplication:    reflect.TypeFor[*Application](),
+	ComponentTypeAutocomplete:   reflect.TypeFor[*Autocomplete](),
+	ComponentTypeBrowser:        reflect.TypeFor[*Browser](),
+	ComponentTypeButton:         reflect.TypeFor[*Button](),
+	ComponentTypeCalendar:       reflect.TypeFor[*Calendar](),
+	ComponentTypeChart:          reflect.TypeFor[*Chart](),
+	ComponentTypeClient:         reflect.TypeFor[*Client](),
+	ComponentTypeCommandPalette: reflect.TypeFor[*CommandPalette](),
+	ComponentTypeDashboard:      reflect.TypeFor[*Dashboard](),
+	ComponentTypeDateTime:       reflect.TypeFor[*DateTime](),
+	ComponentTypeEditor:         reflect.TypeFor[*Editor](),
+	ComponentTypeField:          reflect.TypeFor[*Field](),
+	ComponentTypeForm:           reflect.TypeFor[*Form](),
+	ComponentTypeIcon:           reflect.TypeFor[*Icon](),
+	ComponentTypeInput:          reflect.TypeFor[*Input](),
+	ComponentTypeKanban:         reflect.TypeFor[*Kanban](),
+	ComponentTypeLabel:          reflect.TypeFor[*Label](),
+	ComponentTypeLink:           reflect.TypeFor[*Link](),
+	ComponentTypeList:           reflect.TypeFor[*List](),
+	ComponentTypeLogin:          reflect.TypeFor[*Login](),
+	ComponentTypeMarkdown:       reflect.TypeFor[*Markdown](),
+	ComponentTypeMenuBar:        reflect.TypeFor[*MenuBar](),
+	ComponentTypeNumberInput:    reflect.TypeFor[*NumberInput](),
+	ComponentTypePagination:     reflect.TypeFor[*Pagination](),
+	ComponentTypeRichText:       reflect.TypeFor[*RichText](),
+	ComponentTypeRow:            reflect.TypeFor[*Row](),
+	ComponentTypeSearch:         reflect.TypeFor[*Search](),
+	ComponentTypeSelect:         reflect.TypeFor[*Select](),
+	ComponentTypeSelector:       reflect.TypeFor[*Selector](),
+	ComponentTypeSideBar:        reflect.TypeFor[*SideBar](),
+	ComponentTypeTable:          reflect.TypeFor[*Table](),
+	ComponentTypeToast:          reflect.TypeFor[*Toast](),
+	ComponentTypeToggle:         reflect.TypeFor[*Toggle](),
+	ComponentTypeTreeView:       reflect.TypeFor[*TreeView](),
+	ComponentTypeUpload:         reflect.TypeFor[*Upload](),
+	ComponentTypeWizard:         reflect.TypeFor[*Wizard](),
+
+	sideBarItemPrefix + SideBarItemTypeState:       reflect.TypeFor[*SideBarState](),
+	sideBarItemPrefix + SideBarItemTypeGroup:       reflect.TypeFor[*SideBarGroup](),
+	sideBarItemPrefix + SideBarItemTypeElement:     reflect.TypeFor[*SideBarElement](),
+	sideBarItemPrefix + SideBarItemTypeElementLink: reflect.TypeFor[*SideBarElementLink](),
+	sideBarItemPrefix + SideBarItemTypeStatic:      reflect.TypeFor[*SideBarStatic](),
+	sideBarItemPrefix + SideBarItemTypeSeparator:   reflect.TypeFor[*SideBarSeparator](),
+}
+
+// The type names of the registered types
+var typeNames map[reflect.Type]string = func() map[reflect.Type]string {
+	names := map[reflect.Type]string{}
+	for name, rt := range typeMap {
+		names[rt] = name
+	}
+	return names
+}()
+
+// The types that can contain polymorphic values
+var polymorphicTypes sync.Map
+
+var baseComponentType = reflect.TypeFor[BaseComponent]()
+
+/*
+The RegisterComponent function adds a custom component type to the type registry of the [Marshal] and
+[Unmarshal] functions. The type is created by its pointer value. The types should be registered before the
+first session is loaded.
+
+Example:
+
+	ct.RegisterComponent("demo", &Demo{})
+*/
+func RegisterComponent(componentType string, cc ClientComponent) {
+	RegisterType(componentType, cc)
+}
+
+/*
+The RegisterType function adds any other implementation of an interface field (for example a custom
+[SideBarItem]) to the type registry of the [Marshal] and [Unmarshal] functions.
+*/
+func RegisterType(name string, value any) {
+	rt := reflect.TypeOf(value)
+	typeMap[name] = rt
+	typeNames[rt] = name
+}
+
+/*
+The Marshal function returns the JSON encoding of the component tree. The values of the interface fields
+(for example MainComponent, LabelComponent or the SideBar Items) are encoded with their registered type name:
+
+	{"component_type": "button", "component": {"id": "btn", ...}}
+
+The shared RequestValue of the nested components is stored only by the root component. The fields with
+json:"-" tag (RequestMap, OnResponse) are not encoded.
+*/
+func Marshal(cc ClientComponent) (data []byte, err error) {
+	enc := &jsonEncoder{}
+	if base := jsonBaseComponent(reflect.ValueOf(cc)); base != nil {
+		enc.root, enc.requestValue = base, reflect.ValueOf(base.RequestValue).Pointer()
+	}
+	var value any
+	if value, err = enc.encode(reflect.ValueOf(&cc).Elem()); err != nil {
+		return data, err
+	}
+	return json.Marshal(value)
+}
+
+/*
+RestoreComponent is a component that has to rebuild its not encoded runtime state (for example the function
+values or the json:"-" fields) after the restore. The [Unmarshal] function calls the Restore function of the
+root component.
+*/
+type RestoreComponent interface {
+	Restore() error
+}
+
+/*
+The Unmarshal function restores the component tree of the [Marshal] result into the value pointed to by v.
+The v can be a *ClientComponent or a pointer to a registered component pointer (for example **Application).
+The nested components share the RequestValue and the RequestMap of the root component, and the Render
+function of the root component rebuilds the RequestMap. The OnResponse functions of the child components
+are set by their parent component, but the OnResponse of the root component must be set by the caller.
+If the root component implements the [RestoreComponent] interface, its Restore function is called last.
+*/
+func Unmarshal(data []byte, v any) (err error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return errors.New("invalid unmarshal value")
+	}
+	dec := &jsonDecoder{}
+	var value reflect.Value
+	if value, err = dec.component(data); err != nil {
+		return err
+	}
+	if !value.Type().AssignableTo(rv.Elem().Type()) {
+		return fmt.Errorf("invalid component type: %s", value.Type())
+	}
+	rv.Elem().Set(value)
+	if root := jsonBaseComponent(value); root != nil {
+		if root.RequestMap == nil {
+			root.RequestMap = map[string]ClientComponent{}
+		}
+		for _, base := range dec.shared {
+			base.RequestValue = root.RequestValue
+		}
+		for _, base := range dec.bases {
+			base.RequestMap = root.RequestMap
+		}
+	}
+	if rc, valid := value.Interface().(RestoreComponent); valid {
+		return rc.Restore()
+	}
+	return nil
+}
+
+// Returns the embedded BaseComponent of the component pointer
+func jsonBaseComponent(value reflect.Value) *BaseComponent {
+	if value.Kind() != reflect.Pointer || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	if base, valid := value.Interface().(*BaseComponent); valid {
+		return base
+	}
+	if field := value.Elem().FieldByName("BaseComponent"); field.IsValid() && field.Type() == baseComponentType {
+		return field.Addr().Interface().(*BaseComponent)
+	}
+	return nil
+}
+
+// Checks whether the type can contain a polymorphic (interface) value
+func jsonPolymorphic(rt reflect.Type) bool {
+	if value, found := polymorphicTypes.Load(rt); found {
+		return value.(bool)
+	}
+	result := jsonTypeCheck(rt, map[reflect.Type]bool{})
+	polymorphicTypes.Store(rt, result)
+	return result
+}
+
+func jsonTypeCheck(rt reflect.Type, visited map[reflect.Type]bool) bool {
+	if visited[rt] {
+		return false
+	}
+	visited[rt] = true
+	switch rt.Kind() {
+	case reflect.Interface:
+		return true
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		return jsonTypeCheck(rt.Elem(), visited)
+	case reflect.Map:
+		return rt.Key().Kind() == reflect.String && jsonTypeCheck(rt.Elem(), visited)
+	case reflect.Struct:
+		for index := range rt.NumField() {
+			if field := rt.Field(index); field.IsExported() && field.Tag.Get("json") != "-" &&
+				jsonTypeCheck(field.Type, visited) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type jsonField struct {
+	index     int
+	name      string
+	omitEmpty bool
+	embedded  bool
+}
+
+// Returns the encoded fields of the struct type
+func jsonFields(rt reflect.Type) (fields []jsonField) {
+	for index := range rt.NumField() {
+		field := rt.Field(index)
+		tag := field.Tag.Get("json")
+		name, opts, _ := strings.Cut(tag, ",")
+		if !field.IsExported() || tag == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			fields = append(fields, jsonField{index: index, embedded: true})
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields = append(fields, jsonField{index: index, name: name, omitEmpty: opts == "omitempty"})
+	}
+	return fields
+}
+
+type jsonEncoder struct {
+	root         *BaseComponent
+	requestValue uintptr
+}
+
+func (enc *jsonEncoder) encode(value reflect.Value) (result any, err error) {
+	if !jsonPolymorphic(value.Type()) {
+		return value.Interface(), nil
+	}
+	switch value.Kind() {
+	case reflect.Interface:
+		if value.IsNil() {
+			return nil, nil
+		}
+		elem := value.Elem()
+		if name, found := typeNames[elem.Type()]; found {
+			result, err = enc.encode(elem)
+			return map[string]any{JSONTypeKey: name, JSONValueKey: result}, err
+		}
+		if value.NumMethod() > 0 {
+			return nil, fmt.Errorf("unregistered component type: %s", elem.Type())
+		}
+		return enc.encode(elem)
+
+	case reflect.Pointer:
+		if value.IsNil() {
+			return nil, nil
+		}
+		return enc.encode(value.Elem())
+
+	case reflect.Struct:
+		values := map[string]any{}
+		return values, enc.encodeStruct(value, values)
+
+	case reflect.Map:
+		if value.IsNil() {
+			return nil, nil
+		}
+		values := map[string]any{}
+		iter := value.MapRange()
+		for err == nil && iter.Next() {
+			values[iter.Key().String()], err = enc.encode(iter.Value())
+		}
+		return values, err
+
+	default:
+		// slice and array
+		if value.Kind() == reflect.Slice && value.IsNil() {
+			return nil, nil
+		}
+		values := make([]any, value.Len())
+		for index := 0; index < value.Len() && err == nil; index++ {
+			values[index], err = enc.encode(value.Index(index))
+		}
+		return values, err
+	}
+}
+
+func (enc *jsonEncoder) encodeStruct(value reflect.Value, values map[string]any) (err error) {
+	embedded := map[string]any{}
+	for _, field := range jsonFields(value.Type()) {
+		fv := value.Field(field.index)
+		switch {
+		case field.embedded:
+			err = enc.encodeStruct(fv, embedded)
+		case field.omitEmpty && fv.IsZero():
+		case value.Type() == baseComponentType && field.name == "request_value" && value.CanAddr() &&
+			value.Addr().Interface() != enc.root && enc.requestValue != 0 && fv.Pointer() == enc.requestValue:
+			// the shared RequestValue is stored by the root component
+		default:
+			values[field.name], err = enc.encode(fv)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	// the fields of the outer struct hide the fields of the embedded structs
+	for name, ev := range embedded {
+		if _, found := values[name]; !found {
+			values[name] = ev
+		}
+	}
+	return nil
+}
+
+type jsonDecoder struct {
+	// The decoded BaseComponent values of the component tree
+	bases []*BaseComponent
+	// The BaseComponent values without their own RequestValue
+	shared []*BaseComponent
+}
+
+// Decodes a {"component_type": name, "component": value} value
+func (dec *jsonDecoder) component(data []byte) (value reflect.Value, err error) {
+	var values map[string]json.RawMessage
+	var name string
+	if err = json.Unmarshal(data, &values); err != nil {
+		return value, err
+	}
+	if err = json.Unmarshal(values[JSONTypeKey], &name); err != nil || len(values) != 2 {
+		return value, errors.New("invalid component value")
+	}
+	rt, found := typeMap[name]
+	if !found {
+		return value, fmt.Errorf("unknown component type: %s", name)
+	}
+	value = reflect.New(rt).Elem()
+	return value, dec.decode(values[JSONValueKey], value)
+}
+
+// Decodes an untyped value. The objects can also contain component values.
+func (dec *jsonDecoder) decodeAny(data []byte) (result any, err error) {
+	var values map[string]json.RawMessage
+	var list []json.RawMessage
+	switch {
+	case json.Unmarshal(data, &values) == nil && values != nil:
+		if _, found := values[JSONTypeKey]; found && len(values) == 2 {
+			if _, found := values[JSONValueKey]; found {
+				var value reflect.Value
+				if value, err = dec.component(data); err != nil {
+					return nil, err
+				}
+				return value.Interface(), nil
+			}
+		}
+		items := map[string]any{}
+		for key, raw := range values {
+			if items[key], err = dec.decodeAny(raw); err != nil {
+				return nil, err
+			}
+		}
+		return items, nil
+
+	case json.Unmarshal(data, &list) == nil && list != nil:
+		items := make([]any, len(list))
+		for index, raw := range list {
+			if items[index], err = dec.decodeAny(raw); err != nil {
+				return nil, err
+			}
+		}
+		return items, nil
+
+	default:
+		err = json.Unmarshal(data, &result)
+		return result, err
+	}
+}
+
+func (dec *jsonDecoder) decode(data []byte, value reflect.Value) (err error) {
+	rt := value.Type()
+	if !jsonPolymorphic(rt) {
+		return json.Unmarshal(data, value.Addr().Interface())
+	}
+	if string(data) == "null" {
+		value.SetZero()
+		return nil
+	}
+	switch value.Kind() {
+	case reflect.Interface:
+		var result any
+		var component reflect.Value
+		if value.NumMethod() == 0 {
+			if result, err = dec.decodeAny(data); err == nil && result != nil {
+				value.Set(reflect.ValueOf(result))
+			}
+			return err
+		}
+		if component, err = dec.component(data); err != nil {
+			return err
+		}
+		if !component.Type().AssignableTo(rt) {
+			return fmt.Errorf("invalid %s type: %s", rt, component.Type())
+		}
+		value.Set(component)
+		return nil
+
+	case reflect.Pointer:
+		if value.IsNil() {
+			value.Set(reflect.New(rt.Elem()))
+		}
+		return dec.decode(data, value.Elem())
+
+	case reflect.Struct:
+		var values map[string]json.RawMessage
+		if err = json.Unmarshal(data, &values); err == nil {
+			err = dec.decodeStruct(values, value)
+		}
+		return err
+
+	case reflect.Map:
+		var values map[string]json.RawMessage
+		if err = json.Unmarshal(data, &values); err != nil {
+			return err
+		}
+		items := reflect.MakeMapWithSize(rt, len(values))
+		for key, raw := range values {
+			item := reflect.New(rt.Elem()).Elem()
+			if err = dec.decode(raw, item); err != nil {
+				return err
+			}
+			items.SetMapIndex(reflect.ValueOf(key).Convert(rt.Key()), item)
+		}
+		value.Set(items)
+		return nil
+
+	default:
+		// slice and array
+		var list []json.RawMessage
+		if err = json.Unmarshal(data, &list); err != nil {
+			return err
+		}
+		if value.Kind() == reflect.Slice {
+			value.Set(reflect.MakeSlice(rt, len(list), len(list)))
+		}
+		for index := 0; index < len(list) && index < value.Len() && err == nil; index++ {
+			err = dec.decode(list[index], value.Index(index))
+		}
+		return err
+	}
+}
+
+func (dec *jsonDecoder) decodeStruct(values map[string]json.RawMessage, value reflect.Value) (err error) {
+	if value.Type() == baseComponentType {
+		base := value.Addr().Interface().(*BaseComponent)
+		dec.bases = append(dec.bases, base)
+		if _, found := values["request_value"]; !found {
+			dec.shared = append(dec.shared, base)
+		}
+	}
+	fields := jsonFields(value.Type())
+	embedded := map[string]json.RawMessage{}
+	for key, raw := range values {
+		embedded[key] = raw
+	}
+	for _, field := range fields {
+		if raw, found := values[field.name]; found && !field.embedded {
+			delete(embedded, field.name)
+			if err = dec.decode(raw, value.Field(field.index)); err != nil {
+				return err
+			}
+		}
+	}
+	// the embedded structs get the values that are not decoded by the outer struct
+	for _, field := range fields {
+		if field.embedded {
+			if err = dec.decodeStruct(embedded, value.Field(field.index)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
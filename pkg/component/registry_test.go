@@ -0,0 +1,218 @@
+package component
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	ut "github.com/nervatura/component/pkg/util"
+)
+
+type testSideBarItem struct {
+	Value string `json:"value"`
+	Label string
+}
+
+func (item *testSideBarItem) ItemType() string {
+	return "custom"
+}
+
+func (item *testSideBarItem) GetValue() string {
+	return item.Value
+}
+
+func (item *testSideBarItem) GetSelected() bool {
+	return false
+}
+
+type testRestoreComponent struct {
+	BaseComponent
+	Restored bool `json:"-"`
+}
+
+func (trc *testRestoreComponent) Restore() error {
+	trc.Restored = true
+	return nil
+}
+
+func TestUnmarshal_restore(t *testing.T) {
+	RegisterComponent("restore", &testRestoreComponent{})
+	t.Cleanup(func() {
+		delete(typeNames, typeMap["restore"])
+		delete(typeMap, "restore")
+	})
+	data, err := Marshal(&testRestoreComponent{BaseComponent: BaseComponent{Id: "restore"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cc ClientComponent
+	if err = Unmarshal(data, &cc); err != nil || !cc.(*testRestoreComponent).Restored {
+		t.Errorf("Unmarshal() = %v, error = %v", cc, err)
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	testData := []func(cc ClientComponent) []TestComponent{
+		TestAutocomplete, TestBrowser, TestButton, TestCalendar, TestChart, TestClient, TestCommandPalette, TestDashboard,
+		TestDateTime, TestEditor, TestField, TestForm, TestIcon, TestInput, TestKanban, TestLabel, TestLink, TestList, TestLogin, TestMarkdown,
+		TestMenuBar, TestNumberInput, TestPagination, TestRichText, TestRow, TestSearch, TestSelect, TestSelector, TestSidebar, TestTable, TestToast,
+		TestToggle, TestTreeView, TestUpload, TestWizard,
+	}
+	for _, data := range testData {
+		demo := &BaseComponent{
+			Id: "demo", EventURL: "/event",
+			RequestValue: map[string]ut.IM{}, RequestMap: map[string]ClientComponent{},
+		}
+		for _, tt := range data(demo) {
+			t.Run(tt.ComponentType+"_"+tt.Label, func(t *testing.T) {
+				data, err := Marshal(tt.Component)
+				if err != nil {
+					t.Fatalf("Marshal() error = %v", err)
+				}
+				var cc ClientComponent
+				if err = Unmarshal(data, &cc); err != nil {
+					t.Fatalf("Unmarshal() error = %v", err)
+				}
+				// the typed values of the untyped (ut.IM) fields are restored as JSON objects
+				var want, got any
+				restored, _ := Marshal(cc)
+				json.Unmarshal(data, &want)
+				json.Unmarshal(restored, &got)
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("Unmarshal() = %s, want %s", restored, data)
+				}
+				if _, err = cc.Render(); err != nil {
+					t.Errorf("Unmarshal() Render error = %v", err)
+				}
+			})
+		}
+	}
+}
+
+func TestUnmarshal_tree(t *testing.T) {
+	requestValue := map[string]ut.IM{"login": {"username": "admin"}}
+	app := &Application{
+		BaseComponent: BaseComponent{Id: "app", RequestValue: requestValue, Data: ut.IM{
+			"label": &Label{Value: "label"}, "list": []any{1, "value"}, "map": ut.IM{"key": true},
+		}},
+		MainComponent: &Button{
+			BaseComponent: BaseComponent{Id: "btn", RequestValue: requestValue},
+			LabelComponent: &SideBar{
+				BaseComponent: BaseComponent{Id: "sidebar", RequestValue: requestValue},
+				Items: []SideBarItem{
+					&SideBarElement{Name: "element", Value: "value"},
+					&SideBarSeparator{},
+				}},
+		},
+	}
+	data, err := Marshal(app)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Count(data, []byte(`"username":"admin"`)) != 1 {
+		t.Errorf("Marshal() shared request_value = %s", data)
+	}
+
+	var restored *Application
+	if err = Unmarshal(data, &restored); err != nil {
+		t.Fatal(err)
+	}
+	btn := restored.MainComponent.(*Button)
+	sb := btn.LabelComponent.(*SideBar)
+	if sb.Id != "sidebar" || ut.ToString(sb.RequestValue["login"]["username"], "") != "admin" {
+		t.Errorf("Unmarshal() sidebar = %v", sb)
+	}
+	restored.RequestValue["login"]["username"] = "guest"
+	if ut.ToString(sb.RequestValue["login"]["username"], "") != "guest" || sb.RequestMap == nil {
+		t.Error("Unmarshal() request_value is not shared")
+	}
+	if item, valid := sb.Items[0].(*SideBarElement); !valid || item.Value != "value" {
+		t.Errorf("Unmarshal() sidebar item = %v", item)
+	}
+	if label, valid := restored.Data["label"].(*Label); !valid || label.Value != "label" {
+		t.Errorf("Unmarshal() data = %v", restored.Data)
+	}
+	if _, err = restored.Render(); err != nil {
+		t.Errorf("Unmarshal() Render error = %v", err)
+	}
+}
+
+func TestRegisterType(t *testing.T) {
+	sb := &SideBar{Items: []SideBarItem{&testSideBarItem{Value: "custom"}}}
+	if _, err := Marshal(sb); err == nil {
+		t.Error("Marshal() unregistered type error = nil")
+	}
+	RegisterType("sidebar_custom", &testSideBarItem{})
+	t.Cleanup(func() {
+		delete(typeNames, typeMap["sidebar_custom"])
+		delete(typeMap, "sidebar_custom")
+	})
+	data, err := Marshal(sb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cc ClientComponent
+	if err = Unmarshal(data, &cc); err != nil || cc.(*SideBar).Items[0].GetValue() != "custom" {
+		t.Errorf("Unmarshal() = %v, error = %v", cc, err)
+	}
+
+	RegisterComponent("custom", &testStreamComponent{})
+	t.Cleanup(func() {
+		delete(typeNames, typeMap["custom"])
+		delete(typeMap, "custom")
+	})
+	for _, cc := range []ClientComponent{&testStreamComponent{}, &BaseComponent{}, (*Button)(nil), &SideBar{}} {
+		if data, err = Marshal(cc); err != nil {
+			t.Errorf("Marshal() error = %v", err)
+		}
+		var restored ClientComponent
+		if err = Unmarshal(data, &restored); err != nil {
+			t.Errorf("Unmarshal() error = %v", err)
+		}
+	}
+	var item SideBarItem
+	if err = Unmarshal([]byte(`{"component_type":"sidebar_separator","component":{}}`), &item); err != nil {
+		t.Errorf("Unmarshal() error = %v", err)
+	}
+	if fields := jsonFields(reflect.TypeFor[testSideBarItem]()); fields[1].name != "Label" {
+		t.Errorf("jsonFields() = %v", fields)
+	}
+}
+
+func TestUnmarshal_error(t *testing.T) {
+	var cc ClientComponent
+	var btn *Button
+	var item SideBarItem
+	tests := []struct {
+		name string
+		data string
+		v    any
+	}{
+		{name: "value", data: `{"component_type":"button","component":{}}`, v: nil},
+		{name: "json", data: `{`, v: &cc},
+		{name: "wrapper", data: `{"component_type":"button"}`, v: &cc},
+		{name: "unknown", data: `{"component_type":"unknown","component":{}}`, v: &cc},
+		{name: "type", data: `{"component_type":"label","component":{}}`, v: &btn},
+		{name: "not_component", data: `{"component_type":"sidebar_separator","component":{}}`, v: &cc},
+		{name: "field", data: `{"component_type":"button","component":{"id":1}}`, v: &cc},
+		{name: "interface", data: `{"component_type":"button","component":{"label_component":{}}}`, v: &cc},
+		{name: "interface_type", data: `{"component_type":"button","component":{"label_component":` +
+			`{"component_type":"sidebar_separator","component":{}}}}`, v: &cc},
+		{name: "struct", data: `{"component_type":"application","component":{"head_link":[1]}}`, v: &cc},
+		{name: "embedded", data: `{"component_type":"button","component":{"data":[]}}`, v: &cc},
+		{name: "map", data: `{"component_type":"base","component":{"data":[]}}`, v: &cc},
+		{name: "map_value", data: `{"component_type":"base","component":{"data":{"key":{"component_type":"unknown","component":{}}}}}`, v: &cc},
+		{name: "list_value", data: `{"component_type":"base","component":{"data":{"key":[{"component_type":"unknown","component":{}}]}}}`, v: &cc},
+		{name: "any_value", data: `{"component_type":"base","component":{"data":{"key":{"value":{"component_type":"unknown","component":{}}}}}}`, v: &cc},
+		{name: "slice", data: `{"component_type":"sidebar","component":{"items":{}}}`, v: &cc},
+		{name: "slice_value", data: `{"component_type":"sidebar","component":{"items":[{}]}}`, v: &item},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Unmarshal([]byte(tt.data), tt.v); err == nil {
+				t.Error("Unmarshal() error = nil")
+			}
+		})
+	}
+}
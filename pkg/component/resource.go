@@ -0,0 +1,838 @@
+package component
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	ut "github.com/nervatura/component/pkg/util"
+)
+
+// [Resource] constants
+const (
+	ResourceViewMain        = "main"
+	ResourceDefaultKeyField = "id"
+	ResourceDefaultColumns  = 2
+
+	ResourceActionSave    = "resource_save"
+	ResourceActionDelete  = "resource_delete"
+	ResourceActionNew     = "resource_new"
+	ResourceActionCancel  = "resource_cancel"
+	ResourceActionSimple  = "resource_simple"
+	ResourceActionBrowser = "resource_browser"
+
+	// The simple [Search] filter comparison: the field value contains the filter text (case-insensitive).
+	ResourceCompLike = "like"
+)
+
+/*
+The [Client] side menu values that leave the [Resource] editor. It can be used as the GuardSideMenu value of the Client.
+*/
+var ResourceGuardSideMenu []string = []string{
+	ResourceActionNew, ResourceActionCancel, ResourceActionSimple, ResourceActionBrowser,
+}
+
+/*
+ResourceRepository is an interface that defines the data access methods of a [Resource]. The resource parameter
+is the [Resource] Key value, so a single repository can serve all resources.
+*/
+type ResourceRepository interface {
+	/* Returns the rows of the resource. The filters are the [Browser] filter criterias or the simple [Search]
+	filters with the [ResourceCompLike] comparison (or connection).
+	*/
+	List(resource string, filters []BrowserFilter) (rows []ut.IM, err error)
+	// Returns the values of a resource record.
+	Get(resource string, id any) (values ut.IM, err error)
+	// Creates a new resource record and returns its key value.
+	Create(resource string, values ut.IM) (id any, err error)
+	// Updates the values of a resource record.
+	Update(resource string, id any, values ut.IM) error
+	// Deletes a resource record.
+	Delete(resource string, id any) error
+}
+
+// [Resource] schema field
+type ResourceField struct {
+	// The field name of the data source
+	Name string `json:"name"`
+	// The label of the field
+	Label string `json:"label"`
+	/* [FieldType] variable constants: [FieldTypeString], [FieldTypeText], [FieldTypeInteger], [FieldTypeNumber],
+	[FieldTypeDate], [FieldTypeTime], [FieldTypeDateTime], [FieldTypeBool], [FieldTypeSelect], [FieldTypeColor].
+	Default value: [FieldTypeString] */
+	Type string `json:"type"`
+	// The field value is required when saving
+	Required bool `json:"required"`
+	// Read only field in the editor
+	ReadOnly bool `json:"readonly"`
+	// The field is not displayed in the search result lists
+	HideList bool `json:"hide_list"`
+	// The field is not displayed in the editor
+	HideEditor bool `json:"hide_editor"`
+	// The field value is used by the simple search filter
+	Search bool `json:"search"`
+	// Options of the [FieldTypeSelect] field
+	Options []SelectOption `json:"options"`
+	// The options of the [FieldTypeSelect] field are loaded from another resource
+	Lookup *ResourceLookup `json:"lookup,omitempty"`
+	// The default value of a new record
+	Default any `json:"default"`
+}
+
+// [ResourceField] select options from another resource
+type ResourceLookup struct {
+	// The Key value of the referenced resource
+	Resource string `json:"resource"`
+	// The field name of the option value. Default value: [ResourceDefaultKeyField]
+	ValueField string `json:"value_field"`
+	// The field name of the option text
+	TextField string `json:"text_field"`
+}
+
+// [Resource] one-to-many relation. It is displayed as a table on a separate editor view.
+type ResourceRelation struct {
+	// The editor view key
+	Name string `json:"name"`
+	// The label of the editor view
+	Label string `json:"label"`
+	// Valid [Icon] component value. See more [IconValues] variable values.
+	Icon string `json:"icon"`
+	// The Key value of the child resource
+	Resource string `json:"resource"`
+	// The child resource field name that references the key value of the parent record
+	ForeignKey string `json:"foreign_key"`
+	// The displayed columns of the child rows
+	Fields []ResourceField `json:"fields"`
+	// The child resource definition. If it is set, the child rows can be opened and created from the relation table.
+	Child *Resource `json:"-"`
+}
+
+/*
+The Resource is a generic CRUD module definition. Based on the schema (fields, types, relations) and the
+[ResourceRepository], it produces the [Search], [Browser], [Editor] and [SideBar] components and handles the
+events of the module. The On... hooks can customize or override the default behavior.
+*/
+type Resource struct {
+	// Unique module key. It is used as the search view and editor key of the [Client]
+	Key string `json:"key"`
+	// The caption of the resource
+	Title string `json:"title"`
+	// Valid [Icon] component value. See more [IconValues] variable values.
+	Icon string `json:"icon"`
+	// The key field name of the records. Default value: [ResourceDefaultKeyField]
+	KeyField string `json:"key_field"`
+	// The number of the fields in an editor row. Default value: [ResourceDefaultColumns]
+	Columns int64 `json:"columns"`
+	// The fields of the resource
+	Fields []ResourceField `json:"fields"`
+	// The one-to-many relations of the resource
+	Relations []ResourceRelation `json:"relations"`
+	// Data access of the resource
+	Repository ResourceRepository `json:"-"`
+	// Customize or replace the generated simple search component
+	OnSearch func(sea Search, labels ut.SM, searchData ut.IM) Search `json:"-"`
+	// Customize or replace the generated browser component
+	OnBrowser func(bro Browser, labels ut.SM, searchData ut.IM) Browser `json:"-"`
+	// Customize or replace the generated editor component
+	OnEditor func(edi Editor, viewName string, labels ut.SM, editorData ut.IM) Editor `json:"-"`
+	// Customize or replace the generated side bar component
+	OnSideBar func(sb SideBar, moduleKey string, labels ut.SM, data ut.IM) SideBar `json:"-"`
+	// Called before the record is saved. It can modify the values or cancel the saving with an error.
+	OnSave func(cli *Client, values ut.IM, newRecord bool) (ut.IM, error) `json:"-"`
+	// Called before the record is deleted. It can cancel the deletion with an error.
+	OnDelete func(cli *Client, values ut.IM) error `json:"-"`
+	/* Called before the default event handling. If the handled value is true, the default
+	event handling is skipped and the returned event is the result.
+	*/
+	OnResponse func(cli *Client, evt ResponseEvent) (re ResponseEvent, handled bool) `json:"-"`
+}
+
+func (res *Resource) keyField() string {
+	return ut.ToString(res.KeyField, ResourceDefaultKeyField)
+}
+
+func (res *Resource) label(labels ut.SM, key, defValue string) string {
+	if value, found := labels[key]; found {
+		return value
+	}
+	return defValue
+}
+
+func (res *Resource) tableFields(fields []ResourceField) (tblFields []TableField) {
+	fieldType := func(ftype string) string {
+		if slices.Contains([]string{
+			TableFieldTypeInteger, TableFieldTypeNumber, TableFieldTypeDate, TableFieldTypeTime,
+			TableFieldTypeDateTime, TableFieldTypeBool}, ftype) {
+			return ftype
+		}
+		return TableFieldTypeString
+	}
+	tblFields = []TableField{}
+	for _, field := range fields {
+		if !field.HideList {
+			tblFields = append(tblFields, TableField{
+				Name: field.Name, Label: field.Label, FieldType: fieldType(field.Type),
+			})
+		}
+	}
+	return tblFields
+}
+
+func (res *Resource) searchFilters(value string) (filters []BrowserFilter) {
+	filters = []BrowserFilter{}
+	if value == "" {
+		return filters
+	}
+	for _, field := range res.Fields {
+		if field.Search {
+			filters = append(filters, BrowserFilter{
+				Or: (len(filters) > 0), Field: field.Name, Comp: ResourceCompLike, Value: value,
+			})
+		}
+	}
+	return filters
+}
+
+/*
+The Refresh function reloads the rows of the current search view. The simple search uses the last filter value,
+the browser uses the current filter criterias of the [Client].
+*/
+func (res *Resource) Refresh(cli *Client) (err error) {
+	searchData := cli.getDataIM("search", cli.Data)
+	filters := res.searchFilters(ut.ToString(searchData["filter_value"], ""))
+	if !ut.ToBoolean(searchData["simple"], false) {
+		filters = cli.GetSearchFilters("", []BrowserFilter{})
+	}
+	var rows []ut.IM
+	if rows, err = res.Repository.List(res.Key, filters); err == nil {
+		searchData["rows"] = rows
+		cli.SetProperty("data", cli.Data)
+		cli.CleanComponent("search")
+		cli.CleanComponent("browser")
+	}
+	return err
+}
+
+func (res *Resource) lookupOptions(lookup *ResourceLookup) (options []SelectOption) {
+	options = []SelectOption{}
+	if rows, err := res.Repository.List(lookup.Resource, []BrowserFilter{}); err == nil {
+		for _, row := range rows {
+			options = append(options, SelectOption{
+				Value: ut.ToString(row[ut.ToString(lookup.ValueField, ResourceDefaultKeyField)], ""),
+				Text:  ut.ToString(row[lookup.TextField], ""),
+			})
+		}
+	}
+	return options
+}
+
+func (res *Resource) editorField(field ResourceField, values ut.IM) Field {
+	fieldType := ut.ToString(field.Type, FieldTypeString)
+	value := ut.IM{
+		"name": field.Name, "value": values[field.Name], "disabled": field.ReadOnly,
+	}
+	switch fieldType {
+	case FieldTypeSelect:
+		options := field.Options
+		if field.Lookup != nil {
+			options = res.lookupOptions(field.Lookup)
+		}
+		value["options"] = options
+		value["is_null"] = !field.Required
+	case FieldTypeDate, FieldTypeTime, FieldTypeDateTime:
+		value["is_null"] = !field.Required
+	case FieldTypeBool:
+		value["value"] = ut.ToBoolean(values[field.Name], false)
+	case FieldTypeInteger, FieldTypeNumber:
+		value["readonly"] = field.ReadOnly
+	default:
+		value["readonly"] = field.ReadOnly
+		value["required"] = field.Required
+		value["invalid"] = field.Required && ut.ToString(values[field.Name], "") == ""
+	}
+	return Field{Type: fieldType, Value: value}
+}
+
+func (res *Resource) editorRows(values ut.IM) (rows []Row) {
+	rows = []Row{}
+	columns := int(ut.ToInteger(res.Columns, ResourceDefaultColumns))
+	for _, field := range res.Fields {
+		if field.HideEditor {
+			continue
+		}
+		if len(rows) == 0 || len(rows[len(rows)-1].Columns) == columns {
+			rows = append(rows, Row{Columns: []RowColumn{}, Full: true, BorderBottom: true})
+		}
+		row := &rows[len(rows)-1]
+		row.Columns = append(row.Columns, RowColumn{
+			Label: field.Label, Value: res.editorField(field, values),
+		})
+	}
+	return rows
+}
+
+func (res *Resource) relationRows(values ut.IM) (relations ut.IM, err error) {
+	relations = ut.IM{}
+	id := values[res.keyField()]
+	for _, rel := range res.Relations {
+		if id == nil {
+			relations[rel.Name] = []ut.IM{}
+			continue
+		}
+		var rows []ut.IM
+		if rows, err = res.Repository.List(rel.Resource, []BrowserFilter{
+			{Field: rel.ForeignKey, Comp: "==", Value: id},
+		}); err != nil {
+			return relations, err
+		}
+		relations[rel.Name] = rows
+	}
+	return relations, err
+}
+
+func (res *Resource) getRelation(name string) (rel ResourceRelation, found bool) {
+	idx := slices.IndexFunc(res.Relations, func(rel ResourceRelation) bool {
+		return rel.Name == name
+	})
+	if idx > -1 {
+		return res.Relations[idx], true
+	}
+	return rel, false
+}
+
+func (res *Resource) defaultValues(values ut.IM) ut.IM {
+	defValues := ut.IM{}
+	for _, field := range res.Fields {
+		if field.Default != nil {
+			defValues[field.Name] = field.Default
+		}
+	}
+	return ut.MergeIM(defValues, values)
+}
+
+func (res *Resource) setEditor(cli *Client, viewName string, values ut.IM, newRecord bool) (err error) {
+	var relations ut.IM
+	if relations, err = res.relationRows(values); err == nil {
+		cli.SetEditor(res.Key, viewName, ut.IM{
+			"id": values[res.keyField()], "values": values, "new": newRecord, "relations": relations,
+		})
+	}
+	return err
+}
+
+/*
+The Open function loads the record by the key value of the row and displays it in the [Editor].
+*/
+func (res *Resource) Open(cli *Client, row ut.IM) (err error) {
+	var values ut.IM
+	if values, err = res.Repository.Get(res.Key, row[res.keyField()]); err == nil {
+		err = res.setEditor(cli, ResourceViewMain, values, false)
+		cli.ResetHistory()
+	}
+	return err
+}
+
+/*
+The New function displays a new record in the [Editor]. The values are merged to the field default values.
+*/
+func (res *Resource) New(cli *Client, values ut.IM) (err error) {
+	return res.setEditor(cli, ResourceViewMain, res.defaultValues(values), true)
+}
+
+/*
+The Save function validates and saves the values of the current [Editor] record.
+*/
+func (res *Resource) Save(cli *Client) (err error) {
+	_, _, editorData := cli.GetStateData()
+	values := ut.MergeIM(ut.IM{}, ut.ToIM(editorData["values"], ut.IM{}))
+	newRecord := ut.ToBoolean(editorData["new"], false)
+	for _, field := range res.Fields {
+		if field.Required && ut.ToString(values[field.Name], "") == "" {
+			return fmt.Errorf("%s: %s", field.Label, res.label(cli.Labels(), "resource_required", "Missing required value"))
+		}
+	}
+	if res.OnSave != nil {
+		if values, err = res.OnSave(cli, values, newRecord); err != nil {
+			return err
+		}
+	}
+	if newRecord {
+		var id any
+		if id, err = res.Repository.Create(res.Key, values); err != nil {
+			return err
+		}
+		values[res.keyField()] = id
+	} else if err = res.Repository.Update(res.Key, values[res.keyField()], values); err != nil {
+		return err
+	}
+	if err = res.setEditor(cli, ut.ToString(editorData["view"], ResourceViewMain), values, false); err == nil {
+		cli.SetDirty(false)
+	}
+	return err
+}
+
+/*
+The Delete function deletes the current [Editor] record and returns to the search view.
+*/
+func (res *Resource) Delete(cli *Client) (err error) {
+	_, _, editorData := cli.GetStateData()
+	values := ut.ToIM(editorData["values"], ut.IM{})
+	if ut.ToBoolean(editorData["new"], false) {
+		return errors.New(res.label(cli.Labels(), "resource_new_delete", "The new record has not been saved"))
+	}
+	if res.OnDelete != nil {
+		if err = res.OnDelete(cli, values); err != nil {
+			return err
+		}
+	}
+	if err = res.Repository.Delete(res.Key, values[res.keyField()]); err == nil {
+		cli.ResetEditor()
+		err = res.Refresh(cli)
+	}
+	return err
+}
+
+/*
+The Search function returns the generated simple [Search] component of the resource.
+*/
+func (res *Resource) Search(labels ut.SM, searchData ut.IM) Search {
+	placeholder := []string{}
+	for _, field := range res.Fields {
+		if field.Search {
+			placeholder = append(placeholder, field.Label)
+		}
+	}
+	sea := Search{
+		Fields:            res.tableFields(res.Fields),
+		Rows:              ut.ToIMA(searchData["rows"], []ut.IM{}),
+		Title:             res.Title,
+		FilterPlaceholder: strings.Join(placeholder, ", "),
+		AutoFocus:         true,
+		Full:              true,
+		PageSize:          10,
+	}
+	if res.OnSearch != nil {
+		return res.OnSearch(sea, labels, searchData)
+	}
+	return sea
+}
+
+/*
+The Browser function returns the generated [Browser] component of the resource.
+*/
+func (res *Resource) Browser(labels ut.SM, searchData ut.IM) Browser {
+	visibleColumns := map[string]bool{}
+	fields := res.tableFields(res.Fields)
+	for _, field := range fields {
+		visibleColumns[field.Name] = true
+	}
+	bro := Browser{
+		Table: Table{
+			Fields:      fields,
+			Rows:        ut.ToIMA(searchData["rows"], []ut.IM{}),
+			RowKey:      res.keyField(),
+			TableFilter: true,
+			AddItem:     true,
+			PageSize:    10,
+		},
+		Title:          res.Title,
+		View:           res.Key,
+		HideBookmark:   true,
+		HideHelp:       true,
+		ExportLimit:    65000,
+		Download:       res.Key + ".csv",
+		Labels:         labels,
+		VisibleColumns: visibleColumns,
+	}
+	if res.OnBrowser != nil {
+		return res.OnBrowser(bro, labels, searchData)
+	}
+	return bro
+}
+
+/*
+The Editor function returns the generated [Editor] component of the resource. The main view contains the
+fields of the record, and every relation has a separate view with the child rows.
+*/
+func (res *Resource) Editor(viewName string, labels ut.SM, editorData ut.IM) Editor {
+	values := ut.ToIM(editorData["values"], ut.IM{})
+	relations := ut.ToIM(editorData["relations"], ut.IM{})
+	edi := Editor{
+		Title: res.Title,
+		Icon:  res.Icon,
+		View:  ut.ToString(viewName, ResourceViewMain),
+		Views: []EditorView{
+			{Key: ResourceViewMain, Label: res.Title, Icon: res.Icon},
+		},
+		Rows:   []Row{},
+		Tables: []Table{},
+	}
+	if rel, found := res.getRelation(edi.View); found {
+		edi.Tables = append(edi.Tables, Table{
+			BaseComponent: BaseComponent{
+				Data: ut.IM{"relation": rel.Name},
+			},
+			Fields:      res.tableFields(rel.Fields),
+			Rows:        ut.ToIMA(relations[rel.Name], []ut.IM{}),
+			TableFilter: true,
+			AddItem:     !ut.ToBoolean(editorData["new"], false),
+			RowSelected: true,
+			PageSize:    10,
+		})
+	} else {
+		edi.View = ResourceViewMain
+		edi.Rows = res.editorRows(values)
+	}
+	for _, rel := range res.Relations {
+		edi.Views = append(edi.Views, EditorView{
+			Key: rel.Name, Label: rel.Label, Icon: rel.Icon,
+			Badge: ut.ToString(len(ut.ToIMA(relations[rel.Name], []ut.IM{})), ""),
+		})
+	}
+	if res.OnEditor != nil {
+		return res.OnEditor(edi, viewName, labels, editorData)
+	}
+	return edi
+}
+
+/*
+The SideBar function returns the generated [SideBar] component of the resource. The moduleKey is the
+search state ("search" or "browser") or the editor key.
+*/
+func (res *Resource) SideBar(moduleKey string, labels ut.SM, data ut.IM) SideBar {
+	sb := SideBar{Items: []SideBarItem{}}
+	if moduleKey == "search" || moduleKey == "browser" {
+		sb.Items = []SideBarItem{
+			&SideBarSeparator{},
+			&SideBarElement{
+				Name: ResourceActionSimple, Value: ResourceActionSimple,
+				Label:    res.label(labels, "mnu_search_simple", "Simple Search"),
+				Icon:     IconBolt,
+				Selected: (moduleKey == "search"),
+			},
+			&SideBarElement{
+				Name: ResourceActionBrowser, Value: ResourceActionBrowser,
+				Label:    res.label(labels, "mnu_search_browser", "Browser Search"),
+				Icon:     IconSearch,
+				Selected: (moduleKey == "browser"),
+			},
+		}
+	} else {
+		newRecord := ut.ToBoolean(data["new"], false)
+		sb.Items = []SideBarItem{
+			&SideBarSeparator{},
+			&SideBarElement{
+				Name: ResourceActionCancel, Value: ResourceActionCancel,
+				Label:   res.label(labels, "browser_title", res.Title),
+				Icon:    IconReply,
+				NotFull: true,
+			},
+			&SideBarSeparator{},
+			&SideBarElement{
+				Name: ResourceActionSave, Value: ResourceActionSave,
+				Label: res.label(labels, "editor_save", "Save"),
+				Icon:  IconUpload,
+			},
+			&SideBarElement{
+				Name: ResourceActionDelete, Value: ResourceActionDelete,
+				Label:    res.label(labels, "editor_delete", "Delete"),
+				Icon:     IconTimes,
+				Disabled: newRecord,
+			},
+			&SideBarSeparator{},
+			&SideBarElement{
+				Name: ResourceActionNew, Value: ResourceActionNew,
+				Label:    res.label(labels, "editor_new", "New"),
+				Icon:     IconPlus,
+				Disabled: newRecord,
+			},
+		}
+	}
+	if res.OnSideBar != nil {
+		return res.OnSideBar(sb, moduleKey, labels, data)
+	}
+	return sb
+}
+
+func (res *Resource) fieldEvent(cli *Client, value ut.IM) (err error) {
+	_, _, editorData := cli.GetStateData()
+	fieldName := ut.ToString(value["name"], "")
+	if slices.ContainsFunc(res.Fields, func(field ResourceField) bool {
+		return field.Name == fieldName
+	}) {
+		values := ut.MergeIM(ut.IM{}, ut.ToIM(editorData["values"], ut.IM{}))
+		values[fieldName] = value["value"]
+		cli.SetEditor(res.Key, ut.ToString(editorData["view"], ResourceViewMain),
+			ut.MergeIM(ut.MergeIM(ut.IM{}, editorData), ut.IM{"values": values}))
+		cli.SetDirty(true)
+		return err
+	}
+	rel, found := res.getRelation(ut.ToString(ut.ToIM(value["data"], ut.IM{})["relation"], ""))
+	if !found || rel.Child == nil {
+		return err
+	}
+	return res.relationEvent(cli, rel, fieldName, value["value"])
+}
+
+func (res *Resource) relationEvent(cli *Client, rel ResourceRelation, evtName string, value any) (err error) {
+	child := rel.Child
+	switch evtName {
+	case TableEventAddItem:
+		_, _, editorData := cli.GetStateData()
+		parentID := ut.ToIM(editorData["values"], ut.IM{})[res.keyField()]
+		return child.New(cli, ut.IM{rel.ForeignKey: parentID})
+	case TableEventRowSelected, TableEventEditCell:
+		row := ut.ToIM(value, ut.IM{})
+		return child.Open(cli, ut.ToIM(row["row"], row))
+	}
+	return err
+}
+
+func (res *Resource) errorEvent(evt ResponseEvent, err error) ResponseEvent {
+	return ResponseEvent{
+		Trigger: &Toast{
+			Type:  ToastTypeError,
+			Value: err.Error(),
+		},
+		TriggerName: evt.TriggerName,
+		Name:        evt.Name,
+		Header: ut.SM{
+			HeaderRetarget: "#toast-msg",
+			HeaderReswap:   SwapInnerHTML,
+		},
+	}
+}
+
+/*
+The Response function handles the [Client] events of the resource. The handled value is false if the
+event does not belong to the resource.
+*/
+func (res *Resource) Response(cli *Client, evt ResponseEvent) (re ResponseEvent, handled bool) {
+	if res.OnResponse != nil {
+		if re, handled = res.OnResponse(cli, evt); handled {
+			return re, handled
+		}
+	}
+	rowData := func() ut.IM {
+		value := ut.ToIM(evt.Value, ut.IM{})
+		return ut.ToIM(value["row"], value)
+	}
+	var err error
+	evtMap := map[string]func(){
+		ClientEventModule: func() {
+			cli.SetSearch(res.Key, ut.IM{}, true)
+		},
+		SearchEventSearch: func() {
+			cli.getDataIM("search", cli.Data)["filter_value"] = ut.ToString(evt.Value, "")
+			err = res.Refresh(cli)
+		},
+		BrowserEventSearch: func() {
+			err = res.Refresh(cli)
+		},
+		SearchEventSelected: func() {
+			err = res.Open(cli, rowData())
+		},
+		BrowserEventEditRow: func() {
+			err = res.Open(cli, rowData())
+		},
+		TableEventAddItem: func() {
+			err = res.New(cli, ut.IM{})
+		},
+		EditorEventField: func() {
+			err = res.fieldEvent(cli, ut.ToIM(evt.Value, ut.IM{}))
+		},
+		ClientEventGuardSave: func() {
+			if err = res.Save(cli); err == nil {
+				re = cli.ResumeGuard()
+			}
+		},
+		ClientEventGuardDiscard: func() {
+			re = cli.ResumeGuard()
+		},
+		ClientEventSideMenu: func() {
+			sideMap := map[string]func(){
+				ResourceActionSave: func() {
+					err = res.Save(cli)
+				},
+				ResourceActionDelete: func() {
+					err = res.Delete(cli)
+				},
+				ResourceActionNew: func() {
+					err = res.New(cli, ut.IM{})
+				},
+				ResourceActionCancel: func() {
+					cli.ResetEditor()
+					err = res.Refresh(cli)
+				},
+				ResourceActionSimple: func() {
+					cli.SetSearch(res.Key, ut.IM{}, true)
+				},
+				ResourceActionBrowser: func() {
+					cli.SetSearch(res.Key, ut.IM{}, false)
+				},
+			}
+			if fn, found := sideMap[ut.ToString(evt.Value, "")]; found {
+				fn()
+				return
+			}
+			handled = false
+		},
+	}
+	fn, found := evtMap[evt.Name]
+	if !found {
+		return evt, false
+	}
+	re, handled = evt, true
+	fn()
+	if err != nil {
+		return res.errorEvent(evt, err), handled
+	}
+	return re, handled
+}
+
+/*
+ResourceFunctions implements the [ClientInterface] for the [Resource] modules. The search views and editors
+of the resources are generated, all other components and the labels are provided by the Custom functions.
+*/
+type ResourceFunctions struct {
+	// The resource modules of the client
+	Resources []*Resource
+	// Custom functions of the non-resource components and the labels. Optional.
+	Custom ClientInterface
+}
+
+/*
+The Resource function returns the resource with the specified key.
+*/
+func (rf *ResourceFunctions) Resource(key string) (res *Resource, found bool) {
+	idx := slices.IndexFunc(rf.Resources, func(res *Resource) bool {
+		return res.Key == key
+	})
+	if idx > -1 {
+		return rf.Resources[idx], true
+	}
+	return nil, false
+}
+
+func (rf *ResourceFunctions) Login(labels ut.SM, config ut.IM) Login {
+	if rf.Custom != nil {
+		return rf.Custom.Login(labels, config)
+	}
+	return Login{}
+}
+
+/*
+The Menu function returns the custom main menu. The default menu contains the theme switch,
+the resource modules and the logout items.
+*/
+func (rf *ResourceFunctions) Menu(labels ut.SM, config ut.IM) MenuBar {
+	if rf.Custom != nil {
+		return rf.Custom.Menu(labels, config)
+	}
+	theme := ut.ToString(config["theme"], ThemeLight)
+	mnu := MenuBar{
+		Items: []MenuBarItem{
+			{Value: "theme", Label: ThemeLabel(labels, ThemeNext(theme)), Icon: ThemeIcon(ThemeNext(theme))},
+		},
+		LabelMenu: labels["mnu_menu"],
+		LabelHide: labels["mnu_hide"],
+		SideBar:   true,
+	}
+	for _, res := range rf.Resources {
+		mnu.Items = append(mnu.Items, MenuBarItem{Value: res.Key, Label: res.Title, Icon: res.Icon})
+	}
+	if !ut.ToBoolean(config["login_disabled"], false) {
+		mnu.Items = append(mnu.Items, MenuBarItem{Value: "logout", Label: labels["mnu_logout"], Icon: IconExit})
+	}
+	return mnu
+}
+
+func (rf *ResourceFunctions) SideBar(moduleKey string, labels ut.SM, data ut.IM) SideBar {
+	resKey := moduleKey
+	if moduleKey == "search" || moduleKey == "browser" {
+		resKey = ut.ToString(data["view"], "")
+	}
+	if res, found := rf.Resource(resKey); found {
+		return res.SideBar(moduleKey, labels, data)
+	}
+	if rf.Custom != nil {
+		return rf.Custom.SideBar(moduleKey, labels, data)
+	}
+	return SideBar{Items: []SideBarItem{}}
+}
+
+func (rf *ResourceFunctions) Search(view string, labels ut.SM, searchData ut.IM) Search {
+	if res, found := rf.Resource(view); found {
+		return res.Search(labels, searchData)
+	}
+	if rf.Custom != nil {
+		return rf.Custom.Search(view, labels, searchData)
+	}
+	return Search{}
+}
+
+func (rf *ResourceFunctions) Browser(view string, labels ut.SM, searchData ut.IM) Browser {
+	if res, found := rf.Resource(view); found {
+		return res.Browser(labels, searchData)
+	}
+	if rf.Custom != nil {
+		return rf.Custom.Browser(view, labels, searchData)
+	}
+	return Browser{}
+}
+
+func (rf *ResourceFunctions) Editor(editorKey, viewName string, labels ut.SM, editorData ut.IM) Editor {
+	if res, found := rf.Resource(editorKey); found {
+		return res.Editor(viewName, labels, editorData)
+	}
+	if rf.Custom != nil {
+		return rf.Custom.Editor(editorKey, viewName, labels, editorData)
+	}
+	return Editor{Views: []EditorView{}, Rows: []Row{}, Tables: []Table{}}
+}
+
+func (rf *ResourceFunctions) Form(editorKey, formKey string, labels ut.SM, data ut.IM) (form Form) {
+	if rf.Custom != nil {
+		return rf.Custom.Form(editorKey, formKey, labels, data)
+	}
+	return Form{}
+}
+
+func (rf *ResourceFunctions) Modal(formKey string, labels ut.SM, data ut.IM) (form Form) {
+	if rf.Custom != nil {
+		return rf.Custom.Modal(formKey, labels, data)
+	}
+	return Form{}
+}
+
+func (rf *ResourceFunctions) Labels(lang string) ut.SM {
+	if rf.Custom != nil {
+		return rf.Custom.Labels(lang)
+	}
+	return ut.SM{}
+}
+
+/*
+The OnResponse function returns a [Client] OnResponse function. The events of the resource modules are
+handled by the [Resource], all other events are passed to the next function.
+*/
+func (rf *ResourceFunctions) OnResponse(next func(evt ResponseEvent) (re ResponseEvent)) func(evt ResponseEvent) (re ResponseEvent) {
+	return func(evt ResponseEvent) (re ResponseEvent) {
+		if cli, valid := evt.Trigger.(*Client); valid {
+			_, resKey, _ := cli.GetStateData()
+			if evt.Name == ClientEventModule {
+				resKey = ut.ToString(evt.Value, "")
+			}
+			if res, found := rf.Resource(resKey); found {
+				if re, handled := res.Response(cli, evt); handled {
+					return re
+				}
+			}
+		}
+		if next != nil {
+			return next(evt)
+		}
+		return evt
+	}
+}
@@ -0,0 +1,601 @@
+package component
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	ut "github.com/nervatura/component/pkg/util"
+)
+
+type testResourceRepository struct {
+	data   map[string][]ut.IM
+	nextID int64
+	err    error
+}
+
+func (rep *testResourceRepository) match(row ut.IM, filters []BrowserFilter) bool {
+	result := true
+	for index, filter := range filters {
+		value := ut.ToString(row[filter.Field], "")
+		match := (value == ut.ToString(filter.Value, ""))
+		if filter.Comp == ResourceCompLike {
+			match = strings.Contains(strings.ToLower(value), strings.ToLower(ut.ToString(filter.Value, "")))
+		}
+		if index == 0 {
+			result = match
+		} else if filter.Or {
+			result = result || match
+		} else {
+			result = result && match
+		}
+	}
+	return result
+}
+
+func (rep *testResourceRepository) index(resource string, id any) int {
+	return slices.IndexFunc(rep.data[resource], func(row ut.IM) bool {
+		return ut.ToString(row["id"], "") == ut.ToString(id, "")
+	})
+}
+
+func (rep *testResourceRepository) List(resource string, filters []BrowserFilter) (rows []ut.IM, err error) {
+	rows = []ut.IM{}
+	for _, row := range rep.data[resource] {
+		if rep.match(row, filters) {
+			rows = append(rows, row)
+		}
+	}
+	return rows, rep.err
+}
+
+func (rep *testResourceRepository) Get(resource string, id any) (values ut.IM, err error) {
+	if idx := rep.index(resource, id); idx > -1 {
+		return ut.MergeIM(ut.IM{}, rep.data[resource][idx]), rep.err
+	}
+	return values, errors.New("not found")
+}
+
+func (rep *testResourceRepository) Create(resource string, values ut.IM) (id any, err error) {
+	if rep.err != nil {
+		return id, rep.err
+	}
+	rep.nextID++
+	rep.data[resource] = append(rep.data[resource], ut.MergeIM(ut.IM{}, ut.MergeIM(values, ut.IM{"id": rep.nextID})))
+	return rep.nextID, nil
+}
+
+func (rep *testResourceRepository) Update(resource string, id any, values ut.IM) error {
+	if idx := rep.index(resource, id); idx > -1 && rep.err == nil {
+		rep.data[resource][idx] = ut.MergeIM(ut.IM{}, values)
+	}
+	return rep.err
+}
+
+func (rep *testResourceRepository) Delete(resource string, id any) error {
+	if idx := rep.index(resource, id); idx > -1 && rep.err == nil {
+		rep.data[resource] = slices.Delete(rep.data[resource], idx, idx+1)
+	}
+	return rep.err
+}
+
+func testResources(rep ResourceRepository) []*Resource {
+	contact := &Resource{
+		Key: "contact", Title: "Contact", Icon: IconPhone,
+		Fields: []ResourceField{
+			{Name: "id", Label: "ID", Type: FieldTypeInteger, ReadOnly: true},
+			{Name: "customer_id", Label: "Customer", Type: FieldTypeSelect, Required: true,
+				Lookup: &ResourceLookup{Resource: "customer", TextField: "name"}},
+			{Name: "phone", Label: "Phone", Search: true},
+		},
+		Repository: rep,
+	}
+	customer := &Resource{
+		Key: "customer", Title: "Customer", Icon: IconUser,
+		Fields: []ResourceField{
+			{Name: "id", Label: "ID", Type: FieldTypeInteger, ReadOnly: true},
+			{Name: "name", Label: "Name", Required: true, Search: true},
+			{Name: "city", Label: "City", Search: true},
+			{Name: "active", Label: "Active", Type: FieldTypeBool, Default: true},
+			{Name: "type", Label: "Type", Type: FieldTypeSelect, Options: []SelectOption{
+				{Value: "company", Text: "Company"}, {Value: "private", Text: "Private"}}},
+			{Name: "inactive_date", Label: "Inactive", Type: FieldTypeDate, HideList: true},
+			{Name: "notes", Label: "Notes", Type: FieldTypeText, HideList: true, HideEditor: true},
+		},
+		Relations: []ResourceRelation{
+			{Name: "contacts", Label: "Contacts", Icon: IconPhone, Resource: "contact", ForeignKey: "customer_id",
+				Fields: contact.Fields, Child: contact},
+		},
+		Repository: rep,
+	}
+	return []*Resource{customer, contact}
+}
+
+func testResourceRepositoryData() *testResourceRepository {
+	return &testResourceRepository{
+		data: map[string][]ut.IM{
+			"customer": {
+				{"id": 1, "name": "First Customer", "city": "Budapest", "active": true},
+				{"id": 2, "name": "Second Customer", "city": "Vienna", "active": false},
+			},
+			"contact": {
+				{"id": 1, "customer_id": 1, "phone": "+36 1 1234567"},
+			},
+		},
+		nextID: 2,
+	}
+}
+
+func testResourceClient(rf *ResourceFunctions, data ut.IM) *Client {
+	cli := &Client{
+		BaseComponent: BaseComponent{
+			Id: "client", EventURL: "/event", Data: data,
+			RequestValue: map[string]ut.IM{}, RequestMap: map[string]ClientComponent{},
+		},
+		LoginDisabled:   true,
+		UnsavedGuard:    true,
+		GuardSideMenu:   ResourceGuardSideMenu,
+		CustomFunctions: rf,
+	}
+	cli.OnResponse = rf.OnResponse(nil)
+	return cli
+}
+
+func TestResource_Response(t *testing.T) {
+	searchData := func(simple bool) ut.IM {
+		return ut.IM{"search": ut.IM{"view": "customer", "simple": simple}}
+	}
+	editorData := func(key, view string, values ut.IM, newRecord bool) ut.IM {
+		return ut.IM{
+			"search": ut.IM{"view": key, "simple": true},
+			"editor": ut.IM{"key": key, "view": view, "values": values, "new": newRecord},
+		}
+	}
+	customer := ut.IM{"id": 1, "name": "First Customer", "city": "Budapest"}
+	tests := []struct {
+		name      string
+		data      ut.IM
+		evt       ResponseEvent
+		repErr    error
+		wantName  string
+		wantState string
+		wantKey   string
+		wantRows  int
+	}{
+		{
+			name:      "module",
+			data:      ut.IM{},
+			evt:       ResponseEvent{TriggerName: "main_menu", Name: MenuBarEventValue, Value: "customer"},
+			wantName:  ClientEventModule,
+			wantState: "search", wantKey: "customer",
+		},
+		{
+			name:      "simple_search",
+			data:      searchData(true),
+			evt:       ResponseEvent{TriggerName: "search", Name: SearchEventSearch, Value: "budapest"},
+			wantName:  SearchEventSearch,
+			wantState: "search", wantKey: "customer", wantRows: 1,
+		},
+		{
+			name:      "simple_search_error",
+			data:      searchData(true),
+			evt:       ResponseEvent{TriggerName: "search", Name: SearchEventSearch, Value: "budapest"},
+			repErr:    errors.New("error"),
+			wantName:  SearchEventSearch,
+			wantState: "search", wantKey: "customer",
+		},
+		{
+			name:      "browser_search",
+			data:      searchData(false),
+			evt:       ResponseEvent{TriggerName: "browser", Name: BrowserEventSearch},
+			wantName:  BrowserEventSearch,
+			wantState: "browser", wantKey: "customer", wantRows: 2,
+		},
+		{
+			name:      "search_selected",
+			data:      searchData(true),
+			evt:       ResponseEvent{TriggerName: "search", Name: SearchEventSelected, Value: ut.IM{"row": ut.IM{"id": 1}}},
+			wantName:  SearchEventSelected,
+			wantState: "editor", wantKey: "customer",
+		},
+		{
+			name:      "search_selected_missing",
+			data:      searchData(true),
+			evt:       ResponseEvent{TriggerName: "search", Name: SearchEventSelected, Value: ut.IM{"row": ut.IM{"id": 9}}},
+			wantName:  SearchEventSelected,
+			wantState: "search", wantKey: "customer",
+		},
+		{
+			name: "browser_edit_row",
+			data: searchData(false),
+			evt: ResponseEvent{TriggerName: "browser", Name: BrowserEventEditRow,
+				Trigger: &Browser{}, Value: ut.IM{"id": 2}},
+			wantName:  BrowserEventEditRow,
+			wantState: "editor", wantKey: "customer",
+		},
+		{
+			name:      "browser_add_item",
+			data:      searchData(false),
+			evt:       ResponseEvent{TriggerName: "browser_table", Name: TableEventAddItem},
+			wantName:  TableEventAddItem,
+			wantState: "editor", wantKey: "customer",
+		},
+		{
+			name: "editor_field",
+			data: editorData("customer", "main", customer, false),
+			evt: ResponseEvent{TriggerName: "editor", Name: EditorEventField, Trigger: &Editor{},
+				Value: ut.IM{"name": "city", "value": "Prague"}},
+			wantName:  EditorEventField,
+			wantState: "editor", wantKey: "customer",
+		},
+		{
+			name: "editor_relation_add",
+			data: editorData("customer", "contacts", customer, false),
+			evt: ResponseEvent{TriggerName: "editor", Name: EditorEventField, Trigger: &Editor{},
+				Value: ut.IM{"name": TableEventAddItem, "data": ut.IM{"relation": "contacts"}}},
+			wantName:  EditorEventField,
+			wantState: "editor", wantKey: "contact",
+		},
+		{
+			name: "editor_relation_selected",
+			data: editorData("customer", "contacts", customer, false),
+			evt: ResponseEvent{TriggerName: "editor", Name: EditorEventField, Trigger: &Editor{},
+				Value: ut.IM{"name": TableEventRowSelected, "value": ut.IM{"row": ut.IM{"id": 1}},
+					"data": ut.IM{"relation": "contacts"}}},
+			wantName:  EditorEventField,
+			wantState: "editor", wantKey: "contact",
+		},
+		{
+			name: "editor_relation_sort",
+			data: editorData("customer", "contacts", customer, false),
+			evt: ResponseEvent{TriggerName: "editor", Name: EditorEventField, Trigger: &Editor{},
+				Value: ut.IM{"name": TableEventSort, "data": ut.IM{"relation": "contacts"}}},
+			wantName:  EditorEventField,
+			wantState: "editor", wantKey: "customer",
+		},
+		{
+			name: "editor_unknown_field",
+			data: editorData("customer", "main", customer, false),
+			evt: ResponseEvent{TriggerName: "editor", Name: EditorEventField, Trigger: &Editor{},
+				Value: ut.IM{"name": "unknown"}},
+			wantName:  EditorEventField,
+			wantState: "editor", wantKey: "customer",
+		},
+		{
+			name:      "save",
+			data:      editorData("customer", "main", customer, false),
+			evt:       ResponseEvent{TriggerName: "side_menu", Name: SideBarEventItem, Value: ResourceActionSave},
+			wantName:  ClientEventSideMenu,
+			wantState: "editor", wantKey: "customer",
+		},
+		{
+			name:      "save_new",
+			data:      editorData("customer", "main", ut.IM{"name": "New Customer"}, true),
+			evt:       ResponseEvent{TriggerName: "side_menu", Name: SideBarEventItem, Value: ResourceActionSave},
+			wantName:  ClientEventSideMenu,
+			wantState: "editor", wantKey: "customer",
+		},
+		{
+			name:      "save_new_error",
+			data:      editorData("customer", "main", ut.IM{"name": "New Customer"}, true),
+			evt:       ResponseEvent{TriggerName: "side_menu", Name: SideBarEventItem, Value: ResourceActionSave},
+			repErr:    errors.New("error"),
+			wantName:  ClientEventSideMenu,
+			wantState: "editor", wantKey: "customer",
+		},
+		{
+			name:      "save_update_error",
+			data:      editorData("customer", "main", customer, false),
+			evt:       ResponseEvent{TriggerName: "side_menu", Name: SideBarEventItem, Value: ResourceActionSave},
+			repErr:    errors.New("error"),
+			wantName:  ClientEventSideMenu,
+			wantState: "editor", wantKey: "customer",
+		},
+		{
+			name:      "save_required",
+			data:      editorData("customer", "main", ut.IM{"id": 1}, false),
+			evt:       ResponseEvent{TriggerName: "side_menu", Name: SideBarEventItem, Value: ResourceActionSave},
+			wantName:  ClientEventSideMenu,
+			wantState: "editor", wantKey: "customer",
+		},
+		{
+			name:      "save_hook_error",
+			data:      editorData("contact", "main", ut.IM{"id": 1, "customer_id": 1, "phone": "error"}, false),
+			evt:       ResponseEvent{TriggerName: "side_menu", Name: SideBarEventItem, Value: ResourceActionSave},
+			wantName:  ClientEventSideMenu,
+			wantState: "editor", wantKey: "contact",
+		},
+		{
+			name:      "delete",
+			data:      editorData("customer", "main", customer, false),
+			evt:       ResponseEvent{TriggerName: "side_menu", Name: SideBarEventItem, Value: ResourceActionDelete},
+			wantName:  ClientEventSideMenu,
+			wantState: "search", wantKey: "customer", wantRows: 1,
+		},
+		{
+			name:      "delete_new",
+			data:      editorData("customer", "main", ut.IM{}, true),
+			evt:       ResponseEvent{TriggerName: "side_menu", Name: SideBarEventItem, Value: ResourceActionDelete},
+			wantName:  ClientEventSideMenu,
+			wantState: "editor", wantKey: "customer",
+		},
+		{
+			name:      "delete_hook_error",
+			data:      editorData("contact", "main", ut.IM{"id": 1}, false),
+			evt:       ResponseEvent{TriggerName: "side_menu", Name: SideBarEventItem, Value: ResourceActionDelete},
+			wantName:  ClientEventSideMenu,
+			wantState: "editor", wantKey: "contact",
+		},
+		{
+			name:      "new",
+			data:      editorData("customer", "main", customer, false),
+			evt:       ResponseEvent{TriggerName: "side_menu", Name: SideBarEventItem, Value: ResourceActionNew},
+			wantName:  ClientEventSideMenu,
+			wantState: "editor", wantKey: "customer",
+		},
+		{
+			name:      "cancel",
+			data:      editorData("customer", "main", customer, false),
+			evt:       ResponseEvent{TriggerName: "side_menu", Name: SideBarEventItem, Value: ResourceActionCancel},
+			wantName:  ClientEventSideMenu,
+			wantState: "search", wantKey: "customer", wantRows: 2,
+		},
+		{
+			name:      "browser_view",
+			data:      searchData(true),
+			evt:       ResponseEvent{TriggerName: "side_menu", Name: SideBarEventItem, Value: ResourceActionBrowser},
+			wantName:  ClientEventSideMenu,
+			wantState: "browser", wantKey: "customer",
+		},
+		{
+			name:      "simple_view",
+			data:      searchData(false),
+			evt:       ResponseEvent{TriggerName: "side_menu", Name: SideBarEventItem, Value: ResourceActionSimple},
+			wantName:  ClientEventSideMenu,
+			wantState: "search", wantKey: "customer",
+		},
+		{
+			name:      "side_menu_unknown",
+			data:      searchData(false),
+			evt:       ResponseEvent{TriggerName: "side_menu", Name: SideBarEventItem, Value: "unknown"},
+			wantName:  ClientEventSideMenu,
+			wantState: "browser", wantKey: "customer",
+		},
+		{
+			name:      "response_hook",
+			data:      editorData("contact", "main", ut.IM{"id": 1}, false),
+			evt:       ResponseEvent{TriggerName: "side_menu", Name: SideBarEventItem, Value: "contact_hook"},
+			wantName:  "contact_hook",
+			wantState: "editor", wantKey: "contact",
+		},
+		{
+			name:      "not_resource_event",
+			data:      editorData("customer", "main", customer, false),
+			evt:       ResponseEvent{TriggerName: "main_menu", Name: MenuBarEventSide},
+			wantName:  ClientEventSide,
+			wantState: "editor", wantKey: "customer",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rep := testResourceRepositoryData()
+			rf := &ResourceFunctions{Resources: testResources(rep)}
+			contact, _ := rf.Resource("contact")
+			contact.OnSave = func(cli *Client, values ut.IM, newRecord bool) (ut.IM, error) {
+				if values["phone"] == "error" {
+					return values, errors.New("invalid phone")
+				}
+				return values, nil
+			}
+			contact.OnDelete = func(cli *Client, values ut.IM) error {
+				return errors.New("the contact can not be deleted")
+			}
+			contact.OnResponse = func(cli *Client, evt ResponseEvent) (re ResponseEvent, handled bool) {
+				if evt.Value == "contact_hook" {
+					return ResponseEvent{Name: "contact_hook"}, true
+				}
+				return evt, false
+			}
+			cli := testResourceClient(rf, tt.data)
+			rep.err = tt.repErr
+			got := cli.response(tt.evt)
+			if tt.repErr != nil {
+				if _, valid := got.Trigger.(*Toast); !valid {
+					t.Errorf("Resource.Response() = %v, want error toast", got.Trigger)
+				}
+				return
+			}
+			if _, valid := got.Trigger.(*Toast); !valid && got.Name != tt.wantName {
+				t.Errorf("Resource.Response() = %v, want %v", got.Name, tt.wantName)
+			}
+			state, key, data := cli.GetStateData()
+			if state != tt.wantState || key != tt.wantKey {
+				t.Errorf("Client.GetStateData() = %v %v, want %v %v", state, key, tt.wantState, tt.wantKey)
+			}
+			if rows := ut.ToIMA(data["rows"], []ut.IM{}); tt.wantRows > 0 && len(rows) != tt.wantRows {
+				t.Errorf("Resource.Refresh() = %v, want %v", len(rows), tt.wantRows)
+			}
+			if _, err := cli.Render(); err != nil {
+				t.Errorf("Client.Render() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestResource_Guard(t *testing.T) {
+	rep := testResourceRepositoryData()
+	rf := &ResourceFunctions{Resources: testResources(rep)}
+	cli := testResourceClient(rf, ut.IM{
+		"search": ut.IM{"view": "customer", "simple": true},
+		"editor": ut.IM{"key": "customer", "view": "main", "values": ut.IM{"id": 1}, "dirty": true},
+	})
+	cli.response(ResponseEvent{TriggerName: "side_menu", Name: SideBarEventItem, Value: ResourceActionCancel})
+	if got := cli.response(ResponseEvent{TriggerName: "modal", Name: FormEventCancel, Trigger: &Form{},
+		Value: ut.IM{"value": ut.IM{ClientEventGuardSave: ClientEventGuardSave}}}); got.Name != ClientEventGuardSave {
+		t.Errorf("Resource.Response() = %v, want %v", got.Name, ClientEventGuardSave)
+	}
+	cli.Data["editor"] = ut.IM{"key": "customer", "view": "main", "values": ut.IM{"id": 1, "name": "Customer"}, "dirty": true}
+	cli.response(ResponseEvent{TriggerName: "side_menu", Name: SideBarEventItem, Value: ResourceActionCancel})
+	cli.response(ResponseEvent{TriggerName: "modal", Name: FormEventCancel, Trigger: &Form{},
+		Value: ut.IM{"value": ut.IM{ClientEventGuardSave: ClientEventGuardSave}}})
+	if state, _, _ := cli.GetStateData(); state != "search" {
+		t.Errorf("Client.GetStateData() = %v, want %v", state, "search")
+	}
+	cli.Data["editor"] = ut.IM{"key": "customer", "view": "main", "values": ut.IM{"id": 1}, "dirty": true}
+	cli.response(ResponseEvent{TriggerName: "side_menu", Name: SideBarEventItem, Value: ResourceActionNew})
+	cli.response(ResponseEvent{TriggerName: "modal", Name: FormEventCancel, Trigger: &Form{},
+		Value: ut.IM{"value": ut.IM{ClientEventGuardDiscard: ClientEventGuardDiscard}}})
+	if got := ut.ToBoolean(cli.Data["editor"].(ut.IM)["new"], false); !got {
+		t.Errorf("Client editor new = %v, want %v", got, true)
+	}
+}
+
+func TestResource_History(t *testing.T) {
+	rep := testResourceRepositoryData()
+	res := testResources(rep)[0]
+	cli := testResourceClient(&ResourceFunctions{Resources: []*Resource{res}}, ut.IM{})
+	res.Open(cli, ut.IM{"id": 1})
+	res.fieldEvent(cli, ut.IM{"name": "name", "value": "Customer"})
+	if got := cli.HistoryState(); got["undo"] != 1 || got["dirty"] != true {
+		t.Errorf("Client.HistoryState() = %v", got)
+	}
+	// the other record of the same resource starts a new history
+	res.Open(cli, ut.IM{"id": 2})
+	if got := cli.HistoryState(); got["undo"] != 0 || cli.Undo() {
+		t.Errorf("Client.HistoryState() = %v", got)
+	}
+	// the reloaded record drops the history
+	res.fieldEvent(cli, ut.IM{"name": "name", "value": "Customer"})
+	res.Open(cli, ut.IM{"id": 2})
+	if got := cli.HistoryState(); got["undo"] != 0 {
+		t.Errorf("Client.HistoryState() = %v", got)
+	}
+}
+
+func TestResource_Hooks(t *testing.T) {
+	rep := testResourceRepositoryData()
+	res := testResources(rep)[0]
+	res.OnSearch = func(sea Search, labels ut.SM, searchData ut.IM) Search {
+		sea.Title = "Custom"
+		return sea
+	}
+	res.OnBrowser = func(bro Browser, labels ut.SM, searchData ut.IM) Browser {
+		bro.Title = "Custom"
+		return bro
+	}
+	res.OnEditor = func(edi Editor, viewName string, labels ut.SM, editorData ut.IM) Editor {
+		edi.Title = "Custom"
+		return edi
+	}
+	res.OnSideBar = func(sb SideBar, moduleKey string, labels ut.SM, data ut.IM) SideBar {
+		sb.Items = []SideBarItem{}
+		return sb
+	}
+	if got := res.Search(ut.SM{}, ut.IM{}); got.Title != "Custom" {
+		t.Errorf("Resource.Search() = %v, want %v", got.Title, "Custom")
+	}
+	if got := res.Browser(ut.SM{}, ut.IM{}); got.Title != "Custom" {
+		t.Errorf("Resource.Browser() = %v, want %v", got.Title, "Custom")
+	}
+	if got := res.Editor("contacts", ut.SM{}, ut.IM{}); got.Title != "Custom" {
+		t.Errorf("Resource.Editor() = %v, want %v", got.Title, "Custom")
+	}
+	if got := res.SideBar("customer", ut.SM{}, ut.IM{}); len(got.Items) != 0 {
+		t.Errorf("Resource.SideBar() = %v, want %v", len(got.Items), 0)
+	}
+	rep.err = errors.New("error")
+	if err := res.New(&Client{}, ut.IM{"id": 1}); err == nil {
+		t.Errorf("Resource.New() error = %v, want error", err)
+	}
+}
+
+func TestResourceFunctions(t *testing.T) {
+	rf := &ResourceFunctions{Resources: testResources(testResourceRepositoryData())}
+	tests := []struct {
+		name   string
+		custom ClientInterface
+		data   ut.IM
+	}{
+		{
+			name: "default_search",
+			data: ut.IM{"search": ut.IM{"view": "customer", "simple": true}},
+		},
+		{
+			name: "default_browser",
+			data: ut.IM{"search": ut.IM{"view": "customer", "simple": false}},
+		},
+		{
+			name: "default_editor",
+			data: ut.IM{"editor": ut.IM{"key": "customer", "view": "contacts",
+				"values": ut.IM{"id": 1}, "relations": ut.IM{"contacts": []any{ut.IM{"id": 1}}}}},
+		},
+		{
+			name: "default_other",
+			data: ut.IM{"editor": ut.IM{"key": "setting", "form": ut.IM{"key": "setting"}}, "modal": ut.IM{"key": "info"}},
+		},
+		{
+			name: "default_other_search",
+			data: ut.IM{"search": ut.IM{"view": "other", "simple": true}},
+		},
+		{
+			name: "default_other_browser",
+			data: ut.IM{"search": ut.IM{"view": "other", "simple": false}},
+		},
+		{
+			name: "default_other_editor",
+			data: ut.IM{"editor": ut.IM{"key": "other"}},
+		},
+		{
+			name:   "custom_search",
+			custom: &testCustomFunctions{},
+			data:   ut.IM{"search": ut.IM{"view": "customer_simple", "simple": true}},
+		},
+		{
+			name:   "custom_browser",
+			custom: &testCustomFunctions{},
+			data:   ut.IM{"search": ut.IM{"view": "customer_browser", "simple": false}},
+		},
+		{
+			name:   "custom_editor",
+			custom: &testCustomFunctions{},
+			data:   ut.IM{"editor": ut.IM{"key": "customer_demo", "view": "main"}},
+		},
+		{
+			name:   "custom_resource",
+			custom: &testCustomFunctions{},
+			data:   ut.IM{"search": ut.IM{"view": "customer", "simple": true}},
+		},
+		{
+			name:   "custom_form",
+			custom: &testCustomFunctions{},
+			data:   ut.IM{"editor": ut.IM{"key": "setting", "form": ut.IM{"key": "setting"}}, "modal": ut.IM{"key": "info"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rf.Custom = tt.custom
+			cli := testResourceClient(rf, tt.data)
+			if _, err := cli.Render(); err != nil {
+				t.Errorf("Client.Render() error = %v", err)
+			}
+			cli.LoginDisabled = false
+			for _, ticket := range []Ticket{{}, {SessionID: "SES012345", User: ut.IM{"username": "admin"}}} {
+				cli.Ticket = ticket
+				if _, err := cli.Render(); err != nil {
+					t.Errorf("Client.Render() error = %v", err)
+				}
+			}
+		})
+	}
+	next := func(evt ResponseEvent) (re ResponseEvent) {
+		evt.Name = "next"
+		return evt
+	}
+	if got := rf.OnResponse(next)(ResponseEvent{Trigger: &BaseComponent{}}); got.Name != "next" {
+		t.Errorf("ResourceFunctions.OnResponse() = %v, want %v", got.Name, "next")
+	}
+	if got := rf.OnResponse(nil)(ResponseEvent{Trigger: &BaseComponent{}, Name: "event"}); got.Name != "event" {
+		t.Errorf("ResourceFunctions.OnResponse() = %v, want %v", got.Name, "event")
+	}
+}
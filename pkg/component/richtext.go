@@ -0,0 +1,548 @@
+package component
+
+import (
+	"html/template"
+	"io"
+	"slices"
+	"strings"
+
+	ut "github.com/nervatura/component/pkg/util"
+)
+
+// [RichText] constants
+const (
+	ComponentTypeRichText = "richtext"
+
+	RichTextEventChange = "richtext_change"
+
+	RichTextToolBold          = "bold"
+	RichTextToolItalic        = "italic"
+	RichTextToolUnderline     = "underline"
+	RichTextToolStrikethrough = "strikethrough"
+	RichTextToolHeading       = "heading"
+	RichTextToolUnorderedList = "unordered_list"
+	RichTextToolOrderedList   = "ordered_list"
+	RichTextToolLink          = "link"
+	RichTextToolTable         = "table"
+	RichTextToolCode          = "code"
+)
+
+// [RichText] Toolbar values
+var RichTextToolbar []string = []string{
+	RichTextToolBold, RichTextToolItalic, RichTextToolUnderline, RichTextToolStrikethrough, RichTextToolHeading,
+	RichTextToolUnorderedList, RichTextToolOrderedList, RichTextToolLink, RichTextToolTable, RichTextToolCode,
+}
+
+var richTextDefaultLabel ut.SM = ut.SM{
+	"richtext_bold":           "Bold",
+	"richtext_italic":         "Italic",
+	"richtext_underline":      "Underline",
+	"richtext_strikethrough":  "Strikethrough",
+	"richtext_heading":        "Heading",
+	"richtext_unordered_list": "Bulleted list",
+	"richtext_ordered_list":   "Numbered list",
+	"richtext_link":           "Link",
+	"richtext_table":          "Table",
+	"richtext_code":           "Code block",
+	"richtext_link_url":       "Link URL (empty value removes the link)",
+}
+
+// The toolbar button of the [RichText]
+type richTextTool struct {
+	Name string
+	Icon string
+	// The document.execCommand name and argument of the button
+	Command string
+	Arg     string
+	// The button is shown only if one of the elements is allowed by the policy
+	Tags []string
+}
+
+var richTextTools map[string]richTextTool = map[string]richTextTool{
+	RichTextToolBold:      {Icon: IconBold, Command: "bold", Tags: []string{"b", "strong"}},
+	RichTextToolItalic:    {Icon: IconItalic, Command: "italic", Tags: []string{"i", "em"}},
+	RichTextToolUnderline: {Icon: IconUnderline, Command: "underline", Tags: []string{"u"}},
+	RichTextToolStrikethrough: {
+		Icon: IconStrikethrough, Command: "strikeThrough", Tags: []string{"s", "strike", "del"}},
+	RichTextToolHeading: {Icon: IconTextHeight, Command: "formatBlock", Arg: "h2", Tags: []string{"h2"}},
+	RichTextToolUnorderedList: {
+		Icon: IconListUl, Command: "insertUnorderedList", Tags: []string{"ul"}},
+	RichTextToolOrderedList: {Icon: IconListOl, Command: "insertOrderedList", Tags: []string{"ol"}},
+	RichTextToolLink:        {Icon: IconLink, Command: "createLink", Tags: []string{"a"}},
+	RichTextToolTable: {Icon: IconTh, Command: "insertHTML", Tags: []string{"table"},
+		Arg: "<table><tbody><tr><td><br></td><td><br></td></tr><tr><td><br></td><td><br></td></tr></tbody></table><p><br></p>"},
+	RichTextToolCode: {Icon: IconCode, Command: "formatBlock", Arg: "pre", Tags: []string{"pre"}},
+}
+
+/*
+Creates a rich text editor control with a formatting toolbar (bold, italic, lists, links, tables, headings ...).
+
+The submitted HTML text is sanitized against the allow-list of the Policy before the OnResponse function is
+called, so the Value always contains only the allowed elements and attributes. In Markdown mode the Value is
+stored as Markdown text, and it is converted to HTML for the editing.
+
+For example:
+
+	&RichText{
+	  BaseComponent: BaseComponent{
+	    Id:       "id_richtext_notes",
+	    EventURL: "/event",
+	  },
+	  Value:    "**Product** description",
+	  Markdown: true,
+	  Toolbar:  []string{RichTextToolBold, RichTextToolItalic, RichTextToolUnorderedList, RichTextToolLink},
+	}
+*/
+type RichText struct {
+	BaseComponent
+	// The HTML or Markdown (see the Markdown property) text value of the editor
+	Value string `json:"value"`
+	// Specifies a short hint that is displayed in the empty editor
+	Placeholder string `json:"placeholder"`
+	// The HTML aria-label attribute of the component
+	Label string `json:"label"`
+	/* The buttons of the toolbar. [RichTextToolbar] variable values. The buttons of the elements not allowed
+	by the Policy are not shown. Default value: all [RichTextToolbar] values */
+	Toolbar []string `json:"toolbar"`
+	// The Value is stored as Markdown text. The [RichTextToolUnderline] button is not available.
+	Markdown bool `json:"markdown"`
+	// The allowed elements and attributes of the value. Default value: [ut.DefaultHTMLPolicy]
+	Policy ut.HTMLPolicy `json:"policy"`
+	// Specifies the minimum visible number of lines of the editor
+	Rows int64 `json:"rows"`
+	// Specifies that the editor should be disabled
+	Disabled bool `json:"disabled"`
+	// Specifies that the editor is read-only. The toolbar is not shown.
+	ReadOnly bool `json:"readonly"`
+	// Specifies that the editor should automatically get focus when the page loads
+	AutoFocus bool `json:"auto_focus"`
+	// Sets the values of the invalid class style
+	Invalid bool `json:"invalid"`
+	// Full width editor (100%)
+	Full bool `json:"full"`
+	// The texts of the labels of the toolbar buttons
+	Labels ut.SM `json:"labels"`
+}
+
+/*
+Returns all properties of the [RichText]
+*/
+func (rte *RichText) Properties() ut.IM {
+	return ut.MergeIM(
+		rte.BaseComponent.Properties(),
+		ut.IM{
+			"value":       rte.Value,
+			"placeholder": rte.Placeholder,
+			"label":       rte.Label,
+			"toolbar":     rte.Toolbar,
+			"markdown":    rte.Markdown,
+			"policy":      rte.Policy,
+			"rows":        rte.Rows,
+			"disabled":    rte.Disabled,
+			"readonly":    rte.ReadOnly,
+			"auto_focus":  rte.AutoFocus,
+			"invalid":     rte.Invalid,
+			"full":        rte.Full,
+			"labels":      rte.Labels,
+		})
+}
+
+/*
+Returns the value of the property of the [RichText] with the specified name.
+*/
+func (rte *RichText) GetProperty(propName string) interface{} {
+	return rte.Properties()[propName]
+}
+
+/*
+It checks the value given to the property of the [RichText] and always returns a valid value
+*/
+func (rte *RichText) Validation(propName string, propValue interface{}) interface{} {
+	pm := map[string]func() interface{}{
+		"toolbar": func() interface{} {
+			toolbar := []string{}
+			for _, tool := range ut.ILtoSL(propValue) {
+				if slices.Contains(RichTextToolbar, tool) && !slices.Contains(toolbar, tool) {
+					toolbar = append(toolbar, tool)
+				}
+			}
+			if len(toolbar) == 0 {
+				return slices.Clone(RichTextToolbar)
+			}
+			return toolbar
+		},
+		"policy": func() interface{} {
+			if policy, valid := propValue.(ut.HTMLPolicy); valid {
+				return policy
+			}
+			policy := ut.HTMLPolicy{}
+			if err := ut.ConvertToType(propValue, &policy); err != nil || policy == nil {
+				return ut.HTMLPolicy{}
+			}
+			return policy
+		},
+		"labels": func() interface{} {
+			value := ut.ToSM(rte.Labels, ut.SM{})
+			switch v := propValue.(type) {
+			case ut.SM:
+				value = ut.MergeSM(value, v)
+			case ut.IM:
+				value = ut.MergeSM(value, ut.IMToSM(v))
+			}
+			if len(value) == 0 {
+				value = richTextDefaultLabel
+			}
+			return value
+		},
+		"target": func() interface{} {
+			rte.SetProperty("id", rte.Id)
+			value := ut.ToString(propValue, rte.Id)
+			if value != "this" && !strings.HasPrefix(value, "#") {
+				value = "#" + value
+			}
+			return value
+		},
+	}
+	if _, found := pm[propName]; found {
+		return pm[propName]()
+	}
+	if rte.BaseComponent.GetProperty(propName) != nil {
+		return rte.BaseComponent.Validation(propName, propValue)
+	}
+	return propValue
+}
+
+/*
+Setting a property of the [RichText] value safely. Checks the entered value.
+In case of an invalid value, the default value will be set.
+*/
+func (rte *RichText) SetProperty(propName string, propValue interface{}) interface{} {
+	pm := map[string]func() interface{}{
+		"value": func() interface{} {
+			rte.Value = ut.ToString(propValue, "")
+			return rte.Value
+		},
+		"placeholder": func() interface{} {
+			rte.Placeholder = ut.ToString(propValue, "")
+			return rte.Placeholder
+		},
+		"label": func() interface{} {
+			rte.Label = ut.ToString(propValue, "")
+			return rte.Label
+		},
+		"toolbar": func() interface{} {
+			rte.Toolbar = rte.Validation(propName, propValue).([]string)
+			return rte.Toolbar
+		},
+		"markdown": func() interface{} {
+			rte.Markdown = ut.ToBoolean(propValue, false)
+			return rte.Markdown
+		},
+		"policy": func() interface{} {
+			rte.Policy = rte.Validation(propName, propValue).(ut.HTMLPolicy)
+			return rte.Policy
+		},
+		"rows": func() interface{} {
+			rte.Rows = ut.ToInteger(propValue, 0)
+			return rte.Rows
+		},
+		"disabled": func() interface{} {
+			rte.Disabled = ut.ToBoolean(propValue, false)
+			return rte.Disabled
+		},
+		"readonly": func() interface{} {
+			rte.ReadOnly = ut.ToBoolean(propValue, false)
+			return rte.ReadOnly
+		},
+		"auto_focus": func() interface{} {
+			rte.AutoFocus = ut.ToBoolean(propValue, false)
+			return rte.AutoFocus
+		},
+		"invalid": func() interface{} {
+			rte.Invalid = ut.ToBoolean(propValue, false)
+			return rte.Invalid
+		},
+		"full": func() interface{} {
+			rte.Full = ut.ToBoolean(propValue, false)
+			return rte.Full
+		},
+		"labels": func() interface{} {
+			rte.Labels = rte.Validation(propName, propValue).(ut.SM)
+			return rte.Labels
+		},
+		"target": func() interface{} {
+			rte.Target = rte.Validation(propName, propValue).(string)
+			return rte.Target
+		},
+	}
+	if _, found := pm[propName]; found {
+		return rte.SetRequestValue(propName, pm[propName](), []string{})
+	}
+	if rte.BaseComponent.GetProperty(propName) != nil {
+		return rte.BaseComponent.SetProperty(propName, propValue)
+	}
+	return propValue
+}
+
+func (rte *RichText) msg(labelID string) string {
+	if label, found := rte.Labels[labelID]; found {
+		return label
+	}
+	return richTextDefaultLabel[labelID]
+}
+
+func (rte *RichText) policy() ut.HTMLPolicy {
+	if len(rte.Policy) == 0 {
+		return ut.DefaultHTMLPolicy
+	}
+	return rte.Policy
+}
+
+// Returns the sanitized HTML text of the Value
+func (rte *RichText) content() string {
+	if rte.Markdown {
+		return ut.SanitizeHTML(ut.MarkdownToHTML(rte.Value), rte.policy())
+	}
+	return ut.SanitizeHTML(rte.Value, rte.policy())
+}
+
+// Returns the visible toolbar buttons
+func (rte *RichText) toolbar() (tools []richTextTool) {
+	policy := rte.policy()
+	for _, name := range rte.Toolbar {
+		tool := richTextTools[name]
+		if rte.Markdown && name == RichTextToolUnderline {
+			continue
+		}
+		if slices.ContainsFunc(tool.Tags, func(tag string) bool { _, found := policy[tag]; return found }) {
+			tool.Name = name
+			tools = append(tools, tool)
+		}
+	}
+	return tools
+}
+
+/*
+If the OnResponse function of the [RichText] is implemented, the function calls it after the [TriggerEvent]
+is processed, otherwise the function's return [ResponseEvent] is the processed [TriggerEvent].
+The submitted HTML text is sanitized and in Markdown mode converted to Markdown text.
+*/
+func (rte *RichText) OnRequest(te TriggerEvent) (re ResponseEvent) {
+	value := ut.SanitizeHTML(te.Values.Get(te.Name), rte.policy())
+	if rte.Markdown {
+		value = ut.HTMLToMarkdown(value)
+	}
+	evt := ResponseEvent{
+		Trigger: rte, TriggerName: rte.Name,
+		Name:  RichTextEventChange,
+		Value: rte.SetProperty("value", value),
+	}
+	if rte.OnResponse != nil {
+		return rte.OnResponse(evt)
+	}
+	return evt
+}
+
+/*
+Based on the values, it will generate the html code of the [RichText] or return with an error message.
+*/
+func (rte *RichText) Render() (html template.HTML, err error) {
+	return RenderHTML(rte)
+}
+
+// [RichText] template functions
+var richTextFuncMap = map[string]any{
+	"styleMap": func(rte *RichText) bool {
+		return len(rte.Style) > 0
+	},
+	"customClass": func(rte *RichText) string {
+		return strings.Join(rte.Class, " ")
+	},
+	"toolbar": func(rte *RichText) []richTextTool {
+		return rte.toolbar()
+	},
+	"toolIcon": func(value string) (template.HTML, error) {
+		ico := &Icon{Value: value}
+		return ico.Render()
+	},
+	"msg": func(rte *RichText, labelID string) string {
+		return rte.msg(labelID)
+	},
+	"content": func(rte *RichText) template.HTML {
+		return template.HTML(rte.content())
+	},
+	"contentValue": func(rte *RichText) string {
+		return rte.content()
+	},
+}
+
+/*
+Based on the values, it will write the html code of the [RichText] into the writer or return with an error message.
+*/
+func (rte *RichText) RenderTo(w io.Writer) (err error) {
+	rte.InitProps(rte)
+
+	tpl := `<div id="{{ .Id }}" name="{{ .Name }}"
+	 class="richtext{{ if .Full }} full{{ end }}{{ if .Invalid }} invalid{{ end }}{{ if .Disabled }} richtext-disabled{{ end }} {{ customClass $ }}"
+	{{ if styleMap $ }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}
+	>{{ if eq .ReadOnly false }}<div class="richtext-toolbar" role="toolbar">
+	{{ range toolbar $ }}<button type="button" class="richtext-tool" name="{{ .Name }}"
+	 data-command="{{ .Command }}" data-arg="{{ .Arg }}" title="{{ msg $ (printf "richtext_%s" .Name) }}"
+	 aria-label="{{ msg $ (printf "richtext_%s" .Name) }}"{{ if $.Disabled }} disabled{{ end }}
+	>{{ toolIcon .Icon }}</button>{{ end }}
+	</div>{{ end }}
+	<div id="{{ .Id }}_editor" class="richtext-editor" role="textbox" aria-multiline="true"
+	 contenteditable="{{ if or .ReadOnly .Disabled }}false{{ else }}true{{ end }}"
+	{{ if ne .Placeholder "" }} data-placeholder="{{ .Placeholder }}"{{ end }}
+	{{ if ne .Label "" }} aria-label="{{ .Label }}"{{ end }}
+	{{ if gt .Rows 0 }} style="min-height: {{ .Rows }}lh;"{{ end }}
+	>{{ content $ }}</div>
+	<input id="{{ .Id }}_value" type="hidden" name="{{ .Name }}" value="{{ contentValue $ }}"
+	{{ if ne .EventURL "" }} hx-post="{{ .EventURL }}" hx-target="{{ .Target }}" {{ if ne .Sync "none" }} hx-sync="{{ .Sync }}"{{ end }} hx-swap="{{ .Swap }}" hx-trigger="change"{{ end }}
+	{{ if ne .Indicator "none" }} hx-indicator="#{{ .Indicator }}"{{ end }}
+	>
+	{{ if and (eq .ReadOnly false) (eq .Disabled false) }}<script>
+	(function() {
+		var editor = htmx.find('#{{ .Id }}_editor'), input = htmx.find('#{{ .Id }}_value');
+		var linkLabel = {{ msg $ "richtext_link_url" }};
+		var sync = function() {
+			var value = editor.innerHTML;
+			if ((editor.textContent.trim() === '') && !editor.querySelector('img,hr,table')) {
+				value = '';
+			}
+			if (value !== input.value) {
+				input.value = value;
+				input.dispatchEvent(new Event('change'));
+			}
+		};
+		htmx.findAll('#{{ .Id }} .richtext-tool').forEach(function(tool) {
+			tool.addEventListener('mousedown', function(evt) {
+				evt.preventDefault();
+			});
+			tool.addEventListener('click', function() {
+				var command = tool.dataset.command, arg = tool.dataset.arg;
+				editor.focus();
+				if ((command === 'formatBlock') && (document.queryCommandValue('formatBlock').toLowerCase() === arg)) {
+					arg = 'p';
+				}
+				if (command === 'createLink') {
+					arg = window.prompt(linkLabel, 'https://');
+					if (arg === null) {
+						return;
+					}
+					if (arg.trim() === '') {
+						command = 'unlink';
+					}
+				}
+				document.execCommand(command, false, arg);
+			});
+		});
+		editor.addEventListener('focus', function() {
+			document.execCommand('defaultParagraphSeparator', false, 'p');
+		});
+		editor.addEventListener('blur', sync);
+		{{ if .AutoFocus }}editor.focus();{{ end }}
+	})();
+	</script>{{ end }}
+	</div>`
+
+	if err = ut.CachedTemplateWriter(w, "richtext", tpl, richTextFuncMap, rte); err == nil && rte.EventURL != "" {
+		rte.SetProperty("request_map", rte)
+		// the htmx trigger id of the hidden value input
+		rte.RequestMap[rte.Id+"_value"] = rte
+	}
+	return err
+}
+
+var testRichTextResponse func(evt ResponseEvent) (re ResponseEvent) = func(evt ResponseEvent) (re ResponseEvent) {
+	evt.OOB = append(evt.OOB, OOBToast(ToastTypeInfo, ut.ToString(evt.Value, ""), 4))
+	return evt
+}
+
+// [RichText] test and demo data
+func TestRichText(cc ClientComponent) []TestComponent {
+	id := ut.ToString(cc.GetProperty("id"), "")
+	eventURL := ut.ToString(cc.GetProperty("event_url"), "")
+	requestValue := cc.GetProperty("request_value").(map[string]ut.IM)
+	requestMap := cc.GetProperty("request_map").(map[string]ClientComponent)
+	return []TestComponent{
+		{
+			Label:         "HTML value",
+			ComponentType: ComponentTypeRichText,
+			Component: &RichText{
+				BaseComponent: BaseComponent{
+					Id:           id + "_richtext_html",
+					EventURL:     eventURL,
+					OnResponse:   testRichTextResponse,
+					RequestValue: requestValue,
+					RequestMap:   requestMap,
+				},
+				Value: `<h2>Product description</h2><p>The <b>best</b> product of the <i>year</i>.` +
+					`<img src="x" onerror="alert(1)"></p><ul><li>Fast</li><li>Reliable</li></ul>` +
+					`<table><tbody><tr><th>Size</th><th>Price</th></tr><tr><td>S</td><td>10</td></tr></tbody></table>` +
+					`<p><a href="https://github.com/nervatura/component" target="_blank">More info</a></p>`,
+				Placeholder: "Product description",
+				Rows:        8,
+				Full:        true,
+			}},
+		{
+			Label:         "Markdown storage",
+			ComponentType: ComponentTypeRichText,
+			Component: &RichText{
+				BaseComponent: BaseComponent{
+					Id:           id + "_richtext_markdown",
+					EventURL:     eventURL,
+					OnResponse:   testRichTextResponse,
+					RequestValue: requestValue,
+					RequestMap:   requestMap,
+				},
+				Value:       "## Notes\n\nCall the **customer** before the *delivery*:\n\n1. Check the address\n2. Confirm the date",
+				Placeholder: "Notes",
+				Markdown:    true,
+				Full:        true,
+			}},
+		{
+			Label:         "Restricted policy and toolbar",
+			ComponentType: ComponentTypeRichText,
+			Component: &RichText{
+				BaseComponent: BaseComponent{
+					Id:           id + "_richtext_policy",
+					EventURL:     eventURL,
+					OnResponse:   testRichTextResponse,
+					RequestValue: requestValue,
+					RequestMap:   requestMap,
+				},
+				Value:   `<p>Only <b>bold</b>, <i>italic</i> and <u>lists</u></p><h1>No heading</h1>`,
+				Toolbar: []string{RichTextToolBold, RichTextToolItalic, RichTextToolUnorderedList, RichTextToolTable},
+				Policy: ut.HTMLPolicy{
+					"p": {}, "br": {}, "b": {}, "i": {}, "ul": {}, "li": {},
+				},
+				AutoFocus: true,
+				Labels: ut.SM{
+					"richtext_bold": "Strong",
+				},
+			}},
+		{
+			Label:         "ReadOnly",
+			ComponentType: ComponentTypeRichText,
+			Component: &RichText{
+				BaseComponent: BaseComponent{
+					Id: id + "_richtext_readonly",
+				},
+				Value:    "Read-only **Markdown** text",
+				Markdown: true,
+				ReadOnly: true,
+			}},
+		{
+			Label:         "Disabled",
+			ComponentType: ComponentTypeRichText,
+			Component: &RichText{
+				BaseComponent: BaseComponent{
+					Id: id + "_richtext_disabled",
+				},
+				Value:    "<p>Disabled text</p>",
+				Disabled: true,
+				Invalid:  true,
+			}},
+	}
+}
@@ -0,0 +1,434 @@
+package component
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	ut "github.com/nervatura/component/pkg/util"
+)
+
+func TestTestRichText(t *testing.T) {
+	for _, tt := range TestRichText(&BaseComponent{EventURL: "/demo"}) {
+		t.Run(tt.Label, func(t *testing.T) {
+			tt.Component.Render()
+		})
+	}
+	re := testRichTextResponse(ResponseEvent{Trigger: &RichText{}, Value: "**value**"})
+	if len(re.OOB) != 1 || re.OOB[0].Component.GetProperty("value") != "**value**" {
+		t.Errorf("testRichTextResponse() = %v", re.OOB)
+	}
+}
+
+func TestRichText_GetProperty(t *testing.T) {
+	tests := []struct {
+		name     string
+		propName string
+		want     interface{}
+	}{
+		{
+			name:     "markdown",
+			propName: "markdown",
+			want:     true,
+		},
+		{
+			name:     "rows",
+			propName: "rows",
+			want:     int64(5),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rte := &RichText{Markdown: true, Rows: 5}
+			if got := rte.GetProperty(tt.propName); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RichText.GetProperty() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRichText_Validation(t *testing.T) {
+	type args struct {
+		propName  string
+		propValue interface{}
+	}
+	tests := []struct {
+		name   string
+		labels ut.SM
+		args   args
+		want   interface{}
+	}{
+		{
+			name: "base",
+			args: args{
+				propName:  "id",
+				propValue: "RICHTEXTID",
+			},
+			want: "RICHTEXTID",
+		},
+		{
+			name: "invalid",
+			args: args{
+				propName:  "invalid",
+				propValue: "",
+			},
+			want: "",
+		},
+		{
+			name: "toolbar",
+			args: args{
+				propName:  "toolbar",
+				propValue: []interface{}{RichTextToolLink, "missing", RichTextToolBold, RichTextToolLink},
+			},
+			want: []string{RichTextToolLink, RichTextToolBold},
+		},
+		{
+			name: "toolbar_default",
+			args: args{
+				propName:  "toolbar",
+				propValue: nil,
+			},
+			want: RichTextToolbar,
+		},
+		{
+			name: "policy",
+			args: args{
+				propName:  "policy",
+				propValue: ut.HTMLPolicy{"p": {}},
+			},
+			want: ut.HTMLPolicy{"p": {}},
+		},
+		{
+			name: "policy_map",
+			args: args{
+				propName:  "policy",
+				propValue: ut.IM{"a": []interface{}{"href"}},
+			},
+			want: ut.HTMLPolicy{"a": {"href"}},
+		},
+		{
+			name: "policy_invalid",
+			args: args{
+				propName:  "policy",
+				propValue: "policy",
+			},
+			want: ut.HTMLPolicy{},
+		},
+		{
+			name: "policy_nil",
+			args: args{
+				propName:  "policy",
+				propValue: nil,
+			},
+			want: ut.HTMLPolicy{},
+		},
+		{
+			name: "labels_sm",
+			args: args{
+				propName:  "labels",
+				propValue: ut.SM{"richtext_bold": "Félkövér"},
+			},
+			want: ut.SM{"richtext_bold": "Félkövér"},
+		},
+		{
+			name:   "labels_im",
+			labels: ut.SM{"richtext_link": "Hivatkozás"},
+			args: args{
+				propName:  "labels",
+				propValue: ut.IM{"richtext_bold": "Félkövér"},
+			},
+			want: ut.SM{"richtext_link": "Hivatkozás", "richtext_bold": "Félkövér"},
+		},
+		{
+			name: "labels_default",
+			args: args{
+				propName:  "labels",
+				propValue: nil,
+			},
+			want: richTextDefaultLabel,
+		},
+		{
+			name: "target",
+			args: args{
+				propName:  "target",
+				propValue: "target",
+			},
+			want: "#target",
+		},
+		{
+			name: "target_this",
+			args: args{
+				propName:  "target",
+				propValue: "this",
+			},
+			want: "this",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rte := &RichText{Labels: tt.labels}
+			if got := rte.Validation(tt.args.propName, tt.args.propValue); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RichText.Validation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRichText_SetProperty(t *testing.T) {
+	type args struct {
+		propName  string
+		propValue interface{}
+	}
+	tests := []struct {
+		name string
+		args args
+		want interface{}
+	}{
+		{
+			name: "base",
+			args: args{
+				propName:  "id",
+				propValue: "RICHTEXTID",
+			},
+			want: "RICHTEXTID",
+		},
+		{
+			name: "invalid",
+			args: args{
+				propName:  "invalid",
+				propValue: "",
+			},
+			want: false,
+		},
+		{
+			name: "missing",
+			args: args{
+				propName:  "missing",
+				propValue: "value",
+			},
+			want: "value",
+		},
+		{
+			name: "value",
+			args: args{
+				propName:  "value",
+				propValue: "<p>value</p>",
+			},
+			want: "<p>value</p>",
+		},
+		{
+			name: "placeholder",
+			args: args{
+				propName:  "placeholder",
+				propValue: "placeholder",
+			},
+			want: "placeholder",
+		},
+		{
+			name: "label",
+			args: args{
+				propName:  "label",
+				propValue: "label",
+			},
+			want: "label",
+		},
+		{
+			name: "toolbar",
+			args: args{
+				propName:  "toolbar",
+				propValue: []string{RichTextToolBold},
+			},
+			want: []string{RichTextToolBold},
+		},
+		{
+			name: "markdown",
+			args: args{
+				propName:  "markdown",
+				propValue: true,
+			},
+			want: true,
+		},
+		{
+			name: "policy",
+			args: args{
+				propName:  "policy",
+				propValue: ut.HTMLPolicy{"b": {}},
+			},
+			want: ut.HTMLPolicy{"b": {}},
+		},
+		{
+			name: "rows",
+			args: args{
+				propName:  "rows",
+				propValue: 4,
+			},
+			want: int64(4),
+		},
+		{
+			name: "disabled",
+			args: args{
+				propName:  "disabled",
+				propValue: true,
+			},
+			want: true,
+		},
+		{
+			name: "readonly",
+			args: args{
+				propName:  "readonly",
+				propValue: true,
+			},
+			want: true,
+		},
+		{
+			name: "auto_focus",
+			args: args{
+				propName:  "auto_focus",
+				propValue: true,
+			},
+			want: true,
+		},
+		{
+			name: "full",
+			args: args{
+				propName:  "full",
+				propValue: true,
+			},
+			want: true,
+		},
+		{
+			name: "labels",
+			args: args{
+				propName:  "labels",
+				propValue: ut.SM{"richtext_bold": "Félkövér"},
+			},
+			want: ut.SM{"richtext_bold": "Félkövér"},
+		},
+		{
+			name: "target",
+			args: args{
+				propName:  "target",
+				propValue: "#target",
+			},
+			want: "#target",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rte := &RichText{}
+			if got := rte.SetProperty(tt.args.propName, tt.args.propValue); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RichText.SetProperty() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRichText_OnRequest(t *testing.T) {
+	tests := []struct {
+		name       string
+		rte        *RichText
+		value      string
+		onResponse func(evt ResponseEvent) (re ResponseEvent)
+		want       string
+	}{
+		{
+			name:  "html",
+			rte:   &RichText{},
+			value: `<p onclick="alert(1)">A <b>bold</b> <a href="javascript:alert(1)">link</a></p><script>alert(1)</script>`,
+			want:  `<p>A <b>bold</b> <a>link</a></p>`,
+		},
+		{
+			name:  "policy",
+			rte:   &RichText{Policy: ut.HTMLPolicy{"p": {}}},
+			value: `<p>A <b>bold</b></p><h1>title</h1>`,
+			want:  `<p>A bold</p>title`,
+		},
+		{
+			name:  "markdown",
+			rte:   &RichText{Markdown: true},
+			value: `<h2>Title</h2><p>A <b>bold</b> <u>text</u></p><ul><li>item</li></ul>`,
+			want:  "## Title\n\nA **bold** text\n\n- item",
+		},
+		{
+			name:  "response",
+			rte:   &RichText{},
+			value: `<p>value</p>`,
+			onResponse: func(evt ResponseEvent) (re ResponseEvent) {
+				evt.Value = "response"
+				return evt
+			},
+			want: "response",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.rte.Name = "notes"
+			tt.rte.OnResponse = tt.onResponse
+			evt := tt.rte.OnRequest(TriggerEvent{Name: "notes", Values: url.Values{"notes": []string{tt.value}}})
+			if evt.Name != RichTextEventChange || evt.Value != tt.want {
+				t.Errorf("RichText.OnRequest() = %v, %v, want %v", evt.Name, evt.Value, tt.want)
+			}
+		})
+	}
+}
+
+func TestRichText_Render(t *testing.T) {
+	tests := []struct {
+		name    string
+		rte     *RichText
+		want    []string
+		notWant []string
+	}{
+		{
+			name: "markdown",
+			rte: &RichText{
+				BaseComponent: BaseComponent{Id: "notes", EventURL: "/event", RequestMap: map[string]ClientComponent{}},
+				Value:         "**bold** [link](javascript:alert(1))",
+				Markdown:      true,
+			},
+			want:    []string{`<strong>bold</strong> link`, `name="bold"`, `hx-trigger="change"`},
+			notWant: []string{`name="underline"`, `javascript`},
+		},
+		{
+			name: "policy",
+			rte: &RichText{
+				Value:   `<p>text</p>`,
+				Toolbar: []string{RichTextToolBold, RichTextToolTable, RichTextToolHeading},
+				Policy:  ut.HTMLPolicy{"p": {}, "h2": {}},
+			},
+			want:    []string{`name="heading"`, `<script>`},
+			notWant: []string{`name="bold"`, `name="table"`, `hx-post`},
+		},
+		{
+			name: "readonly",
+			rte: &RichText{
+				Value:    `<p>text</p>`,
+				ReadOnly: true,
+			},
+			want:    []string{`contenteditable="false"`},
+			notWant: []string{`richtext-toolbar`, `<script>`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := tt.rte.Render()
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(string(res), want) {
+					t.Errorf("RichText.Render() missing %s", want)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(string(res), notWant) {
+					t.Errorf("RichText.Render() unexpected %s", notWant)
+				}
+			}
+			if tt.rte.EventURL != "" && tt.rte.RequestMap[tt.rte.Id+"_value"] != tt.rte {
+				t.Error("RichText.Render() missing request map")
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package component
 
 import (
 	"html/template"
+	"io"
 	"strings"
 
 	ut "github.com/nervatura/component/pkg/util"
@@ -112,7 +113,7 @@ func (row *Row) SetProperty(propName string, propValue interface{}) interface{}
 	return propValue
 }
 
-func (row *Row) getComponent(index int, coltype string) (html template.HTML, err error) {
+func (row *Row) component(index int, coltype string) ClientComponent {
 	ccMap := map[string]func() ClientComponent{
 		"field": func() ClientComponent {
 			field := &row.Columns[index].Value
@@ -131,55 +132,72 @@ func (row *Row) getComponent(index int, coltype string) (html template.HTML, err
 			}
 		},
 	}
-	cc := ccMap[coltype]()
-	html, err = cc.Render()
-	return html, err
+	return ccMap[coltype]()
 }
 
 /*
 Based on the values, it will generate the html code of the [Row] or return with an error message.
 */
 func (row *Row) Render() (html template.HTML, err error) {
+	return RenderHTML(row)
+}
+
+// the render data of the [Row] template
+type rowRender struct {
+	*Row
+	hw *ut.HTMLWriter
+}
+
+// [Row] template functions
+var rowFuncMap = map[string]any{
+	"styleMap": func(row *rowRender) bool {
+		return len(row.Style) > 0
+	},
+	"customClass": func(row *rowRender) string {
+		return strings.Join(row.Class, " ")
+	},
+	"rowComponent": func(row *rowRender, index int, coltype string) (template.HTML, error) {
+		return "", RenderTo(row.hw, row.component(index, coltype))
+	},
+	"fieldCol": func(row *rowRender) bool {
+		return (len(row.Columns) == 1) && row.FieldCol
+	},
+	"validCol": func(row *rowRender) bool {
+		return (len(row.Columns) >= 1) && (len(row.Columns) <= 4) && !row.FieldCol
+	},
+	"colClass": func(row *rowRender) string {
+		cols := []string{"s12 m12 l12", "s12 m6 l6", "s12 m4 l4", "s12 m3 l3"}
+		if row.Full {
+			return cols[len(row.Columns)-1]
+		}
+		return ""
+	},
+}
+
+/*
+Based on the values, it will write the html code of the [Row] into the writer or return with an error message.
+*/
+func (row *Row) RenderTo(w io.Writer) (err error) {
 	row.InitProps(row)
 
-	funcMap := map[string]any{
-		"styleMap": func() bool {
-			return len(row.Style) > 0
-		},
-		"customClass": func() string {
-			return strings.Join(row.Class, " ")
-		},
-		"rowComponent": func(index int, coltype string) (template.HTML, error) {
-			return row.getComponent(index, coltype)
-		},
-		"fieldCol": func() bool {
-			return (len(row.Columns) == 1) && row.FieldCol
-		},
-		"validCol": func() bool {
-			return (len(row.Columns) >= 1) && (len(row.Columns) <= 4) && !row.FieldCol
-		},
-		"colClass": func() string {
-			cols := []string{"s12 m12 l12", "s12 m6 l6", "s12 m4 l4", "s12 m3 l3"}
-			if row.Full {
-				return cols[len(row.Columns)-1]
-			}
-			return ""
-		},
-	}
 	tpl := `<div id="{{ .Id }}" name="{{ .Name }}" 
-	class="row section-tiny {{ customClass }}{{ if .Full }} full{{ else }} mobile{{ end }}
+	class="row section-tiny {{ customClass $ }}{{ if .Full }} full{{ else }} mobile{{ end }}
 	{{ if .BorderTop }} border-top{{ end }}{{ if .BorderBottom }} border-bottom{{ end }}"
-	{{ if styleMap }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}
-	>{{ if fieldCol }}<div class="cell padding-small hide-small" style="width: 150px;" >{{ rowComponent 0 "label" }}</div>
-	<div class="cell padding-small" ><div class="section-tiny-bottom hide-medium hide-large" >{{ rowComponent 0 "label" }}</div>
-	{{ rowComponent 0 "field" }}</div>{{ end }}
-	{{ if validCol }}{{ range $index, $col := .Columns }}
-	<div class="cell padding-small {{ colClass }}" >
-	<div class="section-tiny-bottom">{{ rowComponent $index "label" }}</div>{{ rowComponent $index "field" }}</div>
+	{{ if styleMap $ }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}
+	>{{ if fieldCol $ }}<div class="cell padding-small hide-small" style="width: 150px;" >{{ rowComponent $ 0 "label" }}</div>
+	<div class="cell padding-small" ><div class="section-tiny-bottom hide-medium hide-large" >{{ rowComponent $ 0 "label" }}</div>
+	{{ rowComponent $ 0 "field" }}</div>{{ end }}
+	{{ if validCol $ }}{{ range $index, $col := .Columns }}
+	<div class="cell padding-small {{ colClass $ }}" >
+	<div class="section-tiny-bottom">{{ rowComponent $ $index "label" }}</div>{{ rowComponent $ $index "field" }}</div>
 	{{ end }}{{ end }}
 	</div>`
 
-	return ut.TemplateBuilder("row", tpl, funcMap, row)
+	hw := ut.NewHTMLWriter(w)
+	if err = ut.CachedTemplateWriter(hw, "row", tpl, rowFuncMap, &rowRender{Row: row, hw: hw}); err == nil {
+		err = hw.Close()
+	}
+	return err
 }
 
 // [Row] test and demo data
@@ -230,7 +248,7 @@ func TestRow(cc ClientComponent) []TestComponent {
 						Type: FieldTypeText,
 						Value: ut.IM{
 							"name":  "text",
-							"value": `Long text&#13;&#10;Next row...`,
+							"value": "Long text\nNext row...",
 						},
 					}},
 				},
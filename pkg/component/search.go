@@ -2,6 +2,7 @@ package component
 
 import (
 	"html/template"
+	"io"
 	"slices"
 	"strings"
 
@@ -216,7 +217,7 @@ func (sea *Search) response(evt ResponseEvent) (re ResponseEvent) {
 	return selEvt
 }
 
-func (sea *Search) getComponent(name string) (html template.HTML, err error) {
+func (sea *Search) component(name string) ClientComponent {
 	ccBtn := func(icon string) *Button {
 		return &Button{
 			BaseComponent: BaseComponent{
@@ -301,42 +302,59 @@ func (sea *Search) getComponent(name string) (html template.HTML, err error) {
 			}
 		},
 	}
-	cc := ccMap[name]()
-	html, err = cc.Render()
-	return html, err
+	return ccMap[name]()
 }
 
 /*
 Based on the values, it will generate the html code of the [Search] or return with an error message.
 */
 func (sea *Search) Render() (html template.HTML, err error) {
+	return RenderHTML(sea)
+}
+
+// the render data of the [Search] template
+type searchRender struct {
+	*Search
+	hw *ut.HTMLWriter
+}
+
+// [Search] template functions
+var searchFuncMap = map[string]any{
+	"styleMap": func(sea *searchRender) bool {
+		return len(sea.Style) > 0
+	},
+	"customClass": func(sea *searchRender) string {
+		return strings.Join(sea.Class, " ")
+	},
+	"searchComponent": func(sea *searchRender, name string) (template.HTML, error) {
+		return "", RenderTo(sea.hw, sea.component(name))
+	},
+}
+
+/*
+Based on the values, it will write the html code of the [Search] into the writer or return with an error message.
+*/
+func (sea *Search) RenderTo(w io.Writer) (err error) {
 	sea.InitProps(sea)
+	hw := ut.NewHTMLWriter(w)
 
-	funcMap := map[string]any{
-		"styleMap": func() bool {
-			return len(sea.Style) > 0
-		},
-		"customClass": func() string {
-			return strings.Join(sea.Class, " ")
-		},
-		"searchComponent": func(name string) (template.HTML, error) {
-			return sea.getComponent(name)
-		},
-	}
-	tpl := `<div id="{{ .Id }}" name="{{ .Name }}" class="row full {{ customClass }}"
-	{{ if styleMap }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}
+	tpl := `<div id="{{ .Id }}" name="{{ .Name }}" class="row full {{ customClass $ }}"
+	{{ if styleMap $ }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}
 	><div class="panel"><div class="panel-title">
 	<div class="cell title-cell"><span>{{ .Title }}</span></div></div>
 	<div class="section" >
 	<div class="row full container" >
-	<div class="cell">{{ searchComponent "filter_value" }}</div>
-	<div class="cell" style="width: 20px;" >{{ searchComponent "btn_search" }}</div>
-	{{ if .ShowHelp }}<div class="cell" style="width: 20px;" >{{ searchComponent "btn_help" }}</div>{{ end }}
+	<div class="cell">{{ searchComponent $ "filter_value" }}</div>
+	<div class="cell" style="width: 20px;" >{{ searchComponent $ "btn_search" }}</div>
+	{{ if .ShowHelp }}<div class="cell" style="width: 20px;" >{{ searchComponent $ "btn_help" }}</div>{{ end }}
 	</div>
-	<div class="row full container" >{{ searchComponent "search_result" }}</div>
+	<div class="row full container" >{{ searchComponent $ "search_result" }}</div>
 	</div></div></div>`
 
-	return ut.TemplateBuilder("search", tpl, funcMap, sea)
+	if err = ut.CachedTemplateWriter(hw, "search", tpl, searchFuncMap, &searchRender{Search: sea, hw: hw}); err == nil {
+		err = hw.Close()
+	}
+	return err
 }
 
 var testSearchResponse func(evt ResponseEvent) (re ResponseEvent) = func(evt ResponseEvent) (re ResponseEvent) {
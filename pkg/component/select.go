@@ -2,6 +2,7 @@ package component
 
 import (
 	"html/template"
+	"io"
 	"strings"
 
 	ut "github.com/nervatura/component/pkg/util"
@@ -57,6 +58,8 @@ type Select struct {
 	AutoFocus bool `json:"auto_focus"`
 	// Full width input (100%)
 	Full bool `json:"full"`
+	// The name of a registered [LookupProvider]. If the Options is empty, it is loaded from the lookup.
+	Lookup string `json:"lookup"`
 }
 
 /*
@@ -73,6 +76,7 @@ func (sel *Select) Properties() ut.IM {
 			"disabled":   sel.Disabled,
 			"auto_focus": sel.AutoFocus,
 			"full":       sel.Full,
+			"lookup":     sel.Lookup,
 		})
 }
 
@@ -107,7 +111,7 @@ func (sel *Select) Validation(propName string, propValue interface{}) interface{
 		},
 		"value": func() interface{} {
 			value := ut.ToString(propValue, "")
-			if (value == "") && sel.IsNull {
+			if ((value == "") && sel.IsNull) || (sel.Lookup != "" && len(sel.Options) == 0) {
 				return value
 			}
 			valid := false
@@ -116,6 +120,13 @@ func (sel *Select) Validation(propName string, propValue interface{}) interface{
 					valid = true
 				}
 			}
+			// the lookup options contain only the first rows, the current value is always included
+			if !valid && sel.Lookup != "" && value != "" {
+				if option, err := LookupResolve(sel.RequestValue, sel.Lookup, value); err == nil {
+					sel.Options = append(sel.Options, option)
+					valid = true
+				}
+			}
 			if !valid {
 				if len(sel.Options) > 0 {
 					value = sel.Options[0].Value
@@ -170,6 +181,10 @@ func (sel *Select) SetProperty(propName string, propValue interface{}) interface
 			sel.Full = ut.ToBoolean(propValue, false)
 			return sel.Full
 		},
+		"lookup": func() interface{} {
+			sel.Lookup = ut.ToString(propValue, "")
+			return sel.Lookup
+		},
 	}
 	if _, found := pm[propName]; found {
 		return sel.SetRequestValue(propName, pm[propName](), []string{})
@@ -201,32 +216,44 @@ func (sel *Select) OnRequest(te TriggerEvent) (re ResponseEvent) {
 Based on the values, it will generate the html code of the [Select] or return with an error message.
 */
 func (sel *Select) Render() (html template.HTML, err error) {
-	sel.InitProps(sel)
+	return RenderHTML(sel)
+}
 
-	funcMap := map[string]any{
-		"styleMap": func() bool {
-			return len(sel.Style) > 0
-		},
-		"customClass": func() string {
-			return strings.Join(sel.Class, " ")
-		},
+// [Select] template functions
+var selectFuncMap = map[string]any{
+	"styleMap": func(sel *Select) bool {
+		return len(sel.Style) > 0
+	},
+	"customClass": func(sel *Select) string {
+		return strings.Join(sel.Class, " ")
+	},
+}
+
+/*
+Based on the values, it will write the html code of the [Select] into the writer or return with an error message.
+*/
+func (sel *Select) RenderTo(w io.Writer) (err error) {
+	if sel.Lookup != "" && len(sel.Options) == 0 {
+		sel.Options = LookupOptions(sel.RequestValue, sel.Lookup)
 	}
+	sel.InitProps(sel)
+
 	tpl := `<select id="{{ .Id }}" name="{{ .Name }}" value="{{ .Value }}"
 	{{ if ne .EventURL "" }} hx-post="{{ .EventURL }}" hx-target="{{ .Target }}" {{ if ne .Sync "none" }} hx-sync="{{ .Sync }}"{{ end }} hx-swap="{{ .Swap }}"{{ end }}
 	{{ if ne .Indicator "none" }} hx-indicator="#{{ .Indicator }}"{{ end }}
 	{{ if .Disabled }} disabled{{ end }}
 	{{ if .AutoFocus }} autofocus{{ end }}
 	{{ if ne .Label "" }} aria-label="{{ .Label }}"{{ end }}
-	 class="{{ customClass }}{{ if .Full }} full{{ end }}"
-	{{ if styleMap }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}
+	 class="{{ customClass $ }}{{ if .Full }} full{{ end }}"
+	{{ if styleMap $ }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}
 	>{{ if .IsNull }}<option {{ if eq .Value "" }}selected{{ end }} key="-1" value="" ></option>{{ end }}
 	{{ range $index, $option := .Options }}<option {{ if eq .Value $.Value }}selected{{ end }} key="{{ $index }}" value="{{ $option.Value }}" >{{ $option.Text }}</option>{{ end }}
 	</select>`
 
-	if html, err = ut.TemplateBuilder("button", tpl, funcMap, sel); err == nil && sel.EventURL != "" {
+	if err = ut.CachedTemplateWriter(w, "button", tpl, selectFuncMap, sel); err == nil && sel.EventURL != "" {
 		sel.SetProperty("request_map", sel)
 	}
-	return html, nil
+	return nil
 }
 
 // [Select] test and demo data
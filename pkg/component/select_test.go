@@ -2,6 +2,7 @@ package component
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 
 	ut "github.com/nervatura/component/pkg/util"
@@ -300,3 +301,36 @@ func TestSelect_OnRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestSelect_lookup(t *testing.T) {
+	RegisterLookup("test_color", testLookupRows)
+	sel := &Select{
+		BaseComponent: BaseComponent{RequestValue: map[string]ut.IM{}},
+		Value:         "3",
+		Lookup:        "test_color",
+	}
+	if sel.Validation("value", "9") != "9" {
+		t.Error("Select.Validation() lookup value")
+	}
+	if _, err := sel.Render(); err != nil || len(sel.Options) != 3 || sel.Value != "3" {
+		t.Errorf("Select.Render() = %v, %v", sel.Options, err)
+	}
+	if sel.SetProperty("lookup", "test_color") != "test_color" {
+		t.Error("Select.SetProperty() lookup")
+	}
+
+	// the value is not in the first rows of the lookup
+	sel = &Select{
+		BaseComponent: BaseComponent{RequestValue: map[string]ut.IM{}},
+		Options:       []SelectOption{{Value: "1", Text: "Red"}},
+		Value:         "3",
+		Lookup:        "test_color",
+	}
+	if html, err := sel.Render(); err != nil || len(sel.Options) != 2 || sel.Value != "3" ||
+		!strings.Contains(string(html), "Blue") {
+		t.Errorf("Select.Render() = %v, %v", sel.Options, err)
+	}
+	if sel.SetProperty("value", "9") != "1" {
+		t.Error("Select.SetProperty() unknown lookup value")
+	}
+}
@@ -2,6 +2,7 @@ package component
 
 import (
 	"html/template"
+	"io"
 	"strings"
 
 	ut "github.com/nervatura/component/pkg/util"
@@ -51,6 +52,11 @@ type Selector struct {
 	CustomModal bool `json:"custom_modal"`
 	// Icon for the modal button. See more [IconValues] variable values. Default: Search
 	ModalIcon string `json:"modal_icon"`
+	/* The name of a registered [LookupProvider]. The search, the type-ahead filtering and the row selection
+	are handled by the provider, and the text of the value is resolved from the key value. */
+	Lookup string `json:"lookup"`
+	// The page size of the lookup search rows. Default value: [LookupDefaultLimit]
+	LookupLimit int64 `json:"lookup_limit"`
 }
 
 /*
@@ -73,6 +79,8 @@ func (sel *Selector) Properties() ut.IM {
 			"show_modal":         sel.ShowModal,
 			"custom_modal":       sel.CustomModal,
 			"modal_icon":         sel.ModalIcon,
+			"lookup":             sel.Lookup,
+			"lookup_limit":       sel.LookupLimit,
 		})
 }
 
@@ -108,6 +116,9 @@ func (sel *Selector) Validation(propName string, propValue interface{}) interfac
 			if fd, valid := propValue.([]TableField); valid && (fd != nil) {
 				fields = fd
 			}
+			if provider, found := LookupMap[sel.Lookup]; found && len(fields) == 0 {
+				fields = provider.Fields()
+			}
 			if len(fields) == 0 {
 				if len(sel.Rows) > 0 {
 					for field := range sel.Rows[0] {
@@ -121,6 +132,12 @@ func (sel *Selector) Validation(propName string, propValue interface{}) interfac
 		"modal_icon": func() interface{} {
 			return sel.CheckEnumValue(ut.ToString(propValue, ""), IconSearch, IconValues)
 		},
+		"lookup_limit": func() interface{} {
+			if value := ut.ToInteger(propValue, 0); value > 0 {
+				return value
+			}
+			return int64(LookupDefaultLimit)
+		},
 		"target": func() interface{} {
 			sel.SetProperty("id", sel.Id)
 			value := ut.ToString(propValue, sel.Id)
@@ -201,6 +218,14 @@ func (sel *Selector) SetProperty(propName string, propValue interface{}) interfa
 			sel.Target = sel.Validation(propName, propValue).(string)
 			return sel.Target
 		},
+		"lookup": func() interface{} {
+			sel.Lookup = ut.ToString(propValue, "")
+			return sel.Lookup
+		},
+		"lookup_limit": func() interface{} {
+			sel.LookupLimit = sel.Validation(propName, propValue).(int64)
+			return sel.LookupLimit
+		},
 	}
 	if _, found := pm[propName]; found {
 		return sel.SetRequestValue(propName, pm[propName](), []string{})
@@ -211,7 +236,110 @@ func (sel *Selector) SetProperty(propName string, propValue interface{}) interfa
 	return propValue
 }
 
+// loads a page of the lookup rows, the extra row shows the existence of the next page
+func (sel *Selector) lookupSearch(text string, offset int64) (err error) {
+	var rows []ut.IM
+	limit := sel.Validation("lookup_limit", sel.LookupLimit).(int64)
+	offset = max(offset, 0)
+	if rows, err = LookupSearch(sel.RequestValue, sel.Lookup, text, offset, limit+1); err == nil {
+		sel.SetProperty("rows", rows[:min(int64(len(rows)), limit)])
+		sel.SetProperty("data", ut.IM{"offset": offset, "next_page": int64(len(rows)) > limit})
+	}
+	return err
+}
+
+func (sel *Selector) lookupPage(pageName string) int64 {
+	limit := sel.Validation("lookup_limit", sel.LookupLimit).(int64)
+	offset := ut.ToInteger(sel.Data["offset"], 0)
+	if pageName == "btn_next_page" {
+		return offset + limit
+	}
+	return offset - limit
+}
+
+func (sel *Selector) lookupResponse(evt ResponseEvent) (re ResponseEvent, handled bool) {
+	selEvt := ResponseEvent{Trigger: sel, TriggerName: sel.Name, Name: SelectorEventSearch, Value: evt.Value}
+	switch evt.TriggerName {
+	case "selector_result":
+		if evt.Name != TableEventRowSelected {
+			return evt, true
+		}
+		provider, err := lookupProvider(sel.Lookup)
+		if err != nil {
+			return sel.lookupError(err), true
+		}
+		row, _ := ut.ToIM(evt.Value, ut.IM{})["row"].(ut.IM)
+		value := sel.SetProperty("value", provider.Option(row))
+		sel.SetProperty("show_modal", false)
+		selEvt.Name = SelectorEventSelected
+		selEvt.Value = value
+		selEvt.Header = ut.SM{
+			HeaderRetarget: "#" + sel.Id,
+		}
+		if sel.OnResponse != nil {
+			return sel.OnResponse(selEvt), true
+		}
+		return selEvt, true
+
+	case "filter_value":
+		sel.SetProperty("data", ut.IM{evt.TriggerName: evt.Value})
+		if err := sel.lookupSearch(ut.ToString(evt.Value, ""), 0); err != nil {
+			return sel.lookupError(err), true
+		}
+		selEvt.Trigger = sel.resultTable()
+		selEvt.Header = ut.SM{
+			HeaderRetarget: "#" + sel.Id + "_selector_result",
+			HeaderReswap:   SwapOuterHTML,
+		}
+		// the paging buttons are outside of the result table
+		selEvt.OOB = []OOBComponent{
+			OOBSwap(sel.component("btn_prev_page"), SwapOuterHTML, ""),
+			OOBSwap(sel.component("btn_next_page"), SwapOuterHTML, ""),
+		}
+		return selEvt, true
+
+	case "btn_search", "btn_prev_page", "btn_next_page":
+		selEvt.Value = ut.ToString(sel.Data["filter_value"], "")
+		offset := int64(0)
+		if evt.TriggerName != "btn_search" {
+			offset = sel.lookupPage(evt.TriggerName)
+		}
+		if err := sel.lookupSearch(selEvt.Value.(string), offset); err != nil {
+			return sel.lookupError(err), true
+		}
+		return selEvt, true
+
+	case "btn_modal":
+		if !sel.CustomModal {
+			if err := sel.lookupSearch(ut.ToString(sel.Data["filter_value"], ""), 0); err != nil {
+				return sel.lookupError(err), true
+			}
+		}
+	}
+	return evt, false
+}
+
+func (sel *Selector) lookupError(err error) ResponseEvent {
+	return ResponseEvent{
+		Trigger: &Toast{
+			Type:  ToastTypeError,
+			Value: err.Error(),
+		},
+		TriggerName: sel.Name,
+		Name:        SelectorEventSearch,
+		Header: ut.SM{
+			HeaderRetarget: "#toast-msg",
+			HeaderReswap:   SwapInnerHTML,
+		},
+	}
+}
+
 func (sel *Selector) response(evt ResponseEvent) (re ResponseEvent) {
+	if sel.Lookup != "" {
+		if re, handled := sel.lookupResponse(evt); handled {
+			return re
+		}
+	}
 	selEvt := ResponseEvent{Trigger: sel, TriggerName: sel.Name, Value: sel.Value}
 	switch evt.TriggerName {
 	case "selector_result":
@@ -258,7 +386,7 @@ func (sel *Selector) response(evt ResponseEvent) (re ResponseEvent) {
 	return selEvt
 }
 
-func (sel *Selector) getComponent(name string) (html template.HTML, err error) {
+func (sel *Selector) component(name string) ClientComponent {
 	ccBtn := func(icon string, focus bool) *Button {
 		return &Button{
 			BaseComponent: BaseComponent{
@@ -308,6 +436,16 @@ func (sel *Selector) getComponent(name string) (html template.HTML, err error) {
 		"btn_search": func() ClientComponent {
 			return ccBtn(IconSearch, false)
 		},
+		"btn_prev_page": func() ClientComponent {
+			btn := ccBtn(IconArrowLeft, false)
+			btn.Disabled = sel.Disabled || ut.ToInteger(sel.Data["offset"], 0) == 0
+			return btn
+		},
+		"btn_next_page": func() ClientComponent {
+			btn := ccBtn(IconArrowRight, false)
+			btn.Disabled = sel.Disabled || !ut.ToBoolean(sel.Data["next_page"], false)
+			return btn
+		},
 		"selector_text": func() ClientComponent {
 			lbl := &Label{
 				BaseComponent: BaseComponent{
@@ -346,72 +484,104 @@ func (sel *Selector) getComponent(name string) (html template.HTML, err error) {
 			}
 		},
 		"filter_value": func() ClientComponent {
-			return ccInp(ut.ToString(sel.Data["filter_value"], ""))
+			inp := ccInp(ut.ToString(sel.Data["filter_value"], ""))
+			if sel.Lookup != "" {
+				inp.TriggerDelay = LookupDefaultDelay
+			}
+			return inp
 		},
 		"selector_result": func() ClientComponent {
-			return &Table{
-				BaseComponent: BaseComponent{
-					Id:           sel.Id + "_" + name,
-					Name:         name,
-					EventURL:     sel.EventURL,
-					OnResponse:   sel.response,
-					RequestValue: sel.RequestValue,
-					RequestMap:   sel.RequestMap,
-				},
-				Rows:              sel.Rows,
-				Fields:            sel.Fields,
-				Pagination:        PaginationTypeTop,
-				PageSize:          5,
-				HidePaginatonSize: true,
-				TableFilter:       false,
-				AddItem:           false,
-				RowSelected:       true,
-			}
+			return sel.resultTable()
 		},
 	}
-	cc := ccMap[name]()
-	html, err = cc.Render()
-	return html, err
+	return ccMap[name]()
+}
+
+func (sel *Selector) resultTable() *Table {
+	return &Table{
+		BaseComponent: BaseComponent{
+			Id:           sel.Id + "_selector_result",
+			Name:         "selector_result",
+			EventURL:     sel.EventURL,
+			OnResponse:   sel.response,
+			RequestValue: sel.RequestValue,
+			RequestMap:   sel.RequestMap,
+		},
+		Rows:              sel.Rows,
+		Fields:            sel.Fields,
+		Pagination:        PaginationTypeTop,
+		PageSize:          5,
+		HidePaginatonSize: true,
+		TableFilter:       false,
+		AddItem:           false,
+		RowSelected:       true,
+	}
 }
 
 /*
 Based on the values, it will generate the html code of the [Selector] or return with an error message.
 */
 func (sel *Selector) Render() (html template.HTML, err error) {
-	sel.InitProps(sel)
+	return RenderHTML(sel)
+}
 
-	funcMap := map[string]any{
-		"styleMap": func() bool {
-			return len(sel.Style) > 0
-		},
-		"customClass": func() string {
-			return strings.Join(sel.Class, " ")
-		},
-		"selectorComponent": func(name string) (template.HTML, error) {
-			return sel.getComponent(name)
-		},
+// the render data of the [Selector] template
+type selectorRender struct {
+	*Selector
+	hw *ut.HTMLWriter
+}
+
+// [Selector] template functions
+var selectorFuncMap = map[string]any{
+	"styleMap": func(sel *selectorRender) bool {
+		return len(sel.Style) > 0
+	},
+	"customClass": func(sel *selectorRender) string {
+		return strings.Join(sel.Class, " ")
+	},
+	"selectorComponent": func(sel *selectorRender, name string) (template.HTML, error) {
+		return "", RenderTo(sel.hw, sel.component(name))
+	},
+}
+
+/*
+Based on the values, it will write the html code of the [Selector] into the writer or return with an error message.
+*/
+func (sel *Selector) RenderTo(w io.Writer) (err error) {
+	sel.InitProps(sel)
+	if sel.Lookup != "" && sel.Value.Value != "" && sel.Value.Text == "" {
+		if option, err := LookupResolve(sel.RequestValue, sel.Lookup, sel.Value.Value); err == nil {
+			sel.Value.Text = option.Text
+		}
 	}
-	tpl := `<div id="{{ .Id }}" name="{{ .Name }}" class="selector row {{ customClass }}{{ if .Full }} full{{ end }}"
-	{{ if styleMap }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}
-	>{{ if eq .Disabled false }}<div class="cell" style="width: 39px;" >{{ selectorComponent "btn_modal" }}</div>{{ end }}
-	{{ if and (eq .Disabled false) (.IsNull) }}<div class="cell" style="width: 39px;" >{{ selectorComponent "btn_delete" }}</div>{{ end }}
-	<div class="cell" >{{ selectorComponent "selector_text" }}</div>
+
+	tpl := `<div id="{{ .Id }}" name="{{ .Name }}" class="selector row {{ customClass $ }}{{ if .Full }} full{{ end }}"
+	{{ if styleMap $ }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}
+	>{{ if eq .Disabled false }}<div class="cell" style="width: 39px;" >{{ selectorComponent $ "btn_modal" }}</div>{{ end }}
+	{{ if and (eq .Disabled false) (.IsNull) }}<div class="cell" style="width: 39px;" >{{ selectorComponent $ "btn_delete" }}</div>{{ end }}
+	<div class="cell" >{{ selectorComponent $ "selector_text" }}</div>
 	{{ if .ShowModal }}<div class="modal"><div class="dialog"><div class="panel">
 	<div class="panel-title">
 	<div class="cell title-cell"><span>{{ .Title }}</span></div>
-	<div class="cell align-right">{{ selectorComponent "btn_close" }}</div>
+	<div class="cell align-right">{{ selectorComponent $ "btn_close" }}</div>
 	</div>
 	<div class="section" >
 	<div class="row full container" >
-	<div class="cell">{{ selectorComponent "filter_value" }}</div>
-	<div class="cell" style="width: 20px;" >{{ selectorComponent "btn_search" }}</div>
+	<div class="cell">{{ selectorComponent $ "filter_value" }}</div>
+	<div class="cell" style="width: 20px;" >{{ selectorComponent $ "btn_search" }}</div>
+	{{ if ne .Lookup "" }}<div class="cell" style="width: 20px;" >{{ selectorComponent $ "btn_prev_page" }}</div>
+	<div class="cell" style="width: 20px;" >{{ selectorComponent $ "btn_next_page" }}</div>{{ end }}
 	</div>
-	<div class="row full container" >{{ selectorComponent "selector_result" }}</div>
+	<div class="row full container" >{{ selectorComponent $ "selector_result" }}</div>
 	</div>
 	</div></div></div>{{ end }}
 	</div>`
 
-	return ut.TemplateBuilder("selector", tpl, funcMap, sel)
+	hw := ut.NewHTMLWriter(w)
+	if err = ut.CachedTemplateWriter(hw, "selector", tpl, selectorFuncMap, &selectorRender{Selector: sel, hw: hw}); err == nil {
+		err = hw.Close()
+	}
+	return err
 }
 
 var testSelectorResponse func(evt ResponseEvent) (re ResponseEvent) = func(evt ResponseEvent) (re ResponseEvent) {
@@ -488,6 +658,17 @@ var testSelectorRows []ut.IM = []ut.IM{
 	},
 }
 
+var testSelectorLookup *LookupRows = &LookupRows{
+	Rows:      testSelectorRows,
+	TextField: "custname",
+	Columns:   testSelectorFields,
+}
+
+// the demo lookup is registered once
+func init() {
+	RegisterLookup("test_customer", testSelectorLookup)
+}
+
 // [Selector] test and demo data
 func TestSelector(cc ClientComponent) []TestComponent {
 	id := ut.ToString(cc.GetProperty("id"), "")
@@ -569,5 +750,22 @@ func TestSelector(cc ClientComponent) []TestComponent {
 				ModalIcon:   IconBolt,
 			},
 		},
+		{
+			Label:         "Lookup provider",
+			ComponentType: ComponentTypeList,
+			Component: &Selector{
+				BaseComponent: BaseComponent{
+					Id:           id + "_selector_lookup",
+					EventURL:     eventURL,
+					OnResponse:   testSelectorResponse,
+					RequestValue: requestValue,
+					RequestMap:   requestMap,
+				},
+				Value:  SelectOption{Value: "customer-4"},
+				Lookup: "test_customer",
+				IsNull: true,
+				Full:   true,
+			},
+		},
 	}
 }
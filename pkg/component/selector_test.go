@@ -2,6 +2,7 @@ package component
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 
 	ut "github.com/nervatura/component/pkg/util"
@@ -333,7 +334,7 @@ func TestSelector_response(t *testing.T) {
 	}
 }
 
-func TestSelector_getComponent(t *testing.T) {
+func TestSelector_component(t *testing.T) {
 	type fields struct {
 		BaseComponent     BaseComponent
 		Value             SelectOption
@@ -398,11 +399,97 @@ func TestSelector_getComponent(t *testing.T) {
 				Full:              tt.fields.Full,
 				ShowModal:         tt.fields.ShowModal,
 			}
-			_, err := sel.getComponent(tt.args.name)
+			_, err := sel.component(tt.args.name).Render()
 			if (err != nil) != tt.wantErr {
-				t.Errorf("Selector.getComponent() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("Selector.component() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 		})
 	}
 }
+
+func TestSelector_lookup(t *testing.T) {
+	RegisterLookup("test_color", testLookupRows)
+	selectorEvent := func(sel *Selector, triggerName, name string, value any) ResponseEvent {
+		return sel.response(ResponseEvent{TriggerName: triggerName, Name: name, Value: value})
+	}
+	sel := &Selector{
+		BaseComponent: BaseComponent{
+			Id: "sel", RequestValue: map[string]ut.IM{},
+			OnResponse: func(evt ResponseEvent) (re ResponseEvent) {
+				return evt
+			},
+		},
+		Value:  SelectOption{Value: "2"},
+		Lookup: "test_color",
+	}
+	if _, err := sel.Render(); err != nil || sel.Value.Text != "Green" || len(sel.Fields) != 1 {
+		t.Errorf("Selector.Render() = %v, %v", sel.Value, err)
+	}
+	if sel.SetProperty("lookup", "test_color") != "test_color" {
+		t.Error("Selector.SetProperty() lookup")
+	}
+
+	selectorEvent(sel, "btn_modal", "", nil)
+	if len(sel.Rows) != 3 || !sel.ShowModal {
+		t.Errorf("Selector btn_modal rows = %v", sel.Rows)
+	}
+	re := selectorEvent(sel, "filter_value", "", "bl")
+	if _, valid := re.Trigger.(*Table); !valid || len(sel.Rows) != 1 || re.Header[HeaderRetarget] != "#sel_selector_result" {
+		t.Errorf("Selector filter_value = %v", re)
+	}
+	if _, err := re.Trigger.Render(); err != nil {
+		t.Errorf("Selector filter_value Render() error = %v", err)
+	}
+	if re = selectorEvent(sel, "btn_search", "", nil); re.Value != "bl" || re.Name != SelectorEventSearch {
+		t.Errorf("Selector btn_search = %v", re)
+	}
+	selectorEvent(sel, "selector_result", TableEventFormChange, nil)
+	re = selectorEvent(sel, "selector_result", TableEventRowSelected, ut.IM{"row": ut.IM{"id": "3", "name": "Blue"}})
+	if re.Name != SelectorEventSelected || sel.Value.Text != "Blue" || sel.ShowModal {
+		t.Errorf("Selector selector_result = %v", re)
+	}
+	sel.OnResponse = nil
+	selectorEvent(sel, "selector_result", TableEventRowSelected, ut.IM{"row": ut.IM{"id": "1", "name": "Red"}})
+	if sel.Value.Value != "1" {
+		t.Errorf("Selector selector_result value = %v", sel.Value)
+	}
+	selectorEvent(sel, "btn_delete", "", nil)
+
+	// lookup paging
+	if sel.Validation("lookup_limit", 0) != int64(LookupDefaultLimit) || sel.SetProperty("lookup_limit", 2) != int64(2) {
+		t.Error("Selector lookup_limit")
+	}
+	sel.SetProperty("data", ut.IM{"filter_value": ""})
+	selectorEvent(sel, "btn_modal", "", nil)
+	if len(sel.Rows) != 2 || sel.Data["next_page"] != true || sel.Data["offset"] != int64(0) {
+		t.Errorf("Selector btn_modal rows = %v, %v", sel.Rows, sel.Data)
+	}
+	selectorEvent(sel, "btn_next_page", "", nil)
+	if len(sel.Rows) != 1 || sel.Data["next_page"] != false || sel.Data["offset"] != int64(2) {
+		t.Errorf("Selector btn_next_page rows = %v, %v", sel.Rows, sel.Data)
+	}
+	if html, err := sel.Render(); err != nil || !strings.Contains(string(html), `id="sel_btn_next_page"`) {
+		t.Errorf("Selector.Render() = %v, %v", html, err)
+	}
+	selectorEvent(sel, "btn_prev_page", "", nil)
+	selectorEvent(sel, "btn_prev_page", "", nil)
+	if len(sel.Rows) != 2 || sel.Data["offset"] != int64(0) {
+		t.Errorf("Selector btn_prev_page rows = %v, %v", sel.Rows, sel.Data)
+	}
+	if re = selectorEvent(sel, "filter_value", "", "e"); len(re.OOB) != 2 || sel.Data["offset"] != int64(0) {
+		t.Errorf("Selector filter_value = %v", re.OOB)
+	}
+
+	filter, _ := sel.component("filter_value").Render()
+	if filter == "" {
+		t.Error("Selector.component() filter_value")
+	}
+
+	sel.Lookup = "unknown"
+	for _, triggerName := range []string{"btn_modal", "filter_value", "btn_search", "btn_next_page", "selector_result"} {
+		if re = selectorEvent(sel, triggerName, TableEventRowSelected, nil); re.Header[HeaderRetarget] != "#toast-msg" {
+			t.Errorf("Selector %s error = %v", triggerName, re)
+		}
+	}
+}
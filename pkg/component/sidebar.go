@@ -2,6 +2,7 @@ package component
 
 import (
 	"html/template"
+	"io"
 	"strings"
 
 	ut "github.com/nervatura/component/pkg/util"
@@ -260,7 +261,7 @@ func (sb *SideBar) response(evt ResponseEvent) (re ResponseEvent) {
 	return sbEvt
 }
 
-func (sb *SideBar) getComponent(index, groupIndex int) (html template.HTML, err error) {
+func (sb *SideBar) component(index, groupIndex int) ClientComponent {
 	ccBtn := func(idx int, name string) *Button {
 		btn := &Button{
 			BaseComponent: BaseComponent{
@@ -379,60 +380,77 @@ func (sb *SideBar) getComponent(index, groupIndex int) (html template.HTML, err
 			return lbl
 		},
 	}
-	cc := ccMap[sb.Items[index].ItemType()](sb.Items[index])
-	html, err = cc.Render()
-	return html, err
+	return ccMap[sb.Items[index].ItemType()](sb.Items[index])
 }
 
 /*
 Based on the values, it will generate the html code of the [SideBar] or return with an error message.
 */
 func (sb *SideBar) Render() (html template.HTML, err error) {
+	return RenderHTML(sb)
+}
+
+// the render data of the [SideBar] template
+type sideBarRender struct {
+	*SideBar
+	hw *ut.HTMLWriter
+}
+
+// [SideBar] template functions
+var sideBarFuncMap = map[string]any{
+	"styleMap": func(sb *sideBarRender) bool {
+		return len(sb.Style) > 0
+	},
+	"customClass": func(sb *sideBarRender) string {
+		return strings.Join(sb.Class, " ")
+	},
+	"sidebarType": func(sb *sideBarRender, index int) string {
+		return sb.Items[index].ItemType()
+	},
+	"selectedComponent": func(sb *sideBarRender, index int) bool {
+		return sb.Items[index].GetSelected()
+	},
+	"validState": func(index int) bool {
+		return index <= 1
+	},
+	"sidebarComponent": func(sb *sideBarRender, index, groupIndex int) (template.HTML, error) {
+		return "", RenderTo(sb.hw, sb.component(index, groupIndex))
+	},
+}
+
+/*
+Based on the values, it will write the html code of the [SideBar] into the writer or return with an error message.
+*/
+func (sb *SideBar) RenderTo(w io.Writer) (err error) {
 	sb.InitProps(sb)
 
-	funcMap := map[string]any{
-		"styleMap": func() bool {
-			return len(sb.Style) > 0
-		},
-		"customClass": func() string {
-			return strings.Join(sb.Class, " ")
-		},
-		"sidebarType": func(index int) string {
-			return sb.Items[index].ItemType()
-		},
-		"selectedComponent": func(index int) bool {
-			return sb.Items[index].GetSelected()
-		},
-		"validState": func(index int) bool {
-			return index <= 1
-		},
-		"sidebarComponent": func(index, groupIndex int) (template.HTML, error) {
-			return sb.getComponent(index, groupIndex)
-		},
-	}
 	tpl := `<div id="{{ .Id }}" name="{{ .Name }}" 
-	class="sidebar {{ customClass }}{{ if ne .Visibility "auto" }} {{ .Visibility  }}{{ end }}"
-	{{ if styleMap }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}
+	class="sidebar {{ customClass $ }}{{ if ne .Visibility "auto" }} {{ .Visibility  }}{{ end }}"
+	{{ if styleMap $ }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}
 	>{{ range $index, $item := .Items }}
-	{{ $stype := sidebarType $index  }}
+	{{ $stype := sidebarType $ $index  }}
 	{{ if eq $stype "separator" }}<hr id="separator_{{ $index }}" class="separator" />{{ end }}
-	{{ if eq $stype "static" }}<div class="row full"><div id="static_{{ $index }}" class="static-label" >{{ sidebarComponent $index -1 }}</div></div>{{ end }}
-	{{ if eq $stype "element" }}{{ sidebarComponent $index -1 }}{{ end }}
-	{{ if eq $stype "link" }}{{ sidebarComponent $index -1 }}{{ end }}
-	{{ if eq $stype "group" }}<div class="row full">{{ sidebarComponent $index -1 }}</div>
-	{{ if selectedComponent $index }}<div class="row full sidebar-group" >
-	{{ range $groupIndex, $groupItem := $item.Items }}{{ sidebarComponent $index $groupIndex }}{{ end }}
+	{{ if eq $stype "static" }}<div class="row full"><div id="static_{{ $index }}" class="static-label" >{{ sidebarComponent $ $index -1 }}</div></div>{{ end }}
+	{{ if eq $stype "element" }}{{ sidebarComponent $ $index -1 }}{{ end }}
+	{{ if eq $stype "link" }}{{ sidebarComponent $ $index -1 }}{{ end }}
+	{{ if eq $stype "group" }}<div class="row full">{{ sidebarComponent $ $index -1 }}</div>
+	{{ if selectedComponent $ $index }}<div class="row full sidebar-group" >
+	{{ range $groupIndex, $groupItem := $item.Items }}{{ sidebarComponent $ $index $groupIndex }}{{ end }}
 	</div>{{ end }}
 	{{ end }}
 	{{ if eq $stype "state" }}<div class="row full container">
 	{{ range $groupIndex, $groupItem := $item.Items }}{{ if validState $groupIndex }}
-	<div class="cell half">{{ sidebarComponent $index $groupIndex }}</div>
+	<div class="cell half">{{ sidebarComponent $ $index $groupIndex }}</div>
 	{{ end }}{{ end }}
 	</div>{{ end }}
 	{{ end }}
 	</div>`
 
-	return ut.TemplateBuilder("sidebar", tpl, funcMap, sb)
+	hw := ut.NewHTMLWriter(w)
+	if err = ut.CachedTemplateWriter(hw, "sidebar", tpl, sideBarFuncMap, &sideBarRender{SideBar: sb, hw: hw}); err == nil {
+		err = hw.Close()
+	}
+	return err
 }
 
 var testSidebarResponse func(evt ResponseEvent) (re ResponseEvent) = func(evt ResponseEvent) (re ResponseEvent) {
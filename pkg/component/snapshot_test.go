@@ -0,0 +1,67 @@
+package component
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	ut "github.com/nervatura/component/pkg/util"
+	"github.com/nervatura/component/test/snapshot"
+)
+
+// The example data functions of the components
+var snapshotTests = map[string]func(cc ClientComponent) []TestComponent{
+	ComponentTypeAutocomplete: TestAutocomplete, ComponentTypeBrowser: TestBrowser, ComponentTypeButton: TestButton,
+	ComponentTypeCalendar: TestCalendar, ComponentTypeChart: TestChart, ComponentTypeClient: TestClient,
+	ComponentTypeCommandPalette: TestCommandPalette, ComponentTypeDashboard: TestDashboard,
+	ComponentTypeDateTime: TestDateTime, ComponentTypeEditor: TestEditor,
+	ComponentTypeField: TestField, ComponentTypeForm: TestForm, ComponentTypeIcon: TestIcon,
+	ComponentTypeInput: TestInput, ComponentTypeKanban: TestKanban, ComponentTypeLabel: TestLabel,
+	ComponentTypeLink: TestLink, ComponentTypeList: TestList, ComponentTypeLogin: TestLogin,
+	ComponentTypeMarkdown: TestMarkdown, ComponentTypeMenuBar: TestMenuBar,
+	ComponentTypeNumberInput: TestNumberInput, ComponentTypePagination: TestPagination,
+	ComponentTypeRichText: TestRichText, ComponentTypeRow: TestRow,
+	ComponentTypeSearch: TestSearch, ComponentTypeSelect: TestSelect, ComponentTypeSelector: TestSelector,
+	ComponentTypeSideBar: TestSidebar, ComponentTypeTable: TestTable, ComponentTypeToast: TestToast,
+	ComponentTypeToggle: TestToggle, ComponentTypeTreeView: TestTreeView, ComponentTypeUpload: TestUpload,
+	ComponentTypeWizard: TestWizard,
+}
+
+var snapshotName = regexp.MustCompile(`[^a-z0-9]+`)
+
+// The stamp of the table example data is the package initialization time
+func snapshotReplace() []string {
+	stamp := testTableRows[4]["stamp"].(time.Time).Format("2006-01-02 15:04")
+	return []string{"<span>" + stamp + "</span>", "<span>2006-01-02 15:04</span>"}
+}
+
+/*
+Compares the html output of the component example data with the golden files of the testdata/snapshot
+directory. Update the golden files after an intended change of the html output:
+
+	go test ./pkg/component -run TestSnapshot -update
+*/
+func TestSnapshot(t *testing.T) {
+	// the fixed current time of the default date and time values
+	timeNow = func() time.Time {
+		return time.Date(2006, 1, 2, 15, 4, 0, 0, time.Local)
+	}
+	defer func() { timeNow = time.Now }()
+	for componentType, testData := range snapshotTests {
+		names := map[string]int{}
+		for _, tc := range testData(&BaseComponent{EventURL: "/demo"}) {
+			name := strings.Trim(snapshotName.ReplaceAllString(strings.ToLower(tc.Label), "_"), "_")
+			if names[name]++; names[name] > 1 {
+				name += "_" + ut.ToString(names[name], "")
+			}
+			t.Run(componentType+"/"+name, func(t *testing.T) {
+				html, err := tc.Component.Render()
+				if err != nil {
+					t.Fatal(err)
+				}
+				snapshot.Match(t, componentType+"/"+name, string(html), snapshotReplace()...)
+			})
+		}
+	}
+}
@@ -2,6 +2,7 @@ package component
 
 import (
 	"html/template"
+	"io"
 
 	ut "github.com/nervatura/component/pkg/util"
 )
@@ -17,11 +18,18 @@ type Spinner struct {
 Based on the values, it will generate the html code of the [Spinner] or return with an error message.
 */
 func (spn *Spinner) Render() (html template.HTML, err error) {
+	return RenderHTML(spn)
+}
+
+/*
+Based on the values, it will write the html code of the [Spinner] into the writer or return with an error message.
+*/
+func (spn *Spinner) RenderTo(w io.Writer) (err error) {
 	spn.Id = ut.ToString(spn.Id, "spinner")
 	tpl := `<div id="{{ .Id }}" class="htmx-indicator{{ if eq .NoModal false }} modal{{ end }}" >
 	<div class="loading-middle" ><div class="loading">
 	<div></div><div></div><div></div><div></div><div></div><div></div><div></div><div></div>
 	</div></div></div>`
 
-	return ut.TemplateBuilder("spinner", tpl, map[string]any{}, spn)
+	return ut.CachedTemplateWriter(w, "spinner", tpl, map[string]any{}, spn)
 }
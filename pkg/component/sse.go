@@ -0,0 +1,285 @@
+package component
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// [SSEHub] constants
+const (
+	// The event name of the pushed html fragments. The [Application] swaps them by the hx-swap-oob attributes.
+	SSEEventComponent = "component"
+
+	SSEHeartbeat   = 15 * time.Second
+	SSERetry       = 3 * time.Second
+	SSETimeout     = time.Minute
+	SSEHistorySize = 32
+	// The size of the message buffer of a connection. A slower client is disconnected and reconnects.
+	sseBufferSize = 16
+)
+
+type sseMessage struct {
+	id    uint64
+	event string
+	data  string
+}
+
+func (msg *sseMessage) writeTo(w io.Writer) (err error) {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("id: %d\nevent: %s\n", msg.id, msg.event))
+	for _, line := range strings.Split(msg.data, "\n") {
+		sb.WriteString("data: " + line + "\n")
+	}
+	sb.WriteString("\n")
+	_, err = io.WriteString(w, sb.String())
+	return err
+}
+
+type sseSession struct {
+	clients map[chan sseMessage]bool
+	history []sseMessage
+	// The session had an open connection. The messages published before the first connection are sent to it.
+	connected bool
+	// The disconnection time of the last client or the creation time of a session without connections
+	closed time.Time
+}
+
+/*
+SSEHub is a server-sent events push channel of the client sessions. The [Application] connects to the hub by
+the SSEConnect URL and the htmx sse extension, and the published components are swapped into the page as
+out-of-band ([OOBComponent]) fragments. The closed connections are reopened by the browser, and the missed
+messages are sent again based on the Last-Event-ID request header. The zero value is ready to use.
+
+The messages of a session are stored (HistorySize) before its first connection too, and they are sent to the first
+opened event stream. A message is lost, if the session is removed after the Timeout without connections or the
+client misses more than HistorySize messages. A new connection without the Last-Event-ID header (page reload)
+gets only the new messages.
+
+For example:
+
+	hub := &SSEHub{}
+	mux.Handle("GET /sse", hub)
+	app := &Application{SSEConnect: "/sse?session=" + sessionID, ...}
+	...
+	hub.PublishComponent(sessionID, &Toast{Type: ToastTypeSuccess, Value: "Job finished"})
+*/
+type SSEHub struct {
+	// The heartbeat comment interval of the open connections. Default value: [SSEHeartbeat]
+	Heartbeat time.Duration
+	// The reconnection time of the browser. Default value: [SSERetry]
+	Retry time.Duration
+	// A session without connections and its stored messages are removed after the timeout. Default value: [SSETimeout]
+	Timeout time.Duration
+	// The number of stored messages of a session for the reconnecting clients. Default value: [SSEHistorySize]
+	HistorySize int
+	// Returns the session identifier of the request. Default value: the session query parameter of the URL
+	SessionID func(r *http.Request) string
+	mu        sync.Mutex
+	sessions  map[string]*sseSession
+	eventID   uint64
+}
+
+func (hub *SSEHub) duration(value, defValue time.Duration) time.Duration {
+	if value > 0 {
+		return value
+	}
+	return defValue
+}
+
+func (hub *SSEHub) sessionID(r *http.Request) string {
+	if hub.SessionID != nil {
+		return hub.SessionID(r)
+	}
+	return r.URL.Query().Get("session")
+}
+
+// Removes the sessions without connections after the timeout. The hub must be locked.
+func (hub *SSEHub) prune() {
+	for id, ss := range hub.sessions {
+		if len(ss.clients) == 0 && time.Since(ss.closed) > hub.duration(hub.Timeout, SSETimeout) {
+			delete(hub.sessions, id)
+		}
+	}
+}
+
+func (hub *SSEHub) subscribe(session string, lastID uint64) (ch chan sseMessage, history []sseMessage) {
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+	hub.prune()
+	if hub.sessions == nil {
+		hub.sessions = map[string]*sseSession{}
+	}
+	ss, found := hub.sessions[session]
+	if !found {
+		ss = &sseSession{clients: map[chan sseMessage]bool{}}
+		hub.sessions[session] = ss
+	}
+	ch = make(chan sseMessage, sseBufferSize)
+	ss.clients[ch] = true
+	if lastID > 0 || !ss.connected {
+		for _, msg := range ss.history {
+			if msg.id > lastID {
+				history = append(history, msg)
+			}
+		}
+	}
+	ss.connected = true
+	return ch, history
+}
+
+func (hub *SSEHub) unsubscribe(session string, ch chan sseMessage) {
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+	if ss, found := hub.sessions[session]; found && ss.clients[ch] {
+		delete(ss.clients, ch)
+		close(ch)
+		ss.closed = time.Now()
+	}
+}
+
+// Sends the html fragments to the clients of the session or all sessions.
+func (hub *SSEHub) push(session string, all bool, data string) {
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+	size := hub.HistorySize
+	if size <= 0 {
+		size = SSEHistorySize
+	}
+	hub.prune()
+	if hub.sessions == nil {
+		hub.sessions = map[string]*sseSession{}
+	}
+	if _, found := hub.sessions[session]; !found && !all {
+		// the messages are stored until the first connection of the session
+		hub.sessions[session] = &sseSession{clients: map[chan sseMessage]bool{}, closed: time.Now()}
+	}
+	for id, ss := range hub.sessions {
+		if !all && id != session {
+			continue
+		}
+		hub.eventID++
+		msg := sseMessage{id: hub.eventID, event: SSEEventComponent, data: data}
+		if ss.history = append(ss.history, msg); len(ss.history) > size {
+			ss.history = ss.history[len(ss.history)-size:]
+		}
+		for ch := range ss.clients {
+			select {
+			case ch <- msg:
+			default:
+				// the slow client is disconnected and it gets the missed messages after the reconnection
+				delete(ss.clients, ch)
+				close(ch)
+				ss.closed = time.Now()
+			}
+		}
+	}
+}
+
+/*
+The Publish function renders the out-of-band components and pushes them to the connected clients of the
+session. The messages are also stored for the reconnecting clients and for the first connection of the
+session. See more [SSEHub].
+*/
+func (hub *SSEHub) Publish(session string, oob ...OOBComponent) error {
+	html, err := RenderHTML(&ResponseEvent{OOB: oob})
+	if err == nil {
+		hub.push(session, false, string(html))
+	}
+	return err
+}
+
+// The Broadcast function pushes the out-of-band components to all known sessions of the hub.
+func (hub *SSEHub) Broadcast(oob ...OOBComponent) error {
+	html, err := RenderHTML(&ResponseEvent{OOB: oob})
+	if err == nil {
+		hub.push("", true, string(html))
+	}
+	return err
+}
+
+/*
+The PublishComponent function replaces the component with the same id on the page of the session. The
+[Toast] components are displayed as a message of the [Application].
+*/
+func (hub *SSEHub) PublishComponent(session string, cc ClientComponent) error {
+	if _, valid := cc.(*Toast); valid {
+		return hub.Publish(session, OOBSwap(cc, SwapInnerHTML, OOBTargetToast))
+	}
+	return hub.Publish(session, OOBSwap(cc, SwapOuterHTML, ""))
+}
+
+// The PublishHTML function replaces the inner html of the element with the id on the page of the session.
+func (hub *SSEHub) PublishHTML(session, id string, html template.HTML) {
+	hub.push(session, false,
+		`<div hx-swap-oob="innerHTML:#`+template.HTMLEscapeString(id)+`">`+string(html)+`</div>`)
+}
+
+// The Clients function returns the number of the open connections of the session.
+func (hub *SSEHub) Clients(session string) int {
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+	if ss, found := hub.sessions[session]; found {
+		return len(ss.clients)
+	}
+	return 0
+}
+
+// The Close function closes all connections and removes the sessions of the hub. It should be called before the server shutdown.
+func (hub *SSEHub) Close() {
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+	for id, ss := range hub.sessions {
+		for ch := range ss.clients {
+			close(ch)
+		}
+		delete(hub.sessions, id)
+	}
+}
+
+/*
+ServeHTTP opens the event stream of the session. The session identifier is returned by the SessionID function.
+*/
+func (hub *SSEHub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	flusher, valid := w.(http.Flusher)
+	session := hub.sessionID(r)
+	if !valid || session == "" {
+		http.Error(w, "invalid event stream request", http.StatusBadRequest)
+		return
+	}
+	// the WriteTimeout of the server is not applied to the event stream
+	http.NewResponseController(w).SetWriteDeadline(time.Time{})
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	lastID, _ := strconv.ParseUint(r.Header.Get("Last-Event-ID"), 10, 64)
+	ch, history := hub.subscribe(session, lastID)
+	defer hub.unsubscribe(session, ch)
+
+	_, err := fmt.Fprintf(w, "retry: %d\n\n", hub.duration(hub.Retry, SSERetry).Milliseconds())
+	for index := 0; index < len(history) && err == nil; index++ {
+		err = history[index].writeTo(w)
+	}
+	ticker := time.NewTicker(hub.duration(hub.Heartbeat, SSEHeartbeat))
+	defer ticker.Stop()
+	for err == nil {
+		flusher.Flush()
+		select {
+		case <-r.Context().Done():
+			return
+		case msg, open := <-ch:
+			if !open {
+				return
+			}
+			err = msg.writeTo(w)
+		case <-ticker.C:
+			_, err = io.WriteString(w, ": heartbeat\n\n")
+		}
+	}
+}
@@ -0,0 +1,251 @@
+package component
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testSSEStream struct {
+	resp   *http.Response
+	reader *bufio.Reader
+	cancel context.CancelFunc
+}
+
+func testSSEConnect(t *testing.T, hub *SSEHub, url, lastID string) *testSSEStream {
+	ctx, cancel := context.WithCancel(context.Background())
+	req, _ := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if lastID != "" {
+		req.Header.Set("Last-Event-ID", lastID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream := &testSSEStream{resp: resp, reader: bufio.NewReader(resp.Body), cancel: cancel}
+	t.Cleanup(stream.close)
+	if block := stream.read(t); !strings.HasPrefix(block, "retry: ") {
+		t.Fatalf("SSEHub.ServeHTTP() retry = %s", block)
+	}
+	return stream
+}
+
+// Returns the next event block of the stream
+func (stream *testSSEStream) read(t *testing.T) string {
+	var sb strings.Builder
+	for {
+		line, err := stream.reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("SSE read error = %v", err)
+		}
+		if line == "\n" {
+			return sb.String()
+		}
+		sb.WriteString(line)
+	}
+}
+
+func (stream *testSSEStream) close() {
+	stream.cancel()
+	stream.resp.Body.Close()
+}
+
+func testSSEWait(t *testing.T, hub *SSEHub, session string, clients int) {
+	for range 200 {
+		if hub.Clients(session) == clients {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("SSEHub.Clients() = %d, want %d", hub.Clients(session), clients)
+}
+
+func TestSSEHub_ServeHTTP(t *testing.T) {
+	hub := &SSEHub{Retry: time.Second}
+	server := httptest.NewServer(hub)
+	defer server.Close()
+
+	stream := testSSEConnect(t, hub, server.URL+"?session=s1", "")
+	other := testSSEConnect(t, hub, server.URL+"?session=s2", "")
+	testSSEWait(t, hub, "s1", 1)
+	testSSEWait(t, hub, "s2", 1)
+
+	for _, tt := range []struct {
+		name    string
+		publish func() error
+		want    []string
+	}{
+		{
+			name: "toast",
+			publish: func() error {
+				return hub.PublishComponent("s1", &Toast{Value: "Job finished"})
+			},
+			want: []string{"event: component\n", `data: <div hx-swap-oob="innerHTML:#toast-msg">`, "Job finished"},
+		},
+		{
+			name: "component",
+			publish: func() error {
+				return hub.PublishComponent("s1", &Label{BaseComponent: BaseComponent{Id: "badge"}, Value: "3"})
+			},
+			want: []string{`data: <span hx-swap-oob="outerHTML:#badge" id="badge"`},
+		},
+		{
+			name: "html",
+			publish: func() error {
+				hub.PublishHTML("s1", "total", "<b>1\n2</b>")
+				return nil
+			},
+			want: []string{"data: <div hx-swap-oob=\"innerHTML:#total\"><b>1\ndata: 2</b></div>\n"},
+		},
+		{
+			name: "broadcast",
+			publish: func() error {
+				return hub.Broadcast(OOBToast(ToastTypeInfo, "Maintenance", 0))
+			},
+			want: []string{"Maintenance"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.publish(); err != nil {
+				t.Fatal(err)
+			}
+			block := stream.read(t)
+			for _, want := range tt.want {
+				if !strings.Contains(block, want) {
+					t.Errorf("SSEHub event = %s, missing %s", block, want)
+				}
+			}
+		})
+	}
+	if block := other.read(t); !strings.Contains(block, "Maintenance") {
+		t.Errorf("SSEHub.Broadcast() = %s", block)
+	}
+	if err := hub.Publish("s1", OOBSwap(&testStreamComponent{}, SwapOuterHTML, "")); err == nil {
+		t.Error("SSEHub.Publish() error = nil")
+	}
+	if err := hub.Broadcast(OOBSwap(&testStreamComponent{}, SwapOuterHTML, "")); err == nil {
+		t.Error("SSEHub.Broadcast() error = nil")
+	}
+
+	// the missed messages are sent again after the reconnection
+	stream.close()
+	testSSEWait(t, hub, "s1", 0)
+	hub.PublishHTML("s1", "total", "missed")
+	hub.PublishHTML("s3", "total", "pending")
+	stream = testSSEConnect(t, hub, server.URL+"?session=s1", "3")
+	if block := stream.read(t); !strings.Contains(block, "Maintenance") {
+		t.Errorf("SSEHub reconnect = %s", block)
+	}
+	if block := stream.read(t); !strings.Contains(block, "missed") {
+		t.Errorf("SSEHub reconnect = %s", block)
+	}
+	if hub.Clients("unknown") != 0 {
+		t.Error("SSEHub.Clients() unknown session")
+	}
+
+	// the messages published before the first connection are sent to the new stream
+	pending := testSSEConnect(t, hub, server.URL+"?session=s3", "")
+	if block := pending.read(t); !strings.Contains(block, "pending") {
+		t.Errorf("SSEHub first connection = %s", block)
+	}
+	// a new connection of the session without Last-Event-ID gets only the new messages
+	reload := testSSEConnect(t, hub, server.URL+"?session=s3", "")
+	testSSEWait(t, hub, "s3", 2)
+	hub.PublishHTML("s3", "total", "new")
+	if block := reload.read(t); !strings.Contains(block, "new") {
+		t.Errorf("SSEHub reload = %s", block)
+	}
+
+	// the hub closes the open connections
+	hub.Close()
+	if _, err := stream.reader.ReadString('\n'); err == nil {
+		t.Error("SSEHub.Close() stream is open")
+	}
+}
+
+func TestSSEHub_heartbeat(t *testing.T) {
+	hub := &SSEHub{
+		Heartbeat: 10 * time.Millisecond,
+		SessionID: func(r *http.Request) string {
+			return r.Header.Get("X-Session-Token")
+		},
+	}
+	server := httptest.NewServer(hub)
+	defer server.Close()
+
+	resp, _ := http.Get(server.URL)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("SSEHub.ServeHTTP() status = %d", resp.StatusCode)
+	}
+	req, _ := http.NewRequest("GET", server.URL, nil)
+	req.Header.Set("X-Session-Token", "token")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Errorf("SSEHub.ServeHTTP() Content-Type = %s", resp.Header.Get("Content-Type"))
+	}
+	stream := &testSSEStream{resp: resp, reader: bufio.NewReader(resp.Body), cancel: func() {}}
+	stream.read(t)
+	if block := stream.read(t); block != ": heartbeat\n" {
+		t.Errorf("SSEHub heartbeat = %s", block)
+	}
+}
+
+type testSSEWriter struct {
+	*httptest.ResponseRecorder
+}
+
+func (w *testSSEWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("error")
+}
+
+func TestSSEHub_session(t *testing.T) {
+	hub := &SSEHub{HistorySize: 2, Timeout: time.Nanosecond, Heartbeat: time.Millisecond}
+
+	// the slow client is disconnected
+	ch, _ := hub.subscribe("slow", 0)
+	for range sseBufferSize + 1 {
+		hub.PublishHTML("slow", "id", "value")
+	}
+	if _, open := <-ch; !open || hub.Clients("slow") != 0 || len(hub.sessions["slow"].history) != 2 {
+		t.Errorf("SSEHub slow client = %d", hub.Clients("slow"))
+	}
+	hub.unsubscribe("slow", ch)
+
+	// the session without connections is removed after the timeout
+	time.Sleep(time.Millisecond)
+	ch, _ = hub.subscribe("next", 0)
+	if _, found := hub.sessions["slow"]; found {
+		t.Error("SSEHub.prune() session is not removed")
+	}
+	hub.unsubscribe("next", ch)
+
+	// the stored messages of a session without connections are removed after the timeout
+	hub.PublishHTML("pending", "id", "value")
+	time.Sleep(time.Millisecond)
+	if ch, history := hub.subscribe("pending", 0); len(history) != 0 {
+		t.Errorf("SSEHub.subscribe() history = %v", history)
+	} else {
+		hub.unsubscribe("pending", ch)
+	}
+
+	// write errors and the closed request
+	r := httptest.NewRequest("GET", "/sse?session=error", nil)
+	hub.ServeHTTP(&testSSEWriter{httptest.NewRecorder()}, r)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	w := httptest.NewRecorder()
+	hub.ServeHTTP(w, r.WithContext(ctx))
+	if !strings.HasPrefix(w.Body.String(), "retry: 3000") {
+		t.Errorf("SSEHub.ServeHTTP() = %s", w.Body.String())
+	}
+}
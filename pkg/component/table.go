@@ -3,6 +3,8 @@ package component
 import (
 	"fmt"
 	"html/template"
+	"io"
+	"maps"
 	"math"
 	"slices"
 	"sort"
@@ -157,6 +159,8 @@ type TableField struct {
 	This can be useful if the field value affects the possible values ​​of other fields in the row.
 	Only Editable is true. */
 	TriggerEvent bool `json:"trigger_event"`
+	// The name of a registered [LookupProvider]. If the Options is empty, it is loaded from the lookup.
+	Lookup string `json:"lookup"`
 }
 
 // [Table] column
@@ -234,13 +238,14 @@ func (tbl *Table) tableFieldsValidation(propValue interface{}) []TableField {
 					Options:       SelectOptionRangeValidation(values["options"], []SelectOption{}),
 					Required:      ut.ToBoolean(values["required"], false),
 					TriggerEvent:  ut.ToBoolean(values["trigger_event"], false),
+					Lookup:        ut.ToString(values["lookup"], ""),
 				})
 			}
 		}
 	}
 	if len(fields) == 0 {
 		if len(tbl.Rows) > 0 {
-			for field := range tbl.Rows[0] {
+			for _, field := range slices.Sorted(maps.Keys(tbl.Rows[0])) {
 				fields = append(fields,
 					TableField{Name: field, FieldType: TableFieldTypeString, Label: field})
 			}
@@ -623,7 +628,7 @@ func (tbl *Table) Response(evt ResponseEvent) (re ResponseEvent) {
 	return tblEvt
 }
 
-func (tbl *Table) getComponent(name string, pageCount int64, data ut.IM) (html template.HTML, err error) {
+func (tbl *Table) component(name string, pageCount int64, data ut.IM) ClientComponent {
 	ccPgn := func() *Pagination {
 		return &Pagination{
 			BaseComponent: BaseComponent{
@@ -871,15 +876,13 @@ func (tbl *Table) getComponent(name string, pageCount int64, data ut.IM) (html t
 			}
 		},
 	}
-	cc := ccMap[name]()
-	html, err = cc.Render()
-	return html, err
+	return ccMap[name]()
 }
 
 func (tbl *Table) getStyle(styleMap ut.SM) string {
 	style := []string{}
-	for key, value := range styleMap {
-		style = append(style, key+":"+value)
+	for _, key := range slices.Sorted(maps.Keys(styleMap)) {
+		style = append(style, key+":"+styleMap[key])
 	}
 	if len(style) > 0 {
 		return fmt.Sprintf(` style="%s;"`, strings.Join(style, ";"))
@@ -887,6 +890,14 @@ func (tbl *Table) getStyle(styleMap ut.SM) string {
 	return ""
 }
 
+// cellComponent writes the label and the html code of the child component into the cell value
+func (tbl *Table) cellComponent(label, name string, data ut.IM) template.HTML {
+	var sb strings.Builder
+	sb.WriteString(label)
+	_ = RenderTo(&sb, tbl.component(name, 0, data))
+	return template.HTML(sb.String())
+}
+
 type cellFormatOptions struct {
 	Value        interface{}
 	Label        string
@@ -908,13 +919,12 @@ func (tbl *Table) cellFormat(fmtType string, options cellFormatOptions) template
 				`<span class="cell-label">%s</span>`, options.Label)
 			integer := (options.FieldType == TableFieldTypeInteger)
 			if options.EditCell {
-				inp, _ := tbl.getComponent("form_number", 0, ut.IM{
+				return tbl.cellComponent(numberLabel, "form_number", ut.IM{
 					"value":         options.Value,
 					"fieldname":     options.FieldName,
 					"integer":       integer,
 					"trigger_event": options.TriggerEvent,
 				})
-				return template.HTML(numberLabel + string(inp))
 			}
 			return template.HTML(fmt.Sprintf(
 				`<div class="number-cell">%s<span %s >%s</span></div>`,
@@ -953,14 +963,13 @@ func (tbl *Table) cellFormat(fmtType string, options cellFormatOptions) template
 				fmtValue = ""
 			}
 			if options.EditCell {
-				inp, _ := tbl.getComponent("form_datetime", 0, ut.IM{
+				return tbl.cellComponent(dateLabel, "form_datetime", ut.IM{
 					"value":         fmtValue,
 					"fieldname":     options.FieldName,
 					"type":          tbl.CheckEnumValue(options.FieldType, DateTimeTypeDateTime, DateTimeType),
 					"required":      options.Required,
 					"trigger_event": options.TriggerEvent,
 				})
-				return template.HTML(dateLabel + string(inp))
 			}
 			return template.HTML(fmt.Sprintf(`%s<span>%s</span>`, dateLabel, fmtValue))
 		},
@@ -969,43 +978,36 @@ func (tbl *Table) cellFormat(fmtType string, options cellFormatOptions) template
 				`<span class="cell-label">%s</span>`, options.Label)
 			value := ut.ToString(ut.ToBoolean(options.Value, false), "false")
 			if options.EditCell {
-				inp, _ := tbl.getComponent("form_bool", 0, ut.IM{
+				return tbl.cellComponent(boolLabel, "form_bool", ut.IM{
 					"value":         options.Value,
 					"fieldname":     options.FieldName,
 					"trigger_event": options.TriggerEvent,
 				})
-				return template.HTML(boolLabel + string(inp))
 			}
-			icon, _ := tbl.getComponent("icon_"+value, 0, ut.IM{})
-			return template.HTML(fmt.Sprintf(
-				`%s<span class="middle centered">%s</span>`, boolLabel, string(icon)))
+			return tbl.cellComponent(boolLabel+`<span class="middle centered">`, "icon_"+value, ut.IM{}) + `</span>`
 		},
 		"link": func() template.HTML {
 			linkLabel := fmt.Sprintf(
 				`<span class="cell-label">%s</span>`, options.Label)
 			if options.EditCell {
-				inp, _ := tbl.getComponent("form_string", 0, ut.IM{
+				return tbl.cellComponent(linkLabel, "form_string", ut.IM{
 					"value": options.Value, "fieldname": options.FieldName,
 					"trigger_event": options.TriggerEvent,
 				})
-				return template.HTML(linkLabel + string(inp))
 			}
-			var link template.HTML
-			link, _ = tbl.getComponent("link_cell", 0, ut.IM{
+			return tbl.cellComponent(linkLabel, "link_cell", ut.IM{
 				"value": options.Value, "fieldname": options.FieldName, "result": options.ResultValue, "row": options.RowData,
 			})
-			return template.HTML(linkLabel + string(link))
 		},
 		"string": func() template.HTML {
 			stringLabel := fmt.Sprintf(
 				`<span class="cell-label">%s</span>`, options.Label)
 			if options.EditCell {
-				inp, _ := tbl.getComponent("form_string", 0, ut.IM{
+				return tbl.cellComponent(stringLabel, "form_string", ut.IM{
 					"value": options.Value, "fieldname": options.FieldName,
 					"options": options.Options, "required": options.Required,
 					"trigger_event": options.TriggerEvent,
 				})
-				return template.HTML(stringLabel + string(inp))
 			}
 			for _, opt := range options.Options {
 				if opt.Value == options.Value {
@@ -1316,127 +1318,160 @@ func (tbl *Table) tableMap(key string, row ut.IM, index int) bool {
 	return rMap[key]()
 }
 
+func (tbl *Table) lookupFields() {
+	fields := slices.Clone(tbl.Fields)
+	for index, field := range fields {
+		if field.Lookup != "" && len(field.Options) == 0 {
+			fields[index].Options = LookupOptions(tbl.RequestValue, field.Lookup)
+		}
+	}
+	tbl.Fields = fields
+}
+
 /*
 Based on the values, it will generate the html code of the [Table] or return with an error message.
 */
 func (tbl *Table) Render() (html template.HTML, err error) {
+	return RenderHTML(tbl)
+}
+
+// the render data of the [Table] template
+type tableRender struct {
+	*Table
+	cols      []TableColumn
+	pageCount int64
+	rows      []ut.IM
+	hw        *ut.HTMLWriter
+}
+
+// [Table] template functions
+var tableFuncMap = map[string]any{
+	"styleMap": func(tbl *tableRender) bool {
+		return tbl.tableMap("styleMap", ut.IM{}, 0)
+	},
+	"customClass": func(tbl *tableRender) string {
+		return strings.Join(tbl.Class, " ")
+	},
+	"topPagination": func(tbl *tableRender) bool {
+		return tbl.tableMap("topPagination", ut.IM{}, 0)
+	},
+	"bottomPagination": func(tbl *tableRender) bool {
+		return tbl.tableMap("bottomPagination", ut.IM{}, 0)
+	},
+	"tableComponent": func(tbl *tableRender, name string) (template.HTML, error) {
+		return "", RenderTo(tbl.hw, tbl.component(name, tbl.pageCount, ut.IM{}))
+	},
+	"pageRows": func(tbl *tableRender) []ut.IM {
+		if tbl.Pagination != PaginationTypeNone {
+			currentPage := tbl.Validation("current_page", tbl.CurrentPage).(int64)
+			start := (currentPage - 1) * tbl.PageSize
+			end := currentPage * tbl.PageSize
+			if end > int64(len(tbl.rows)) {
+				end = int64(len(tbl.rows))
+			}
+			return tbl.rows[start:end]
+		}
+		return tbl.rows
+	},
+	"colID": func(tbl *tableRender, col TableColumn) string {
+		colID := tbl.Id + "_header_" + col.Id
+		_ = RenderTo(io.Discard, tbl.component("header_sort", tbl.pageCount, ut.IM{"col_id": colID, "fieldname": col.Id, "fieldtype": col.Field.FieldType}))
+		return colID
+	},
+	"rowTrigger": func(tbl *tableRender, index int) bool {
+		return tbl.tableMap("rowTrigger", ut.IM{}, index)
+	},
+	"formBtn": func(tbl *tableRender, row ut.IM, index int) bool {
+		return tbl.tableMap("formBtn", row, index)
+	},
+	"rowID": func(tbl *tableRender, row ut.IM, index int) string {
+		rowID := tbl.Id + "_row_" + ut.ToString(index, "")
+		_ = RenderTo(io.Discard, tbl.component("data_row", tbl.pageCount, ut.IM{"row_id": rowID, "row": row, "index": index}))
+		return rowID
+	},
+	"pointerClass": func(tbl *tableRender, row ut.IM, index int) string {
+		if disabled, found := row["disabled"].(bool); found && disabled {
+			return "cursor-disabled"
+		}
+		if (tbl.RowSelected && !tbl.Editable) || (tbl.Editable && (int64(index) != tbl.EditIndex-1)) {
+			return "cursor-pointer"
+		}
+		return ""
+	},
+	"cols": func(tbl *tableRender) []TableColumn {
+		return tbl.cols
+	},
+	"sortClass": func(tbl *tableRender, colID string) string {
+		if tbl.SortCol == colID && !tbl.Unsortable {
+			if tbl.SortAsc {
+				return "sort-asc"
+			}
+			return "sort-desc"
+		}
+		return "sort-none"
+	},
+	"cellStyle": func(styleMap ut.SM) bool {
+		return len(styleMap) > 0
+	},
+	"cellValue": func(tbl *tableRender, row ut.IM, col TableColumn, rowIndex int) template.HTML {
+		if col.Cell != nil {
+			return col.Cell(row, col, row[col.Id], int64(rowIndex), tbl.Table)
+		}
+		return template.HTML(ut.ToString(row[col.Id], ""))
+	},
+}
+
+/*
+Based on the values, it will write the html code of the [Table] into the writer or return with an error message.
+*/
+func (tbl *Table) RenderTo(w io.Writer) (err error) {
 	tbl.InitProps(tbl)
+	tbl.lookupFields()
 
 	cols := tbl.columns()
 	rows := tbl.filterRows()
 	pageCount := int64(math.Ceil(float64(len(rows)) / float64(tbl.PageSize)))
 
-	funcMap := map[string]any{
-		"styleMap": func() bool {
-			return tbl.tableMap("styleMap", ut.IM{}, 0)
-		},
-		"customClass": func() string {
-			return strings.Join(tbl.Class, " ")
-		},
-		"topPagination": func() bool {
-			return tbl.tableMap("topPagination", ut.IM{}, 0)
-		},
-		"bottomPagination": func() bool {
-			return tbl.tableMap("bottomPagination", ut.IM{}, 0)
-		},
-		"tableComponent": func(name string) (template.HTML, error) {
-			return tbl.getComponent(name, pageCount, ut.IM{})
-		},
-		"pageRows": func() []ut.IM {
-			if tbl.Pagination != PaginationTypeNone {
-				currentPage := tbl.Validation("current_page", tbl.CurrentPage).(int64)
-				start := (currentPage - 1) * tbl.PageSize
-				end := currentPage * tbl.PageSize
-				if end > int64(len(rows)) {
-					end = int64(len(rows))
-				}
-				return rows[start:end]
-			}
-			return rows
-		},
-		"colID": func(col TableColumn) string {
-			colID := tbl.Id + "_header_" + col.Id
-			_, _ = tbl.getComponent("header_sort", pageCount, ut.IM{"col_id": colID, "fieldname": col.Id, "fieldtype": col.Field.FieldType})
-			return colID
-		},
-		"rowTrigger": func(index int) bool {
-			return tbl.tableMap("rowTrigger", ut.IM{}, index)
-		},
-		"formBtn": func(row ut.IM, index int) bool {
-			return tbl.tableMap("formBtn", row, index)
-		},
-		"rowID": func(row ut.IM, index int) string {
-			rowID := tbl.Id + "_row_" + ut.ToString(index, "")
-			_, _ = tbl.getComponent("data_row", pageCount, ut.IM{"row_id": rowID, "row": row, "index": index})
-			return rowID
-		},
-		"pointerClass": func(row ut.IM, index int) string {
-			if disabled, found := row["disabled"].(bool); found && disabled {
-				return "cursor-disabled"
-			}
-			if (tbl.RowSelected && !tbl.Editable) || (tbl.Editable && (int64(index) != tbl.EditIndex-1)) {
-				return "cursor-pointer"
-			}
-			return ""
-		},
-		"cols": func() []TableColumn {
-			return cols
-		},
-		"sortClass": func(colID string) string {
-			if tbl.SortCol == colID && !tbl.Unsortable {
-				if tbl.SortAsc {
-					return "sort-asc"
-				}
-				return "sort-desc"
-			}
-			return "sort-none"
-		},
-		"cellStyle": func(styleMap ut.SM) bool {
-			return len(styleMap) > 0
-		},
-		"cellValue": func(row ut.IM, col TableColumn, rowIndex int) template.HTML {
-			if col.Cell != nil {
-				return col.Cell(row, col, row[col.Id], int64(rowIndex), tbl)
-			}
-			return template.HTML(ut.ToString(row[col.Id], ""))
-		},
-	}
-	tpl := `<div id="{{ .Id }}" name="{{ .Name }}" class="responsive {{ customClass }}">
-	{{ if or .TableFilter topPagination }}<div>
-	{{ if topPagination }}<div>{{ tableComponent "top_pagination" }}</div>{{ end }}
+	tpl := `<div id="{{ .Id }}" name="{{ .Name }}" class="responsive {{ customClass $ }}">
+	{{ if or .TableFilter (topPagination $) }}<div>
+	{{ if topPagination $ }}<div>{{ tableComponent $ "top_pagination" }}</div>{{ end }}
 	{{ if .TableFilter }}<div class="row full">
-	<div class="cell" >{{ tableComponent "filter" }}</div>
-	{{ if .AddItem }}<div class="cell" style="width: 20px;" >{{ tableComponent "btn_add" }}</div>{{ end }}
+	<div class="cell" >{{ tableComponent $ "filter" }}</div>
+	{{ if .AddItem }}<div class="cell" style="width: 20px;" >{{ tableComponent $ "btn_add" }}</div>{{ end }}
 	</div>{{ end }}</div>{{ end }}
 	<div class="table-wrap" >{{ if $.Editable }}<form id="{{ .Id }}" name="table_form" 
 	{{ if ne $.EventURL "" }} hx-post="{{ $.EventURL }}" hx-target="{{ $.Target }}" {{ if ne $.Sync "none" }} hx-sync="{{ $.Sync }}"{{ end }} hx-swap="{{ $.Swap }}"{{ end }}
 	{{ if ne $.Indicator "none" }} hx-indicator="#{{ $.Indicator }}"{{ end }} >{{ end }}<table class="ui-table"
-	{{ if styleMap }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}>
-	{{ if not $.HideHeader }}<thead><tr>{{ range $icol, $col := cols }}
-	<th id="{{ colID $col }}" name="header_cell" 
-	class="{{ if not $.Unsortable }}sort {{ end }}{{ sortClass $col.Id }}" 
+	{{ if styleMap $ }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}>
+	{{ if not $.HideHeader }}<thead><tr>{{ range $icol, $col := cols $ }}
+	<th id="{{ colID $ $col }}" name="header_cell" 
+	class="{{ if not $.Unsortable }}sort {{ end }}{{ sortClass $ $col.Id }}" 
 	{{ if and (ne $.EventURL "") (not $.Unsortable) }} hx-post="{{ $.EventURL }}" hx-target="{{ $.Target }}" {{ if ne $.Sync "none" }} hx-sync="{{ $.Sync }}"{{ end }} hx-swap="{{ $.Swap }}"{{ end }}
 	{{ if and (ne $.Indicator "none") (not $.Unsortable) }} hx-indicator="#{{ $.Indicator }}"{{ end }} 
 	{{ if cellStyle $col.HeaderStyle }} style="{{ range $key, $value := $col.HeaderStyle }}{{ $key }}:{{ $value }};{{ end }}"{{ end }} 
 	>{{ $col.Header }}</th>
 	{{ end }}</tr></thead>{{ end }}
-	<tbody>{{ range $index, $row := pageRows }}
-	<tr id="{{ rowID $row $index }}" class="{{ pointerClass $row $index }}" 
-	{{ if and (rowTrigger $index) (ne $.EventURL "") }} hx-post="{{ $.EventURL }}" hx-target="{{ $.Target }}" {{ if ne $.Sync "none" }} hx-sync="{{ $.Sync }}"{{ end }} hx-swap="{{ $.Swap }}"{{ end }}
-	{{ if and (rowTrigger $index) (ne $.Indicator "none") }} hx-indicator="#{{ $.Indicator }}"{{ end }}
-	>{{ range $icol, $col := cols }}<td
+	<tbody>{{ range $index, $row := pageRows $ }}
+	<tr id="{{ rowID $ $row $index }}" class="{{ pointerClass $ $row $index }}" 
+	{{ if and (rowTrigger $ $index) (ne $.EventURL "") }} hx-post="{{ $.EventURL }}" hx-target="{{ $.Target }}" {{ if ne $.Sync "none" }} hx-sync="{{ $.Sync }}"{{ end }} hx-swap="{{ $.Swap }}"{{ end }}
+	{{ if and (rowTrigger $ $index) (ne $.Indicator "none") }} hx-indicator="#{{ $.Indicator }}"{{ end }}
+	>{{ range $icol, $col := cols $ }}<td
 	{{ if cellStyle $col.CellStyle }} style="{{ range $key, $value := $col.CellStyle }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}
-	>{{ cellValue $row $col $index }}</td>{{ end }}</tr>
-	{{ if formBtn $row $index }}<tr><td class="ui-table-form" colspan="{{ len cols }}">{{ tableComponent "form_btn" }}</td></tr>{{ end }}
+	>{{ cellValue $ $row $col $index }}</td>{{ end }}</tr>
+	{{ if formBtn $ $row $index }}<tr><td class="ui-table-form" colspan="{{ len (cols $) }}">{{ tableComponent $ "form_btn" }}</td></tr>{{ end }}
 	{{ end }}</tbody>
 	</table>{{ if $.Editable }}</form>{{ end }}</div>
-	{{ if bottomPagination }}<div>{{ tableComponent "bottom_pagination" }}</div>{{ end }}
+	{{ if bottomPagination $ }}<div>{{ tableComponent $ "bottom_pagination" }}</div>{{ end }}
 	</div>`
 
-	if html, err = ut.TemplateBuilder("table", tpl, funcMap, tbl); err == nil && tbl.EventURL != "" {
+	hw := ut.NewHTMLWriter(w)
+	if err = ut.CachedTemplateWriter(hw, "table", tpl, tableFuncMap, &tableRender{Table: tbl, cols: cols, pageCount: pageCount, rows: rows, hw: hw}); err == nil {
+		err = hw.Close()
+	}
+	if err == nil && tbl.EventURL != "" {
 		tbl.SetProperty("request_map", tbl)
 	}
-	return html, err
+	return err
 }
 
 var testTableFields []TableField = []TableField{
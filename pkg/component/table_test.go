@@ -865,3 +865,26 @@ func TestTable_formEvent(t *testing.T) {
 		})
 	}
 }
+
+func TestTable_lookup(t *testing.T) {
+	RegisterLookup("test_color", testLookupRows)
+	fields := []TableField{
+		{Name: "color", Lookup: "test_color"},
+		{Name: "name"},
+	}
+	tbl := &Table{
+		BaseComponent: BaseComponent{RequestValue: map[string]ut.IM{}},
+		Rows:          []ut.IM{{"id": 1, "color": "2", "name": "name"}},
+		Fields:        fields,
+		Editable:      true,
+	}
+	if _, err := tbl.Render(); err != nil || len(tbl.Fields[0].Options) != 3 {
+		t.Errorf("Table.Render() = %v, %v", tbl.Fields, err)
+	}
+	if len(fields[0].Options) != 0 {
+		t.Error("Table.Render() modified the source fields")
+	}
+	if fd := tbl.tableFieldsValidation([]interface{}{ut.IM{"name": "color", "lookup": "test_color"}}); fd[0].Lookup != "test_color" {
+		t.Errorf("Table.tableFieldsValidation() = %v", fd)
+	}
+}
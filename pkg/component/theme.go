@@ -0,0 +1,220 @@
+package component
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// ThemeColor is an RGB color token. The CSS variable value is used with the rgba() function.
+type ThemeColor [3]uint8
+
+func (tc ThemeColor) String() string {
+	return fmt.Sprintf("%d, %d, %d", tc[0], tc[1], tc[2])
+}
+
+// ThemeAccent is the accent color tokens of a [ThemeData]
+type ThemeAccent struct {
+	// The primary accent color (--accent-1)
+	Primary ThemeColor `json:"primary"`
+	// The hover and active state of the accent color (--accent-1b)
+	Hover ThemeColor `json:"hover"`
+	// The text color of the accent background (--accent-1c)
+	Text ThemeColor `json:"text"`
+}
+
+// ThemeFunctional is the functional color tokens of a [ThemeData]
+type ThemeFunctional struct {
+	Blue   ThemeColor `json:"blue"`
+	Red    ThemeColor `json:"red"`
+	Yellow ThemeColor `json:"yellow"`
+	Green  ThemeColor `json:"green"`
+	Beige  ThemeColor `json:"beige"`
+}
+
+// ThemeFont is the font tokens of a [ThemeData]. The empty values are inherited from the variable.css.
+type ThemeFont struct {
+	// Example: "Noto Sans"
+	Family string `json:"family"`
+	// Example: "14px"
+	Size string `json:"size"`
+}
+
+// ThemeSpacing is the layout size tokens of a [ThemeData]. The empty values are inherited from the variable.css.
+type ThemeSpacing struct {
+	// Example: "43.5px"
+	MenuTopHeight string `json:"menu_top_height"`
+	// Example: "250px"
+	MenuSideWidth string `json:"menu_side_width"`
+}
+
+// ThemeData is the design tokens of a theme. See more [RegisterTheme] function.
+type ThemeData struct {
+	// The displayed name of the theme switch, if the component labels do not contain a theme_[name] value
+	Label string `json:"label"`
+	// The icon of the theme switch. Default value: [IconSun]
+	Icon string `json:"icon"`
+	// Foreground and background neutral colors (--neutral-1, --neutral-2)
+	Neutral [2]ThemeColor `json:"neutral"`
+	Accent  ThemeAccent   `json:"accent"`
+	// Background colors of the surface levels (--base-0 ... --base-4)
+	Base       [5]ThemeColor   `json:"base"`
+	Functional ThemeFunctional `json:"functional"`
+	// Primary, secondary and disabled text colors (--text-1 ... --text-3). Example: "rgba(0, 0, 0, .90)"
+	Text [3]string `json:"text"`
+	// Box shadow value (--shadow-1)
+	Shadow  string       `json:"shadow"`
+	Font    ThemeFont    `json:"font"`
+	Spacing ThemeSpacing `json:"spacing"`
+}
+
+// The registered themes. The default themes are the same as the variable.css values.
+var themeMap map[string]ThemeData = map[string]ThemeData{
+	ThemeLight: {
+		Label:   "Light",
+		Icon:    IconSun,
+		Neutral: [2]ThemeColor{{0, 0, 0}, {255, 255, 255}},
+		Accent:  ThemeAccent{Primary: ThemeColor{0, 28, 50}, Hover: ThemeColor{0, 71, 93}, Text: ThemeColor{255, 255, 255}},
+		Base:    [5]ThemeColor{{255, 255, 255}, {235, 235, 235}, {245, 245, 245}, {255, 255, 255}, {255, 255, 255}},
+		Functional: ThemeFunctional{
+			Blue: ThemeColor{20, 120, 220}, Red: ThemeColor{210, 105, 125}, Yellow: ThemeColor{220, 168, 40},
+			Green: ThemeColor{50, 168, 40}, Beige: ThemeColor{217, 216, 217},
+		},
+		Text:   [3]string{"rgba(0, 0, 0, .90)", "rgba(0, 0, 0, .60)", "rgba(0, 0, 0, .20)"},
+		Shadow: "0 2px 8px rgba(0,0,0,.1), 0 1px 4px rgba(0,0,0,.05)",
+	},
+	ThemeDark: {
+		Label:   "Dark",
+		Icon:    IconMoon,
+		Neutral: [2]ThemeColor{{255, 255, 255}, {0, 0, 0}},
+		Accent:  ThemeAccent{Primary: ThemeColor{0, 28, 50}, Hover: ThemeColor{0, 71, 93}, Text: ThemeColor{255, 255, 255}},
+		Base:    [5]ThemeColor{{0, 0, 2}, {15, 15, 15}, {25, 25, 25}, {35, 35, 35}, {45, 45, 45}},
+		Functional: ThemeFunctional{
+			Blue: ThemeColor{20, 120, 220}, Red: ThemeColor{210, 105, 125}, Yellow: ThemeColor{220, 160, 40},
+			Green: ThemeColor{40, 160, 40}, Beige: ThemeColor{85, 85, 85},
+		},
+		Text:   [3]string{"rgba(255, 255, 255, .90)", "rgba(255, 255, 255, .60)", "rgba(255, 255, 255, .20)"},
+		Shadow: "0 2px 8px rgba(0,0,0,.2), 0 1px 4px rgba(0,0,0,.15)",
+	},
+	ThemeHighContrast: {
+		Label:   "High contrast",
+		Icon:    IconEye,
+		Neutral: [2]ThemeColor{{255, 255, 255}, {0, 0, 0}},
+		Accent:  ThemeAccent{Primary: ThemeColor{255, 214, 0}, Hover: ThemeColor{255, 235, 59}, Text: ThemeColor{0, 0, 0}},
+		Base:    [5]ThemeColor{{0, 0, 0}, {0, 0, 0}, {20, 20, 20}, {0, 0, 0}, {30, 30, 30}},
+		Functional: ThemeFunctional{
+			Blue: ThemeColor{0, 170, 255}, Red: ThemeColor{255, 90, 90}, Yellow: ThemeColor{255, 214, 0},
+			Green: ThemeColor{0, 230, 118}, Beige: ThemeColor{160, 160, 160},
+		},
+		Text:   [3]string{"rgba(255, 255, 255, 1)", "rgba(255, 255, 255, .85)", "rgba(255, 255, 255, .60)"},
+		Shadow: "0 0 0 1px rgba(255,255,255,.80)",
+	},
+}
+
+/*
+The RegisterTheme function adds a custom theme to the valid [Theme] values. An existing theme with the same
+name is replaced. The [Application], [Client], [Login] components accept any registered theme name, and the
+CSS variables of the themes are generated by the [ThemeCSS] function. The themes should be registered
+before the first request is served.
+
+Example:
+
+	brand, _ := ct.GetTheme(ct.ThemeLight)
+	brand.Accent.Primary = ct.ThemeColor{120, 20, 60}
+	ct.RegisterTheme("brand", brand)
+*/
+func RegisterTheme(name string, theme ThemeData) {
+	themeMap[name] = theme
+	if !slices.Contains(Theme, name) {
+		Theme = append(Theme, name)
+	}
+}
+
+// The GetTheme function returns a copy of the tokens of a registered theme.
+func GetTheme(name string) (theme ThemeData, found bool) {
+	theme, found = themeMap[name]
+	return theme, found
+}
+
+// The ThemeNext function returns the next theme of the theme switch in the order of the [Theme] values.
+func ThemeNext(theme string) string {
+	return Theme[(slices.Index(Theme, theme)+1)%len(Theme)]
+}
+
+// The ThemeIcon function returns the icon of the registered theme. Default value: [IconSun]
+func ThemeIcon(theme string) string {
+	if icon := themeMap[theme].Icon; icon != "" {
+		return icon
+	}
+	return IconSun
+}
+
+/*
+The ThemeLabel function returns the theme_[name] value of the labels or the Label of the registered theme.
+*/
+func ThemeLabel(labels map[string]string, theme string) string {
+	if label, found := labels["theme_"+theme]; found {
+		return label
+	}
+	return themeMap[theme].Label
+}
+
+func (theme *ThemeData) variables() [][2]string {
+	values := [][2]string{
+		{"neutral-1", theme.Neutral[0].String()},
+		{"neutral-2", theme.Neutral[1].String()},
+		{"accent-1", theme.Accent.Primary.String()},
+		{"accent-1b", theme.Accent.Hover.String()},
+		{"accent-1c", theme.Accent.Text.String()},
+	}
+	for index, color := range theme.Base {
+		values = append(values, [2]string{fmt.Sprintf("base-%d", index), color.String()})
+	}
+	values = append(values, [][2]string{
+		{"functional-blue", theme.Functional.Blue.String()},
+		{"functional-red", theme.Functional.Red.String()},
+		{"functional-yellow", theme.Functional.Yellow.String()},
+		{"functional-green", theme.Functional.Green.String()},
+		{"functional-beige", theme.Functional.Beige.String()},
+		{"text-1", theme.Text[0]}, {"text-2", theme.Text[1]}, {"text-3", theme.Text[2]},
+		{"shadow-1", theme.Shadow},
+		{"font-family", theme.Font.Family}, {"font-size", theme.Font.Size},
+		{"menu-top-height", theme.Spacing.MenuTopHeight}, {"menu-side-width", theme.Spacing.MenuSideWidth},
+	}...)
+	return slices.DeleteFunc(values, func(value [2]string) bool {
+		return value[1] == ""
+	})
+}
+
+/*
+The ThemeCSS function returns the CSS variable blocks of the themes. If the names are not set, all registered
+themes are included. The result can be served alongside the index.css (see [ThemeHandler]) or can be
+included in a style element.
+*/
+func ThemeCSS(names ...string) string {
+	if len(names) == 0 {
+		names = Theme
+	}
+	var sb strings.Builder
+	for _, name := range names {
+		if theme, found := themeMap[name]; found {
+			sb.WriteString(fmt.Sprintf("*[theme=%q] {\n", name))
+			for _, value := range theme.variables() {
+				sb.WriteString(fmt.Sprintf("  --%s: %s;\n", value[0], value[1]))
+			}
+			sb.WriteString("}\n")
+		}
+	}
+	return sb.String()
+}
+
+/*
+The ThemeHandler function serves the [ThemeCSS] of all registered themes. Example:
+
+	mux.HandleFunc("GET /theme.css", ct.ThemeHandler)
+*/
+func ThemeHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/css; charset=utf-8")
+	w.Write([]byte(ThemeCSS()))
+}
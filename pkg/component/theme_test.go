@@ -0,0 +1,109 @@
+package component
+
+import (
+	"io/fs"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	st "github.com/nervatura/component/pkg/static"
+)
+
+func testRegisterTheme(t *testing.T, name string, theme ThemeData) {
+	values := slices.Clone(Theme)
+	t.Cleanup(func() {
+		Theme = values
+		delete(themeMap, name)
+	})
+	RegisterTheme(name, theme)
+}
+
+func TestRegisterTheme(t *testing.T) {
+	brand, found := GetTheme(ThemeLight)
+	if !found {
+		t.Fatal("GetTheme() not found")
+	}
+	brand.Label = ""
+	brand.Icon = ""
+	brand.Accent.Primary = ThemeColor{120, 20, 60}
+	brand.Font = ThemeFont{Family: `"Roboto"`, Size: "15px"}
+	brand.Spacing = ThemeSpacing{MenuSideWidth: "280px"}
+	testRegisterTheme(t, "brand", brand)
+	RegisterTheme("brand", brand)
+	if slices.Index(Theme, "brand") != len(Theme)-1 || len(Theme) != 4 {
+		t.Errorf("RegisterTheme() Theme = %v", Theme)
+	}
+	if theme, _ := GetTheme(ThemeLight); theme.Accent.Primary == brand.Accent.Primary {
+		t.Error("GetTheme() is not a copy")
+	}
+
+	for _, tt := range []struct {
+		theme, next, icon string
+	}{
+		{theme: ThemeLight, next: ThemeDark, icon: IconMoon},
+		{theme: ThemeDark, next: ThemeHighContrast, icon: IconEye},
+		{theme: ThemeHighContrast, next: "brand", icon: IconSun},
+		{theme: "brand", next: ThemeLight, icon: IconSun},
+		{theme: "", next: ThemeLight, icon: IconSun},
+	} {
+		if next := ThemeNext(tt.theme); next != tt.next || ThemeIcon(next) != tt.icon {
+			t.Errorf("ThemeNext(%s) = %s, %s", tt.theme, next, ThemeIcon(next))
+		}
+	}
+	if label := ThemeLabel(map[string]string{"theme_dark": "Sötét"}, ThemeDark); label != "Sötét" {
+		t.Errorf("ThemeLabel() = %s", label)
+	}
+	if label := ThemeLabel(nil, ThemeHighContrast); label != "High contrast" {
+		t.Errorf("ThemeLabel() = %s", label)
+	}
+
+	app := &Application{}
+	if theme := app.SetProperty("theme", "brand"); theme != "brand" {
+		t.Errorf("Application.SetProperty() = %v", theme)
+	}
+	lgn := &Login{}
+	if theme := lgn.SetProperty("theme", "brand"); theme != "brand" {
+		t.Errorf("Login.SetProperty() = %v", theme)
+	}
+	cli := &Client{}
+	if theme := cli.SetProperty("theme", "brand"); theme != "brand" {
+		t.Errorf("Client.SetProperty() = %v", theme)
+	}
+}
+
+func TestThemeCSS(t *testing.T) {
+	brand, _ := GetTheme(ThemeDark)
+	brand.Font = ThemeFont{Family: `"Roboto"`}
+	brand.Spacing = ThemeSpacing{MenuTopHeight: "48px"}
+	testRegisterTheme(t, "brand", brand)
+
+	css := ThemeCSS()
+	for _, want := range []string{
+		`*[theme="light"] {`, `*[theme="dark"] {`, `*[theme="contrast"] {`, `*[theme="brand"] {`,
+		`  --font-family: "Roboto";`, `  --menu-top-height: 48px;`,
+	} {
+		if !strings.Contains(css, want) {
+			t.Errorf("ThemeCSS() missing %s", want)
+		}
+	}
+	if css = ThemeCSS("brand", "missing"); strings.Count(css, "{") != 1 || strings.Contains(css, "--font-size") {
+		t.Errorf("ThemeCSS() = %s", css)
+	}
+
+	// the default themes are the same as the static variable.css values
+	variables, _ := fs.ReadFile(st.Static, "css/variable.css")
+	for _, name := range []string{ThemeLight, ThemeDark, ThemeHighContrast} {
+		for _, line := range strings.Split(strings.TrimSpace(ThemeCSS(name)), "\n") {
+			if !strings.Contains(string(variables), line) {
+				t.Errorf("variable.css missing %s %s", name, line)
+			}
+		}
+	}
+
+	w := httptest.NewRecorder()
+	ThemeHandler(w, httptest.NewRequest("GET", "/theme.css", nil))
+	if w.Header().Get("Content-Type") != "text/css; charset=utf-8" || !strings.Contains(w.Body.String(), `*[theme="brand"]`) {
+		t.Errorf("ThemeHandler() = %s", w.Body.String())
+	}
+}
@@ -2,6 +2,7 @@ package component
 
 import (
 	"html/template"
+	"io"
 	"strings"
 
 	ut "github.com/nervatura/component/pkg/util"
@@ -131,28 +132,37 @@ func (tst *Toast) getComponent(name string) (template.HTML, error) {
 Based on the values, it will generate the html code of the [Toast] or return with an error message.
 */
 func (tst *Toast) Render() (html template.HTML, err error) {
+	return RenderHTML(tst)
+}
+
+// [Toast] template functions
+var toastFuncMap = map[string]any{
+	"styleMap": func(tst *Toast) bool {
+		return len(tst.Style) > 0
+	},
+	"customClass": func(tst *Toast) string {
+		return strings.Join(tst.Class, " ")
+	},
+	"toastComponent": func(tst *Toast, name string) (template.HTML, error) {
+		return tst.getComponent(name)
+	},
+}
+
+/*
+Based on the values, it will write the html code of the [Toast] into the writer or return with an error message.
+*/
+func (tst *Toast) RenderTo(w io.Writer) (err error) {
 	tst.InitProps(tst)
 
-	funcMap := map[string]any{
-		"styleMap": func() bool {
-			return len(tst.Style) > 0
-		},
-		"customClass": func() string {
-			return strings.Join(tst.Class, " ")
-		},
-		"toastComponent": func(name string) (template.HTML, error) {
-			return tst.getComponent(name)
-		},
-	}
 	tpl := `<div id="{{ .Id }}" name="{{ .Name }}" type="{{ .Type }}" 
-	 class="toast {{ customClass }}" onclick="htmx.remove(htmx.find('#{{ .Id }}'))"
+	 class="toast {{ customClass $ }}" onclick="htmx.remove(htmx.find('#{{ .Id }}'))"
 	{{ if gt .Timeout 0 }} remove-me="{{ .Timeout }}s"{{ end }}
-	{{ if styleMap }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}
-	><span class="toast-icon">{{ toastComponent "icon" }}</span>
+	{{ if styleMap $ }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}
+	><span class="toast-icon">{{ toastComponent $ "icon" }}</span>
 	<span id="{{ .Id }}-value">{{ .Value }}</span>
 	</div>`
 
-	return ut.TemplateBuilder("toast", tpl, funcMap, tst)
+	return ut.CachedTemplateWriter(w, "toast", tpl, toastFuncMap, tst)
 }
 
 var testToastResponse func(evt ResponseEvent) (re ResponseEvent) = func(evt ResponseEvent) (re ResponseEvent) {
@@ -2,6 +2,7 @@ package component
 
 import (
 	"html/template"
+	"io"
 	"strings"
 
 	ut "github.com/nervatura/component/pkg/util"
@@ -108,35 +109,44 @@ func (tgl *Toggle) OnRequest(te TriggerEvent) (re ResponseEvent) {
 Based on the values, it will generate the html code of the [Toggle] or return with an error message.
 */
 func (tgl *Toggle) Render() (html template.HTML, err error) {
+	return RenderHTML(tgl)
+}
+
+// [Toggle] template functions
+var toggleFuncMap = map[string]any{
+	"styleMap": func(tgl *Toggle) bool {
+		return len(tgl.Style) > 0
+	},
+	"customClass": func(tgl *Toggle) string {
+		return strings.Join(tgl.Class, " ")
+	},
+	"inputValue": func(tgl *Toggle) string {
+		return ut.ToString(tgl.Value, "false")
+	},
+}
+
+/*
+Based on the values, it will write the html code of the [Toggle] into the writer or return with an error message.
+*/
+func (tgl *Toggle) RenderTo(w io.Writer) (err error) {
 	tgl.InitProps(tgl)
 
-	funcMap := map[string]any{
-		"styleMap": func() bool {
-			return len(tgl.Style) > 0
-		},
-		"customClass": func() string {
-			return strings.Join(tgl.Class, " ")
-		},
-		"inputValue": func() string {
-			return ut.ToString(tgl.Value, "false")
-		},
-	}
 	tpl := `<div id="{{ .Id }}"
 		{{ if eq .Disabled false }}{{ if ne .EventURL "" }} hx-post="{{ .EventURL }}" hx-target="{{ .Target }}" {{ if ne .Sync "none" }} hx-sync="{{ .Sync }}"{{ end }} hx-swap="{{ .Swap }}"{{ end }}
 		{{ if ne .Indicator "none" }} hx-indicator="#{{ .Indicator }}"{{ end }}{{ end }}
-		 class="toggle {{ customClass }}{{ if .Full }} full{{ end }}{{ if .Disabled }} toggle-disabled{{ end }}
+		 class="toggle {{ customClass $ }}{{ if .Full }} full{{ end }}{{ if .Disabled }} toggle-disabled{{ end }}
 		{{ if .Border }} toggle-border{{ end }}"
-		{{ if styleMap }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}
+		{{ if styleMap $ }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}
 		><label class="{{ if .CheckBox }}checkmark-container{{ else }}switch{{ end }}">
-		<input name="{{ .Name }}" type="checkbox" value="{{ inputValue }}"
+		<input name="{{ .Name }}" type="checkbox" value="{{ inputValue $ }}"
 		{{ if .Value }} checked{{ end }} {{ if .Disabled }} disabled{{ end }}>
 		<span class="{{ if .CheckBox }}checkmark{{ else }}slider round{{ end }}{{ if .Disabled }} toggle-disabled{{ end }}"></span>
 		</label></div>`
 
-	if html, err = ut.TemplateBuilder("toggle", tpl, funcMap, tgl); err == nil && tgl.EventURL != "" {
+	if err = ut.CachedTemplateWriter(w, "toggle", tpl, toggleFuncMap, tgl); err == nil && tgl.EventURL != "" {
 		tgl.SetProperty("request_map", tgl)
 	}
-	return html, nil
+	return nil
 }
 
 // [Toggle] test and demo data
@@ -0,0 +1,721 @@
+package component
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"slices"
+	"strings"
+
+	ut "github.com/nervatura/component/pkg/util"
+)
+
+// [TreeView] constants
+const (
+	ComponentTypeTreeView = "treeview"
+
+	TreeViewEventExpand   = "treeview_expand"
+	TreeViewEventCollapse = "treeview_collapse"
+	TreeViewEventLoad     = "treeview_load"
+	TreeViewEventSelected = "treeview_selected"
+	TreeViewEventEdit     = "treeview_edit"
+	TreeViewEventRename   = "treeview_rename"
+	TreeViewEventMove     = "treeview_move"
+
+	TreeViewSelectNone   = "none"
+	TreeViewSelectSingle = "single"
+	TreeViewSelectMulti  = "multi"
+)
+
+// [TreeView] SelectMode values
+var TreeViewSelect []string = []string{TreeViewSelectNone, TreeViewSelectSingle, TreeViewSelectMulti}
+
+// [TreeView] node
+type TreeNode struct {
+	// Unique node identifier
+	Id    string `json:"id"`
+	Label string `json:"label"`
+	// Optional [Icon] value of the node
+	Icon string `json:"icon"`
+	// The children of the node are loaded on the first expand (see [TreeViewEventLoad])
+	Lazy bool `json:"lazy"`
+	// The node cannot be selected, renamed or moved
+	Disabled bool       `json:"disabled"`
+	Children []TreeNode `json:"children"`
+}
+
+/*
+Creates a hierarchical tree control with expand/collapse, lazy loaded child nodes, single or multiple (checkbox)
+selection, keyboard navigation, inline rename and drag and drop reparenting.
+
+The expanded, selected and loaded nodes are stored in the RequestValue, so the state of the tree is preserved
+between the requests. The children of the Lazy nodes can be set by the [TreeView.SetChildren] function in the
+OnResponse function of the [TreeViewEventLoad] event.
+
+For example:
+
+	&TreeView{
+	  BaseComponent: BaseComponent{
+	    Id:       "id_treeview_accounts",
+	    EventURL: "/event",
+	  },
+	  Nodes: []TreeNode{
+	    {Id: "1", Label: "Assets", Children: []TreeNode{
+	      {Id: "11", Label: "Fixed assets", Lazy: true},
+	      {Id: "12", Label: "Current assets"},
+	    }},
+	    {Id: "2", Label: "Liabilities"},
+	  },
+	  Expanded:  []string{"1"},
+	  Editable:  true,
+	  Draggable: true,
+	}
+*/
+type TreeView struct {
+	BaseComponent
+	Nodes []TreeNode `json:"nodes"`
+	// The identifiers of the expanded nodes
+	Expanded []string `json:"expanded"`
+	// The identifiers of the selected (checked) nodes
+	Selected []string `json:"selected"`
+	/* [TreeViewSelect] variable constants: [TreeViewSelectNone], [TreeViewSelectSingle], [TreeViewSelectMulti].
+	Default value: [TreeViewSelectSingle] */
+	SelectMode string `json:"select_mode"`
+	// Enables the inline rename of the nodes
+	Editable bool `json:"editable"`
+	// Enables the drag and drop reparenting of the nodes
+	Draggable bool `json:"draggable"`
+	// The identifier of the currently renamed node
+	EditNode string `json:"edit_node"`
+	// The node of the last request gets the keyboard focus
+	focus string
+}
+
+/*
+Returns all properties of the [TreeView]
+*/
+func (tre *TreeView) Properties() ut.IM {
+	return ut.MergeIM(
+		tre.BaseComponent.Properties(),
+		ut.IM{
+			"nodes":       tre.Nodes,
+			"expanded":    tre.Expanded,
+			"selected":    tre.Selected,
+			"select_mode": tre.SelectMode,
+			"editable":    tre.Editable,
+			"draggable":   tre.Draggable,
+			"edit_node":   tre.EditNode,
+		})
+}
+
+/*
+Returns the value of the property of the [TreeView] with the specified name.
+*/
+func (tre *TreeView) GetProperty(propName string) interface{} {
+	return tre.Properties()[propName]
+}
+
+/*
+It checks the value given to the property of the [TreeView] and always returns a valid value
+*/
+func (tre *TreeView) Validation(propName string, propValue interface{}) interface{} {
+	pm := map[string]func() interface{}{
+		"nodes": func() interface{} {
+			if nodes, valid := propValue.([]TreeNode); valid && nodes != nil {
+				return nodes
+			}
+			nodes := []TreeNode{}
+			if err := ut.ConvertToType(propValue, &nodes); err != nil || nodes == nil {
+				return []TreeNode{}
+			}
+			return nodes
+		},
+		"expanded": func() interface{} {
+			return ut.ILtoSL(propValue)
+		},
+		"selected": func() interface{} {
+			return ut.ILtoSL(propValue)
+		},
+		"select_mode": func() interface{} {
+			return tre.CheckEnumValue(ut.ToString(propValue, ""), TreeViewSelectSingle, TreeViewSelect)
+		},
+		"target": func() interface{} {
+			tre.SetProperty("id", tre.Id)
+			value := ut.ToString(propValue, tre.Id)
+			if value != "this" && !strings.HasPrefix(value, "#") {
+				value = "#" + value
+			}
+			return value
+		},
+	}
+	if _, found := pm[propName]; found {
+		return pm[propName]()
+	}
+	if tre.BaseComponent.GetProperty(propName) != nil {
+		return tre.BaseComponent.Validation(propName, propValue)
+	}
+	return propValue
+}
+
+/*
+Setting a property of the [TreeView] value safely. Checks the entered value.
+In case of an invalid value, the default value will be set.
+*/
+func (tre *TreeView) SetProperty(propName string, propValue interface{}) interface{} {
+	pm := map[string]func() interface{}{
+		"nodes": func() interface{} {
+			tre.Nodes = tre.Validation(propName, propValue).([]TreeNode)
+			return tre.Nodes
+		},
+		"expanded": func() interface{} {
+			tre.Expanded = tre.Validation(propName, propValue).([]string)
+			return tre.Expanded
+		},
+		"selected": func() interface{} {
+			tre.Selected = tre.Validation(propName, propValue).([]string)
+			return tre.Selected
+		},
+		"select_mode": func() interface{} {
+			tre.SelectMode = tre.Validation(propName, propValue).(string)
+			return tre.SelectMode
+		},
+		"editable": func() interface{} {
+			tre.Editable = ut.ToBoolean(propValue, false)
+			return tre.Editable
+		},
+		"draggable": func() interface{} {
+			tre.Draggable = ut.ToBoolean(propValue, false)
+			return tre.Draggable
+		},
+		"edit_node": func() interface{} {
+			tre.EditNode = ut.ToString(propValue, "")
+			return tre.EditNode
+		},
+		"target": func() interface{} {
+			tre.Target = tre.Validation(propName, propValue).(string)
+			return tre.Target
+		},
+	}
+	if _, found := pm[propName]; found {
+		return tre.SetRequestValue(propName, pm[propName](), []string{})
+	}
+	if tre.BaseComponent.GetProperty(propName) != nil {
+		return tre.BaseComponent.SetProperty(propName, propValue)
+	}
+	return propValue
+}
+
+// Returns the node with the identifier
+func treeFind(nodes []TreeNode, id string) *TreeNode {
+	for index := range nodes {
+		if nodes[index].Id == id {
+			return &nodes[index]
+		}
+		if node := treeFind(nodes[index].Children, id); node != nil {
+			return node
+		}
+	}
+	return nil
+}
+
+// Returns the identifiers of the node and its descendants
+func treeIds(node TreeNode) (ids []string) {
+	ids = append(ids, node.Id)
+	for _, child := range node.Children {
+		ids = append(ids, treeIds(child)...)
+	}
+	return ids
+}
+
+// Returns the nodes without the node of the identifier
+func treeRemove(nodes []TreeNode, id string) (result []TreeNode) {
+	result = []TreeNode{}
+	for _, node := range nodes {
+		if node.Id != id {
+			node.Children = treeRemove(node.Children, id)
+			result = append(result, node)
+		}
+	}
+	return result
+}
+
+/*
+Updates the checked state of the parent nodes (a node is checked if all of its children are checked) and
+returns the checked identifiers in the tree order
+*/
+func treeSync(nodes []TreeNode, checked map[string]bool) (ids []string) {
+	for _, node := range nodes {
+		childIds := treeSync(node.Children, checked)
+		if len(node.Children) > 0 {
+			checked[node.Id] = !slices.ContainsFunc(node.Children, func(child TreeNode) bool {
+				return !checked[child.Id]
+			})
+		}
+		if checked[node.Id] {
+			ids = append(ids, node.Id)
+		}
+		ids = append(ids, childIds...)
+	}
+	return ids
+}
+
+func (tre *TreeView) checkedMap() map[string]bool {
+	checked := map[string]bool{}
+	for _, id := range tre.Selected {
+		checked[id] = true
+	}
+	return checked
+}
+
+// Checks or unchecks the node and its descendants
+func (tre *TreeView) checkNode(node TreeNode) {
+	checked := tre.checkedMap()
+	value := !checked[node.Id]
+	for _, id := range treeIds(node) {
+		checked[id] = value
+	}
+	tre.SetProperty("selected", treeSync(tre.Nodes, checked))
+}
+
+/*
+SetChildren sets the child nodes of the node, e.g. the loaded nodes of a Lazy node in the
+[TreeViewEventLoad] event. It returns false if the node does not exist.
+*/
+func (tre *TreeView) SetChildren(id string, children []TreeNode) bool {
+	node := treeFind(tre.Nodes, id)
+	if node == nil {
+		return false
+	}
+	node.Children = children
+	tre.SetProperty("nodes", tre.Nodes)
+	if tre.SelectMode == TreeViewSelectMulti {
+		checked := tre.checkedMap()
+		if checked[id] {
+			for _, childID := range treeIds(*node) {
+				checked[childID] = true
+			}
+		}
+		tre.SetProperty("selected", treeSync(tre.Nodes, checked))
+	}
+	return true
+}
+
+// Moves the node to the end of the children of the parent node (or to the root level)
+func (tre *TreeView) moveNode(id, parentID string) bool {
+	node := treeFind(tre.Nodes, id)
+	if node == nil || node.Disabled || id == parentID {
+		return false
+	}
+	if parentID != "" {
+		if child := treeFind(node.Children, parentID); child != nil {
+			return false
+		}
+		if parent := treeFind(tre.Nodes, parentID); parent == nil {
+			return false
+		}
+	}
+	moved := *node
+	nodes := treeRemove(tre.Nodes, id)
+	if parent := treeFind(nodes, parentID); parent != nil {
+		parent.Children = append(parent.Children, moved)
+		if !slices.Contains(tre.Expanded, parentID) {
+			tre.SetProperty("expanded", append(tre.Expanded, parentID))
+		}
+	} else {
+		nodes = append(nodes, moved)
+	}
+	tre.SetProperty("nodes", nodes)
+	if tre.SelectMode == TreeViewSelectMulti {
+		tre.SetProperty("selected", treeSync(tre.Nodes, tre.checkedMap()))
+	}
+	return true
+}
+
+/*
+If the OnResponse function of the [TreeView] is implemented, the function calls it after the [TriggerEvent]
+is processed, otherwise the function's return [ResponseEvent] is the processed [TriggerEvent].
+*/
+func (tre *TreeView) OnRequest(te TriggerEvent) (re ResponseEvent) {
+	nodeID := te.Values.Get("node")
+	evt := ResponseEvent{Trigger: tre, TriggerName: tre.Name, Value: ut.IM{"id": nodeID}}
+	node := treeFind(tre.Nodes, nodeID)
+	if node != nil {
+		evt.Value = ut.IM{"id": nodeID, "node": *node}
+		tre.focus = nodeID
+	}
+	switch action := te.Values.Get("action"); {
+	case te.Values.Has("drag"):
+		dragID := te.Values.Get("drag")
+		evt.Name = TreeViewEventMove
+		evt.Value = ut.IM{"id": dragID, "parent": nodeID}
+		if tre.moveNode(dragID, nodeID) {
+			moved := treeFind(tre.Nodes, dragID)
+			evt.Value = ut.IM{"id": dragID, "parent": nodeID, "node": *moved}
+			tre.focus = dragID
+		}
+
+	case node == nil:
+		evt.Name = TreeViewEventSelected
+
+	case action == "toggle":
+		evt.Name = TreeViewEventExpand
+		if slices.Contains(tre.Expanded, nodeID) {
+			evt.Name = TreeViewEventCollapse
+			tre.SetProperty("expanded", slices.DeleteFunc(slices.Clone(tre.Expanded), func(id string) bool {
+				return id == nodeID
+			}))
+			break
+		}
+		if node.Lazy && len(node.Children) == 0 {
+			evt.Name = TreeViewEventLoad
+		}
+		tre.SetProperty("expanded", append(tre.Expanded, nodeID))
+
+	case action == "edit":
+		evt.Name = TreeViewEventEdit
+		tre.SetProperty("edit_node", nodeID)
+
+	case action == "rename":
+		value := strings.TrimSpace(te.Values.Get("value"))
+		tre.SetProperty("edit_node", "")
+		evt.Name = TreeViewEventEdit
+		if value != "" && value != node.Label {
+			evt.Name = TreeViewEventRename
+			oldValue := node.Label
+			node.Label = value
+			tre.SetProperty("nodes", tre.Nodes)
+			evt.Value = ut.IM{"id": nodeID, "node": *node, "value": value, "old_value": oldValue}
+		}
+
+	default:
+		evt.Name = TreeViewEventSelected
+		switch tre.SelectMode {
+		case TreeViewSelectSingle:
+			tre.SetProperty("selected", []string{nodeID})
+		case TreeViewSelectMulti:
+			tre.checkNode(*node)
+		}
+		evt.Value = ut.IM{"id": nodeID, "node": *node, "selected": tre.Selected}
+	}
+	if tre.OnResponse != nil {
+		return tre.OnResponse(evt)
+	}
+	return evt
+}
+
+// A visible node of the tree
+type treeRow struct {
+	Node     TreeNode
+	Level    int
+	Branch   bool
+	Expanded bool
+	Selected bool
+	// The tri-state checkbox value: true, false or mixed
+	Checked string
+	Editing bool
+	Focus   bool
+	// The htmx trigger id prefix of the row elements
+	Id string
+}
+
+// Returns the visible nodes of the tree in the display order
+func (tre *TreeView) rows() (rows []treeRow) {
+	checked := tre.checkedMap()
+	var walk func(nodes []TreeNode, level int) bool
+	// returns true if any of the nodes or their descendants is checked
+	walk = func(nodes []TreeNode, level int) (anyChecked bool) {
+		for _, node := range nodes {
+			row := treeRow{
+				Node: node, Level: level, Branch: node.Lazy || len(node.Children) > 0,
+				Expanded: slices.Contains(tre.Expanded, node.Id), Checked: "false",
+				Selected: tre.SelectMode == TreeViewSelectSingle && checked[node.Id],
+				Editing:  tre.Editable && tre.EditNode == node.Id,
+				Focus:    tre.focus == node.Id,
+				Id:       fmt.Sprintf("%s_%d", tre.Id, len(rows)+1),
+			}
+			index := len(rows)
+			rows = append(rows, row)
+			if row.Expanded {
+				if walk(node.Children, level+1) && !checked[node.Id] {
+					rows[index].Checked = "mixed"
+				}
+			} else if slices.ContainsFunc(treeIds(node)[1:], func(id string) bool { return checked[id] }) {
+				rows[index].Checked = "mixed"
+			}
+			if checked[node.Id] {
+				rows[index].Checked = "true"
+			}
+			anyChecked = anyChecked || rows[index].Checked != "false"
+		}
+		return anyChecked
+	}
+	walk(tre.Nodes, 1)
+	if len(rows) > 0 && !slices.ContainsFunc(rows, func(row treeRow) bool { return row.Focus }) {
+		// roving tabindex: the first node is focusable
+		rows[0].Focus = true
+	}
+	return rows
+}
+
+/*
+Based on the values, it will generate the html code of the [TreeView] or return with an error message.
+*/
+func (tre *TreeView) Render() (html template.HTML, err error) {
+	return RenderHTML(tre)
+}
+
+// the render data of the [TreeView] template
+type treeViewRender struct {
+	*TreeView
+	treeRows []treeRow
+}
+
+// [TreeView] template functions
+var treeViewFuncMap = map[string]any{
+	"styleMap": func(tre *treeViewRender) bool {
+		return len(tre.Style) > 0
+	},
+	"customClass": func(tre *treeViewRender) string {
+		return strings.Join(tre.Class, " ")
+	},
+	"rows": func(tre *treeViewRender) []treeRow {
+		return tre.treeRows
+	},
+	"indent": func(level int) int {
+		return (level - 1) * 20
+	},
+	"vals": func(node TreeNode, action string) string {
+		values := ut.IM{"node": node.Id}
+		if action != "" {
+			values["action"] = action
+		}
+		data, _ := json.Marshal(values)
+		return string(data)
+	},
+	"treeIcon": func(value string) (template.HTML, error) {
+		return (&Icon{Value: value, Width: 16, Height: 16}).Render()
+	},
+	"checkIcon": func(checked string) string {
+		return map[string]string{"true": IconCheckSquare, "mixed": IconSquare}[checked]
+	},
+	"focused": func(tre *treeViewRender) bool {
+		return tre.focus != ""
+	},
+}
+
+/*
+Based on the values, it will write the html code of the [TreeView] into the writer or return with an error message.
+*/
+func (tre *TreeView) RenderTo(w io.Writer) (err error) {
+	tre.InitProps(tre)
+	rows := tre.rows()
+
+	event := ` hx-post="{{ $.EventURL }}" hx-target="{{ $.Target }}" {{ if ne $.Sync "none" }} hx-sync="{{ $.Sync }}"{{ end }} hx-swap="{{ $.Swap }}"
+	{{ if ne $.Indicator "none" }} hx-indicator="#{{ $.Indicator }}"{{ end }}`
+	tpl := `<div id="{{ .Id }}" name="{{ .Name }}" class="treeview {{ customClass $ }}" role="tree"
+	{{ if eq .SelectMode "multi" }} aria-multiselectable="true"{{ end }}
+	{{ if styleMap $ }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}
+	>{{ range rows $ }}<div id="{{ .Id }}" class="treeview-row{{ if .Selected }} selected{{ end }}{{ if .Node.Disabled }} disabled{{ end }}"
+	 role="treeitem" aria-level="{{ .Level }}"{{ if .Branch }} aria-expanded="{{ .Expanded }}"{{ end }}
+	{{ if ne $.SelectMode "none" }} aria-selected="{{ or .Selected (eq .Checked "true") }}"{{ end }}
+	 tabindex="{{ if .Focus }}0{{ else }}-1{{ end }}" data-node="{{ .Node.Id }}"{{ if and .Focus (focused $) }} data-focus="true"{{ end }}
+	 style="padding-left:{{ indent .Level }}px;"
+	{{ if and (ne $.EventURL "") (not .Node.Disabled) (not .Editing) }} hx-vals="{{ vals .Node "" }}"` + event + `
+	{{ if $.Draggable }} draggable="true"{{ end }}{{ end }}
+	>{{ if .Branch }}<span id="{{ .Id }}_toggle" class="treeview-toggle{{ if .Expanded }} expanded{{ end }}"
+	{{ if ne $.EventURL "" }} hx-vals="{{ vals .Node "toggle" }}" hx-trigger="click consume"` + event + `{{ end }}
+	>{{ treeIcon "CaretRight" }}</span>{{ else }}<span class="treeview-toggle"></span>{{ end }}
+	{{ if eq $.SelectMode "multi" }}<span id="{{ .Id }}_check" class="treeview-check" role="checkbox" aria-checked="{{ .Checked }}"
+	{{ if and (ne $.EventURL "") (not .Node.Disabled) }} hx-vals="{{ vals .Node "check" }}" hx-trigger="click consume"` + event + `{{ end }}
+	>{{ treeIcon (or (checkIcon .Checked) "SquareEmpty") }}</span>{{ end }}
+	{{ if ne .Node.Icon "" }}<span class="treeview-icon">{{ treeIcon .Node.Icon }}</span>{{ end }}
+	{{ if .Editing }}<input id="{{ .Id }}_input" name="value" class="treeview-input" value="{{ .Node.Label }}" autofocus
+	{{ if ne $.EventURL "" }} hx-vals="{{ vals .Node "rename" }}" hx-trigger="change, keyup[key=='Escape']"` + event + `{{ end }}
+	 />{{ else }}<span class="treeview-label">{{ .Node.Label }}</span>{{ end }}
+	{{ if and $.Editable (ne $.EventURL "") (not .Editing) (not .Node.Disabled) }}<span id="{{ .Id }}_edit" class="treeview-edit"
+	 hx-vals="{{ vals .Node "edit" }}" hx-trigger="click consume"` + event + `
+	>{{ treeIcon "Edit" }}</span>{{ end }}
+	</div>{{ end }}
+	{{ if ne .EventURL "" }}<script>
+	(function() {
+		var tree = htmx.find('#{{ .Id }}');
+		var rows = function() { return Array.prototype.slice.call(tree.querySelectorAll('.treeview-row')); };
+		var level = function(row) { return parseInt(row.getAttribute('aria-level')); };
+		var focusRow = function(row) {
+			if (!row) { return; }
+			rows().forEach(function(item) { item.tabIndex = -1; });
+			row.tabIndex = 0;
+			row.focus();
+		};
+		var action = function(row, selector) {
+			var elt = row.querySelector(selector);
+			if (elt) { elt.click(); }
+		};
+		tree.addEventListener('keydown', function(evt) {
+			var row = evt.target.closest('.treeview-row');
+			if (!row) { return; }
+			if (evt.target.tagName === 'INPUT') {
+				if (evt.key === 'Escape') { evt.target.value = evt.target.defaultValue; }
+				return;
+			}
+			var list = rows(), index = list.indexOf(row), expanded = row.getAttribute('aria-expanded');
+			switch (evt.key) {
+			case 'ArrowDown': focusRow(list[index + 1]); break;
+			case 'ArrowUp': focusRow(list[index - 1]); break;
+			case 'Home': focusRow(list[0]); break;
+			case 'End': focusRow(list[list.length - 1]); break;
+			case 'ArrowRight':
+				if (expanded === 'false') { action(row, '.treeview-toggle'); }
+				else if (expanded === 'true' && list[index + 1] && level(list[index + 1]) > level(row)) { focusRow(list[index + 1]); }
+				break;
+			case 'ArrowLeft':
+				if (expanded === 'true') { action(row, '.treeview-toggle'); break; }
+				for (var i = index - 1; i >= 0; i--) {
+					if (level(list[i]) < level(row)) { focusRow(list[i]); break; }
+				}
+				break;
+			case 'Enter': row.click(); break;
+			case ' ': row.click(); break;
+			case 'F2': action(row, '.treeview-edit'); break;
+			default: return;
+			}
+			evt.preventDefault();
+		});
+		var active = tree.querySelector('[data-focus]');
+		if (active && !tree.querySelector('.treeview-input')) { active.focus(); }
+		{{ if .Draggable }}var drag = null;
+		tree.addEventListener('dragstart', function(evt) {
+			var row = evt.target.closest('.treeview-row');
+			if (!row) { return; }
+			drag = row.getAttribute('data-node');
+			evt.dataTransfer.effectAllowed = 'move';
+			evt.dataTransfer.setData('text/plain', drag);
+		});
+		tree.addEventListener('dragover', function(evt) {
+			if (drag !== null) { evt.preventDefault(); }
+		});
+		tree.addEventListener('drop', function(evt) {
+			if (drag === null) { return; }
+			evt.preventDefault();
+			var row = evt.target.closest('.treeview-row');
+			htmx.ajax('POST', {{ .EventURL }}, {
+				source: row || tree, target: {{ .Target }}, swap: {{ .Swap }},
+				values: {drag: drag, node: row ? row.getAttribute('data-node') : ''}
+			});
+			drag = null;
+		});{{ end }}
+	})();
+	</script>{{ end }}
+	</div>`
+
+	if err = ut.CachedTemplateWriter(w, "treeview", tpl, treeViewFuncMap, &treeViewRender{TreeView: tre, treeRows: rows}); err == nil && tre.EventURL != "" {
+		tre.SetProperty("request_map", tre)
+		// the htmx trigger ids of the row elements
+		for _, row := range rows {
+			for _, suffix := range []string{"", "_toggle", "_check", "_input", "_edit"} {
+				tre.RequestMap[row.Id+suffix] = tre
+			}
+		}
+	}
+	return err
+}
+
+var testTreeViewResponse func(evt ResponseEvent) (re ResponseEvent) = func(evt ResponseEvent) (re ResponseEvent) {
+	if evt.Name == TreeViewEventLoad {
+		tre := evt.Trigger.(*TreeView)
+		node := ut.ToIM(evt.Value, ut.IM{})["node"].(TreeNode)
+		children := []TreeNode{}
+		for index := 1; index <= 3; index++ {
+			children = append(children, TreeNode{
+				Id: fmt.Sprintf("%s_%d", node.Id, index), Label: fmt.Sprintf("%s %d", node.Label, index), Lazy: index == 1,
+			})
+		}
+		tre.SetChildren(node.Id, children)
+	}
+	return evt
+}
+
+func testTreeViewAccounts() []TreeNode {
+	return []TreeNode{
+		{Id: "1", Label: "Assets", Icon: IconBriefcase, Children: []TreeNode{
+			{Id: "11", Label: "Fixed assets", Children: []TreeNode{
+				{Id: "111", Label: "Buildings"},
+				{Id: "112", Label: "Machinery"},
+			}},
+			{Id: "12", Label: "Current assets", Children: []TreeNode{
+				{Id: "121", Label: "Inventories"},
+				{Id: "122", Label: "Receivables"},
+				{Id: "123", Label: "Cash", Disabled: true},
+			}},
+		}},
+		{Id: "2", Label: "Liabilities", Icon: IconMoney, Children: []TreeNode{
+			{Id: "21", Label: "Long-term liabilities"},
+			{Id: "22", Label: "Short-term liabilities"},
+		}},
+		{Id: "3", Label: "Equity", Icon: IconDollar},
+	}
+}
+
+// [TreeView] test and demo data
+func TestTreeView(cc ClientComponent) []TestComponent {
+	id := ut.ToString(cc.GetProperty("id"), "")
+	eventURL := ut.ToString(cc.GetProperty("event_url"), "")
+	requestValue := cc.GetProperty("request_value").(map[string]ut.IM)
+	requestMap := cc.GetProperty("request_map").(map[string]ClientComponent)
+	base := func(name string) BaseComponent {
+		return BaseComponent{
+			Id:           id + "_treeview_" + name,
+			EventURL:     eventURL,
+			OnResponse:   testTreeViewResponse,
+			RequestValue: requestValue,
+			RequestMap:   requestMap,
+		}
+	}
+	return []TestComponent{
+		{
+			Label:         "Chart of accounts (rename and drag and drop)",
+			ComponentType: ComponentTypeTreeView,
+			Component: &TreeView{
+				BaseComponent: base("accounts"),
+				Nodes:         testTreeViewAccounts(),
+				Expanded:      []string{"1", "12"},
+				Selected:      []string{"121"},
+				Editable:      true,
+				Draggable:     true,
+			}},
+		{
+			Label:         "Product categories (multiple selection)",
+			ComponentType: ComponentTypeTreeView,
+			Component: &TreeView{
+				BaseComponent: base("categories"),
+				Nodes: []TreeNode{
+					{Id: "food", Label: "Food", Children: []TreeNode{
+						{Id: "fruit", Label: "Fruit"},
+						{Id: "dairy", Label: "Dairy"},
+						{Id: "bakery", Label: "Bakery"},
+					}},
+					{Id: "drinks", Label: "Drinks", Children: []TreeNode{
+						{Id: "water", Label: "Water"},
+						{Id: "juice", Label: "Juice"},
+					}},
+					{Id: "household", Label: "Household", Lazy: true},
+				},
+				Expanded:   []string{"food"},
+				Selected:   []string{"fruit", "drinks", "water", "juice"},
+				SelectMode: TreeViewSelectMulti,
+			}},
+		{
+			Label:         "Organization units (lazy loading)",
+			ComponentType: ComponentTypeTreeView,
+			Component: &TreeView{
+				BaseComponent: base("units"),
+				Nodes: []TreeNode{
+					{Id: "hq", Label: "Headquarters", Icon: IconHome, Lazy: true},
+					{Id: "sales", Label: "Sales", Icon: IconShoppingCart, Lazy: true},
+					{Id: "logistics", Label: "Logistics", Icon: IconTruck, Lazy: true},
+				},
+				SelectMode: TreeViewSelectNone,
+			}},
+	}
+}
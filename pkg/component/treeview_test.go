@@ -0,0 +1,647 @@
+package component
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	ut "github.com/nervatura/component/pkg/util"
+)
+
+func TestTestTreeView(t *testing.T) {
+	for _, tt := range TestTreeView(&BaseComponent{EventURL: "/demo"}) {
+		t.Run(tt.Label, func(t *testing.T) {
+			tt.Component.Render()
+		})
+	}
+	tre := &TreeView{Nodes: []TreeNode{{Id: "hq", Label: "Headquarters", Lazy: true}}}
+	testTreeViewResponse(ResponseEvent{Trigger: tre, Name: TreeViewEventLoad, Value: ut.IM{"node": tre.Nodes[0]}})
+	if len(tre.Nodes[0].Children) != 3 {
+		t.Errorf("testTreeViewResponse() nodes = %v", tre.Nodes)
+	}
+	testTreeViewResponse(ResponseEvent{Trigger: tre, Name: TreeViewEventExpand})
+}
+
+func TestTreeView_GetProperty(t *testing.T) {
+	tests := []struct {
+		name     string
+		propName string
+		want     interface{}
+	}{
+		{
+			name:     "select_mode",
+			propName: "select_mode",
+			want:     TreeViewSelectMulti,
+		},
+		{
+			name:     "expanded",
+			propName: "expanded",
+			want:     []string{"1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tre := &TreeView{SelectMode: TreeViewSelectMulti, Expanded: []string{"1"}}
+			if got := tre.GetProperty(tt.propName); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TreeView.GetProperty() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTreeView_Validation(t *testing.T) {
+	type args struct {
+		propName  string
+		propValue interface{}
+	}
+	tests := []struct {
+		name string
+		args args
+		want interface{}
+	}{
+		{
+			name: "base",
+			args: args{
+				propName:  "id",
+				propValue: "TREEID",
+			},
+			want: "TREEID",
+		},
+		{
+			name: "invalid",
+			args: args{
+				propName:  "invalid",
+				propValue: "",
+			},
+			want: "",
+		},
+		{
+			name: "nodes",
+			args: args{
+				propName:  "nodes",
+				propValue: []TreeNode{{Id: "1", Label: "Assets"}},
+			},
+			want: []TreeNode{{Id: "1", Label: "Assets"}},
+		},
+		{
+			name: "nodes_map",
+			args: args{
+				propName:  "nodes",
+				propValue: []interface{}{ut.IM{"id": "1", "label": "Assets", "children": []interface{}{ut.IM{"id": "11"}}}},
+			},
+			want: []TreeNode{{Id: "1", Label: "Assets", Children: []TreeNode{{Id: "11"}}}},
+		},
+		{
+			name: "nodes_invalid",
+			args: args{
+				propName:  "nodes",
+				propValue: "nodes",
+			},
+			want: []TreeNode{},
+		},
+		{
+			name: "nodes_nil",
+			args: args{
+				propName:  "nodes",
+				propValue: nil,
+			},
+			want: []TreeNode{},
+		},
+		{
+			name: "expanded",
+			args: args{
+				propName:  "expanded",
+				propValue: []interface{}{"1", "12"},
+			},
+			want: []string{"1", "12"},
+		},
+		{
+			name: "selected",
+			args: args{
+				propName:  "selected",
+				propValue: []string{"121"},
+			},
+			want: []string{"121"},
+		},
+		{
+			name: "select_mode",
+			args: args{
+				propName:  "select_mode",
+				propValue: "all",
+			},
+			want: TreeViewSelectSingle,
+		},
+		{
+			name: "target_this",
+			args: args{
+				propName:  "target",
+				propValue: "this",
+			},
+			want: "this",
+		},
+		{
+			name: "target_id",
+			args: args{
+				propName:  "target",
+				propValue: "#target",
+			},
+			want: "#target",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tre := &TreeView{}
+			if got := tre.Validation(tt.args.propName, tt.args.propValue); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TreeView.Validation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTreeView_SetProperty(t *testing.T) {
+	type args struct {
+		propName  string
+		propValue interface{}
+	}
+	tests := []struct {
+		name string
+		args args
+		want interface{}
+	}{
+		{
+			name: "base",
+			args: args{
+				propName:  "id",
+				propValue: "TREEID",
+			},
+			want: "TREEID",
+		},
+		{
+			name: "invalid",
+			args: args{
+				propName:  "invalid",
+				propValue: "",
+			},
+			want: "",
+		},
+		{
+			name: "nodes",
+			args: args{
+				propName:  "nodes",
+				propValue: []TreeNode{{Id: "1"}},
+			},
+			want: []TreeNode{{Id: "1"}},
+		},
+		{
+			name: "expanded",
+			args: args{
+				propName:  "expanded",
+				propValue: []string{"1"},
+			},
+			want: []string{"1"},
+		},
+		{
+			name: "selected",
+			args: args{
+				propName:  "selected",
+				propValue: []string{"1"},
+			},
+			want: []string{"1"},
+		},
+		{
+			name: "select_mode",
+			args: args{
+				propName:  "select_mode",
+				propValue: TreeViewSelectNone,
+			},
+			want: TreeViewSelectNone,
+		},
+		{
+			name: "editable",
+			args: args{
+				propName:  "editable",
+				propValue: true,
+			},
+			want: true,
+		},
+		{
+			name: "draggable",
+			args: args{
+				propName:  "draggable",
+				propValue: true,
+			},
+			want: true,
+		},
+		{
+			name: "edit_node",
+			args: args{
+				propName:  "edit_node",
+				propValue: "1",
+			},
+			want: "1",
+		},
+		{
+			name: "target",
+			args: args{
+				propName:  "target",
+				propValue: "target",
+			},
+			want: "#target",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tre := &TreeView{}
+			if got := tre.SetProperty(tt.args.propName, tt.args.propValue); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TreeView.SetProperty() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTreeView_SetChildren(t *testing.T) {
+	tests := []struct {
+		name         string
+		id           string
+		selectMode   string
+		selected     []string
+		want         bool
+		wantSelected []string
+	}{
+		{
+			name: "missing",
+			id:   "missing",
+			want: false,
+		},
+		{
+			name:         "single",
+			id:           "2",
+			selectMode:   TreeViewSelectSingle,
+			selected:     []string{"2"},
+			want:         true,
+			wantSelected: []string{"2"},
+		},
+		{
+			name:         "multi_checked",
+			id:           "2",
+			selectMode:   TreeViewSelectMulti,
+			selected:     []string{"2"},
+			want:         true,
+			wantSelected: []string{"2", "21", "22"},
+		},
+		{
+			name:         "multi_unchecked",
+			id:           "2",
+			selectMode:   TreeViewSelectMulti,
+			selected:     []string{"1"},
+			want:         true,
+			wantSelected: []string{"1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tre := &TreeView{
+				Nodes:      []TreeNode{{Id: "1"}, {Id: "2", Lazy: true}},
+				Selected:   tt.selected,
+				SelectMode: tt.selectMode,
+			}
+			if got := tre.SetChildren(tt.id, []TreeNode{{Id: "21"}, {Id: "22"}}); got != tt.want {
+				t.Errorf("TreeView.SetChildren() = %v, want %v", got, tt.want)
+			}
+			if tt.want && (len(tre.Nodes[1].Children) != 2 || !reflect.DeepEqual(tre.Selected, tt.wantSelected)) {
+				t.Errorf("TreeView.SetChildren() nodes = %v, selected = %v", tre.Nodes, tre.Selected)
+			}
+		})
+	}
+}
+
+func TestTreeView_moveNode(t *testing.T) {
+	tests := []struct {
+		name       string
+		id         string
+		parentID   string
+		selectMode string
+		expanded   []string
+		want       bool
+		wantNodes  []string
+	}{
+		{
+			name:     "missing",
+			id:       "missing",
+			parentID: "2",
+			want:     false,
+		},
+		{
+			name:     "disabled",
+			id:       "123",
+			parentID: "2",
+			want:     false,
+		},
+		{
+			name:     "self",
+			id:       "12",
+			parentID: "12",
+			want:     false,
+		},
+		{
+			name:     "descendant",
+			id:       "1",
+			parentID: "121",
+			want:     false,
+		},
+		{
+			name:     "unknown_parent",
+			id:       "121",
+			parentID: "missing",
+			want:     false,
+		},
+		{
+			name:      "parent",
+			id:        "12",
+			parentID:  "2",
+			want:      true,
+			wantNodes: []string{"1", "11", "111", "112", "2", "21", "22", "12", "121", "122", "123", "3"},
+		},
+		{
+			name:       "parent_expanded",
+			id:         "121",
+			parentID:   "2",
+			selectMode: TreeViewSelectMulti,
+			expanded:   []string{"2"},
+			want:       true,
+			wantNodes:  []string{"1", "11", "111", "112", "12", "122", "123", "2", "21", "22", "121", "3"},
+		},
+		{
+			name:      "root",
+			id:        "11",
+			want:      true,
+			wantNodes: []string{"1", "12", "121", "122", "123", "2", "21", "22", "3", "11", "111", "112"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tre := &TreeView{Nodes: testTreeViewAccounts(), Expanded: tt.expanded, SelectMode: tt.selectMode}
+			if got := tre.moveNode(tt.id, tt.parentID); got != tt.want {
+				t.Errorf("TreeView.moveNode() = %v, want %v", got, tt.want)
+			}
+			if tt.want {
+				ids := []string{}
+				for _, node := range tre.Nodes {
+					ids = append(ids, treeIds(node)...)
+				}
+				if !reflect.DeepEqual(ids, tt.wantNodes) {
+					t.Errorf("TreeView.moveNode() nodes = %v, want %v", ids, tt.wantNodes)
+				}
+				if tt.parentID != "" && !reflect.DeepEqual(tre.Expanded, []string{tt.parentID}) {
+					t.Errorf("TreeView.moveNode() expanded = %v", tre.Expanded)
+				}
+			}
+		})
+	}
+}
+
+func TestTreeView_OnRequest(t *testing.T) {
+	node := func(id string) TreeNode {
+		return *treeFind(testTreeViewAccounts(), id)
+	}
+	tests := []struct {
+		name         string
+		selectMode   string
+		onResponse   func(evt ResponseEvent) (re ResponseEvent)
+		values       url.Values
+		wantName     string
+		want         interface{}
+		wantExpanded []string
+		wantSelected []string
+	}{
+		{
+			name:         "move",
+			values:       url.Values{"drag": []string{"3"}, "node": []string{"2"}},
+			wantName:     TreeViewEventMove,
+			want:         ut.IM{"id": "3", "parent": "2", "node": node("3")},
+			wantExpanded: []string{"1", "2"},
+		},
+		{
+			name:         "move_invalid",
+			values:       url.Values{"drag": []string{"1"}, "node": []string{"11"}},
+			wantName:     TreeViewEventMove,
+			want:         ut.IM{"id": "1", "parent": "11"},
+			wantExpanded: []string{"1"},
+		},
+		{
+			name:         "missing_node",
+			values:       url.Values{"node": []string{"missing"}},
+			wantName:     TreeViewEventSelected,
+			want:         ut.IM{"id": "missing"},
+			wantExpanded: []string{"1"},
+		},
+		{
+			name:         "collapse",
+			values:       url.Values{"node": []string{"1"}, "action": []string{"toggle"}},
+			wantName:     TreeViewEventCollapse,
+			want:         ut.IM{"id": "1", "node": node("1")},
+			wantExpanded: []string{},
+		},
+		{
+			name:         "expand",
+			values:       url.Values{"node": []string{"2"}, "action": []string{"toggle"}},
+			wantName:     TreeViewEventExpand,
+			want:         ut.IM{"id": "2", "node": node("2")},
+			wantExpanded: []string{"1", "2"},
+		},
+		{
+			name:         "load",
+			values:       url.Values{"node": []string{"4"}, "action": []string{"toggle"}},
+			wantName:     TreeViewEventLoad,
+			want:         ut.IM{"id": "4", "node": TreeNode{Id: "4", Label: "Reserves", Lazy: true}},
+			wantExpanded: []string{"1", "4"},
+		},
+		{
+			name:         "edit",
+			values:       url.Values{"node": []string{"3"}, "action": []string{"edit"}},
+			wantName:     TreeViewEventEdit,
+			want:         ut.IM{"id": "3", "node": node("3")},
+			wantExpanded: []string{"1"},
+		},
+		{
+			name:         "rename",
+			values:       url.Values{"node": []string{"3"}, "action": []string{"rename"}, "value": []string{" Capital "}},
+			wantName:     TreeViewEventRename,
+			want:         ut.IM{"id": "3", "node": TreeNode{Id: "3", Label: "Capital", Icon: IconDollar}, "value": "Capital", "old_value": "Equity"},
+			wantExpanded: []string{"1"},
+		},
+		{
+			name:         "rename_unchanged",
+			values:       url.Values{"node": []string{"3"}, "action": []string{"rename"}, "value": []string{"Equity"}},
+			wantName:     TreeViewEventEdit,
+			want:         ut.IM{"id": "3", "node": node("3")},
+			wantExpanded: []string{"1"},
+		},
+		{
+			name:         "selected_single",
+			selectMode:   TreeViewSelectSingle,
+			values:       url.Values{"node": []string{"3"}},
+			wantName:     TreeViewEventSelected,
+			want:         ut.IM{"id": "3", "node": node("3"), "selected": []string{"3"}},
+			wantExpanded: []string{"1"},
+			wantSelected: []string{"3"},
+		},
+		{
+			name:         "selected_multi",
+			selectMode:   TreeViewSelectMulti,
+			values:       url.Values{"node": []string{"12"}, "action": []string{"check"}},
+			wantName:     TreeViewEventSelected,
+			want:         ut.IM{"id": "12", "node": node("12"), "selected": []string{"12", "121", "122", "123"}},
+			wantExpanded: []string{"1"},
+			wantSelected: []string{"12", "121", "122", "123"},
+		},
+		{
+			name:         "selected_none",
+			selectMode:   TreeViewSelectNone,
+			values:       url.Values{"node": []string{"3"}},
+			wantName:     TreeViewEventSelected,
+			want:         ut.IM{"id": "3", "node": node("3"), "selected": []string{"121"}},
+			wantExpanded: []string{"1"},
+			wantSelected: []string{"121"},
+		},
+		{
+			name: "on_response",
+			onResponse: func(evt ResponseEvent) (re ResponseEvent) {
+				evt.Value = "response"
+				return evt
+			},
+			values:       url.Values{"node": []string{"3"}, "action": []string{"edit"}},
+			wantName:     TreeViewEventEdit,
+			want:         "response",
+			wantExpanded: []string{"1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tre := &TreeView{
+				BaseComponent: BaseComponent{OnResponse: tt.onResponse},
+				Nodes:         append(testTreeViewAccounts(), TreeNode{Id: "4", Label: "Reserves", Lazy: true}),
+				Expanded:      []string{"1"},
+				Selected:      []string{"121"},
+				SelectMode:    tt.selectMode,
+			}
+			re := tre.OnRequest(TriggerEvent{Values: tt.values})
+			if re.Name != tt.wantName || !reflect.DeepEqual(re.Value, tt.want) {
+				t.Errorf("TreeView.OnRequest() = %v %v, want %v", re.Name, re.Value, tt.want)
+			}
+			if !reflect.DeepEqual(tre.Expanded, tt.wantExpanded) {
+				t.Errorf("TreeView.OnRequest() expanded = %v, want %v", tre.Expanded, tt.wantExpanded)
+			}
+			if tt.wantSelected != nil && !reflect.DeepEqual(tre.Selected, tt.wantSelected) {
+				t.Errorf("TreeView.OnRequest() selected = %v, want %v", tre.Selected, tt.wantSelected)
+			}
+		})
+	}
+}
+
+func TestTreeView_rows(t *testing.T) {
+	tests := []struct {
+		name     string
+		expanded []string
+		selected []string
+		focus    string
+		want     []string
+	}{
+		{
+			name:     "collapsed_mixed",
+			selected: []string{"121"},
+			want:     []string{"1:mixed:true", "2:false:false", "3:false:false"},
+		},
+		{
+			name:     "expanded_mixed",
+			expanded: []string{"1"},
+			selected: []string{"121"},
+			focus:    "2",
+			want:     []string{"1:mixed:false", "11:false:false", "12:mixed:false", "2:false:true", "3:false:false"},
+		},
+		{
+			name:     "checked",
+			expanded: []string{"2"},
+			selected: []string{"2", "21", "22"},
+			want:     []string{"1:false:true", "2:true:false", "21:true:false", "22:true:false", "3:false:false"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tre := &TreeView{
+				Nodes: testTreeViewAccounts(), Expanded: tt.expanded, Selected: tt.selected,
+				SelectMode: TreeViewSelectMulti, focus: tt.focus,
+			}
+			rows := []string{}
+			for _, row := range tre.rows() {
+				rows = append(rows, strings.Join([]string{row.Node.Id, row.Checked, ut.ToString(row.Focus, "")}, ":"))
+			}
+			if !reflect.DeepEqual(rows, tt.want) {
+				t.Errorf("TreeView.rows() = %v, want %v", rows, tt.want)
+			}
+		})
+	}
+}
+
+func TestTreeView_Render(t *testing.T) {
+	tests := []struct {
+		name     string
+		treeview TreeView
+		want     []string
+		skip     []string
+	}{
+		{
+			name: "readonly",
+			treeview: TreeView{
+				BaseComponent: BaseComponent{Id: "tree", Style: ut.SM{"max-width": "400px"}, Class: []string{"accounts"}},
+				Nodes:         testTreeViewAccounts(), Expanded: []string{"1"}, Editable: true,
+			},
+			want: []string{`class="treeview accounts"`, `style="max-width:400px;"`, `aria-expanded="true"`, "Fixed assets"},
+			skip: []string{"hx-post", `class="treeview-edit"`, "<script>"},
+		},
+		{
+			name: "editable",
+			treeview: TreeView{
+				BaseComponent: BaseComponent{Id: "tree", EventURL: "/event"},
+				Nodes:         testTreeViewAccounts(), Expanded: []string{"1", "12"}, Selected: []string{"121"},
+				Editable: true, Draggable: true, EditNode: "122", focus: "122",
+			},
+			want: []string{
+				`id="tree_5_input"`, `value="Receivables"`, `id="tree_4_edit"`, `draggable="true"`, "htmx.ajax",
+				`class="treeview-row selected"`, `class="treeview-row disabled"`, `data-focus="true"`,
+			},
+			skip: []string{`id="tree_6_edit"`, `class="treeview-check"`},
+		},
+		{
+			name: "multi",
+			treeview: TreeView{
+				BaseComponent: BaseComponent{Id: "tree", EventURL: "/event"},
+				Nodes:         testTreeViewAccounts(), Selected: []string{"3", "121"}, SelectMode: TreeViewSelectMulti,
+			},
+			want: []string{`aria-multiselectable="true"`, `aria-checked="mixed"`, `aria-checked="true"`, `id="tree_1_check"`},
+			skip: []string{"htmx.ajax", `class="treeview-edit"`, `data-focus="true"`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			html, err := tt.treeview.Render()
+			if err != nil {
+				t.Fatalf("TreeView.Render() error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(string(html), want) {
+					t.Errorf("TreeView.Render() = %v, want %v", html, want)
+				}
+			}
+			for _, skip := range tt.skip {
+				if strings.Contains(string(html), skip) {
+					t.Errorf("TreeView.Render() = %v, skip %v", html, skip)
+				}
+			}
+			if tt.treeview.EventURL != "" && tt.treeview.RequestMap["tree_1_toggle"] != &tt.treeview {
+				t.Errorf("TreeView.Render() request_map = %v", tt.treeview.RequestMap)
+			}
+		})
+	}
+}
@@ -1,7 +1,9 @@
 package component
 
 import (
+	"fmt"
 	"html/template"
+	"io"
 	"strings"
 
 	ut "github.com/nervatura/component/pkg/util"
@@ -14,9 +16,25 @@ const (
 	UploadEventUpload         = "upload_upload"
 	UploadDefaultPlaceholder  = "Choose file to upload"
 	UploadDefaultToastMessage = "Successful file upload"
+	UploadErrorAccept         = "Invalid file type"
+	UploadErrorSize           = "The file is too large"
+	UploadErrorMultiple       = "Only one file can be uploaded"
 )
 
-// Creates a file upload control
+/*
+Creates a file upload control with a drag and drop zone. The selected files are checked in the browser
+and on the server side (Accept and MaxSize), and they are uploaded one by one with a progress bar and a
+cancel button. The files of the request are delivered in the [TriggerEvent] Files, and the valid files
+are saved into the Store if it is set.
+
+The value of the [UploadEventUpload] event:
+
+	ut.IM{
+	  "files":    []UploadFile{}, // the valid uploaded files
+	  "stored":   []StoredFile{}, // the saved files of the Store
+	  "rejected": []ut.IM{},      // name and error of the invalid files
+	}
+*/
 type Upload struct {
 	BaseComponent
 	// Specifies a filter for what file types the user can pick from the file input dialog box
@@ -32,6 +50,14 @@ type Upload struct {
 	MaxLength int64 `json:"max_length"`
 	// Full width cell (100%)
 	Full bool `json:"full"`
+	// Allows the selection of multiple files
+	Multiple bool `json:"multiple"`
+	// The maximum size of a file in bytes. The default 0 means no limit.
+	MaxSize int64 `json:"max_size"`
+	// Image thumbnail previews of the selected files
+	Preview bool `json:"preview"`
+	// The storage of the uploaded files. See [LocalFileStore] and [MemoryFileStore]
+	Store FileStore `json:"-"`
 }
 
 /*
@@ -47,6 +73,9 @@ func (upl *Upload) Properties() ut.IM {
 			"disabled":      upl.Disabled,
 			"max_length":    upl.MaxLength,
 			"full":          upl.Full,
+			"multiple":      upl.Multiple,
+			"max_size":      upl.MaxSize,
+			"preview":       upl.Preview,
 		})
 }
 
@@ -83,6 +112,18 @@ func (upl *Upload) SetProperty(propName string, propValue interface{}) interface
 			upl.Full = ut.ToBoolean(propValue, false)
 			return upl.Full
 		},
+		"multiple": func() interface{} {
+			upl.Multiple = ut.ToBoolean(propValue, false)
+			return upl.Multiple
+		},
+		"max_size": func() interface{} {
+			upl.MaxSize = max(ut.ToInteger(propValue, 0), 0)
+			return upl.MaxSize
+		},
+		"preview": func() interface{} {
+			upl.Preview = ut.ToBoolean(propValue, false)
+			return upl.Preview
+		},
 		"toast_message": func() interface{} {
 			upl.ToastMessage = ut.ToString(propValue, UploadDefaultToastMessage)
 			return upl.ToastMessage
@@ -102,13 +143,33 @@ If the OnResponse function of the [Upload] is implemented, the function calls it
 is processed, otherwise the function's return [ResponseEvent] is the processed [TriggerEvent].
 */
 func (upl *Upload) OnRequest(te TriggerEvent) (re ResponseEvent) {
+	files, stored, rejected := []UploadFile{}, []StoredFile{}, []ut.IM{}
+	messages := []string{}
+	for index, file := range te.Files {
+		err := upl.checkFile(index, file)
+		if err == "" && upl.Store != nil {
+			if ref, storeErr := upl.Store.Save(file); storeErr == nil {
+				stored = append(stored, ref)
+			} else {
+				err = storeErr.Error()
+			}
+		}
+		if err != "" {
+			rejected = append(rejected, ut.IM{"name": file.Name, "error": err})
+			messages = append(messages, file.Name+": "+err)
+			continue
+		}
+		files = append(files, file)
+	}
+	toast := &Toast{Type: ToastTypeSuccess, Value: upl.ToastMessage}
+	if len(rejected) > 0 {
+		toast = &Toast{Type: ToastTypeError, Value: strings.Join(messages, "; ")}
+	}
 	evt := ResponseEvent{
-		Trigger: &Toast{
-			Type:  ToastTypeSuccess,
-			Value: upl.ToastMessage,
-		},
+		Trigger:     toast,
 		TriggerName: upl.Name,
 		Name:        UploadEventUpload,
+		Value:       ut.IM{"files": files, "stored": stored, "rejected": rejected},
 		Header: ut.SM{
 			HeaderRetarget: "#toast-msg",
 			HeaderReswap:   SwapInnerHTML,
@@ -120,7 +181,37 @@ func (upl *Upload) OnRequest(te TriggerEvent) (re ResponseEvent) {
 	return evt
 }
 
-func (upl *Upload) getComponent(name string) (html template.HTML, err error) {
+// Returns true if the file matches the Accept file extensions and MIME types
+func (upl *Upload) accepted(file UploadFile) bool {
+	if strings.TrimSpace(upl.Accept) == "" {
+		return true
+	}
+	name, fileType := strings.ToLower(file.Name), strings.ToLower(file.Type)
+	for _, item := range strings.Split(strings.ToLower(upl.Accept), ",") {
+		item = strings.TrimSpace(item)
+		if (strings.HasPrefix(item, ".") && strings.HasSuffix(name, item)) ||
+			(strings.HasSuffix(item, "/*") && strings.HasPrefix(fileType, strings.TrimSuffix(item, "*"))) ||
+			item == fileType {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the error message of the invalid file
+func (upl *Upload) checkFile(index int, file UploadFile) string {
+	switch {
+	case index > 0 && !upl.Multiple:
+		return UploadErrorMultiple
+	case !upl.accepted(file):
+		return UploadErrorAccept
+	case upl.MaxSize > 0 && file.Size > upl.MaxSize:
+		return UploadErrorSize
+	}
+	return ""
+}
+
+func (upl *Upload) component(name string) ClientComponent {
 	ccMap := map[string]func() ClientComponent{
 		"submit": func() ClientComponent {
 			return &Button{
@@ -129,89 +220,227 @@ func (upl *Upload) getComponent(name string) (html template.HTML, err error) {
 					Style: ut.SM{"padding": "8px"},
 				},
 				ButtonStyle: ButtonStyleDefault,
-				Type:        ButtonTypeSubmit,
+				Type:        ButtonTypeButton,
 				Icon:        "Upload",
 			}
 		},
 	}
-	cc := ccMap[name]()
-	html, err = cc.Render()
-	return html, err
+	return ccMap[name]()
 }
 
 /*
 Based on the values, it will generate the html code of the [Upload] or return with an error message.
 */
 func (upl *Upload) Render() (html template.HTML, err error) {
+	return RenderHTML(upl)
+}
+
+// the render data of the [Upload] template
+type uploadRender struct {
+	*Upload
+	hw *ut.HTMLWriter
+}
+
+// [Upload] template functions
+var uploadFuncMap = map[string]any{
+	"styleMap": func(upl *uploadRender) bool {
+		return len(upl.Style) > 0
+	},
+	"customClass": func(upl *uploadRender) string {
+		return strings.Join(upl.Class, " ")
+	},
+	"uploadComponent": func(upl *uploadRender, name string) (template.HTML, error) {
+		return "", RenderTo(upl.hw, upl.component(name))
+	},
+	"errorAccept": func() string {
+		return UploadErrorAccept
+	},
+	"errorSize": func() string {
+		return UploadErrorSize
+	},
+}
+
+/*
+Based on the values, it will write the html code of the [Upload] into the writer or return with an error message.
+*/
+func (upl *Upload) RenderTo(w io.Writer) (err error) {
 	upl.InitProps(upl)
 
-	funcMap := map[string]any{
-		"styleMap": func() bool {
-			return len(upl.Style) > 0
-		},
-		"customClass": func() string {
-			return strings.Join(upl.Class, " ")
-		},
-		"uploadComponent": func(name string) (template.HTML, error) {
-			return upl.getComponent(name)
-		},
-	}
 	tpl := `
 	<form id="{{ .Id }}" name="{{ .Name }}" method="POST" enctype="multipart/form-data" 
 	{{ if eq .Disabled false }}{{ if ne .EventURL "" }} hx-post="{{ .EventURL }}" hx-target="{{ .Target }}" {{ if ne .Sync "none" }} hx-sync="{{ .Sync }}"{{ end }} hx-swap="{{ .Swap }}"{{ end }}
 	{{ if ne .Indicator "none" }} hx-indicator="#{{ .Indicator }}"{{ end }}{{ end }}>
-	<div class="upload{{ if .Full }} full{{ end }} {{ customClass }}"
-	{{ if styleMap }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}>
+	<div id="{{ .Id }}_zone" class="upload{{ if .Full }} full{{ end }} {{ customClass $ }}"
+	{{ if styleMap $ }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}>
 	  <div class="row{{ if .Full }} full{{ end }}"><div class="cell">
 		<label id="{{ .Id }}_label" for="{{ .Id }}_input" 
 		class="{{ if .Disabled }}upload-disabled{{else}}link{{end}}" >{{ .Placeholder }}</label>
 		</div><div class="cell" style="width: 1px;">
-	  <input id="{{ .Id }}_input" type="file" {{ if .Disabled }}disabled{{ end }} name="file"></input>
+	  <input id="{{ .Id }}_input" type="file" {{ if .Disabled }}disabled{{ end }} name="file"
+		{{ if ne .Accept "" }} accept="{{ .Accept }}"{{ end }}{{ if .Multiple }} multiple{{ end }}></input>
 		</div><div id="{{ .Id }}_submit_cell" class="hide">
-	  {{ uploadComponent "submit" }}
-		</div></div>
-		<div class="row full"><div id="{{ .Id }}_progress_cell" class="hide">
-	  <progress id="{{ .Id }}_progress" value="0" max="100"></progress>
+	  {{ uploadComponent $ "submit" }}
 		</div></div>
+		<ul id="{{ .Id }}_files" class="upload-files"></ul>
 	</div>
 	</form>
-  <script>
-	htmx.on('#{{ .Id }}_input', 'change', function(evt) {
-		var maxlen = {{ .MaxLength }};
-    if (evt.target.files[0]) {
-			var fName = evt.target.files[0].name;
-			if ((maxlen > 0) && (fName.length > maxlen)) {
-				fName = fName.substring(0,maxlen)+'...';
-			} 
-			htmx.find('#{{ .Id }}_label').textContent = fName;
-			htmx.find('#{{ .Id }}_submit_cell').className = 'cell';
-		} else {
-			htmx.find('#{{ .Id }}_label').textContent = '{{ .Placeholder }}';
-			htmx.find('#{{ .Id }}_submit_cell').className = 'hide';
-		}
-  });
-  htmx.on('#{{ .Id }}', 'htmx:xhr:progress', function(evt) {
-		//console.log('Here ' + evt.detail.loaded/evt.detail.total * 100)
-    htmx.find('#{{ .Id }}_progress').setAttribute('value', evt.detail.loaded/evt.detail.total * 100)
-  });
-	htmx.on('#{{ .Id }}', 'htmx:xhr:loadstart', function(evt) {
-		htmx.find('#{{ .Id }}_progress_cell').className = 'cell';
-  });
-	htmx.on('#{{ .Id }}', 'htmx:xhr:abort', function(evt) {
-		htmx.find('#{{ .Id }}_progress_cell').className = 'hide';
-  });
-	htmx.on('#{{ .Id }}', 'htmx:xhr:loadend', function(evt) {
-		htmx.find('#{{ .Id }}_progress_cell').className = 'hide';
-		htmx.find('#{{ .Id }}_submit_cell').className = 'hide';
-		htmx.find('#{{ .Id }}_input').setAttribute('value', null);
-		htmx.find('#{{ .Id }}_label').textContent = '{{ .Placeholder }}';
-  });
-  </script>`
-
-	if html, err = ut.TemplateBuilder("upload", tpl, funcMap, upl); err == nil && upl.EventURL != "" {
+	{{ if eq .Disabled false }}<script>
+	(function() {
+		var form = htmx.find('#{{ .Id }}'), input = htmx.find('#{{ .Id }}_input'), zone = htmx.find('#{{ .Id }}_zone'),
+			list = htmx.find('#{{ .Id }}_files'), submit = htmx.find('#{{ .Id }}_submit_cell');
+		var accept = {{ .Accept }}, maxSize = {{ .MaxSize }}, maxlen = {{ .MaxLength }}, 
+			multiple = {{ .Multiple }}, preview = {{ .Preview }};
+		var queue = [], current = null;
+		var accepted = function(file) {
+			return (accept.trim() === '') || accept.toLowerCase().split(',').some(function(item) {
+				item = item.trim();
+				return (item.startsWith('.') && file.name.toLowerCase().endsWith(item)) ||
+					(item.endsWith('/*') && file.type.toLowerCase().startsWith(item.slice(0, -1))) || (item === file.type.toLowerCase());
+			});
+		};
+		var fileSize = function(size) {
+			var units = ['B', 'KB', 'MB', 'GB'], index = 0;
+			while ((size >= 1024) && (index < units.length-1)) {
+				size /= 1024; index++;
+			}
+			return ((index > 0) ? size.toFixed(1) : size) + ' ' + units[index];
+		};
+		var element = function(parent, tag, className, text) {
+			var elt = document.createElement(tag);
+			elt.className = className;
+			if (text !== undefined) {
+				elt.textContent = text;
+			}
+			parent.appendChild(elt);
+			return elt;
+		};
+		var refresh = function() {
+			var pending = queue.some(function(item) { return !item.error && !item.done; });
+			submit.className = (pending && (current === null)) ? 'cell' : 'hide';
+		};
+		var remove = function(item) {
+			if (item === current) {
+				item.canceled = true;
+				htmx.trigger(form, 'htmx:abort');
+				return;
+			}
+			queue.splice(queue.indexOf(item), 1);
+			item.el.remove();
+			if (item.url) {
+				URL.revokeObjectURL(item.url);
+			}
+			refresh();
+		};
+		var addFiles = function(files) {
+			files = Array.prototype.slice.call(files);
+			if (!multiple) {
+				queue.slice().forEach(function(item) { if (item !== current) { remove(item); } });
+				files = files.slice(0, 1);
+			}
+			files.forEach(function(file) {
+				var item = { file: file, error: '' }, name = file.name;
+				if (!accepted(file)) {
+					item.error = {{ errorAccept }};
+				} else if ((maxSize > 0) && (file.size > maxSize)) {
+					item.error = {{ errorSize }};
+				}
+				item.el = element(list, 'li', 'upload-file' + (item.error ? ' upload-file-invalid' : ''));
+				if (preview && file.type.startsWith('image/')) {
+					item.url = URL.createObjectURL(file);
+					element(item.el, 'img', 'upload-thumb').src = item.url;
+				}
+				if ((maxlen > 0) && (name.length > maxlen)) {
+					name = name.substring(0,maxlen)+'...';
+				}
+				element(item.el, 'span', 'upload-file-name', name).title = file.name;
+				element(item.el, 'span', 'upload-file-size', fileSize(file.size));
+				if (item.error) {
+					element(item.el, 'span', 'upload-file-error', item.error);
+				} else {
+					item.progress = element(item.el, 'progress', 'upload-file-progress');
+					item.progress.max = 100; item.progress.value = 0;
+				}
+				var cancel = element(item.el, 'span', 'upload-file-cancel', '\u00d7');
+				cancel.title = 'Cancel';
+				cancel.onclick = function() { remove(item); };
+				queue.push(item);
+			});
+			refresh();
+		};
+		var next = function() {
+			current = queue.find(function(item) { return !item.error && !item.done; }) || null;
+			if (current) {
+				var transfer = new DataTransfer();
+				transfer.items.add(current.file);
+				input.files = transfer.files;
+				current.el.classList.add('upload-file-active');
+				htmx.trigger(form, 'submit');
+			}
+			refresh();
+		};
+		htmx.on(input, 'change', function() {
+			addFiles(input.files);
+			input.value = '';
+		});
+		htmx.on('#{{ .Id }}_submit', 'click', next);
+		htmx.on(zone, 'dragover', function(evt) {
+			evt.preventDefault();
+			zone.classList.add('upload-dragover');
+		});
+		htmx.on(zone, 'dragleave', function() {
+			zone.classList.remove('upload-dragover');
+		});
+		htmx.on(zone, 'drop', function(evt) {
+			evt.preventDefault();
+			zone.classList.remove('upload-dragover');
+			addFiles(evt.dataTransfer.files);
+		});
+		htmx.on(form, 'htmx:xhr:progress', function(evt) {
+			if (current && evt.detail.total) {
+				current.progress.value = evt.detail.loaded / evt.detail.total * 100;
+			}
+		});
+		htmx.on(form, 'htmx:afterRequest', function(evt) {
+			var item = current;
+			if (item) {
+				current = null;
+				input.value = '';
+				item.done = true;
+				if (item.canceled) {
+					remove(item);
+				} else {
+					item.el.classList.replace('upload-file-active', evt.detail.successful ? 'upload-file-done' : 'upload-file-failed');
+				}
+				next();
+			}
+		});
+	})();
+	</script>{{ end }}`
+
+	hw := ut.NewHTMLWriter(w)
+	if err = ut.CachedTemplateWriter(hw, "upload", tpl, uploadFuncMap, &uploadRender{Upload: upl, hw: hw}); err == nil {
+		err = hw.Close()
+	}
+	if err == nil && upl.EventURL != "" {
 		upl.SetProperty("request_map", upl)
 	}
-	return html, nil
+	return nil
+}
+
+// The demo files are removed from the store after the upload
+var testUploadStore FileStore = &MemoryFileStore{}
+
+var testUploadResponse func(evt ResponseEvent) (re ResponseEvent) = func(evt ResponseEvent) (re ResponseEvent) {
+	value := ut.ToIM(evt.Value, ut.IM{})
+	if stored, valid := value["stored"].([]StoredFile); valid && len(stored) > 0 {
+		names := []string{}
+		for _, ref := range stored {
+			names = append(names, fmt.Sprintf("%s (%d bytes)", ref.Name, ref.Size))
+			testUploadStore.Delete(ref.Id)
+		}
+		evt.Trigger.SetProperty("value", UploadDefaultToastMessage+": "+strings.Join(names, ", "))
+	}
+	return evt
 }
 
 // [Upload] test and demo data
@@ -249,5 +478,25 @@ func TestUpload(cc ClientComponent) []TestComponent {
 				Full:     true,
 				Disabled: true,
 			}},
+		{
+			Label:         "Multiple files, drop zone, images and PDF up to 2 MB",
+			ComponentType: ComponentTypeUpload,
+			Component: &Upload{
+				BaseComponent: BaseComponent{
+					Id:           id + "_upload_multiple",
+					EventURL:     eventURL,
+					OnResponse:   testUploadResponse,
+					RequestValue: requestValue,
+					RequestMap:   requestMap,
+				},
+				Placeholder: "Choose or drop files to upload",
+				Accept:      "image/*,.pdf",
+				MaxSize:     2 << 20,
+				MaxLength:   40,
+				Multiple:    true,
+				Preview:     true,
+				Full:        true,
+				Store:       testUploadStore,
+			}},
 	}
 }
@@ -1,8 +1,14 @@
 package component
 
 import (
+	"errors"
+	"io"
 	"reflect"
+	"strings"
 	"testing"
+	"testing/iotest"
+
+	ut "github.com/nervatura/component/pkg/util"
 )
 
 func TestTestUpload(t *testing.T) {
@@ -11,6 +17,12 @@ func TestTestUpload(t *testing.T) {
 			tt.Component.Render()
 		})
 	}
+	toast := &Toast{}
+	testUploadResponse(ResponseEvent{Trigger: toast, Value: ut.IM{"stored": []StoredFile{{Id: "id", Name: "image.png", Size: 3}}}})
+	if toast.Value != UploadDefaultToastMessage+": image.png (3 bytes)" {
+		t.Errorf("testUploadResponse() = %v", toast.Value)
+	}
+	testUploadResponse(ResponseEvent{Trigger: toast})
 }
 
 func TestUpload_GetProperty(t *testing.T) {
@@ -86,6 +98,22 @@ func TestUpload_SetProperty(t *testing.T) {
 			},
 			want: "",
 		},
+		{
+			name: "max_size",
+			args: args{
+				propName:  "max_size",
+				propValue: -1,
+			},
+			want: int64(0),
+		},
+		{
+			name: "multiple",
+			args: args{
+				propName:  "multiple",
+				propValue: true,
+			},
+			want: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -163,3 +191,79 @@ func TestUpload_OnRequest(t *testing.T) {
 		})
 	}
 }
+
+func testUploadFile(name, fileType, content string) UploadFile {
+	return UploadFile{
+		Name: name, Type: fileType, Size: int64(len(content)), Reader: io.NopCloser(strings.NewReader(content)),
+	}
+}
+
+func TestUpload_OnRequestFiles(t *testing.T) {
+	tests := []struct {
+		name      string
+		upload    Upload
+		files     []UploadFile
+		wantToast string
+		wantFiles []string
+		wantStore int
+		wantError []string
+	}{
+		{
+			name:      "multiple",
+			upload:    Upload{Accept: "image/*, .PDF", MaxSize: 4, Multiple: true, Store: &MemoryFileStore{}},
+			files:     []UploadFile{testUploadFile("image.png", "image/png", "png"), testUploadFile("doc.pdf", "", "pdf")},
+			wantToast: ToastTypeSuccess,
+			wantFiles: []string{"image.png", "doc.pdf"},
+			wantStore: 2,
+			wantError: []string{},
+		},
+		{
+			name:   "invalid",
+			upload: Upload{Accept: "text/csv", MaxSize: 4},
+			files: []UploadFile{
+				testUploadFile("data.csv", "text/csv", "a;b"), testUploadFile("big.csv", "text/csv", "a;b;c"),
+			},
+			wantToast: ToastTypeError,
+			wantFiles: []string{"data.csv"},
+			wantError: []string{UploadErrorMultiple},
+		},
+		{
+			name:   "rejected",
+			upload: Upload{Accept: "text/csv", MaxSize: 4, Multiple: true},
+			files: []UploadFile{
+				testUploadFile("image.png", "image/png", "png"), testUploadFile("big.csv", "text/csv", "a;b;c"),
+			},
+			wantToast: ToastTypeError,
+			wantFiles: []string{},
+			wantError: []string{UploadErrorAccept, UploadErrorSize},
+		},
+		{
+			name:      "store_error",
+			upload:    Upload{Store: &MemoryFileStore{}},
+			files:     []UploadFile{{Name: "image.png", Reader: io.NopCloser(iotest.ErrReader(errors.New("read error")))}},
+			wantToast: ToastTypeError,
+			wantFiles: []string{},
+			wantError: []string{"read error"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			evt := tt.upload.OnRequest(TriggerEvent{Id: "upload", Files: tt.files})
+			if toast := evt.Trigger.(*Toast); toast.Type != tt.wantToast {
+				t.Errorf("Upload.OnRequest() toast = %v, want %v", toast.Type, tt.wantToast)
+			}
+			value := evt.Value.(ut.IM)
+			files, errors := []string{}, []string{}
+			for _, file := range value["files"].([]UploadFile) {
+				files = append(files, file.Name)
+			}
+			for _, rejected := range value["rejected"].([]ut.IM) {
+				errors = append(errors, ut.ToString(rejected["error"], ""))
+			}
+			if !reflect.DeepEqual(files, tt.wantFiles) || !reflect.DeepEqual(errors, tt.wantError) ||
+				len(value["stored"].([]StoredFile)) != tt.wantStore {
+				t.Errorf("Upload.OnRequest() = %v", value)
+			}
+		})
+	}
+}
@@ -0,0 +1,697 @@
+package component
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"slices"
+	"strings"
+
+	ut "github.com/nervatura/component/pkg/util"
+)
+
+// [Wizard] constants
+const (
+	ComponentTypeWizard = "wizard"
+
+	WizardEventNext    = "wizard_next"
+	WizardEventBack    = "wizard_back"
+	WizardEventFinish  = "wizard_finish"
+	WizardEventCancel  = "wizard_cancel"
+	WizardEventInvalid = "wizard_invalid"
+)
+
+var wizardDefaultLabel ut.SM = ut.SM{
+	"wizard_next":     "Next",
+	"wizard_back":     "Back",
+	"wizard_finish":   "Finish",
+	"wizard_cancel":   "Cancel",
+	"wizard_required": "Please fill in the required fields!",
+}
+
+// [Wizard] step
+type WizardStep struct {
+	// Unique step name
+	Name string `json:"name"`
+	// The caption of the step indicator
+	Title string `json:"title"`
+	// The input rows of the step
+	Rows []Row `json:"rows"`
+	// The step is skipped if the function returns true. The values are the accumulated values of the wizard.
+	Skip func(values ut.IM) bool `json:"-"`
+	// Custom validation of the step. The advancing is blocked if the function returns an error message.
+	Validate func(values ut.IM) string `json:"-"`
+}
+
+/*
+Creates a multi-step input form. The current step rows are submitted by the Next, Back and Finish buttons,
+and the submitted values are accumulated in the Values. The required fields (see the required property of the
+[Field] value) and the Validate function of the step are checked before advancing, and the step is not left
+if the check fails ([WizardEventInvalid]).
+
+The current step and the accumulated values are stored in the RequestValue, so the state of the wizard is
+preserved between the requests.
+
+For example:
+
+	&Wizard{
+	  BaseComponent: BaseComponent{
+	    Id:       "id_wizard_onboarding",
+	    EventURL: "/event",
+	  },
+	  Title: "Onboarding",
+	  Steps: []WizardStep{
+	    {Name: "company", Title: "Company", Rows: companyRows},
+	    {Name: "tax", Title: "Tax details", Rows: taxRows, Skip: func(values ut.IM) bool {
+	      return values["type"] == "private"
+	    }},
+	    {Name: "contact", Title: "Contact", Rows: contactRows},
+	  },
+	}
+*/
+type Wizard struct {
+	BaseComponent
+	// The caption of the wizard
+	Title string `json:"title"`
+	// Valid [Icon] component value. See more [IconValues] variable values.
+	Icon string `json:"icon"`
+	// The ordered steps of the wizard
+	Steps []WizardStep `json:"steps"`
+	// The index of the current step
+	Step int64 `json:"step"`
+	// The accumulated input values of the steps
+	Values ut.IM `json:"values"`
+	// The validation error message of the current step
+	Error string `json:"error"`
+	// The modal mode
+	Modal bool `json:"modal"`
+	// The texts of the labels of the controls
+	Labels ut.SM `json:"labels"`
+}
+
+/*
+Returns all properties of the [Wizard]
+*/
+func (wiz *Wizard) Properties() ut.IM {
+	return ut.MergeIM(
+		wiz.BaseComponent.Properties(),
+		ut.IM{
+			"title":  wiz.Title,
+			"icon":   wiz.Icon,
+			"steps":  wiz.Steps,
+			"step":   wiz.Step,
+			"values": wiz.Values,
+			"error":  wiz.Error,
+			"modal":  wiz.Modal,
+			"labels": wiz.Labels,
+		})
+}
+
+/*
+Returns the value of the property of the [Wizard] with the specified name.
+*/
+func (wiz *Wizard) GetProperty(propName string) interface{} {
+	return wiz.Properties()[propName]
+}
+
+/*
+It checks the value given to the property of the [Wizard] and always returns a valid value
+*/
+func (wiz *Wizard) Validation(propName string, propValue interface{}) interface{} {
+	pm := map[string]func() interface{}{
+		"icon": func() interface{} {
+			return wiz.CheckEnumValue(ut.ToString(propValue, ""), IconMagic, IconValues)
+		},
+		"steps": func() interface{} {
+			if value, valid := propValue.([]WizardStep); valid {
+				return value
+			}
+			return []WizardStep{}
+		},
+		"step": func() interface{} {
+			if value := ut.ToInteger(propValue, 0); value > 0 {
+				return value
+			}
+			return int64(0)
+		},
+		"values": func() interface{} {
+			return ut.ToIM(propValue, ut.IM{})
+		},
+		"labels": func() interface{} {
+			value := ut.ToSM(wiz.Labels, ut.SM{})
+			switch v := propValue.(type) {
+			case ut.SM:
+				value = ut.MergeSM(value, v)
+			case ut.IM:
+				value = ut.MergeSM(value, ut.IMToSM(v))
+			}
+			if len(value) == 0 {
+				value = wizardDefaultLabel
+			}
+			return value
+		},
+		"target": func() interface{} {
+			wiz.SetProperty("id", wiz.Id)
+			value := ut.ToString(propValue, wiz.Id)
+			if value != "this" && !strings.HasPrefix(value, "#") {
+				value = "#" + value
+			}
+			return value
+		},
+	}
+	if _, found := pm[propName]; found {
+		return pm[propName]()
+	}
+	if wiz.BaseComponent.GetProperty(propName) != nil {
+		return wiz.BaseComponent.Validation(propName, propValue)
+	}
+	return propValue
+}
+
+/*
+Setting a property of the [Wizard] value safely. Checks the entered value.
+In case of an invalid value, the default value will be set.
+*/
+func (wiz *Wizard) SetProperty(propName string, propValue interface{}) interface{} {
+	pm := map[string]func() interface{}{
+		"title": func() interface{} {
+			wiz.Title = ut.ToString(propValue, "")
+			return wiz.Title
+		},
+		"icon": func() interface{} {
+			wiz.Icon = wiz.Validation(propName, propValue).(string)
+			return wiz.Icon
+		},
+		"steps": func() interface{} {
+			wiz.Steps = wiz.Validation(propName, propValue).([]WizardStep)
+			return wiz.Steps
+		},
+		"step": func() interface{} {
+			wiz.Step = wiz.Validation(propName, propValue).(int64)
+			return wiz.Step
+		},
+		"values": func() interface{} {
+			wiz.Values = wiz.Validation(propName, propValue).(ut.IM)
+			return wiz.Values
+		},
+		"error": func() interface{} {
+			wiz.Error = ut.ToString(propValue, "")
+			return wiz.Error
+		},
+		"modal": func() interface{} {
+			wiz.Modal = ut.ToBoolean(propValue, false)
+			return wiz.Modal
+		},
+		"labels": func() interface{} {
+			wiz.Labels = wiz.Validation(propName, propValue).(ut.SM)
+			return wiz.Labels
+		},
+		"target": func() interface{} {
+			wiz.Target = wiz.Validation(propName, propValue).(string)
+			return wiz.Target
+		},
+	}
+	if _, found := pm[propName]; found {
+		return wiz.SetRequestValue(propName, pm[propName](), []string{})
+	}
+	if wiz.BaseComponent.GetProperty(propName) != nil {
+		return wiz.BaseComponent.SetProperty(propName, propValue)
+	}
+	return propValue
+}
+
+func (wiz *Wizard) msg(labelID string) string {
+	if label, found := wiz.Labels[labelID]; found {
+		return label
+	}
+	return labelID
+}
+
+// Returns the index of the current step
+func (wiz *Wizard) current() int {
+	return min(int(wiz.Step), max(len(wiz.Steps)-1, 0))
+}
+
+// Returns true if the step is not skipped by the accumulated values
+func (wiz *Wizard) visible(index int) bool {
+	return wiz.Steps[index].Skip == nil || !wiz.Steps[index].Skip(wiz.Values)
+}
+
+// Returns the index of the next (direction 1) or the previous (direction -1) visible step or -1
+func (wiz *Wizard) nextStep(direction int) int {
+	for index := wiz.current() + direction; index >= 0 && index < len(wiz.Steps); index += direction {
+		if wiz.visible(index) {
+			return index
+		}
+	}
+	return -1
+}
+
+// Returns the input fields of the current step
+func (wiz *Wizard) fields() (fields []Field) {
+	for _, row := range wiz.Steps[wiz.current()].Rows {
+		for _, column := range row.Columns {
+			if ut.ToString(column.Value.Value["name"], "") != "" {
+				fields = append(fields, column.Value)
+			}
+		}
+	}
+	return fields
+}
+
+// Returns true if the required field has no value
+func (wiz *Wizard) missing(field Field) bool {
+	return ut.ToBoolean(field.Value["required"], false) &&
+		ut.ToString(wiz.Values[ut.ToString(field.Value["name"], "")], "") == ""
+}
+
+// Checks the required fields and the Validate function of the current step
+func (wiz *Wizard) validate() string {
+	if slices.ContainsFunc(wiz.fields(), wiz.missing) {
+		return wiz.msg("wizard_required")
+	}
+	if validate := wiz.Steps[wiz.current()].Validate; validate != nil {
+		return validate(wiz.Values)
+	}
+	return ""
+}
+
+// Merges the submitted values of the current step into the accumulated values
+func (wiz *Wizard) mergeValues(te TriggerEvent) {
+	values := ut.MergeIM(ut.IM{}, wiz.Values)
+	for _, field := range wiz.fields() {
+		name := ut.ToString(field.Value["name"], "")
+		if field.Type == FieldTypeBool {
+			// the unchecked checkbox is not submitted
+			values[name] = te.Values.Has(name)
+		} else if te.Values.Has(name) {
+			values[name] = te.Values.Get(name)
+		}
+	}
+	wiz.SetProperty("values", values)
+}
+
+func (wiz *Wizard) response(name string) (re ResponseEvent) {
+	evt := ResponseEvent{
+		Trigger: wiz, TriggerName: wiz.Name, Name: name,
+		Value: ut.IM{"values": wiz.Values, "data": wiz.Data},
+	}
+	if len(wiz.Steps) > 0 {
+		evt.Value = ut.IM{"step": wiz.Steps[wiz.current()].Name, "values": wiz.Values, "data": wiz.Data}
+	}
+	if name == WizardEventInvalid {
+		evt.Value = ut.MergeIM(ut.ToIM(evt.Value, ut.IM{}), ut.IM{"error": wiz.Error})
+	}
+	if wiz.OnResponse != nil {
+		return wiz.OnResponse(evt)
+	}
+	return evt
+}
+
+/*
+If the OnResponse function of the [Wizard] is implemented, the function calls it after the [TriggerEvent]
+is processed, otherwise the function's return [ResponseEvent] is the processed [TriggerEvent].
+*/
+func (wiz *Wizard) OnRequest(te TriggerEvent) (re ResponseEvent) {
+	if te.Values.Has(WizardEventCancel) || len(wiz.Steps) == 0 {
+		return wiz.response(WizardEventCancel)
+	}
+	wiz.mergeValues(te)
+	wiz.SetProperty("error", "")
+	if te.Values.Has(WizardEventBack) {
+		if index := wiz.nextStep(-1); index > -1 {
+			wiz.SetProperty("step", index)
+		}
+		return wiz.response(WizardEventBack)
+	}
+	if err := wiz.validate(); err != "" {
+		wiz.SetProperty("error", err)
+		return wiz.response(WizardEventInvalid)
+	}
+	if index := wiz.nextStep(1); index > -1 {
+		wiz.SetProperty("step", index)
+		return wiz.response(WizardEventNext)
+	}
+	return wiz.response(WizardEventFinish)
+}
+
+// A visible step of the step indicator
+type wizardIndicator struct {
+	Number int
+	Title  string
+	// done, current or todo
+	State string
+}
+
+// Returns the visible steps of the step indicator
+func (wiz *Wizard) indicator() (steps []wizardIndicator) {
+	for index, step := range wiz.Steps {
+		if index == wiz.current() || wiz.visible(index) {
+			state := map[bool]string{true: "done", false: "todo"}[index < wiz.current()]
+			if index == wiz.current() {
+				state = "current"
+			}
+			steps = append(steps, wizardIndicator{Number: len(steps) + 1, Title: step.Title, State: state})
+		}
+	}
+	return steps
+}
+
+// Returns the rows of the current step with the accumulated values
+func (wiz *Wizard) stepRows() (rows []Row) {
+	if len(wiz.Steps) == 0 {
+		return rows
+	}
+	for _, row := range wiz.Steps[wiz.current()].Rows {
+		row.Columns = slices.Clone(row.Columns)
+		for index, column := range row.Columns {
+			value := ut.MergeIM(ut.IM{}, column.Value.Value)
+			if fieldValue, found := wiz.Values[ut.ToString(value["name"], "")]; found {
+				value["value"] = fieldValue
+			}
+			if wiz.Error != "" && wiz.missing(column.Value) {
+				value["invalid"] = true
+			}
+			row.Columns[index].Value.Value = value
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func (wiz *Wizard) getComponent(name string) (html template.HTML, err error) {
+	button := func(buttonStyle, icon string) *Button {
+		return &Button{
+			BaseComponent: BaseComponent{
+				Id:    wiz.Id + "_" + name,
+				Name:  name,
+				Class: []string{name},
+			},
+			Type:        ButtonTypeSubmit,
+			ButtonStyle: buttonStyle,
+			Icon:        icon,
+			Label:       wiz.msg(name),
+		}
+	}
+	ccMap := map[string]func() ClientComponent{
+		"title": func() ClientComponent {
+			return &Label{
+				Value:    wiz.Title,
+				LeftIcon: wiz.Icon,
+			}
+		},
+		"error": func() ClientComponent {
+			return &Label{
+				Value:    wiz.Error,
+				LeftIcon: IconExclamationTriangle,
+			}
+		},
+		"btn_close": func() ClientComponent {
+			return &Icon{
+				BaseComponent: BaseComponent{
+					Id:           wiz.Id + "_" + name,
+					Name:         name,
+					EventURL:     wiz.EventURL,
+					Target:       wiz.Target,
+					RequestValue: wiz.RequestValue,
+					RequestMap:   wiz.RequestMap,
+					Class:        []string{"close-icon"},
+					OnResponse: func(evt ResponseEvent) (re ResponseEvent) {
+						return wiz.response(WizardEventCancel)
+					},
+				},
+				Value: IconTimes,
+			}
+		},
+		WizardEventNext: func() ClientComponent {
+			return button(ButtonStylePrimary, IconArrowRight)
+		},
+		WizardEventFinish: func() ClientComponent {
+			return button(ButtonStylePrimary, IconCheck)
+		},
+		WizardEventBack: func() ClientComponent {
+			return button(ButtonStyleDefault, IconArrowLeft)
+		},
+		WizardEventCancel: func() ClientComponent {
+			return button(ButtonStyleDefault, IconTimes)
+		},
+	}
+	return ccMap[name]().Render()
+}
+
+/*
+Based on the values, it will generate the html code of the [Wizard] or return with an error message.
+*/
+func (wiz *Wizard) Render() (html template.HTML, err error) {
+	return RenderHTML(wiz)
+}
+
+// [Wizard] template functions
+var wizardFuncMap = map[string]any{
+	"styleMap": func(wiz *Wizard) bool {
+		return len(wiz.Style) > 0
+	},
+	"customClass": func(wiz *Wizard) string {
+		return strings.Join(wiz.Class, " ")
+	},
+	"inputComponent": func(wiz *Wizard, name string) (template.HTML, error) {
+		return wiz.getComponent(name)
+	},
+	"indicator": func(wiz *Wizard) []wizardIndicator {
+		return wiz.indicator()
+	},
+	"stepRows": func(wiz *Wizard) []Row {
+		return wiz.stepRows()
+	},
+	"rowComponent": func(row Row) (template.HTML, error) {
+		return row.Render()
+	},
+	"hasNext": func(wiz *Wizard) bool {
+		return wiz.nextStep(1) > -1
+	},
+	"hasBack": func(wiz *Wizard) bool {
+		return wiz.nextStep(-1) > -1
+	},
+	"stepInfo": func(wiz *Wizard) string {
+		steps := wiz.indicator()
+		number := slices.IndexFunc(steps, func(step wizardIndicator) bool { return step.State == "current" }) + 1
+		return fmt.Sprintf("%d / %d", number, len(steps))
+	},
+}
+
+/*
+Based on the values, it will write the html code of the [Wizard] into the writer or return with an error message.
+*/
+func (wiz *Wizard) RenderTo(w io.Writer) (err error) {
+	wiz.InitProps(wiz)
+
+	tpl := `<div id="{{ .Id }}" name="{{ .Name }}" class="row full wizard {{ customClass $ }}">
+	<form id="{{ .Id }}_form" name="wizard_form" novalidate
+	{{ if ne .EventURL "" }} hx-post="{{ .EventURL }}" hx-target="{{ .Target }}" {{ if ne .Sync "none" }} hx-sync="{{ .Sync }}"{{ end }} hx-swap="{{ .Swap }}"{{ end }}
+	{{ if ne .Indicator "none" }} hx-indicator="#{{ .Indicator }}"{{ end }}
+	>{{ if .Modal }}<div class="modal"><div class="dialog"
+	{{ if styleMap $ }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}>{{ end }}
+	<div class="editor" {{ if and (eq .Modal false) (styleMap $) }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}>
+	<div class="editor-title">
+	<div class="cell">{{ inputComponent $ "title" }}</div>
+	<div class="cell align-right"><span class="wizard-step-info">{{ stepInfo $ }}</span>
+	{{ if .Modal }}{{ inputComponent $ "btn_close" }}{{ end }}</div></div>
+	<ol class="wizard-steps">{{ range indicator $ }}<li class="wizard-step {{ .State }}"{{ if eq .State "current" }} aria-current="step"{{ end }}
+	><span class="wizard-step-number">{{ .Number }}</span><span class="wizard-step-title">{{ .Title }}</span></li>{{ end }}</ol>
+	<div class="section-small container-small" >
+	{{ range stepRows $ }}{{ rowComponent . }}{{ end }}
+	</div>
+	{{ if ne .Error "" }}<div class="wizard-error">{{ inputComponent $ "error" }}</div>{{ end }}
+	<div class="section-small container-small wizard-buttons" >
+	{{ if hasNext $ }}{{ inputComponent $ "wizard_next" }}{{ else }}{{ inputComponent $ "wizard_finish" }}{{ end }}
+	{{ if hasBack $ }}{{ inputComponent $ "wizard_back" }}{{ end }}
+	{{ inputComponent $ "wizard_cancel" }}
+	</div>
+	</div>{{ if .Modal }}</div></div>{{ end }}
+	</form></div>`
+
+	if err = ut.CachedTemplateWriter(w, "wizard", tpl, wizardFuncMap, wiz); err == nil && wiz.EventURL != "" {
+		wiz.SetProperty("request_map", wiz)
+		// the htmx trigger id of the form
+		wiz.RequestMap[wiz.Id+"_form"] = wiz
+	}
+	return err
+}
+
+var testWizardResponse func(evt ResponseEvent) (re ResponseEvent) = func(evt ResponseEvent) (re ResponseEvent) {
+	if !slices.Contains([]string{WizardEventFinish, WizardEventCancel}, evt.Name) {
+		return evt
+	}
+	value := evt.Name
+	if evt.Name == WizardEventFinish {
+		data, _ := ut.ConvertToByte(ut.ToIM(evt.Value, ut.IM{})["values"])
+		value = string(data)
+	}
+	// restart the wizard
+	evt.Trigger.SetProperty("step", 0)
+	evt.Trigger.SetProperty("values", ut.IM{})
+	return ResponseEvent{
+		Trigger: &Toast{
+			Type:    ToastTypeInfo,
+			Value:   value,
+			Timeout: 6,
+		},
+		TriggerName: evt.TriggerName,
+		Name:        evt.Name,
+		Header: ut.SM{
+			HeaderRetarget: "#toast-msg",
+			HeaderReswap:   SwapInnerHTML,
+		},
+	}
+}
+
+func testWizardRow(label string, field Field) Row {
+	return Row{
+		Columns: []RowColumn{{Label: label, Value: field}},
+		Full:    true,
+	}
+}
+
+func testWizardOnboarding() []WizardStep {
+	return []WizardStep{
+		{
+			Name: "company", Title: "Company",
+			Rows: []Row{
+				testWizardRow("Company name", Field{Type: FieldTypeString,
+					Value: ut.IM{"name": "company_name", "placeholder": "Required field", "required": true, "auto_focus": true}}),
+				testWizardRow("Company type", Field{Type: FieldTypeSelect,
+					Value: ut.IM{"name": "company_type", "value": "business", "options": []SelectOption{
+						{Value: "business", Text: "Business"}, {Value: "private", Text: "Private person"},
+					}}}),
+			},
+		},
+		{
+			Name: "tax", Title: "Tax details",
+			Skip: func(values ut.IM) bool {
+				return values["company_type"] == "private"
+			},
+			Validate: func(values ut.IM) string {
+				if len(ut.ToString(values["tax_number"], "")) < 8 {
+					return "The tax number must be at least 8 characters!"
+				}
+				return ""
+			},
+			Rows: []Row{
+				testWizardRow("Tax number", Field{Type: FieldTypeString,
+					Value: ut.IM{"name": "tax_number", "placeholder": "12345678", "required": true}}),
+			},
+		},
+		{
+			Name: "contact", Title: "Contact",
+			Rows: []Row{
+				testWizardRow("Email", Field{Type: FieldTypeString,
+					Value: ut.IM{"name": "email", "placeholder": "name@example.com", "required": true}}),
+				testWizardRow("Phone", Field{Type: FieldTypeString,
+					Value: ut.IM{"name": "phone"}}),
+			},
+		},
+		{
+			Name: "options", Title: "Options",
+			Rows: []Row{
+				testWizardRow("Subscribe to the newsletter", Field{Type: FieldTypeBool,
+					Value: ut.IM{"name": "newsletter"}}),
+			},
+		},
+	}
+}
+
+func testWizardImport() []WizardStep {
+	return []WizardStep{
+		{
+			Name: "source", Title: "Source",
+			Rows: []Row{
+				testWizardRow("File type", Field{Type: FieldTypeSelect,
+					Value: ut.IM{"name": "file_type", "value": "csv", "options": []SelectOption{
+						{Value: "csv", Text: "CSV"}, {Value: "json", Text: "JSON"},
+					}}}),
+			},
+		},
+		{
+			Name: "csv", Title: "CSV options",
+			Skip: func(values ut.IM) bool {
+				return values["file_type"] != "csv"
+			},
+			Rows: []Row{
+				testWizardRow("Separator", Field{Type: FieldTypeString,
+					Value: ut.IM{"name": "separator", "value": ";", "required": true}}),
+				testWizardRow("Header row", Field{Type: FieldTypeBool,
+					Value: ut.IM{"name": "header", "value": true}}),
+			},
+		},
+		{
+			Name: "confirm", Title: "Confirm",
+			Rows: []Row{
+				testWizardRow("Import", Field{Type: FieldTypeLabel,
+					Value: ut.IM{"value": "The data will be imported into the current database."}}),
+			},
+		},
+	}
+}
+
+// [Wizard] test and demo data
+func TestWizard(cc ClientComponent) []TestComponent {
+	id := ut.ToString(cc.GetProperty("id"), "")
+	eventURL := ut.ToString(cc.GetProperty("event_url"), "")
+	requestValue := cc.GetProperty("request_value").(map[string]ut.IM)
+	requestMap := cc.GetProperty("request_map").(map[string]ClientComponent)
+	return []TestComponent{
+		{
+			Label:         "Onboarding",
+			ComponentType: ComponentTypeWizard,
+			Component: &Wizard{
+				BaseComponent: BaseComponent{
+					Id:           id + "_wizard_onboarding",
+					EventURL:     eventURL,
+					OnResponse:   testWizardResponse,
+					RequestValue: requestValue,
+					RequestMap:   requestMap,
+				},
+				Title: "Onboarding",
+				Icon:  IconUser,
+				Steps: testWizardOnboarding(),
+			},
+		},
+		{
+			Label:         "Validation error",
+			ComponentType: ComponentTypeWizard,
+			Component: &Wizard{
+				BaseComponent: BaseComponent{
+					Id:           id + "_wizard_invalid",
+					EventURL:     eventURL,
+					OnResponse:   testWizardResponse,
+					RequestValue: requestValue,
+					RequestMap:   requestMap,
+				},
+				Title:  "Onboarding",
+				Icon:   IconUser,
+				Steps:  testWizardOnboarding(),
+				Step:   1,
+				Values: ut.IM{"company_name": "Kalevala Ltd.", "company_type": "business", "tax_number": ""},
+				Error:  "Please fill in the required fields!",
+			},
+		},
+		{
+			Label:         "Modal import",
+			ComponentType: ComponentTypeWizard,
+			Component: &Wizard{
+				BaseComponent: BaseComponent{
+					Id:           id + "_wizard_import",
+					EventURL:     eventURL,
+					OnResponse:   testWizardResponse,
+					RequestValue: requestValue,
+					RequestMap:   requestMap,
+					Style:        ut.SM{"max-width": "500px"},
+				},
+				Title: "Import data",
+				Icon:  IconUpload,
+				Steps: testWizardImport(),
+				Modal: true,
+			},
+		},
+	}
+}
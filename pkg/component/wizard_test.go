@@ -0,0 +1,541 @@
+package component
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	ut "github.com/nervatura/component/pkg/util"
+)
+
+func TestTestWizard(t *testing.T) {
+	for _, tt := range TestWizard(&BaseComponent{EventURL: "/demo"}) {
+		t.Run(tt.Label, func(t *testing.T) {
+			tt.Component.Render()
+		})
+	}
+	wiz := &Wizard{Step: 2, Values: ut.IM{"email": "a@b.c"}}
+	testWizardResponse(ResponseEvent{Trigger: wiz, Name: WizardEventNext})
+	testWizardResponse(ResponseEvent{Trigger: wiz, Name: WizardEventCancel})
+	testWizardResponse(ResponseEvent{Trigger: wiz, Name: WizardEventFinish, Value: ut.IM{"values": wiz.Values}})
+	if wiz.Step != 0 || len(wiz.Values) != 0 {
+		t.Errorf("testWizardResponse() = %v, %v", wiz.Step, wiz.Values)
+	}
+	for _, step := range testWizardOnboarding() {
+		if step.Skip != nil {
+			step.Skip(ut.IM{"company_type": "private"})
+		}
+		if step.Validate != nil {
+			step.Validate(ut.IM{"tax_number": "12345678"})
+			step.Validate(ut.IM{})
+		}
+	}
+	for _, step := range testWizardImport() {
+		if step.Skip != nil {
+			step.Skip(ut.IM{"file_type": "json"})
+		}
+	}
+}
+
+func TestWizard_GetProperty(t *testing.T) {
+	tests := []struct {
+		name     string
+		propName string
+		want     interface{}
+	}{
+		{
+			name:     "step",
+			propName: "step",
+			want:     int64(1),
+		},
+		{
+			name:     "modal",
+			propName: "modal",
+			want:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wiz := &Wizard{Step: 1, Modal: true}
+			if got := wiz.GetProperty(tt.propName); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Wizard.GetProperty() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWizard_Validation(t *testing.T) {
+	type args struct {
+		propName  string
+		propValue interface{}
+	}
+	tests := []struct {
+		name   string
+		labels ut.SM
+		args   args
+		want   interface{}
+	}{
+		{
+			name: "base",
+			args: args{
+				propName:  "id",
+				propValue: "WIZARDID",
+			},
+			want: "WIZARDID",
+		},
+		{
+			name: "invalid",
+			args: args{
+				propName:  "invalid",
+				propValue: "",
+			},
+			want: "",
+		},
+		{
+			name: "icon",
+			args: args{
+				propName:  "icon",
+				propValue: "missing",
+			},
+			want: IconMagic,
+		},
+		{
+			name: "steps",
+			args: args{
+				propName:  "steps",
+				propValue: []WizardStep{{Name: "first"}},
+			},
+			want: []WizardStep{{Name: "first"}},
+		},
+		{
+			name: "steps_invalid",
+			args: args{
+				propName:  "steps",
+				propValue: "steps",
+			},
+			want: []WizardStep{},
+		},
+		{
+			name: "step",
+			args: args{
+				propName:  "step",
+				propValue: 2,
+			},
+			want: int64(2),
+		},
+		{
+			name: "step_negative",
+			args: args{
+				propName:  "step",
+				propValue: -1,
+			},
+			want: int64(0),
+		},
+		{
+			name: "values",
+			args: args{
+				propName:  "values",
+				propValue: ut.IM{"name": "value"},
+			},
+			want: ut.IM{"name": "value"},
+		},
+		{
+			name: "labels_sm",
+			args: args{
+				propName:  "labels",
+				propValue: ut.SM{"wizard_next": "Tovább"},
+			},
+			want: ut.SM{"wizard_next": "Tovább"},
+		},
+		{
+			name:   "labels_im",
+			labels: ut.SM{"wizard_back": "Vissza"},
+			args: args{
+				propName:  "labels",
+				propValue: ut.IM{"wizard_next": "Tovább"},
+			},
+			want: ut.SM{"wizard_back": "Vissza", "wizard_next": "Tovább"},
+		},
+		{
+			name: "labels_default",
+			args: args{
+				propName:  "labels",
+				propValue: nil,
+			},
+			want: wizardDefaultLabel,
+		},
+		{
+			name: "target",
+			args: args{
+				propName:  "target",
+				propValue: "target",
+			},
+			want: "#target",
+		},
+		{
+			name: "target_this",
+			args: args{
+				propName:  "target",
+				propValue: "this",
+			},
+			want: "this",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wiz := &Wizard{Labels: tt.labels}
+			if got := wiz.Validation(tt.args.propName, tt.args.propValue); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Wizard.Validation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWizard_SetProperty(t *testing.T) {
+	type args struct {
+		propName  string
+		propValue interface{}
+	}
+	tests := []struct {
+		name string
+		args args
+		want interface{}
+	}{
+		{
+			name: "base",
+			args: args{
+				propName:  "id",
+				propValue: "WIZARDID",
+			},
+			want: "WIZARDID",
+		},
+		{
+			name: "invalid",
+			args: args{
+				propName:  "invalid",
+				propValue: "",
+			},
+			want: "",
+		},
+		{
+			name: "title",
+			args: args{
+				propName:  "title",
+				propValue: "Onboarding",
+			},
+			want: "Onboarding",
+		},
+		{
+			name: "icon",
+			args: args{
+				propName:  "icon",
+				propValue: IconUser,
+			},
+			want: IconUser,
+		},
+		{
+			name: "steps",
+			args: args{
+				propName:  "steps",
+				propValue: []WizardStep{{Name: "first"}},
+			},
+			want: []WizardStep{{Name: "first"}},
+		},
+		{
+			name: "step",
+			args: args{
+				propName:  "step",
+				propValue: 1,
+			},
+			want: int64(1),
+		},
+		{
+			name: "values",
+			args: args{
+				propName:  "values",
+				propValue: ut.IM{"name": "value"},
+			},
+			want: ut.IM{"name": "value"},
+		},
+		{
+			name: "error",
+			args: args{
+				propName:  "error",
+				propValue: "error",
+			},
+			want: "error",
+		},
+		{
+			name: "modal",
+			args: args{
+				propName:  "modal",
+				propValue: true,
+			},
+			want: true,
+		},
+		{
+			name: "labels",
+			args: args{
+				propName:  "labels",
+				propValue: ut.SM{"wizard_next": "Tovább"},
+			},
+			want: ut.SM{"wizard_next": "Tovább"},
+		},
+		{
+			name: "target",
+			args: args{
+				propName:  "target",
+				propValue: "#target",
+			},
+			want: "#target",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wiz := &Wizard{}
+			if got := wiz.SetProperty(tt.args.propName, tt.args.propValue); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Wizard.SetProperty() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func testWizardSteps() []WizardStep {
+	return []WizardStep{
+		{
+			Name: "first", Title: "First",
+			Rows: []Row{
+				testWizardRow("Name", Field{Type: FieldTypeString, Value: ut.IM{"name": "name", "required": true}}),
+				testWizardRow("Skip", Field{Type: FieldTypeBool, Value: ut.IM{"name": "skip"}}),
+				testWizardRow("Info", Field{Type: FieldTypeLabel, Value: ut.IM{"value": "Info"}}),
+			},
+		},
+		{
+			Name: "second", Title: "Second",
+			Skip: func(values ut.IM) bool {
+				return values["skip"] == true
+			},
+			Validate: func(values ut.IM) string {
+				if values["code"] != "ok" {
+					return "invalid code"
+				}
+				return ""
+			},
+			Rows: []Row{
+				testWizardRow("Code", Field{Type: FieldTypeString, Value: ut.IM{"name": "code"}}),
+			},
+		},
+		{
+			Name: "third", Title: "Third",
+		},
+	}
+}
+
+func TestWizard_OnRequest(t *testing.T) {
+	tests := []struct {
+		name       string
+		steps      []WizardStep
+		step       int64
+		values     ut.IM
+		onResponse func(evt ResponseEvent) (re ResponseEvent)
+		request    url.Values
+		wantName   string
+		wantStep   int64
+		wantValues ut.IM
+		wantError  string
+	}{
+		{
+			name:       "cancel",
+			steps:      testWizardSteps(),
+			request:    url.Values{"name": []string{"Name"}, WizardEventCancel: []string{WizardEventCancel}},
+			wantName:   WizardEventCancel,
+			wantValues: ut.IM{},
+		},
+		{
+			name:       "empty",
+			request:    url.Values{WizardEventNext: []string{WizardEventNext}},
+			wantName:   WizardEventCancel,
+			wantValues: ut.IM{},
+		},
+		{
+			name:       "required",
+			steps:      testWizardSteps(),
+			request:    url.Values{"name": []string{""}, WizardEventNext: []string{WizardEventNext}},
+			wantName:   WizardEventInvalid,
+			wantValues: ut.IM{"name": "", "skip": false},
+			wantError:  wizardDefaultLabel["wizard_required"],
+		},
+		{
+			name:       "next",
+			steps:      testWizardSteps(),
+			request:    url.Values{"name": []string{"Name"}, WizardEventNext: []string{WizardEventNext}},
+			wantName:   WizardEventNext,
+			wantStep:   1,
+			wantValues: ut.IM{"name": "Name", "skip": false},
+		},
+		{
+			name:       "next_skip",
+			steps:      testWizardSteps(),
+			request:    url.Values{"name": []string{"Name"}, "skip": []string{"false"}, WizardEventNext: []string{WizardEventNext}},
+			wantName:   WizardEventNext,
+			wantStep:   2,
+			wantValues: ut.IM{"name": "Name", "skip": true},
+		},
+		{
+			name:       "validate",
+			steps:      testWizardSteps(),
+			step:       1,
+			values:     ut.IM{"name": "Name", "skip": false},
+			request:    url.Values{"code": []string{"error"}, WizardEventNext: []string{WizardEventNext}},
+			wantName:   WizardEventInvalid,
+			wantStep:   1,
+			wantValues: ut.IM{"name": "Name", "skip": false, "code": "error"},
+			wantError:  "invalid code",
+		},
+		{
+			name:       "back",
+			steps:      testWizardSteps(),
+			step:       1,
+			values:     ut.IM{"name": "Name", "skip": false, "code": "ok"},
+			request:    url.Values{"code": []string{"error"}, WizardEventBack: []string{WizardEventBack}},
+			wantName:   WizardEventBack,
+			wantValues: ut.IM{"name": "Name", "skip": false, "code": "error"},
+		},
+		{
+			name:       "back_first",
+			steps:      testWizardSteps(),
+			request:    url.Values{WizardEventBack: []string{WizardEventBack}},
+			wantName:   WizardEventBack,
+			wantValues: ut.IM{"skip": false},
+		},
+		{
+			name:       "finish",
+			steps:      testWizardSteps(),
+			step:       2,
+			values:     ut.IM{"name": "Name", "skip": true},
+			request:    url.Values{WizardEventFinish: []string{WizardEventFinish}},
+			wantName:   WizardEventFinish,
+			wantStep:   2,
+			wantValues: ut.IM{"name": "Name", "skip": true},
+		},
+		{
+			name:  "response",
+			steps: testWizardSteps(),
+			step:  2,
+			onResponse: func(evt ResponseEvent) (re ResponseEvent) {
+				evt.Name = "response"
+				return evt
+			},
+			request:    url.Values{WizardEventFinish: []string{WizardEventFinish}},
+			wantName:   "response",
+			wantStep:   2,
+			wantValues: ut.IM{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wiz := &Wizard{
+				BaseComponent: BaseComponent{Id: "wiz", OnResponse: tt.onResponse, Data: ut.IM{"tag": "wizard"}},
+				Steps:         tt.steps, Step: tt.step, Values: ut.MergeIM(ut.IM{}, tt.values),
+				Labels: wizardDefaultLabel,
+			}
+			evt := wiz.OnRequest(TriggerEvent{Id: "wiz_form", Values: tt.request})
+			if evt.Name != tt.wantName {
+				t.Errorf("Wizard.OnRequest() name = %v, want %v", evt.Name, tt.wantName)
+			}
+			if wiz.Step != tt.wantStep || wiz.Error != tt.wantError {
+				t.Errorf("Wizard.OnRequest() step = %v, error = %v", wiz.Step, wiz.Error)
+			}
+			value := ut.ToIM(evt.Value, ut.IM{})
+			if !reflect.DeepEqual(value["values"], tt.wantValues) || !reflect.DeepEqual(value["data"], ut.IM{"tag": "wizard"}) {
+				t.Errorf("Wizard.OnRequest() value = %v, want %v", value, tt.wantValues)
+			}
+		})
+	}
+}
+
+func TestWizard_Render(t *testing.T) {
+	tests := []struct {
+		name   string
+		wizard Wizard
+		want   []string
+		skip   []string
+	}{
+		{
+			name: "first",
+			wizard: Wizard{
+				BaseComponent: BaseComponent{Id: "wiz", EventURL: "/event", Class: []string{"onboarding"}},
+				Title:         "Onboarding", Steps: testWizardSteps(), Values: ut.IM{"name": "Name"},
+			},
+			want: []string{
+				`class="row full wizard onboarding"`, `id="wiz_form"`, `hx-post="/event"`, "1 / 3",
+				`class="wizard-step current" aria-current="step"`, `value="Name"`, `name="wizard_next"`,
+			},
+			skip: []string{`name="wizard_back"`, `name="wizard_finish"`, "wizard-error", `class="modal"`},
+		},
+		{
+			name: "skipped_invalid",
+			wizard: Wizard{
+				BaseComponent: BaseComponent{Id: "wiz", EventURL: "/event", Style: ut.SM{"width": "400px"}},
+				Steps:         testWizardSteps(), Step: 0, Values: ut.IM{"skip": true}, Error: "error",
+				Labels: ut.SM{"wizard_next": "Tovább"},
+			},
+			want: []string{"1 / 2", `class="wizard-error"`, "invalid", `style="width:400px;"`, "Tovább", "wizard_cancel"},
+			skip: []string{"Second"},
+		},
+		{
+			name: "modal_last",
+			wizard: Wizard{
+				BaseComponent: BaseComponent{Id: "wiz", EventURL: "/event", Style: ut.SM{"width": "400px"}},
+				Steps:         testWizardSteps(), Step: 2, Modal: true,
+			},
+			want: []string{
+				`class="modal"`, `class="dialog"`, `style="width:400px;"`, "close-icon", "3 / 3",
+				`class="wizard-step done"`, `name="wizard_back"`, `name="wizard_finish"`,
+			},
+			skip: []string{`name="wizard_next"`, `class="editor" style`},
+		},
+		{
+			name:   "static",
+			wizard: Wizard{},
+			want:   []string{"0 / 0"},
+			skip:   []string{"hx-post", "wizard-step "},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			html, err := tt.wizard.Render()
+			if err != nil {
+				t.Fatalf("Wizard.Render() error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(string(html), want) {
+					t.Errorf("Wizard.Render() = %v, want %v", html, want)
+				}
+			}
+			for _, skip := range tt.skip {
+				if strings.Contains(string(html), skip) {
+					t.Errorf("Wizard.Render() = %v, skip %v", html, skip)
+				}
+			}
+			if tt.wizard.EventURL != "" && tt.wizard.RequestMap["wiz_form"] != &tt.wizard {
+				t.Errorf("Wizard.Render() request_map = %v", tt.wizard.RequestMap)
+			}
+		})
+	}
+}
+
+func TestWizard_closeIcon(t *testing.T) {
+	wiz := &Wizard{
+		BaseComponent: BaseComponent{Id: "wiz", EventURL: "/event"},
+		Steps:         testWizardSteps(), Modal: true,
+	}
+	if _, err := wiz.Render(); err != nil {
+		t.Fatal(err)
+	}
+	evt := wiz.RequestMap["wiz_btn_close"].OnRequest(TriggerEvent{Id: "wiz_btn_close"})
+	if evt.Name != WizardEventCancel || evt.Trigger != wiz {
+		t.Errorf("Wizard close icon = %v", evt)
+	}
+}
@@ -40,6 +40,7 @@ import (
 	"io/fs"
 	"log"
 	"net/http"
+	"net/url"
 	"os"
 	"time"
 
@@ -91,6 +92,7 @@ type App struct {
 	osMkdir     func(name string, perm fs.FileMode) error
 	osCreate    func(name string) (*os.File, error)
 	osReadFile  func(name string) ([]byte, error)
+	sseHub      *ct.SSEHub
 }
 
 // It creates a new application and starts an http server.
@@ -104,6 +106,7 @@ func New(version string, httpPort int64) {
 		osCreate:   os.Create,
 		osReadFile: os.ReadFile,
 	}
+	app.sseHub = &ct.SSEHub{SessionID: app.sseSessionID}
 	app.saveSession = app.SaveFileSession
 	app.loadSession = app.LoadFileSession
 	if len(sql.Drivers()) > 0 {
@@ -114,11 +117,30 @@ func New(version string, httpPort int64) {
 		app.saveSession = app.SaveDbSession
 		app.loadSession = app.LoadDbSession
 	}
+	server := &http.Server{
+		Handler:      app.routes(),
+		Addr:         fmt.Sprintf(":%d", httpPort),
+		ReadTimeout:  time.Duration(httpReadTimeout) * time.Second,
+		WriteTimeout: time.Duration(httpWriteTimeout) * time.Second,
+	}
+
+	app.infoLog.Printf("HTTP server serving at: %d. \n", httpPort)
+	if err := server.ListenAndServe(); err != nil {
+		app.infoLog.Printf("server error: %s\n", err)
+	}
+}
+
+// Returns the http handler of the API routes and the static files
+func (app *App) routes() *http.ServeMux {
 	mux := http.NewServeMux()
 	// Register API routes.
 	mux.HandleFunc("/", app.HomeRoute)
 	mux.HandleFunc("/session", app.HomeRoute)
 	mux.HandleFunc("POST /event", app.AppEvent)
+	// generated css variables of the registered themes
+	mux.HandleFunc("GET /theme.css", ct.ThemeHandler)
+	// server-sent events of the sessions
+	mux.Handle("GET /sse", app.sseHub)
 
 	// Register static dirs.
 	// app (demo component) css files
@@ -127,18 +149,19 @@ func New(version string, httpPort int64) {
 	var staticFS, _ = fs.Sub(st.Static, ".")
 	mux.Handle("/public/", http.StripPrefix("/public/", http.FileServer(http.FS(publicFS))))
 	mux.Handle("/static/", http.StripPrefix("/static/", http.FileServer(http.FS(staticFS))))
+	return mux
+}
 
-	server := &http.Server{
-		Handler:      mux,
-		Addr:         fmt.Sprintf(":%d", httpPort),
-		ReadTimeout:  time.Duration(httpReadTimeout) * time.Second,
-		WriteTimeout: time.Duration(httpWriteTimeout) * time.Second,
-	}
+// Counts the written bytes of the streamed response
+type responseCounter struct {
+	io.Writer
+	size int
+}
 
-	app.infoLog.Printf("HTTP server serving at: %d. \n", httpPort)
-	if err := server.ListenAndServe(); err != nil {
-		app.infoLog.Printf("server error: %s\n", err)
-	}
+func (rc *responseCounter) Write(p []byte) (n int, err error) {
+	n, err = rc.Writer.Write(p)
+	rc.size += n
+	return n, err
 }
 
 func (app *App) respondMessage(w http.ResponseWriter, html template.HTML, err error) {
@@ -150,6 +173,11 @@ func (app *App) respondMessage(w http.ResponseWriter, html template.HTML, err er
 	w.Write([]byte(html))
 }
 
+// The event stream session identifier is the same as the session identifier of the [App.AppEvent]
+func (app *App) sseSessionID(r *http.Request) string {
+	return base64.StdEncoding.EncodeToString([]byte(r.URL.Query().Get("session")))
+}
+
 // Creates and returns an Application/[Demo] component.
 // It stores the state of the component in memory or in a session file
 func (app *App) HomeRoute(w http.ResponseWriter, r *http.Request) {
@@ -163,13 +191,19 @@ func (app *App) HomeRoute(w http.ResponseWriter, r *http.Request) {
 		Script: []string{
 			"static/js/htmx.min.js",
 			"static/js/remove-me.js",
+			"static/js/sse.js",
 		},
 		HeadLink: []ct.HeadLink{
 			{Rel: "icon", Href: "/static/favicon.svg", Type: "image/svg+xml"},
 			{Rel: "stylesheet", Href: "/public/demo.css"},
 			{Rel: "stylesheet", Href: "/static/css/index.css"},
+			{Rel: "stylesheet", Href: "/theme.css"},
 		},
 		MainComponent: demo,
+		IconSprite:    true,
+	}
+	if app.sseHub != nil {
+		ccApp.SSEConnect = "/sse?session=" + url.QueryEscape(tokenID)
 	}
 	var err error
 	var html template.HTML
@@ -187,7 +221,6 @@ func (app *App) HomeRoute(w http.ResponseWriter, r *http.Request) {
 // Loads the Demo component based on the X-Session-Token identifier.
 func (app *App) AppEvent(w http.ResponseWriter, r *http.Request) {
 	var err error
-	var html template.HTML
 	var evt ct.ResponseEvent
 	var demo *Demo
 
@@ -201,25 +234,12 @@ func (app *App) AppEvent(w http.ResponseWriter, r *http.Request) {
 	}
 	switch strings.Split(r.Header.Get("Content-Type"), ";")[0] {
 	case "multipart/form-data":
-		// File upload handling
-		//var file multipart.File
-		//var handler *multipart.FileHeader
-		//var dst *os.File
-		// Parse request body as multipart form data with 32MB max memory
+		// File upload handling. Parse request body as multipart form data with 32MB max memory
 		if err = r.ParseMultipartForm(32 << 20); err == nil {
-			// Get file from Form
-			_, _, err = r.FormFile("file")
-			/*
-				if file, _, err = r.FormFile("file"); err == nil {
-					// Create file locally
-					if dst, err = os.Create(handler.Filename); err == nil {
-						// Copy the uploaded file data to the newly created file on the filesystem
-						_, err = io.Copy(dst, file)
-					}
-					defer dst.Close()
-				}
-				defer file.Close()
-			*/
+			defer r.MultipartForm.RemoveAll()
+			te.Values = r.MultipartForm.Value
+			te.Files, err = ct.FormFiles(r.MultipartForm, "file")
+			defer ct.CloseFiles(te.Files)
 		}
 	case "application/x-www-form-urlencoded":
 		if err = r.ParseForm(); err == nil {
@@ -235,32 +255,40 @@ func (app *App) AppEvent(w http.ResponseWriter, r *http.Request) {
 			evt = mem.OnRequest(te)
 		} else if dataSave {
 			if err = app.loadSession(sessionID, &demo); err == nil {
-				demo.DemoMap = DemoMap
-				demo.RequestMap = map[string]ct.ClientComponent{}
-				demo.InitDemoMap()
-				_, err = demo.Render()
-				if err == nil {
-					evt = demo.OnRequest(te)
-				}
+				evt = demo.OnRequest(te)
 			}
 		}
+		if evt.Trigger == nil && len(evt.OOB) > 0 {
+			// only the out-of-band components are swapped
+			w.Header().Set(ct.HeaderReswap, ct.SwapNone)
+		}
 		for key, value := range evt.Header {
 			w.Header().Set(key, value)
 		}
-		if evt.Trigger != nil {
-			html, err = evt.Trigger.Render()
-		} else {
+		if evt.Trigger == nil && len(evt.OOB) == 0 {
 			err = errors.New("missing component")
 		}
 	}
 
+	if err == nil {
+		// The component and the out-of-band components are streamed directly into the response
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		rc := &responseCounter{Writer: w}
+		if err = evt.RenderTo(rc); err != nil && rc.size > 0 {
+			// The response has already been started, the error message cannot be sent
+			app.infoLog.Printf("render error: %s\n", err)
+			err = nil
+		}
+	}
+
 	if err != nil {
-		html, _ = (&ct.Toast{
+		html, _ := (&ct.Toast{
 			Type: ct.ToastTypeError, Value: err.Error(),
 		}).Render()
+		app.respondMessage(w, html, nil)
+		return
 	}
-	if dataSave && (err == nil) {
+	if dataSave {
 		app.saveSession(sessionID, demo)
 	}
-	app.respondMessage(w, html, nil)
 }
@@ -3,7 +3,6 @@ package demo
 import (
 	"bytes"
 	"encoding/base64"
-	"encoding/json"
 	"errors"
 	"html/template"
 	"io"
@@ -16,6 +15,8 @@ import (
 	"strings"
 	"testing"
 
+	ct "github.com/nervatura/component/pkg/component"
+	"github.com/nervatura/component/test/htmxtest"
 	_ "github.com/nervatura/component/test/sqltest"
 )
 
@@ -30,6 +31,7 @@ func TestApp_HomeRoute(t *testing.T) {
 		osMkdir     func(name string, perm fs.FileMode) error
 		osCreate    func(name string) (*os.File, error)
 		osReadFile  func(name string) ([]byte, error)
+		sseHub      *ct.SSEHub
 	}
 	type args struct {
 		w http.ResponseWriter
@@ -69,6 +71,7 @@ func TestApp_HomeRoute(t *testing.T) {
 			name: "ok_mem",
 			fields: fields{
 				memSession: map[string]*Demo{},
+				sseHub:     &ct.SSEHub{},
 			},
 			args: args{
 				w: httptest.NewRecorder(),
@@ -88,12 +91,21 @@ func TestApp_HomeRoute(t *testing.T) {
 				osMkdir:     tt.fields.osMkdir,
 				osCreate:    tt.fields.osCreate,
 				osReadFile:  tt.fields.osReadFile,
+				sseHub:      tt.fields.sseHub,
 			}
 			app.HomeRoute(tt.args.w, tt.args.r)
 		})
 	}
 }
 
+func TestApp_sseSessionID(t *testing.T) {
+	app := &App{}
+	r := httptest.NewRequest("GET", "/sse?session=SessionID", nil)
+	if sessionID := app.sseSessionID(r); sessionID != base64.StdEncoding.EncodeToString([]byte("SessionID")) {
+		t.Errorf("App.sseSessionID() = %v", sessionID)
+	}
+}
+
 func TestApp_AppEvent(t *testing.T) {
 	type fields struct {
 		version     string
@@ -170,7 +182,7 @@ func TestApp_AppEvent(t *testing.T) {
 					return os.NewFile(0, name), nil
 				},
 				osReadFile: func(name string) ([]byte, error) {
-					app, _ := json.Marshal(demoApp)
+					app, _ := ct.Marshal(NewDemo("/event", "Demo"))
 					return []byte(app), nil
 				},
 				loadSession: func(name string, data any) (err error) {
@@ -295,6 +307,71 @@ func TestApp_AppEvent(t *testing.T) {
 	}
 }
 
+type testErrorComponent struct {
+	ct.BaseComponent
+}
+
+func (tec *testErrorComponent) Render() (template.HTML, error) {
+	return "", errors.New("error")
+}
+
+func TestApp_AppEvent_stream(t *testing.T) {
+	sessionID := base64.StdEncoding.EncodeToString([]byte("SessionID"))
+	response := func(trigger ct.ClientComponent, oob []ct.OOBComponent) func(evt ct.ResponseEvent) ct.ResponseEvent {
+		return func(evt ct.ResponseEvent) ct.ResponseEvent {
+			return ct.ResponseEvent{Trigger: trigger, OOB: oob}
+		}
+	}
+	tests := []struct {
+		name     string
+		trigger  ct.ClientComponent
+		oob      []ct.OOBComponent
+		want     string
+		wantLog  string
+		wantSave bool
+	}{
+		{name: "stream", trigger: &ct.Label{Value: "label"}, want: "label", wantSave: true},
+		{name: "render_error", trigger: &testErrorComponent{}, want: "toast"},
+		{name: "stream_error", trigger: &ct.Application{MainComponent: &testErrorComponent{}}, want: "<!DOCTYPE html>",
+			wantLog: "render error: template: application", wantSave: true},
+		{name: "oob", oob: []ct.OOBComponent{ct.OOBToast(ct.ToastTypeInfo, "info", 0)}, want: `hx-swap-oob="innerHTML:#toast-msg"`, wantSave: true},
+		{name: "missing", want: "missing component"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logs bytes.Buffer
+			saved := false
+			app := &App{
+				infoLog: log.New(&logs, "", 0),
+				saveSession: func(name string, data any) (err error) {
+					saved = true
+					return nil
+				},
+				memSession: map[string]*Demo{
+					sessionID: {BaseComponent: ct.BaseComponent{
+						RequestMap: map[string]ct.ClientComponent{
+							"stream": &ct.BaseComponent{OnResponse: response(tt.trigger, tt.oob)},
+						},
+					}},
+				},
+			}
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", "/event", nil)
+			r.Header.Set("X-Session-Token", "SessionID")
+			r.Header.Set("HX-Trigger", "stream")
+			r.Header.Set("Hx-Current-Url", "/session")
+			r.Header.Set("Content-Type", "application/json")
+			app.AppEvent(w, r)
+			if !strings.Contains(w.Body.String(), tt.want) {
+				t.Errorf("App.AppEvent() = %v", w.Body.String())
+			}
+			if !strings.Contains(logs.String(), tt.wantLog) || saved != tt.wantSave {
+				t.Errorf("App.AppEvent() log = %v, saved = %v", logs.String(), saved)
+			}
+		})
+	}
+}
+
 func TestNew(t *testing.T) {
 	type args struct {
 		version  string
@@ -362,3 +439,77 @@ func TestApp_respondMessage(t *testing.T) {
 		})
 	}
 }
+
+func TestApp_routes(t *testing.T) {
+	app := &App{memSession: make(map[string]*Demo), sseHub: &ct.SSEHub{}}
+	client := htmxtest.NewClient(app.routes())
+	if err := client.Get("/"); err != nil {
+		t.Fatal(err)
+	}
+	group := client.ByName("selected_group")
+	if group == nil {
+		t.Fatal("missing selected_group")
+	}
+	demoId := strings.TrimSuffix(group.GetAttr("id"), "_selected_group")
+
+	if err := client.Change(demoId+"_selected_group", ComponentGroupMolecule); err != nil {
+		t.Fatal(err)
+	}
+	if value := client.ByID(demoId + "_selected_group").Value(); value != ComponentGroupMolecule {
+		t.Errorf("selected_group = %s", value)
+	}
+	if options := client.ByID(demoId + "_selected_type").ByTag("option"); options[0].TextContent() != ct.ComponentTypeTable {
+		t.Errorf("selected_type = %s", options[0].TextContent())
+	}
+
+	theme := client.ByID(demoId).GetAttr("theme")
+	if err := client.Click(demoId + "_theme"); err != nil {
+		t.Fatal(err)
+	}
+	if value := client.ByID(demoId).GetAttr("theme"); value != ct.ThemeNext(theme) {
+		t.Errorf("theme = %s", value)
+	}
+
+	// unknown component id: the error message is retargeted to the toast
+	client.ByID(demoId+"_view_size").SetAttr("id", "unknown")
+	if err := client.Click("unknown"); err != nil {
+		t.Fatal(err)
+	}
+	if msg := client.ByID("toast-msg").TextContent(); !strings.Contains(msg, "Invalid parameter: unknown") {
+		t.Errorf("toast = %s", msg)
+	}
+}
+
+func TestApp_AppEvent_session(t *testing.T) {
+	demo := NewDemo("/event", "Demo")
+	session, _ := marshalSession(demo)
+	if strings.Contains(string(session), "demo_map") {
+		t.Error("marshalSession() the example components are stored")
+	}
+	var saved *Demo
+	app := &App{
+		memSession: map[string]*Demo{},
+		loadSession: func(name string, data any) (err error) {
+			return ct.Unmarshal(session, data)
+		},
+		saveSession: func(name string, data any) (err error) {
+			saved = data.(*Demo)
+			return nil
+		},
+	}
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/event", nil)
+	r.Header.Set("X-Session-Token", "SessionID")
+	r.Header.Set("Hx-Current-Url", "/session")
+	r.Header.Set("HX-Trigger", demo.Id+"_theme")
+	r.Header.Set("Content-Type", "application/json")
+	app.AppEvent(w, r)
+	if saved == nil {
+		t.Fatalf("App.AppEvent() = %v", w.Body.String())
+	}
+	// the restored session gets the example components with their event handlers
+	cc := saved.DemoMap[saved.SelectedGroup][saved.SelectedType].Session[saved.SelectedDemo].Component
+	if btn, valid := cc.(*ct.Button); !valid || btn.OnResponse == nil {
+		t.Errorf("App.AppEvent() session component = %v", cc)
+	}
+}
@@ -3,6 +3,7 @@ package demo
 import (
 	"fmt"
 	"html/template"
+	"io"
 	"strings"
 
 	ct "github.com/nervatura/component/pkg/component"
@@ -22,15 +23,22 @@ const (
 
 	ViewSizeCentered = "centered"
 	ViewSizeFull     = "full"
+
+	ComponentTypeDemo = "demo"
 )
 
+func init() {
+	// the session component tree can be restored by the ct.Unmarshal function
+	ct.RegisterComponent(ComponentTypeDemo, &Demo{})
+}
+
 type Demo struct {
 	ct.BaseComponent
 	// Application title
 	Title string `json:"title"`
 	/*
 		The theme of the application.
-		[Theme] variable constants: [ThemeLight], [ThemeDark].
+		Any registered [Theme] value, see more [RegisterTheme].
 		Default value: [ThemeLight]
 	*/
 	Theme string `json:"theme"`
@@ -88,7 +96,10 @@ var DemoMap map[string][]DemoView = map[string][]DemoView{
 		{ComponentType: ct.ComponentTypeToast, TestData: ct.TestToast},
 		{ComponentType: ct.ComponentTypeToggle, TestData: ct.TestToggle},
 		{ComponentType: ct.ComponentTypeUpload, TestData: ct.TestUpload},
+		{ComponentType: ct.ComponentTypeRichText, TestData: ct.TestRichText},
+		{ComponentType: ct.ComponentTypeMarkdown, TestData: ct.TestMarkdown},
 		{ComponentType: ct.ComponentTypeSelector, TestData: ct.TestSelector},
+		{ComponentType: ct.ComponentTypeAutocomplete, TestData: ct.TestAutocomplete},
 		{ComponentType: ct.ComponentTypeRow, TestData: ct.TestRow},
 	},
 	ComponentGroupMolecule: {
@@ -97,19 +108,25 @@ var DemoMap map[string][]DemoView = map[string][]DemoView{
 		{ComponentType: ct.ComponentTypeMenuBar, TestData: ct.TestMenuBar},
 		{ComponentType: ct.ComponentTypePagination, TestData: ct.TestPagination},
 		{ComponentType: ct.ComponentTypeSideBar, TestData: ct.TestSidebar},
+		{ComponentType: ct.ComponentTypeChart, TestData: ct.TestChart},
+		{ComponentType: ct.ComponentTypeCalendar, TestData: ct.TestCalendar},
+		{ComponentType: ct.ComponentTypeTreeView, TestData: ct.TestTreeView},
+		{ComponentType: ct.ComponentTypeKanban, TestData: ct.TestKanban},
 	},
 	ComponentGroupTemplate: {
 		{ComponentType: ct.ComponentTypeLogin, TestData: ct.TestLogin},
 		{ComponentType: ct.ComponentTypeBrowser, TestData: ct.TestBrowser},
 		{ComponentType: ct.ComponentTypeForm, TestData: ct.TestForm},
+		{ComponentType: ct.ComponentTypeWizard, TestData: ct.TestWizard},
 		{ComponentType: ct.ComponentTypeEditor, TestData: ct.TestEditor},
 		{ComponentType: ct.ComponentTypeSearch, TestData: ct.TestSearch},
 		{ComponentType: ct.ComponentTypeClient, TestData: ct.TestClient},
+		{ComponentType: ct.ComponentTypeDashboard, TestData: ct.TestDashboard},
+		{ComponentType: ct.ComponentTypeCommandPalette, TestData: ct.TestCommandPalette},
 	},
 }
 
 var testIcoMap map[string][]string = map[string][]string{
-	ct.ThemeDark: {ct.ThemeLight, "Sun"}, ct.ThemeLight: {ct.ThemeDark, "Moon"},
 	ViewSizeCentered: {ViewSizeFull, "Desktop"}, ViewSizeFull: {ViewSizeCentered, "Mobile"},
 }
 
@@ -141,6 +158,18 @@ func (sto *Demo) InitDemoMap() {
 	}
 }
 
+/*
+The Restore function is called by the ct.Unmarshal function after the session has been loaded. The example
+components and their event handlers are not stored in the session, so the DemoMap is rebuilt and the
+RequestMap is rebound by rendering the restored component tree.
+*/
+func (sto *Demo) Restore() (err error) {
+	sto.DemoMap = DemoMap
+	sto.InitDemoMap()
+	_, err = sto.Render()
+	return err
+}
+
 /*
 Returns all properties of the [Demo]
 */
@@ -287,7 +316,7 @@ func (sto *Demo) response(evt ct.ResponseEvent) (re ct.ResponseEvent) {
 	switch evt.TriggerName {
 	case "theme":
 		stoEvt.Name = DemoEventTheme
-		value = sto.SetProperty("theme", testIcoMap[sto.Theme][0])
+		value = sto.SetProperty("theme", ct.ThemeNext(sto.Theme))
 
 	case "view_size":
 		stoEvt.Name = DemoEventViewSize
@@ -311,6 +340,10 @@ func (sto *Demo) getComponent(name string) (html template.HTML, err error) {
 		"selected_demo": ut.ToString(sto.SelectedDemo, ""),
 	}
 	ccBtn := func() *ct.Button {
+		icon := ct.ThemeIcon(ct.ThemeNext(sto.Theme))
+		if name == "view_size" {
+			icon = testIcoMap[propValue[name]][1]
+		}
 		return &ct.Button{
 			BaseComponent: ct.BaseComponent{
 				Id: sto.Id + "_" + name, Name: name,
@@ -322,7 +355,7 @@ func (sto *Demo) getComponent(name string) (html template.HTML, err error) {
 				RequestMap:   sto.RequestMap,
 			},
 			ButtonStyle:    ct.ButtonStylePrimary,
-			LabelComponent: &ct.Icon{Value: testIcoMap[propValue[name]][1], Width: 18, Height: 18},
+			LabelComponent: &ct.Icon{Value: icon, Width: 18, Height: 18},
 		}
 	}
 	ccSel := func() *ct.Select {
@@ -376,62 +409,80 @@ func (sto *Demo) getComponent(name string) (html template.HTML, err error) {
 Based on the values, it will generate the html code of the [Demo] or return with an error message.
 */
 func (sto *Demo) Render() (html template.HTML, err error) {
+	return ct.RenderHTML(sto)
+}
+
+// the render data of the [Demo] template
+type demoRender struct {
+	*Demo
+	hw *ut.HTMLWriter
+}
+
+// [Demo] template functions
+var demoFuncMap = map[string]any{
+	"styleMap": func(sto *demoRender) bool {
+		return len(sto.Style) > 0
+	},
+	"customClass": func(sto *demoRender) string {
+		return strings.Join(sto.Class, " ")
+	},
+	"demoComponent": func(sto *demoRender, name string) (template.HTML, error) {
+		return sto.getComponent(name)
+	},
+	"label": func(value string) (template.HTML, error) {
+		return (&ct.Label{
+			BaseComponent: ct.BaseComponent{Style: ut.SM{"color": "brown"}},
+			Value:         value,
+		}).Render()
+	},
+	"clientComponent": func(sto *demoRender, cc ct.ClientComponent) (template.HTML, error) {
+		return "", ct.RenderTo(sto.hw, cc)
+	},
+	"stories": func(sto *demoRender) []DemoSession {
+		return sto.DemoMap[sto.SelectedGroup][sto.SelectedType].Session
+	},
+	"demo": func(sto *demoRender) DemoSession {
+		return sto.DemoMap[sto.SelectedGroup][sto.SelectedType].Session[sto.SelectedDemo]
+	},
+}
+
+/*
+Based on the values, it will write the html code of the [Demo] into the writer or return with an error message.
+*/
+func (sto *Demo) RenderTo(w io.Writer) (err error) {
 	sto.InitProps(sto)
+	hw := ut.NewHTMLWriter(w)
 
-	funcMap := map[string]any{
-		"styleMap": func() bool {
-			return len(sto.Style) > 0
-		},
-		"customClass": func() string {
-			return strings.Join(sto.Class, " ")
-		},
-		"demoComponent": func(name string) (template.HTML, error) {
-			return sto.getComponent(name)
-		},
-		"label": func(value string) (template.HTML, error) {
-			return (&ct.Label{
-				BaseComponent: ct.BaseComponent{Style: ut.SM{"color": "brown"}},
-				Value:         value,
-			}).Render()
-		},
-		"clientComponent": func(cc ct.ClientComponent) (template.HTML, error) {
-			res, err := cc.Render()
-			return res, err
-		},
-		"stories": func() []DemoSession {
-			return sto.DemoMap[sto.SelectedGroup][sto.SelectedType].Session
-		},
-		"demo": func() DemoSession {
-			return sto.DemoMap[sto.SelectedGroup][sto.SelectedType].Session[sto.SelectedDemo]
-		},
-	}
-	tpl := `<div id="{{ .Id }}" theme="{{ .Theme }}" class="demo row mobile {{ .ViewSize }} {{ customClass }}"
-	{{ if styleMap }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}>
+	tpl := `<div id="{{ .Id }}" theme="{{ .Theme }}" class="demo row mobile {{ .ViewSize }} {{ customClass $ }}"
+	{{ if styleMap $ }} style="{{ range $key, $value := .Style }}{{ $key }}:{{ $value }};{{ end }}"{{ end }}>
 	<div class="menubar">
 	<div class="section-small">
 	<div class="cell menu-label padding-small bold">{{ .Title }}</div>
 	<div class="cell mobile">
-	<div class="cell padding-tiny">{{ demoComponent "theme" }}</div>
-	<div class="cell padding-tiny">{{ demoComponent "view_size" }}</div>
-	<div class="cell padding-tiny">{{ demoComponent "selected_group" }}</div>
-	<div class="cell padding-tiny">{{ demoComponent "selected_type" }}</div>
+	<div class="cell padding-tiny">{{ demoComponent $ "theme" }}</div>
+	<div class="cell padding-tiny">{{ demoComponent $ "view_size" }}</div>
+	<div class="cell padding-tiny">{{ demoComponent $ "selected_group" }}</div>
+	<div class="cell padding-tiny">{{ demoComponent $ "selected_type" }}</div>
 	</div>
 	{{ if ne $.SelectedGroup "atom" }}
-	<div class="cell padding-tiny mobile">{{ demoComponent "selected_demo" }}</div>
+	<div class="cell padding-tiny mobile">{{ demoComponent $ "selected_demo" }}</div>
 	{{ end }}
 	</div></div>
 	<div class="row full section">
 	{{ if eq $.SelectedGroup "atom" }}
-	{{ range $index, $se := stories }}
+	{{ range $index, $se := stories $ }}
 	<div class="row full"><div class="cell bold italic padding-normal">{{ label $se.Label }}</div></div>
-	<div class="row full"><div class="cell padding-normal">{{ clientComponent $se.Component }}</div></div>
+	<div class="row full"><div class="cell padding-normal">{{ clientComponent $ $se.Component }}</div></div>
 	{{ end }}
 	{{ else }}
-	{{ $st := demo }}
+	{{ $st := demo $ }}
 	<div class="row full"><div class="cell bold italic padding-normal">{{ label $st.Label }}</div></div>
-	<div class="row full"><div class="cell padding-normal">{{ clientComponent $st.Component }}</div></div>
+	<div class="row full"><div class="cell padding-normal">{{ clientComponent $ $st.Component }}</div></div>
 	{{ end }}
 	</div></div>`
 
-	return ut.TemplateBuilder("demo", tpl, funcMap, sto)
+	if err = ut.CachedTemplateWriter(hw, "demo", tpl, demoFuncMap, &demoRender{Demo: sto, hw: hw}); err == nil {
+		err = hw.Close()
+	}
+	return err
 }
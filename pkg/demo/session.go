@@ -8,9 +8,18 @@ import (
 	"os"
 	"time"
 
+	ct "github.com/nervatura/component/pkg/component"
 	ut "github.com/nervatura/component/pkg/util"
 )
 
+// The component trees are encoded with their component types, and they can be restored by ct.Unmarshal.
+func marshalSession(data any) ([]byte, error) {
+	if cc, valid := data.(ct.ClientComponent); valid {
+		return ct.Marshal(cc)
+	}
+	return json.Marshal(data)
+}
+
 // Saving component state in a session json file.
 func (app *App) SaveFileSession(fileName string, data any) (err error) {
 	if _, err = app.osStat(sessionPath); errors.Is(err, os.ErrNotExist) {
@@ -21,7 +30,7 @@ func (app *App) SaveFileSession(fileName string, data any) (err error) {
 	filePath := fmt.Sprintf(`%s/%s.json`, sessionPath, fileName)
 	sessionFile, err := app.osCreate(filePath)
 	if err == nil {
-		bin, err := json.Marshal(data)
+		bin, err := marshalSession(data)
 		if err == nil {
 			sessionFile.Write(bin)
 		}
@@ -35,7 +44,7 @@ func (app *App) LoadFileSession(fileName string, data any) (err error) {
 	filePath := fmt.Sprintf(`%s/%s.json`, sessionPath, fileName)
 	sessionFile, err := app.osReadFile(filePath)
 	if err == nil {
-		err = json.Unmarshal(sessionFile, &data)
+		err = ct.Unmarshal(sessionFile, data)
 	}
 	return err
 }
@@ -98,7 +107,7 @@ func (app *App) SaveDbSession(sessionID string, data any) (err error) {
 	var db *sql.DB
 	if db, err = app.checkSessionTable(); err == nil {
 		var bin []byte
-		if bin, err = json.Marshal(data); err == nil {
+		if bin, err = marshalSession(data); err == nil {
 			var params []any = []any{sessionID, bin, time.Now().Unix()}
 			var sqlString string = fmt.Sprintf(
 				"INSERT INTO %s(id, value, stamp) VALUES(?, ?, ?)", sessionTable)
@@ -121,7 +130,7 @@ func (app *App) LoadDbSession(sessionID string, data any) (err error) {
 	if db, err = app.checkSessionTable(); err == nil {
 		var value string
 		if value, err = app.getSessionValue(db, sessionID); value != "" {
-			err = json.Unmarshal([]byte(value), &data)
+			err = ct.Unmarshal([]byte(value), data)
 		}
 	}
 	defer db.Close()
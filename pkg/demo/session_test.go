@@ -66,6 +66,22 @@ func TestApp_SaveFileSession(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "json",
+			fields: fields{
+				osStat: func(name string) (fs.FileInfo, error) {
+					return nil, nil
+				},
+				osCreate: func(name string) (*os.File, error) {
+					return os.NewFile(0, name), nil
+				},
+			},
+			args: args{
+				fileName: "filename",
+				data:     map[string]any{"key": "value"},
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -289,11 +305,11 @@ func TestApp_SaveDbSession(t *testing.T) {
 			name: "update",
 			fields: fields{
 				driverName: "sqltest",
-				dataSource: "test",
+				dataSource: "session",
 			},
 			args: args{
 				sessionID: "SESID",
-				data:      &Demo{},
+				data:      new(*Demo),
 			},
 			wantErr: false,
 		},
@@ -344,11 +360,11 @@ func TestApp_LoadDbSession(t *testing.T) {
 			name: "load",
 			fields: fields{
 				driverName: "sqltest",
-				dataSource: "test",
+				dataSource: "session",
 			},
 			args: args{
 				sessionID: "SESID",
-				data:      &Demo{},
+				data:      new(*Demo),
 			},
 			wantErr: false,
 		},
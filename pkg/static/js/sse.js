@@ -0,0 +1,78 @@
+// A minimal implementation of the sse-connect and sse-swap attributes of the htmx sse extension (htmx-ext-sse).
+(function() {
+  var swapSelector = '[sse-swap], [data-sse-swap]'
+
+  function getSource(elt) {
+    var parent = elt.closest('[sse-connect], [data-sse-connect]')
+    return parent ? parent['htmx-sse-source'] : null
+  }
+
+  function connect(elt, retryCount) {
+    var url = elt.getAttribute('sse-connect') || elt.getAttribute('data-sse-connect')
+    if (!url || (elt['htmx-sse-source'] && !retryCount)) {
+      return
+    }
+    // the browser reconnects automatically after a network error and sends the Last-Event-ID header
+    var source = new EventSource(url)
+    source.onopen = function() {
+      retryCount = 0
+      htmx.trigger(elt, 'htmx:sseOpen', { source: source })
+    }
+    source.onerror = function() {
+      htmx.trigger(elt, 'htmx:sseError', { source: source })
+      // the closed stream (for example an error response of the restarted server) is reopened
+      // with an exponential backoff, like the official extension does
+      if (source.readyState === EventSource.CLOSED && document.body.contains(elt)) {
+        retryCount = Math.max(Math.min(retryCount * 2, 128), 1)
+        setTimeout(function() {
+          if (elt['htmx-sse-source'] === source) {
+            connect(elt, retryCount)
+          }
+        }, retryCount * 500)
+      }
+    }
+    elt['htmx-sse-source'] = source
+    if (elt.matches(swapSelector)) {
+      listen(elt)
+    }
+    elt.querySelectorAll(swapSelector).forEach(listen)
+  }
+
+  function listen(elt) {
+    var names = elt.getAttribute('sse-swap') || elt.getAttribute('data-sse-swap')
+    var source = getSource(elt)
+    if (names && source && elt['htmx-sse-listener'] !== source) {
+      var listener = function(event) {
+        if (!document.body.contains(elt)) {
+          names.split(',').forEach(function(name) {
+            source.removeEventListener(name.trim(), listener)
+          })
+          return
+        }
+        // the hx-swap-oob elements of the message are swapped by their targets
+        htmx.swap(elt, event.data, { swapStyle: elt.getAttribute('hx-swap') || 'innerHTML' })
+      }
+      names.split(',').forEach(function(name) {
+        source.addEventListener(name.trim(), listener)
+      })
+      elt['htmx-sse-listener'] = source
+    }
+  }
+
+  htmx.defineExtension('sse', {
+    getSelectors: function() {
+      return ['[sse-connect]', '[data-sse-connect]', '[sse-swap]', '[data-sse-swap]']
+    },
+    onEvent: function(name, evt) {
+      var elt = evt.detail.elt
+      if (name === 'htmx:afterProcessNode' && elt.getAttribute) {
+        connect(elt, 0)
+        listen(elt)
+      }
+      if (name === 'htmx:beforeCleanupElement' && elt['htmx-sse-source']) {
+        elt['htmx-sse-source'].close()
+        elt['htmx-sse-source'] = null
+      }
+    }
+  })
+})()
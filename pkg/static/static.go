@@ -11,3 +11,12 @@ var JSLibs []string = []string{
 	"https://unpkg.com/htmx.org@2.0.2",
 	"https://unpkg.com/htmx-ext-remove-me@2.0.0/remove-me.js",
 }
+
+/*
+The htmx sse extension of the server-sent events. The static/js/sse.js file is not a copy of it, but a minimal
+compatible implementation of the sse-connect and sse-swap attributes (the subset used by the Application
+component) for the offline use without a CDN or npm dependency. The EventSource of the browser reconnects after
+the network errors and sends the Last-Event-ID header, and the closed streams are reopened with the same
+exponential backoff as the official extension.
+*/
+var SSELib string = "https://unpkg.com/htmx-ext-sse@2.2.2/sse.js"
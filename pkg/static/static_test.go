@@ -0,0 +1,16 @@
+package component
+
+import (
+	"os/exec"
+	"testing"
+)
+
+func TestSSEReconnect(t *testing.T) {
+	node, err := exec.LookPath("node")
+	if err != nil {
+		t.Skip("the sse.js test requires Node.js")
+	}
+	if out, err := exec.Command(node, "testdata/sse.js", "js/sse.js").CombinedOutput(); err != nil {
+		t.Errorf("sse.js reconnect error = %v\n%s", err, out)
+	}
+}
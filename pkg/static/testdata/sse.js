@@ -0,0 +1,133 @@
+// The reconnection test of the js/sse.js extension with the stubs of the EventSource, the DOM and htmx.
+// Usage: node testdata/sse.js js/sse.js
+const fs = require('fs')
+const vm = require('vm')
+
+const sources = []
+const timers = []
+const swaps = []
+const events = []
+
+class EventSource {
+  constructor(url) {
+    this.url = url
+    this.readyState = EventSource.CONNECTING
+    this.listeners = {}
+    sources.push(this)
+  }
+  addEventListener(name, listener) {
+    this.listeners[name] = (this.listeners[name] || []).concat(listener)
+  }
+  removeEventListener(name, listener) {
+    this.listeners[name] = (this.listeners[name] || []).filter(fn => fn !== listener)
+  }
+  close() {
+    this.readyState = EventSource.CLOSED
+  }
+  emit(name, data) {
+    if (this.readyState !== EventSource.CLOSED) {
+      (this.listeners[name] || []).forEach(listener => listener({ data }))
+    }
+  }
+}
+EventSource.CONNECTING = 0
+EventSource.OPEN = 1
+EventSource.CLOSED = 2
+
+function element(attrs, children = []) {
+  const elt = {
+    attrs, children, parent: null,
+    getAttribute(name) {
+      return name in this.attrs ? this.attrs[name] : null
+    },
+    matches(selector) {
+      return selector.split(',').some(attr => this.getAttribute(attr.trim().slice(1, -1)) !== null)
+    },
+    closest(selector) {
+      for (let elt = this; elt; elt = elt.parent) {
+        if (elt.matches(selector)) {
+          return elt
+        }
+      }
+      return null
+    },
+    querySelectorAll(selector) {
+      return this.children.flatMap(child => (child.matches(selector) ? [child] : []).concat(child.querySelectorAll(selector)))
+    },
+  }
+  children.forEach(child => { child.parent = elt })
+  return elt
+}
+
+const swap = element({ 'sse-swap': 'component' })
+const conn = element({ 'sse-connect': '/sse' }, [swap])
+const body = element({}, [conn])
+let extension
+
+const context = {
+  EventSource,
+  setTimeout: (fn, delay) => timers.push({ fn, delay }),
+  document: {
+    body: {
+      contains: elt => {
+        for (; elt; elt = elt.parent) {
+          if (elt === body) {
+            return true
+          }
+        }
+        return false
+      }
+    }
+  },
+  htmx: {
+    defineExtension: (name, ext) => { extension = ext },
+    trigger: (elt, name) => events.push(name),
+    swap: (elt, data) => swaps.push(data),
+  },
+}
+
+function assert(value, message) {
+  if (!value) {
+    throw new Error(message + ': ' + JSON.stringify({ sources: sources.length, timers, swaps, events }))
+  }
+}
+
+function nextTimer() {
+  assert(timers.length > 0, 'timer')
+  const timer = timers.shift()
+  timer.fn()
+  return timer.delay
+}
+
+vm.runInNewContext(fs.readFileSync(process.argv[2], 'utf8'), context)
+extension.onEvent('htmx:afterProcessNode', { detail: { elt: conn } })
+extension.onEvent('htmx:afterProcessNode', { detail: { elt: swap } })
+assert(sources.length === 1 && sources[0].url === '/sse', 'connect')
+sources[0].onopen()
+sources[0].emit('component', 'first')
+assert(swaps.join() === 'first', 'swap')
+
+// the browser reconnects the stream after a network error
+sources[0].onerror()
+assert(timers.length === 0 && events.includes('htmx:sseError'), 'network error')
+
+// the closed stream is reopened with an exponential backoff
+sources[0].close()
+sources[0].onerror()
+assert(nextTimer() === 500 && sources.length === 2, 'reconnect')
+sources[1].close()
+sources[1].onerror()
+assert(nextTimer() === 1000 && sources.length === 3, 'backoff')
+sources[2].onopen()
+sources[2].emit('component', 'second')
+assert(swaps.join() === 'first,second', 'swap after reconnect')
+sources[2].close()
+sources[2].onerror()
+assert(nextTimer() === 500 && sources.length === 4, 'backoff reset')
+
+// the removed element is not reconnected
+extension.onEvent('htmx:beforeCleanupElement', { detail: { elt: conn } })
+assert(sources[3].readyState === EventSource.CLOSED, 'cleanup')
+sources[3].onerror()
+nextTimer()
+assert(sources.length === 4, 'cleanup reconnect')
@@ -0,0 +1,299 @@
+package component
+
+import (
+	"html"
+	"slices"
+	"strings"
+)
+
+/*
+HTMLPolicy is the allow-list of the SanitizeHTML function. The keys are the allowed element names and the
+values are the allowed attribute names of the element.
+*/
+type HTMLPolicy map[string][]string
+
+// The default allow-list of the rich text content
+var DefaultHTMLPolicy HTMLPolicy = HTMLPolicy{
+	"p": {}, "div": {}, "br": {}, "hr": {}, "span": {},
+	"b": {}, "strong": {}, "i": {}, "em": {}, "u": {}, "s": {}, "strike": {}, "del": {},
+	"h1": {}, "h2": {}, "h3": {}, "h4": {}, "h5": {}, "h6": {},
+	"ul": {}, "ol": {}, "li": {}, "blockquote": {}, "pre": {}, "code": {},
+	"a":     {"href", "title", "target"},
+	"img":   {"src", "alt", "title"},
+	"table": {}, "thead": {}, "tbody": {}, "tr": {},
+	"th": {"colspan", "rowspan"}, "td": {"colspan", "rowspan"},
+}
+
+const (
+	htmlTokenText = iota
+	htmlTokenStart
+	htmlTokenEnd
+)
+
+type htmlToken struct {
+	Type  int
+	Tag   string
+	Attrs [][2]string
+	// The unescaped text of the text token
+	Text string
+	// The text content of a raw text element (script, style ...)
+	Raw bool
+}
+
+// The content of these elements is not parsed as HTML
+var htmlRawElements = []string{
+	"script", "style", "textarea", "title", "iframe", "noembed", "noframes", "noscript", "xmp", "plaintext",
+}
+
+// Elements without content and end tag
+var htmlVoidElements = []string{"br", "hr", "img", "wbr", "col", "input", "meta", "link", "source", "area", "base"}
+
+// URL attributes and the allowed URL schemes. A relative URL is always allowed.
+var htmlURLAttributes = []string{"href", "src", "cite", "action", "formaction", "poster"}
+var htmlURLSchemes = []string{"http", "https", "mailto", "tel"}
+
+func htmlNameChar(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c == '-' || c == ':' || c == '_'
+}
+
+func htmlLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// Parses the attributes of the start tag and returns the position after the closing >
+func htmlAttributes(src string, pos int) (attrs [][2]string, next int) {
+	for pos < len(src) {
+		for pos < len(src) && (strings.IndexByte(" \t\n\r\f/", src[pos]) > -1) {
+			pos++
+		}
+		if pos >= len(src) || src[pos] == '>' {
+			break
+		}
+		start := pos
+		for pos < len(src) && strings.IndexByte(" \t\n\r\f/>=", src[pos]) == -1 {
+			pos++
+		}
+		name, value := strings.ToLower(src[start:pos]), ""
+		for pos < len(src) && strings.IndexByte(" \t\n\r\f", src[pos]) > -1 {
+			pos++
+		}
+		if pos < len(src) && src[pos] == '=' {
+			pos++
+			for pos < len(src) && strings.IndexByte(" \t\n\r\f", src[pos]) > -1 {
+				pos++
+			}
+			if pos < len(src) && (src[pos] == '"' || src[pos] == '\'') {
+				end := strings.IndexByte(src[pos+1:], src[pos])
+				if end == -1 {
+					return attrs, len(src)
+				}
+				value, pos = src[pos+1:pos+1+end], pos+end+2
+			} else {
+				start = pos
+				for pos < len(src) && strings.IndexByte(" \t\n\r\f>", src[pos]) == -1 {
+					pos++
+				}
+				value = src[start:pos]
+			}
+		}
+		attrs = append(attrs, [2]string{name, html.UnescapeString(value)})
+	}
+	return attrs, min(pos+1, len(src))
+}
+
+/*
+Splits the HTML text into text, start tag and end tag tokens. Comments, doctype and processing instructions
+are skipped, and the content of the raw text elements (script, style ...) is returned as a raw text token.
+*/
+func htmlTokenize(src string) (tokens []htmlToken) {
+	text := strings.Builder{}
+	flush := func() {
+		if text.Len() > 0 {
+			tokens = append(tokens, htmlToken{Type: htmlTokenText, Text: html.UnescapeString(text.String())})
+			text.Reset()
+		}
+	}
+	skipTo := func(pos int, sep string) int {
+		if end := strings.Index(src[pos:], sep); end > -1 {
+			return pos + end + len(sep)
+		}
+		return len(src)
+	}
+	pos := 0
+	for pos < len(src) {
+		index := strings.IndexByte(src[pos:], '<')
+		if index == -1 {
+			text.WriteString(src[pos:])
+			break
+		}
+		text.WriteString(src[pos : pos+index])
+		pos += index
+		rest := src[pos:]
+		switch {
+		case strings.HasPrefix(rest, "<!--"):
+			pos = skipTo(pos+4, "-->")
+		case len(rest) > 1 && (rest[1] == '!' || rest[1] == '?'):
+			pos = skipTo(pos, ">")
+		case len(rest) > 2 && rest[1] == '/' && htmlLetter(rest[2]):
+			end := 2
+			for end < len(rest) && htmlNameChar(rest[end]) {
+				end++
+			}
+			flush()
+			tokens = append(tokens, htmlToken{Type: htmlTokenEnd, Tag: strings.ToLower(rest[2:end])})
+			pos = skipTo(pos+end, ">")
+		case len(rest) > 1 && htmlLetter(rest[1]):
+			end := 1
+			for end < len(rest) && htmlNameChar(rest[end]) {
+				end++
+			}
+			flush()
+			token := htmlToken{Type: htmlTokenStart, Tag: strings.ToLower(rest[1:end])}
+			token.Attrs, pos = htmlAttributes(src, pos+end)
+			tokens = append(tokens, token)
+			if slices.Contains(htmlRawElements, token.Tag) {
+				// the raw text ends at the end tag of the element
+				end = strings.Index(strings.ToLower(src[pos:]), "</"+token.Tag)
+				if end == -1 {
+					end = len(src) - pos
+				}
+				if end > 0 {
+					tokens = append(tokens, htmlToken{Type: htmlTokenText, Tag: token.Tag, Text: src[pos : pos+end], Raw: true})
+				}
+				pos += end
+			}
+		default:
+			text.WriteByte('<')
+			pos++
+		}
+	}
+	flush()
+	return tokens
+}
+
+// Returns true if the URL is relative or its scheme is allowed
+func htmlSafeURL(value string) bool {
+	url := strings.Map(func(r rune) rune {
+		if r <= ' ' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, strings.ToLower(value))
+	if index := strings.IndexAny(url, ":/?#"); index > -1 && url[index] == ':' {
+		return slices.Contains(htmlURLSchemes, url[:index])
+	}
+	return true
+}
+
+/*
+SanitizeHTML returns the HTML text with only the elements and attributes of the allow-list policy. The not
+allowed elements are removed, but their text content is kept, except for the content of the raw text elements
+(script, style ...). The URL attributes are only allowed with a relative URL or with the http, https,
+mailto and tel schemes. The text is escaped and the elements are always closed.
+*/
+func SanitizeHTML(src string, policy HTMLPolicy) string {
+	sb := strings.Builder{}
+	stack := []string{}
+	for _, token := range htmlTokenize(src) {
+		_, allowed := policy[token.Tag]
+		switch token.Type {
+		case htmlTokenText:
+			if !token.Raw || allowed {
+				sb.WriteString(html.EscapeString(token.Text))
+			}
+		case htmlTokenStart:
+			if allowed {
+				sb.WriteString("<" + token.Tag)
+				for _, attr := range token.Attrs {
+					if slices.Contains(policy[token.Tag], attr[0]) &&
+						(!slices.Contains(htmlURLAttributes, attr[0]) || htmlSafeURL(attr[1])) {
+						sb.WriteString(" " + attr[0] + `="` + html.EscapeString(attr[1]) + `"`)
+					}
+				}
+				sb.WriteString(">")
+				if !slices.Contains(htmlVoidElements, token.Tag) {
+					stack = append(stack, token.Tag)
+				}
+			}
+		case htmlTokenEnd:
+			// the innermost open element with the same name
+			index := len(stack) - 1
+			for index > -1 && stack[index] != token.Tag {
+				index--
+			}
+			if index > -1 {
+				for len(stack) > index {
+					sb.WriteString("</" + stack[len(stack)-1] + ">")
+					stack = stack[:len(stack)-1]
+				}
+			}
+		}
+	}
+	for i := len(stack) - 1; i >= 0; i-- {
+		sb.WriteString("</" + stack[i] + ">")
+	}
+	return sb.String()
+}
+
+// An element or text node of the sanitized HTML text
+type htmlNode struct {
+	Tag      string
+	Attrs    map[string]string
+	Text     string
+	Children []*htmlNode
+}
+
+// Returns the node tree of the HTML text sanitized with the DefaultHTMLPolicy
+func htmlTree(src string) *htmlNode {
+	root := &htmlNode{}
+	stack := []*htmlNode{root}
+	for _, token := range htmlTokenize(SanitizeHTML(src, DefaultHTMLPolicy)) {
+		parent := stack[len(stack)-1]
+		switch token.Type {
+		case htmlTokenText:
+			parent.Children = append(parent.Children, &htmlNode{Text: token.Text})
+		case htmlTokenStart:
+			node := &htmlNode{Tag: token.Tag, Attrs: map[string]string{}}
+			for _, attr := range token.Attrs {
+				node.Attrs[attr[0]] = attr[1]
+			}
+			parent.Children = append(parent.Children, node)
+			if !slices.Contains(htmlVoidElements, token.Tag) {
+				stack = append(stack, node)
+			}
+		case htmlTokenEnd:
+			// the sanitized elements are always balanced
+			stack = stack[:len(stack)-1]
+		}
+	}
+	return root
+}
+
+/*
+RewriteHTML returns the HTML text with the attributes of the start tags replaced by the return value of the
+rewrite function. The rewrite function is called with the element name and the current attributes of every
+start tag. The text is escaped and the comments are removed, but the elements are not checked, so it should
+be used with a sanitized HTML text.
+*/
+func RewriteHTML(src string, rewrite func(tag string, attrs [][2]string) [][2]string) string {
+	sb := strings.Builder{}
+	for _, token := range htmlTokenize(src) {
+		switch token.Type {
+		case htmlTokenText:
+			if token.Raw {
+				sb.WriteString(token.Text)
+			} else {
+				sb.WriteString(html.EscapeString(token.Text))
+			}
+		case htmlTokenStart:
+			sb.WriteString("<" + token.Tag)
+			for _, attr := range rewrite(token.Tag, token.Attrs) {
+				sb.WriteString(" " + attr[0] + `="` + html.EscapeString(attr[1]) + `"`)
+			}
+			sb.WriteString(">")
+		case htmlTokenEnd:
+			sb.WriteString("</" + token.Tag + ">")
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,97 @@
+package component
+
+import "testing"
+
+func TestSanitizeHTML(t *testing.T) {
+	tests := []struct {
+		name   string
+		src    string
+		policy HTMLPolicy
+		want   string
+	}{
+		{
+			name:   "text",
+			src:    `a < b & "c" <!-- comment --><!DOCTYPE html><?xml?>`,
+			policy: DefaultHTMLPolicy,
+			want:   `a &lt; b &amp; &#34;c&#34; `,
+		},
+		{
+			name:   "elements",
+			src:    `<P Class="x"><B>bold</B><br/><u>u</u><font>font</font></p><hr>`,
+			policy: DefaultHTMLPolicy,
+			want:   `<p><b>bold</b><br><u>u</u>font</p><hr>`,
+		},
+		{
+			name:   "raw",
+			src:    `<script>alert("<b>")</script><STYLE>p{}</style><textarea>text`,
+			policy: DefaultHTMLPolicy,
+			want:   ``,
+		},
+		{
+			name:   "raw allowed",
+			src:    `<style>p > b {}</style><title></title>`,
+			policy: HTMLPolicy{"style": {}},
+			want:   `<style>p &gt; b {}</style>`,
+		},
+		{
+			name: "attributes",
+			src: `<a href=https://example.com target='_blank' onclick="x()">a</a>` +
+				`<a href=" java&#x09;script:alert(1)" title="t &amp; &quot;q&quot;">b</a>` +
+				`<a href = "/page?x=a:b#c" title>c</a><img src=data:image/png alt="img"><img src="x`,
+			policy: DefaultHTMLPolicy,
+			want: `<a href="https://example.com" target="_blank">a</a><a title="t &amp; &#34;q&#34;">b</a>` +
+				`<a href="/page?x=a:b#c" title="">c</a><img alt="img"><img>`,
+		},
+		{
+			name:   "nesting",
+			src:    `<ul><li><b>a<i>b</li></ul></p><td>c`,
+			policy: DefaultHTMLPolicy,
+			want:   `<ul><li><b>a<i>b</i></b></li></ul><td>c</td>`,
+		},
+		{
+			name:   "tags",
+			src:    `<p>1 <2 </ 3 < /b> < <!-- open`,
+			policy: HTMLPolicy{},
+			want:   `1 &lt;2 &lt;/ 3 &lt; /b&gt; &lt; `,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SanitizeHTML(tt.src, tt.policy); got != tt.want {
+				t.Errorf("SanitizeHTML() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRewriteHTML(t *testing.T) {
+	addTarget := func(tag string, attrs [][2]string) [][2]string {
+		if tag == "a" {
+			return append(attrs, [2]string{"target", "_blank"})
+		}
+		return attrs
+	}
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{
+			name: "links",
+			src:  `<p class="x">a &amp; <a href="/page?a=1&amp;b=2">b</a><br></p><!-- c -->`,
+			want: `<p class="x">a &amp; <a href="/page?a=1&amp;b=2" target="_blank">b</a><br></p>`,
+		},
+		{
+			name: "raw",
+			src:  `<style>p &gt; b {}</style>`,
+			want: `<style>p &gt; b {}</style>`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RewriteHTML(tt.src, addTarget); got != tt.want {
+				t.Errorf("RewriteHTML() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
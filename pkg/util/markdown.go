@@ -0,0 +1,566 @@
+package component
+
+import (
+	"html"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	mdHeading   = regexp.MustCompile(`^(#{1,6})\s+(.*?)(\s+#+)?\s*$`)
+	mdRule      = regexp.MustCompile(`^((\*\s*){3,}|(-\s*){3,}|(_\s*){3,})$`)
+	mdListItem  = regexp.MustCompile(`^( *)([-*+]|\d{1,9}[.)])( +|$)(.*)$`)
+	mdTableSep  = regexp.MustCompile(`^\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?$`)
+	mdAutoLink  = regexp.MustCompile(`^<((https?://|mailto:)[^\s<>]+)>`)
+	mdLineStart = regexp.MustCompile(`^(#{1,6}\s|[-+*>]\s|\d+[.)]\s)`)
+	mdSpace     = regexp.MustCompile(`\s+`)
+	mdTask      = regexp.MustCompile(`^\[([ xX])\]\s+`)
+)
+
+// The characters of the backslash escapes
+const mdPunct = "\\`*_{}[]()#+-.!|~<>\""
+
+// The block elements of the HTML to Markdown conversion
+var mdBlockTags = []string{
+	"p", "div", "h1", "h2", "h3", "h4", "h5", "h6", "ul", "ol", "li", "blockquote", "pre", "table", "hr",
+}
+
+/*
+MarkdownToHTML converts a Markdown text to HTML. It supports the commonly used subset of the CommonMark and
+GitHub Flavored Markdown formats: ATX headings, paragraphs, hard line breaks, emphasis, strong emphasis,
+strikethrough, code spans, fenced code blocks, block quotes, nested lists, task lists, links, images,
+autolinks, thematic breaks and pipe tables. The info string of the fenced code block is set as the
+language-* class of the code element, and the task list items start with a disabled checkbox input. Raw HTML is not supported, it is escaped as text.
+*/
+func MarkdownToHTML(src string) string {
+	src = strings.ReplaceAll(strings.ReplaceAll(src, "\r\n", "\n"), "\t", "    ")
+	return mdBlocks(strings.Split(src, "\n"))
+}
+
+func mdBlank(line string) bool {
+	return strings.TrimSpace(line) == ""
+}
+
+func mdIndent(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// Returns true if the line starts a new block and does not continue the paragraph
+func mdBlockStart(line string) bool {
+	text := strings.TrimSpace(line)
+	return mdHeading.MatchString(text) || mdRule.MatchString(text) || strings.HasPrefix(text, "```") ||
+		strings.HasPrefix(text, ">") || mdListItem.MatchString(line)
+}
+
+func mdBlocks(lines []string) string {
+	sb := strings.Builder{}
+	for i := 0; i < len(lines); {
+		text := strings.TrimSpace(lines[i])
+		switch {
+		case text == "":
+			i++
+		case strings.HasPrefix(text, "```"):
+			end := i + 1
+			for end < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[end]), "```") {
+				end++
+			}
+			code := "<code>"
+			if info := strings.Fields(strings.TrimPrefix(text, "```")); len(info) > 0 {
+				code = `<code class="language-` + html.EscapeString(info[0]) + `">`
+			}
+			sb.WriteString("<pre>" + code + html.EscapeString(strings.Join(lines[i+1:min(end, len(lines))], "\n")) + "</code></pre>\n")
+			i = end + 1
+		case mdHeading.MatchString(text):
+			match := mdHeading.FindStringSubmatch(text)
+			level := strconv.Itoa(len(match[1]))
+			sb.WriteString("<h" + level + ">" + mdInline(match[2]) + "</h" + level + ">\n")
+			i++
+		case mdRule.MatchString(text):
+			sb.WriteString("<hr>\n")
+			i++
+		case strings.HasPrefix(text, ">"):
+			quote := []string{}
+			for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), ">"); i++ {
+				quote = append(quote, strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(lines[i]), ">"), " "))
+			}
+			sb.WriteString("<blockquote>\n" + mdBlocks(quote) + "</blockquote>\n")
+		case mdListItem.MatchString(lines[i]):
+			i = mdList(lines, i, &sb)
+		case i+1 < len(lines) && strings.Contains(text, "|") && mdTableSep.MatchString(strings.TrimSpace(lines[i+1])):
+			i = mdTable(lines, i, &sb)
+		default:
+			paragraph := []string{strings.TrimLeft(lines[i], " ")}
+			for i++; i < len(lines) && !mdBlank(lines[i]) && !mdBlockStart(lines[i]); i++ {
+				paragraph = append(paragraph, strings.TrimLeft(lines[i], " "))
+			}
+			sb.WriteString("<p>" + mdInline(strings.TrimRight(strings.Join(paragraph, "\n"), " ")) + "</p>\n")
+		}
+	}
+	return sb.String()
+}
+
+func mdOrdered(marker string) bool {
+	return strings.IndexByte("-*+", marker[0]) == -1
+}
+
+// Writes the list of the current line and returns the index of the next line after the list
+func mdList(lines []string, i int, sb *strings.Builder) int {
+	match := mdListItem.FindStringSubmatch(lines[i])
+	indent, ordered := len(match[1]), mdOrdered(match[2])
+	tag := "ul"
+	if ordered {
+		tag = "ol"
+		if start, _ := strconv.Atoi(match[2][:len(match[2])-1]); start != 1 {
+			tag += ` start="` + strconv.Itoa(start) + `"`
+		}
+	}
+	sibling := func(line string) bool {
+		match := mdListItem.FindStringSubmatch(line)
+		return match != nil && len(match[1]) == indent && mdOrdered(match[2]) == ordered
+	}
+	sb.WriteString("<" + tag + ">\n")
+	for i < len(lines) {
+		next := i
+		for next < len(lines) && mdBlank(lines[next]) {
+			next++
+		}
+		if next == len(lines) || !sibling(lines[next]) {
+			break
+		}
+		match = mdListItem.FindStringSubmatch(lines[next])
+		contentIndent := len(match[1]) + len(match[2]) + max(len(match[3]), 1)
+		item, loose := []string{match[4]}, false
+		for i = next + 1; i < len(lines); i++ {
+			line := lines[i]
+			if mdBlank(line) {
+				next = i
+				for next < len(lines) && mdBlank(lines[next]) {
+					next++
+				}
+				if next < len(lines) && mdIndent(lines[next]) >= contentIndent {
+					item, loose = append(item, ""), true
+					continue
+				}
+				break
+			}
+			if mdIndent(line) > indent && (mdIndent(line) >= contentIndent || mdListItem.MatchString(line)) {
+				item = append(item, line[min(mdIndent(line), contentIndent):])
+				continue
+			}
+			if mdBlockStart(line) {
+				break
+			}
+			// lazy paragraph continuation
+			item = append(item, strings.TrimLeft(line, " "))
+		}
+		task := ""
+		if match := mdTask.FindStringSubmatch(item[0]); match != nil {
+			task, item[0] = `<input type="checkbox" disabled> `, item[0][len(match[0]):]
+			if match[1] != " " {
+				task = `<input type="checkbox" disabled checked> `
+			}
+		}
+		content := mdBlocks(item)
+		if task != "" {
+			if strings.HasPrefix(content, "<p>") {
+				content = "<p>" + task + content[3:]
+			} else {
+				content = task + content
+			}
+		}
+		if !loose {
+			content = strings.ReplaceAll(strings.ReplaceAll(content, "<p>", ""), "</p>", "")
+		}
+		sb.WriteString("<li>" + strings.TrimSuffix(content, "\n") + "</li>\n")
+	}
+	sb.WriteString("</" + tag[:2] + ">\n")
+	return i
+}
+
+// Returns the cells of the table row
+func mdTableCells(line string) (cells []string) {
+	line = strings.TrimPrefix(strings.TrimSpace(line), "|")
+	cell := strings.Builder{}
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteString(`\|`)
+			i++
+		case line[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+	if text := strings.TrimSpace(cell.String()); text != "" || len(cells) == 0 {
+		cells = append(cells, text)
+	}
+	return cells
+}
+
+// Writes the table of the current line and returns the index of the next line after the table
+func mdTable(lines []string, i int, sb *strings.Builder) int {
+	header := mdTableCells(lines[i])
+	row := func(cells []string, tag string) {
+		sb.WriteString("<tr>")
+		for index := range header {
+			value := ""
+			if index < len(cells) {
+				value = mdInline(cells[index])
+			}
+			sb.WriteString("<" + tag + ">" + value + "</" + tag + ">")
+		}
+		sb.WriteString("</tr>\n")
+	}
+	sb.WriteString("<table>\n<thead>\n")
+	row(header, "th")
+	sb.WriteString("</thead>\n<tbody>\n")
+	for i += 2; i < len(lines) && !mdBlank(lines[i]) && strings.Contains(lines[i], "|"); i++ {
+		row(mdTableCells(lines[i]), "td")
+	}
+	sb.WriteString("</tbody>\n</table>\n")
+	return i
+}
+
+// Returns the label, the URL and the position after the link of the [label](url "title") link text
+func mdLink(text string, pos int) (label, url string, next int, found bool) {
+	depth := 0
+	for i := pos; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '[':
+			depth++
+		case ']':
+			if depth--; depth == 0 {
+				if !strings.HasPrefix(text[i+1:], "(") {
+					return "", "", 0, false
+				}
+				end, parens := -1, 0
+				for pos := i + 2; pos < len(text) && end == -1; pos++ {
+					// the balanced parentheses are part of the URL
+					switch {
+					case text[pos] == '(':
+						parens++
+					case text[pos] == ')' && parens > 0:
+						parens--
+					case text[pos] == ')':
+						end = pos - i - 2
+					}
+				}
+				if end == -1 {
+					return "", "", 0, false
+				}
+				url, _, _ = strings.Cut(strings.TrimSpace(text[i+2:i+2+end]), " ")
+				url = strings.TrimSuffix(strings.TrimPrefix(url, "<"), ">")
+				return text[pos+1 : i], url, i + 3 + end, true
+			}
+		}
+	}
+	return "", "", 0, false
+}
+
+// Returns the position of the closing emphasis delimiter or -1
+func mdClose(text string, pos int, delim string) int {
+	for i := pos; i < len(text); i++ {
+		switch {
+		case text[i] == '\\':
+			i++
+		case strings.HasPrefix(text[i:], delim):
+			if len(delim) == 1 && i+1 < len(text) && text[i+1] == delim[0] {
+				// skip the double delimiter of the single delimiter
+				i++
+				continue
+			}
+			if i > pos && text[i-1] != ' ' && text[i-1] != '\n' {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func mdWordChar(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// Converts the inline Markdown elements of the text to HTML
+func mdInline(text string) string {
+	sb := strings.Builder{}
+	tags := map[string]string{"**": "strong", "__": "strong", "~~": "del", "*": "em", "_": "em"}
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch {
+		case c == '\\' && i+1 < len(text) && text[i+1] == '\n':
+			sb.WriteString("<br>\n")
+			i += 2
+		case c == '\\' && i+1 < len(text) && strings.IndexByte(mdPunct, text[i+1]) > -1:
+			sb.WriteString(html.EscapeString(text[i+1 : i+2]))
+			i += 2
+		case c == ' ':
+			end := i
+			for end < len(text) && text[end] == ' ' {
+				end++
+			}
+			if end < len(text) && text[end] == '\n' && end-i >= 2 {
+				sb.WriteString("<br>")
+			} else {
+				sb.WriteString(text[i:end])
+			}
+			i = end
+		case c == '`':
+			delim := text[i : i+len(text[i:])-len(strings.TrimLeft(text[i:], "`"))]
+			if end := strings.Index(text[i+len(delim):], delim); end > -1 {
+				code := text[i+len(delim) : i+len(delim)+end]
+				sb.WriteString("<code>" + html.EscapeString(strings.TrimSpace(code)) + "</code>")
+				i += len(delim)*2 + end
+			} else {
+				sb.WriteString(delim)
+				i += len(delim)
+			}
+		case c == '!' || c == '[':
+			start := i
+			if c == '!' {
+				start++
+			}
+			label, url, next, found := mdLink(text, start)
+			switch {
+			case !found || (c == '!' && text[start] != '['):
+				sb.WriteString(html.EscapeString(text[i : i+1]))
+				i++
+				continue
+			case !htmlSafeURL(url):
+				sb.WriteString(mdInline(label))
+			case c == '!':
+				sb.WriteString(`<img src="` + html.EscapeString(url) + `" alt="` + html.EscapeString(label) + `">`)
+			default:
+				sb.WriteString(`<a href="` + html.EscapeString(url) + `">` + mdInline(label) + "</a>")
+			}
+			i = next
+		case c == '<' && mdAutoLink.MatchString(text[i:]):
+			match := mdAutoLink.FindStringSubmatch(text[i:])
+			sb.WriteString(`<a href="` + html.EscapeString(match[1]) + `">` + html.EscapeString(match[1]) + "</a>")
+			i += len(match[0])
+		case c == '*' || c == '_' || c == '~':
+			delim := text[i : i+1]
+			if i+1 < len(text) && text[i+1] == c {
+				delim += delim
+			}
+			tag, valid := tags[delim]
+			end := -1
+			if valid && i+len(delim) < len(text) && text[i+len(delim)] != ' ' && (c != '_' || i == 0 || !mdWordChar(text[i-1])) {
+				end = mdClose(text, i+len(delim), delim)
+			}
+			if end == -1 {
+				sb.WriteString(delim)
+				i += len(delim)
+				continue
+			}
+			sb.WriteString("<" + tag + ">" + mdInline(text[i+len(delim):end]) + "</" + tag + ">")
+			i = end + len(delim)
+		default:
+			sb.WriteString(html.EscapeString(text[i : i+1]))
+			i++
+		}
+	}
+	return sb.String()
+}
+
+/*
+HTMLToMarkdown converts the HTML text to Markdown. The HTML text is sanitized with the DefaultHTMLPolicy,
+and the elements without Markdown equivalent (for example u and span) are converted to their text content.
+*/
+func HTMLToMarkdown(src string) string {
+	return strings.TrimSpace(mdFromBlocks(htmlTree(src).Children, "\n\n"))
+}
+
+// Returns the text content of the node
+func (node *htmlNode) textContent() string {
+	sb := strings.Builder{}
+	sb.WriteString(node.Text)
+	for _, child := range node.Children {
+		sb.WriteString(child.textContent())
+	}
+	return sb.String()
+}
+
+func mdFromBlocks(nodes []*htmlNode, sep string) string {
+	blocks, inline := []string{}, []*htmlNode{}
+	flush := func() {
+		// the trailing line breaks of the contenteditable blocks
+		for len(inline) > 0 && inline[len(inline)-1].Tag == "br" {
+			inline = inline[:len(inline)-1]
+		}
+		if text := strings.TrimSpace(mdFromInline(inline)); text != "" {
+			lines := strings.Split(text, "\n")
+			for index, line := range lines {
+				// escape the block markers at the beginning of the lines
+				if match := mdLineStart.FindString(line); match != "" {
+					pos := strings.IndexAny(match, "#-+*>.)")
+					lines[index] = line[:pos] + `\` + line[pos:]
+				}
+			}
+			blocks = append(blocks, strings.Join(lines, "\n"))
+		}
+		inline = []*htmlNode{}
+	}
+	for _, node := range nodes {
+		if !slices.Contains(mdBlockTags, node.Tag) {
+			inline = append(inline, node)
+			continue
+		}
+		flush()
+		if block := mdFromBlock(node); block != "" {
+			blocks = append(blocks, block)
+		}
+	}
+	flush()
+	return strings.Join(blocks, sep)
+}
+
+func mdFromBlock(node *htmlNode) string {
+	switch node.Tag {
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		return strings.Repeat("#", int(node.Tag[1]-'0')) + " " + strings.TrimSpace(mdFromInline(node.Children))
+	case "ul", "ol":
+		return mdFromList(node)
+	case "blockquote":
+		lines := strings.Split(mdFromBlocks(node.Children, "\n\n"), "\n")
+		for index, line := range lines {
+			lines[index] = strings.TrimRight("> "+line, " ")
+		}
+		return strings.Join(lines, "\n")
+	case "pre":
+		return "```\n" + strings.TrimSuffix(node.textContent(), "\n") + "\n```"
+	case "table":
+		return mdFromTable(node)
+	case "hr":
+		return "---"
+	}
+	return mdFromBlocks(node.Children, "\n\n")
+}
+
+func mdFromList(node *htmlNode) string {
+	lines, number, indent := []string{}, 1, "  "
+	for _, item := range node.Children {
+		content, marker := "", ""
+		switch item.Tag {
+		case "li":
+			marker = "- "
+			if node.Tag == "ol" {
+				marker = strconv.Itoa(number) + ". "
+				number++
+			}
+			content, indent = mdFromBlocks(item.Children, "\n"), strings.Repeat(" ", len(marker))
+		case "ul", "ol":
+			// a nested list without a list item parent
+			content = indent + strings.ReplaceAll(mdFromList(item), "\n", "\n"+indent)
+		}
+		for index, line := range strings.Split(content, "\n") {
+			if index == 0 {
+				line = marker + line
+			} else if line != "" {
+				line = strings.Repeat(" ", len(marker)) + line
+			}
+			if strings.TrimSpace(line) != "" {
+				lines = append(lines, line)
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func mdFromTable(node *htmlNode) string {
+	rows, columns := [][]string{}, 0
+	var collect func(node *htmlNode)
+	collect = func(node *htmlNode) {
+		for _, child := range node.Children {
+			switch child.Tag {
+			case "tr":
+				cells := []string{}
+				for _, cell := range child.Children {
+					if cell.Tag == "th" || cell.Tag == "td" {
+						value := strings.ReplaceAll(strings.TrimSpace(mdFromInline(cell.Children)), "\\\n", " ")
+						cells = append(cells, strings.ReplaceAll(value, "|", `\|`))
+					}
+				}
+				rows, columns = append(rows, cells), max(columns, len(cells))
+			case "thead", "tbody":
+				collect(child)
+			}
+		}
+	}
+	collect(node)
+	if columns == 0 {
+		return ""
+	}
+	lines := []string{}
+	for index, cells := range rows {
+		cells = append(cells, make([]string, columns-len(cells))...)
+		lines = append(lines, "| "+strings.Join(cells, " | ")+" |")
+		if index == 0 {
+			lines = append(lines, "|"+strings.Repeat(" --- |", columns))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Escapes the Markdown inline markers of the text
+func mdEscape(text string) string {
+	sb := strings.Builder{}
+	for i := 0; i < len(text); i++ {
+		if strings.IndexByte("\\`*_[]~", text[i]) > -1 {
+			sb.WriteByte('\\')
+		}
+		sb.WriteByte(text[i])
+	}
+	return sb.String()
+}
+
+// Wraps the text with the delimiter, the leading and trailing spaces are moved outside
+func mdWrap(delim, text string) string {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return text
+	}
+	start := strings.Index(text, trimmed)
+	return text[:start] + delim + trimmed + delim + text[start+len(trimmed):]
+}
+
+func mdFromInline(nodes []*htmlNode) string {
+	sb := strings.Builder{}
+	for _, node := range nodes {
+		switch node.Tag {
+		case "":
+			sb.WriteString(mdEscape(mdSpace.ReplaceAllString(node.Text, " ")))
+		case "b", "strong":
+			sb.WriteString(mdWrap("**", mdFromInline(node.Children)))
+		case "i", "em":
+			sb.WriteString(mdWrap("*", mdFromInline(node.Children)))
+		case "s", "strike", "del":
+			sb.WriteString(mdWrap("~~", mdFromInline(node.Children)))
+		case "code":
+			code, delim := node.textContent(), "`"
+			if strings.Contains(code, "`") {
+				code, delim = " "+code+" ", "``"
+			}
+			sb.WriteString(delim + code + delim)
+		case "a":
+			if href := node.Attrs["href"]; href != "" {
+				sb.WriteString("[" + mdFromInline(node.Children) + "](" + href + ")")
+			} else {
+				sb.WriteString(mdFromInline(node.Children))
+			}
+		case "img":
+			sb.WriteString("![" + mdEscape(node.Attrs["alt"]) + "](" + node.Attrs["src"] + ")")
+		case "br":
+			sb.WriteString("\\\n")
+		default:
+			sb.WriteString(mdFromInline(node.Children))
+		}
+	}
+	return sb.String()
+}
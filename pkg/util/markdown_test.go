@@ -0,0 +1,102 @@
+package component
+
+import "testing"
+
+func TestMarkdownToHTML(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{
+			name: "blocks",
+			src:  "# Title #\r\n\n###### h6\nText *em* and **strong**  \nline\\\nnext\n---\n```\n<code>\n```\n> quote\n> > nested\n\n```\nopen",
+			want: "<h1>Title</h1>\n<h6>h6</h6>\n<p>Text <em>em</em> and <strong>strong</strong><br>\nline<br>\nnext</p>\n<hr>\n" +
+				"<pre><code>&lt;code&gt;</code></pre>\n<blockquote>\n<p>quote</p>\n<blockquote>\n<p>nested</p>\n</blockquote>\n</blockquote>\n" +
+				"<pre><code>open</code></pre>\n",
+		},
+		{
+			name: "lists",
+			src:  "- a\n  - b\n\tc\n+ d\n\n3. one\n4) two\n\n   para\n\n   more\n-",
+			want: "<ul>\n<li>a\n<ul>\n<li>b\nc</li>\n</ul></li>\n<li>d</li>\n</ul>\n" +
+				"<ol start=\"3\">\n<li>one</li>\n<li><p>two</p>\n<p>para</p>\n<p>more</p></li>\n</ol>\n<ul>\n<li></li>\n</ul>\n",
+		},
+		{
+			name: "list end",
+			src:  "1. a\nlazy\n# b\n- c\n\nd",
+			want: "<ol>\n<li>a\nlazy</li>\n</ol>\n<h1>b</h1>\n<ul>\n<li>c</li>\n</ul>\n<p>d</p>\n",
+		},
+		{
+			name: "task list",
+			src:  "- [ ] todo\n- [x] done\n- [X] loose\n\n  para\n- [ ]\n- [ ] > q\n```go run\nfmt.Println()\n```",
+			want: "<ul>\n<li><input type=\"checkbox\" disabled> todo</li>\n<li><input type=\"checkbox\" disabled checked> done</li>\n" +
+				"<li><p><input type=\"checkbox\" disabled checked> loose</p>\n<p>para</p></li>\n<li>[ ]</li>\n<li><input type=\"checkbox\" disabled> <blockquote>\nq\n</blockquote></li>\n</ul>\n" +
+				"<pre><code class=\"language-go\">fmt.Println()</code></pre>\n",
+		},
+		{
+			name: "list ordered nested",
+			src:  "1. a\n  - b\n",
+			want: "<ol>\n<li>a\n<ul>\n<li>b</li>\n</ul></li>\n</ol>\n",
+		},
+		{
+			name: "table",
+			src:  "| Name | Value \\| x |\n|:---|---:|\n| a | `b` | c |\n| d\n\n| a\n",
+			want: "<table>\n<thead>\n<tr><th>Name</th><th>Value | x</th></tr>\n</thead>\n<tbody>\n" +
+				"<tr><td>a</td><td><code>b</code></td></tr>\n<tr><td>d</td><td></td></tr>\n</tbody>\n</table>\n<p>| a</p>\n",
+		},
+		{
+			name: "inline",
+			src: "\\*a\\* \\q ``code ` x`` `open ~~del~~ __b__ _i_ snake_case_name * a * ** ~x~ *a **b** c* " +
+				"[link *x*](https://example.com \"title\") [bad](javascript:alert(1)) ![img](<a.png>) ![x] " +
+				"[x] [x](open [x]y <https://example.com> <b> & [a\\]b](c) *a\\*b* *c [d",
+			want: "<p>*a* \\q <code>code ` x</code> `open <del>del</del> <strong>b</strong> <em>i</em> snake_case_name * a * ** ~x~ " +
+				"<em>a <strong>b</strong> c</em> <a href=\"https://example.com\">link <em>x</em></a> bad " +
+				"<img src=\"a.png\" alt=\"img\"> ![x] [x] [x](open [x]y <a href=\"https://example.com\">https://example.com</a> &lt;b&gt; &amp; <a href=\"c\">a]b</a> <em>a*b</em> *c [d</p>\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MarkdownToHTML(tt.src); got != tt.want {
+				t.Errorf("MarkdownToHTML() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHTMLToMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{
+			name: "blocks",
+			src: "<h2>Title</h2><p>Text <b>bold </b><i>it</i> <s>del</s> <u>u</u> <strong></strong>1 * 2_3<br>line</p>" +
+				"<div># no title<br></div><div><br></div><p>1. no list</p><hr><pre><code>a\n  b\n</code></pre><blockquote><p>quote</p><p>next</p></blockquote>",
+			want: "## Title\n\nText **bold** *it* ~~del~~ u 1 \\* 2\\_3\\\nline\n\n\\# no title\n\n1\\. no list\n\n---\n\n" +
+				"```\na\n  b\n```\n\n> quote\n>\n> next",
+		},
+		{
+			name: "lists",
+			src:  "<ul><li>a<ul><li>b</li></ul></li><li><p>c</p></li><ol><li>d</li></ol></ul><ol><li>one</li><li>two<br>lines</li><li></li></ol>",
+			want: "- a\n  - b\n- c\n  1. d\n\n1. one\n2. two\\\n   lines\n3.",
+		},
+		{
+			name: "inline",
+			src:  "text <a href=\"https://example.com\">link</a> <a>no link</a> <code>a`b</code> <code>c</code> <img src=\"a.png\" alt=\"a_b\">",
+			want: "text [link](https://example.com) no link `` a`b `` `c` ![a\\_b](a.png)",
+		},
+		{
+			name: "table",
+			src:  "<table><thead><tr><th>A</th><th>B</th></tr></thead><tbody><tr><td>a|b<br>c</td></tr></tbody></table><table></table>",
+			want: "| A | B |\n| --- | --- |\n| a\\|b c |  |",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HTMLToMarkdown(tt.src); got != tt.want {
+				t.Errorf("HTMLToMarkdown() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
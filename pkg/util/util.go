@@ -10,6 +10,7 @@ import (
 	"math/big"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -336,18 +337,147 @@ func GetComponentID() string {
 	return "ID" + RandString(16)
 }
 
-func TemplateBuilder(name, tpl string, funcMap map[string]any, data any) (html template.HTML, err error) {
+/*
+The parsed templates of the [CachedTemplateWriter] are cached by the name and the template source. Disable
+the cache only for debugging or for benchmark comparisons.
+*/
+var TemplateCacheEnabled bool = true
+
+var templateCache sync.Map
+
+func templateCacheKey(name, tpl string) string {
+	return name + "\x00" + tpl
+}
+
+/*
+The templatePool function returns the pool of the reusable template instances. The template source is parsed
+only once with the funcMap, the instances are cloned from the parsed template and escaped at their first execution.
+*/
+func templatePool(name, tpl string, funcMap map[string]any) (pool *sync.Pool, err error) {
+	key := templateCacheKey(name, tpl)
+	if value, found := templateCache.Load(key); found {
+		return value.(*sync.Pool), nil
+	}
 	var tmp *template.Template
 	if tmp, err = template.New(name).Funcs(funcMap).Parse(tpl); err != nil {
-		return "", err
+		return nil, err
+	}
+	pool = &sync.Pool{New: func() any {
+		clone, _ := tmp.Clone()
+		return clone
+	}}
+	value, _ := templateCache.LoadOrStore(key, pool)
+	return value.(*sync.Pool), nil
+}
+
+/*
+HTMLWriter removes the newline and tab pairs of the template source indentation from the rendered html
+before writing it into the underlying writer. The nested component renders share the writer of the
+parent component, every [NewHTMLWriter] call must be closed by the [HTMLWriter.Close] function.
+*/
+type HTMLWriter struct {
+	w       io.Writer
+	newline bool
+	nested  int
+}
+
+// The NewHTMLWriter function returns the writer itself, if it is already an [HTMLWriter].
+func NewHTMLWriter(w io.Writer) *HTMLWriter {
+	if hw, valid := w.(*HTMLWriter); valid {
+		hw.nested++
+		return hw
+	}
+	return &HTMLWriter{w: w}
+}
+
+func (hw *HTMLWriter) Write(p []byte) (n int, err error) {
+	buf := make([]byte, 0, len(p)+1)
+	for _, ch := range p {
+		switch {
+		case hw.newline && ch == '\t':
+			hw.newline = false
+		case ch == '\n':
+			if hw.newline {
+				buf = append(buf, '\n')
+			}
+			hw.newline = true
+		default:
+			if hw.newline {
+				buf = append(buf, '\n')
+				hw.newline = false
+			}
+			buf = append(buf, ch)
+		}
+	}
+	if _, err = hw.w.Write(buf); err != nil {
+		return 0, err
 	}
+	return len(p), nil
+}
+
+// The Close function writes the pending newline character into the underlying writer of the outermost render.
+func (hw *HTMLWriter) Close() (err error) {
+	if hw.nested > 0 {
+		hw.nested--
+		return nil
+	}
+	if hw.newline {
+		hw.newline = false
+		_, err = hw.w.Write([]byte{'\n'})
+	}
+	return err
+}
+
+func templateExecute(w io.Writer, tmp *template.Template, data any) (err error) {
+	hw := NewHTMLWriter(w)
+	err = tmp.Execute(hw, data)
+	if cerr := hw.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
 
+/*
+The TemplateWriter function parses and executes the template with the render data and function map and
+writes the result into the writer.
+*/
+func TemplateWriter(w io.Writer, name, tpl string, funcMap map[string]any, data any) (err error) {
+	var tmp *template.Template
+	if tmp, err = template.New(name).Funcs(funcMap).Parse(tpl); err != nil {
+		return err
+	}
+	return templateExecute(w, tmp, data)
+}
+
+/*
+The CachedTemplateWriter function is the [TemplateWriter] with the parsed template cache. The template source
+and the funcMap of a template name must be static, the cached template instances keep the functions of the
+first parse. The per-render state is passed to the functions by the data argument (the $ variable of the
+template).
+*/
+func CachedTemplateWriter(w io.Writer, name, tpl string, funcMap map[string]any, data any) (err error) {
+	if !TemplateCacheEnabled {
+		return TemplateWriter(w, name, tpl, funcMap, data)
+	}
+	var pool *sync.Pool
+	if pool, err = templatePool(name, tpl, funcMap); err != nil {
+		return err
+	}
+	tmp := pool.Get().(*template.Template)
+	defer pool.Put(tmp)
+	return templateExecute(w, tmp, data)
+}
+
+/*
+The TemplateBuilder function executes the template with the render data and function map and returns the
+html result. See more [TemplateWriter].
+*/
+func TemplateBuilder(name, tpl string, funcMap map[string]any, data any) (html template.HTML, err error) {
 	var sb strings.Builder
-	if err = tmp.Execute(&sb, data); err != nil {
+	if err = TemplateWriter(&sb, name, tpl, funcMap, data); err != nil {
 		return "", err
 	}
-
-	return template.HTML(strings.ReplaceAll(sb.String(), "\n\t", "")), err
+	return template.HTML(sb.String()), err
 }
 
 func MergeSM(baseMap, valueMap SM) SM {
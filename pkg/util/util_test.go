@@ -5,6 +5,8 @@ import (
 	"errors"
 	"io"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -1048,3 +1050,102 @@ func TestConvertToType(t *testing.T) {
 		})
 	}
 }
+
+func TestTemplateBuilder_funcMap(t *testing.T) {
+	render := func(value string) string {
+		html, _ := TemplateBuilder("func_map", `<div>{{ data }}</div>`, map[string]any{
+			"data": func() string {
+				return value
+			},
+		}, nil)
+		return string(html)
+	}
+	for _, value := range []string{"first", "second"} {
+		if html := render(value); html != "<div>"+value+"</div>" {
+			t.Errorf("TemplateBuilder() = %v", html)
+		}
+	}
+}
+
+func TestCachedTemplateWriter(t *testing.T) {
+	tpl := `<div>{{ data $ }}</div>`
+	funcMap := map[string]any{
+		"data": func(value string) string {
+			return value
+		},
+	}
+	render := func(value string) string {
+		var sb strings.Builder
+		CachedTemplateWriter(&sb, "cache", tpl, funcMap, value)
+		return sb.String()
+	}
+	if html := render("first"); html != "<div>first</div>" {
+		t.Errorf("CachedTemplateWriter() = %v", html)
+	}
+	if html := render("second"); html != "<div>second</div>" {
+		t.Errorf("CachedTemplateWriter() cached = %v", html)
+	}
+	var wg sync.WaitGroup
+	for index := range 10 {
+		wg.Go(func() {
+			value := ToString(index, "")
+			if html := render(value); html != "<div>"+value+"</div>" {
+				t.Errorf("CachedTemplateWriter() concurrent = %v", html)
+			}
+		})
+	}
+	wg.Wait()
+	if err := CachedTemplateWriter(io.Discard, "cache", `{{.???`, nil, nil); err == nil {
+		t.Error("CachedTemplateWriter() error = nil")
+	}
+	TemplateCacheEnabled = false
+	defer func() { TemplateCacheEnabled = true }()
+	if html := render("third"); html != "<div>third</div>" {
+		t.Errorf("CachedTemplateWriter() disabled cache = %v", html)
+	}
+}
+
+type testErrorWriter struct{}
+
+func (w *testErrorWriter) Write(p []byte) (n int, err error) {
+	return 0, errors.New("error")
+}
+
+func TestHTMLWriter(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []string
+		want   string
+	}{
+		{name: "indent", values: []string{"<div>\n\t<span>\n\t\t</span>\n</div>"}, want: "<div><span>\t</span>\n</div>"},
+		{name: "split", values: []string{"<div>\n", "\t<span></span>\n", "\n"}, want: "<div><span></span>\n\n"},
+		{name: "nested", values: []string{"<div>\n", "\n\t</div>"}, want: "<div>\n</div>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sb strings.Builder
+			hw := NewHTMLWriter(&sb)
+			for _, value := range tt.values {
+				nw := NewHTMLWriter(hw)
+				if n, err := nw.Write([]byte(value)); err != nil || n != len(value) {
+					t.Errorf("HTMLWriter.Write() = %v, %v", n, err)
+				}
+				nw.Close()
+			}
+			if err := hw.Close(); err != nil || sb.String() != tt.want {
+				t.Errorf("HTMLWriter = %q, want %q", sb.String(), tt.want)
+			}
+		})
+	}
+
+	hw := NewHTMLWriter(&testErrorWriter{})
+	if _, err := hw.Write([]byte("<div>\n")); err == nil {
+		t.Error("HTMLWriter.Write() error = nil")
+	}
+	if err := hw.Close(); err == nil {
+		t.Error("HTMLWriter.Close() error = nil")
+	}
+	if err := TemplateWriter(&testErrorWriter{}, "error", `<div></div>`, nil, nil); err == nil {
+		t.Error("TemplateWriter() error = nil")
+	}
+}
@@ -0,0 +1,338 @@
+package component
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var yamlNumber = regexp.MustCompile(`^[-+]?(\d+\.?\d*|\.\d+)([eE][-+]?\d+)?$`)
+
+type yamlParser struct {
+	lines []string
+	pos   int
+}
+
+/*
+ParseYAML converts a YAML document to the same values as the json.Unmarshal function (map[string]any,
+[]any, string, float64, bool and nil). It supports the commonly used subset of the format: block mappings
+and sequences, single line flow collections, quoted and plain scalars, literal (|) and folded (>) block scalars and
+comments. Anchors, tags and multiple documents are not supported.
+*/
+func ParseYAML(data []byte) (result any, err error) {
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	parser := &yamlParser{lines: strings.Split(text, "\n")}
+	if _, line, found := parser.next(); found && line == "---" {
+		parser.pos++
+	}
+	if indent, _, found := parser.next(); found {
+		if result, err = parser.node(indent); err == nil {
+			if _, _, found = parser.next(); found {
+				err = parser.errorf("invalid indentation")
+			}
+		}
+	}
+	return result, err
+}
+
+func (p *yamlParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("yaml: line %d: %s", p.pos+1, fmt.Sprintf(format, args...))
+}
+
+// Returns the next not empty line without the comment
+func (p *yamlParser) next() (indent int, line string, found bool) {
+	for ; p.pos < len(p.lines); p.pos++ {
+		line = yamlStripComment(p.lines[p.pos])
+		if text := strings.TrimLeft(line, " "); text != "" && text != "..." {
+			return len(line) - len(text), text, true
+		}
+	}
+	return 0, "", false
+}
+
+func (p *yamlParser) node(indent int) (any, error) {
+	_, line, _ := p.next()
+	if line == "-" || strings.HasPrefix(line, "- ") {
+		return p.sequence(indent)
+	}
+	if _, _, found := yamlKey(line); found {
+		return p.mapping(indent)
+	}
+	p.pos++
+	return yamlValue(line)
+}
+
+// Parses the child node of a sequence item or a mapping key
+func (p *yamlParser) child(indent int, sequence bool) (any, error) {
+	p.pos++
+	next, line, found := p.next()
+	if found && (next > indent || (!sequence && next == indent && (line == "-" || strings.HasPrefix(line, "- ")))) {
+		return p.node(next)
+	}
+	return nil, nil
+}
+
+func (p *yamlParser) sequence(indent int) (any, error) {
+	items := []any{}
+	for {
+		next, line, found := p.next()
+		if !found || next < indent {
+			return items, nil
+		}
+		if next > indent {
+			return nil, p.errorf("invalid indentation")
+		}
+		if line != "-" && !strings.HasPrefix(line, "- ") {
+			// the sequence value of a mapping key can have the same indentation as the key
+			return items, nil
+		}
+		var item any
+		var err error
+		if rest := strings.TrimLeft(strings.TrimPrefix(line, "-"), " "); rest == "" {
+			item, err = p.child(indent, true)
+		} else {
+			// the compact nested node is parsed as a line with a deeper indentation
+			itemIndent := indent + len(line) - len(rest)
+			p.lines[p.pos] = strings.Repeat(" ", itemIndent) + rest
+			item, err = p.node(itemIndent)
+		}
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+}
+
+func (p *yamlParser) mapping(indent int) (any, error) {
+	items := map[string]any{}
+	for {
+		next, line, found := p.next()
+		if !found || next < indent || line == "-" || strings.HasPrefix(line, "- ") {
+			return items, nil
+		}
+		key, rest, valid := yamlKey(line)
+		if next > indent || !valid {
+			return nil, p.errorf("invalid mapping")
+		}
+		if _, exists := items[key]; exists {
+			return nil, p.errorf("duplicate key: %s", key)
+		}
+		var err error
+		switch {
+		case rest == "":
+			items[key], err = p.child(indent, false)
+		case strings.HasPrefix(rest, "|") || strings.HasPrefix(rest, ">"):
+			items[key] = p.block(indent, rest)
+		default:
+			if items[key], err = yamlValue(rest); err != nil {
+				return nil, p.errorf("%s", err)
+			}
+			p.pos++
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Parses a literal (|) or folded (>) block scalar
+func (p *yamlParser) block(indent int, style string) string {
+	lines := []string{}
+	blockIndent := -1
+	for p.pos++; p.pos < len(p.lines); p.pos++ {
+		line := p.lines[p.pos]
+		text := strings.TrimLeft(line, " ")
+		if text == "" {
+			lines = append(lines, "")
+			continue
+		}
+		if len(line)-len(text) <= indent {
+			break
+		}
+		if blockIndent < 0 {
+			blockIndent = len(line) - len(text)
+		}
+		lines = append(lines, line[min(blockIndent, len(line)-len(text)):])
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	value := strings.Join(lines, "\n")
+	if strings.HasPrefix(style, ">") {
+		value = strings.ReplaceAll(strings.ReplaceAll(value, "\n\n", "\x00"), "\n", " ")
+		value = strings.ReplaceAll(value, "\x00", "\n")
+	}
+	if !strings.HasSuffix(style, "-") && value != "" {
+		value += "\n"
+	}
+	return value
+}
+
+// Returns the line without the comment. The # character starts a comment at the beginning of the line
+// or after a space outside of the quoted values.
+func yamlStripComment(line string) string {
+	var quote byte
+	for index := 0; index < len(line); index++ {
+		switch ch := line[index]; {
+		case quote != 0:
+			if ch == quote {
+				quote = 0
+			} else if ch == '\\' && quote == '"' {
+				index++
+			}
+		case ch == '"' || ch == '\'':
+			if index == 0 || strings.ContainsRune(" [{,:-", rune(line[index-1])) {
+				quote = ch
+			}
+		case ch == '#' && (index == 0 || line[index-1] == ' '):
+			return strings.TrimRight(line[:index], " ")
+		}
+	}
+	return strings.TrimRight(line, " ")
+}
+
+// Splits the mapping key and the rest of the line
+func yamlKey(line string) (key, rest string, found bool) {
+	if strings.HasPrefix(line, "\"") || strings.HasPrefix(line, "'") {
+		end := yamlQuoteEnd(line)
+		if end < 0 || !strings.HasPrefix(line[end:], ":") {
+			return "", "", false
+		}
+		value, err := yamlScalar(line[:end])
+		if after := line[end+1:]; err == nil && (after == "" || after[0] == ' ') {
+			return ToString(value, ""), strings.TrimSpace(after), true
+		}
+		return "", "", false
+	}
+	if strings.HasPrefix(line, "[") || strings.HasPrefix(line, "{") {
+		return "", "", false
+	}
+	if index := strings.Index(line+" ", ": "); index > 0 {
+		return strings.TrimSpace(line[:index]), strings.TrimSpace(line[min(index+2, len(line)):]), true
+	}
+	return "", "", false
+}
+
+// Returns the end position of the leading quoted value, or -1 if the quote is not closed
+func yamlQuoteEnd(text string) int {
+	quote := text[0]
+	for index := 1; index < len(text); index++ {
+		switch {
+		case text[index] == '\\' && quote == '"':
+			index++
+		case text[index] == quote && quote == '\'' && index+1 < len(text) && text[index+1] == '\'':
+			index++
+		case text[index] == quote:
+			return index + 1
+		}
+	}
+	return -1
+}
+
+// Parses an inline (flow or scalar) value
+func yamlValue(text string) (result any, err error) {
+	if strings.HasPrefix(text, "[") || strings.HasPrefix(text, "{") {
+		var rest string
+		if result, rest, err = yamlFlow(text); err == nil && strings.TrimSpace(rest) != "" {
+			err = fmt.Errorf("invalid flow value: %s", text)
+		}
+		return result, err
+	}
+	return yamlScalar(text)
+}
+
+// Parses a flow collection or a flow scalar and returns the rest of the text
+func yamlFlow(text string) (result any, rest string, err error) {
+	text = strings.TrimLeft(text, " ")
+	switch {
+	case strings.HasPrefix(text, "["):
+		items := []any{}
+		rest = strings.TrimLeft(text[1:], " ")
+		for !strings.HasPrefix(rest, "]") {
+			var item any
+			if item, rest, err = yamlFlow(rest); err != nil {
+				return nil, rest, err
+			}
+			items = append(items, item)
+			if rest, err = yamlFlowNext(rest, ']'); err != nil {
+				return nil, rest, err
+			}
+		}
+		return items, rest[1:], nil
+
+	case strings.HasPrefix(text, "{"):
+		items := map[string]any{}
+		rest = strings.TrimLeft(text[1:], " ")
+		for !strings.HasPrefix(rest, "}") {
+			var key, item any
+			if key, rest, err = yamlFlow(rest); err != nil {
+				return nil, rest, err
+			}
+			if rest = strings.TrimLeft(rest, " "); !strings.HasPrefix(rest, ":") {
+				return nil, rest, fmt.Errorf("invalid flow mapping: %s", text)
+			}
+			if item, rest, err = yamlFlow(rest[1:]); err != nil {
+				return nil, rest, err
+			}
+			items[ToString(key, "")] = item
+			if rest, err = yamlFlowNext(rest, '}'); err != nil {
+				return nil, rest, err
+			}
+		}
+		return items, rest[1:], nil
+
+	case strings.HasPrefix(text, "\"") || strings.HasPrefix(text, "'"):
+		end := yamlQuoteEnd(text)
+		if end < 0 {
+			return nil, text, fmt.Errorf("unterminated quoted value: %s", text)
+		}
+		result, err = yamlScalar(text[:end])
+		return result, text[end:], err
+
+	default:
+		end := strings.IndexAny(text, ",]}")
+		if index := strings.Index(text, ": "); index >= 0 && (end < 0 || index < end) {
+			end = index
+		}
+		if end < 0 {
+			return nil, text, fmt.Errorf("unterminated flow value: %s", text)
+		}
+		result, err = yamlScalar(strings.TrimSpace(text[:end]))
+		return result, text[end:], err
+	}
+}
+
+// Skips the separator of the flow collection items
+func yamlFlowNext(text string, end byte) (rest string, err error) {
+	rest = strings.TrimLeft(text, " ")
+	if strings.HasPrefix(rest, ",") {
+		return strings.TrimLeft(rest[1:], " "), nil
+	}
+	if rest == "" || rest[0] != end {
+		return rest, fmt.Errorf("invalid flow collection: %s", text)
+	}
+	return rest, nil
+}
+
+func yamlScalar(text string) (any, error) {
+	switch {
+	case strings.HasPrefix(text, "\""):
+		if value, err := strconv.Unquote(text); err == nil {
+			return value, nil
+		}
+		return nil, fmt.Errorf("invalid quoted value: %s", text)
+	case strings.HasPrefix(text, "'"):
+		if len(text) < 2 || !strings.HasSuffix(text, "'") {
+			return nil, fmt.Errorf("invalid quoted value: %s", text)
+		}
+		return strings.ReplaceAll(text[1:len(text)-1], "''", "'"), nil
+	case text == "" || text == "~" || text == "null":
+		return nil, nil
+	case text == "true" || text == "false":
+		return text == "true", nil
+	case yamlNumber.MatchString(text):
+		return strconv.ParseFloat(text, 64)
+	}
+	return text, nil
+}
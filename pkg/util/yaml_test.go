@@ -0,0 +1,118 @@
+package component
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseYAML(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want any
+	}{
+		{
+			name: "empty",
+			data: "# comment only\n",
+			want: nil,
+		},
+		{
+			name: "scalar",
+			data: "---\nvalue\n...",
+			want: "value",
+		},
+		{
+			name: "mapping",
+			data: "id: form # comment\r\ntitle: \"Customer: #1\"\nicon: 'it''s'\nmodal: true\ncount: -1.5e2\n" +
+				"url: http://localhost\nempty:\nnull: ~\n\"quoted key\": null\n'it''s': key\n",
+			want: map[string]any{
+				"id": "form", "title": "Customer: #1", "icon": "it's", "modal": true, "count": float64(-150),
+				"url": "http://localhost", "empty": nil, "null": nil, "quoted key": nil, "it's": "key",
+			},
+		},
+		{
+			name: "sequence",
+			data: "rows:\n- columns:\n    - label: Name\n      value: {type: text, value: {name: name}}\n  full: true\n" +
+				"-\n  columns: []\n- - a\n  - b\n-\nlist: [1, \"two\", 'three', [false]]\n",
+			want: map[string]any{
+				"rows": []any{
+					map[string]any{
+						"columns": []any{map[string]any{
+							"label": "Name", "value": map[string]any{"type": "text", "value": map[string]any{"name": "name"}},
+						}},
+						"full": true,
+					},
+					map[string]any{"columns": []any{}},
+					[]any{"a", "b"},
+					nil,
+				},
+				"list": []any{float64(1), "two", "three", []any{false}},
+			},
+		},
+		{
+			name: "block",
+			data: "text: |\n  line 1\n    line 2\n\n  line 3\n\nfolded: >-\n  a\n  b\n\n  c\nempty: |\nnext: value",
+			want: map[string]any{
+				"text": "line 1\n  line 2\n\nline 3\n", "folded": "a b\nc", "empty": "", "next": "value",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseYAML([]byte(tt.data))
+			if err != nil {
+				t.Fatalf("ParseYAML() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseYAML() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseYAML_error(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "indentation", data: "key: value\n  next: value"},
+		{name: "sequence", data: "- a\n  - b"},
+		{name: "mapping", data: "key: value\nscalar"},
+		{name: "duplicate", data: "key: 1\nkey: 2"},
+		{name: "child", data: "key:\n  - a\n    - b"},
+		{name: "item", data: "- key: [1"},
+		{name: "flow", data: "key: [1] 2"},
+		{name: "flow_item", data: "key: [\"1]"},
+		{name: "flow_separator", data: "key: [\"1\" 2]"},
+		{name: "flow_mapping", data: "key: {a b}"},
+		{name: "flow_key", data: "key: {\"a: b}"},
+		{name: "flow_value", data: "key: {a: [1}"},
+		{name: "flow_end", data: "key: {a: 1"},
+		{name: "flow_mapping_separator", data: "key: {a: \"1\" b}"},
+		{name: "document", data: "key: value\n- item"},
+		{name: "quoted", data: "key: \"\\x\""},
+		{name: "single_quoted", data: "key: 'value"},
+		{name: "number", data: "key: [1e999]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseYAML([]byte(tt.data)); err == nil {
+				t.Error("ParseYAML() error = nil")
+			}
+		})
+	}
+}
+
+func TestYamlKey(t *testing.T) {
+	for _, line := range []string{`"key`, `"key"x`, `"key":x`, `"\x": value`, "[a]: b", "{a: b}", "scalar"} {
+		if _, _, found := yamlKey(line); found {
+			t.Errorf("yamlKey(%s) found", line)
+		}
+	}
+	if key, rest, found := yamlKey(`'a # b': "c # d" # comment`); !found || key != "a # b" || rest != `"c # d" # comment` {
+		t.Errorf("yamlKey() = %s, %s", key, rest)
+	}
+	if line := yamlStripComment(`key: "a \" # b" # comment`); line != `key: "a \" # b"` {
+		t.Errorf("yamlStripComment() = %s", line)
+	}
+}
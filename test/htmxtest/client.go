@@ -0,0 +1,517 @@
+package htmxtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// The htmx request attributes and their http methods
+var requestAttrs []string = []string{"hx-get", "hx-post", "hx-put", "hx-patch", "hx-delete"}
+
+// The htmx swap styles
+var swapStyles []string = []string{
+	"innerHTML", "outerHTML", "textContent", "beforebegin", "afterbegin", "beforeend", "afterend", "delete", "none",
+}
+
+/*
+Client is a headless htmx browser of an http.Handler. It loads the page into an in-memory document, and the
+user events (Click, Change, Submit) send the htmx requests of the elements the way htmx does: the request
+attributes (hx-post, hx-target, hx-swap, hx-vals and the inherited hx-headers) and the values of the form
+are read from the document, and the response is swapped into the document by the hx-target and hx-swap
+attributes and the HX-Retarget, HX-Reswap response headers. The out-of-band (hx-swap-oob) elements of the
+response are swapped by their own targets.
+
+For example:
+
+	client := htmxtest.NewClient(mux)
+	if err := client.Get("/"); err != nil {
+		t.Fatal(err)
+	}
+	client.Change("login_username", "admin")
+	client.Click("login_btn_login")
+	if client.ByID("toast-msg").TextContent() != "" {
+		...
+	}
+
+The hx-trigger filters and the javascript (js:) values of the hx-vals attribute are not evaluated.
+*/
+type Client struct {
+	Handler http.Handler
+	// The loaded page
+	Document *Node
+	// The URL of the loaded page. It is sent in the HX-Current-URL request header.
+	URL string
+	// Additional request headers
+	Header http.Header
+	// The response of the last request
+	Response *http.Response
+}
+
+// NewClient returns a new Client of the handler
+func NewClient(handler http.Handler) *Client {
+	return &Client{Handler: handler, Document: &Node{Type: DocumentNode}, Header: http.Header{}}
+}
+
+func (c *Client) do(req *http.Request) (body string, err error) {
+	for key, values := range c.Header {
+		req.Header[key] = values
+	}
+	rec := httptest.NewRecorder()
+	c.Handler.ServeHTTP(rec, req)
+	c.Response = rec.Result()
+	data, _ := io.ReadAll(c.Response.Body)
+	if c.Response.StatusCode >= http.StatusBadRequest {
+		// htmx does not swap the error responses
+		return "", fmt.Errorf("htmxtest: %s %s: status %d", req.Method, req.URL, c.Response.StatusCode)
+	}
+	return string(data), nil
+}
+
+// The Get function loads the page of the path into the Document
+func (c *Client) Get(path string) (err error) {
+	var body string
+	if body, err = c.do(httptest.NewRequest(http.MethodGet, path, nil)); err == nil {
+		c.Document = ParseHTML(body)
+		c.URL = path
+	}
+	return err
+}
+
+// Returns the element of the Document with the id attribute or nil
+func (c *Client) ByID(id string) *Node {
+	return c.Document.ByID(id)
+}
+
+// Returns the first element of the Document with the name attribute or nil
+func (c *Client) ByName(name string) *Node {
+	return c.Document.ByName(name)
+}
+
+func (c *Client) element(id string) (*Node, error) {
+	if elt := c.ByID(id); elt != nil {
+		return elt, nil
+	}
+	return nil, fmt.Errorf("htmxtest: element not found: #%s", id)
+}
+
+// Returns the htmx http method and the request URL of the element
+func requestVerb(elt *Node) (method, path string) {
+	for _, attr := range requestAttrs {
+		if value, found := elt.LookupAttr(attr); found {
+			return strings.ToUpper(strings.TrimPrefix(attr, "hx-")), value
+		}
+	}
+	return "", ""
+}
+
+func hasRequest(elt *Node) bool {
+	method, _ := requestVerb(elt)
+	return method != ""
+}
+
+// Returns the trigger event names of the element. The default trigger is the change event of the
+// input elements, the submit event of the form and the click event of the other elements.
+func triggerEvents(elt *Node) (events []string) {
+	if value, found := elt.LookupAttr("hx-trigger"); found {
+		for _, trigger := range strings.Split(value, ",") {
+			if fields := strings.Fields(trigger); len(fields) > 0 {
+				events = append(events, strings.Split(fields[0], "[")[0])
+			}
+		}
+		return events
+	}
+	switch elt.Tag {
+	case "input", "select", "textarea":
+		return []string{"change"}
+	case "form":
+		return []string{"submit"}
+	}
+	return []string{"click"}
+}
+
+// The Click function sends the request of the element or its closest ancestor with a click trigger.
+func (c *Client) Click(id string) error {
+	elt, err := c.element(id)
+	if err != nil {
+		return err
+	}
+	source := elt.Closest(func(node *Node) bool {
+		return hasRequest(node) && slices.Contains(triggerEvents(node), "click")
+	})
+	if source == nil {
+		return fmt.Errorf("htmxtest: no click request: #%s", id)
+	}
+	return c.Trigger(source, nil)
+}
+
+/*
+The Change function sets the value of the input, textarea or select element and sends the request of
+the element or its closest ancestor with an htmx request attribute.
+*/
+func (c *Client) Change(id, value string) error {
+	elt, err := c.element(id)
+	if err != nil {
+		return err
+	}
+	elt.SetValue(value)
+	source := elt.Closest(hasRequest)
+	if source == nil {
+		return fmt.Errorf("htmxtest: no change request: #%s", id)
+	}
+	return c.Trigger(source, nil)
+}
+
+// The Submit function sends the request of the form element. The id can be the id of the form or an element of the form.
+func (c *Client) Submit(id string) error {
+	elt, err := c.element(id)
+	if err != nil {
+		return err
+	}
+	isForm := func(node *Node) bool {
+		return node.Tag == "form"
+	}
+	form := elt.Closest(isForm)
+	if form == nil {
+		form = elt.Find(isForm)
+	}
+	if form == nil || !hasRequest(form) {
+		return fmt.Errorf("htmxtest: no form request: #%s", id)
+	}
+	return c.Trigger(form, nil)
+}
+
+// Returns the value of the attribute of the element or its closest ancestor and the element of the attribute
+func inheritedAttr(elt *Node, key string) (value string, owner *Node) {
+	if owner = elt.Closest(func(node *Node) bool { return node.HasAttr(key) }); owner != nil {
+		return owner.GetAttr(key), owner
+	}
+	return "", nil
+}
+
+// Returns the values of the form elements with a name attribute
+func formValues(form *Node, values url.Values) {
+	for _, elt := range form.FindAll(func(node *Node) bool {
+		return slices.Contains([]string{"input", "select", "textarea"}, node.Tag) &&
+			node.GetAttr("name") != "" && !node.HasAttr("disabled")
+	}) {
+		switch strings.ToLower(elt.GetAttr("type")) {
+		case "checkbox", "radio":
+			if value, found := elt.LookupAttr("value"); elt.HasAttr("checked") {
+				if !found {
+					value = "on"
+				}
+				values.Add(elt.GetAttr("name"), value)
+			}
+		case "submit", "button", "reset", "image", "file":
+		default:
+			values.Add(elt.GetAttr("name"), elt.Value())
+		}
+	}
+}
+
+// Returns the parameters of the request. The values of the closest form are included in the not GET requests.
+func requestValues(source *Node, method string) url.Values {
+	values := url.Values{}
+	if form := source.Closest(func(node *Node) bool { return node.Tag == "form" }); form != nil && method != http.MethodGet {
+		formValues(form, values)
+	}
+	if name := source.GetAttr("name"); name != "" && source.Tag != "form" &&
+		slices.Contains([]string{"input", "select", "textarea", "button"}, source.Tag) {
+		values.Set(name, source.Value())
+	}
+	// the hx-vals values of the ancestors are overwritten by the closer elements
+	for node := source; node != nil; node = node.Parent {
+		if vals, found := node.LookupAttr("hx-vals"); found && !strings.HasPrefix(vals, "js:") &&
+			!strings.HasPrefix(vals, "javascript:") {
+			items := map[string]any{}
+			json.Unmarshal([]byte(vals), &items)
+			for key, value := range items {
+				if !values.Has(key) {
+					values.Set(key, fmt.Sprint(value))
+				}
+			}
+		}
+	}
+	return values
+}
+
+// Returns the hx-headers values of the element and its ancestors
+func requestHeaders(source *Node, header http.Header) {
+	for node := source; node != nil; node = node.Parent {
+		if value, found := node.LookupAttr("hx-headers"); found {
+			items := map[string]any{}
+			json.Unmarshal([]byte(value), &items)
+			for key, value := range items {
+				if header.Get(key) == "" {
+					header.Set(key, fmt.Sprint(value))
+				}
+			}
+		}
+	}
+}
+
+/*
+The Trigger function sends the htmx request of the source element with the additional values, and swaps the
+response into the Document.
+*/
+func (c *Client) Trigger(source *Node, values url.Values) (err error) {
+	method, path := requestVerb(source)
+	if method == "" {
+		return fmt.Errorf("htmxtest: missing htmx request attribute: <%s id=%q>", source.Tag, source.GetAttr("id"))
+	}
+	params := requestValues(source, method)
+	for key, value := range values {
+		params[key] = value
+	}
+	targetSelector, owner := inheritedAttr(source, "hx-target")
+	target := source
+	if owner != nil {
+		if target = c.Select(owner, targetSelector); target == nil {
+			return fmt.Errorf("htmxtest: target not found: %s", targetSelector)
+		}
+	}
+
+	var req *http.Request
+	if method == http.MethodGet {
+		if len(params) > 0 {
+			sep := "?"
+			if strings.Contains(path, "?") {
+				sep = "&"
+			}
+			path += sep + params.Encode()
+		}
+		req = httptest.NewRequest(method, path, nil)
+	} else {
+		req = httptest.NewRequest(method, path, strings.NewReader(params.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	req.Header.Set("HX-Request", "true")
+	req.Header.Set("HX-Current-URL", c.URL)
+	if id := source.GetAttr("id"); id != "" {
+		req.Header.Set("HX-Trigger", id)
+	}
+	if name := source.GetAttr("name"); name != "" {
+		req.Header.Set("HX-Trigger-Name", name)
+	}
+	if id := target.GetAttr("id"); id != "" {
+		req.Header.Set("HX-Target", id)
+	}
+	requestHeaders(source, req.Header)
+
+	var body string
+	if body, err = c.do(req); err != nil {
+		return err
+	}
+	return c.response(source, target, body)
+}
+
+// Processes the htmx response headers and swaps the response
+func (c *Client) response(source, target *Node, body string) error {
+	header := c.Response.Header
+	if redirect := header.Get("HX-Redirect"); redirect != "" {
+		return c.Get(redirect)
+	}
+	if header.Get("HX-Refresh") == "true" {
+		return c.Get(c.URL)
+	}
+	for _, key := range []string{"HX-Push-Url", "HX-Replace-Url"} {
+		if value := header.Get(key); value != "" && value != "false" {
+			c.URL = value
+		}
+	}
+	if c.Response.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	swap, _ := inheritedAttr(source, "hx-swap")
+	if reswap := header.Get("HX-Reswap"); reswap != "" {
+		swap = reswap
+	}
+	if retarget := header.Get("HX-Retarget"); retarget != "" {
+		if target = c.Select(source, retarget); target == nil {
+			return fmt.Errorf("htmxtest: target not found: %s", retarget)
+		}
+	}
+	return c.Swap(target, body, swap)
+}
+
+/*
+The Swap function swaps the html content into the target element of the Document. The out-of-band
+(hx-swap-oob) elements of the content are swapped by their own target. The swap can be any htmx swap
+style (innerHTML, outerHTML, beforeend...), and the default value is innerHTML. The target can be nil
+if the content contains only out-of-band elements (for example, a server-sent event message).
+*/
+func (c *Client) Swap(target *Node, content, swap string) (err error) {
+	fragment := ParseHTML(content)
+	for _, oob := range fragment.FindAll(func(node *Node) bool { return node.HasAttr("hx-swap-oob") }) {
+		// the nested out-of-band elements are swapped with their parent element
+		if root := oob.Closest(func(node *Node) bool { return node.Parent == fragment }); root != nil {
+			if err = c.swapOOB(oob); err != nil {
+				return err
+			}
+		}
+	}
+	style := swapStyle(swap)
+	if target == nil || style == "none" {
+		return nil
+	}
+	return swapNodes(target, fragment.Children, style)
+}
+
+func (c *Client) swapOOB(oob *Node) error {
+	value := oob.GetAttr("hx-swap-oob")
+	oob.RemoveAttr("hx-swap-oob")
+	oob.Parent.RemoveChild(oob)
+	style, selector, found := strings.Cut(value, ":")
+	if value == "true" {
+		style = "outerHTML"
+	}
+	if !found {
+		selector = "#" + oob.GetAttr("id")
+	}
+	target := c.Select(oob, selector)
+	if target == nil {
+		return fmt.Errorf("htmxtest: out-of-band target not found: %s", selector)
+	}
+	if style = swapStyle(style); style == "outerHTML" {
+		return swapNodes(target, []*Node{oob}, style)
+	}
+	return swapNodes(target, slices.Clone(oob.Children), style)
+}
+
+// Returns the swap style of the hx-swap value without the modifiers
+func swapStyle(swap string) string {
+	if fields := strings.Fields(swap); len(fields) > 0 {
+		for _, style := range swapStyles {
+			if strings.EqualFold(style, fields[0]) {
+				return style
+			}
+		}
+		return fields[0]
+	}
+	return "innerHTML"
+}
+
+func swapNodes(target *Node, nodes []*Node, style string) error {
+	nodes = slices.Clone(nodes)
+	parent := target.Parent
+	if parent == nil && slices.Contains([]string{"outerHTML", "beforebegin", "afterend", "delete"}, style) {
+		return fmt.Errorf("htmxtest: %s swap of the document", style)
+	}
+	switch style {
+	case "innerHTML":
+		target.Children = []*Node{}
+		for _, node := range nodes {
+			target.AppendChild(node)
+		}
+	case "textContent":
+		var sb strings.Builder
+		for _, node := range nodes {
+			node.writeText(&sb)
+		}
+		target.Children = []*Node{}
+		target.AppendChild(&Node{Type: TextNode, Text: strings.TrimSpace(sb.String())})
+	case "outerHTML", "beforebegin":
+		index := slices.Index(parent.Children, target)
+		for offset, node := range nodes {
+			parent.InsertChild(index+offset, node)
+		}
+		if style == "outerHTML" {
+			parent.RemoveChild(target)
+		}
+	case "afterbegin":
+		for offset, node := range nodes {
+			target.InsertChild(offset, node)
+		}
+	case "beforeend":
+		for _, node := range nodes {
+			target.AppendChild(node)
+		}
+	case "afterend":
+		index := slices.Index(parent.Children, target) + 1
+		for offset, node := range nodes {
+			parent.InsertChild(index+offset, node)
+		}
+	case "delete":
+		parent.RemoveChild(target)
+	default:
+		return fmt.Errorf("htmxtest: invalid swap style: %s", style)
+	}
+	return nil
+}
+
+/*
+The Select function returns the element of the extended htmx selector relative to the element: this,
+closest <selector>, find <selector>, next, previous, body, document, or a simple CSS selector (#id, .class,
+tag or [attr=value]) of the Document.
+*/
+func (c *Client) Select(elt *Node, selector string) *Node {
+	selector = strings.TrimSpace(selector)
+	keyword, rest, _ := strings.Cut(selector, " ")
+	switch keyword {
+	case "this":
+		return elt
+	case "body", "document":
+		if body := c.Document.Find(matchSelector("body")); body != nil {
+			return body
+		}
+		return c.Document
+	case "closest":
+		return elt.Closest(matchSelector(rest))
+	case "find":
+		for _, child := range elt.Children {
+			if node := child.Find(matchSelector(rest)); node != nil {
+				return node
+			}
+		}
+		return nil
+	case "next", "previous":
+		return siblingElement(elt, keyword == "next")
+	}
+	return c.Document.Find(matchSelector(selector))
+}
+
+func siblingElement(elt *Node, next bool) *Node {
+	if elt.Parent == nil {
+		return nil
+	}
+	step := 1
+	if !next {
+		step = -1
+	}
+	children := elt.Parent.Children
+	for index := slices.Index(children, elt) + step; index >= 0 && index < len(children); index += step {
+		if children[index].Type == ElementNode {
+			return children[index]
+		}
+	}
+	return nil
+}
+
+// Returns a match function of a simple CSS selector: #id, .class, tag or [attr] and [attr=value]
+func matchSelector(selector string) func(node *Node) bool {
+	selector = strings.TrimSpace(selector)
+	return func(node *Node) bool {
+		switch {
+		case selector == "":
+			return false
+		case strings.HasPrefix(selector, "#"):
+			return len(selector) > 1 && node.GetAttr("id") == selector[1:]
+		case strings.HasPrefix(selector, "."):
+			return slices.Contains(strings.Fields(node.GetAttr("class")), selector[1:])
+		case strings.HasPrefix(selector, "[") && strings.HasSuffix(selector, "]"):
+			key, value, found := strings.Cut(selector[1:len(selector)-1], "=")
+			if !found {
+				return node.HasAttr(key)
+			}
+			attr, exists := node.LookupAttr(key)
+			return exists && attr == strings.Trim(value, `"'`)
+		}
+		return node.Tag == strings.ToLower(selector)
+	}
+}
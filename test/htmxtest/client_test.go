@@ -0,0 +1,387 @@
+package htmxtest
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+const testPage = `<!DOCTYPE html><html><body hx-headers='{"X-Token":"body","X-Page":"index"}'>
+<div id="toast-msg"></div><ul id="list"><li id="item">1</li></ul><div id="result"></div>
+<form id="frm" hx-post="/echo" hx-target="#result" hx-headers='{"X-Token":"form"}'>
+	<input id="name" name="name" value="Joe">
+	<input id="agree" type="checkbox" name="agree" checked><input type="checkbox" name="skip">
+	<input type="radio" name="size" value="m" checked><input type="submit" name="submit" value="OK">
+	<input name="disabled" value="1" disabled><select name="group"><option>a</option></select>
+	<button id="btn" name="btn" value="ok" hx-post="/echo" hx-vals='{"extra":1}' hx-target="this"
+		hx-swap="outerHTML"><span id="btn_label">OK</span></button>
+</form>
+<div id="panel" hx-vals='{"outer":"o","q":"ignored"}' hx-target="#result">
+	<input id="search" name="q" value="x" hx-get="/echo" hx-trigger="keyup changed delay:500ms, , search" hx-vals="js:{a: 1}">
+	<select id="sel" name="sel" hx-get="/echo"><option>a</option><option>b</option></select>
+</div>
+<div id="nested"><form id="inner" hx-put="/echo"><input id="inner_name" name="inner" value="i"></form></div>
+<div id="plain"><span id="plain_label">plain</span></div><form id="nopost"><input id="nopost_name" name="n"></form>
+</body></html>`
+
+func testHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testPage)
+	})
+	mux.HandleFunc("GET /page", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<body><div id="page">`+r.Header.Get("X-Client")+`</div></body>`)
+	})
+	mux.HandleFunc("/echo", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		fmt.Fprintf(w, `<b id="echo">%s %s %s %s %s %s %s</b>`, r.Method, r.Form.Encode(), r.Header.Get("X-Token"),
+			r.Header.Get("X-Page"), r.Header.Get("HX-Trigger"), r.Header.Get("HX-Trigger-Name"), r.Header.Get("HX-Target"))
+	})
+	mux.HandleFunc("/oob", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<i>main</i><div id="toast-msg" hx-swap-oob="true">message<b id="item" hx-swap-oob="true">nested</b></div>`+
+			`<div hx-swap-oob="beforeend:#list"><li>2</li><li>3</li></div><p id="result" hx-swap-oob="innerHTML">oob</p>`)
+	})
+	mux.HandleFunc("/header", func(w http.ResponseWriter, r *http.Request) {
+		for key, value := range r.URL.Query() {
+			w.Header().Set(key, value[0])
+		}
+		fmt.Fprint(w, `<i id="header">header</i>`)
+	})
+	mux.HandleFunc("/nocontent", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/missing", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<div id="missing" hx-swap-oob="true"></div>`)
+	})
+	return mux
+}
+
+// Sets the request attribute of the #plain element
+func plainRequest(attr, value string) func(c *Client) {
+	return func(c *Client) {
+		c.ByID("plain").SetAttr(attr, value)
+	}
+}
+
+func TestClient(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(c *Client)
+		action  func(c *Client) error
+		check   func(c *Client) string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "submit",
+			action: func(c *Client) error { return c.Submit("name") },
+			check:  func(c *Client) string { return c.ByID("result").TextContent() },
+			want:   "POST agree=on&group=a&name=Joe&size=m form index frm result",
+		},
+		{
+			name:   "submit_inner",
+			action: func(c *Client) error { return c.Submit("nested") },
+			check:  func(c *Client) string { return c.ByID("inner").TextContent() },
+			want:   "PUT inner=i body index inner inner",
+		},
+		{
+			name: "click",
+			action: func(c *Client) error {
+				c.ByID("name").SetValue("Jane")
+				return c.Click("btn_label")
+			},
+			check: func(c *Client) string { return c.ByID("echo").TextContent() + " " + c.ByID("echo").Parent.Tag },
+			want:  "POST agree=on&btn=ok&extra=1&group=a&name=Jane&size=m form index btn btn btn form",
+		},
+		{
+			name:   "change",
+			action: func(c *Client) error { return c.Change("sel", "b") },
+			check:  func(c *Client) string { return c.ByID("result").TextContent() },
+			want:   "GET outer=o&q=ignored&sel=b body index sel sel result",
+		},
+		{
+			name:   "change_name",
+			action: func(c *Client) error { return c.Change("search", "z") },
+			check:  func(c *Client) string { return c.ByName("q").Value() + " " + c.ByID("result").TextContent() },
+			want:   "z GET outer=o&q=z body index search q result",
+		},
+		{
+			name:   "change_ancestor",
+			action: func(c *Client) error { return c.Change("name", "Jane") },
+			check:  func(c *Client) string { return c.ByID("result").TextContent() },
+			want:   "POST agree=on&group=a&name=Jane&size=m form index frm result",
+		},
+		{
+			name:   "trigger",
+			action: func(c *Client) error { return c.Trigger(c.ByID("search"), url.Values{"q": {"y"}}) },
+			check:  func(c *Client) string { return c.ByID("result").TextContent() },
+			want:   "GET outer=o&q=y body index search q result",
+		},
+		{
+			name:   "client_header",
+			setup:  func(c *Client) { c.Header.Set("X-Client", "client") },
+			action: func(c *Client) error { return c.Get("/page") },
+			check:  func(c *Client) string { return c.ByID("page").TextContent() + " " + c.URL },
+			want:   "client /page",
+		},
+		{
+			name:   "oob",
+			setup:  plainRequest("hx-get", "/oob"),
+			action: func(c *Client) error { return c.Click("plain_label") },
+			check: func(c *Client) string {
+				return c.ByID("plain").InnerHTML() + c.ByID("toast-msg").OuterHTML() + c.ByID("list").InnerHTML() +
+					c.ByID("result").InnerHTML()
+			},
+			want: `<i>main</i><div id="toast-msg">message<b id="item" hx-swap-oob="true">nested</b></div>` +
+				`<li id="item">1</li><li>2</li><li>3</li>oob`,
+		},
+		{
+			name:    "oob_missing",
+			setup:   plainRequest("hx-get", "/missing"),
+			action:  func(c *Client) error { return c.Click("plain") },
+			wantErr: true,
+		},
+		{
+			name: "retarget",
+			setup: plainRequest("hx-get",
+				"/header?HX-Retarget=%23toast-msg&HX-Reswap=beforeend&HX-Push-Url=/pushed&HX-Replace-Url=false"),
+			action: func(c *Client) error { return c.Click("plain") },
+			check:  func(c *Client) string { return c.ByID("toast-msg").InnerHTML() + " " + c.URL },
+			want:   `<i id="header">header</i> /pushed`,
+		},
+		{
+			name:    "retarget_missing",
+			setup:   plainRequest("hx-get", "/header?HX-Retarget=%23unknown"),
+			action:  func(c *Client) error { return c.Click("plain") },
+			wantErr: true,
+		},
+		{
+			name: "reswap_none",
+			setup: func(c *Client) {
+				c.ByID("plain").SetAttr("hx-get", "/header?HX-Reswap=none")
+				c.ByID("plain").SetAttr("hx-vals", `{"X-Value":1}`)
+			},
+			action: func(c *Client) error { return c.Click("plain") },
+			check: func(c *Client) string {
+				return c.ByID("plain").TextContent() + " " + c.Response.Header.Get("X-Value")
+			},
+			want: "plain 1",
+		},
+		{
+			name:   "redirect",
+			setup:  plainRequest("hx-get", "/header?HX-Redirect=/page"),
+			action: func(c *Client) error { return c.Click("plain") },
+			check:  func(c *Client) string { return c.URL },
+			want:   "/page",
+		},
+		{
+			name: "refresh",
+			setup: func(c *Client) {
+				c.ByID("plain").SetAttr("hx-get", "/header?HX-Refresh=true")
+				c.ByID("toast-msg").SetValue("message")
+			},
+			action: func(c *Client) error { return c.Click("plain") },
+			check:  func(c *Client) string { return c.ByID("toast-msg").GetAttr("value") + c.ByID("plain").TextContent() },
+			want:   "plain",
+		},
+		{
+			name:   "nocontent",
+			setup:  plainRequest("hx-delete", "/nocontent"),
+			action: func(c *Client) error { return c.Click("plain") },
+			check:  func(c *Client) string { return c.ByID("plain").TextContent() },
+			want:   "plain",
+		},
+		{
+			name:    "status_error",
+			setup:   plainRequest("hx-patch", "/unknown"),
+			action:  func(c *Client) error { return c.Click("plain") },
+			wantErr: true,
+		},
+		{
+			name:    "click_trigger",
+			action:  func(c *Client) error { return c.Click("search") },
+			wantErr: true,
+		},
+		{
+			name:    "click_select",
+			action:  func(c *Client) error { return c.Click("sel") },
+			wantErr: true,
+		},
+		{
+			name:    "click_form",
+			action:  func(c *Client) error { return c.Click("name") },
+			wantErr: true,
+		},
+		{
+			name:    "get_error",
+			action:  func(c *Client) error { return c.Get("/unknown") },
+			wantErr: true,
+		},
+		{
+			name:    "target_missing",
+			setup:   plainRequest("hx-get", "/echo"),
+			action:  func(c *Client) error { c.ByID("plain").SetAttr("hx-target", "#unknown"); return c.Click("plain") },
+			wantErr: true,
+		},
+		{
+			name:    "click_missing",
+			action:  func(c *Client) error { return c.Click("unknown") },
+			wantErr: true,
+		},
+		{
+			name:    "click_request",
+			action:  func(c *Client) error { return c.Click("plain") },
+			wantErr: true,
+		},
+		{
+			name:    "change_missing",
+			action:  func(c *Client) error { return c.Change("unknown", "") },
+			wantErr: true,
+		},
+		{
+			name:    "change_request",
+			action:  func(c *Client) error { return c.Change("nopost_name", "") },
+			wantErr: true,
+		},
+		{
+			name:    "submit_missing",
+			action:  func(c *Client) error { return c.Submit("unknown") },
+			wantErr: true,
+		},
+		{
+			name:    "submit_request",
+			action:  func(c *Client) error { return c.Submit("nopost") },
+			wantErr: true,
+		},
+		{
+			name:    "submit_form",
+			action:  func(c *Client) error { return c.Submit("plain") },
+			wantErr: true,
+		},
+		{
+			name:    "trigger_request",
+			action:  func(c *Client) error { return c.Trigger(c.ByID("plain"), nil) },
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClient(testHandler())
+			if err := client.Get("/"); err != nil {
+				t.Fatal(err)
+			}
+			if tt.setup != nil {
+				tt.setup(client)
+			}
+			err := tt.action(client)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Client error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.check != nil {
+				if got := tt.check(client); got != tt.want {
+					t.Errorf("Client = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestClient_Swap(t *testing.T) {
+	tests := []struct {
+		name    string
+		swap    string
+		target  string
+		want    string
+		wantErr bool
+	}{
+		{name: "default", swap: "", target: "t", want: `<b id="t"><i>n</i></b>`},
+		{name: "innerHTML", swap: "innerhtml swap:1s", target: "t", want: `<b id="t"><i>n</i></b>`},
+		{name: "outerHTML", swap: "outerHTML", target: "t", want: `<i>n</i>`},
+		{name: "textContent", swap: "textContent", target: "t", want: `<b id="t">n</b>`},
+		{name: "beforebegin", swap: "beforebegin", target: "t", want: `<i>n</i><b id="t">x</b>`},
+		{name: "afterbegin", swap: "afterbegin", target: "t", want: `<b id="t"><i>n</i>x</b>`},
+		{name: "beforeend", swap: "beforeend", target: "t", want: `<b id="t">x<i>n</i></b>`},
+		{name: "afterend", swap: "afterend", target: "t", want: `<b id="t">x</b><i>n</i>`},
+		{name: "delete", swap: "delete", target: "t", want: ``},
+		{name: "none", swap: "none", target: "t", want: `<b id="t">x</b>`},
+		{name: "nil_target", swap: "", want: `<b id="t">x</b>`},
+		{name: "invalid", swap: "replace", target: "t", wantErr: true},
+		{name: "document", swap: "outerHTML", target: "document", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &Client{Document: ParseHTML(`<div id="p"><b id="t">x</b></div>`)}
+			var target *Node
+			switch tt.target {
+			case "document":
+				target = client.Document
+			case "t":
+				target = client.ByID("t")
+			}
+			err := client.Swap(target, "<i>n</i>", tt.swap)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Client.Swap() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := client.ByID("p").InnerHTML(); !tt.wantErr && got != tt.want {
+				t.Errorf("Client.Swap() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_Select(t *testing.T) {
+	client := &Client{Document: ParseHTML(`<body><div id="p" class="row main"><b id="a">a</b> <i id="b" data-x="1">b</i>` +
+		`<span id="c" data-y>c</span></div><p></p></body>`)}
+	elt := client.ByID("b")
+	tests := []struct {
+		selector string
+		want     string
+	}{
+		{selector: "this", want: "b"},
+		{selector: "body", want: "body"},
+		{selector: "closest .main", want: "p"},
+		{selector: "closest div", want: "p"},
+		{selector: "find b", want: ""},
+		{selector: "next", want: "c"},
+		{selector: "previous", want: "a"},
+		{selector: "#c", want: "c"},
+		{selector: "#", want: ""},
+		{selector: "", want: ""},
+		{selector: "[data-x='1']", want: "b"},
+		{selector: "[data-x=2]", want: ""},
+		{selector: "[data-y]", want: "c"},
+		{selector: "SPAN", want: "c"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			got := ""
+			if node := client.Select(elt, tt.selector); node != nil {
+				got = node.GetAttr("id")
+				if node.Tag == "body" {
+					got = node.Tag
+				}
+			}
+			if got != tt.want {
+				t.Errorf("Client.Select() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if node := client.Select(client.ByID("p"), "find [data-y]"); node != client.ByID("c") {
+		t.Errorf("Client.Select() = %v", node)
+	}
+	if node := client.Select(client.ByID("c"), "next"); node != nil {
+		t.Errorf("Client.Select() = %v", node)
+	}
+	if node := client.Select(client.Document, "previous"); node != nil {
+		t.Errorf("Client.Select() = %v", node)
+	}
+	fragment := &Client{Document: ParseHTML(`<div></div>`)}
+	if node := fragment.Select(elt, "document"); node != fragment.Document {
+		t.Errorf("Client.Select() = %v", node)
+	}
+	if !strings.Contains(client.ByID("p").OuterHTML(), `class="row main"`) {
+		t.Errorf("Client.Select() = %v", client.ByID("p").OuterHTML())
+	}
+}
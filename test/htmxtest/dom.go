@@ -0,0 +1,415 @@
+/* Headless htmx test client
+ */
+package htmxtest
+
+import (
+	"html"
+	"slices"
+	"strings"
+)
+
+// [Node] types
+const (
+	DocumentNode = iota
+	ElementNode
+	TextNode
+	CommentNode
+)
+
+// The elements without end tag
+var voidElements []string = []string{
+	"area", "base", "br", "col", "embed", "hr", "img", "input", "link", "meta", "source", "track", "wbr",
+}
+
+// The elements with unparsed text content
+var rawTextElements []string = []string{"script", "style", "textarea", "title"}
+
+// The elements that are closed by the next sibling element with the same tag
+var siblingElements []string = []string{"li", "option", "p", "tr", "td", "th", "dt", "dd"}
+
+// Attribute of an element [Node]
+type Attr struct {
+	Key string
+	Val string
+}
+
+// Node of the in-memory document
+type Node struct {
+	// [Node] type constants: [DocumentNode], [ElementNode], [TextNode], [CommentNode]
+	Type int
+	// The lower case tag name of the element
+	Tag string
+	// The unescaped text of the text and comment nodes
+	Text     string
+	Attr     []Attr
+	Parent   *Node
+	Children []*Node
+}
+
+/*
+ParseHTML parses an html document or fragment into a [DocumentNode]. It is a lenient parser of the
+html output of the components: the void and raw text elements are recognized, the not closed elements
+are closed by the end tag of their parent, and the unmatched end tags are ignored.
+*/
+func ParseHTML(src string) *Node {
+	doc := &Node{Type: DocumentNode}
+	current := doc
+	for pos := 0; pos < len(src); {
+		switch {
+		case strings.HasPrefix(src[pos:], "<!--"):
+			end := strings.Index(src[pos+4:], "-->")
+			if end < 0 {
+				end = len(src) - pos - 4
+			}
+			current.AppendChild(&Node{Type: CommentNode, Text: src[pos+4 : pos+4+end]})
+			pos = min(pos+4+end+3, len(src))
+
+		case strings.HasPrefix(src[pos:], "</"):
+			end := strings.IndexByte(src[pos:], '>')
+			if end < 0 {
+				end = len(src) - pos - 1
+			}
+			tag := strings.ToLower(strings.TrimSpace(src[pos+2 : pos+end]))
+			for node := current; node != doc; node = node.Parent {
+				if node.Tag == tag {
+					current = node.Parent
+					break
+				}
+			}
+			pos += end + 1
+
+		case strings.HasPrefix(src[pos:], "<!") || strings.HasPrefix(src[pos:], "<?"):
+			// doctype and processing instruction
+			end := strings.IndexByte(src[pos:], '>')
+			if end < 0 {
+				end = len(src) - pos - 1
+			}
+			pos += end + 1
+
+		case src[pos] == '<' && pos+1 < len(src) && isLetter(src[pos+1]):
+			var node *Node
+			var selfClosing bool
+			node, selfClosing, pos = parseStartTag(src, pos+1)
+			if slices.Contains(siblingElements, node.Tag) && current.Tag == node.Tag {
+				current = current.Parent
+			}
+			current.AppendChild(node)
+			switch {
+			case slices.Contains(rawTextElements, node.Tag):
+				end := strings.Index(strings.ToLower(src[pos:]), "</"+node.Tag)
+				if end < 0 {
+					end = len(src) - pos
+				}
+				if text := src[pos : pos+end]; text != "" {
+					if node.Tag != "script" && node.Tag != "style" {
+						text = html.UnescapeString(text)
+					}
+					node.AppendChild(&Node{Type: TextNode, Text: text})
+				}
+				pos += end
+				if close := strings.IndexByte(src[pos:], '>'); close >= 0 {
+					pos += close + 1
+				}
+			case !selfClosing && !slices.Contains(voidElements, node.Tag):
+				current = node
+			}
+
+		default:
+			end := strings.IndexByte(src[pos+1:], '<')
+			if end < 0 {
+				end = len(src) - pos - 1
+			}
+			current.AppendChild(&Node{Type: TextNode, Text: html.UnescapeString(src[pos : pos+1+end])})
+			pos += end + 1
+		}
+	}
+	return doc
+}
+
+func isLetter(ch byte) bool {
+	return (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z')
+}
+
+func isSpace(ch byte) bool {
+	return ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r' || ch == '\f'
+}
+
+// Parses the tag name and the attributes of a start tag. The pos is the position after the < character.
+func parseStartTag(src string, pos int) (node *Node, selfClosing bool, next int) {
+	start := pos
+	for pos < len(src) && !isSpace(src[pos]) && src[pos] != '>' && src[pos] != '/' {
+		pos++
+	}
+	node = &Node{Type: ElementNode, Tag: strings.ToLower(src[start:pos])}
+	for pos < len(src) {
+		for pos < len(src) && isSpace(src[pos]) {
+			pos++
+		}
+		if pos >= len(src) {
+			break
+		}
+		if src[pos] == '>' {
+			return node, selfClosing, pos + 1
+		}
+		if src[pos] == '/' {
+			selfClosing = true
+			pos++
+			continue
+		}
+		start = pos
+		for pos < len(src) && !isSpace(src[pos]) && !strings.ContainsRune("=>/", rune(src[pos])) {
+			pos++
+		}
+		attr := Attr{Key: strings.ToLower(src[start:pos])}
+		for pos < len(src) && isSpace(src[pos]) {
+			pos++
+		}
+		if pos < len(src) && src[pos] == '=' {
+			pos++
+			for pos < len(src) && isSpace(src[pos]) {
+				pos++
+			}
+			if pos < len(src) && (src[pos] == '"' || src[pos] == '\'') {
+				quote := src[pos]
+				end := strings.IndexByte(src[pos+1:], quote)
+				if end < 0 {
+					end = len(src) - pos - 1
+				}
+				attr.Val = html.UnescapeString(src[pos+1 : pos+1+end])
+				pos = min(pos+end+2, len(src))
+			} else {
+				start = pos
+				for pos < len(src) && !isSpace(src[pos]) && src[pos] != '>' {
+					pos++
+				}
+				attr.Val = html.UnescapeString(src[start:pos])
+			}
+		}
+		if attr.Key != "" && !node.HasAttr(attr.Key) {
+			node.Attr = append(node.Attr, attr)
+		}
+		selfClosing = false
+	}
+	return node, selfClosing, pos
+}
+
+// Returns the value of the attribute of the element
+func (n *Node) GetAttr(key string) (value string) {
+	value, _ = n.LookupAttr(key)
+	return value
+}
+
+// Returns the value of the attribute and whether the element has the attribute
+func (n *Node) LookupAttr(key string) (string, bool) {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			return attr.Val, true
+		}
+	}
+	return "", false
+}
+
+func (n *Node) HasAttr(key string) bool {
+	_, found := n.LookupAttr(key)
+	return found
+}
+
+// Sets or adds the attribute of the element
+func (n *Node) SetAttr(key, value string) {
+	for index, attr := range n.Attr {
+		if attr.Key == key {
+			n.Attr[index].Val = value
+			return
+		}
+	}
+	n.Attr = append(n.Attr, Attr{Key: key, Val: value})
+}
+
+func (n *Node) RemoveAttr(key string) {
+	n.Attr = slices.DeleteFunc(n.Attr, func(attr Attr) bool {
+		return attr.Key == key
+	})
+}
+
+func (n *Node) AppendChild(child *Node) {
+	n.InsertChild(len(n.Children), child)
+}
+
+// Inserts the child node at the index of the children
+func (n *Node) InsertChild(index int, child *Node) {
+	if child.Parent != nil {
+		child.Parent.RemoveChild(child)
+	}
+	child.Parent = n
+	n.Children = slices.Insert(n.Children, index, child)
+}
+
+func (n *Node) RemoveChild(child *Node) {
+	if index := slices.Index(n.Children, child); index >= 0 {
+		n.Children = slices.Delete(n.Children, index, index+1)
+		child.Parent = nil
+	}
+}
+
+// Returns the element and all descendant elements that match the function, in document order
+func (n *Node) FindAll(match func(node *Node) bool) (result []*Node) {
+	if n.Type == ElementNode && match(n) {
+		result = append(result, n)
+	}
+	for _, child := range n.Children {
+		result = append(result, child.FindAll(match)...)
+	}
+	return result
+}
+
+// Returns the first matching element or nil
+func (n *Node) Find(match func(node *Node) bool) *Node {
+	if n.Type == ElementNode && match(n) {
+		return n
+	}
+	for _, child := range n.Children {
+		if node := child.Find(match); node != nil {
+			return node
+		}
+	}
+	return nil
+}
+
+// Returns the element with the id attribute or nil
+func (n *Node) ByID(id string) *Node {
+	return n.Find(func(node *Node) bool {
+		return node.GetAttr("id") == id
+	})
+}
+
+// Returns the first element with the name attribute or nil
+func (n *Node) ByName(name string) *Node {
+	return n.Find(func(node *Node) bool {
+		return node.GetAttr("name") == name
+	})
+}
+
+// Returns the elements with the tag name
+func (n *Node) ByTag(tag string) []*Node {
+	return n.FindAll(func(node *Node) bool {
+		return node.Tag == tag
+	})
+}
+
+// Returns the closest element (the node itself or an ancestor) that matches the function, or nil
+func (n *Node) Closest(match func(node *Node) bool) *Node {
+	for node := n; node != nil; node = node.Parent {
+		if node.Type == ElementNode && match(node) {
+			return node
+		}
+	}
+	return nil
+}
+
+// Returns the whitespace normalized text content of the node
+func (n *Node) TextContent() string {
+	var sb strings.Builder
+	n.writeText(&sb)
+	return strings.Join(strings.Fields(sb.String()), " ")
+}
+
+func (n *Node) writeText(sb *strings.Builder) {
+	if n.Type == TextNode {
+		sb.WriteString(n.Text + " ")
+	}
+	for _, child := range n.Children {
+		child.writeText(sb)
+	}
+}
+
+// Returns the current value of the input, textarea and select elements
+func (n *Node) Value() string {
+	switch n.Tag {
+	case "textarea":
+		var sb strings.Builder
+		for _, child := range n.Children {
+			sb.WriteString(child.Text)
+		}
+		return sb.String()
+	case "select":
+		options := n.ByTag("option")
+		for _, option := range options {
+			if option.HasAttr("selected") {
+				return option.optionValue()
+			}
+		}
+		if len(options) > 0 {
+			return options[0].optionValue()
+		}
+		return ""
+	}
+	return n.GetAttr("value")
+}
+
+func (n *Node) optionValue() string {
+	if value, found := n.LookupAttr("value"); found {
+		return value
+	}
+	return n.TextContent()
+}
+
+// Sets the value of the input, textarea and select elements
+func (n *Node) SetValue(value string) {
+	switch n.Tag {
+	case "textarea":
+		n.Children = []*Node{}
+		n.AppendChild(&Node{Type: TextNode, Text: value})
+	case "select":
+		for _, option := range n.ByTag("option") {
+			if option.optionValue() == value {
+				option.SetAttr("selected", "")
+			} else {
+				option.RemoveAttr("selected")
+			}
+		}
+	default:
+		n.SetAttr("value", value)
+	}
+}
+
+// Returns the html code of the children of the node
+func (n *Node) InnerHTML() string {
+	var sb strings.Builder
+	for _, child := range n.Children {
+		child.render(&sb)
+	}
+	return sb.String()
+}
+
+// Returns the html code of the node
+func (n *Node) OuterHTML() string {
+	var sb strings.Builder
+	n.render(&sb)
+	return sb.String()
+}
+
+func (n *Node) render(sb *strings.Builder) {
+	switch n.Type {
+	case TextNode:
+		if n.Parent != nil && (n.Parent.Tag == "script" || n.Parent.Tag == "style") {
+			sb.WriteString(n.Text)
+		} else {
+			sb.WriteString(html.EscapeString(n.Text))
+		}
+	case CommentNode:
+		sb.WriteString("<!--" + n.Text + "-->")
+	case ElementNode:
+		sb.WriteString("<" + n.Tag)
+		for _, attr := range n.Attr {
+			sb.WriteString(" " + attr.Key + `="` + html.EscapeString(attr.Val) + `"`)
+		}
+		sb.WriteString(">")
+		if slices.Contains(voidElements, n.Tag) {
+			return
+		}
+		sb.WriteString(n.InnerHTML())
+		sb.WriteString("</" + n.Tag + ">")
+	default:
+		sb.WriteString(n.InnerHTML())
+	}
+}
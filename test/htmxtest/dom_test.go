@@ -0,0 +1,138 @@
+package htmxtest
+
+import (
+	"testing"
+)
+
+func TestParseHTML(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{
+			name: "document",
+			src: `<!DOCTYPE html><html><head><title>A &amp; B</title><style>p > b {}</style></head>` +
+				`<body class=main><!-- comment --><p>1<p>2</p><br/><input name='a' value="x &lt; y" disabled>` +
+				`<script>if (a < b) {}</script></body></html>`,
+			want: `<html><head><title>A &amp; B</title><style>p > b {}</style></head>` +
+				`<body class="main"><!-- comment --><p>1</p><p>2</p><br><input name="a" value="x &lt; y" disabled="">` +
+				`<script>if (a < b) {}</script></body></html>`,
+		},
+		{
+			name: "unmatched",
+			src:  `<div><span>text</div></b><ul><li>1<li>2</ul>`,
+			want: `<div><span>text</span></div><ul><li>1</li><li>2</li></ul>`,
+		},
+		{
+			name: "attributes",
+			src:  `<div ID="a" id="b" data-x = y hidden / class="c"><img src="i.png" /></div>`,
+			want: `<div id="a" data-x="y" hidden="" class="c"><img src="i.png"></div>`,
+		},
+		{
+			name: "unclosed",
+			src:  `<div a="1`,
+			want: `<div a="1"></div>`,
+		},
+		{
+			name: "unclosed_tag",
+			src:  `<div a`,
+			want: `<div a=""></div>`,
+		},
+		{
+			name: "unclosed_tag_space",
+			src:  `<div `,
+			want: `<div></div>`,
+		},
+		{
+			name: "unclosed_comment",
+			src:  `<!-- comment`,
+			want: `<!-- comment-->`,
+		},
+		{
+			name: "unclosed_end",
+			src:  `<div>text</div`,
+			want: `<div>text</div>`,
+		},
+		{
+			name: "unclosed_doctype",
+			src:  `<!doctype`,
+			want: ``,
+		},
+		{
+			name: "unclosed_raw",
+			src:  `<textarea>a &gt; b`,
+			want: `<textarea>a &gt; b</textarea>`,
+		},
+		{
+			name: "text",
+			src:  `a &amp; b`,
+			want: `a &amp; b`,
+		},
+		{
+			name: "empty_raw",
+			src:  `<script></script><p>text < 1</p>`,
+			want: `<script></script><p>text &lt; 1</p>`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseHTML(tt.src).OuterHTML(); got != tt.want {
+				t.Errorf("ParseHTML() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNode(t *testing.T) {
+	doc := ParseHTML(`<form id="frm"><input id="name" name="name" value="Joe"><textarea id="memo">a</textarea>` +
+		`<select id="sel"><option>A</option><option value="b" selected>B</option></select>` +
+		`<select id="first"><option> C </option></select><select id="empty"></select></form>`)
+
+	name := doc.ByID("name")
+	if name == nil || doc.ByName("name") != name || len(doc.ByTag("select")) != 3 || doc.ByID("missing") != nil {
+		t.Fatalf("Node.ByID() = %v", name)
+	}
+	if frm := name.Closest(func(node *Node) bool { return node.Tag == "form" }); frm != doc.ByID("frm") {
+		t.Errorf("Node.Closest() = %v", frm)
+	}
+	if node := name.Closest(func(node *Node) bool { return node.Tag == "body" }); node != nil {
+		t.Errorf("Node.Closest() = %v", node)
+	}
+
+	name.SetAttr("class", "a")
+	name.SetAttr("class", "b")
+	if value, found := name.LookupAttr("class"); !found || value != "b" {
+		t.Errorf("Node.SetAttr() = %v", value)
+	}
+	name.RemoveAttr("class")
+	if name.HasAttr("class") || name.GetAttr("class") != "" {
+		t.Errorf("Node.RemoveAttr() = %v", name.Attr)
+	}
+
+	values := map[string][]string{
+		"name": {"Joe", "Jane"}, "memo": {"a", "b"}, "sel": {"b", "A"}, "first": {"C", "C"}, "empty": {"", ""},
+	}
+	for id, value := range values {
+		elt := doc.ByID(id)
+		if elt.Value() != value[0] {
+			t.Errorf("Node.Value(%s) = %v, want %v", id, elt.Value(), value[0])
+		}
+		elt.SetValue(value[1])
+		if elt.Value() != value[1] {
+			t.Errorf("Node.SetValue(%s) = %v, want %v", id, elt.Value(), value[1])
+		}
+	}
+
+	frm := doc.ByID("frm")
+	frm.RemoveChild(name)
+	frm.RemoveChild(name)
+	frm.InsertChild(0, name)
+	doc.AppendChild(name)
+	if name.Parent != doc || frm.ByID("name") != nil {
+		t.Errorf("Node.AppendChild() = %v", name.Parent)
+	}
+	if text := ParseHTML("<p> a\n <b>b </b></p>").TextContent(); text != "a b" {
+		t.Errorf("Node.TextContent() = %v", text)
+	}
+}
@@ -0,0 +1,210 @@
+/* Golden-file snapshot testing of the component html output
+ */
+package snapshot
+
+import (
+	"flag"
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/nervatura/component/test/htmxtest"
+)
+
+// The go test -update flag rewrites the golden files with the current html output
+var update = flag.Bool("update", false, "update the golden files of the snapshot tests")
+
+// The directory of the golden files
+var Dir string = filepath.Join("testdata", "snapshot")
+
+// The number of the unchanged lines around the changed lines of the diff
+var DiffContext int = 3
+
+// The random component ids of the ut.GetComponentID function
+var componentID = regexp.MustCompile(`ID[A-Za-z0-9]{16,}`)
+
+/*
+Normalize replaces the random component ids (see ut.GetComponentID) with stable ids (ID_1, ID_2...) in the
+order of their first occurrence. The optional replace values are old, new string pairs, for example the
+current date of the default input values.
+*/
+func Normalize(src string, replace ...string) string {
+	if len(replace) > 1 {
+		src = strings.NewReplacer(replace...).Replace(src)
+	}
+	ids := map[string]string{}
+	return componentID.ReplaceAllStringFunc(src, func(id string) string {
+		if len(id) != 18 {
+			return id
+		}
+		if _, found := ids[id]; !found {
+			ids[id] = fmt.Sprintf("ID_%d", len(ids)+1)
+		}
+		return ids[id]
+	})
+}
+
+/*
+Format returns the pretty-printed html code: every element, text and comment node is in a separate line,
+indented by the depth of the node. The whitespace of the text nodes is normalized, and the attributes are
+kept in their original order.
+*/
+func Format(src string) string {
+	var sb strings.Builder
+	for _, node := range htmxtest.ParseHTML(src).Children {
+		formatNode(&sb, node, 0)
+	}
+	return sb.String()
+}
+
+func startTag(node *htmxtest.Node) string {
+	var sb strings.Builder
+	sb.WriteString("<" + node.Tag)
+	for _, attr := range node.Attr {
+		sb.WriteString(" " + attr.Key + `="` + html.EscapeString(attr.Val) + `"`)
+	}
+	sb.WriteString(">")
+	return sb.String()
+}
+
+func formatNode(sb *strings.Builder, node *htmxtest.Node, depth int) {
+	indent := strings.Repeat("  ", depth)
+	switch node.Type {
+	case htmxtest.TextNode:
+		if tag := node.Parent.Tag; tag == "script" || tag == "style" || tag == "textarea" {
+			for _, line := range strings.Split(node.Text, "\n") {
+				if line = strings.TrimSpace(line); line != "" {
+					sb.WriteString(indent + line + "\n")
+				}
+			}
+			return
+		}
+		if text := strings.Join(strings.Fields(node.Text), " "); text != "" {
+			sb.WriteString(indent + html.EscapeString(text) + "\n")
+		}
+
+	case htmxtest.CommentNode:
+		sb.WriteString(indent + "<!--" + strings.TrimSpace(node.Text) + "-->\n")
+
+	default:
+		tag := startTag(node)
+		text := node.InnerHTML()
+		switch {
+		case node.OuterHTML() == tag:
+			// void element
+			sb.WriteString(indent + tag + "\n")
+		case len(node.Children) == 0 || (len(node.Children) == 1 && node.Children[0].Type == htmxtest.TextNode &&
+			!strings.Contains(text, "\n")):
+			sb.WriteString(indent + tag + strings.Join(strings.Fields(text), " ") + "</" + node.Tag + ">\n")
+		default:
+			sb.WriteString(indent + tag + "\n")
+			for _, child := range node.Children {
+				formatNode(sb, child, depth+1)
+			}
+			sb.WriteString(indent + "</" + node.Tag + ">\n")
+		}
+	}
+}
+
+/*
+Diff returns the line differences of the want and got values. The removed lines are prefixed with -, the
+added lines with + and the unchanged lines of the context with a space character. It returns an empty
+string if the values are equal.
+*/
+func Diff(want, got string) string {
+	if want == got {
+		return ""
+	}
+	a, b := strings.Split(want, "\n"), strings.Split(got, "\n")
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	type diffLine struct {
+		op   byte
+		line int
+		text string
+	}
+	lines := []diffLine{}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			lines = append(lines, diffLine{op: ' ', line: i + 1, text: a[i]})
+			i, j = i+1, j+1
+		case j < len(b) && (i == len(a) || lcs[i][j+1] >= lcs[i+1][j]):
+			lines = append(lines, diffLine{op: '+', line: i + 1, text: b[j]})
+			j++
+		default:
+			lines = append(lines, diffLine{op: '-', line: i + 1, text: a[i]})
+			i++
+		}
+	}
+	var sb strings.Builder
+	last := -1
+	for index, dl := range lines {
+		changed := false
+		for k := max(index-DiffContext, 0); k <= min(index+DiffContext, len(lines)-1); k++ {
+			changed = changed || lines[k].op != ' '
+		}
+		if !changed {
+			continue
+		}
+		if last < 0 || last != index-1 {
+			sb.WriteString(fmt.Sprintf("@@ line %d @@\n", dl.line))
+		}
+		sb.WriteString(string(dl.op) + " " + dl.text + "\n")
+		last = index
+	}
+	return sb.String()
+}
+
+/*
+Match compares the normalized (see [Normalize]) and pretty-printed (see [Format]) html code with the golden
+file of the name in the [Dir] directory, and reports the differences as a test error. The go test -update
+flag creates or rewrites the golden file.
+
+For example:
+
+	func TestButton(t *testing.T) {
+		html, _ := (&ct.Button{Label: "OK"}).Render()
+		snapshot.Match(t, "button/default", string(html))
+	}
+*/
+func Match(t testing.TB, name, src string, replace ...string) {
+	t.Helper()
+	got := Format(Normalize(src, replace...))
+	path := filepath.Join(Dir, filepath.FromSlash(name)+".html")
+	if *update {
+		err := os.MkdirAll(filepath.Dir(path), 0o755)
+		if err == nil {
+			err = os.WriteFile(path, []byte(got), 0o644)
+		}
+		if err != nil {
+			t.Errorf("snapshot %s: %v", name, err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Errorf("snapshot %s: %v (run go test -update to create the golden file)", name, err)
+		return
+	}
+	if diff := Diff(string(want), got); diff != "" {
+		t.Errorf("snapshot %s mismatch (-golden +got):\n%s", name, diff)
+	}
+}
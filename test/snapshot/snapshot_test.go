@@ -0,0 +1,122 @@
+package snapshot
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Records the test errors of the Match function
+type testRecorder struct {
+	testing.TB
+	errors []string
+}
+
+func (tr *testRecorder) Helper() {}
+
+func (tr *testRecorder) Errorf(format string, args ...any) {
+	tr.errors = append(tr.errors, fmt.Sprintf(format, args...))
+}
+
+func TestNormalize(t *testing.T) {
+	src := `<div id="IDAbcdefghij012345" name="IDAbcdefghij012345_name"><b id="IDZbcdefghij012345"></b>` +
+		`<i id="IDAbcdefghij0123456789" title="2024-01-02"></i></div>`
+	want := `<div id="ID_1" name="ID_1_name"><b id="ID_2"></b><i id="IDAbcdefghij0123456789" title="date"></i></div>`
+	if got := Normalize(src, "2024-01-02", "date"); got != want {
+		t.Errorf("Normalize() = %v, want %v", got, want)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	src := "<!-- comment --><div class=\"row\"><span>Label  text</span><br><b></b>\n<p><i>a</i> b</p>" +
+		"<script>\n  var a = 1;\n\n  var b = 2;\n</script><textarea></textarea></div>"
+	want := `<!--comment-->
+<div class="row">
+  <span>Label text</span>
+  <br>
+  <b></b>
+  <p>
+    <i>a</i>
+    b
+  </p>
+  <script>
+    var a = 1;
+    var b = 2;
+  </script>
+  <textarea></textarea>
+</div>
+`
+	if got := Format(src); got != want {
+		t.Errorf("Format() = %v, want %v", got, want)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	DiffContext = 1
+	t.Cleanup(func() { DiffContext = 3 })
+	tests := []struct {
+		name string
+		want string
+		got  string
+		diff string
+	}{
+		{name: "equal", want: "a\nb", got: "a\nb", diff: ""},
+		{
+			name: "changed",
+			want: "1\n2\n3\n4\n5\n6\n7",
+			got:  "1\n2x\n3\n4\n5\n6\n7\n8",
+			diff: "@@ line 1 @@\n  1\n+ 2x\n- 2\n  3\n@@ line 7 @@\n  7\n+ 8\n",
+		},
+		{
+			name: "removed",
+			want: "1\n2\n3",
+			got:  "1\n3",
+			diff: "@@ line 1 @@\n  1\n- 2\n  3\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Diff(tt.want, tt.got); got != tt.diff {
+				t.Errorf("Diff() = %q, want %q", got, tt.diff)
+			}
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	dir := Dir
+	Dir = t.TempDir()
+	t.Cleanup(func() {
+		Dir = dir
+		*update = false
+	})
+
+	tr := &testRecorder{}
+	Match(tr, "button/default", `<button id="IDAbcdefghij012345">OK</button>`)
+	if len(tr.errors) != 1 || !strings.Contains(tr.errors[0], "-update") {
+		t.Errorf("Match() missing file errors = %v", tr.errors)
+	}
+
+	*update = true
+	tr = &testRecorder{}
+	Match(tr, "button/default", `<button id="IDAbcdefghij012345">OK</button>`)
+	if data, err := os.ReadFile(filepath.Join(Dir, "button", "default.html")); err != nil ||
+		string(data) != "<button id=\"ID_1\">OK</button>\n" || len(tr.errors) > 0 {
+		t.Errorf("Match() update = %s, %v, %v", data, err, tr.errors)
+	}
+	// the golden file path is an existing file
+	Match(tr, "button/default.html/invalid", `<b></b>`)
+	if len(tr.errors) != 1 {
+		t.Errorf("Match() update errors = %v", tr.errors)
+	}
+
+	*update = false
+	tr = &testRecorder{}
+	Match(tr, "button/default", `<button id="IDZbcdefghij012345">OK</button>`)
+	Match(tr, "button/default", `<button id="IDZbcdefghij012345">Cancel</button>`)
+	if len(tr.errors) != 1 || !strings.Contains(tr.errors[0], "- <button id=\"ID_1\">OK</button>") {
+		t.Errorf("Match() errors = %v", tr.errors)
+	}
+}
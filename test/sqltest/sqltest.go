@@ -102,6 +102,14 @@ func (stmt *testStmt) QueryContext(ctx context.Context, args []driver.NamedValue
 		values: [][]driver.Value{{`{}`}},
 		cols:   []string{"value"},
 	}
+	if stmt.dns == "session" {
+		// a stored component session value
+		rows.values = [][]driver.Value{{`{"component_type":"demo","component":{}}`}}
+	}
+	if stmt.dns == "scan_error" {
+		// the value is not convertible to string
+		rows.values = [][]driver.Value{{struct{}{}}}
+	}
 	return rows, nil
 }
 
@@ -135,6 +143,9 @@ func (r *testRows) Next(dest []driver.Value) error {
 	if r.pos > len(r.values) || r.dns == "not_found" {
 		return io.EOF
 	}
+	if r.dns == "next_error" {
+		return errors.New(r.dns)
+	}
 
 	copy(dest[:], r.values[r.pos-1])
 